@@ -15,6 +15,7 @@
 package auth
 
 import (
+	"context"
 	"strings"
 
 	"github.com/dolthub/vitess/go/mysql"
@@ -31,19 +32,23 @@ const (
 	ReadPerm Permission = 1 << iota
 	// WritePerm means that it writes.
 	WritePerm
+	// ExecutePerm means that it calls stored procedures. It isn't part of the read and write permissions, so users
+	// need it granted to run CALL.
+	ExecutePerm
 )
 
 var (
 	// AllPermissions hold all defined permissions.
-	AllPermissions = ReadPerm | WritePerm
+	AllPermissions = ReadPerm | WritePerm | ExecutePerm
 	// DefaultPermissions are the permissions granted to a user if not defined.
 	DefaultPermissions = ReadPerm
 
 	// PermissionNames is used to translate from human to machine
 	// representations.
 	PermissionNames = map[string]Permission{
-		"read":  ReadPerm,
-		"write": WritePerm,
+		"read":    ReadPerm,
+		"write":   WritePerm,
+		"execute": ExecutePerm,
 	}
 
 	// ErrNotAuthorized is returned when the user is not allowed to use a
@@ -65,6 +70,30 @@ func (p Permission) String() string {
 	return strings.Join(str, ", ")
 }
 
+// Privileges returns the MySQL privileges of the statements the permissions allow, as they're listed in a GRANT
+// statement.
+func (p Permission) Privileges() string {
+	if p&AllPermissions == AllPermissions {
+		return "ALL PRIVILEGES"
+	}
+
+	var privileges []string
+	if p&ReadPerm != 0 {
+		privileges = append(privileges, "SELECT")
+	}
+	if p&WritePerm != 0 {
+		privileges = append(privileges, "INSERT", "UPDATE", "DELETE", "CREATE", "DROP", "INDEX", "ALTER", "LOCK TABLES")
+	}
+	if p&ExecutePerm != 0 {
+		privileges = append(privileges, "EXECUTE")
+	}
+	if len(privileges) == 0 {
+		return "USAGE"
+	}
+
+	return strings.Join(privileges, ", ")
+}
+
 // Auth interface provides mysql authentication methods and permission checking
 // for users.
 type Auth interface {
@@ -75,3 +104,55 @@ type Auth interface {
 	// Otherwise is an error using the authentication method.
 	Allowed(ctx *sql.Context, permission Permission) error
 }
+
+// Users is implemented by Auth methods that keep the permissions of every user, which lets the permissions of a user
+// other than the one running a query be checked, such as those of the definer of a stored procedure.
+type Users interface {
+	// UserPermissions returns the permissions granted to the given user, and false if there is no such user.
+	UserPermissions(user string) (Permission, bool)
+}
+
+// UsersOf returns the Users of the given Auth method, and false if it doesn't keep the permissions of every user.
+func UsersOf(a Auth) (Users, bool) {
+	if audit, ok := a.(*Audit); ok {
+		a = audit.auth
+	}
+	users, ok := a.(Users)
+	return users, ok
+}
+
+type authKey struct{}
+
+// NewContext returns a copy of the given context that carries the Auth method given, for the parts of the engine that
+// need to know the users of the engine running a query, such as SHOW GRANTS.
+func NewContext(ctx *sql.Context, a Auth) *sql.Context {
+	return ctx.WithContext(context.WithValue(ctx.Context, authKey{}, a))
+}
+
+// FromContext returns the Auth method carried by the given context, and false if it carries none.
+func FromContext(ctx *sql.Context) (Auth, bool) {
+	a, ok := ctx.Value(authKey{}).(Auth)
+	return a, ok
+}
+
+// AllowedUser checks the permissions of the given user instead of those of the user running the query. Auth methods
+// that don't implement Users only know the permissions of the user running the query, so those are checked instead.
+func AllowedUser(ctx *sql.Context, a Auth, user string, permission Permission) error {
+	if audit, ok := a.(*Audit); ok {
+		err := AllowedUser(ctx, audit.auth, user, permission)
+		audit.method.Authorization(ctx, permission, err)
+		return err
+	}
+
+	users, ok := UsersOf(a)
+	if !ok {
+		return a.Allowed(ctx, permission)
+	}
+
+	perm, ok := users.UserPermissions(user)
+	if !ok {
+		return ErrNotAuthorized.Wrap(ErrNoPermission.New(permission))
+	}
+
+	return nativeUser{Name: user, Permissions: perm}.Allowed(permission)
+}
@@ -157,6 +157,12 @@ func (s *Native) Mysql() mysql.AuthServer {
 	return auth
 }
 
+// UserPermissions implements Users interface.
+func (s *Native) UserPermissions(user string) (Permission, bool) {
+	u, ok := s.users[user]
+	return u.Permissions, ok
+}
+
 // Allowed implements Auth interface.
 func (s *Native) Allowed(ctx *sql.Context, permission Permission) error {
 	name := ctx.Client().User
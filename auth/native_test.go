@@ -22,6 +22,7 @@ import (
 	"github.com/dolthub/go-mysql-server/auth"
 
 	_ "github.com/go-sql-driver/mysql"
+	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/require"
 	"gopkg.in/src-d/go-errors.v1"
 )
@@ -237,6 +238,38 @@ func TestNativeAuthorization(t *testing.T) {
 	testAuthorization(t, a, tests, nil)
 }
 
+func TestNativeUserPermissions(t *testing.T) {
+	require := require.New(t)
+
+	conf, err := writeConfig(baseConfig)
+	require.NoError(err)
+	defer os.Remove(conf)
+
+	a, err := auth.NewNativeFile(conf)
+	require.NoError(err)
+
+	users, ok := auth.UsersOf(auth.NewAudit(a, auth.NewAuditLog(logrus.New())))
+	require.True(ok)
+
+	perm, ok := users.UserPermissions("root")
+	require.True(ok)
+	require.Equal(auth.ReadPerm|auth.WritePerm, perm)
+	require.Equal("SELECT, INSERT, UPDATE, DELETE, CREATE, DROP, INDEX, ALTER, LOCK TABLES", perm.Privileges())
+
+	perm, ok = users.UserPermissions("user")
+	require.True(ok)
+	require.Equal("SELECT", perm.Privileges())
+
+	_, ok = users.UserPermissions("nobody")
+	require.False(ok)
+
+	require.Equal("ALL PRIVILEGES", auth.AllPermissions.Privileges())
+	require.Equal("USAGE", auth.Permission(0).Privileges())
+
+	_, ok = auth.UsersOf(new(auth.None))
+	require.False(ok)
+}
+
 func TestNativeErrors(t *testing.T) {
 	tests := []struct {
 		name   string
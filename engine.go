@@ -17,6 +17,7 @@ package sqle
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/dolthub/go-mysql-server/memory"
 
@@ -34,6 +35,9 @@ type Config struct {
 	VersionPostfix string
 	// Auth used for authentication and authorization.
 	Auth auth.Auth
+	// LowerCaseTableNames is how the names of databases, tables and columns are matched. The default matches them
+	// case-insensitively.
+	LowerCaseTableNames sql.LowerCaseTableNames
 }
 
 // Engine is a SQL engine.
@@ -41,6 +45,7 @@ type Engine struct {
 	Analyzer      *analyzer.Analyzer
 	Auth          auth.Auth
 	LS            *sql.LockSubsystem
+	Sequences     *sql.Sequences
 	ProcessList   sql.ProcessList
 	MemoryManager *sql.MemoryManager
 }
@@ -60,12 +65,20 @@ func New(a *analyzer.Analyzer, cfg *Config) *Engine {
 
 	ls := sql.NewLockSubsystem()
 
+	if cfg != nil {
+		if c, ok := a.Catalog.(*analyzer.Catalog); ok {
+			c.SetLowerCaseTableNames(cfg.LowerCaseTableNames)
+		}
+	}
+
 	a.Catalog.RegisterFunction(
 		sql.FunctionN{
 			Name: "version",
 			Fn:   function.NewVersion(versionPostfix),
 		})
 	a.Catalog.RegisterFunction(function.GetLockingFuncs(ls)...)
+	sequences := sql.NewSequences()
+	a.Catalog.RegisterFunction(function.GetSequenceFuncs(sequences)...)
 
 	// use auth.None if auth is not specified
 	var au auth.Auth
@@ -81,6 +94,7 @@ func New(a *analyzer.Analyzer, cfg *Config) *Engine {
 		ProcessList:   NewProcessList(),
 		Auth:          au,
 		LS:            ls,
+		Sequences:     sequences,
 	}
 }
 
@@ -100,7 +114,7 @@ func (e *Engine) AnalyzeQuery(
 		return nil, err
 	}
 
-	analyzed, err := e.Analyzer.Analyze(ctx, parsed, nil)
+	analyzed, err := e.Analyzer.Analyze(auth.NewContext(ctx, e.Auth), parsed, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -160,7 +174,12 @@ func (e *Engine) QueryNodeWithBindings(
 		}
 	}
 
-	analyzed, err = e.Analyzer.Analyze(ctx, parsed, nil)
+	analyzed, err = e.Analyzer.Analyze(auth.NewContext(ctx, e.Auth), parsed, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = e.authCheckProcedures(ctx, analyzed, ctx.Client().User)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -215,7 +234,7 @@ func (e *Engine) beginTransaction(ctx *sql.Context, parsed sql.Node) (string, er
 
 			tdb, ok := database.(sql.TransactionDatabase)
 			if ok {
-				tx, err := tdb.StartTransaction(ctx, sql.ReadWrite)
+				tx, err := sql.StartTransaction(ctx, tdb, sql.ReadWrite)
 				if err != nil {
 					return "", err
 				}
@@ -235,17 +254,12 @@ func readCommitted(ctx *sql.Context) bool {
 		return false
 	}
 
-	val, err := ctx.GetSessionVariable(ctx, "transaction_isolation")
+	level, err := ctx.GetTransactionIsolationLevel()
 	if err != nil {
 		return false
 	}
 
-	valStr, ok := val.(string)
-	if !ok {
-		return false
-	}
-
-	return valStr == "READ-COMMITTED"
+	return level == sql.IsolationLevelReadCommitted
 }
 
 // transactionCommittingIter is a simple RowIter wrapper to allow the engine to conditionally commit a transaction
@@ -327,6 +341,11 @@ func getTransactionDatabase(ctx *sql.Context, parsed sql.Node) string {
 }
 
 func (e *Engine) authCheck(ctx *sql.Context, node sql.Node) error {
+	return e.Auth.Allowed(ctx, requiredPermission(node))
+}
+
+// requiredPermission returns the permission needed to run the given statement.
+func requiredPermission(node sql.Node) auth.Permission {
 	var perm = auth.ReadPerm
 	if plan.IsDDLNode(node) {
 		perm = auth.ReadPerm | auth.WritePerm
@@ -335,9 +354,59 @@ func (e *Engine) authCheck(ctx *sql.Context, node sql.Node) error {
 	case
 		*plan.DeleteFrom, *plan.InsertInto, *plan.Update, *plan.LockTables, *plan.UnlockTables:
 		perm = auth.ReadPerm | auth.WritePerm
+	case *plan.Call:
+		perm = auth.ExecutePerm
 	}
 
-	return e.Auth.Allowed(ctx, perm)
+	return perm
+}
+
+// authCheckProcedures checks that the statements of the stored procedures called in the given node are allowed for the
+// user each procedure runs as. That is its definer, unless the procedure was created with SQL SECURITY INVOKER or
+// without a definer, in which case it runs as the user calling it.
+func (e *Engine) authCheckProcedures(ctx *sql.Context, node sql.Node, user string) error {
+	var err error
+	plan.Inspect(node, func(n sql.Node) bool {
+		if err != nil {
+			return false
+		}
+		call, ok := n.(*plan.Call)
+		if !ok {
+			return true
+		}
+		proc := call.Procedure()
+		if proc == nil {
+			return false
+		}
+
+		procUser := user
+		if proc.SecurityContext == plan.ProcedureSecurityContext_Definer && proc.Definer != "" {
+			procUser = definerUser(proc.Definer)
+		}
+
+		perm := auth.ReadPerm
+		plan.Inspect(proc, func(n sql.Node) bool {
+			perm |= requiredPermission(n)
+			return true
+		})
+
+		err = auth.AllowedUser(ctx, e.Auth, procUser, perm)
+		if err == nil {
+			err = e.authCheckProcedures(ctx, proc, procUser)
+		}
+		return false
+	})
+
+	return err
+}
+
+// definerUser returns the user name of the definer of a stored procedure, which is either a user name or an account
+// name, as in user@host. Users are known by name alone, so the host is left out.
+func definerUser(definer string) string {
+	if at := strings.IndexByte(definer, '@'); at >= 0 {
+		return definer[:at]
+	}
+	return definer
 }
 
 // ApplyDefaults applies the default values of the given column indices to the given row, and returns a new row with the updated values.
@@ -137,6 +137,54 @@ var DeleteTests = []WriteQueryTest{
 		SelectQuery:         "SELECT * FROM mytable;",
 		ExpectedSelect:      []sql.Row{{int64(1), "first row"}, {int64(2), "second row"}, {int64(3), "third row"}},
 	},
+	{
+		WriteQuery:          "DELETE mytable, othertable FROM mytable JOIN othertable ON mytable.i = othertable.i2 WHERE othertable.s2 = 'first';",
+		ExpectedWriteResult: []sql.Row{{sql.NewOkResult(2)}},
+		SelectQuery:         "SELECT * FROM mytable JOIN othertable ON mytable.i = othertable.i2 ORDER BY i;",
+		ExpectedSelect:      []sql.Row{{int64(1), "first row", "third", int64(1)}, {int64(2), "second row", "second", int64(2)}},
+	},
+	{
+		WriteQuery:          "DELETE m FROM mytable m JOIN othertable o ON m.i = o.i2 WHERE o.s2 <> 'first';",
+		ExpectedWriteResult: []sql.Row{{sql.NewOkResult(2)}},
+		SelectQuery:         "SELECT * FROM mytable;",
+		ExpectedSelect:      []sql.Row{{int64(3), "third row"}},
+	},
+	{
+		WriteQuery:          "DELETE m FROM mytable m JOIN othertable o ON m.i = o.i2 WHERE o.s2 <> 'first';",
+		ExpectedWriteResult: []sql.Row{{sql.NewOkResult(2)}},
+		SelectQuery:         "SELECT * FROM othertable ORDER BY i2;",
+		ExpectedSelect:      []sql.Row{{"third", int64(1)}, {"second", int64(2)}, {"first", int64(3)}},
+	},
+	{
+		WriteQuery:          "DELETE mytable FROM mytable, othertable WHERE mytable.i = 1;",
+		ExpectedWriteResult: []sql.Row{{sql.NewOkResult(1)}},
+		SelectQuery:         "SELECT * FROM mytable;",
+		ExpectedSelect:      []sql.Row{{int64(2), "second row"}, {int64(3), "third row"}},
+	},
+	{
+		WriteQuery:          "DELETE o FROM othertable o LEFT JOIN mytable m ON o.i2 = m.i + 1 WHERE m.i IS NULL;",
+		ExpectedWriteResult: []sql.Row{{sql.NewOkResult(1)}},
+		SelectQuery:         "SELECT * FROM othertable ORDER BY i2;",
+		ExpectedSelect:      []sql.Row{{"second", int64(2)}, {"first", int64(3)}},
+	},
+	{
+		WriteQuery:          "DELETE FROM mytable, othertable USING mytable JOIN othertable ON mytable.i = othertable.i2 WHERE othertable.s2 = 'first';",
+		ExpectedWriteResult: []sql.Row{{sql.NewOkResult(2)}},
+		SelectQuery:         "SELECT * FROM mytable JOIN othertable ON mytable.i = othertable.i2 ORDER BY i;",
+		ExpectedSelect:      []sql.Row{{int64(1), "first row", "third", int64(1)}, {int64(2), "second row", "second", int64(2)}},
+	},
+	{
+		WriteQuery:          "DELETE FROM o USING othertable o JOIN mytable m ON o.i2 = m.i WHERE m.s = 'first row';",
+		ExpectedWriteResult: []sql.Row{{sql.NewOkResult(1)}},
+		SelectQuery:         "SELECT * FROM othertable ORDER BY i2;",
+		ExpectedSelect:      []sql.Row{{"second", int64(2)}, {"first", int64(3)}},
+	},
+	{
+		WriteQuery:          "DELETE mytable, othertable FROM mytable JOIN othertable ON mytable.i >= othertable.i2 WHERE mytable.i > 1;",
+		ExpectedWriteResult: []sql.Row{{sql.NewOkResult(5)}},
+		SelectQuery:         "SELECT * FROM mytable;",
+		ExpectedSelect:      []sql.Row{{int64(1), "first row"}},
+	},
 }
 
 var DeleteErrorTests = []GenericErrorQueryTest{
@@ -172,4 +220,12 @@ var DeleteErrorTests = []GenericErrorQueryTest{
 		Name:  "targets subquery alias",
 		Query: "DELETE FROM (SELECT * FROM mytable) mytable WHERE id = 1;",
 	},
+	{
+		Name:  "unknown multiple-table delete target",
+		Query: "DELETE othertable FROM mytable WHERE i = 1;",
+	},
+	{
+		Name:  "multiple-table delete target referenced by table name instead of alias",
+		Query: "DELETE mytable FROM mytable m JOIN othertable o ON m.i = o.i2;",
+	},
 }
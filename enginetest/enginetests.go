@@ -17,6 +17,8 @@ package enginetest
 import (
 	"context"
 	"fmt"
+	"io/ioutil"
+	"os"
 	"strings"
 	"sync/atomic"
 	"testing"
@@ -33,6 +35,7 @@ import (
 	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/dolthub/go-mysql-server/sql/analyzer"
 	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/expression/function/aggregation"
 	"github.com/dolthub/go-mysql-server/sql/information_schema"
 	"github.com/dolthub/go-mysql-server/sql/parse"
 	"github.com/dolthub/go-mysql-server/sql/plan"
@@ -149,6 +152,21 @@ func TestReadOnlyDatabases(t *testing.T, harness Harness) {
 			})
 		}
 	}
+
+	// Explaining a write doesn't perform it, so it's allowed in a read-only database
+	for _, query := range []string{
+		"EXPLAIN INSERT INTO mytable (i, s) VALUES (10, 'ten')",
+		"EXPLAIN UPDATE mytable SET s = 'updated' WHERE i = 1",
+		"EXPLAIN DELETE FROM mytable WHERE i = 1",
+	} {
+		t.Run(query, func(t *testing.T) {
+			ctx := NewContext(harness)
+			_, iter, err := engine.Query(ctx, query)
+			require.NoError(t, err)
+			_, err = sql.RowIterToRows(ctx, iter)
+			require.NoError(t, err)
+		})
+	}
 }
 
 func createReadOnlyDatabases(h ReadOnlyDatabaseHarness) (dbs []sql.Database) {
@@ -311,6 +329,118 @@ func TestReadOnly(t *testing.T, harness Harness) {
 	}
 }
 
+// TestStoredProcedureSecurity checks that CALL requires the EXECUTE permission, and that the statements of a procedure
+// run with the permissions of its definer, or of the user calling it for SQL SECURITY INVOKER procedures.
+func TestStoredProcedureSecurity(t *testing.T, harness Harness) {
+	require := require.New(t)
+
+	db := harness.NewDatabase("mydb")
+
+	wrapInTransaction(t, db, harness, func() {
+		_, err := harness.NewTable(db, "mytable", sql.NewPrimaryKeySchema(sql.Schema{
+			{Name: "i", Type: sql.Int64, Source: "mytable", PrimaryKey: true},
+			{Name: "s", Type: sql.Text, Source: "mytable"},
+		}))
+		require.NoError(err)
+	})
+
+	pro := harness.NewDatabaseProvider(db)
+
+	e := sqle.New(analyzer.NewBuilder(pro).Build(), new(sqle.Config))
+	for _, query := range []string{
+		`CREATE DEFINER = root PROCEDURE p_definer() INSERT INTO mytable VALUES (1, 'definer')`,
+		`CREATE DEFINER = root PROCEDURE p_invoker() SQL SECURITY INVOKER INSERT INTO mytable VALUES (2, 'invoker')`,
+		`CREATE DEFINER = root PROCEDURE p_called() INSERT INTO mytable VALUES (3, 'called')`,
+		`CREATE DEFINER = root PROCEDURE p_nested() SQL SECURITY INVOKER CALL p_called()`,
+		`CREATE DEFINER = root@localhost PROCEDURE p_account() INSERT INTO mytable VALUES (4, 'account')`,
+	} {
+		RunQuery(t, e, harness, query)
+	}
+
+	newEngine := func(userPermissions string) *sqle.Engine {
+		file, err := ioutil.TempFile("", "native-config")
+		require.NoError(err)
+		defer os.Remove(file.Name())
+		_, err = file.WriteString(`[
+	{"name": "root", "permissions": ["read", "write", "execute"]},
+	{"name": "user", "permissions": ` + userPermissions + `}
+]`)
+		require.NoError(err)
+		require.NoError(file.Close())
+
+		au, err := auth.NewNativeFile(file.Name())
+		require.NoError(err)
+		return sqle.New(analyzer.NewBuilder(pro).Build(), &sqle.Config{Auth: au})
+	}
+
+	e = newEngine(`["read", "execute"]`)
+	TestQueryWithContext(t, NewContext(harness), e, `CALL p_definer()`, []sql.Row{{sql.NewOkResult(1)}}, nil, nil)
+	TestQueryWithContext(t, NewContext(harness), e, `CALL p_nested()`, []sql.Row{{sql.NewOkResult(1)}}, nil, nil)
+	AssertErr(t, e, harness, `CALL p_invoker()`, auth.ErrNotAuthorized)
+	TestQueryWithContext(t, NewContext(harness), e, `CALL p_account()`, []sql.Row{{sql.NewOkResult(1)}}, nil, nil)
+	TestQueryWithContext(t, NewContext(harness), e, `SELECT * FROM mytable`, []sql.Row{{int64(1), "definer"}, {int64(3), "called"}, {int64(4), "account"}}, nil, nil)
+
+	e = newEngine(`["read", "write"]`)
+	AssertErr(t, e, harness, `CALL p_definer()`, auth.ErrNotAuthorized)
+}
+
+// TestShowGrantsWithUsers checks that SHOW GRANTS shows the privileges of the users of the Auth method, for any host of
+// their accounts, and that it fails for accounts that don't exist.
+func TestShowGrantsWithUsers(t *testing.T, harness Harness) {
+	require := require.New(t)
+
+	file, err := ioutil.TempFile("", "native-config")
+	require.NoError(err)
+	defer os.Remove(file.Name())
+	_, err = file.WriteString(`[
+	{"name": "root", "permissions": ["read", "write", "execute"]},
+	{"name": "user", "permissions": ["read", "execute"]},
+	{"name": "writer", "permissions": ["read", "write"]}
+]`)
+	require.NoError(err)
+	require.NoError(file.Close())
+
+	au, err := auth.NewNativeFile(file.Name())
+	require.NoError(err)
+
+	pro := harness.NewDatabaseProvider(harness.NewDatabase("mydb"))
+	e := sqle.New(analyzer.NewBuilder(pro).Build(), &sqle.Config{Auth: au})
+
+	for _, tt := range []QueryTest{
+		{
+			Query:    `SHOW GRANTS`,
+			Expected: []sql.Row{{"GRANT SELECT, EXECUTE ON *.* TO 'user'@'%'"}},
+			ExpectedColumns: sql.Schema{
+				{Name: "Grants for user@%", Type: sql.LongText},
+			},
+		},
+		{
+			Query:    `SHOW GRANTS FOR CURRENT_USER`,
+			Expected: []sql.Row{{"GRANT SELECT, EXECUTE ON *.* TO 'user'@'%'"}},
+		},
+		{
+			Query:    `SHOW GRANTS FOR root`,
+			Expected: []sql.Row{{"GRANT ALL PRIVILEGES ON *.* TO 'root'@'%'"}},
+		},
+		{
+			Query:    `SHOW GRANTS FOR 'root'@'localhost'`,
+			Expected: []sql.Row{{"GRANT ALL PRIVILEGES ON *.* TO 'root'@'localhost'"}},
+			ExpectedColumns: sql.Schema{
+				{Name: "Grants for root@localhost", Type: sql.LongText},
+			},
+		},
+		{
+			Query:    `SHOW GRANTS FOR 'writer'@'%'`,
+			Expected: []sql.Row{{"GRANT SELECT, INSERT, UPDATE, DELETE, CREATE, DROP, INDEX, ALTER, LOCK TABLES ON *.* TO 'writer'@'%'"}},
+		},
+	} {
+		TestQueryWithContext(t, NewContext(harness), e, tt.Query, tt.Expected, tt.ExpectedColumns, nil)
+	}
+
+	AssertErr(t, e, harness, `SHOW GRANTS FOR nobody`, sql.ErrNoSuchGrant)
+	AssertErr(t, e, harness, `SHOW GRANTS FOR 'nobody'@'localhost'`, sql.ErrNoSuchGrant)
+}
+
 func TestExplode(t *testing.T, harness Harness) {
 	db := harness.NewDatabase("mydb")
 	table, err := harness.NewTable(db, "t", sql.NewPrimaryKeySchema(sql.Schema{
@@ -944,6 +1074,12 @@ func TestStoredProcedures(t *testing.T, harness Harness) {
 	}
 }
 
+func TestStoredFunctions(t *testing.T, harness Harness) {
+	for _, script := range StoredFunctionTests {
+		TestScript(t, harness, script)
+	}
+}
+
 func TestTriggerErrors(t *testing.T, harness Harness) {
 	for _, script := range TriggerErrorTests {
 		TestScript(t, harness, script)
@@ -1173,7 +1309,7 @@ func TestCreateTable(t *testing.T, harness Harness) {
 	t.Run("Multiple primary keys", func(t *testing.T) {
 		TestQuery(t, harness, e, "CREATE TABLE t3(a INTEGER NOT NULL,"+
 			"b TEXT NOT NULL,"+
-			"c bool, primary key (a,b))", []sql.Row(nil), nil, nil)
+			"c bool, primary key (a,b(10)))", []sql.Row(nil), nil, nil)
 
 		db, err := e.Analyzer.Catalog.Database("mydb")
 		require.NoError(t, err)
@@ -1963,6 +2099,37 @@ func TestDropDatabase(t *testing.T, harness Harness) {
 
 		AssertWarningAndTestQuery(t, e, ctx, harness, "DROP DATABASE IF EXISTS testdb", []sql.Row{{sql.OkResult{RowsAffected: 0}}}, nil, mysql.ERDbDropExists)
 	})
+
+	t.Run("DROP DATABASE drops the tables and views of the database", func(t *testing.T) {
+		e := NewEngine(t, harness)
+		ctx := NewContext(harness)
+
+		RunQueryWithContext(t, e, ctx, "CREATE DATABASE testdb")
+		RunQueryWithContext(t, e, ctx, "USE testdb")
+		RunQueryWithContext(t, e, ctx, "CREATE TABLE parent (a int primary key)")
+		RunQueryWithContext(t, e, ctx, "CREATE TABLE child (a int primary key, foreign key (a) references parent (a))")
+		RunQueryWithContext(t, e, ctx, "CREATE VIEW v AS SELECT * FROM parent")
+		require.NoError(t, ctx.GetViewRegistry().Register("testdb", sql.NewView("registered", nil, "SELECT 1")))
+
+		db, err := e.Analyzer.Catalog.Database("testdb")
+		require.NoError(t, err)
+
+		TestQueryWithContext(t, ctx, e, "DROP DATABASE TESTDB", []sql.Row{{sql.OkResult{RowsAffected: 1}}}, nil, nil)
+		require.Equal(t, "", ctx.GetCurrentDatabase())
+
+		names, err := db.GetTableNames(ctx)
+		require.NoError(t, err)
+		require.Empty(t, names)
+		if viewDb, ok := db.(sql.ViewDatabase); ok {
+			views, err := viewDb.AllViews(ctx)
+			require.NoError(t, err)
+			require.Empty(t, views)
+		}
+		require.Empty(t, ctx.GetViewRegistry().ViewsInDatabase("testdb"))
+
+		TestQueryWithContext(t, ctx, e, "SELECT schema_name FROM information_schema.schemata WHERE schema_name = 'testdb'", nil, nil, nil)
+		AssertErrWithCtx(t, e, ctx, "SELECT * FROM testdb.parent", sql.ErrDatabaseNotFound)
+	})
 }
 
 func TestCreateForeignKeys(t *testing.T, harness Harness) {
@@ -2603,6 +2770,100 @@ func TestWindowAgg(t *testing.T, harness Harness) {
 		{4, 1},
 		{5, 0},
 	}, nil, nil)
+
+	// aggregates over the whole partition count every distinct value once per partition
+	TestQuery(t, harness, e, `SELECT a, count(distinct b) over (partition by c), sum(distinct b) over (partition by c), avg(distinct b) over (partition by c) FROM t1 order by a`, []sql.Row{
+		{0, 4, 6.0, 1.5},
+		{1, 1, 1.0, 1.0},
+		{2, 4, 6.0, 1.5},
+		{3, 4, 6.0, 1.5},
+		{4, 4, 6.0, 1.5},
+		{5, 4, 6.0, 1.5},
+	}, nil, nil)
+
+	TestQuery(t, harness, e, `SELECT a, count(distinct c) over (partition by b), count(c) over (partition by b), count(distinct c) over () FROM t1 order by a`, []sql.Row{
+		{0, 1, 2, 2},
+		{1, 2, 2, 2},
+		{2, 1, 1, 2},
+		{3, 1, 2, 2},
+		{4, 2, 2, 2},
+		{5, 1, 1, 2},
+	}, nil, nil)
+
+	AssertErr(t, e, harness, `SELECT a, count(distinct b) over (partition by c order by a) FROM t1`, sql.ErrWindowDistinctFrame)
+	AssertErr(t, e, harness, `SELECT a, sum(distinct b) over (order by a) FROM t1`, sql.ErrWindowDistinctFrame)
+	AssertErr(t, e, harness, `SELECT a, count(distinct b) over (order by a rows between unbounded preceding and current row) FROM t1`, sql.ErrWindowDistinctFrame)
+	AssertErr(t, e, harness, `SELECT a, avg(distinct b) over (partition by c range unbounded preceding) FROM t1`, sql.ErrWindowDistinctFrame)
+
+	// rank skips ranks after ties, dense_rank doesn't, and NULL order values are peers
+	RunQuery(t, e, harness, "CREATE TABLE t2 (a INTEGER PRIMARY KEY, b INTEGER, c integer)")
+	RunQuery(t, e, harness, "INSERT INTO t2 VALUES (0,0,0), (1,1,1), (2,2,0), (3,0,0), (4,1,0), (5,3,0), (6,null,0), (7,null,1), (8,1,1)")
+
+	TestQuery(t, harness, e, `SELECT a, rank() over (order by b), dense_rank() over (order by b) FROM t2 order by a`, []sql.Row{
+		{0, 3, 2},
+		{1, 5, 3},
+		{2, 8, 4},
+		{3, 3, 2},
+		{4, 5, 3},
+		{5, 9, 5},
+		{6, 1, 1},
+		{7, 1, 1},
+		{8, 5, 3},
+	}, nil, nil)
+
+	TestQuery(t, harness, e, `SELECT a, rank() over (partition by c order by b desc), dense_rank() over (partition by c order by b desc) FROM t2 order by a`, []sql.Row{
+		{0, 4, 4},
+		{1, 1, 1},
+		{2, 2, 2},
+		{3, 4, 4},
+		{4, 3, 3},
+		{5, 1, 1},
+		{6, 6, 5},
+		{7, 3, 2},
+		{8, 1, 1},
+	}, nil, nil)
+
+	TestQuery(t, harness, e, `SELECT a, rank() over (order by b, c), dense_rank() over (order by b, c) FROM t2 order by a`, []sql.Row{
+		{0, 3, 3},
+		{1, 6, 5},
+		{2, 8, 6},
+		{3, 3, 3},
+		{4, 5, 4},
+		{5, 9, 7},
+		{6, 1, 1},
+		{7, 2, 2},
+		{8, 6, 5},
+	}, nil, nil)
+
+	// no order by clause -> all rows are peers
+	TestQuery(t, harness, e, `SELECT a, rank() over (), dense_rank() over (partition by c) FROM t2 order by a`, []sql.Row{
+		{0, 1, 1},
+		{1, 1, 1},
+		{2, 1, 1},
+		{3, 1, 1},
+		{4, 1, 1},
+		{5, 1, 1},
+		{6, 1, 1},
+		{7, 1, 1},
+		{8, 1, 1},
+	}, nil, nil)
+
+	// lag and lead look at the rows before and after in the partition, and NULL values of the rows they look at aren't
+	// replaced by the default value
+	TestQuery(t, harness, e, `SELECT a, lag(b) over (partition by c order by a), lag(b, 2, -1) over (partition by c order by a), lead(b) over (partition by c order by a), lead(b, 10, a) over (partition by c order by a), lag(b, 0) over (order by a) FROM t2 order by a`, []sql.Row{
+		{0, nil, -1, 2, 0, 0},
+		{1, nil, -1, nil, 1, 1},
+		{2, 0, -1, 0, 2, 2},
+		{3, 2, 0, 1, 3, 0},
+		{4, 0, 2, 3, 4, 1},
+		{5, 1, 0, nil, 5, 3},
+		{6, 3, 1, nil, 6, nil},
+		{7, 1, -1, 1, 7, nil},
+		{8, nil, 1, nil, 8, 1},
+	}, nil, nil)
+
+	AssertErr(t, e, harness, `SELECT a, lag(b, -1) over (order by a) FROM t2`, sql.ErrInvalidArgument)
+	AssertErr(t, e, harness, `SELECT a, lead(b, a) over (order by a) FROM t2`, sql.ErrInvalidArgument)
 }
 func TestNaturalJoin(t *testing.T, harness Harness) {
 	require := require.New(t)
@@ -3356,6 +3617,295 @@ func (c customFunc) WithChildren(children ...sql.Expression) (sql.Expression, er
 	return &customFunc{expression.UnaryExpression{children[0]}}, nil
 }
 
+// customProduct is a custom aggregate function returning the product of the non-NULL values of its argument.
+type customProduct struct {
+	expression.UnaryExpression
+}
+
+var _ sql.Aggregation = (*customProduct)(nil)
+
+func (p *customProduct) String() string {
+	return "PRODUCT(" + p.Child.String() + ")"
+}
+
+func (p *customProduct) Type() sql.Type {
+	return sql.Float64
+}
+
+func (p *customProduct) IsNullable() bool {
+	return true
+}
+
+func (p *customProduct) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	return nil, aggregation.ErrEvalUnsupportedOnAggregation.New("customProduct")
+}
+
+func (p *customProduct) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(p, len(children), 1)
+	}
+	return &customProduct{expression.UnaryExpression{Child: children[0]}}, nil
+}
+
+func (p *customProduct) NewBuffer() (sql.AggregationBuffer, error) {
+	return &customProductBuffer{expr: p.Child}, nil
+}
+
+type customProductBuffer struct {
+	expr    sql.Expression
+	product float64
+	seen    bool
+}
+
+func (b *customProductBuffer) Update(ctx *sql.Context, row sql.Row) error {
+	v, err := b.expr.Eval(ctx, row)
+	if err != nil || v == nil {
+		return err
+	}
+	v, err = sql.Float64.Convert(v)
+	if err != nil {
+		return err
+	}
+	if !b.seen {
+		b.product, b.seen = 1, true
+	}
+	b.product *= v.(float64)
+	return nil
+}
+
+func (b *customProductBuffer) Eval(ctx *sql.Context) (interface{}, error) {
+	if !b.seen {
+		return nil, nil
+	}
+	return b.product, nil
+}
+
+func (b *customProductBuffer) Dispose() {}
+
+// TestCustomAggregateFunctions tests that aggregate functions registered with the catalog can be used wherever the
+// built-in aggregate functions can.
+func TestCustomAggregateFunctions(t *testing.T, harness Harness) {
+	e := NewEngine(t, harness)
+	e.Analyzer.Catalog.RegisterFunction(sql.AggregateFunction{
+		Name:       "product",
+		MinArgs:    1,
+		MaxArgs:    1,
+		ReturnType: sql.Float64,
+		Fn: func(args ...sql.Expression) (sql.Aggregation, error) {
+			return &customProduct{expression.UnaryExpression{Child: args[0]}}, nil
+		},
+	})
+
+	for _, tt := range []QueryTest{
+		{
+			Query:    "SELECT product(i) FROM mytable",
+			Expected: []sql.Row{{float64(6)}},
+		},
+		{
+			Query:    "SELECT product(i) + 1 AS p FROM mytable",
+			Expected: []sql.Row{{float64(7)}},
+		},
+		{
+			Query:    "SELECT product(i) FROM mytable WHERE i > 5",
+			Expected: []sql.Row{{nil}},
+		},
+		{
+			Query:    "SELECT i % 2 AS parity, product(i) FROM mytable GROUP BY 1 ORDER BY 1",
+			Expected: []sql.Row{{int64(0), float64(2)}, {int64(1), float64(3)}},
+		},
+		{
+			Query:    "SELECT i % 2 AS parity, product(i) AS p FROM mytable GROUP BY 1 HAVING p > 2",
+			Expected: []sql.Row{{int64(1), float64(3)}},
+		},
+		{
+			Query:    "SELECT i, product(i) FROM mytable GROUP BY i ORDER BY product(i) DESC",
+			Expected: []sql.Row{{int64(3), float64(3)}, {int64(2), float64(2)}, {int64(1), float64(1)}},
+		},
+		{
+			Query:    "SELECT i FROM mytable WHERE i = (SELECT product(i) FROM mytable WHERE i < 3)",
+			Expected: []sql.Row{{int64(2)}},
+		},
+	} {
+		TestQuery(t, harness, e, tt.Query, tt.Expected, nil, nil)
+	}
+
+	AssertErr(t, e, harness, "SELECT product(i, s) FROM mytable", sql.ErrInvalidArgumentNumber)
+	AssertErr(t, e, harness, "SELECT product(i) FROM mytable WHERE product(i) > 1", analyzer.ErrAggregationUnsupported)
+}
+
+// TestLowerCaseTableNames tests that the names of databases, tables and columns are matched as the engine's
+// LowerCaseTableNames setting says to.
+func TestLowerCaseTableNames(t *testing.T, harness Harness) {
+	setUp := []string{
+		"CREATE TABLE mytable (i int primary key, s varchar(20))",
+		"INSERT INTO mytable VALUES (1, 'first')",
+	}
+
+	for _, tt := range []struct {
+		name                string
+		lowerCaseTableNames sql.LowerCaseTableNames
+		assertions          []ScriptTestAssertion
+	}{
+		{
+			name:                "case-insensitive",
+			lowerCaseTableNames: sql.LowerCaseTableNames_Insensitive,
+			assertions: []ScriptTestAssertion{
+				{
+					Query:    "SELECT * FROM MyTable",
+					Expected: []sql.Row{{1, "first"}},
+				},
+				{
+					Query:    "SELECT S FROM MYDB.MyTable",
+					Expected: []sql.Row{{"first"}},
+				},
+				{
+					Query:    "CREATE TABLE OtherTable (i int primary key)",
+					Expected: []sql.Row{},
+				},
+				{
+					Query:    "SHOW TABLES LIKE '%table'",
+					Expected: []sql.Row{{"mytable"}, {"OtherTable"}},
+				},
+			},
+		},
+		{
+			name:                "lower case",
+			lowerCaseTableNames: sql.LowerCaseTableNames_Lowercase,
+			assertions: []ScriptTestAssertion{
+				{
+					Query:    "SELECT * FROM MyTable",
+					Expected: []sql.Row{{1, "first"}},
+				},
+				{
+					Query:    "SELECT S FROM MYDB.MyTable",
+					Expected: []sql.Row{{"first"}},
+				},
+				{
+					Query:    "CREATE TABLE OtherTable (i int primary key)",
+					Expected: []sql.Row{},
+				},
+				{
+					Query:    "RENAME TABLE OtherTable TO NewTable",
+					Expected: []sql.Row(nil),
+				},
+				{
+					Query:    "SHOW TABLES LIKE '%table'",
+					Expected: []sql.Row{{"mytable"}, {"newtable"}},
+				},
+				{
+					Query:    "CREATE DATABASE OtherDb",
+					Expected: []sql.Row{{sql.NewOkResult(1)}},
+				},
+				{
+					Query:    "SELECT SCHEMA_NAME FROM information_schema.SCHEMATA WHERE SCHEMA_NAME LIKE 'other%'",
+					Expected: []sql.Row{{"otherdb"}},
+				},
+			},
+		},
+		{
+			name:                "case-sensitive",
+			lowerCaseTableNames: sql.LowerCaseTableNames_Sensitive,
+			assertions: []ScriptTestAssertion{
+				{
+					Query:       "SELECT * FROM MyTable",
+					ExpectedErr: sql.ErrTableNotFound,
+				},
+				{
+					Query:    "SELECT * FROM mytable",
+					Expected: []sql.Row{{1, "first"}},
+				},
+				{
+					Query:       "SELECT s FROM MYDB.mytable",
+					ExpectedErr: sql.ErrDatabaseNotFound,
+				},
+				{
+					Query:       "SELECT S FROM mydb.mytable",
+					ExpectedErr: sql.ErrTableColumnNotFound,
+				},
+				{
+					Query:    "SELECT TABLE_NAME FROM INFORMATION_SCHEMA.TABLES WHERE TABLE_SCHEMA = 'mydb' AND TABLE_TYPE = 'BASE TABLE'",
+					Expected: []sql.Row{{"mytable"}},
+				},
+				{
+					Query:    "CREATE TABLE othertable (i int primary key)",
+					Expected: []sql.Row{},
+				},
+				{
+					Query:    "CREATE TABLE niltable (i int primary key)",
+					Expected: []sql.Row{},
+				},
+				{
+					Query:    "CREATE VIEW MyView2 AS SELECT i FROM mytable",
+					Expected: []sql.Row{},
+				},
+				{
+					Query:       "SELECT * FROM myview2",
+					ExpectedErr: sql.ErrTableNotFound,
+				},
+				{
+					Query:    "SELECT * FROM MyView2",
+					Expected: []sql.Row{{1}},
+				},
+				{
+					Query:       "DROP TABLE MyTable",
+					ExpectedErr: sql.ErrTableNotFound,
+				},
+				{
+					Query:    "DROP TABLE IF EXISTS NILTABLE",
+					Expected: []sql.Row{},
+				},
+				{
+					Query:       "RENAME TABLE MyTable TO gone",
+					ExpectedErr: sql.ErrTableNotFound,
+				},
+				{
+					Query:       "ALTER TABLE Othertable RENAME TO gone2",
+					ExpectedErr: sql.ErrTableNotFound,
+				},
+				{
+					Query:       "DROP VIEW myview2",
+					ExpectedErr: sql.ErrViewDoesNotExist,
+				},
+				{
+					Query:    "DROP VIEW IF EXISTS myview2",
+					Expected: []sql.Row{},
+				},
+				{
+					Query:    "SELECT TABLE_NAME FROM INFORMATION_SCHEMA.TABLES WHERE TABLE_SCHEMA = 'mydb' ORDER BY 1",
+					Expected: []sql.Row{{"MyView2"}, {"mytable"}, {"myview"}, {"niltable"}, {"othertable"}},
+				},
+				{
+					Query:    "RENAME TABLE othertable TO OtherTable",
+					Expected: []sql.Row(nil),
+				},
+				{
+					Query:    "DROP TABLE OtherTable, niltable",
+					Expected: []sql.Row{},
+				},
+				{
+					Query:    "DROP VIEW MyView2",
+					Expected: []sql.Row{},
+				},
+				{
+					Query:    "SELECT TABLE_NAME FROM INFORMATION_SCHEMA.TABLES WHERE TABLE_SCHEMA = 'mydb' ORDER BY 1",
+					Expected: []sql.Row{{"mytable"}, {"myview"}},
+				},
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			databases := append(harness.NewDatabases("mydb"), information_schema.NewInformationSchemaDatabase())
+			a := analyzer.NewDefault(harness.NewDatabaseProvider(databases...))
+			e := sqle.New(a, &sqle.Config{LowerCaseTableNames: tt.lowerCaseTableNames})
+			TestScriptWithEngine(t, e, harness, ScriptTest{
+				Name:        tt.name,
+				SetUpScript: setUp,
+				Assertions:  tt.assertions,
+			})
+		})
+	}
+}
+
 func TestDateParse(t *testing.T, harness Harness) {
 	engine := NewEngine(t, harness)
 	for _, tt := range DateParseQueries {
@@ -3696,6 +4246,24 @@ func TestColumnDefaults(t *testing.T, harness Harness) {
 		TestQuery(t, harness, e, "CREATE TABLE t1009(pk BIGINT DEFAULT (v2) PRIMARY KEY, v1 BIGINT DEFAULT (pk), v2 BIGINT)", []sql.Row(nil), nil, nil)
 		AssertErr(t, e, harness, "ALTER TABLE t1009 ADD COLUMN v1 BIGINT DEFAULT (pk) AFTER v3", sql.ErrTableColumnNotFound)
 	})
+
+	t.Run("Default expression drawing from a sequence", func(t *testing.T) {
+		require.NoError(e.Sequences.Create("ids", 100, 10))
+		TestQuery(t, harness, e, "CREATE TABLE t1010(pk BIGINT PRIMARY KEY DEFAULT (NEXTVAL('ids')), v1 VARCHAR(10))", []sql.Row(nil), nil, nil)
+		TestQuery(t, harness, e, "CREATE TABLE t1011(pk BIGINT PRIMARY KEY DEFAULT (NEXTVAL('IDS')), v1 VARCHAR(10))", []sql.Row(nil), nil, nil)
+		RunQuery(t, e, harness, "INSERT INTO t1010 (v1) VALUES ('a'), ('b')")
+		RunQuery(t, e, harness, "INSERT INTO t1011 (v1) VALUES ('c')")
+		RunQuery(t, e, harness, "INSERT INTO t1010 VALUES (1, 'd')")
+		RunQuery(t, e, harness, "INSERT INTO t1010 (v1) VALUES ('e')")
+		TestQuery(t, harness, e, "SELECT * FROM t1010 ORDER BY pk", []sql.Row{{1, "d"}, {100, "a"}, {110, "b"}, {130, "e"}}, nil, nil)
+		TestQuery(t, harness, e, "SELECT * FROM t1011", []sql.Row{{120, "c"}}, nil, nil)
+		TestQuery(t, harness, e, "SELECT NEXTVAL('ids')", []sql.Row{{int64(140)}}, nil, nil)
+	})
+
+	t.Run("Default expression drawing from an unknown sequence, fails on insertion", func(t *testing.T) {
+		TestQuery(t, harness, e, "CREATE TABLE t1012(pk BIGINT PRIMARY KEY DEFAULT (NEXTVAL('unknown')), v1 BIGINT)", []sql.Row(nil), nil, nil)
+		AssertErr(t, e, harness, "INSERT INTO t1012 (v1) VALUES (1)", sql.ErrSequenceNotFound)
+	})
 }
 
 func TestPersist(t *testing.T, harness Harness, newPersistableSess func(ctx *sql.Context) sql.PersistableSession) {
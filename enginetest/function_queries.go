@@ -0,0 +1,237 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enginetest
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+var StoredFunctionTests = []ScriptTest{
+	{
+		Name: "Calling stored functions in a SELECT",
+		SetUpScript: []string{
+			"CREATE TABLE t (pk INT PRIMARY KEY, name VARCHAR(20))",
+			"INSERT INTO t VALUES (1, 'one'), (2, 'two'), (3, 'three')",
+			"CREATE FUNCTION add_one(x INT) RETURNS INT DETERMINISTIC RETURN x + 1",
+			"CREATE FUNCTION greet(name VARCHAR(20)) RETURNS VARCHAR(40) RETURN CONCAT('hello ', name, '!')",
+			"CREATE FUNCTION twice(x INT) RETURNS BIGINT RETURN add_one(x) * 2 - 2",
+			"CREATE FUNCTION count_above(m INT) RETURNS INT READS SQL DATA RETURN (SELECT COUNT(*) FROM t WHERE pk > m)",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "SELECT add_one(41)",
+				Expected: []sql.Row{{int32(42)}},
+			},
+			{
+				Query:    "SELECT pk, add_one(pk) FROM t ORDER BY pk",
+				Expected: []sql.Row{{1, int32(2)}, {2, int32(3)}, {3, int32(4)}},
+			},
+			{
+				Query:    "SELECT pk FROM t WHERE add_one(pk) = 3",
+				Expected: []sql.Row{{2}},
+			},
+			{
+				Query:    "SELECT greet(name) FROM t WHERE pk = 1",
+				Expected: []sql.Row{{"hello one!"}},
+			},
+			{
+				Query:    "SELECT twice(5), add_one(NULL)",
+				Expected: []sql.Row{{int64(10), nil}},
+			},
+			{
+				Query:    "SELECT count_above(1), count_above(pk) FROM t ORDER BY pk",
+				Expected: []sql.Row{{int32(2), int32(2)}, {int32(2), int32(1)}, {int32(2), int32(0)}},
+			},
+			{
+				Query:    "SELECT pk, (SELECT add_one(pk)) FROM t ORDER BY pk",
+				Expected: []sql.Row{{1, int32(2)}, {2, int32(3)}, {3, int32(4)}},
+			},
+			{
+				Query:       "SELECT add_one(1, 2)",
+				ExpectedErr: sql.ErrStoredFunctionIncorrectArgumentCount,
+			},
+			{
+				Query:       "SELECT greet('a name that is much too long')",
+				ExpectedErr: sql.ErrDataTooLong,
+			},
+		},
+	},
+	{
+		Name: "Stored functions with a BEGIN ... END body",
+		SetUpScript: []string{
+			"CREATE TABLE t (pk INT PRIMARY KEY)",
+			"INSERT INTO t VALUES (1), (2), (3)",
+			"CREATE FUNCTION double_it(x INT) RETURNS INT BEGIN DECLARE y INT; SET y = x * 2; RETURN y; END",
+			`CREATE FUNCTION sign_of(x INT) RETURNS VARCHAR(10) BEGIN
+				IF x > 0 THEN RETURN 'positive';
+				ELSEIF x < 0 THEN RETURN 'negative';
+				END IF;
+				RETURN 'zero';
+			END`,
+			"CREATE FUNCTION total_above(m INT) RETURNS INT READS SQL DATA BEGIN DECLARE s, c INT; SET s = (SELECT SUM(pk) FROM t WHERE pk > m); SET c = 1; RETURN s + c; END",
+			"CREATE FUNCTION no_return(x INT) RETURNS INT BEGIN IF x > 0 THEN RETURN x; END IF; END",
+			"CREATE FUNCTION count_rows() RETURNS INT BEGIN DECLARE n INT; SELECT COUNT(*) INTO n FROM t; RETURN n; END",
+			"CREATE FUNCTION sum_and_max() RETURNS INT BEGIN DECLARE s, m INT; SELECT SUM(pk), MAX(pk) FROM t INTO s, m; RETURN s * 10 + m; END",
+			"CREATE FUNCTION any_pk() RETURNS INT BEGIN DECLARE n INT; SELECT pk INTO n FROM t; RETURN n; END",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "SELECT double_it(21)",
+				Expected: []sql.Row{{int32(42)}},
+			},
+			{
+				Query:    "SELECT pk, double_it(pk) FROM t ORDER BY pk",
+				Expected: []sql.Row{{1, int32(2)}, {2, int32(4)}, {3, int32(6)}},
+			},
+			{
+				Query:    "SELECT sign_of(5), sign_of(-5), sign_of(0)",
+				Expected: []sql.Row{{"positive", "negative", "zero"}},
+			},
+			{
+				Query:    "SELECT total_above(1)",
+				Expected: []sql.Row{{int32(6)}},
+			},
+			{
+				Query:    "SELECT no_return(1)",
+				Expected: []sql.Row{{int32(1)}},
+			},
+			{
+				Query:       "SELECT no_return(0)",
+				ExpectedErr: sql.ErrStoredFunctionEndedWithoutReturn,
+			},
+			{
+				Query:    "SELECT count_rows(), sum_and_max()",
+				Expected: []sql.Row{{int32(3), int32(63)}},
+			},
+			{
+				Query:       "SELECT any_pk()",
+				ExpectedErr: sql.ErrSelectIntoMultipleRows,
+			},
+		},
+	},
+	{
+		Name: "Stored functions can't be recursive",
+		SetUpScript: []string{
+			"CREATE FUNCTION f1(x INT) RETURNS INT RETURN f2(x)",
+			"CREATE FUNCTION f2(x INT) RETURNS INT RETURN f1(x) + 1",
+			"CREATE FUNCTION f3(x INT) RETURNS INT RETURN IF(x > 0, f3(x - 1), 0)",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:       "SELECT f1(1)",
+				ExpectedErr: sql.ErrStoredFunctionRecursiveCall,
+			},
+			{
+				Query:       "SELECT f3(1)",
+				ExpectedErr: sql.ErrStoredFunctionRecursiveCall,
+			},
+		},
+	},
+	{
+		Name: "Built-in functions take precedence over stored functions",
+		SetUpScript: []string{
+			"CREATE FUNCTION `upper`(x INT) RETURNS INT RETURN x",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "SELECT UPPER('a')",
+				Expected: []sql.Row{{"A"}},
+			},
+		},
+	},
+	{
+		Name: "Invalid stored functions",
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:       "CREATE FUNCTION f(x INT, X INT) RETURNS INT RETURN x",
+				ExpectedErr: sql.ErrStoredFunctionDuplicateParameterName,
+			},
+			{
+				Query:       "CREATE FUNCTION f(x INT) RETURNS INT BEGIN SET x = x + 1; END",
+				ExpectedErr: sql.ErrStoredFunctionNoReturn,
+			},
+			{
+				Query:       "CREATE FUNCTION f(x INT) RETURNS INT BEGIN SELECT x; RETURN x; END",
+				ExpectedErr: sql.ErrStoredFunctionResultSet,
+			},
+			{
+				Query:       "CREATE FUNCTION f(x INT) RETURNS INT BEGIN RETURN x, x; END",
+				ExpectedErr: sql.ErrSyntaxError,
+			},
+			{
+				Query:       "CREATE FUNCTION f(x INT) RETURNS INT RETURN x FROM mytable",
+				ExpectedErr: sql.ErrSyntaxError,
+			},
+			{
+				Query:       "CREATE FUNCTION f(x INT) RETURN x",
+				ExpectedErr: sql.ErrSyntaxError,
+			},
+			{
+				Query:    "CREATE FUNCTION f(x INT) RETURNS INT RETURN x",
+				Expected: []sql.Row{{sql.NewOkResult(0)}},
+			},
+			{
+				Query:       "CREATE FUNCTION F(y INT) RETURNS INT RETURN y",
+				ExpectedErr: sql.ErrStoredFunctionAlreadyExists,
+			},
+		},
+	},
+	{
+		Name: "DROP functions",
+		SetUpScript: []string{
+			"CREATE FUNCTION f1() RETURNS INT RETURN 5",
+			"CREATE FUNCTION f2() RETURNS INT RETURN 6",
+			"CREATE FUNCTION f5() RETURNS INT RETURN 7",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "SELECT f1(), f2()",
+				Expected: []sql.Row{{int32(5), int32(6)}},
+			},
+			{
+				Query:    "DROP FUNCTION f1",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:       "SELECT f1()",
+				ExpectedErr: sql.ErrFunctionNotFound,
+			},
+			{
+				Query:    "DROP FUNCTION IF EXISTS f2",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:       "DROP FUNCTION f3",
+				ExpectedErr: sql.ErrStoredFunctionDoesNotExist,
+			},
+			{
+				Query:    "DROP FUNCTION IF EXISTS f4",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "DROP FUNCTION IF EXISTS mydb.f5",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:       "SELECT f5()",
+				ExpectedErr: sql.ErrFunctionNotFound,
+			},
+			{
+				Query:       "DROP FUNCTION nodb.f5",
+				ExpectedErr: sql.ErrDatabaseNotFound,
+			},
+		},
+	},
+}
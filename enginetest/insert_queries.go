@@ -934,6 +934,48 @@ var InsertScripts = []ScriptTest{
 			},
 		},
 	},
+	{
+		Name: "explicit DEFAULT mixed with values in multiple rows",
+		SetUpScript: []string{
+			"CREATE TABLE t (pk int PRIMARY KEY, a int DEFAULT 5, b varchar(10) DEFAULT 'x', c datetime DEFAULT CURRENT_TIMESTAMP, d int DEFAULT (pk * 2))",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query: "INSERT INTO t VALUES (1, DEFAULT, 'y', DEFAULT, DEFAULT), (2, 7, DEFAULT, DEFAULT, 3), (3, DEFAULT, DEFAULT, DEFAULT, DEFAULT)",
+				Expected: []sql.Row{
+					{sql.OkResult{RowsAffected: 3}},
+				},
+			},
+			{
+				Query: "INSERT INTO t (b, pk) VALUES ('z', 4), (DEFAULT, 5)",
+				Expected: []sql.Row{
+					{sql.OkResult{RowsAffected: 2}},
+				},
+			},
+			{
+				Query: "INSERT INTO t (pk, a) VALUES (6, DEFAULT(a)), (7, 8)",
+				Expected: []sql.Row{
+					{sql.OkResult{RowsAffected: 2}},
+				},
+			},
+			{
+				Query: "SELECT pk, a, b, d FROM t ORDER BY pk",
+				Expected: []sql.Row{
+					{1, 5, "y", 2},
+					{2, 7, "x", 3},
+					{3, 5, "x", 6},
+					{4, 5, "z", 8},
+					{5, 5, "x", 10},
+					{6, 5, "x", 12},
+					{7, 8, "x", 14},
+				},
+			},
+			{
+				Query:    "SELECT COUNT(DISTINCT c) FROM t WHERE pk <= 3",
+				Expected: []sql.Row{{1}},
+			},
+		},
+	},
 	{
 		Name: "Try INSERT IGNORE with primary key, non null, and single row violations",
 		SetUpScript: []string{
@@ -1033,6 +1075,94 @@ var InsertScripts = []ScriptTest{
 			},
 		},
 	},
+	{
+		Name: "ON DUPLICATE KEY UPDATE with multiple unique keys",
+		SetUpScript: []string{
+			"CREATE TABLE test (pk int PRIMARY KEY, a int, b int, c int, UNIQUE KEY ua (a), UNIQUE KEY ub (b))",
+			"INSERT INTO test VALUES (1, 1, 1, 0), (2, 2, 2, 0)",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				// Conflicts with row 1 on ua and with row 2 on ub, only the first one is updated
+				Query:    "INSERT INTO test VALUES (3, 1, 2, 0) ON DUPLICATE KEY UPDATE c = c + 10",
+				Expected: []sql.Row{{sql.NewOkResult(2)}},
+			},
+			{
+				Query:    "SELECT * FROM test ORDER BY pk",
+				Expected: []sql.Row{{1, 1, 1, 10}, {2, 2, 2, 0}},
+			},
+			{
+				// Conflicts with row 2 on the primary key and with row 1 on ua
+				Query:    "INSERT INTO test VALUES (2, 1, 3, 0) ON DUPLICATE KEY UPDATE c = c + 20",
+				Expected: []sql.Row{{sql.NewOkResult(2)}},
+			},
+			{
+				Query:    "SELECT * FROM test ORDER BY pk",
+				Expected: []sql.Row{{1, 1, 1, 10}, {2, 2, 2, 20}},
+			},
+			{
+				Query:       "INSERT INTO test VALUES (3, 1, 3, 0) ON DUPLICATE KEY UPDATE b = 2",
+				ExpectedErr: sql.ErrUniqueKeyViolation,
+			},
+			{
+				Query:       "INSERT INTO test VALUES (3, 3, 2, 0)",
+				ExpectedErr: sql.ErrUniqueKeyViolation,
+			},
+			{
+				Query:       "UPDATE test SET a = 2 WHERE pk = 1",
+				ExpectedErr: sql.ErrUniqueKeyViolation,
+			},
+			{
+				Query:    "INSERT INTO test VALUES (3, NULL, NULL, 0), (4, NULL, NULL, 0)",
+				Expected: []sql.Row{{sql.NewOkResult(2)}},
+			},
+			{
+				Query:    "SELECT * FROM test ORDER BY pk",
+				Expected: []sql.Row{{1, 1, 1, 10}, {2, 2, 2, 20}, {3, nil, nil, 0}, {4, nil, nil, 0}},
+			},
+		},
+	},
+	{
+		Name: "ON DUPLICATE KEY UPDATE with VALUES() on unique key conflicts",
+		SetUpScript: []string{
+			"CREATE TABLE upsert (id int PRIMARY KEY, code varchar(10), qty int, UNIQUE KEY (code))",
+			"INSERT INTO upsert VALUES (1, 'a', 1), (2, 'b', 2)",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				// Conflicts with row 2 on the unique key only
+				Query:    "INSERT INTO upsert (id, code, qty) VALUES (3, 'b', 5) ON DUPLICATE KEY UPDATE qty = qty + VALUES(qty)",
+				Expected: []sql.Row{{sql.NewOkResult(2)}},
+			},
+			{
+				Query:    "SELECT * FROM upsert ORDER BY id",
+				Expected: []sql.Row{{1, "a", 1}, {2, "b", 7}},
+			},
+			{
+				// Inserts row 3, updates row 1 through the primary key, row 2 through the unique key, and then the
+				// row inserted by the same statement
+				Query:    "INSERT INTO upsert VALUES (3, 'c', 3), (1, 'z', 10), (9, 'b', 20), (4, 'c', 30) ON DUPLICATE KEY UPDATE qty = VALUES(qty) + 1",
+				Expected: []sql.Row{{sql.NewOkResult(7)}},
+			},
+			{
+				Query:    "SELECT * FROM upsert ORDER BY id",
+				Expected: []sql.Row{{1, "a", 11}, {2, "b", 21}, {3, "c", 31}},
+			},
+			{
+				// Updating a row to the values it already has doesn't affect it
+				Query:    "INSERT INTO upsert VALUES (5, 'a', 11) ON DUPLICATE KEY UPDATE qty = VALUES(qty)",
+				Expected: []sql.Row{{sql.NewOkResult(0)}},
+			},
+			{
+				Query:    "INSERT INTO upsert (code, id, qty) VALUES ('d', 4, 4) ON DUPLICATE KEY UPDATE code = VALUES(code)",
+				Expected: []sql.Row{{sql.NewOkResult(1)}},
+			},
+			{
+				Query:    "SELECT * FROM upsert ORDER BY id",
+				Expected: []sql.Row{{1, "a", 11}, {2, "b", 21}, {3, "c", 31}, {4, "d", 4}},
+			},
+		},
+	},
 }
 
 var InsertErrorTests = []GenericErrorQueryTest{
@@ -1134,6 +1264,16 @@ var InsertErrorScripts = []ScriptTest{
 		Query:       "create table bad (pk1 int auto_increment default 10, c0 int);",
 		ExpectedErr: parse.ErrInvalidAutoIncCols,
 	},
+	{
+		Name:        "create varchar auto_increment column",
+		Query:       "create table bad (pk varchar(10) primary key auto_increment);",
+		ExpectedErr: parse.ErrInvalidAutoIncCols,
+	},
+	{
+		Name:        "create decimal auto_increment column",
+		Query:       "create table bad (pk decimal(10,2) primary key auto_increment);",
+		ExpectedErr: parse.ErrInvalidAutoIncCols,
+	},
 }
 
 var InsertIgnoreScripts = []ScriptTest{
@@ -120,7 +120,7 @@ var JsonScripts = []ScriptTest{
 				Query: "SELECT JSON_ARRAYAGG(o_id) FROM t2",
 				Expected: []sql.Row{
 					{
-						sql.MustJSON(`[]`),
+						nil,
 					},
 				},
 			},
@@ -317,6 +317,34 @@ var JsonScripts = []ScriptTest{
 					},
 				},
 			},
+			{
+				Query: `SELECT JSON_ARRAYAGG(val) from j where pk = 1`,
+				Expected: []sql.Row{
+					{
+						sql.MustJSON(`[{"key1": "value1", "key2": "value2"}, {"key1": {"key": [2, 3]}}]`),
+					},
+				},
+			},
+			{
+				Query: `SELECT JSON_EXTRACT(JSON_OBJECTAGG(pk, val), '$."1".key1.key[1]'), JSON_EXTRACT(JSON_ARRAYAGG(val), '$[2][0]') from j`,
+				Expected: []sql.Row{
+					{
+						sql.MustJSON(`3`), sql.MustJSON(`"a"`),
+					},
+				},
+			},
+			{
+				Query:    `SELECT pk, JSON_ARRAYAGG(val) from j where pk > 5 group by pk`,
+				Expected: []sql.Row{},
+			},
+			{
+				Query: `SELECT JSON_ARRAYAGG(val), JSON_OBJECTAGG(pk, val) from j where pk > 5`,
+				Expected: []sql.Row{
+					{
+						nil, nil,
+					},
+				},
+			},
 		},
 	},
 	{
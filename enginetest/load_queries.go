@@ -51,7 +51,7 @@ var LoadDataScripts = []ScriptTest{
 	{
 		Name: "Load data with csv with prefix.",
 		SetUpScript: []string{
-			"create table loadtable(pk longtext primary key, c1 int)",
+			"create table loadtable(pk varchar(20) primary key, c1 int)",
 			"LOAD DATA INFILE './testdata/test3.csv' INTO TABLE loadtable FIELDS TERMINATED BY ',' LINES STARTING BY 'xxx' IGNORE 1 LINES (`pk`, `c1`)",
 		},
 		Assertions: []ScriptTestAssertion{
@@ -85,7 +85,7 @@ var LoadDataErrorScripts = []ScriptTest{
 	{
 		Name: "Load data with unknown files throws an error.",
 		SetUpScript: []string{
-			"create table loadtable(pk longtext primary key, c1 int)",
+			"create table loadtable(pk varchar(20) primary key, c1 int)",
 		},
 		Assertions: []ScriptTestAssertion{
 			{
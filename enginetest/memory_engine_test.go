@@ -402,6 +402,10 @@ func TestStoredProcedures(t *testing.T) {
 	enginetest.TestStoredProcedures(t, enginetest.NewDefaultMemoryHarness())
 }
 
+func TestStoredFunctions(t *testing.T) {
+	enginetest.TestStoredFunctions(t, enginetest.NewDefaultMemoryHarness())
+}
+
 func TestTriggersErrors(t *testing.T) {
 	enginetest.TestTriggerErrors(t, enginetest.NewDefaultMemoryHarness())
 }
@@ -486,6 +490,14 @@ func TestReadOnly(t *testing.T) {
 	enginetest.TestReadOnly(t, enginetest.NewDefaultMemoryHarness())
 }
 
+func TestStoredProcedureSecurity(t *testing.T) {
+	enginetest.TestStoredProcedureSecurity(t, enginetest.NewDefaultMemoryHarness())
+}
+
+func TestShowGrantsWithUsers(t *testing.T) {
+	enginetest.TestShowGrantsWithUsers(t, enginetest.NewDefaultMemoryHarness())
+}
+
 func TestViews(t *testing.T) {
 	enginetest.TestViews(t, enginetest.NewDefaultMemoryHarness())
 }
@@ -514,6 +526,14 @@ func TestInnerNestedInNaturalJoins(t *testing.T) {
 	enginetest.TestInnerNestedInNaturalJoins(t, enginetest.NewDefaultMemoryHarness())
 }
 
+func TestCustomAggregateFunctions(t *testing.T) {
+	enginetest.TestCustomAggregateFunctions(t, enginetest.NewDefaultMemoryHarness())
+}
+
+func TestLowerCaseTableNames(t *testing.T) {
+	enginetest.TestLowerCaseTableNames(t, enginetest.NewDefaultMemoryHarness())
+}
+
 func TestColumnDefaults(t *testing.T) {
 	enginetest.TestColumnDefaults(t, enginetest.NewDefaultMemoryHarness())
 }
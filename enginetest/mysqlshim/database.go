@@ -185,7 +185,7 @@ func (d Database) DropStoredProcedure(ctx *sql.Context, name string) error {
 
 // CreateView implements the interface sql.ViewDatabase.
 func (d Database) CreateView(ctx *sql.Context, name string, selectStatement string) error {
-	return d.shim.Exec(d.name, fmt.Sprintf("CREATE VIEW `%s` AS %s;", name, selectStatement))
+	return d.shim.Exec(d.name, sql.CreateViewStatement(name, selectStatement)+";")
 }
 
 // DropView implements the interface sql.ViewDatabase.
@@ -546,6 +546,44 @@ END;`,
 			},
 		},
 	},
+	{
+		Name: "SELECT ... INTO assigns parameters and user variables",
+		SetUpScript: []string{
+			"CREATE TABLE t (pk INT PRIMARY KEY, v VARCHAR(10))",
+			"INSERT INTO t VALUES (1, 'a'), (2, 'b')",
+			`CREATE PROCEDURE p1(x INT)
+BEGIN
+	DECLARE s VARCHAR(10);
+	SELECT v INTO s FROM t WHERE pk = x;
+	SELECT pk, v FROM t WHERE pk = x INTO @pk, @v;
+	SELECT s, @pk, @v;
+END;`,
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query: "CALL p1(2)",
+				Expected: []sql.Row{
+					{"b", int32(2), "b"},
+				},
+			},
+			{
+				Query:    "SELECT COUNT(*) INTO @n FROM t",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "SELECT @n",
+				Expected: []sql.Row{{int64(2)}},
+			},
+			{
+				Query:       "SELECT pk INTO @n FROM t",
+				ExpectedErr: sql.ErrSelectIntoMultipleRows,
+			},
+			{
+				Query:       "SELECT pk, v INTO @n FROM t WHERE pk = 1",
+				ExpectedErr: sql.ErrSelectIntoColumnCount,
+			},
+		},
+	},
 	{
 		Name: "DECLARE CONDITION",
 		SetUpScript: []string{
@@ -807,6 +845,24 @@ var ProcedureCallTests = []ScriptTest{
 			},
 		},
 	},
+	{
+		Name: "DECLARE variables",
+		SetUpScript: []string{
+			"SET @outparam = 5",
+			"CREATE PROCEDURE testabc(INOUT x BIGINT) BEGIN DECLARE y, z BIGINT; SET y = x * 2; SET z = y + 1; SET x = z; END;",
+			"CALL testabc(@outparam)",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query: "SELECT @outparam",
+				Expected: []sql.Row{
+					{
+						int64(11),
+					},
+				},
+			},
+		},
+	},
 	{
 		Name: "INOUT param without SET",
 		SetUpScript: []string{
@@ -891,6 +947,28 @@ var ProcedureCallTests = []ScriptTest{
 			},
 		},
 	},
+	{
+		Name: "transaction statements in the body",
+		SetUpScript: []string{
+			"CREATE TABLE t (pk BIGINT PRIMARY KEY)",
+			"CREATE PROCEDURE p1() BEGIN START TRANSACTION; INSERT INTO t VALUES (1); COMMIT; INSERT INTO t VALUES (2); END;",
+			"CREATE PROCEDURE p2() BEGIN INSERT INTO t VALUES (3); ROLLBACK; END;",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "CALL p1()",
+				Expected: []sql.Row{{sql.NewOkResult(1)}},
+			},
+			{
+				Query:    "CALL p2()",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "SELECT * FROM t ORDER BY pk",
+				Expected: []sql.Row{{1}, {2}, {3}},
+			},
+		},
+	},
 }
 
 var ProcedureDropTests = []ScriptTest{
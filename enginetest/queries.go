@@ -15,13 +15,13 @@
 package enginetest
 
 import (
+	"math"
 	"time"
 
 	"github.com/dolthub/vitess/go/sqltypes"
 	"gopkg.in/src-d/go-errors.v1"
 
 	"github.com/dolthub/go-mysql-server/sql"
-	"github.com/dolthub/go-mysql-server/sql/analyzer"
 	"github.com/dolthub/go-mysql-server/sql/expression"
 )
 
@@ -51,6 +51,18 @@ var QueryTests = []QueryTest{
 			},
 		},
 	},
+	{
+		Query:    "select group_concat(s order by i separator '') from mytable",
+		Expected: []sql.Row{{"first rowsecond rowthird row"}},
+	},
+	{
+		Query:    "select group_concat(s order by i separator '__empty_separator') from mytable",
+		Expected: []sql.Row{{"first row__empty_separatorsecond row__empty_separatorthird row"}},
+	},
+	{
+		Query:    "select group_concat(s order by i separator '__rewrite_empty_separator') from mytable",
+		Expected: []sql.Row{{"first row__rewrite_empty_separatorsecond row__rewrite_empty_separatorthird row"}},
+	},
 	{
 		Query: "SELECT mytable.* FROM mytable;",
 		Expected: []sql.Row{
@@ -183,6 +195,55 @@ var QueryTests = []QueryTest{
 			{1, 50.0},
 		},
 	},
+	{
+		Query: "SELECT pk1, SUM(c1) FROM (SELECT * FROM two_pk ORDER BY pk1, pk2) sq GROUP BY pk1",
+		Expected: []sql.Row{
+			{0, 10.0},
+			{1, 50.0},
+		},
+	},
+	{
+		Query: "SELECT pk2, COUNT(*) FROM (SELECT * FROM two_pk ORDER BY pk2 DESC, pk1) sq GROUP BY pk2",
+		Expected: []sql.Row{
+			{1, int64(2)},
+			{0, int64(2)},
+		},
+	},
+	{
+		Query: "SELECT pk DIV 2, SUM(c3) FROM one_pk GROUP BY pk DIV 2 ORDER BY pk DIV 2",
+		Expected: []sql.Row{
+			{int64(0), float64(14)},
+			{int64(1), float64(54)},
+		},
+	},
+	{
+		Query: "SELECT (pk + 1) * 10 FROM one_pk GROUP BY pk + 1 ORDER BY 1",
+		Expected: []sql.Row{
+			{int64(10)},
+			{int64(20)},
+			{int64(30)},
+			{int64(40)},
+		},
+	},
+	{
+		Query: "SELECT pk % 2 AS parity, SUM(c1) FROM one_pk GROUP BY pk % 2 HAVING pk % 2 = 1",
+		Expected: []sql.Row{
+			{int64(1), float64(40)},
+		},
+	},
+	{
+		Query: "SELECT YEAR(date_col), COUNT(*) FROM datetime_table GROUP BY YEAR(date_col) ORDER BY 1",
+		Expected: []sql.Row{
+			{int32(2019), int64(1)},
+			{int32(2020), int64(2)},
+		},
+	},
+	{
+		Query: "SELECT year(date_col) + 1, COUNT(*) FROM datetime_table GROUP BY YEAR(date_col) HAVING YEAR(date_col) > 2019",
+		Expected: []sql.Row{
+			{int64(2021), int64(2)},
+		},
+	},
 	{
 		Query:    "select max(pk),c2 from one_pk group by c1 order by 1",
 		Expected: []sql.Row{{0, 1}, {1, 11}, {2, 21}, {3, 31}},
@@ -195,6 +256,26 @@ var QueryTests = []QueryTest{
 		Query:    "SELECT i FROM mytable;",
 		Expected: []sql.Row{{int64(1)}, {int64(2)}, {int64(3)}},
 	},
+	{
+		Query:    "SELECT i FROM mytable FOR UPDATE SKIP LOCKED;",
+		Expected: []sql.Row{{int64(1)}, {int64(2)}, {int64(3)}},
+	},
+	{
+		Query:    "SELECT i FROM mytable WHERE i > 1 FOR UPDATE OF mytable NOWAIT;",
+		Expected: []sql.Row{{int64(2)}, {int64(3)}},
+	},
+	{
+		Query:    "SELECT s FROM mytable WHERE i = 2 FOR SHARE SKIP LOCKED;",
+		Expected: []sql.Row{{"second row"}},
+	},
+	{
+		Query:    "SELECT * FROM mytable WHERE i = 1 /* it's */ FOR UPDATE SKIP LOCKED",
+		Expected: []sql.Row{{int64(1), "first row"}},
+	},
+	{
+		Query:    "SELECT i FROM mytable WHERE i IN (SELECT i2 FROM othertable FOR SHARE NOWAIT) FOR UPDATE NOWAIT;",
+		Expected: []sql.Row{{int64(1)}, {int64(2)}, {int64(3)}},
+	},
 	{
 		Query:    "SELECT i AS x FROM mytable ORDER BY i DESC",
 		Expected: []sql.Row{{3}, {2}, {1}},
@@ -428,6 +509,18 @@ var QueryTests = []QueryTest{
 			{3, 3.0},
 		},
 	},
+	{
+		Query:    `SELECT var_pop(i), var_samp(i), variance(i), stddev_samp(i), std(i) = stddev_pop(i), stddev(i) = sqrt(var_pop(i)) FROM mytable`,
+		Expected: []sql.Row{{float64(2) / 3, 1.0, float64(2) / 3, 1.0, true, true}},
+	},
+	{
+		Query:    `SELECT bit_and(i), bit_or(i), bit_xor(i) FROM mytable`,
+		Expected: []sql.Row{{uint64(0), uint64(3), uint64(0)}},
+	},
+	{
+		Query:    `SELECT i, var_pop(i), var_samp(i) FROM mytable group by 1 having stddev_pop(i) = 0 order by 1`,
+		Expected: []sql.Row{{1, 0.0, nil}, {2, 0.0, nil}, {3, 0.0, nil}},
+	},
 	{
 		Query: `SELECT i, s, i2, s2 FROM MYTABLE JOIN OTHERTABLE ON i = i2 AND NOT (s2 <=> s)`,
 		Expected: []sql.Row{
@@ -1236,6 +1329,42 @@ var QueryTests = []QueryTest{
 		Query:    "SELECT 1 FROM DUAL WHERE 1 in (1)",
 		Expected: []sql.Row{{1}},
 	},
+	{
+		Query:    "SELECT 1 FROM DUAL WHERE 1=0",
+		Expected: []sql.Row{},
+	},
+	{
+		Query:    "SELECT 1+1",
+		Expected: []sql.Row{{2}},
+	},
+	{
+		Query:    "SELECT 1+1 FROM DUAL",
+		Expected: []sql.Row{{2}},
+	},
+	{
+		Query:    "SELECT (1 < 2) + 1, (1 > 2) + 1",
+		Expected: []sql.Row{{2, 1}},
+	},
+	{
+		Query:    "SELECT (2 IN (1, 2)) * 10, ('abc' LIKE 'a%') - 1",
+		Expected: []sql.Row{{10, 0}},
+	},
+	{
+		Query:    "SELECT i FROM mytable WHERE (i > 1) + (i > 2) = 1",
+		Expected: []sql.Row{{2}},
+	},
+	{
+		Query:    "SELECT COUNT(*)",
+		Expected: []sql.Row{{1}},
+	},
+	{
+		Query:    "SELECT COUNT(*) FROM DUAL WHERE 1=0",
+		Expected: []sql.Row{{0}},
+	},
+	{
+		Query:    "SELECT @@version",
+		Expected: []sql.Row{{""}},
+	},
 	{
 		Query:    "SELECT 1 FROM DUAL WHERE (1, 2) in ((1, 2))",
 		Expected: []sql.Row{{1}},
@@ -1384,6 +1513,30 @@ var QueryTests = []QueryTest{
 		Query:    "SELECT (1, 2) in (select 2, 3 from dual) from dual",
 		Expected: []sql.Row{{false}},
 	},
+	{
+		Query:    "SELECT (1, 2) in (select 1, null from dual) from dual",
+		Expected: []sql.Row{{nil}},
+	},
+	{
+		Query:    "SELECT (1, null) in (select 1, 2 from dual) from dual",
+		Expected: []sql.Row{{nil}},
+	},
+	{
+		Query:    "SELECT (2, null) in (select 1, 2 from dual) from dual",
+		Expected: []sql.Row{{false}},
+	},
+	{
+		Query:    "SELECT 1 in (select '1' from dual) from dual",
+		Expected: []sql.Row{{true}},
+	},
+	{
+		Query:    "SELECT i, (i, s) IN (SELECT i2, CONCAT(s2, ' row') FROM othertable) FROM mytable ORDER BY i",
+		Expected: []sql.Row{{1, false}, {2, true}, {3, false}},
+	},
+	{
+		Query:    "SELECT i, i IN (SELECT i2 FROM niltable) FROM mytable ORDER BY i",
+		Expected: []sql.Row{{1, nil}, {2, true}, {3, nil}},
+	},
 	{
 		Query:    "SELECT (select 1, 2 from dual) in ((1, 2)) from dual",
 		Expected: []sql.Row{{true}},
@@ -1414,6 +1567,18 @@ var QueryTests = []QueryTest{
 		Query:    "SELECT i FROM mytable WHERE i IN (1, 3)",
 		Expected: []sql.Row{{int64(1)}, {int64(3)}},
 	},
+	{
+		Query:    "SELECT i FROM mytable WHERE i IN (1, '2', 3.0) ORDER BY i",
+		Expected: []sql.Row{{int64(1)}, {int64(2)}, {int64(3)}},
+	},
+	{
+		Query:    "SELECT i FROM mytable WHERE i IN (1.5, '2.5', '3 apples') ORDER BY i",
+		Expected: []sql.Row{{int64(3)}},
+	},
+	{
+		Query:    "SELECT 3 IN (3.5), 3 IN ('3.0'), 0 IN ('abc', 5)",
+		Expected: []sql.Row{{false, true, true}},
+	},
 	{
 		Query:    "SELECT i FROM mytable WHERE i = 1 OR i = 3",
 		Expected: []sql.Row{{int64(1)}, {int64(3)}},
@@ -1565,6 +1730,18 @@ var QueryTests = []QueryTest{
 		Query:    "SELECT i FROM mytable ORDER BY i LIMIT 2,100;",
 		Expected: []sql.Row{{int64(3)}},
 	},
+	{
+		Query:    "SELECT i, (SELECT s2 FROM othertable WHERE i2 = i) AS s FROM mytable ORDER BY i DESC LIMIT 2",
+		Expected: []sql.Row{{int64(3), "first"}, {int64(2), "second"}},
+	},
+	{
+		Query:    "SELECT i, (SELECT s2 FROM othertable WHERE i2 = i) AS s FROM mytable ORDER BY i + 1 LIMIT 1 OFFSET 1",
+		Expected: []sql.Row{{int64(2), "second"}},
+	},
+	{
+		Query:    "SELECT i, (SELECT s2 FROM othertable WHERE i2 = i) AS s FROM mytable ORDER BY s LIMIT 1",
+		Expected: []sql.Row{{int64(3), "first"}},
+	},
 	{
 		Query:    "SELECT i FROM niltable WHERE b IS NULL",
 		Expected: []sql.Row{{int64(1)}, {int64(4)}},
@@ -1598,6 +1775,22 @@ var QueryTests = []QueryTest{
 			{int64(6)},
 		},
 	},
+	{
+		Query:    "SELECT i FROM niltable WHERE NOT (i2 > 1 AND b) ORDER BY i",
+		Expected: []sql.Row{{int64(3)}, {int64(6)}},
+	},
+	{
+		Query:    "SELECT i FROM niltable WHERE NOT (i2 <= 2 OR b) ORDER BY i",
+		Expected: []sql.Row{{int64(6)}},
+	},
+	{
+		Query:    "SELECT i FROM niltable WHERE NOT (i2 < 4) ORDER BY i",
+		Expected: []sql.Row{{int64(4)}, {int64(6)}},
+	},
+	{
+		Query:    "SELECT i FROM niltable WHERE NOT NOT (i2 > 2) ORDER BY i",
+		Expected: []sql.Row{{int64(4)}, {int64(6)}},
+	},
 	{
 		Query:    "SELECT i FROM niltable WHERE b IS TRUE",
 		Expected: []sql.Row{{int64(2)}, {int64(5)}},
@@ -1625,6 +1818,44 @@ var QueryTests = []QueryTest{
 		Query:    "SELECT i FROM niltable WHERE b IS NOT FALSE",
 		Expected: []sql.Row{{int64(1)}, {int64(2)}, {int64(4)}, {int64(5)}},
 	},
+	{
+		Query:    "SELECT i FROM niltable WHERE b IS UNKNOWN ORDER BY 1",
+		Expected: []sql.Row{{int64(1)}, {int64(4)}},
+	},
+	{
+		Query:    "SELECT i FROM niltable WHERE b IS NOT UNKNOWN ORDER BY 1",
+		Expected: []sql.Row{{int64(2)}, {int64(3)}, {int64(5)}, {int64(6)}},
+	},
+	{
+		Query:    "SELECT NULL IS TRUE, NULL IS NOT TRUE, NULL IS FALSE, NULL IS NOT FALSE, NULL IS UNKNOWN, NULL IS NOT UNKNOWN",
+		Expected: []sql.Row{{false, true, false, true, true, false}},
+	},
+	{
+		Query:    "SELECT 0 IS TRUE, 0 IS FALSE, 0 IS UNKNOWN, 1 IS TRUE, 1 IS NOT FALSE, -2.5 IS TRUE, 0.0 IS FALSE, 'is unknown'",
+		Expected: []sql.Row{{false, true, false, true, true, true, true, "is unknown"}},
+	},
+	{
+		Query:    "SELECT 1 /* it's */ IS UNKNOWN, 'a' /* it's */ IS NOT UNKNOWN",
+		Expected: []sql.Row{{false, true}},
+	},
+	{
+		Query:    "SELECT 1 -- it's\n IS UNKNOWN, 2 # it's\n IS NOT UNKNOWN",
+		Expected: []sql.Row{{false, true}},
+	},
+	{
+		Query:    "SELECT i FROM niltable WHERE b /* 'is unknown' */ IS UNKNOWN ORDER BY 1",
+		Expected: []sql.Row{{int64(1)}, {int64(4)}},
+	},
+	{
+		Query:    "SELECT i IS UNKNOWN FROM mytable WHERE i = 1",
+		Expected: []sql.Row{{false}},
+		ExpectedColumns: sql.Schema{
+			{
+				Name: "i IS UNKNOWN",
+				Type: sql.Boolean,
+			},
+		},
+	},
 	{
 		Query:    "SELECT i FROM niltable WHERE i2 IS NULL ORDER BY 1",
 		Expected: []sql.Row{{int64(1)}, {int64(3)}, {int64(5)}},
@@ -1685,6 +1916,18 @@ var QueryTests = []QueryTest{
 		Query:    "select i from datetime_table where datetime_col = '2020-01-01T12:00:01'",
 		Expected: []sql.Row{},
 	},
+	{
+		Query:    "select i from datetime_table where datetime_col in ('2020-01-01 12:00:00', datetime('2020-01-07T12:00:00')) order by 1",
+		Expected: []sql.Row{{1}, {3}},
+	},
+	{
+		Query:    "select i from datetime_table where timestamp_col in ('2020-01-02 12:00:00', '2020-01-07 12:00:01') order by 1",
+		Expected: []sql.Row{{1}, {3}},
+	},
+	{
+		Query:    "select i from datetime_table where date_col in ('2019-12-31', date('2020-01-07T12:00:00')) order by 1",
+		Expected: []sql.Row{{1}, {3}},
+	},
 	{
 		Query:    "select i from datetime_table where datetime_col > '2020-01-01T12:00:00' order by 1",
 		Expected: []sql.Row{{2}, {3}},
@@ -2139,6 +2382,18 @@ var QueryTests = []QueryTest{
 		Query:    "SELECT DAYOFYEAR('20071211') FROM mytable",
 		Expected: []sql.Row{{int32(345)}, {int32(345)}, {int32(345)}},
 	},
+	{
+		Query:    "SELECT LAST_DAY('2020-02-10'), LAST_DAY('2021-02-10 12:00:00'), LAST_DAY('2021-13-01')",
+		Expected: []sql.Row{{time.Date(2020, time.February, 29, 0, 0, 0, 0, time.UTC), time.Date(2021, time.February, 28, 0, 0, 0, 0, time.UTC), nil}},
+	},
+	{
+		Query:    "SELECT MAKEDATE(2020, 60), MAKEDATE(2021, 366), MAKEDATE(2021, 0)",
+		Expected: []sql.Row{{time.Date(2020, time.February, 29, 0, 0, 0, 0, time.UTC), time.Date(2022, time.January, 1, 0, 0, 0, 0, time.UTC), nil}},
+	},
+	{
+		Query:    "SELECT MAKETIME(12, 15, 30), MAKETIME(100, 0, 0), MAKETIME(1, 60, 0), MAKETIME(NULL, 0, 0)",
+		Expected: []sql.Row{{"12:15:30", "100:00:00", nil, nil}},
+	},
 	{
 		Query:    "SELECT YEARWEEK('0000-01-01')",
 		Expected: []sql.Row{{int32(1)}},
@@ -2167,6 +2422,38 @@ var QueryTests = []QueryTest{
 		Query:    "SELECT i FROM mytable WHERE i NOT BETWEEN 1 AND 2",
 		Expected: []sql.Row{{int64(3)}},
 	},
+	{
+		Query:    "SELECT NOT 1 BETWEEN 2 AND 3, NOT NULL IS NULL, NOT 0 = 1, NOT 1 IN (2, 3)",
+		Expected: []sql.Row{{true, false, true, true}},
+	},
+	{
+		Query:    "SELECT 1 = 1 = 1, 2 = 2 = 2, 3 > 2 > 1, 1 < 2 < 3, 1 IS NOT NULL = 1, 0 = 0 BETWEEN 0 AND 1",
+		Expected: []sql.Row{{true, false, false, true, true, true}},
+		ExpectedColumns: sql.Schema{
+			{Name: "1 = 1 = 1", Type: sql.Boolean},
+			{Name: "2 = 2 = 2", Type: sql.Boolean},
+			{Name: "3 > 2 > 1", Type: sql.Boolean},
+			{Name: "1 < 2 < 3", Type: sql.Boolean},
+			{Name: "1 IS NOT NULL = 1", Type: sql.Boolean},
+			{Name: "0 = 0 BETWEEN 0 AND 1", Type: sql.Boolean},
+		},
+	},
+	{
+		Query:    "SELECT i FROM mytable WHERE i = 2 = 0 AND NOT i = 3 = 1 ORDER BY i",
+		Expected: []sql.Row{{int64(1)}},
+	},
+	{
+		Query:    "SELECT 2 BETWEEN 1 AND 3 = 1, 2 BETWEEN 1 AND 3 = 1 IS NULL",
+		Expected: []sql.Row{{false, false}},
+	},
+	{
+		Query:    "SELECT i /* it's */ FROM mytable WHERE i = 1 = 1",
+		Expected: []sql.Row{{int64(1)}},
+	},
+	{
+		Query:    "SELECT 1 = 1 = 1 /* it's */ = 1",
+		Expected: []sql.Row{{true}},
+	},
 	{
 		Query:    "SELECT id FROM typestable WHERE ti > '2019-12-31'",
 		Expected: []sql.Row{{int64(1)}},
@@ -2690,6 +2977,48 @@ var QueryTests = []QueryTest{
 			{uint64(18446744073709551613)},
 		},
 	},
+	{
+		Query:    "SELECT CAST(12345678901234567890123456789.5 AS DOUBLE), CAST(12345678901234567890123456789.5 AS FLOAT), CONVERT(i, REAL), CAST('1.25' AS FLOAT(30)) FROM mytable WHERE i = 1",
+		Expected: []sql.Row{{1.2345678901234568e28, float32(1.2345679e28), float64(1), 1.25}},
+		ExpectedColumns: sql.Schema{
+			{
+				Name: "CAST(12345678901234567890123456789.5 AS DOUBLE)",
+				Type: sql.Float64,
+			},
+			{
+				Name: "CAST(12345678901234567890123456789.5 AS FLOAT)",
+				Type: sql.Float32,
+			},
+			{
+				Name: "CONVERT(i, REAL)",
+				Type: sql.Float64,
+			},
+			{
+				Name: "CAST('1.25' AS FLOAT(30))",
+				Type: sql.Float64,
+			},
+		},
+	},
+	{
+		Query:    "SELECT CONVERT('-3', SIGNED), CONVERT(i, CHAR), CONVERT(s, CHAR(5)) FROM mytable ORDER BY i",
+		Expected: []sql.Row{{int64(-3), "1", "first"}, {int64(-3), "2", "secon"}, {int64(-3), "3", "third"}},
+	},
+	{
+		Query:    "SELECT CONVERT('123.456', DECIMAL(10,2)), CAST(-123.456 AS DECIMAL(4,1)), CONVERT(2.5, DECIMAL(3))",
+		Expected: []sql.Row{{"123.46", "-123.5", "3"}},
+	},
+	{
+		Query:    "SELECT CONVERT('añ€ř' USING latin1), CONVERT('añ€ř' USING ascii), CONVERT('añ€ř' USING utf8mb4)",
+		Expected: []sql.Row{{"añ€?", "a???", "añ€ř"}},
+	},
+	{
+		Query:    "SELECT CONVERT(i USING utf8mb4), CONVERT(s, CHAR CHARACTER SET ascii) FROM mytable WHERE i = 1",
+		Expected: []sql.Row{{"1", "first row"}},
+	},
+	{
+		Query:    "SELECT CONVERT(NULL USING utf8mb4), CONVERT(NULL, DECIMAL(5,2))",
+		Expected: []sql.Row{{nil, nil}},
+	},
 	{
 		Query: "SELECT '3' > 2 FROM tabletest",
 		Expected: []sql.Row{
@@ -2737,6 +3066,22 @@ var QueryTests = []QueryTest{
 			{"third row"},
 		},
 	},
+	{
+		Query:    "SELECT s FROM mytable WHERE i IN (1, NULL)",
+		Expected: []sql.Row{{"first row"}},
+	},
+	{
+		Query:    "SELECT s FROM mytable WHERE i NOT IN (1, NULL)",
+		Expected: []sql.Row{},
+	},
+	{
+		Query:    "SELECT s FROM mytable WHERE (i, 1) NOT IN ((1, NULL), (2, 1))",
+		Expected: []sql.Row{{"third row"}},
+	},
+	{
+		Query:    "SELECT i, i NOT IN (1, NULL), (i, NULL) NOT IN ((1, 2)) FROM mytable ORDER BY i",
+		Expected: []sql.Row{{1, false, nil}, {2, nil, true}, {3, nil, true}},
+	},
 	{
 		Query: "SELECT 1 + 2",
 		Expected: []sql.Row{
@@ -2999,6 +3344,22 @@ var QueryTests = []QueryTest{
 			{1},
 		},
 	},
+	{
+		Query: "SELECT BIT_COUNT(i), BIT_COUNT(-i), BIT_COUNT(NULL) from mytable order by i",
+		Expected: []sql.Row{
+			{1, 64, nil},
+			{1, 63, nil},
+			{2, 63, nil},
+		},
+	},
+	{
+		Query: "SELECT i << 62, i << 63, i << 64, i << 100, -1 >> i, 18446744073709551615 >> 64, NULL << i from mytable order by i",
+		Expected: []sql.Row{
+			{uint64(1) << 62, uint64(1) << 63, uint64(0), uint64(0), uint64(math.MaxUint64) >> 1, uint64(0), nil},
+			{uint64(1) << 63, uint64(0), uint64(0), uint64(0), uint64(math.MaxUint64) >> 2, uint64(0), nil},
+			{uint64(3) << 62, uint64(1) << 63, uint64(0), uint64(0), uint64(math.MaxUint64) >> 3, uint64(0), nil},
+		},
+	},
 	{
 		Query: "SELECT ASCII(s) from mytable order by i limit 1",
 		Expected: []sql.Row{
@@ -3083,6 +3444,58 @@ var QueryTests = []QueryTest{
 			{int64(1), float64(1)},
 		},
 	},
+	{
+		Query: "SELECT SUM(i), i FROM mytable GROUP BY i ORDER BY 1+SUM(i) DESC",
+		Expected: []sql.Row{
+			{float64(3), int64(3)},
+			{float64(2), int64(2)},
+			{float64(1), int64(1)},
+		},
+	},
+	{
+		Query: "SELECT SUM(i) as sum, i FROM mytable GROUP BY i ORDER BY 1+SUM(i) ASC",
+		Expected: []sql.Row{
+			{float64(1), int64(1)},
+			{float64(2), int64(2)},
+			{float64(3), int64(3)},
+		},
+	},
+	{
+		Query:    "SELECT i FROM mytable GROUP BY i ORDER BY SUM(i) DESC",
+		Expected: []sql.Row{{int64(3)}, {int64(2)}, {int64(1)}},
+	},
+	{
+		Query:    "SELECT i FROM mytable GROUP BY i ORDER BY MAX(s) DESC LIMIT 2",
+		Expected: []sql.Row{{int64(3)}, {int64(2)}},
+	},
+	{
+		Query:    "SELECT i FROM mytable GROUP BY i HAVING SUM(i) > 1 ORDER BY -SUM(i)",
+		Expected: []sql.Row{{int64(3)}, {int64(2)}},
+	},
+	{
+		Query:    "SELECT i * 10 FROM mytable GROUP BY i ORDER BY COUNT(*) + i DESC",
+		Expected: []sql.Row{{int64(30)}, {int64(20)}, {int64(10)}},
+	},
+	{
+		Query: "SELECT i, COUNT(*) c FROM mytable GROUP BY i ORDER BY c * -i",
+		Expected: []sql.Row{
+			{int64(3), int64(1)},
+			{int64(2), int64(1)},
+			{int64(1), int64(1)},
+		},
+	},
+	{
+		Query:    "SELECT COUNT(*) FROM mytable ORDER BY SUM(i)",
+		Expected: []sql.Row{{int64(3)}},
+	},
+	{
+		Query: "SELECT i AS x, -i AS i FROM mytable GROUP BY x ORDER BY i",
+		Expected: []sql.Row{
+			{int64(3), int64(-3)},
+			{int64(2), int64(-2)},
+			{int64(1), int64(-1)},
+		},
+	},
 	{
 		Query: "SELECT i, SUM(i) as `sum(i)` FROM mytable GROUP BY i ORDER BY sum(i) DESC",
 		Expected: []sql.Row{
@@ -3151,8 +3564,28 @@ var QueryTests = []QueryTest{
 		Expected: []sql.Row{{"mydb"}, {"foo"}, {"information_schema"}},
 	},
 	{
-		Query:    `SHOW GRANTS`,
-		Expected: []sql.Row{{"GRANT ALL PRIVILEGES ON *.* TO 'root'@'%' WITH GRANT OPTION"}},
+		Query: `SHOW GRANTS`,
+		Expected: []sql.Row{
+			{"GRANT ALL PRIVILEGES ON *.* TO 'user'@'%' WITH GRANT OPTION"},
+			{"GRANT PROXY ON ''@'' TO 'user'@'%' WITH GRANT OPTION"},
+		},
+	},
+	{
+		Query: `SHOW GRANTS FOR CURRENT_USER()`,
+		Expected: []sql.Row{
+			{"GRANT ALL PRIVILEGES ON *.* TO 'user'@'%' WITH GRANT OPTION"},
+			{"GRANT PROXY ON ''@'' TO 'user'@'%' WITH GRANT OPTION"},
+		},
+		ExpectedColumns: sql.Schema{
+			{Name: "Grants for user@%", Type: sql.LongText},
+		},
+	},
+	{
+		Query: `SHOW GRANTS FOR user@localhost`,
+		Expected: []sql.Row{
+			{"GRANT ALL PRIVILEGES ON *.* TO 'user'@'localhost' WITH GRANT OPTION"},
+			{"GRANT PROXY ON ''@'' TO 'user'@'localhost' WITH GRANT OPTION"},
+		},
 	},
 	{
 		Query: `SELECT SCHEMA_NAME, DEFAULT_CHARACTER_SET_NAME, DEFAULT_COLLATION_NAME FROM information_schema.SCHEMATA`,
@@ -3317,6 +3750,30 @@ var QueryTests = []QueryTest{
 		Query:    `select JSON_EXTRACT('{"id":234}', '$.id') = 234;`,
 		Expected: []sql.Row{{true}},
 	},
+	{
+		Query:    `SELECT JSON_EXTRACT('{"a": [1, 2, 3], "b": {"c": 4}}', '$.a[last]', '$.a[0 to 1]', '$.b.*')`,
+		Expected: []sql.Row{{sql.MustJSON(`[3, 1, 2, 4]`)}},
+	},
+	{
+		Query:    `SELECT JSON_EXTRACT('[{"a": 1}, {"a": 2}, {"b": 3}]', '$[*].a')`,
+		Expected: []sql.Row{{sql.MustJSON(`[1, 2]`)}},
+	},
+	{
+		Query:    `SELECT JSON_EXTRACT('{"a": {"b": 1}, "c": [{"b": 2}]}', '$**.b')`,
+		Expected: []sql.Row{{sql.MustJSON(`[1, 2]`)}},
+	},
+	{
+		Query:    `SELECT JSON_EXTRACT('{"aa": 1, "b": 2}', '$.*'), JSON_EXTRACT('{"aa": {"c": 1}, "b": {"c": 2}}', '$**.c')`,
+		Expected: []sql.Row{{sql.MustJSON(`[2, 1]`), sql.MustJSON(`[2, 1]`)}},
+	},
+	{
+		Query:    `SELECT JSON_EXTRACT('{"a": 1}', '$.b'), JSON_EXTRACT('{"a": 1}', '$.b', '$.c')`,
+		Expected: []sql.Row{{nil, nil}},
+	},
+	{
+		Query:    `SELECT json_unquote(json_extract('{"hi":"there"}', '$.nope'))`,
+		Expected: []sql.Row{{nil}},
+	},
 	{
 		Query:    `SELECT CONNECTION_ID()`,
 		Expected: []sql.Row{{uint32(1)}},
@@ -3348,14 +3805,18 @@ var QueryTests = []QueryTest{
 		Query: `SHOW CREATE TABLE myview`,
 		Expected: []sql.Row{{
 			"myview",
-			"CREATE VIEW `myview` AS SELECT * FROM mytable",
+			"CREATE ALGORITHM=UNDEFINED DEFINER=`root`@`localhost` SQL SECURITY DEFINER VIEW `myview` AS SELECT * FROM mytable",
+			"utf8mb4",
+			"utf8mb4_0900_bin",
 		}},
 	},
 	{
 		Query: `SHOW CREATE VIEW myview`,
 		Expected: []sql.Row{{
 			"myview",
-			"CREATE VIEW `myview` AS SELECT * FROM mytable",
+			"CREATE ALGORITHM=UNDEFINED DEFINER=`root`@`localhost` SQL SECURITY DEFINER VIEW `myview` AS SELECT * FROM mytable",
+			"utf8mb4",
+			"utf8mb4_0900_bin",
 		}},
 	},
 	{
@@ -3574,6 +4035,15 @@ var QueryTests = []QueryTest{
 	},
 	{
 		Query: `SHOW COLLATION LIKE 'bin%'`,
+		ExpectedColumns: sql.Schema{
+			{Name: "Collation", Type: sql.MustCreateStringWithDefaults(sqltypes.VarChar, 64)},
+			{Name: "Charset", Type: sql.MustCreateStringWithDefaults(sqltypes.VarChar, 64)},
+			{Name: "Id", Type: sql.Uint64},
+			{Name: "Default", Type: sql.MustCreateStringWithDefaults(sqltypes.VarChar, 3)},
+			{Name: "Compiled", Type: sql.MustCreateStringWithDefaults(sqltypes.VarChar, 3)},
+			{Name: "Sortlen", Type: sql.Uint32},
+			{Name: "Pad_attribute", Type: sql.MustCreateStringWithDefaults(sqltypes.VarChar, 9)},
+		},
 		Expected: []sql.Row{
 			{
 				sql.Collation_binary.String(),
@@ -3586,6 +4056,12 @@ var QueryTests = []QueryTest{
 			},
 		},
 	},
+	{
+		Query: "SHOW COLLATION WHERE Charset = 'latin1' AND Sortlen > 1",
+		Expected: []sql.Row{
+			{"latin1_german2_ci", "latin1", int64(31), "", "Yes", int64(2), "PAD SPACE"},
+		},
+	},
 	{
 		Query:    `SHOW COLLATION WHERE charset = 'foo'`,
 		Expected: nil,
@@ -3605,19 +4081,27 @@ var QueryTests = []QueryTest{
 		},
 	},
 	{
-		Query: "SHOW CHARSET",
+		Query: "SHOW CHARSET LIKE 'latin%'",
+		ExpectedColumns: sql.Schema{
+			{Name: "Charset", Type: sql.MustCreateStringWithDefaults(sqltypes.VarChar, 64)},
+			{Name: "Description", Type: sql.MustCreateStringWithDefaults(sqltypes.VarChar, 2048)},
+			{Name: "Default collation", Type: sql.MustCreateStringWithDefaults(sqltypes.VarChar, 64)},
+			{Name: "Maxlen", Type: sql.Uint8},
+		},
 		Expected: []sql.Row{
-			{
-				sql.CharacterSet_utf8mb4.String(),
-				sql.CharacterSet_utf8mb4.Description(),
-				sql.CharacterSet_utf8mb4.DefaultCollation().String(),
-				sql.CharacterSet_utf8mb4.MaxLength(),
-			},
+			{"latin1", "cp1252 West European", "latin1_swedish_ci", int64(1)},
+			{"latin2", "ISO 8859-2 Central European", "latin2_general_ci", int64(1)},
+			{"latin5", "ISO 8859-9 Turkish", "latin5_turkish_ci", int64(1)},
+			{"latin7", "ISO 8859-13 Baltic", "latin7_general_ci", int64(1)},
 		},
 	},
 	{
-		Query: "SHOW CHARACTER SET",
+		Query: "SHOW CHARACTER SET WHERE Maxlen = 4",
 		Expected: []sql.Row{
+			{"gb18030", "China National Standard GB18030", "gb18030_chinese_ci", int64(4)},
+			{"utf16", "UTF-16 Unicode", "utf16_general_ci", int64(4)},
+			{"utf16le", "UTF-16LE Unicode", "utf16le_general_ci", int64(4)},
+			{"utf32", "UTF-32 Unicode", "utf32_general_ci", int64(4)},
 			{
 				sql.CharacterSet_utf8mb4.String(),
 				sql.CharacterSet_utf8mb4.Description(),
@@ -3629,6 +4113,7 @@ var QueryTests = []QueryTest{
 	{
 		Query: "SHOW CHARSET LIKE 'utf8%'",
 		Expected: []sql.Row{
+			{"utf8mb3", "UTF-8 Unicode", "utf8mb3_general_ci", int64(3)},
 			{
 				sql.CharacterSet_utf8mb4.String(),
 				sql.CharacterSet_utf8mb4.Description(),
@@ -3639,7 +4124,11 @@ var QueryTests = []QueryTest{
 	},
 	{
 		Query:    "show charset where charset='binary'",
-		Expected: nil,
+		Expected: []sql.Row{{"binary", "Binary pseudo charset", "binary", int64(1)}},
+	},
+	{
+		Query:    "SELECT COUNT(*) FROM information_schema.character_sets",
+		Expected: []sql.Row{{int64(41)}},
 	},
 	{
 		Query:    `SHOW CHARSET WHERE Charset = 'foo'`,
@@ -3819,6 +4308,27 @@ var QueryTests = []QueryTest{
 		Query:    "SELECT CONVERT('10000-12-31 23:59:59', DATETIME)",
 		Expected: []sql.Row{{nil}},
 	},
+	{
+		Query:    "SELECT CAST('2020-01-02 03:04:05.678' AS DATE), CAST('2020-01-02 03:04:05.678' AS DATETIME), CAST('2020-01-02 03:04:05.678' AS DATETIME(2))",
+		Expected: []sql.Row{{time.Date(2020, time.January, 2, 0, 0, 0, 0, time.UTC), time.Date(2020, time.January, 2, 3, 4, 6, 0, time.UTC), time.Date(2020, time.January, 2, 3, 4, 5, 680000000, time.UTC)}},
+	},
+	{
+		Query:    "SELECT CAST('03:04:05.678' AS TIME), CAST('03:04:05.678' AS TIME(1)), CAST('2020-01-02 03:04:05' AS TIME)",
+		Expected: []sql.Row{{"03:04:06", "03:04:05.700000", "03:04:05"}},
+	},
+	{
+		Query:    "SELECT CAST(20200102 AS DATE), CAST(20200102030405 AS DATETIME), CAST(30405 AS TIME)",
+		Expected: []sql.Row{{time.Date(2020, time.January, 2, 0, 0, 0, 0, time.UTC), time.Date(2020, time.January, 2, 3, 4, 5, 0, time.UTC), "03:04:05"}},
+	},
+	{
+		Query: "SELECT CAST(datetime_col AS DATE), CAST(datetime_col AS TIME), CAST(date_col AS DATETIME), CAST(date_col AS TIME) FROM datetime_table WHERE i = 1",
+		Expected: []sql.Row{{
+			time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC),
+			"12:00:00",
+			time.Date(2019, time.December, 31, 0, 0, 0, 0, time.UTC),
+			"00:00:00",
+		}},
+	},
 	{
 		Query:    "SELECT '9999-12-31 23:59:59' + INTERVAL 1 DAY",
 		Expected: []sql.Row{{nil}},
@@ -3852,7 +4362,7 @@ var QueryTests = []QueryTest{
 		Expected: []sql.Row{{int64(1)}, {int64(2)}, {int64(3)}},
 	},
 	{
-		Query:    `SELECT JSON_EXTRACT('[1, 2, 3]', '$.[0]')`,
+		Query:    `SELECT JSON_EXTRACT('[1, 2, 3]', '$[0]')`,
 		Expected: []sql.Row{{sql.MustJSON(`1`)}},
 	},
 	// TODO(andy)
@@ -3861,7 +4371,7 @@ var QueryTests = []QueryTest{
 		Expected: []sql.Row{{int32(3)}},
 	},
 	{
-		Query:    `SELECT ARRAY_LENGTH(JSON_EXTRACT('[{"i":0}, {"i":1, "y":"yyy"}, {"i":2, "x":"xxx"}]', '$.i'))`,
+		Query:    `SELECT ARRAY_LENGTH(JSON_EXTRACT('[{"i":0}, {"i":1, "y":"yyy"}, {"i":2, "x":"xxx"}]', '$[*].i'))`,
 		Expected: []sql.Row{{int32(3)}},
 	},
 	{
@@ -3870,11 +4380,11 @@ var QueryTests = []QueryTest{
 	},
 	{
 		Query:    `SELECT GREATEST(1, 2, "3", 4)`,
-		Expected: []sql.Row{{float64(4)}},
+		Expected: []sql.Row{{"4"}},
 	},
 	{
 		Query:    `SELECT GREATEST(1, 2, "9", "foo999")`,
-		Expected: []sql.Row{{float64(9)}},
+		Expected: []sql.Row{{"foo999"}},
 	},
 	{
 		Query:    `SELECT GREATEST("aaa", "bbb", "ccc")`,
@@ -3882,7 +4392,23 @@ var QueryTests = []QueryTest{
 	},
 	{
 		Query:    `SELECT GREATEST(i, s) FROM mytable`,
-		Expected: []sql.Row{{float64(1)}, {float64(2)}, {float64(3)}},
+		Expected: []sql.Row{{"first row"}, {"second row"}, {"third row"}},
+	},
+	{
+		Query:    `SELECT GREATEST(1.5, 2, 0.25e1)`,
+		Expected: []sql.Row{{float64(2.5)}},
+	},
+	{
+		Query:    `SELECT GREATEST(1, CAST(2.5 AS DECIMAL(3,1)), CAST(2.25 AS DECIMAL(4,2)))`,
+		Expected: []sql.Row{{"2.50"}},
+	},
+	{
+		Query:    `SELECT GREATEST(10, '9')`,
+		Expected: []sql.Row{{"9"}},
+	},
+	{
+		Query:    `SELECT GREATEST(1, NULL, 2)`,
+		Expected: []sql.Row{{nil}},
 	},
 	{
 		Query:    "select abs(-i) from mytable order by 1",
@@ -3952,6 +4478,18 @@ var QueryTests = []QueryTest{
 		Query:    "select log(3, i) from mytable order by i",
 		Expected: []sql.Row{{0.0}, {0.6309297535714575}, {1.0}},
 	},
+	{
+		Query:    "select log(0), log(-1), ln(0), log2(-2), log10(0)",
+		Expected: []sql.Row{{nil, nil, nil, nil, nil}},
+	},
+	{
+		Query:    "select log(2, 8), log(1, 8), log(-2, 8)",
+		Expected: []sql.Row{{3.0, nil, nil}},
+	},
+	{
+		Query:    "select exp(0), exp(1000), exp(null)",
+		Expected: []sql.Row{{1.0, nil, nil}},
+	},
 	{
 		Query: "select lower(s) from mytable order by i",
 		Expected: []sql.Row{
@@ -4018,11 +4556,11 @@ var QueryTests = []QueryTest{
 	},
 	{
 		Query:    `SELECT LEAST(1, 2, "3", 4)`,
-		Expected: []sql.Row{{float64(1)}},
+		Expected: []sql.Row{{"1"}},
 	},
 	{
 		Query:    `SELECT LEAST(1, 2, "9", "foo999")`,
-		Expected: []sql.Row{{float64(1)}},
+		Expected: []sql.Row{{"1"}},
 	},
 	{
 		Query:    `SELECT LEAST("aaa", "bbb", "ccc")`,
@@ -4030,7 +4568,15 @@ var QueryTests = []QueryTest{
 	},
 	{
 		Query:    `SELECT LEAST(i, s) FROM mytable`,
-		Expected: []sql.Row{{float64(1)}, {float64(2)}, {float64(3)}},
+		Expected: []sql.Row{{"1"}, {"2"}, {"3"}},
+	},
+	{
+		Query:    `SELECT LEAST(1.5, 2, 0.25e1)`,
+		Expected: []sql.Row{{float64(1.5)}},
+	},
+	{
+		Query:    `SELECT LEAST(2, NULL, 1)`,
+		Expected: []sql.Row{{nil}},
 	},
 	{
 		Query:    `SELECT LEAST(CAST("1920-02-03 07:41:11" AS DATETIME), CAST("1980-06-22 14:32:56" AS DATETIME))`,
@@ -4223,19 +4769,43 @@ var QueryTests = []QueryTest{
 		Expected: []sql.Row{{"mydb", "mytable", "TABLE"}},
 	},
 	{
-		Query: "SELECT REGEXP_LIKE('testing', 'TESTING');",
+		Query: "SELECT REGEXP_LIKE('testing', 'TESTING' COLLATE utf8mb4_0900_ai_ci);",
 		Expected: []sql.Row{
 			{1},
 		},
 	},
 	{
-		Query: "SELECT REGEXP_LIKE('testing', 'TESTING') FROM mytable;",
+		Query: "SELECT REGEXP_LIKE('testing' COLLATE utf8mb4_0900_ai_ci, 'TESTING') FROM mytable;",
 		Expected: []sql.Row{
 			{1},
 			{1},
 			{1},
 		},
 	},
+	{
+		Query:    "SELECT REGEXP_LIKE('testing', 'TESTING'), REGEXP_LIKE('testing', 'TESTING', 'i'), REGEXP_LIKE('testing' COLLATE utf8mb4_0900_ai_ci, 'TESTING', 'c')",
+		Expected: []sql.Row{{0, 1, 0}},
+	},
+	{
+		Query:    "SELECT 'testing' REGEXP 'TESTING', 'testing' COLLATE utf8mb4_0900_ai_ci REGEXP 'TESTING'",
+		Expected: []sql.Row{{false, true}},
+	},
+	{
+		Query:    "SELECT 'café' LIKE 'cafe%' COLLATE utf8mb4_0900_ai_ci, 'café' COLLATE utf8mb4_0900_ai_ci LIKE 'CAFE%', 'café' LIKE 'cafe%'",
+		Expected: []sql.Row{{true, true, false}},
+	},
+	{
+		Query:    "SELECT REGEXP_LIKE('a\nb', 'a.b'), REGEXP_LIKE('a\nb', 'a.b', 'n'), REGEXP_LIKE('a\nb', '^b$'), REGEXP_LIKE('a\nb', '^b$', 'm'), REGEXP_LIKE('a\nb', '^b$', 'mu')",
+		Expected: []sql.Row{{0, 1, 0, 1, 1}},
+	},
+	{
+		Query:    "SELECT i, REGEXP_LIKE(s, CONCAT('^', SUBSTRING(s, 1, 1), 'i')) FROM mytable ORDER BY i",
+		Expected: []sql.Row{{1, 1}, {2, 0}, {3, 0}},
+	},
+	{
+		Query:    "SELECT REGEXP_LIKE('foo', NULL), REGEXP_LIKE(NULL, 'foo'), REGEXP_LIKE('foo', 'foo', NULL)",
+		Expected: []sql.Row{{nil, nil, nil}},
+	},
 	{
 		Query: "SELECT i, s, REGEXP_LIKE(s, '[a-z]+d row') FROM mytable;",
 		Expected: []sql.Row{
@@ -4284,6 +4854,30 @@ var QueryTests = []QueryTest{
 			{"XXXXX XXX"},
 		},
 	},
+	{
+		Query:    `SELECT REGEXP_REPLACE("first second third", "([a-z]+) ([a-z]+)", "$2 $1")`,
+		Expected: []sql.Row{{"second first third"}},
+	},
+	{
+		Query:    `SELECT REGEXP_REPLACE("a1 b2 c3", "([a-z])([0-9])", "$2$1", 4, 2)`,
+		Expected: []sql.Row{{"a1 b2 3c"}},
+	},
+	{
+		Query:    `SELECT REGEXP_SUBSTR("abc def ghi", "[a-z]+"), REGEXP_SUBSTR("abc def ghi", "[a-z]+", 2), REGEXP_SUBSTR("abc def ghi", "[a-z]+", 1, 3)`,
+		Expected: []sql.Row{{"abc", "bc", "ghi"}},
+	},
+	{
+		Query:    `SELECT REGEXP_SUBSTR("abc def", "[a-z]+", 1, 3), REGEXP_SUBSTR("ABC", "b", 1, 1, "c"), REGEXP_SUBSTR("ABC", "b", 1, 1, "i")`,
+		Expected: []sql.Row{{nil, nil, "B"}},
+	},
+	{
+		Query: `SELECT REGEXP_SUBSTR(s, "[a-z]+", 1, 2) from mytable`,
+		Expected: []sql.Row{
+			{"row"},
+			{"row"},
+			{"row"},
+		},
+	},
 
 	{
 		Query: "SELECT * FROM newlinetable WHERE s LIKE '%text%'",
@@ -5671,6 +6265,58 @@ var QueryTests = []QueryTest{
 		Query:    `SELECT JSON_CONTAINS('{"a": 1, "b": 2, "c": {"d": 4}}', '{"d": 4}', '$.c')`,
 		Expected: []sql.Row{{true}},
 	},
+	{
+		Query:    `SELECT JSON_CONTAINS_PATH('{"a": 1, "b": 2, "c": {"d": 4}}', 'one', '$.a', '$.e')`,
+		Expected: []sql.Row{{true}},
+	},
+	{
+		Query:    `SELECT JSON_CONTAINS_PATH('{"a": 1, "b": 2, "c": {"d": 4}}', 'all', '$.a', '$.e')`,
+		Expected: []sql.Row{{false}},
+	},
+	{
+		Query:    `SELECT JSON_CONTAINS_PATH('{"a": 1, "b": 2, "c": {"d": 4}}', 'one', '$.c.d')`,
+		Expected: []sql.Row{{true}},
+	},
+	{
+		Query:    `SELECT JSON_CONTAINS_PATH(NULL, 'one', '$.a')`,
+		Expected: []sql.Row{{nil}},
+	},
+	{
+		Query:    `SELECT JSON_SEARCH('["abc", [{"k": "10"}, "def"], {"x": "abc"}, {"y": "bcd"}]', 'one', 'abc')`,
+		Expected: []sql.Row{{sql.MustJSON(`"$[0]"`)}},
+	},
+	{
+		Query:    `SELECT JSON_SEARCH('["abc", [{"k": "10"}, "def"], {"x": "abc"}, {"y": "bcd"}]', 'all', 'abc')`,
+		Expected: []sql.Row{{sql.MustJSON(`["$[0]", "$[2].x"]`)}},
+	},
+	{
+		Query:    `SELECT JSON_SEARCH('["abc", [{"k": "10"}, "def"], {"x": "abc"}, {"y": "bcd"}]', 'all', '%b%', NULL, '$[3]')`,
+		Expected: []sql.Row{{sql.MustJSON(`"$[3].y"`)}},
+	},
+	{
+		Query:    `SELECT JSON_SEARCH('["abc", [{"k": "10"}, "def"], {"x": "abc"}, {"y": "bcd"}]', 'all', 'ghi')`,
+		Expected: []sql.Row{{nil}},
+	},
+	{
+		Query:    `SELECT JSON_SEARCH('[{"a":"x"},{"a":"x"}]', 'all', 'x', NULL, '$[*].a'), JSON_SEARCH('{"a b":"x"}', 'all', 'x', NULL, '$."a b"')`,
+		Expected: []sql.Row{{sql.MustJSON(`["$[0].a", "$[1].a"]`), sql.MustJSON(`"$.\"a b\""`)}},
+	},
+	{
+		Query:    `SELECT JSON_PRETTY('{"bb": 1, "a": [2, {}], "c": {"d": null}}')`,
+		Expected: []sql.Row{{"{\n  \"a\": [\n    2,\n    {}\n  ],\n  \"c\": {\n    \"d\": null\n  },\n  \"bb\": 1\n}"}},
+	},
+	{
+		Query:    `SELECT JSON_PRETTY('"abc"'), JSON_PRETTY('[]'), JSON_PRETTY(NULL)`,
+		Expected: []sql.Row{{`"abc"`, "[]", nil}},
+	},
+	{
+		Query:    `SELECT JSON_STORAGE_SIZE('[100, "sakila", [1, 3, 5], 425.05]')`,
+		Expected: []sql.Row{{int64(45)}},
+	},
+	{
+		Query:    `SELECT JSON_STORAGE_SIZE('{"a": 1000, "b": "wxyz", "c": "[1, 3, 5, 7]"}'), JSON_STORAGE_SIZE(NULL)`,
+		Expected: []sql.Row{{int64(47), nil}},
+	},
 	{
 		Query: "select one_pk.pk, one_pk.c1 from one_pk join two_pk on one_pk.c1 = two_pk.c1 order by two_pk.c1",
 		Expected: []sql.Row{
@@ -6269,11 +6915,6 @@ var BrokenQueries = []QueryTest{
 			},
 		},
 	},
-	// https://github.com/dolthub/go-mysql-server/issues/600
-	{
-		Query:    `SELECT json_unquote(json_extract('{"hi":"there"}', '$.nope'))`,
-		Expected: []sql.Row{{nil}}, // currently returns string "null"
-	},
 	// Null-safe and type conversion tuple comparison is not correctly
 	// implemented yet.
 	{
@@ -6517,6 +7158,30 @@ var InfoSchemaQueries = []QueryTest{
 			{"s", "varchar(20)", "utf8mb4_0900_bin", "NO", "UNI", "", "", "", "column s"},
 		},
 	},
+	{
+		Query: `SHOW FULL COLUMNS FROM mytable LIKE 's'`,
+		Expected: []sql.Row{
+			{"s", "varchar(20)", "utf8mb4_0900_bin", "NO", "UNI", "", "", "", "column s"},
+		},
+	},
+	{
+		Query: `DESCRIBE mytable s`,
+		Expected: []sql.Row{
+			{"s", "varchar(20)", "NO", "UNI", "", ""},
+		},
+	},
+	{
+		Query: `DESC mytable 'i%'`,
+		Expected: []sql.Row{
+			{"i", "bigint", "NO", "PRI", "", ""},
+		},
+	},
+	{
+		Query: "EXPLAIN mydb.mytable `s`",
+		Expected: []sql.Row{
+			{"s", "varchar(20)", "NO", "UNI", "", ""},
+		},
+	},
 	{
 		Query: "SHOW TABLES WHERE `Table` = 'mytable'",
 		Expected: []sql.Row{
@@ -6730,6 +7395,14 @@ var InfoSchemaQueries = []QueryTest{
 	},
 	{
 		Query: "SHOW ENGINES",
+		ExpectedColumns: sql.Schema{
+			{Name: "Engine", Type: sql.MustCreateStringWithDefaults(sqltypes.VarChar, 64)},
+			{Name: "Support", Type: sql.MustCreateStringWithDefaults(sqltypes.VarChar, 8)},
+			{Name: "Comment", Type: sql.MustCreateStringWithDefaults(sqltypes.VarChar, 80)},
+			{Name: "Transactions", Type: sql.MustCreateStringWithDefaults(sqltypes.VarChar, 3)},
+			{Name: "XA", Type: sql.MustCreateStringWithDefaults(sqltypes.VarChar, 3)},
+			{Name: "Savepoints", Type: sql.MustCreateStringWithDefaults(sqltypes.VarChar, 3)},
+		},
 		Expected: []sql.Row{
 			{"InnoDB", "DEFAULT", "Supports transactions, row-level locking, and foreign keys", "YES", "YES", "YES"},
 		},
@@ -7172,6 +7845,14 @@ var errorQueries = []QueryErrorTest{
 		Query:       `SELECT JSON_OBJECT(1, 2) FROM dual`,
 		ExpectedErr: sql.ErrInvalidType,
 	},
+	{
+		Query:       `SELECT CONVERT(1, DECIMAL(5, 6))`,
+		ExpectedErr: sql.ErrInvalidType,
+	},
+	{
+		Query:       `SELECT CONVERT('a' USING notacharset)`,
+		ExpectedErr: sql.ErrCharacterSetNotSupported,
+	},
 	{
 		Query:          `select JSON_EXTRACT('{"id":"abc"}', '$.id')-1;`,
 		ExpectedErrStr: `error: 'abc' is not a valid value for 'DOUBLE'`,
@@ -7184,15 +7865,6 @@ var errorQueries = []QueryErrorTest{
 		Query:       `alter table mytable add primary key (s)`,
 		ExpectedErr: sql.ErrMultiplePrimaryKeysDefined,
 	},
-	// TODO: The following two queries should work. See https://github.com/dolthub/go-mysql-server/issues/542.
-	{
-		Query:       "SELECT SUM(i), i FROM mytable GROUP BY i ORDER BY 1+SUM(i) ASC",
-		ExpectedErr: analyzer.ErrAggregationUnsupported,
-	},
-	{
-		Query:       "SELECT SUM(i) as sum, i FROM mytable GROUP BY i ORDER BY 1+SUM(i) ASC",
-		ExpectedErr: analyzer.ErrAggregationUnsupported,
-	},
 	{
 		Query:       "select ((1, 2)) from dual",
 		ExpectedErr: sql.ErrInvalidOperandColumns,
@@ -7233,6 +7905,10 @@ var errorQueries = []QueryErrorTest{
 		Query:       "select (((1,2),3)) = (((1))) from dual",
 		ExpectedErr: sql.ErrInvalidOperandColumns,
 	},
+	{
+		Query:       "select * from dual",
+		ExpectedErr: sql.ErrNoTablesUsed,
+	},
 	{
 		Query:       "select (((1,2),3)) = (((1,2),3),(4,5)) from dual",
 		ExpectedErr: sql.ErrInvalidOperandColumns,
@@ -7249,6 +7925,30 @@ var errorQueries = []QueryErrorTest{
 		Query:       `SELECT pk, (SELECT concat(pk, pk) FROM one_pk WHERE pk < opk.pk ORDER BY 1 DESC LIMIT 1) as strpk FROM one_pk opk where strpk > "0" ORDER BY 2`,
 		ExpectedErr: sql.ErrColumnNotFound,
 	},
+	{
+		Query:       "SELECT REGEXP_LIKE(s, '(') FROM mytable",
+		ExpectedErr: expression.ErrInvalidRegexp,
+	},
+	{
+		Query:       "SELECT REGEXP_LIKE('foo', 'foo', 'x')",
+		ExpectedErr: sql.ErrInvalidArgument,
+	},
+	{
+		Query:       `SELECT JSON_EXTRACT('{"a": 1}', '$.a[')`,
+		ExpectedErr: sql.ErrInvalidJSONPath,
+	},
+	{
+		Query:       `SELECT JSON_EXTRACT('{"a": 1}', 'a')`,
+		ExpectedErr: sql.ErrInvalidJSONPath,
+	},
+	{
+		Query:       `SHOW GRANTS FOR 'bob'@'localhost'`,
+		ExpectedErr: sql.ErrNoSuchGrant,
+	},
+	{
+		Query:       `SHOW GRANTS FOR root@localhost`,
+		ExpectedErr: sql.ErrNoSuchGrant,
+	},
 }
 
 // WriteQueryTest is a query test for INSERT, UPDATE, etc. statements. It has a query to run and a select query to
@@ -7350,8 +8050,8 @@ var ViewTests = []QueryTest{
 	{
 		Query: "select * from information_schema.views where table_schema = 'mydb' order by table_name",
 		Expected: []sql.Row{
-			sql.NewRow("def", "mydb", "myview", "SELECT * FROM mytable", "NONE", "YES", "", "DEFINER", "utf8mb4", "utf8mb4_0900_bin"),
-			sql.NewRow("def", "mydb", "myview2", "SELECT * FROM myview WHERE i = 1", "NONE", "YES", "", "DEFINER", "utf8mb4", "utf8mb4_0900_bin"),
+			sql.NewRow("def", "mydb", "myview", "SELECT * FROM mytable", "NONE", "YES", "root@localhost", "DEFINER", "utf8mb4", "utf8mb4_0900_bin"),
+			sql.NewRow("def", "mydb", "myview2", "SELECT * FROM myview WHERE i = 1", "NONE", "YES", "root@localhost", "DEFINER", "utf8mb4", "utf8mb4_0900_bin"),
 		},
 	},
 	{
@@ -7442,9 +8142,9 @@ var VersionedViewTests = []QueryTest{
 	{
 		Query: "select * from information_schema.views where table_schema = 'mydb'",
 		Expected: []sql.Row{
-			sql.NewRow("def", "mydb", "myview", "SELECT * FROM mytable", "NONE", "YES", "", "DEFINER", "utf8mb4", "utf8mb4_0900_bin"),
-			sql.NewRow("def", "mydb", "myview1", "SELECT * FROM myhistorytable", "NONE", "YES", "", "DEFINER", "utf8mb4", "utf8mb4_0900_bin"),
-			sql.NewRow("def", "mydb", "myview2", "SELECT * FROM myview1 WHERE i = 1", "NONE", "YES", "", "DEFINER", "utf8mb4", "utf8mb4_0900_bin"),
+			sql.NewRow("def", "mydb", "myview", "SELECT * FROM mytable", "NONE", "YES", "root@localhost", "DEFINER", "utf8mb4", "utf8mb4_0900_bin"),
+			sql.NewRow("def", "mydb", "myview1", "SELECT * FROM myhistorytable", "NONE", "YES", "root@localhost", "DEFINER", "utf8mb4", "utf8mb4_0900_bin"),
+			sql.NewRow("def", "mydb", "myview2", "SELECT * FROM myview1 WHERE i = 1", "NONE", "YES", "root@localhost", "DEFINER", "utf8mb4", "utf8mb4_0900_bin"),
 		},
 	},
 	{
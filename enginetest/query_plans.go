@@ -53,6 +53,31 @@ var PlanTests = []QueryPlanTest{
 			"     └─ IndexedTableAccess(one_pk_two_idx on [one_pk_two_idx.v1,one_pk_two_idx.v2])\n" +
 			"",
 	},
+	{
+		Query: `SELECT * FROM one_pk_two_idx WHERE NOT (v1 >= 2 OR v2 IS NULL)`,
+		ExpectedPlan: "Filter((one_pk_two_idx.v1 < 2) AND (NOT(one_pk_two_idx.v2 IS NULL)))\n" +
+			" └─ Projected table access on [pk v1 v2]\n" +
+			"     └─ IndexedTableAccess(one_pk_two_idx on [one_pk_two_idx.v1,one_pk_two_idx.v2])\n" +
+			"",
+	},
+	{
+		Query: `SELECT * FROM mytable WHERE NOT (i < 2 AND s = 'first row')`,
+		ExpectedPlan: "Filter((mytable.i >= 2) OR (NOT((mytable.s = \"first row\"))))\n" +
+			" └─ Projected table access on [i s]\n" +
+			"     └─ Table(mytable)\n" +
+			"",
+	},
+	{
+		Query: `SELECT * FROM mytable a JOIN othertable b ON a.i = b.i2 WHERE NOT (a.i = 1 OR b.s2 = 'first')`,
+		ExpectedPlan: "IndexedJoin(a.i = b.i2)\n" +
+			" ├─ Filter(NOT((a.i = 1)))\n" +
+			" │   └─ TableAlias(a)\n" +
+			" │       └─ IndexedTableAccess(mytable on [mytable.i])\n" +
+			" └─ Filter(NOT((b.s2 = \"first\")))\n" +
+			"     └─ TableAlias(b)\n" +
+			"         └─ IndexedTableAccess(othertable on [othertable.i2])\n" +
+			"",
+	},
 	{
 		Query: `SELECT * FROM one_pk_two_idx WHERE v1 IN (1, 2) AND v2 <= 2`,
 		ExpectedPlan: "Filter((one_pk_two_idx.v1 HASH IN (1, 2)) AND (one_pk_two_idx.v2 <= 2))\n" +
@@ -218,6 +243,47 @@ var PlanTests = []QueryPlanTest{
 			"             └─ IndexedTableAccess(othertable on [othertable.i2])\n" +
 			"",
 	},
+	{
+		Query: `SELECT DISTINCT i FROM mytable ORDER BY i`,
+		ExpectedPlan: "OrderedDistinct\n" +
+			" └─ Sort(mytable.i ASC)\n" +
+			"     └─ Project(mytable.i)\n" +
+			"         └─ Projected table access on [i]\n" +
+			"             └─ Table(mytable)\n" +
+			"",
+	},
+	{
+		Query: `SELECT pk1, SUM(c1) FROM (SELECT * FROM two_pk ORDER BY pk1, pk2) sq GROUP BY pk1`,
+		ExpectedPlan: "Project(sq.pk1, SUM(sq.c1) as SUM(c1))\n" +
+			" └─ OrderedGroupBy\n" +
+			"     ├─ SelectedExprs(sq.pk1, SUM(sq.c1))\n" +
+			"     ├─ Grouping(sq.pk1)\n" +
+			"     └─ SubqueryAlias(sq)\n" +
+			"         └─ Sort(two_pk.pk1 ASC, two_pk.pk2 ASC)\n" +
+			"             └─ Projected table access on [pk1 pk2 c1 c2 c3 c4 c5]\n" +
+			"                 └─ Table(two_pk)\n" +
+			"",
+	},
+	{
+		Query: `SELECT pk2, SUM(c1) FROM (SELECT * FROM two_pk ORDER BY pk1, pk2) sq GROUP BY pk2`,
+		ExpectedPlan: "Project(sq.pk2, SUM(sq.c1) as SUM(c1))\n" +
+			" └─ GroupBy\n" +
+			"     ├─ SelectedExprs(sq.pk2, SUM(sq.c1))\n" +
+			"     ├─ Grouping(sq.pk2)\n" +
+			"     └─ SubqueryAlias(sq)\n" +
+			"         └─ Sort(two_pk.pk1 ASC, two_pk.pk2 ASC)\n" +
+			"             └─ Projected table access on [pk1 pk2 c1 c2 c3 c4 c5]\n" +
+			"                 └─ Table(two_pk)\n" +
+			"",
+	},
+	{
+		Query: `SELECT DISTINCT i, s FROM mytable ORDER BY i`,
+		ExpectedPlan: "Sort(mytable.i ASC)\n" +
+			" └─ Distinct\n" +
+			"     └─ Projected table access on [i s]\n" +
+			"         └─ Table(mytable)\n" +
+			"",
+	},
 	{
 		Query: `SELECT sub.i, sub.i2, sub.s2, ot.i2, ot.s2 FROM (SELECT i, i2, s2 FROM mytable INNER JOIN othertable ON i = i2) sub INNER JOIN othertable ot ON sub.i = ot.i2`,
 		ExpectedPlan: "Project(sub.i, sub.i2, sub.s2, ot.i2, ot.s2)\n" +
@@ -1869,6 +1935,36 @@ var PlanTests = []QueryPlanTest{
 			"     └─ IndexedTableAccess(invert_pk on [invert_pk.y,invert_pk.z,invert_pk.x])\n" +
 			"",
 	},
+	{
+		Query: `SELECT pk FROM one_pk WHERE NOT EXISTS (SELECT * FROM two_pk WHERE two_pk.c1 = one_pk.c1) ORDER BY pk`,
+		ExpectedPlan: "Sort(one_pk.pk ASC)\n" +
+			" └─ Project(one_pk.pk)\n" +
+			"     └─ AntiJoin((two_pk.c1 = one_pk.c1), left keys: (one_pk.c1), right keys: (two_pk.c1))\n" +
+			"         ├─ Table(one_pk)\n" +
+			"         └─ Projected table access on [pk1 pk2 c1 c2 c3 c4 c5]\n" +
+			"             └─ Table(two_pk)\n" +
+			"",
+	},
+	{
+		Query: `SELECT pk FROM one_pk WHERE NOT EXISTS (SELECT 1 FROM two_pk WHERE two_pk.c1 = one_pk.c1 AND two_pk.c2 > one_pk.pk) ORDER BY pk`,
+		ExpectedPlan: "Sort(one_pk.pk ASC)\n" +
+			" └─ Project(one_pk.pk)\n" +
+			"     └─ AntiJoin(((two_pk.c1 = one_pk.c1) AND (two_pk.c2 > one_pk.pk)), left keys: (one_pk.c1), right keys: (two_pk.c1))\n" +
+			"         ├─ Table(one_pk)\n" +
+			"         └─ Projected table access on [c1 c2]\n" +
+			"             └─ Table(two_pk)\n" +
+			"",
+	},
+	{
+		Query: `SELECT * FROM mytable WHERE i NOT IN (SELECT i2 FROM niltable) ORDER BY i`,
+		ExpectedPlan: "Sort(mytable.i ASC)\n" +
+			" └─ Filter(NOT((mytable.i IN (Project(niltable.i2)\n" +
+			"     └─ Projected table access on [i2]\n" +
+			"         └─ Table(niltable)\n" +
+			"    ))))\n" +
+			"     └─ Table(mytable)\n" +
+			"",
+	},
 }
 
 // Queries where the query planner produces a correct (results) but suboptimal plan.
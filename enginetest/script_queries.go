@@ -15,11 +15,16 @@
 package enginetest
 
 import (
+	"math"
+	"strings"
+
+	"github.com/dolthub/vitess/go/mysql"
 	"gopkg.in/src-d/go-errors.v1"
 
 	"github.com/dolthub/go-mysql-server/sql/analyzer"
 
 	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/parse"
 	"github.com/dolthub/go-mysql-server/sql/plan"
 )
 
@@ -788,6 +793,11 @@ var ScriptTests = []ScriptTest{
 
 			"create table nulls(pk int)",
 			"INSERT INTO nulls VALUES (NULL)",
+
+			"create table names (name varchar(20) collate utf8mb4_general_ci)",
+			"INSERT INTO names VALUES ('bob'), ('Alice'), ('alice'), ('Carol'), ('BOB'), (NULL)",
+			"create table names_bin (name varchar(20) collate utf8mb4_bin)",
+			"INSERT INTO names_bin VALUES ('bob'), ('Alice'), ('alice'), ('Carol'), ('bob')",
 		},
 		Assertions: []ScriptTestAssertion{
 			{
@@ -802,13 +812,21 @@ var ScriptTests = []ScriptTest{
 				Query:    `SELECT group_concat(DISTINCT pk ORDER BY pk SEPARATOR '-') FROM x;`,
 				Expected: []sql.Row{{"1-2-3-4"}},
 			},
+			{
+				Query:    `SELECT group_concat(DISTINCT pk ORDER BY pk SEPARATOR '') FROM x;`,
+				Expected: []sql.Row{{"1234"}},
+			},
+			{
+				Query:    `SELECT group_concat(pk ORDER BY pk SEPARATOR ''), group_concat(pk ORDER BY pk), 'SEPARATOR ''' FROM x;`,
+				Expected: []sql.Row{{"1234", "1,2,3,4", "SEPARATOR '"}},
+			},
 			{
 				Query:    `SELECT group_concat(attribute ORDER BY attribute) FROM t group by o_id order by o_id asc`,
 				Expected: []sql.Row{{"color,fabric"}, {"color,shape"}},
 			},
 			{
-				Query:    `SELECT group_concat(DISTINCT attribute ORDER BY value DESC SEPARATOR ';') FROM t group by o_id order by o_id asc`,
-				Expected: []sql.Row{{"fabric;color"}, {"shape;color"}},
+				Query:       `SELECT group_concat(DISTINCT attribute ORDER BY value DESC SEPARATOR ';') FROM t group by o_id order by o_id asc`,
+				ExpectedErr: sql.ErrGroupConcatDistinctOrderBy,
 			},
 			{
 				Query:    `SELECT group_concat(DISTINCT attribute ORDER BY attribute) FROM t`,
@@ -851,13 +869,160 @@ var ScriptTests = []ScriptTest{
 				Expected: []sql.Row{{"color,fabric"}},
 			},
 			{
-				Query:    `SELECT group_concat(DISTINCT attribute ORDER BY value DESC SEPARATOR ';') FROM t group by o_id order by o_id asc`,
-				Expected: []sql.Row{{"fabric;color"}, {"shape;color"}},
+				Query:       `SELECT group_concat(DISTINCT attribute ORDER BY value DESC SEPARATOR ';') FROM t group by o_id order by o_id asc`,
+				ExpectedErr: sql.ErrGroupConcatDistinctOrderBy,
 			},
 			{
 				Query:    `SELECT group_concat(o_id) FROM t WHERE attribute='color'`,
 				Expected: []sql.Row{{"2,3"}},
 			},
+			{
+				Query:    `SELECT group_concat(DISTINCT name ORDER BY name SEPARATOR '; ') FROM names`,
+				Expected: []sql.Row{{"Alice; Carol; bob"}},
+			},
+			{
+				Query:    `SELECT group_concat(DISTINCT name ORDER BY name DESC SEPARATOR '; ') FROM names_bin`,
+				Expected: []sql.Row{{"bob; alice; Carol; Alice"}},
+			},
+			{
+				Query:    `SELECT group_concat(o_id, attribute ORDER BY o_id DESC, attribute SEPARATOR ' ') FROM t`,
+				Expected: []sql.Row{{"3color 3shape 2color 2fabric"}},
+			},
+			{
+				Query:    `SELECT group_concat(DISTINCT attribute, '!' ORDER BY attribute DESC) FROM t`,
+				Expected: []sql.Row{{"shape!,fabric!,color!"}},
+			},
+			{
+				Query:    `SELECT group_concat(DISTINCT pk, '-', pk + 1 ORDER BY pk DESC SEPARATOR ', ') FROM x`,
+				Expected: []sql.Row{{"4-5, 3-4, 2-3, 1-2"}},
+			},
+			{
+				Query:    `SET group_concat_max_len = 10`,
+				Expected: []sql.Row{{}},
+			},
+			{
+				Query:           `SELECT group_concat(DISTINCT name ORDER BY name SEPARATOR '; ') FROM names`,
+				Expected:        []sql.Row{{"Alice; Car"}},
+				ExpectedWarning: 1260,
+			},
+			{
+				Query:    `SET group_concat_max_len = DEFAULT`,
+				Expected: []sql.Row{{}},
+			},
+		},
+	},
+	{
+		Name: "variance and standard deviation",
+		SetUpScript: []string{
+			"CREATE TABLE t (pk int primary key, grp int, v double)",
+			"INSERT INTO t VALUES (1, 1, 2), (2, 1, 4), (3, 1, 4), (4, 1, 4), (5, 1, 5), (6, 1, 5), (7, 1, 7), (8, 1, 9), (9, 1, NULL)",
+			"INSERT INTO t VALUES (10, 2, 1000000004), (11, 2, 1000000007), (12, 2, 1000000013), (13, 2, 1000000016)",
+			"INSERT INTO t VALUES (14, 3, 42), (15, 3, NULL), (16, 4, NULL)",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query: "SELECT grp, round(var_pop(v), 6), round(var_samp(v), 6), round(stddev_pop(v), 6), round(stddev_samp(v), 6) FROM t GROUP BY grp ORDER BY grp",
+				Expected: []sql.Row{
+					{1, 4.0, 4.571429, 2.0, 2.13809},
+					{2, 22.5, 30.0, 4.743416, 5.477226},
+					{3, 0.0, nil, 0.0, nil},
+					{4, nil, nil, nil, nil},
+				},
+			},
+			{
+				Query:    "SELECT round(variance(v), 6), round(std(v), 6), round(stddev(v), 6) FROM t WHERE grp = 1",
+				Expected: []sql.Row{{4.0, 2.0, 2.0}},
+			},
+			{
+				Query:    "SELECT var_samp(v) FROM t WHERE grp > 4",
+				Expected: []sql.Row{{nil}},
+			},
+		},
+	},
+	{
+		Name: "bitwise aggregations",
+		SetUpScript: []string{
+			"CREATE TABLE t (pk int primary key, grp int, v bigint, d double)",
+			"INSERT INTO t VALUES (1, 1, 12, 2.5), (2, 1, 10, NULL), (3, 1, NULL, 1.2), (4, 1, 14, -1.5)",
+			"INSERT INTO t VALUES (5, 2, -1, NULL), (6, 2, 6, NULL)",
+			"INSERT INTO t VALUES (7, 3, 5, 5), (8, 4, NULL, NULL)",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query: "SELECT grp, bit_and(v), bit_or(v), bit_xor(v) FROM t GROUP BY grp ORDER BY grp",
+				Expected: []sql.Row{
+					{1, uint64(8), uint64(14), uint64(8)},
+					{2, uint64(6), uint64(18446744073709551615), uint64(18446744073709551609)},
+					{3, uint64(5), uint64(5), uint64(5)},
+					{4, uint64(18446744073709551615), uint64(0), uint64(0)},
+				},
+			},
+			{
+				Query:    "SELECT bit_and(d), bit_or(d), bit_xor(d) FROM t WHERE grp = 1",
+				Expected: []sql.Row{{uint64(0), uint64(18446744073709551615), uint64(18446744073709551612)}},
+			},
+			{
+				Query:    "SELECT bit_and(v), bit_or(v), bit_xor(v) FROM t WHERE grp > 4",
+				Expected: []sql.Row{{uint64(18446744073709551615), uint64(0), uint64(0)}},
+			},
+		},
+	},
+	{
+		Name: "priority and delayed modifiers are accepted",
+		SetUpScript: []string{
+			"CREATE TABLE t (pk int primary key, v varchar(10))",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:           "INSERT DELAYED INTO t VALUES (1, 'a')",
+				Expected:        []sql.Row{{sql.NewOkResult(1)}},
+				ExpectedWarning: 3005,
+			},
+			{
+				Query:    "INSERT HIGH_PRIORITY INTO t VALUES (2, 'b')",
+				Expected: []sql.Row{{sql.NewOkResult(1)}},
+			},
+			{
+				Query:    "INSERT LOW_PRIORITY IGNORE INTO t VALUES (2, 'c'), (3, 'c')",
+				Expected: []sql.Row{{sql.NewOkResult(1)}},
+			},
+			{
+				Query:    "REPLACE LOW_PRIORITY INTO t VALUES (3, 'd')",
+				Expected: []sql.Row{{sql.NewOkResult(2)}},
+			},
+			{
+				Query:    "UPDATE LOW_PRIORITY t SET v = 'e' WHERE pk = 1",
+				Expected: []sql.Row{{newUpdateResult(1, 1)}},
+			},
+			{
+				Query:    "DELETE LOW_PRIORITY QUICK FROM t WHERE pk = 2",
+				Expected: []sql.Row{{sql.NewOkResult(1)}},
+			},
+			{
+				Query:    "SELECT HIGH_PRIORITY * FROM t ORDER BY pk",
+				Expected: []sql.Row{{1, "e"}, {3, "d"}},
+			},
+		},
+	},
+	{
+		Name:        "out of domain logarithms and exponents warn",
+		SetUpScript: []string{},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:           "SELECT LOG(0)",
+				Expected:        []sql.Row{{nil}},
+				ExpectedWarning: 3020,
+			},
+			{
+				Query:           "SELECT LOG(2, -8)",
+				Expected:        []sql.Row{{nil}},
+				ExpectedWarning: 3020,
+			},
+			{
+				Query:           "SELECT EXP(1000)",
+				Expected:        []sql.Row{{nil}},
+				ExpectedWarning: 1690,
+			},
 		},
 	},
 	{
@@ -908,6 +1073,195 @@ var ScriptTests = []ScriptTest{
 			},
 		},
 	},
+	{
+		Name: "ALTER COLUMN SET / DROP DEFAULT validates and shows the new default",
+		SetUpScript: []string{
+			"CREATE TABLE test (pk int PRIMARY KEY AUTO_INCREMENT, v1 int NOT NULL DEFAULT 3, v2 varchar(10));",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "ALTER TABLE test ALTER COLUMN v1 SET DEFAULT 5;",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "ALTER TABLE test ALTER COLUMN v2 SET DEFAULT 'abc';",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "INSERT INTO test (pk) VALUES (1);",
+				Expected: []sql.Row{{sql.NewOkResult(1)}},
+			},
+			{
+				Query:    "SELECT * FROM test;",
+				Expected: []sql.Row{{1, 5, "abc"}},
+			},
+			{
+				Query: "SHOW CREATE TABLE test;",
+				Expected: []sql.Row{{"test", "CREATE TABLE `test` (\n" +
+					"  `pk` int NOT NULL AUTO_INCREMENT,\n" +
+					"  `v1` int NOT NULL DEFAULT 5,\n" +
+					"  `v2` varchar(10) DEFAULT \"abc\",\n" +
+					"  PRIMARY KEY (`pk`)\n" +
+					") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4"}},
+			},
+			{
+				Query:       "ALTER TABLE test ALTER COLUMN v1 SET DEFAULT 'abc';",
+				ExpectedErr: sql.ErrIncompatibleDefaultType,
+			},
+			{
+				Query:       "ALTER TABLE test ALTER COLUMN pk SET DEFAULT 5;",
+				ExpectedErr: sql.ErrInvalidAutoIncrementDefault,
+			},
+			{
+				Query:    "ALTER TABLE test ALTER COLUMN v2 DROP DEFAULT;",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "INSERT INTO test (pk) VALUES (2);",
+				Expected: []sql.Row{{sql.NewOkResult(1)}},
+			},
+			{
+				Query:    "SELECT * FROM test ORDER BY pk;",
+				Expected: []sql.Row{{1, 5, "abc"}, {2, 5, nil}},
+			},
+			{
+				Query: "SHOW CREATE TABLE test;",
+				Expected: []sql.Row{{"test", "CREATE TABLE `test` (\n" +
+					"  `pk` int NOT NULL AUTO_INCREMENT,\n" +
+					"  `v1` int NOT NULL DEFAULT 5,\n" +
+					"  `v2` varchar(10),\n" +
+					"  PRIMARY KEY (`pk`)\n" +
+					") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4"}},
+			},
+		},
+	},
+	{
+		Name: "renaming tables",
+		SetUpScript: []string{
+			"CREATE TABLE parent (pk int PRIMARY KEY);",
+			"CREATE TABLE child (pk int PRIMARY KEY, p int, CONSTRAINT fk_p FOREIGN KEY (p) REFERENCES parent (pk));",
+			"CREATE TABLE other (pk int PRIMARY KEY);",
+			"INSERT INTO parent VALUES (1);",
+			"INSERT INTO other VALUES (2);",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "RENAME TABLE parent TO tmp, other TO parent, tmp TO other;",
+				Expected: []sql.Row(nil),
+			},
+			{
+				Query:    "SELECT * FROM parent;",
+				Expected: []sql.Row{{2}},
+			},
+			{
+				Query:    "SELECT * FROM other;",
+				Expected: []sql.Row{{1}},
+			},
+			{
+				Query: "SHOW CREATE TABLE child;",
+				Expected: []sql.Row{{"child", "CREATE TABLE `child` (\n" +
+					"  `pk` int NOT NULL,\n" +
+					"  `p` int,\n" +
+					"  PRIMARY KEY (`pk`),\n" +
+					"  CONSTRAINT `fk_p` FOREIGN KEY (`p`) REFERENCES `other` (`pk`)\n" +
+					") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4"}},
+			},
+			{
+				Query:       "RENAME TABLE child TO child2, missing TO other2;",
+				ExpectedErr: sql.ErrTableNotFound,
+			},
+			{
+				Query:       "RENAME TABLE child TO child2, other TO parent;",
+				ExpectedErr: sql.ErrTableAlreadyExists,
+			},
+			{
+				Query:    "SELECT count(*) FROM child;",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "RENAME TABLE mydb.child TO mydb.child2;",
+				Expected: []sql.Row(nil),
+			},
+			{
+				Query:    "SELECT count(*) FROM child2;",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:       "RENAME TABLE mydb.child2 TO foo.child2;",
+				ExpectedErr: parse.ErrUnsupportedFeature,
+			},
+		},
+	},
+	{
+		Name: "writing through views WITH CHECK OPTION",
+		SetUpScript: []string{
+			"CREATE TABLE t (pk int PRIMARY KEY, v int);",
+			"INSERT INTO t VALUES (1, 1), (2, 20);",
+			"CREATE VIEW checked AS SELECT pk, v AS val FROM t WHERE v < 10 WITH LOCAL CHECK OPTION;",
+			"CREATE VIEW unchecked AS SELECT * FROM t WHERE v < 10;",
+			"CREATE VIEW grouped AS SELECT v, count(*) FROM t GROUP BY v;",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "INSERT INTO checked VALUES (3, 3);",
+				Expected: []sql.Row{{sql.NewOkResult(1)}},
+			},
+			{
+				Query:       "INSERT INTO checked VALUES (4, 40);",
+				ExpectedErr: sql.ErrCheckOptionViolation,
+			},
+			{
+				Query:       "INSERT INTO checked (pk) VALUES (4);",
+				ExpectedErr: sql.ErrCheckOptionViolation,
+			},
+			{
+				Query:    "INSERT INTO unchecked VALUES (5, 50);",
+				Expected: []sql.Row{{sql.NewOkResult(1)}},
+			},
+			{
+				Query: "UPDATE checked SET val = val + 1;",
+				Expected: []sql.Row{{sql.OkResult{
+					RowsAffected: 2,
+					Info:         plan.UpdateInfo{Matched: 2, Updated: 2},
+				}}},
+			},
+			{
+				Query:       "UPDATE checked SET val = 100 WHERE pk = 1;",
+				ExpectedErr: sql.ErrCheckOptionViolation,
+			},
+			{
+				Query: "UPDATE unchecked SET v = 100 WHERE pk = 1;",
+				Expected: []sql.Row{{sql.OkResult{
+					RowsAffected: 1,
+					Info:         plan.UpdateInfo{Matched: 1, Updated: 1},
+				}}},
+			},
+			{
+				Query:    "DELETE FROM checked WHERE val > 0;",
+				Expected: []sql.Row{{sql.NewOkResult(1)}},
+			},
+			{
+				Query:    "SELECT * FROM t ORDER BY pk;",
+				Expected: []sql.Row{{1, 100}, {2, 20}, {5, 50}},
+			},
+			{
+				Query:       "UPDATE checked SET v = 1;",
+				ExpectedErr: sql.ErrColumnNotFound,
+			},
+			{
+				Query:       "INSERT INTO grouped VALUES (1, 1);",
+				ExpectedErr: sql.ErrNonUpdatableTable,
+			},
+			{
+				Query:    "SELECT check_option FROM information_schema.views WHERE table_name = 'checked';",
+				Expected: []sql.Row{{"LOCAL"}},
+			},
+			{
+				Query:    "SHOW CREATE VIEW checked;",
+				Expected: []sql.Row{{"checked", "CREATE ALGORITHM=UNDEFINED DEFINER=`root`@`localhost` SQL SECURITY DEFINER VIEW `checked` AS SELECT pk, v AS val FROM t WHERE v < 10 WITH LOCAL CHECK OPTION", "utf8mb4", "utf8mb4_0900_bin"}},
+			},
+		},
+	},
 	{
 		Name: "ALTER TABLE AUTO INCREMENT no-ops on table with no original auto increment key",
 		SetUpScript: []string{
@@ -1024,6 +1378,14 @@ var ScriptTests = []ScriptTest{
 				Query:       "SELECT floor(cor0.col1) * ceil(cor0.col0) AS col2 FROM tab1 AS cor0 GROUP BY cor0.col0",
 				ExpectedErr: analyzer.ErrValidationGroupBy,
 			},
+			{
+				Query:       "SELECT col0 + 1 FROM tab1 GROUP BY col0 + 2",
+				ExpectedErr: analyzer.ErrValidationGroupBy,
+			},
+			{
+				Query:       "SELECT col0 FROM tab1 GROUP BY col0 + 1",
+				ExpectedErr: analyzer.ErrValidationGroupBy,
+			},
 		},
 	},
 	{
@@ -1213,7 +1575,7 @@ var ScriptTests = []ScriptTest{
 			{
 				Query: `update test inner join test2 on test.pk = test2.pk SET test.pk=test.pk*10, test2.pk = test2.pk * 4 where test.pk < 10;`,
 				Expected: []sql.Row{{sql.OkResult{RowsAffected: 6, Info: plan.UpdateInfo{
-					Matched:  6, // TODO: The answer should be 8
+					Matched:  8,
 					Updated:  6,
 					Warnings: 0,
 				}}}},
@@ -1354,6 +1716,31 @@ var ScriptTests = []ScriptTest{
 			},
 		},
 	},
+	{
+		Name: "IN lists of decimals and floats",
+		SetUpScript: []string{
+			"CREATE TABLE in_nums (pk int primary key, d decimal(10,2), f double)",
+			"INSERT INTO in_nums VALUES (1, 1.5, -0.0), (2, 2, 0.0), (3, 2.25, 3.5)",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "SELECT pk FROM in_nums WHERE d IN (1.50, '2', 4) ORDER BY 1",
+				Expected: []sql.Row{{1}, {2}},
+			},
+			{
+				Query:    "SELECT pk FROM in_nums WHERE d IN (2.250, 7) ORDER BY 1",
+				Expected: []sql.Row{{3}},
+			},
+			{
+				Query:    "SELECT pk FROM in_nums WHERE f IN (0.0, 7) ORDER BY 1",
+				Expected: []sql.Row{{1}, {2}},
+			},
+			{
+				Query:    "SELECT pk FROM in_nums WHERE f IN (-0.0, 3.50) ORDER BY 1",
+				Expected: []sql.Row{{1}, {2}, {3}},
+			},
+		},
+	},
 	{
 		Name: "JOIN on non-index-prefix columns do not panic (Dolt Issue #2366)",
 		SetUpScript: []string{
@@ -1369,6 +1756,1777 @@ var ScriptTests = []ScriptTest{
 			},
 		},
 	},
+	{
+		Name: "LOCK TABLES and UNLOCK TABLES",
+		SetUpScript: []string{
+			"CREATE TABLE t1 (pk int primary key);",
+			"CREATE TABLE t2 (pk int primary key);",
+			"INSERT INTO t1 VALUES (1);",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "LOCK TABLES t1 READ, t2 WRITE",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "SELECT * FROM t1",
+				Expected: []sql.Row{{1}},
+			},
+			{
+				Query:    "INSERT INTO t2 VALUES (2)",
+				Expected: []sql.Row{{sql.NewOkResult(1)}},
+			},
+			{
+				Query:    "UNLOCK TABLES",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "LOCK TABLES t1 AS a READ LOCAL, t2 LOW_PRIORITY WRITE",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "UNLOCK TABLES",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:       "LOCK TABLES t3 READ",
+				ExpectedErr: sql.ErrTableNotFound,
+			},
+		},
+	},
+	{
+		Name: "database default character set and collation",
+		SetUpScript: []string{
+			"CREATE DATABASE coll_db CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci",
+			"CREATE TABLE coll_db.t1 (pk int primary key, a varchar(10), b varchar(10) COLLATE utf8mb4_bin, c blob)",
+			"CREATE TABLE coll_db.t2 (pk int primary key, a varchar(10)) DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "SELECT DEFAULT_CHARACTER_SET_NAME, DEFAULT_COLLATION_NAME FROM information_schema.SCHEMATA WHERE SCHEMA_NAME = 'coll_db'",
+				Expected: []sql.Row{{"utf8mb4", "utf8mb4_unicode_ci"}},
+			},
+			{
+				Query:    "SELECT COLUMN_NAME, COLLATION_NAME FROM information_schema.COLUMNS WHERE TABLE_SCHEMA = 'coll_db' AND TABLE_NAME = 't1' AND COLUMN_NAME IN ('a', 'b') ORDER BY 1",
+				Expected: []sql.Row{{"a", "utf8mb4_unicode_ci"}, {"b", "utf8mb4_bin"}},
+			},
+			{
+				Query:    "SELECT COLLATION_NAME FROM information_schema.COLUMNS WHERE TABLE_SCHEMA = 'coll_db' AND TABLE_NAME = 't2' AND COLUMN_NAME = 'a'",
+				Expected: []sql.Row{{"utf8mb4_general_ci"}},
+			},
+			{
+				Query:    "ALTER DATABASE coll_db COLLATE utf8mb4_general_ci",
+				Expected: []sql.Row{{sql.NewOkResult(1)}},
+			},
+			{
+				Query:    "SHOW CREATE DATABASE coll_db",
+				Expected: []sql.Row{{"coll_db", "CREATE DATABASE `coll_db` /*!40100 DEFAULT CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci */"}},
+			},
+			{
+				Query:    "CREATE TABLE coll_db.t3 (pk int primary key, a varchar(10))",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "SELECT COLLATION_NAME FROM information_schema.COLUMNS WHERE TABLE_SCHEMA = 'coll_db' AND TABLE_NAME = 't3' AND COLUMN_NAME = 'a'",
+				Expected: []sql.Row{{"utf8mb4_general_ci"}},
+			},
+			{
+				Query:    "CREATE TABLE coll_db.t4 (pk int primary key, a varchar(10)) COMMENT='the charset is latin1'",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "SELECT COLLATION_NAME FROM information_schema.COLUMNS WHERE TABLE_SCHEMA = 'coll_db' AND TABLE_NAME = 't4' AND COLUMN_NAME = 'a'",
+				Expected: []sql.Row{{"utf8mb4_general_ci"}},
+			},
+			{
+				Query:    "CREATE DATABASE coll_db2 /* collate utf8mb4_bin */",
+				Expected: []sql.Row{{sql.NewOkResult(1)}},
+			},
+			{
+				Query:    "CREATE DATABASE coll_db3 COMMENT 'charset=latin1'",
+				Expected: []sql.Row{{sql.NewOkResult(1)}},
+			},
+			{
+				Query:    "SELECT SCHEMA_NAME, DEFAULT_COLLATION_NAME FROM information_schema.SCHEMATA WHERE SCHEMA_NAME IN ('coll_db2', 'coll_db3') ORDER BY 1",
+				Expected: []sql.Row{{"coll_db2", sql.Collation_Default.Name}, {"coll_db3", sql.Collation_Default.Name}},
+			},
+			{
+				Query:       "CREATE DATABASE bad_coll_db COLLATE not_a_collation",
+				ExpectedErr: sql.ErrCollationNotSupported,
+			},
+			{
+				Query:          "ALTER DATABASE coll_db CHARACTER SET latin1 COLLATE utf8mb4_bin",
+				ExpectedErrStr: "latin1 is not a valid character set for utf8mb4_bin",
+			},
+			{
+				Query:    "ALTER DATABASE coll_db COLLATE=utf8mb4_bin",
+				Expected: []sql.Row{{sql.NewOkResult(1)}},
+			},
+			{
+				Query:    "SELECT DEFAULT_COLLATION_NAME FROM information_schema.SCHEMATA WHERE SCHEMA_NAME = 'coll_db'",
+				Expected: []sql.Row{{"utf8mb4_bin"}},
+			},
+			{
+				Query:    "ALTER DATABASE `coll_db` DEFAULT CHARACTER SET latin1 COLLATE latin1_swedish_ci",
+				Expected: []sql.Row{{sql.NewOkResult(1)}},
+			},
+			{
+				Query:    "SELECT DEFAULT_CHARACTER_SET_NAME, DEFAULT_COLLATION_NAME FROM information_schema.SCHEMATA WHERE SCHEMA_NAME = 'coll_db'",
+				Expected: []sql.Row{{"latin1", "latin1_swedish_ci"}},
+			},
+			{
+				Query:    "ALTER SCHEMA coll_db/* comment */CHARSET=utf8mb4",
+				Expected: []sql.Row{{sql.NewOkResult(1)}},
+			},
+			{
+				Query:    "SELECT DEFAULT_CHARACTER_SET_NAME, DEFAULT_COLLATION_NAME FROM information_schema.SCHEMATA WHERE SCHEMA_NAME = 'coll_db'",
+				Expected: []sql.Row{{"utf8mb4", "utf8mb4_0900_ai_ci"}},
+			},
+		},
+	},
+	{
+		Name: "casting unparseable values to temporal types",
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:           "SELECT CAST('not a date' AS DATE)",
+				Expected:        []sql.Row{{nil}},
+				ExpectedWarning: 1292,
+			},
+			{
+				Query:           "SELECT CAST('not a datetime' AS DATETIME(3))",
+				Expected:        []sql.Row{{nil}},
+				ExpectedWarning: 1292,
+			},
+			{
+				Query:           "SELECT CAST('not a time' AS TIME)",
+				Expected:        []sql.Row{{nil}},
+				ExpectedWarning: 1292,
+			},
+		},
+	},
+	{
+		Name: "explaining writes doesn't perform them",
+		SetUpScript: []string{
+			"create table t (pk int primary key auto_increment, v int, index (v))",
+			"insert into t (v) values (10), (20)",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query: "explain update t set v = 5 where v = 10",
+				Expected: []sql.Row{
+					{"Update"},
+					{" └─ UpdateSource(SET t.v = 5)"},
+					{"     └─ Filter(t.v = 10)"},
+					{"         └─ IndexedTableAccess(t on [t.v])"},
+				},
+			},
+			{
+				Query: "explain delete from t where v = 20",
+				Expected: []sql.Row{
+					{"Delete"},
+					{" └─ Filter(t.v = 20)"},
+					{"     └─ IndexedTableAccess(t on [t.v])"},
+				},
+			},
+			{
+				Query: "explain insert into t (v) values (30) on duplicate key update v = v + 1",
+				Expected: []sql.Row{
+					{"Insert(v)"},
+					{" ├─ Table(t)"},
+					{" ├─ Project(AutoIncrement(), v)"},
+					{" │   └─ Values(30)"},
+					{" └─ OnDuplicateKeyUpdate(SET t.v = (t.v + 1))"},
+				},
+			},
+			{
+				Query:    "select * from t order by pk",
+				Expected: []sql.Row{{1, 10}, {2, 20}},
+			},
+			{
+				Query:    "insert into t (v) values (40)",
+				Expected: []sql.Row{{sql.NewOkResult(1)}},
+			},
+			{
+				Query:    "select * from t order by pk",
+				Expected: []sql.Row{{1, 10}, {2, 20}, {3, 40}},
+			},
+		},
+	},
+	{
+		Name: "charset introducers on string literals",
+		SetUpScript: []string{
+			"create table t (pk int primary key, s varchar(10) character set latin1)",
+			"insert into t values (1, _latin1'Abc'), (2, _latin1 X'646566')",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "select _latin1'abc', _ascii 0x616263, _utf8mb4'abc'",
+				Expected: []sql.Row{{"abc", "abc", "abc"}},
+			},
+			{
+				Query:    "select * from t order by pk",
+				Expected: []sql.Row{{1, "Abc"}, {2, "def"}},
+			},
+			{
+				Query:    "select _utf8mb4'a' = _latin1'a', _utf8mb4'a' = _latin1'A'",
+				Expected: []sql.Row{{true, true}},
+			},
+			{
+				Query:    "select pk from t where s = _ascii'abc'",
+				Expected: []sql.Row{{1}},
+			},
+			{
+				Query:       "select _latin1'a' = _ascii'a'",
+				ExpectedErr: sql.ErrCollationIllegalMix,
+			},
+			{
+				Query:       "select _unknown'a'",
+				ExpectedErr: sql.ErrCharacterSetNotSupported,
+			},
+			{
+				Query:       "select 'abc' collate _latin1",
+				ExpectedErr: sql.ErrCollationNotSupported,
+			},
+			{
+				Query:       "select 'abc' collate __rewrite_latin1",
+				ExpectedErr: sql.ErrCollationNotSupported,
+			},
+		},
+	},
+	{
+		Name: "spatial relations between points and polygons",
+		SetUpScript: []string{
+			"create table places (name varchar(20) primary key, x double, y double)",
+			"insert into places values ('inside', 1, 1), ('edge', 0, 2), ('hole', 2.5, 2.5), ('outside', 5, 5), ('unknown', null, 1)",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "select name from places where st_contains(st_geomfromtext('POLYGON((0 0,4 0,4 4,0 4,0 0),(2 2,3 2,3 3,2 3,2 2))'), point(x, y)) order by name",
+				Expected: []sql.Row{{"inside"}},
+			},
+			{
+				Query:    "select name from places where st_within(point(x, y), st_geomfromtext('POLYGON((0 0,4 0,4 4,0 4,0 0),(2 2,3 2,3 3,2 3,2 2))')) order by name",
+				Expected: []sql.Row{{"inside"}},
+			},
+			{
+				Query:    "select name from places where st_intersects(point(x, y), st_geomfromtext('POLYGON((0 0,4 0,4 4,0 4,0 0),(2 2,3 2,3 3,2 3,2 2))')) order by name",
+				Expected: []sql.Row{{"edge"}, {"inside"}},
+			},
+			{
+				Query:    "select name, st_contains(st_geomfromtext('POLYGON((0 0,4 0,4 4,0 4,0 0))'), point(x, y)) from places order by name",
+				Expected: []sql.Row{{"edge", false}, {"hole", true}, {"inside", true}, {"outside", false}, {"unknown", nil}},
+			},
+			{
+				Query:    "select st_astext(point(x, y)) from places where name = 'hole'",
+				Expected: []sql.Row{{"POINT(2.5 2.5)"}},
+			},
+			{
+				Query:    "select st_astext(st_geomfromtext('polygon((0 0, 1 0, 1 1, 0 0))'))",
+				Expected: []sql.Row{{"POLYGON((0 0,1 0,1 1,0 0))"}},
+			},
+			{
+				Query:       "select st_contains(1, point(1, 1))",
+				ExpectedErr: sql.ErrInvalidGISData,
+			},
+			{
+				Query:       "select st_geomfromtext('POLYGON((0 0,1 0,1 1))')",
+				ExpectedErr: sql.ErrInvalidGISData,
+			},
+		},
+	},
+	{
+		Name: "multi-row inserts with duplicate keys in the same statement",
+		SetUpScript: []string{
+			"create table t (pk int primary key, v int)",
+			"create table a (id int primary key auto_increment, v int)",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "insert into t values (1, 1), (2, 2), (1, 10), (3, 3) on duplicate key update v = values(v)",
+				Expected: []sql.Row{{sql.NewOkResult(5)}},
+			},
+			{
+				Query:    "select * from t order by pk",
+				Expected: []sql.Row{{1, 10}, {2, 2}, {3, 3}},
+			},
+			{
+				Query:    "insert ignore into t values (2, 20), (4, 4), (4, 40)",
+				Expected: []sql.Row{{sql.NewOkResult(1)}},
+			},
+			{
+				Query:    "select * from t order by pk",
+				Expected: []sql.Row{{1, 10}, {2, 2}, {3, 3}, {4, 4}},
+			},
+			{
+				Query:       "insert into t values (5, 5), (5, 50)",
+				ExpectedErr: sql.ErrPrimaryKeyViolation,
+			},
+			{
+				Query:    "select * from t order by pk",
+				Expected: []sql.Row{{1, 10}, {2, 2}, {3, 3}, {4, 4}},
+			},
+			{
+				Query:    "insert into a (v) values (1), (2), (3)",
+				Expected: []sql.Row{{sql.NewOkResult(3)}},
+			},
+			{
+				Query:    "insert into a (v) values (4), (5)",
+				Expected: []sql.Row{{sql.NewOkResult(2)}},
+			},
+			{
+				Query:    "select last_insert_id()",
+				Expected: []sql.Row{{4}},
+			},
+			{
+				Query:    "select * from a order by id",
+				Expected: []sql.Row{{1, 1}, {2, 2}, {3, 3}, {4, 4}, {5, 5}},
+			},
+		},
+	},
+	{
+		Name: "index comments",
+		SetUpScript: []string{
+			"create table t (pk int primary key, a int, b int not null, index ib (b) comment 'on b')",
+			"create index ia on t (a) comment 'it''s a'",
+			"alter table t add unique index iab (a, b) comment 'on a and b'",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query: "show create table t",
+				Expected: []sql.Row{{"t", "CREATE TABLE `t` (\n" +
+					"  `pk` int NOT NULL,\n" +
+					"  `a` int,\n" +
+					"  `b` int NOT NULL,\n" +
+					"  PRIMARY KEY (`pk`),\n" +
+					"  KEY `ia` (`a`) COMMENT 'it''s a',\n" +
+					"  UNIQUE KEY `iab` (`a`,`b`) COMMENT 'on a and b',\n" +
+					"  KEY `ib` (`b`) COMMENT 'on b'\n" +
+					") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4"}},
+			},
+			{
+				Query: "show index from t",
+				Expected: []sql.Row{
+					{"t", 0, "PRIMARY", 1, "pk", nil, 0, nil, nil, "", "BTREE", "", "", "YES", nil},
+					{"t", 1, "ia", 1, "a", nil, 0, nil, nil, "YES", "BTREE", "", "it's a", "YES", nil},
+					{"t", 0, "iab", 1, "a", nil, 0, nil, nil, "YES", "BTREE", "", "on a and b", "YES", nil},
+					{"t", 0, "iab", 2, "b", nil, 0, nil, nil, "", "BTREE", "", "on a and b", "YES", nil},
+					{"t", 1, "ib", 1, "b", nil, 0, nil, nil, "", "BTREE", "", "on b", "YES", nil},
+				},
+			},
+			{
+				Query: "select index_name, seq_in_index, column_name, non_unique, index_comment from information_schema.statistics where table_name = 't' and index_name != 'PRIMARY' order by 1, 2",
+				Expected: []sql.Row{
+					{"ia", 1, "a", 1, "it's a"},
+					{"iab", 1, "a", 0, "on a and b"},
+					{"iab", 2, "b", 0, "on a and b"},
+					{"ib", 1, "b", 1, "on b"},
+				},
+			},
+			{
+				Query:    "create index ic on t (a) comment '" + strings.Repeat("é", sql.MaxIndexCommentLength) + "'",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:       "create index id on t (a) comment '" + strings.Repeat("x", sql.MaxIndexCommentLength+1) + "'",
+				ExpectedErr: sql.ErrTooLongIndexComment,
+			},
+			{
+				Query:       "alter table t add index id (a) comment '" + strings.Repeat("x", sql.MaxIndexCommentLength+1) + "'",
+				ExpectedErr: sql.ErrTooLongIndexComment,
+			},
+			{
+				Query:       "create table u (pk int primary key, index iu (pk) comment '" + strings.Repeat("x", sql.MaxIndexCommentLength+1) + "')",
+				ExpectedErr: sql.ErrTooLongIndexComment,
+			},
+		},
+	},
+	{
+		Name: "ordering and comparing JSON values",
+		SetUpScript: []string{
+			"create table j (pk int primary key, c json)",
+			`insert into j values (1, '10'), (2, '9'), (3, '"abc"'), (4, '[1, 2]'), (5, '{"a": 1}'), (6, 'true'),
+				(7, 'null'), (8, '[1]'), (9, '2.5'), (10, 'false'), (11, '"B"'), (12, NULL)`,
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "select pk from j order by c, pk",
+				Expected: []sql.Row{{12}, {7}, {9}, {2}, {1}, {11}, {3}, {5}, {8}, {4}, {10}, {6}},
+			},
+			{
+				Query:    "select pk from j order by c desc, pk",
+				Expected: []sql.Row{{6}, {10}, {4}, {8}, {5}, {3}, {11}, {1}, {2}, {9}, {7}, {12}},
+			},
+			{
+				Query:    "select pk from j where c < cast('[1, 2]' as json) order by pk",
+				Expected: []sql.Row{{1}, {2}, {3}, {5}, {7}, {8}, {9}, {11}},
+			},
+			{
+				Query:    "select pk from j where c = (select max(c) from j)",
+				Expected: []sql.Row{{6}},
+			},
+			{
+				Query:    "select pk from j where c = (select min(c) from j)",
+				Expected: []sql.Row{{7}},
+			},
+			{
+				Query:    `select json_object('a', 1) < json_object('a', 2.5), json_object('a', 2) = cast('{"a": 2.0}' as json)`,
+				Expected: []sql.Row{{true, true}},
+			},
+		},
+	},
+	{
+		Name: "ALTER VIEW",
+		SetUpScript: []string{
+			"create table t (pk int primary key, v int)",
+			"insert into t values (1, 10), (2, 20), (3, 30)",
+			"create view v1 as select pk from t",
+			"create view v2 as select * from v1",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "alter view v1 as select pk, v from t where v > 10",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "select * from v1 order by pk",
+				Expected: []sql.Row{{2, 20}, {3, 30}},
+			},
+			{
+				Query:    "select * from v2 order by pk",
+				Expected: []sql.Row{{2, 20}, {3, 30}},
+			},
+			{
+				Query:    "show create view v1",
+				Expected: []sql.Row{{"v1", "CREATE ALGORITHM=UNDEFINED DEFINER=`root`@`localhost` SQL SECURITY DEFINER VIEW `v1` AS select pk, v from t where v > 10", "utf8mb4", "utf8mb4_0900_bin"}},
+			},
+			{
+				Query:    "alter algorithm = undefined definer = `root`@`localhost` sql security invoker view v1 as select v from t where v < 30 with check option",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "select * from v2 order by v",
+				Expected: []sql.Row{{10}, {20}},
+			},
+			{
+				Query:       "update v1 set v = 40 where v = 20",
+				ExpectedErr: sql.ErrCheckOptionViolation,
+			},
+			{
+				Query:       "alter view v3 as select * from t",
+				ExpectedErr: sql.ErrViewDoesNotExist,
+			},
+			{
+				Query:       "alter view v1 as select * from missing",
+				ExpectedErr: sql.ErrTableNotFound,
+			},
+			{
+				Query:    "select * from v1 order by v",
+				Expected: []sql.Row{{10}, {20}},
+			},
+		},
+	},
+	{
+		Name: "SHOW CREATE VIEW of views with a column list, DEFINER and SQL SECURITY",
+		SetUpScript: []string{
+			"create table t (pk int primary key, v int)",
+			"insert into t values (1, 10), (2, 20), (3, 30)",
+			"create algorithm=merge definer='bob'@'%' sql security invoker view v1 (a, `b c`) as select pk, v from t where v > 10",
+			"create view v2 (x) as select a from v1",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "select a, `b c` from v1 order by a",
+				Expected: []sql.Row{{2, 20}, {3, 30}},
+			},
+			{
+				Query:    "select * from v2 order by x",
+				Expected: []sql.Row{{2}, {3}},
+			},
+			{
+				Query:    "show create view v1",
+				Expected: []sql.Row{{"v1", "CREATE ALGORITHM=MERGE DEFINER=`bob`@`%` SQL SECURITY INVOKER VIEW `v1` (`a`,`b c`) AS select pk, v from t where v > 10", "utf8mb4", "utf8mb4_0900_bin"}},
+			},
+			{
+				Query:    "select view_definition, definer, security_type from information_schema.views where table_name = 'v1'",
+				Expected: []sql.Row{{"select pk, v from t where v > 10", "bob@%", "INVOKER"}},
+			},
+			{
+				Query:    "drop view v2, v1",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "CREATE ALGORITHM=MERGE DEFINER=`bob`@`%` SQL SECURITY INVOKER VIEW `v1` (`a`,`b c`) AS select pk, v from t where v > 10",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "show create view v1",
+				Expected: []sql.Row{{"v1", "CREATE ALGORITHM=MERGE DEFINER=`bob`@`%` SQL SECURITY INVOKER VIEW `v1` (`a`,`b c`) AS select pk, v from t where v > 10", "utf8mb4", "utf8mb4_0900_bin"}},
+			},
+			{
+				Query:    "select `b c` from v1 order by a",
+				Expected: []sql.Row{{20}, {30}},
+			},
+			{
+				Query:       "create view v3 (a, b, c) as select pk, v from t",
+				ExpectedErr: sql.ErrColumnCountMismatch,
+			},
+			{
+				Query:       "create algorithm=fast view v3 as select pk from t",
+				ExpectedErr: sql.ErrSyntaxError,
+			},
+			{
+				Query:       "show create view v3",
+				ExpectedErr: sql.ErrTableNotFound,
+			},
+			{
+				Query:       "show create view t",
+				ExpectedErr: plan.ErrNotView,
+			},
+		},
+	},
+	{
+		Name: "correlated subqueries with ORDER BY and LIMIT",
+		SetUpScript: []string{
+			"create table parent (id int primary key, name varchar(10))",
+			"create table child (id int primary key, pid int, x int, created datetime, index (pid))",
+			"insert into parent values (1, 'a'), (2, 'b'), (3, 'c'), (4, 'd')",
+			`insert into child values (1, 1, 10, '2020-01-01'), (2, 1, 30, '2020-03-01'), (3, 1, 20, '2020-02-01'),
+				(4, 2, 5, '2021-01-01'), (5, 2, 50, '2019-01-01'), (6, 3, 7, '2022-01-01')`,
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "select p.id, (select c.id from child c where c.pid = p.id order by c.created desc limit 1) from parent p order by p.id",
+				Expected: []sql.Row{{1, 2}, {2, 4}, {3, 6}, {4, nil}},
+			},
+			{
+				Query: `select p.id from parent p where
+					(select c.created from child c where c.pid = p.id order by c.created desc limit 1) =
+					(select max(c.created) from child c where c.pid = p.id) order by p.id`,
+				Expected: []sql.Row{{1}, {2}, {3}},
+			},
+			{
+				Query:    "select p.id, (select x from child c where c.pid = p.id order by x limit 1 offset 1) from parent p order by p.id",
+				Expected: []sql.Row{{1, 20}, {2, 50}, {3, nil}, {4, nil}},
+			},
+			{
+				Query:    "select p.id, (select c.id from child c where c.pid = p.id order by p.id * c.x desc limit 1) from parent p order by p.id",
+				Expected: []sql.Row{{1, 2}, {2, 5}, {3, 6}, {4, nil}},
+			},
+			{
+				Query:    "select p.id, (select c.x from child c where c.pid <= p.id order by p.id - c.pid, c.x desc limit 1) from parent p order by p.id",
+				Expected: []sql.Row{{1, 30}, {2, 50}, {3, 7}, {4, 7}},
+			},
+		},
+	},
+	{
+		Name: "INSERT VALUES with functions and user variables",
+		SetUpScript: []string{
+			"create table t (id int primary key, created datetime, v int)",
+			"create table a (id int primary key auto_increment, x int)",
+			"set @v = 0",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "insert into t values (1, now(), @v := @v + 1), (2, now(), @v := @v + 1), (3, now(), @v := @v + 1)",
+				Expected: []sql.Row{{sql.NewOkResult(3)}},
+			},
+			{
+				Query:    "select id, v from t order by id",
+				Expected: []sql.Row{{1, 1}, {2, 2}, {3, 3}},
+			},
+			{
+				Query:    "select count(distinct created), @v from t",
+				Expected: []sql.Row{{1, 3}},
+			},
+			{
+				Query:    "insert into a (x) values (10), (20)",
+				Expected: []sql.Row{{sql.NewOkResult(2)}},
+			},
+			{
+				Query:    "insert into t (id, created, v) values (last_insert_id() + 10, now(), @v := @v * 10)",
+				Expected: []sql.Row{{sql.NewOkResult(1)}},
+			},
+			{
+				Query:    "select id, v, @v from t where id > 3",
+				Expected: []sql.Row{{11, 30, 30}},
+			},
+			{
+				Query:    "select @w := 1, @w := @w + 1 as n, @w",
+				Expected: []sql.Row{{1, 2, 2}},
+			},
+			{
+				Query:    "set @x := 5, @y := @z := @x * 2",
+				Expected: []sql.Row{{}},
+			},
+			{
+				Query:    "select @x, @y, @z",
+				Expected: []sql.Row{{5, 10, 10}},
+			},
+			{
+				Query:    "select @b := 2 # it's",
+				Expected: []sql.Row{{2}},
+			},
+			{
+				Query:    "select @b := @b + 1 /* it's */ as n, @c := 1 -- it's\n + 1, @b, @c",
+				Expected: []sql.Row{{3, 2, 3, 2}},
+			},
+			{
+				Query:    "set @x := 6 # it's",
+				Expected: []sql.Row{{}},
+			},
+			{
+				Query:    "select @x",
+				Expected: []sql.Row{{6}},
+			},
+			{
+				Query:       "select __user_var_assignment(@x, 7)",
+				ExpectedErr: sql.ErrFunctionNotFound,
+			},
+			{
+				Query:       "select __rewrite_user_var_assignment(@x, 7)",
+				ExpectedErr: sql.ErrFunctionNotFound,
+			},
+			{
+				Query:    "select @x",
+				Expected: []sql.Row{{6}},
+			},
+		},
+	},
+	{
+		Name: "MIN and MAX compare values using the type of their argument",
+		SetUpScript: []string{
+			`create table t (pk int primary key, e enum('z', 'a', 'm'), d date, tm time, de decimal(10, 2),
+				ci varchar(10) collate utf8mb4_0900_ai_ci, cs varchar(10) collate utf8mb4_bin, g int)`,
+			`insert into t values (1, 'a', '2021-01-05', '10:00:00', 9.5, 'a', 'a', 1),
+				(2, 'z', '2020-12-31', '-01:00:00', 10.25, 'B', 'B', 1),
+				(3, 'm', '2021-11-01', '100:00:00', -3, 'c', 'c', 2)`,
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "select min(e), max(e), min(ci), max(ci), min(cs), max(cs) from t",
+				Expected: []sql.Row{{"z", "m", "a", "c", "B", "c"}},
+			},
+			{
+				Query:    "select date_format(min(d), '%Y-%m-%d'), date_format(max(d), '%Y-%m-%d'), cast(min(tm) as char), cast(max(tm) as char), cast(min(de) as char), cast(max(de) as char) from t",
+				Expected: []sql.Row{{"2020-12-31", "2021-11-01", "-01:00:00", "100:00:00", "-3.00", "10.25"}},
+			},
+			{
+				Query:    "select g, min(e), max(e), min(ci), max(ci), min(cs), max(cs) from t group by g order by g",
+				Expected: []sql.Row{{1, "z", "a", "a", "B", "B", "a"}, {2, "m", "m", "c", "c", "c", "c"}},
+			},
+			{
+				Query:    "select pk, min(e) over (), max(ci) over (), min(cs) over () from t order by pk",
+				Expected: []sql.Row{{1, "z", "c", "B"}, {2, "z", "c", "B"}, {3, "z", "c", "B"}},
+			},
+		},
+	},
+	{
+		Name: "IN compares strings using the collation of the left operand",
+		SetUpScript: []string{
+			`create table t (pk int primary key, ci varchar(10) collate utf8mb4_0900_ai_ci, cs varchar(10) collate utf8mb4_bin)`,
+			`insert into t values (1, 'Foo', 'Foo'), (2, 'bar', 'bar'), (3, 'baz', 'baz')`,
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "select pk from t where ci in ('foo', 'BAR') order by pk",
+				Expected: []sql.Row{{1}, {2}},
+			},
+			{
+				Query:    "select pk from t where cs in ('foo', 'BAR') order by pk",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "select pk from t where ci not in ('foo', 'BAR') order by pk",
+				Expected: []sql.Row{{3}},
+			},
+			{
+				Query:    "select pk, ci in ('FOO', concat('b', 'AR')), cs in ('Foo', concat('b', 'AR')) from t order by pk",
+				Expected: []sql.Row{{1, true, true}, {2, true, false}, {3, false, false}},
+			},
+			{
+				Query:    "select pk from t where (ci, pk) in (('FOO', 1), ('BAZ', 2)) order by pk",
+				Expected: []sql.Row{{1}},
+			},
+			{
+				Query:    "select pk from t where ci in (select 'BAZ') order by pk",
+				Expected: []sql.Row{{3}},
+			},
+		},
+	},
+	{
+		Name: "LIKE ignores accents under accent-insensitive collations",
+		SetUpScript: []string{
+			`create table t (pk int primary key, ai varchar(20) collate utf8mb4_0900_ai_ci, as_ci varchar(20) collate utf8mb4_0900_as_ci, bin varchar(20) collate utf8mb4_bin)`,
+			`insert into t values (1, 'café au lait', 'café au lait', 'café au lait'), (2, 'Cafe noir', 'Cafe noir', 'Cafe noir'), (3, 'tea', 'tea', 'tea')`,
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "select pk from t where ai like 'cafe%' order by pk",
+				Expected: []sql.Row{{1}, {2}},
+			},
+			{
+				Query:    "select pk from t where ai like 'CAFÉ%' order by pk",
+				Expected: []sql.Row{{1}, {2}},
+			},
+			{
+				Query:    "select pk from t where ai like '%NOÏR' order by pk",
+				Expected: []sql.Row{{2}},
+			},
+			{
+				Query:    "select pk from t where as_ci like 'CAFÉ%' order by pk",
+				Expected: []sql.Row{{1}},
+			},
+			{
+				Query:    "select pk from t where bin like 'cafe au%' order by pk",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "select pk from t where ai in ('CAFE AU LAIT', 'café noir') order by pk",
+				Expected: []sql.Row{{1}, {2}},
+			},
+			{
+				Query:    "select 'a' = 'á' collate utf8mb4_general_ci, 'a' = 'á' collate utf8mb4_unicode_ci, 'a' = 'á' collate utf8mb4_0900_as_ci",
+				Expected: []sql.Row{{true, true, false}},
+			},
+			{
+				Query:    "select pk from t where ai collate utf8mb4_general_ci like 'CAFE%' order by pk",
+				Expected: []sql.Row{{1}, {2}},
+			},
+			{
+				Query:    "select pk from t where ai collate utf8mb4_unicode_ci in ('CAFE AU LAIT', 'café noir') order by pk",
+				Expected: []sql.Row{{1}, {2}},
+			},
+		},
+	},
+	{
+		Name: "NOT EXISTS and NOT IN with NULLs in the subquery",
+		SetUpScript: []string{
+			"create table o (pk int primary key, x int)",
+			"create table n (y int, z int)",
+			"insert into o values (1, 1), (2, 2), (3, 3), (4, null)",
+			"insert into n values (1, 10), (null, 20), (3, null)",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "select pk from o where x not in (select y from n) order by pk",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "select pk from o where x not in (select y from n where y is not null) order by pk",
+				Expected: []sql.Row{{2}},
+			},
+			{
+				Query:    "select pk from o where not exists (select y from n where n.y = o.x) order by pk",
+				Expected: []sql.Row{{2}, {4}},
+			},
+			{
+				Query:    "select pk from o where not exists (select * from n where n.y = o.x and n.z > 5) order by pk",
+				Expected: []sql.Row{{2}, {3}, {4}},
+			},
+			{
+				Query:    "select pk from o where not exists (select * from n where n.z = o.x * 10 or n.y = o.x) order by pk",
+				Expected: []sql.Row{{4}},
+			},
+			{
+				Query:    "select pk from o where not exists (select * from n where n.y = o.x) and pk > 2 order by pk",
+				Expected: []sql.Row{{4}},
+			},
+			{
+				Query:    "select pk from o where exists (select * from n where n.y = o.x) order by pk",
+				Expected: []sql.Row{{1}, {3}},
+			},
+		},
+	},
+	{
+		Name: "row_number over partitions",
+		SetUpScript: []string{
+			"create table emp (id int primary key, dept varchar(10), team int, salary int)",
+			"insert into emp values (1, 'a', 1, 100), (2, 'a', 1, 200), (3, 'b', 1, 150), (4, 'a', 2, 200), (5, 'b', 1, null), (6, 'a', 1, 200)",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "select id, row_number() over (partition by dept order by salary desc, id) from emp order by id",
+				Expected: []sql.Row{{1, 4}, {2, 1}, {3, 1}, {4, 2}, {5, 2}, {6, 3}},
+			},
+			{
+				Query:    "select id, row_number() over (partition by dept, team order by salary desc, id desc) from emp order by id",
+				Expected: []sql.Row{{1, 3}, {2, 2}, {3, 1}, {4, 1}, {5, 2}, {6, 1}},
+			},
+			{
+				Query:    "select count(*), count(distinct rn), min(rn), max(rn) from (select row_number() over () rn from emp) t",
+				Expected: []sql.Row{{6, 6, 1, 6}},
+			},
+			{
+				Query:    "select dept, count(*), max(rn) from (select dept, row_number() over (partition by dept) rn from emp) t group by dept order by dept",
+				Expected: []sql.Row{{"a", 4, 4}, {"b", 2, 2}},
+			},
+		},
+	},
+	{
+		Name: "recursive common table expressions",
+		SetUpScript: []string{
+			"create table edges (src int, dst int)",
+			"insert into edges values (1, 2), (2, 3), (3, 1), (3, 4), (5, 6)",
+			"create table nums (n int)",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "with recursive reach (n) as (select 1 union select e.dst from reach r join edges e on e.src = r.n) select n from reach order by n",
+				Expected: []sql.Row{{1}, {2}, {3}, {4}},
+			},
+			{
+				Query:    "with recursive counter (n) as (select 1 union all select n + 1 from counter where n < 5) select n from counter",
+				Expected: []sql.Row{{1}, {2}, {3}, {4}, {5}},
+			},
+			{
+				Query:    "with recursive pairs (n, m) as (select 1, 1 union all select n + 1, m from pairs where n < 3) select m, count(*) from pairs group by m",
+				Expected: []sql.Row{{1, 3}},
+			},
+			{
+				Query:    "with recursive loop (n) as (select 1 union select n from loop) select n from loop",
+				Expected: []sql.Row{{1}},
+			},
+			{
+				Query:       "with recursive loop (n) as (select 1 union all select n from loop) select n from loop",
+				ExpectedErr: sql.ErrCteRecursionLimit,
+			},
+			{
+				Query:    "set cte_max_recursion_depth = 10",
+				Expected: []sql.Row{{}},
+			},
+			{
+				Query:    "with recursive counter (n) as (select 1 union all select n + 1 from counter where n < 11) select count(*) from counter",
+				Expected: []sql.Row{{11}},
+			},
+			{
+				Query:       "with recursive counter (n) as (select 1 union all select n + 1 from counter where n < 12) select count(*) from counter",
+				ExpectedErr: sql.ErrCteRecursionLimit,
+			},
+			{
+				Query:       "with recursive loop (n) as (select n from loop) select n from loop",
+				ExpectedErr: sql.ErrCteRecursionWithoutUnion,
+			},
+			{
+				Query:       "with recursive loop (n) as (select n from loop union select 1) select n from loop",
+				ExpectedErr: sql.ErrCteRecursiveAnchor,
+			},
+			{
+				Query:    "select * from (with recursive c (n) as (select 1 union all select n + 1 from c where n < 3) select n from c) x order by n",
+				Expected: []sql.Row{{1}, {2}, {3}},
+			},
+			{
+				Query:    "select (with recursive c (n) as (select 1 union all select n + 1 from c where n < 4) select max(n) from c)",
+				Expected: []sql.Row{{4}},
+			},
+			{
+				Query:    "with w (n) as (select 1) select * from (with recursive c (n) as (select n from w union all select n + 1 from c where n < 3) select n from c) x order by n",
+				Expected: []sql.Row{{1}, {2}, {3}},
+			},
+			{
+				Query:    "create view rv as with recursive c (n) as (select 1 union all select n + 1 from c where n < 3) select n from c",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "select n from rv order by n",
+				Expected: []sql.Row{{1}, {2}, {3}},
+			},
+			{
+				Query:    "insert into nums with recursive c (n) as (select 1 union all select n + 1 from c where n < 3) select n from c",
+				Expected: []sql.Row{{sql.NewOkResult(3)}},
+			},
+			{
+				Query:    "select n from nums order by n",
+				Expected: []sql.Row{{1}, {2}, {3}},
+			},
+		},
+	},
+	{
+		Name: "Extra column information derived from column attributes",
+		SetUpScript: []string{
+			`create table t (pk int primary key auto_increment, ts timestamp default current_timestamp on update current_timestamp,
+				dt datetime default (now()), up datetime on update localtime, i int default 5, e int default (i + 1))`,
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query: "show columns from t",
+				Expected: []sql.Row{
+					{"pk", "int", "NO", "PRI", "", "auto_increment"},
+					{"ts", "timestamp", "YES", "", "CURRENT_TIMESTAMP()", "DEFAULT_GENERATED on update CURRENT_TIMESTAMP"},
+					{"dt", "datetime", "YES", "", "(NOW())", "DEFAULT_GENERATED"},
+					{"up", "datetime", "YES", "", "", "on update CURRENT_TIMESTAMP"},
+					{"i", "int", "YES", "", "5", ""},
+					{"e", "int", "YES", "", "((i + 1))", "DEFAULT_GENERATED"},
+				},
+			},
+			{
+				Query: "select column_name, extra from information_schema.columns where table_name = 't' order by ordinal_position",
+				Expected: []sql.Row{
+					{"pk", "auto_increment"},
+					{"ts", "DEFAULT_GENERATED on update CURRENT_TIMESTAMP"},
+					{"dt", "DEFAULT_GENERATED"},
+					{"up", "on update CURRENT_TIMESTAMP"},
+					{"i", ""},
+					{"e", "DEFAULT_GENERATED"},
+				},
+			},
+			{
+				Query: "show create table t",
+				Expected: []sql.Row{{"t", "CREATE TABLE `t` (\n" +
+					"  `pk` int NOT NULL AUTO_INCREMENT,\n" +
+					"  `ts` timestamp DEFAULT CURRENT_TIMESTAMP() ON UPDATE CURRENT_TIMESTAMP,\n" +
+					"  `dt` datetime DEFAULT (NOW()),\n" +
+					"  `up` datetime ON UPDATE CURRENT_TIMESTAMP,\n" +
+					"  `i` int DEFAULT 5,\n" +
+					"  `e` int DEFAULT ((i + 1)),\n" +
+					"  PRIMARY KEY (`pk`)\n" +
+					") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4"}},
+			},
+			{
+				Query:    "alter table t modify column i timestamp on update current_timestamp",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "select extra from information_schema.columns where table_name = 't' and column_name = 'i'",
+				Expected: []sql.Row{{"on update CURRENT_TIMESTAMP"}},
+			},
+			{
+				Query:       "create table bad (i int on update current_timestamp)",
+				ExpectedErr: sql.ErrInvalidOnUpdate,
+			},
+			{
+				Query:       "create table bad (d date on update current_timestamp)",
+				ExpectedErr: sql.ErrInvalidOnUpdate,
+			},
+			{
+				Query:       "create table bad (d datetime on update utc_timestamp)",
+				ExpectedErr: sql.ErrInvalidOnUpdate,
+			},
+		},
+	},
+	{
+		Name: "NATURAL JOIN and JOIN USING coalesce the join columns",
+		SetUpScript: []string{
+			"create table a (x int, y int, p int)",
+			"create table b (y int, x int, q int)",
+			"insert into a values (1, 1, 10), (2, 2, 20), (3, 3, 30)",
+			"insert into b values (1, 1, 100), (9, 2, 200), (4, 4, 400)",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "select * from a join b using (x, y)",
+				Expected: []sql.Row{{1, 1, 10, 100}},
+			},
+			{
+				Query:    "select * from a join b using (y, x)",
+				Expected: []sql.Row{{1, 1, 10, 100}},
+			},
+			{
+				Query:    "select * from a join b using (x) order by x",
+				Expected: []sql.Row{{1, 1, 10, 1, 100}, {2, 2, 20, 9, 200}},
+			},
+			{
+				Query:    "select x, a.x, b.x, a.y, b.y from a join b using (x) order by x",
+				Expected: []sql.Row{{1, 1, 1, 1, 1}, {2, 2, 2, 2, 9}},
+			},
+			{
+				Query:    "select * from a left join b using (x) order by x",
+				Expected: []sql.Row{{1, 1, 10, 1, 100}, {2, 2, 20, 9, 200}, {3, 3, 30, nil, nil}},
+			},
+			{
+				Query:    "select * from a right join b using (x) order by x",
+				Expected: []sql.Row{{1, 1, 100, 1, 10}, {2, 9, 200, 2, 20}, {4, 4, 400, nil, nil}},
+			},
+			{
+				Query:    "select x from a left join b using (x) where q is null",
+				Expected: []sql.Row{{3}},
+			},
+			{
+				Query:    "select p from a left join b using (x) where b.x is null",
+				Expected: []sql.Row{{30}},
+			},
+			{
+				Query:    "select x, a.x, b.x, b.y from a left join b using (x) order by x",
+				Expected: []sql.Row{{1, 1, 1, 1}, {2, 2, 2, 9}, {3, 3, nil, nil}},
+			},
+			{
+				Query:    "select q from a right join b using (x) where a.x is null",
+				Expected: []sql.Row{{400}},
+			},
+			{
+				Query:    "select x, a.x, b.x from a right join b using (x) order by x",
+				Expected: []sql.Row{{1, 1, 1}, {2, 2, 2}, {4, nil, 4}},
+			},
+			{
+				Query:    "select * from (select * from a natural left join b) t order by x",
+				Expected: []sql.Row{{1, 1, 10, 100}, {2, 2, 20, nil}, {3, 3, 30, nil}},
+			},
+			{
+				Query:    "select * from a natural left join b where b.y is null order by x",
+				Expected: []sql.Row{{2, 2, 20, nil}, {3, 3, 30, nil}},
+			},
+			{
+				Query:    "select * from a natural join b",
+				Expected: []sql.Row{{1, 1, 10, 100}},
+			},
+			{
+				Query:    "select * from a natural left join b order by x",
+				Expected: []sql.Row{{1, 1, 10, 100}, {2, 2, 20, nil}, {3, 3, 30, nil}},
+			},
+			{
+				Query:    "select * from a natural right join b order by x",
+				Expected: []sql.Row{{1, 1, 100, 10}, {9, 2, 200, nil}, {4, 4, 400, nil}},
+			},
+			{
+				Query:       "select * from a join b using (p)",
+				ExpectedErr: sql.ErrColumnNotFound,
+			},
+		},
+	},
+	{
+		Name: "YEAR columns and casts to YEAR",
+		SetUpScript: []string{
+			"create table years (pk int primary key, y year)",
+			"insert into years values (1, 99), (2, 5), (3, 0), (4, '0'), (5, '2155'), (6, null)",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "select pk, y from years order by y, pk",
+				Expected: []sql.Row{{6, nil}, {3, 0}, {1, 1999}, {4, 2000}, {2, 2005}, {5, 2155}},
+			},
+			{
+				Query:    "select pk from years where y < cast(2000 as year) order by pk",
+				Expected: []sql.Row{{1}, {3}},
+			},
+			{
+				Query:    "select cast(1 as year), cast(69 as year), cast(70 as year), cast(99 as year), cast(0 as year), cast('0' as year), convert('00', year)",
+				Expected: []sql.Row{{2001, 2069, 1970, 1999, 0, 2000, 2000}},
+			},
+			{
+				Query:    "select cast(1999.5 as year), cast('2021' as year), cast(date('2010-05-06') as year), cast(null as year)",
+				Expected: []sql.Row{{2000, 2021, 2010, nil}},
+			},
+			{
+				Query:           "select cast(2156 as year)",
+				Expected:        []sql.Row{{nil}},
+				ExpectedWarning: 1292,
+			},
+			{
+				Query:       "select cast('2021' as char _year)",
+				ExpectedErr: sql.ErrCharacterSetNotSupported,
+			},
+			{
+				Query:       "select convert('2021', char __rewrite_year)",
+				ExpectedErr: sql.ErrCharacterSetNotSupported,
+			},
+			{
+				Query:    "select column_type, data_type from information_schema.columns where table_name = 'years' and column_name = 'y'",
+				Expected: []sql.Row{{"year", "year"}},
+			},
+			{
+				Query:       "insert into years values (7, 2156)",
+				ExpectedErr: sql.ErrDataOutOfRange,
+			},
+			{
+				Query:       "insert into years values (7, 1900)",
+				ExpectedErr: sql.ErrDataOutOfRange,
+			},
+		},
+	},
+	{
+		Name: "values that don't fit their columns in strict and non-strict SQL modes",
+		SetUpScript: []string{
+			"create table vals (pk int primary key, s varchar(3), n tinyint, u int unsigned, d date, e decimal(4,1))",
+			"insert into vals (pk) values (1)",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:       "insert into vals (pk, s) values (2, 'abcdef')",
+				ExpectedErr: sql.ErrDataTooLong,
+			},
+			{
+				Query:       "insert into vals (pk, n) values (2, 1000)",
+				ExpectedErr: sql.ErrDataOutOfRange,
+			},
+			{
+				Query:       "insert into vals (pk, e) values (2, 12345)",
+				ExpectedErr: sql.ErrDataOutOfRange,
+			},
+			{
+				Query:       "insert into vals (pk, d) values (2, '2020-13-45')",
+				ExpectedErr: sql.ErrInvalidDate,
+			},
+			{
+				Query:          "insert into vals (pk, n) values (2, 1), (3, '12abc')",
+				ExpectedErrStr: "Incorrect integer value: '12abc' for column 'n' at row 2",
+			},
+			{
+				Query:       "insert into vals (pk, u) values (2, '-5')",
+				ExpectedErr: sql.ErrDataOutOfRange,
+			},
+			{
+				Query:       "update vals set s = 'abcdef'",
+				ExpectedErr: sql.ErrDataTooLong,
+			},
+			{
+				Query:           "insert ignore into vals (pk, s) values (2, 'abcdef')",
+				Expected:        []sql.Row{{sql.NewOkResult(1)}},
+				ExpectedWarning: 1265,
+			},
+			{
+				Query:    "set sql_mode = 'NO_ENGINE_SUBSTITUTION'",
+				Expected: []sql.Row{{}},
+			},
+			{
+				Query:           "insert into vals (pk, s) values (3, 'defghi')",
+				Expected:        []sql.Row{{sql.NewOkResult(1)}},
+				ExpectedWarning: 1265,
+			},
+			{
+				Query:           "insert into vals (pk, n, u) values (4, 1000, 5000000000), (5, -1000, 1)",
+				Expected:        []sql.Row{{sql.NewOkResult(2)}},
+				ExpectedWarning: 1264,
+			},
+			{
+				Query:           "insert into vals (pk, e) values (6, -12345)",
+				Expected:        []sql.Row{{sql.NewOkResult(1)}},
+				ExpectedWarning: 1264,
+			},
+			{
+				Query:           "insert into vals (pk, n) values (7, '12abc')",
+				Expected:        []sql.Row{{sql.NewOkResult(1)}},
+				ExpectedWarning: 1265,
+			},
+			{
+				Query: "update vals set s = 'xyzxyz' where pk = 1",
+				Expected: []sql.Row{{sql.OkResult{
+					RowsAffected: 1,
+					Info:         plan.UpdateInfo{Matched: 1, Updated: 1, Warnings: 1},
+				}}},
+				ExpectedWarning: 1265,
+			},
+			{
+				Query: "select pk, s, n, u, e from vals order by pk",
+				Expected: []sql.Row{
+					{1, "xyz", nil, nil, nil},
+					{2, "abc", nil, nil, nil},
+					{3, "def", nil, nil, nil},
+					{4, nil, int8(127), uint32(4294967295), nil},
+					{5, nil, int8(-128), uint32(1), nil},
+					{6, nil, nil, nil, "-999.9"},
+					{7, nil, int8(12), nil, nil},
+				},
+			},
+			{
+				Query: "update vals set n = '34xyz' where pk >= 6",
+				Expected: []sql.Row{{sql.OkResult{
+					RowsAffected: 2,
+					Info:         plan.UpdateInfo{Matched: 2, Updated: 2, Warnings: 2},
+				}}},
+				ExpectedWarning: 1265,
+			},
+			{
+				Query:    "set sql_mode = 'STRICT_TRANS_TABLES,NO_ENGINE_SUBSTITUTION'",
+				Expected: []sql.Row{{}},
+			},
+		},
+	},
+	{
+		Name: "REPLACE INTO deletes every row that conflicts on a primary or unique key",
+		SetUpScript: []string{
+			"create table r (pk int primary key, a int unique, b int unique, c int)",
+			"insert into r values (1, 10, 100, 1), (2, 20, 200, 2), (3, 30, 300, 3)",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "replace into r values (4, 10, 200, 4)",
+				Expected: []sql.Row{{sql.NewOkResult(3)}},
+			},
+			{
+				Query:    "select * from r order by pk",
+				Expected: []sql.Row{{3, 30, 300, 3}, {4, 10, 200, 4}},
+			},
+			{
+				Query:    "replace into r values (3, 10, 200, 5)",
+				Expected: []sql.Row{{sql.NewOkResult(3)}},
+			},
+			{
+				Query:    "select * from r order by pk",
+				Expected: []sql.Row{{3, 10, 200, 5}},
+			},
+			{
+				Query:    "replace into r values (5, 50, 500, 5), (6, 50, 600, 6), (7, 70, 700, 7)",
+				Expected: []sql.Row{{sql.NewOkResult(4)}},
+			},
+			{
+				Query:    "replace into r (pk, a, b, c) select 8, a, b, c from r where pk = 3",
+				Expected: []sql.Row{{sql.NewOkResult(2)}},
+			},
+			{
+				Query:    "select * from r order by pk",
+				Expected: []sql.Row{{6, 50, 600, 6}, {7, 70, 700, 7}, {8, 10, 200, 5}},
+			},
+		},
+	},
+	{
+		Name: "DROP VIEW with a list of existing and missing views",
+		SetUpScript: []string{
+			"CREATE TABLE t (i INT PRIMARY KEY)",
+			"INSERT INTO t VALUES (1), (2)",
+			"CREATE VIEW v1 AS SELECT * FROM t",
+			"CREATE VIEW v2 AS SELECT * FROM v1",
+			"CREATE VIEW v3 AS SELECT * FROM t",
+			"CREATE VIEW v4 AS SELECT * FROM t",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:           "DROP VIEW IF EXISTS v1, missing1, missing2",
+				Expected:        []sql.Row{},
+				ExpectedWarning: mysql.ERBadTable,
+			},
+			{
+				// Views that depend on a dropped view are left in place
+				Query:       "SELECT * FROM v2",
+				ExpectedErr: sql.ErrTableNotFound,
+			},
+			{
+				Query:       "DROP VIEW v2, missing1",
+				ExpectedErr: sql.ErrViewDoesNotExist,
+			},
+			{
+				Query:       "DROP VIEW v3, missing1, v4, missing2",
+				ExpectedErr: sql.ErrViewsDoNotExist,
+			},
+			{
+				Query:    "SHOW FULL TABLES LIKE 'v%'",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "DROP VIEW IF EXISTS missing1",
+				Expected: []sql.Row{},
+			},
+		},
+	},
+	{
+		Name: "Comparisons of signed and unsigned integers",
+		SetUpScript: []string{
+			"CREATE TABLE t (pk INT PRIMARY KEY, s BIGINT, u BIGINT UNSIGNED, INDEX (s), INDEX (u))",
+			"INSERT INTO t VALUES (1, -1, 18446744073709551615), (2, 9223372036854775807, 9223372036854775808), (3, -9223372036854775808, 0), (4, 5, 5)",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "SELECT pk, s < u, s = u, s > u, u > s FROM t ORDER BY pk",
+				Expected: []sql.Row{{1, true, false, false, true}, {2, true, false, false, true}, {3, true, false, false, true}, {4, false, true, false, false}},
+			},
+			{
+				Query:    "SELECT pk FROM t WHERE s < u ORDER BY pk",
+				Expected: []sql.Row{{1}, {2}, {3}},
+			},
+			{
+				Query:    "SELECT CAST(-1 AS UNSIGNED) > 0, -1 < CAST(18446744073709551615 AS UNSIGNED), 9223372036854775808 > 9223372036854775807",
+				Expected: []sql.Row{{true, true, true}},
+			},
+			{
+				Query:    "SELECT pk FROM t WHERE s < 9223372036854775808 ORDER BY pk",
+				Expected: []sql.Row{{1}, {2}, {3}, {4}},
+			},
+			{
+				Query:    "SELECT pk FROM t WHERE s <= 18446744073709551615 AND s > 0 ORDER BY pk",
+				Expected: []sql.Row{{2}, {4}},
+			},
+			{
+				Query:    "SELECT pk FROM t WHERE u > -1 ORDER BY pk",
+				Expected: []sql.Row{{1}, {2}, {3}, {4}},
+			},
+			{
+				Query:    "SELECT pk FROM t WHERE u = -1",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "SELECT pk FROM t WHERE u > 9223372036854775807 ORDER BY pk",
+				Expected: []sql.Row{{1}, {2}},
+			},
+			{
+				Query:    "SELECT pk FROM t WHERE u IN (-1, 0) ORDER BY pk",
+				Expected: []sql.Row{{3}},
+			},
+			{
+				Query:    "SELECT pk FROM t WHERE s IN (9223372036854775807, 18446744073709551615) ORDER BY pk",
+				Expected: []sql.Row{{2}},
+			},
+		},
+	},
+	{
+		Name: "GROUP BY puts all NULL values in one group",
+		SetUpScript: []string{
+			"CREATE TABLE t (pk INT PRIMARY KEY, a INT, b VARCHAR(10), INDEX (a))",
+			"INSERT INTO t VALUES (1, NULL, 'x'), (2, NULL, 'x'), (3, 1, NULL), (4, NULL, NULL), (5, 1, NULL), (6, NULL, NULL), (7, 3, 'y')",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "SELECT a, COUNT(*) FROM t GROUP BY a ORDER BY a",
+				Expected: []sql.Row{{nil, 4}, {1, 2}, {3, 1}},
+			},
+			{
+				Query:    "SELECT a, b, COUNT(*) FROM t GROUP BY a, b ORDER BY a, b",
+				Expected: []sql.Row{{nil, nil, 2}, {nil, "x", 2}, {1, nil, 2}, {3, "y", 1}},
+			},
+			{
+				// NULLIF returns NULL both for a NULL argument and for equal arguments
+				Query:    "SELECT COUNT(*) FROM t GROUP BY NULLIF(a, 3) ORDER BY 1",
+				Expected: []sql.Row{{2}, {5}},
+			},
+			{
+				Query:    "SELECT COUNT(*) FROM (SELECT DISTINCT NULLIF(a, 3) FROM t) s",
+				Expected: []sql.Row{{2}},
+			},
+		},
+	},
+	{
+		Name: "IN lists with elements that can't be hashed",
+		SetUpScript: []string{
+			"CREATE TABLE t (pk INT PRIMARY KEY, i INT, d DATE)",
+			"INSERT INTO t VALUES (1, 0, '2020-01-01'), (2, 1, '2020-01-02'), (3, 2, NULL)",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "SELECT pk FROM t WHERE i IN (1, 'abc') ORDER BY pk",
+				Expected: []sql.Row{{1}, {2}},
+			},
+			{
+				Query:    "SELECT pk FROM t WHERE d IN ('2020-01-01', 'notadate') ORDER BY pk",
+				Expected: []sql.Row{{1}},
+			},
+			{
+				Query:    "SELECT pk FROM t WHERE d NOT IN ('2020-01-01', 'notadate') ORDER BY pk",
+				Expected: []sql.Row{{2}},
+			},
+			{
+				Query:    "SELECT pk FROM t WHERE (i + 0, DATE(d)) IN ((0, '2020-01-01'), (1, '2020-01-02')) ORDER BY pk",
+				Expected: []sql.Row{{1}, {2}},
+			},
+		},
+	},
+	{
+		Name: "COLLATE on the values of aggregations",
+		SetUpScript: []string{
+			"CREATE TABLE t (pk INT PRIMARY KEY, name VARCHAR(10) COLLATE utf8mb4_0900_ai_ci)",
+			"INSERT INTO t VALUES (1, '1abc'), (2, '1ABC'), (3, '2b'), (4, '1Abc'), (5, '2B')",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "SELECT LOWER(GROUP_CONCAT(DISTINCT name ORDER BY name)) FROM t",
+				Expected: []sql.Row{{"1abc,2b"}},
+			},
+			{
+				Query:    "SELECT GROUP_CONCAT(DISTINCT name COLLATE utf8mb4_bin ORDER BY name COLLATE utf8mb4_bin) FROM t",
+				Expected: []sql.Row{{"1ABC,1Abc,1abc,2B,2b"}},
+			},
+			{
+				Query:    "SELECT COUNT(DISTINCT name), COUNT(DISTINCT name COLLATE utf8mb4_bin) FROM t",
+				Expected: []sql.Row{{2, 5}},
+			},
+			{
+				Query:       "SELECT name COLLATE latin1_swedish_ci FROM t",
+				ExpectedErr: sql.ErrCollationInvalidForCharacterSet,
+			},
+			{
+				Query:       "SELECT name COLLATE not_a_collation FROM t",
+				ExpectedErr: sql.ErrCollationNotSupported,
+			},
+		},
+	},
+	{
+		Name: "foreign keys between columns of compatible types",
+		SetUpScript: []string{
+			"CREATE TABLE parent (pk BIGINT PRIMARY KEY, u INT UNSIGNED, name VARCHAR(20), UNIQUE KEY (u), UNIQUE KEY (name))",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:       "CREATE TABLE child1 (pk INT PRIMARY KEY, p INT, CONSTRAINT fk_p FOREIGN KEY (p) REFERENCES parent (pk))",
+				ExpectedErr: sql.ErrForeignKeyColumnTypeMismatch,
+			},
+			{
+				Query:       "CREATE TABLE child2 (pk INT PRIMARY KEY, u INT, CONSTRAINT fk_u FOREIGN KEY (u) REFERENCES parent (u))",
+				ExpectedErr: sql.ErrForeignKeyColumnTypeMismatch,
+			},
+			{
+				Query:       "CREATE TABLE child3 (pk INT PRIMARY KEY, p BIGINT, CONSTRAINT fk_p FOREIGN KEY (q) REFERENCES parent (pk))",
+				ExpectedErr: sql.ErrUnknownForeignKeyColumn,
+			},
+			{
+				Query:       "CREATE TABLE child4 (pk INT PRIMARY KEY, p BIGINT, CONSTRAINT fk_p FOREIGN KEY (pk, p) REFERENCES parent (pk))",
+				ExpectedErr: sql.ErrForeignKeyColumnCountMismatch,
+			},
+			{
+				Query:    "CREATE TABLE child (pk INT PRIMARY KEY, p BIGINT, name VARCHAR(50), CONSTRAINT fk_p FOREIGN KEY (p) REFERENCES parent (pk), CONSTRAINT fk_name FOREIGN KEY (name) REFERENCES parent (name))",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:       "ALTER TABLE child ADD CONSTRAINT fk_pk FOREIGN KEY (pk) REFERENCES parent (u)",
+				ExpectedErr: sql.ErrForeignKeyColumnTypeMismatch,
+			},
+		},
+	},
+	{
+		Name: "duplicate index names in CREATE TABLE",
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:       "CREATE TABLE t (a int, b int, KEY k (a), KEY K (b))",
+				ExpectedErr: sql.ErrDuplicateIndexName,
+			},
+			{
+				Query:       "CREATE TABLE t (a int, b int, PRIMARY KEY (a), UNIQUE KEY `primary` (b))",
+				ExpectedErr: sql.ErrDuplicateIndexName,
+			},
+			{
+				Query:       "CREATE TABLE t (a int UNIQUE, b int, KEY a (b))",
+				ExpectedErr: sql.ErrDuplicateIndexName,
+			},
+			{
+				Query:    "SHOW TABLES LIKE 't'",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "CREATE TABLE t (a int UNIQUE, b int, KEY k (a), UNIQUE KEY (b))",
+				Expected: []sql.Row{},
+			},
+		},
+	},
+	{
+		Name: "prefix lengths of indexes in CREATE TABLE",
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:       "CREATE TABLE t (pk int PRIMARY KEY, a varchar(10), b text, KEY (a, b))",
+				ExpectedErr: parse.ErrIndexPrefixRequired,
+			},
+			{
+				Query:       "CREATE TABLE t (pk int PRIMARY KEY, a varchar(10), b text, KEY (a(11), b(20)))",
+				ExpectedErr: parse.ErrInvalidIndexPrefix,
+			},
+			{
+				Query:       "CREATE TABLE t (pk int PRIMARY KEY, a varchar(10), b text, KEY (pk(1), b(20)))",
+				ExpectedErr: parse.ErrInvalidIndexPrefix,
+			},
+			{
+				Query:    "CREATE TABLE t (pk int PRIMARY KEY, a varchar(10), b text, KEY (pk, a(10), b(20)), KEY (a))",
+				Expected: []sql.Row{},
+			},
+		},
+	},
+	{
+		Name: "CAST to floating point types",
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:           "SELECT CAST('1.5abc' AS DOUBLE), CAST('abc' AS FLOAT)",
+				Expected:        []sql.Row{{1.5, float32(0)}},
+				ExpectedWarning: mysql.ERTruncatedWrongValue,
+			},
+			{
+				Query:           "SELECT CAST('1e400' AS DOUBLE)",
+				Expected:        []sql.Row{{math.MaxFloat64}},
+				ExpectedWarning: 1264,
+			},
+			{
+				Query:       "SELECT CAST(1 AS FLOAT(54))",
+				ExpectedErr: sql.ErrInvalidType,
+			},
+		},
+	},
+	{
+		Name: "GROUP BY columns determined by a grouped key",
+		SetUpScript: []string{
+			"CREATE TABLE users (id int primary key, name varchar(20), email varchar(40) not null, nick varchar(20), UNIQUE KEY (email), UNIQUE KEY (nick))",
+			"INSERT INTO users VALUES (1, 'alice', 'alice@example.com', 'al'), (2, 'bob', 'bob@example.com', NULL), (3, 'carol', 'carol@example.com', NULL)",
+			"CREATE TABLE posts (id int primary key, user_id int, title varchar(20))",
+			"INSERT INTO posts VALUES (1, 1, 'first'), (2, 1, 'second'), (3, 2, 'third')",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "SELECT id, name FROM users GROUP BY id ORDER BY id",
+				Expected: []sql.Row{{1, "alice"}, {2, "bob"}, {3, "carol"}},
+			},
+			{
+				Query:    "SELECT u.id, upper(u.name), u.email FROM users u GROUP BY u.id ORDER BY 1",
+				Expected: []sql.Row{{1, "ALICE", "alice@example.com"}, {2, "BOB", "bob@example.com"}, {3, "CAROL", "carol@example.com"}},
+			},
+			{
+				Query:    "SELECT email, name FROM users GROUP BY email ORDER BY email",
+				Expected: []sql.Row{{"alice@example.com", "alice"}, {"bob@example.com", "bob"}, {"carol@example.com", "carol"}},
+			},
+			{
+				Query:    "SELECT u.id, u.name, count(p.id) FROM users u JOIN posts p ON u.id = p.user_id GROUP BY u.id ORDER BY 1",
+				Expected: []sql.Row{{1, "alice", 2}, {2, "bob", 1}},
+			},
+			{
+				Query:       "SELECT u.id, p.title FROM users u JOIN posts p ON u.id = p.user_id GROUP BY u.id",
+				ExpectedErr: analyzer.ErrValidationGroupBy,
+			},
+			{
+				Query:       "SELECT nick, name FROM users GROUP BY nick",
+				ExpectedErr: analyzer.ErrValidationGroupBy,
+			},
+			{
+				Query:       "SELECT name, id FROM users GROUP BY name",
+				ExpectedErr: analyzer.ErrValidationGroupBy,
+			},
+			{
+				Query:    "SELECT name, ANY_VALUE(id) FROM users GROUP BY name ORDER BY name",
+				Expected: []sql.Row{{"alice", 1}, {"bob", 2}, {"carol", 3}},
+			},
+			{
+				Query:    "SELECT DISTINCT ANY_VALUE(user_id) FROM posts GROUP BY title ORDER BY 1",
+				Expected: []sql.Row{{1}, {2}},
+			},
+		},
+	},
+	{
+		Name: "LIKE and REGEXP on binary strings",
+		SetUpScript: []string{
+			"CREATE TABLE strs (id int primary key, s varchar(20) COLLATE utf8mb4_general_ci, b varbinary(20), bl blob)",
+			"INSERT INTO strs VALUES (1, 'abc', 'abc', 'abc'), (2, 'ABC', 'ABC', 'ABC'), (3, 'é', 'é', 'é'), (4, 'aé', 'aé', 'aé')",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "SELECT id FROM strs WHERE s LIKE 'a%' ORDER BY id",
+				Expected: []sql.Row{{1}, {2}, {4}},
+			},
+			{
+				Query:    "SELECT id FROM strs WHERE b LIKE 'a%' ORDER BY id",
+				Expected: []sql.Row{{1}, {4}},
+			},
+			{
+				Query:    "SELECT id FROM strs WHERE bl LIKE 'a%' ORDER BY id",
+				Expected: []sql.Row{{1}, {4}},
+			},
+			{
+				Query:    "SELECT id FROM strs WHERE s LIKE '_' ORDER BY id",
+				Expected: []sql.Row{{3}},
+			},
+			{
+				Query:    "SELECT id FROM strs WHERE b LIKE '_' ORDER BY id",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "SELECT id FROM strs WHERE b LIKE '__' ORDER BY id",
+				Expected: []sql.Row{{3}},
+			},
+			{
+				Query:    "SELECT id FROM strs WHERE s LIKE CAST('a%' AS BINARY) ORDER BY id",
+				Expected: []sql.Row{{1}, {4}},
+			},
+			{
+				Query:    "SELECT id FROM strs WHERE s REGEXP '^a' ORDER BY id",
+				Expected: []sql.Row{{1}, {2}, {4}},
+			},
+			{
+				Query:    "SELECT id FROM strs WHERE b REGEXP '^a' ORDER BY id",
+				Expected: []sql.Row{{1}, {4}},
+			},
+			{
+				Query:    "SELECT id FROM strs WHERE s REGEXP '^..$' ORDER BY id",
+				Expected: []sql.Row{{4}},
+			},
+			{
+				Query:    "SELECT id FROM strs WHERE bl REGEXP '^..$' ORDER BY id",
+				Expected: []sql.Row{{3}},
+			},
+		},
+	},
+	{
+		Name: "FIELD and FIND_IN_SET",
+		SetUpScript: []string{
+			"CREATE TABLE tickets (id int primary key, status varchar(10))",
+			"INSERT INTO tickets VALUES (1, 'closed'), (2, 'new'), (3, 'open'), (4, 'other'), (5, NULL), (6, 'new')",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "SELECT id, status FROM tickets ORDER BY FIELD(status, 'new', 'open', 'closed'), id",
+				Expected: []sql.Row{{4, "other"}, {5, nil}, {2, "new"}, {6, "new"}, {3, "open"}, {1, "closed"}},
+			},
+			{
+				Query:    "SELECT FIELD('b', 'a', 'b', 'b'), FIELD('c', 'a', 'b'), FIELD(NULL, 'a', NULL), FIELD(2, '1', 2.0)",
+				Expected: []sql.Row{{2, 0, 0, 2}},
+			},
+			{
+				Query:       "SELECT FIELD('a')",
+				ExpectedErr: sql.ErrInvalidArgumentNumber,
+			},
+			{
+				Query:    "SELECT id, FIND_IN_SET(status, 'new,open,closed') FROM tickets ORDER BY id",
+				Expected: []sql.Row{{1, 3}, {2, 1}, {3, 2}, {4, 0}, {5, nil}, {6, 1}},
+			},
+			{
+				Query:    "SELECT FIND_IN_SET('b', 'a,b,c'), FIND_IN_SET('d', 'a,b,c'), FIND_IN_SET('a', ''), FIND_IN_SET('a,b', 'a,b')",
+				Expected: []sql.Row{{2, 0, 0, 0}},
+			},
+			{
+				Query:    "SELECT FIND_IN_SET(NULL, 'a,b'), FIND_IN_SET('a', NULL)",
+				Expected: []sql.Row{{nil, nil}},
+			},
+		},
+	},
+	{
+		Name: "correlated row subqueries",
+		SetUpScript: []string{
+			"create table parent (id int primary key, x int, y int)",
+			"create table child (pid int, a int, b int)",
+			"insert into parent values (1, 10, 20), (2, 30, 40)",
+			"insert into child values (1, 10, 20), (1, 11, 21), (2, 5, 6)",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "select id from parent where (x, y) = (select a, b from child where pid = parent.id order by a limit 1)",
+				Expected: []sql.Row{{1}},
+			},
+			{
+				Query:    "select id from parent where (select a, b from child where pid = parent.id order by a limit 1) = (x, y)",
+				Expected: []sql.Row{{1}},
+			},
+			{
+				Query:    "select id, (x, y) > (select a, b from child where pid = parent.id order by a desc limit 1) from parent order by id",
+				Expected: []sql.Row{{1, false}, {2, true}},
+			},
+			{
+				Query:    "select id, (x, y) = (select a, b from child where pid = 3) from parent order by id",
+				Expected: []sql.Row{{1, nil}, {2, nil}},
+			},
+			{
+				Query:    "select id, (select a from child where pid = parent.id order by a limit 1) from parent order by id",
+				Expected: []sql.Row{{1, 10}, {2, 5}},
+			},
+			{
+				Query:       "select id, (select a, b from child where pid = parent.id limit 1) from parent",
+				ExpectedErr: sql.ErrInvalidOperandColumns,
+			},
+			{
+				Query:       "select id from parent where (x, y) = (select a from child where pid = parent.id limit 1)",
+				ExpectedErr: sql.ErrInvalidOperandColumns,
+			},
+			{
+				Query:       "select id, (select a from child where pid = parent.id) from parent",
+				ExpectedErr: sql.ErrExpectedSingleRow,
+			},
+			{
+				Query:       "select id from parent where (x, y) = (select a, b from child where pid = parent.id)",
+				ExpectedErr: sql.ErrExpectedSingleRow,
+			},
+		},
+	},
+	{
+		Name: "UPDATE JOIN with base rows matched more than once",
+		SetUpScript: []string{
+			"create table accounts (id int primary key, balance int, updates int)",
+			"create table payments (id int primary key, account int, amount int, applied int)",
+			"insert into accounts values (1, 0, 0), (2, 0, 0), (3, 0, 0)",
+			"insert into payments values (1, 1, 10, 0), (2, 1, 10, 0), (3, 2, 5, 0)",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "update accounts join payments on accounts.id = payments.account set accounts.balance = payments.amount",
+				Expected: []sql.Row{{newUpdateResult(2, 2)}},
+			},
+			{
+				Query:    "select * from accounts order by id",
+				Expected: []sql.Row{{1, 10, 0}, {2, 5, 0}, {3, 0, 0}},
+			},
+			{
+				Query:    "update accounts join payments on accounts.id = payments.account set accounts.updates = accounts.updates + 1",
+				Expected: []sql.Row{{newUpdateResult(2, 2)}},
+			},
+			{
+				Query:    "select * from accounts order by id",
+				Expected: []sql.Row{{1, 10, 1}, {2, 5, 1}, {3, 0, 0}},
+			},
+			{
+				Query:    "update accounts join payments on accounts.id = payments.account set accounts.updates = accounts.updates + 1, payments.applied = payments.amount",
+				Expected: []sql.Row{{newUpdateResult(5, 5)}},
+			},
+			{
+				Query:    "select * from accounts order by id",
+				Expected: []sql.Row{{1, 10, 2}, {2, 5, 2}, {3, 0, 0}},
+			},
+			{
+				Query:    "select * from payments order by id",
+				Expected: []sql.Row{{1, 1, 10, 10}, {2, 1, 10, 10}, {3, 2, 5, 5}},
+			},
+			{
+				Query:    "update accounts join payments on accounts.id = payments.account set accounts.balance = accounts.balance",
+				Expected: []sql.Row{{newUpdateResult(2, 0)}},
+			},
+			{
+				Query:    "update accounts join payments on accounts.id = payments.account set accounts.balance = 10",
+				Expected: []sql.Row{{newUpdateResult(2, 1)}},
+			},
+			{
+				Query:    "select * from accounts order by id",
+				Expected: []sql.Row{{1, 10, 2}, {2, 10, 2}, {3, 0, 0}},
+			},
+		},
+	},
+	{
+		Name: "JSON column-path operators",
+		SetUpScript: []string{
+			"create table j (pk int primary key, c json)",
+			`insert into j values (1, '{"a": {"b": "x"}, "n": [1, 2, 3]}'), (2, '{"a": 2}'), (3, null)`,
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query: "select pk, c->'$.a.b', c->>'$.a.b', c->'$.n[last]' from j order by pk",
+				Expected: []sql.Row{
+					{1, sql.MustJSON(`"x"`), "x", sql.MustJSON(`3`)},
+					{2, nil, nil, nil},
+					{3, nil, nil, nil},
+				},
+			},
+			{
+				Query:    "select pk from j where c->>'$.a.b' = 'x'",
+				Expected: []sql.Row{{1}},
+			},
+			{
+				Query:    "select c->'$.n[*]', c->'$.n[1 to last]' from j where pk = 1",
+				Expected: []sql.Row{{sql.MustJSON(`[1, 2, 3]`), sql.MustJSON(`[2, 3]`)}},
+			},
+			{
+				Query:       "select c->'$.a[' from j",
+				ExpectedErr: sql.ErrInvalidJSONPath,
+			},
+		},
+	},
+	{
+		Name: "UPDATE SET with correlated and uncorrelated subqueries",
+		SetUpScript: []string{
+			"CREATE TABLE p (id int PRIMARY KEY, cnt int, mx int)",
+			"CREATE TABLE child (cid int PRIMARY KEY, pid int, v int)",
+			"INSERT INTO p VALUES (1, 0, 0), (2, 0, 0), (3, 0, 0)",
+			"INSERT INTO child VALUES (1, 1, 5), (2, 1, 7), (3, 2, 9)",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "UPDATE p SET cnt = (SELECT count(*) FROM child WHERE child.pid = p.id)",
+				Expected: []sql.Row{{newUpdateResult(3, 2)}},
+			},
+			{
+				Query:    "SELECT * FROM p ORDER BY id",
+				Expected: []sql.Row{{1, 2, 0}, {2, 1, 0}, {3, 0, 0}},
+			},
+			{
+				Query:    "UPDATE p SET mx = (SELECT max(v) FROM child)",
+				Expected: []sql.Row{{newUpdateResult(3, 3)}},
+			},
+			{
+				Query:    "SELECT * FROM p ORDER BY id",
+				Expected: []sql.Row{{1, 2, 9}, {2, 1, 9}, {3, 0, 9}},
+			},
+			{
+				Query:    "UPDATE p pp SET cnt = (SELECT count(*) FROM child WHERE pid = pp.id AND v > 5), mx = (SELECT sum(v) FROM child WHERE pid = pp.id) WHERE id < 3",
+				Expected: []sql.Row{{newUpdateResult(2, 1)}},
+			},
+			{
+				Query:    "SELECT * FROM p ORDER BY id",
+				Expected: []sql.Row{{1, 1, 12}, {2, 1, 9}, {3, 0, 9}},
+			},
+		},
+	},
 }
 
 var CreateCheckConstraintsScripts = []ScriptTest{
@@ -1224,7 +1224,7 @@ end;`,
 		},
 		Assertions: []ScriptTestAssertion{
 			{
-				Query: "select * from information_schema.triggers",
+				Query: "select trigger_catalog, trigger_schema, trigger_name, event_manipulation, event_object_catalog, event_object_schema, event_object_table, action_order, action_condition, action_statement, action_orientation, action_timing, action_reference_old_table, action_reference_new_table, action_reference_old_row, action_reference_new_row, created > date_sub(now(), interval 1 hour), sql_mode, definer, character_set_client, collation_connection, database_collation from information_schema.triggers",
 				Expected: []sql.Row{
 					{
 						"def",                   // trigger_catalog
@@ -1243,9 +1243,9 @@ end;`,
 						nil,                     // action_reference_new_table
 						"OLD",                   // action_reference_old_row
 						"NEW",                   // action_reference_new_row
-						time.Unix(0, 0).UTC(),   // created
-						"",                      // sql_mode
-						"",                      // definer
+						true,                    // created
+						"STRICT_TRANS_TABLES,NO_ENGINE_SUBSTITUTION", // sql_mode
+						"user@client", // definer
 						sql.Collation_Default.CharacterSet().String(), // character_set_client
 						sql.Collation_Default.String(),                // collation_connection
 						sql.Collation_Default.String(),                // database_collation
@@ -1267,9 +1267,9 @@ end;`,
 						nil,                     // action_reference_new_table
 						"OLD",                   // action_reference_old_row
 						"NEW",                   // action_reference_new_row
-						time.Unix(0, 0).UTC(),   // created
-						"",                      // sql_mode
-						"",                      // definer
+						true,                    // created
+						"STRICT_TRANS_TABLES,NO_ENGINE_SUBSTITUTION", // sql_mode
+						"user@client", // definer
 						sql.Collation_Default.CharacterSet().String(), // character_set_client
 						sql.Collation_Default.String(),                // collation_connection
 						sql.Collation_Default.String(),                // database_collation
@@ -1291,9 +1291,9 @@ end;`,
 						nil,                     // action_reference_new_table
 						"OLD",                   // action_reference_old_row
 						"NEW",                   // action_reference_new_row
-						time.Unix(0, 0).UTC(),   // created
-						"",                      // sql_mode
-						"",                      // definer
+						true,                    // created
+						"STRICT_TRANS_TABLES,NO_ENGINE_SUBSTITUTION", // sql_mode
+						"user@client", // definer
 						sql.Collation_Default.CharacterSet().String(), // character_set_client
 						sql.Collation_Default.String(),                // collation_connection
 						sql.Collation_Default.String(),                // database_collation
@@ -1315,9 +1315,9 @@ end;`,
 						nil,                     // action_reference_new_table
 						"OLD",                   // action_reference_old_row
 						"NEW",                   // action_reference_new_row
-						time.Unix(0, 0).UTC(),   // created
-						"",                      // sql_mode
-						"",                      // definer
+						true,                    // created
+						"STRICT_TRANS_TABLES,NO_ENGINE_SUBSTITUTION", // sql_mode
+						"user@client", // definer
 						sql.Collation_Default.CharacterSet().String(), // character_set_client
 						sql.Collation_Default.String(),                // collation_connection
 						sql.Collation_Default.String(),                // database_collation
@@ -1339,9 +1339,9 @@ end;`,
 						nil,                                     // action_reference_new_table
 						"OLD",                                   // action_reference_old_row
 						"NEW",                                   // action_reference_new_row
-						time.Unix(0, 0).UTC(),                   // created
-						"",                                      // sql_mode
-						"",                                      // definer
+						true,                                    // created
+						"STRICT_TRANS_TABLES,NO_ENGINE_SUBSTITUTION", // sql_mode
+						"user@client", // definer
 						sql.Collation_Default.CharacterSet().String(), // character_set_client
 						sql.Collation_Default.String(),                // collation_connection
 						sql.Collation_Default.String(),                // database_collation
@@ -1363,9 +1363,9 @@ end;`,
 						nil,                                     // action_reference_new_table
 						"OLD",                                   // action_reference_old_row
 						"NEW",                                   // action_reference_new_row
-						time.Unix(0, 0).UTC(),                   // created
-						"",                                      // sql_mode
-						"",                                      // definer
+						true,                                    // created
+						"STRICT_TRANS_TABLES,NO_ENGINE_SUBSTITUTION", // sql_mode
+						"user@client", // definer
 						sql.Collation_Default.CharacterSet().String(), // character_set_client
 						sql.Collation_Default.String(),                // collation_connection
 						sql.Collation_Default.String(),                // database_collation
@@ -1387,9 +1387,9 @@ end;`,
 						nil,                                     // action_reference_new_table
 						"OLD",                                   // action_reference_old_row
 						"NEW",                                   // action_reference_new_row
-						time.Unix(0, 0).UTC(),                   // created
-						"",                                      // sql_mode
-						"",                                      // definer
+						true,                                    // created
+						"STRICT_TRANS_TABLES,NO_ENGINE_SUBSTITUTION", // sql_mode
+						"user@client", // definer
 						sql.Collation_Default.CharacterSet().String(), // character_set_client
 						sql.Collation_Default.String(),                // collation_connection
 						sql.Collation_Default.String(),                // database_collation
@@ -1411,15 +1411,23 @@ end;`,
 						nil,                                     // action_reference_new_table
 						"OLD",                                   // action_reference_old_row
 						"NEW",                                   // action_reference_new_row
-						time.Unix(0, 0).UTC(),                   // created
-						"",                                      // sql_mode
-						"",                                      // definer
+						true,                                    // created
+						"STRICT_TRANS_TABLES,NO_ENGINE_SUBSTITUTION", // sql_mode
+						"user@client", // definer
 						sql.Collation_Default.CharacterSet().String(), // character_set_client
 						sql.Collation_Default.String(),                // collation_connection
 						sql.Collation_Default.String(),                // database_collation
 					},
 				},
 			},
+			{
+				Query:    "select count(*) from information_schema.triggers where created > '2021-01-01'",
+				Expected: []sql.Row{{8}},
+			},
+			{
+				Query:    "select data_type from information_schema.columns where table_schema = 'information_schema' and table_name = 'triggers' and column_name = 'created'",
+				Expected: []sql.Row{{"timestamp"}},
+			},
 		},
 	},
 	// SHOW CREATE TRIGGER scripts
@@ -1437,7 +1445,7 @@ end;`,
 				Expected: []sql.Row{
 					{
 						"a1", // Trigger
-						"",   // sql_mode
+						"STRICT_TRANS_TABLES,NO_ENGINE_SUBSTITUTION",                              // sql_mode
 						"create trigger a1 before insert on a for each row set new.x = new.x + 1", // SQL Original Statement
 						sql.Collation_Default.CharacterSet().String(),                             // character_set_client
 						sql.Collation_Default.String(),                                            // collation_connection
@@ -1451,7 +1459,7 @@ end;`,
 				Expected: []sql.Row{
 					{
 						"b1", // Trigger
-						"",   // sql_mode
+						"STRICT_TRANS_TABLES,NO_ENGINE_SUBSTITUTION",                              // sql_mode
 						"create trigger b1 before insert on b for each row set new.y = new.y + 2", // SQL Original Statement
 						sql.Collation_Default.CharacterSet().String(),                             // character_set_client
 						sql.Collation_Default.String(),                                            // collation_connection
@@ -1488,8 +1496,8 @@ end;`,
 						"set new.x = new.x + 1", // Statement
 						"BEFORE",                // Timing
 						time.Unix(0, 0).UTC(),   // Created
-						"",                      // sql_mode
-						"",                      // Definer
+						"STRICT_TRANS_TABLES,NO_ENGINE_SUBSTITUTION", // sql_mode
+						"user@client", // Definer
 						sql.Collation_Default.CharacterSet().String(), // character_set_client
 						sql.Collation_Default.String(),                // collation_connection
 						sql.Collation_Default.String(),                // Database Collation
@@ -1501,8 +1509,8 @@ end;`,
 						"set new.x = new.x + 2", // Statement
 						"BEFORE",                // Timing
 						time.Unix(0, 0).UTC(),   // Created
-						"",                      // sql_mode
-						"",                      // Definer
+						"STRICT_TRANS_TABLES,NO_ENGINE_SUBSTITUTION", // sql_mode
+						"user@client", // Definer
 						sql.Collation_Default.CharacterSet().String(), // character_set_client
 						sql.Collation_Default.String(),                // collation_connection
 						sql.Collation_Default.String(),                // Database Collation
@@ -1514,8 +1522,8 @@ end;`,
 						"insert into abb values (new.y)", // Statement
 						"AFTER",                          // Timing
 						time.Unix(0, 0).UTC(),            // Created
-						"",                               // sql_mode
-						"",                               // Definer
+						"STRICT_TRANS_TABLES,NO_ENGINE_SUBSTITUTION", // sql_mode
+						"user@client", // Definer
 						sql.Collation_Default.CharacterSet().String(), // character_set_client
 						sql.Collation_Default.String(),                // collation_connection
 						sql.Collation_Default.String(),                // Database Collation
@@ -1527,8 +1535,8 @@ end;`,
 						"set new.y = old.y + 2", // Statement
 						"BEFORE",                // Timing
 						time.Unix(0, 0).UTC(),   // Created
-						"",                      // sql_mode
-						"",                      // Definer
+						"STRICT_TRANS_TABLES,NO_ENGINE_SUBSTITUTION", // sql_mode
+						"user@client", // Definer
 						sql.Collation_Default.CharacterSet().String(), // character_set_client
 						sql.Collation_Default.String(),                // collation_connection
 						sql.Collation_Default.String(),                // Database Collation
@@ -1545,8 +1553,8 @@ end;`,
 						"set new.x = new.x + 1", // Statement
 						"BEFORE",                // Timing
 						time.Unix(0, 0).UTC(),   // Created
-						"",                      // sql_mode
-						"",                      // Definer
+						"STRICT_TRANS_TABLES,NO_ENGINE_SUBSTITUTION", // sql_mode
+						"user@client", // Definer
 						sql.Collation_Default.CharacterSet().String(), // character_set_client
 						sql.Collation_Default.String(),                // collation_connection
 						sql.Collation_Default.String(),                // Database Collation
@@ -1558,8 +1566,8 @@ end;`,
 						"set new.x = new.x + 2", // Statement
 						"BEFORE",                // Timing
 						time.Unix(0, 0).UTC(),   // Created
-						"",                      // sql_mode
-						"",                      // Definer
+						"STRICT_TRANS_TABLES,NO_ENGINE_SUBSTITUTION", // sql_mode
+						"user@client", // Definer
 						sql.Collation_Default.CharacterSet().String(), // character_set_client
 						sql.Collation_Default.String(),                // collation_connection
 						sql.Collation_Default.String(),                // Database Collation
@@ -1571,8 +1579,8 @@ end;`,
 						"insert into abb values (new.y)", // Statement
 						"AFTER",                          // Timing
 						time.Unix(0, 0).UTC(),            // Created
-						"",                               // sql_mode
-						"",                               // Definer
+						"STRICT_TRANS_TABLES,NO_ENGINE_SUBSTITUTION", // sql_mode
+						"user@client", // Definer
 						sql.Collation_Default.CharacterSet().String(), // character_set_client
 						sql.Collation_Default.String(),                // collation_connection
 						sql.Collation_Default.String(),                // Database Collation
@@ -1584,8 +1592,8 @@ end;`,
 						"set new.y = old.y + 2", // Statement
 						"BEFORE",                // Timing
 						time.Unix(0, 0).UTC(),   // Created
-						"",                      // sql_mode
-						"",                      // Definer
+						"STRICT_TRANS_TABLES,NO_ENGINE_SUBSTITUTION", // sql_mode
+						"user@client", // Definer
 						sql.Collation_Default.CharacterSet().String(), // character_set_client
 						sql.Collation_Default.String(),                // collation_connection
 						sql.Collation_Default.String(),                // Database Collation
@@ -1602,8 +1610,8 @@ end;`,
 						"insert into abb values (new.y)", // Statement
 						"AFTER",                          // Timing
 						time.Unix(0, 0).UTC(),            // Created
-						"",                               // sql_mode
-						"",                               // Definer
+						"STRICT_TRANS_TABLES,NO_ENGINE_SUBSTITUTION", // sql_mode
+						"user@client", // Definer
 						sql.Collation_Default.CharacterSet().String(), // character_set_client
 						sql.Collation_Default.String(),                // collation_connection
 						sql.Collation_Default.String(),                // Database Collation
@@ -1615,8 +1623,8 @@ end;`,
 						"set new.y = old.y + 2", // Statement
 						"BEFORE",                // Timing
 						time.Unix(0, 0).UTC(),   // Created
-						"",                      // sql_mode
-						"",                      // Definer
+						"STRICT_TRANS_TABLES,NO_ENGINE_SUBSTITUTION", // sql_mode
+						"user@client", // Definer
 						sql.Collation_Default.CharacterSet().String(), // character_set_client
 						sql.Collation_Default.String(),                // collation_connection
 						sql.Collation_Default.String(),                // Database Collation
@@ -1633,8 +1641,8 @@ end;`,
 						"set new.x = new.x + 1", // Statement
 						"BEFORE",                // Timing
 						time.Unix(0, 0).UTC(),   // Created
-						"",                      // sql_mode
-						"",                      // Definer
+						"STRICT_TRANS_TABLES,NO_ENGINE_SUBSTITUTION", // sql_mode
+						"user@client", // Definer
 						sql.Collation_Default.CharacterSet().String(), // character_set_client
 						sql.Collation_Default.String(),                // collation_connection
 						sql.Collation_Default.String(),                // Database Collation
@@ -1646,8 +1654,8 @@ end;`,
 						"set new.x = new.x + 2", // Statement
 						"BEFORE",                // Timing
 						time.Unix(0, 0).UTC(),   // Created
-						"",                      // sql_mode
-						"",                      // Definer
+						"STRICT_TRANS_TABLES,NO_ENGINE_SUBSTITUTION", // sql_mode
+						"user@client", // Definer
 						sql.Collation_Default.CharacterSet().String(), // character_set_client
 						sql.Collation_Default.String(),                // collation_connection
 						sql.Collation_Default.String(),                // Database Collation
@@ -1659,8 +1667,8 @@ end;`,
 						"insert into abb values (new.y)", // Statement
 						"AFTER",                          // Timing
 						time.Unix(0, 0).UTC(),            // Created
-						"",                               // sql_mode
-						"",                               // Definer
+						"STRICT_TRANS_TABLES,NO_ENGINE_SUBSTITUTION", // sql_mode
+						"user@client", // Definer
 						sql.Collation_Default.CharacterSet().String(), // character_set_client
 						sql.Collation_Default.String(),                // collation_connection
 						sql.Collation_Default.String(),                // Database Collation
@@ -1677,8 +1685,8 @@ end;`,
 						"insert into abb values (new.y)", // Statement
 						"AFTER",                          // Timing
 						time.Unix(0, 0).UTC(),            // Created
-						"",                               // sql_mode
-						"",                               // Definer
+						"STRICT_TRANS_TABLES,NO_ENGINE_SUBSTITUTION", // sql_mode
+						"user@client", // Definer
 						sql.Collation_Default.CharacterSet().String(), // character_set_client
 						sql.Collation_Default.String(),                // collation_connection
 						sql.Collation_Default.String(),                // Database Collation
@@ -1695,8 +1703,8 @@ end;`,
 						"set new.x = new.x + 1", // Statement
 						"BEFORE",                // Timing
 						time.Unix(0, 0).UTC(),   // Created
-						"",                      // sql_mode
-						"",                      // Definer
+						"STRICT_TRANS_TABLES,NO_ENGINE_SUBSTITUTION", // sql_mode
+						"user@client", // Definer
 						sql.Collation_Default.CharacterSet().String(), // character_set_client
 						sql.Collation_Default.String(),                // collation_connection
 						sql.Collation_Default.String(),                // Database Collation
@@ -1708,8 +1716,8 @@ end;`,
 						"set new.x = new.x + 2", // Statement
 						"BEFORE",                // Timing
 						time.Unix(0, 0).UTC(),   // Created
-						"",                      // sql_mode
-						"",                      // Definer
+						"STRICT_TRANS_TABLES,NO_ENGINE_SUBSTITUTION", // sql_mode
+						"user@client", // Definer
 						sql.Collation_Default.CharacterSet().String(), // character_set_client
 						sql.Collation_Default.String(),                // collation_connection
 						sql.Collation_Default.String(),                // Database Collation
@@ -1850,6 +1858,49 @@ end;`,
 		},
 		Assertions: nil,
 	},
+	{
+		Name: "show triggers with their definer and sql_mode",
+		SetUpScript: []string{
+			"create table abb (x int primary key)",
+			"create table acc (y int primary key)",
+			"set sql_mode = 'ANSI_QUOTES'",
+			"create definer = `root@localhost` trigger t1 before insert on abb for each row set new.x = new.x + 1",
+			"set sql_mode = 'STRICT_TRANS_TABLES'",
+			"create trigger t2 after delete on acc for each row set @x = old.y",
+			"set sql_mode = default",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query: "show triggers like 'a_b'",
+				Expected: []sql.Row{
+					{
+						"t1",                    // Trigger
+						"INSERT",                // Event
+						"abb",                   // Table
+						"set new.x = new.x + 1", // Statement
+						"BEFORE",                // Timing
+						time.Unix(0, 0).UTC(),   // Created
+						"ANSI_QUOTES",           // sql_mode
+						"root@localhost",        // Definer
+						sql.Collation_Default.CharacterSet().String(), // character_set_client
+						sql.Collation_Default.String(),                // collation_connection
+						sql.Collation_Default.String(),                // Database Collation
+					},
+				},
+			},
+			{
+				Query: "select trigger_name, event_manipulation, event_object_table, action_statement, action_timing, sql_mode, definer from information_schema.triggers order by trigger_name",
+				Expected: []sql.Row{
+					{"t1", "INSERT", "abb", "set new.x = new.x + 1", "BEFORE", "ANSI_QUOTES", "root@localhost"},
+					{"t2", "DELETE", "acc", "set @x = old.y", "AFTER", "STRICT_TRANS_TABLES", "user@client"},
+				},
+			},
+			{
+				Query:    "select count(*) from information_schema.triggers where created > '2021-01-01'",
+				Expected: []sql.Row{{2}},
+			},
+		},
+	},
 }
 
 var TriggerErrorTests = []ScriptTest{
@@ -258,7 +258,7 @@ var UpdateTests = []WriteQueryTest{
 	},
 	{
 		WriteQuery:          `UPDATE one_pk INNER JOIN two_pk on one_pk.pk = two_pk.pk1 SET one_pk.c1 = one_pk.c1 + 1, two_pk.c1 = two_pk.c2 + 1`,
-		ExpectedWriteResult: []sql.Row{{newUpdateResult(8, 6)}}, // TODO: Should be matched = 6
+		ExpectedWriteResult: []sql.Row{{newUpdateResult(6, 6)}},
 		SelectQuery:         "SELECT * FROM two_pk;",
 		ExpectedSelect: []sql.Row{
 			sql.NewRow(0, 0, 2, 1, 2, 3, 4),
@@ -421,21 +421,22 @@ var UpdateTests = []WriteQueryTest{
 			sql.NewRow(3, "third row"),
 		},
 	},
+	{
+		WriteQuery:          `UPDATE mytable SET (i, s) = (SELECT i2 + 10, s2 FROM othertable WHERE othertable.i2 = mytable.i)`,
+		ExpectedWriteResult: []sql.Row{{newUpdateResult(3, 3)}},
+		SelectQuery:         "SELECT * FROM mytable;",
+		ExpectedSelect:      []sql.Row{{int64(11), "third"}, {int64(12), "second"}, {int64(13), "first"}},
+	},
+	{
+		WriteQuery:          `UPDATE mytable SET (s, i) = ROW('updated', i + 10), s = concat(s, '!') WHERE i = 1`,
+		ExpectedWriteResult: []sql.Row{{newUpdateResult(1, 1)}},
+		SelectQuery:         "SELECT * FROM mytable;",
+		ExpectedSelect:      []sql.Row{{int64(2), "second row"}, {int64(3), "third row"}, {int64(11), "updated!"}},
+	},
 }
 
 // These tests return the correct select query answer but the wrong write result.
 var SkippedUpdateTests = []WriteQueryTest{
-	{
-		WriteQuery:          `UPDATE one_pk INNER JOIN two_pk on one_pk.pk = two_pk.pk1 SET one_pk.c1 = one_pk.c1 + 1, two_pk.c1 = two_pk.c2 + 1`,
-		ExpectedWriteResult: []sql.Row{{newUpdateResult(8, 6)}}, // TODO: Should be matched = 6
-		SelectQuery:         "SELECT * FROM two_pk;",
-		ExpectedSelect: []sql.Row{
-			sql.NewRow(0, 0, 2, 1, 2, 3, 4),
-			sql.NewRow(0, 1, 12, 11, 12, 13, 14),
-			sql.NewRow(1, 0, 22, 21, 22, 23, 24),
-			sql.NewRow(1, 1, 32, 31, 32, 33, 34),
-		},
-	},
 	{
 		WriteQuery:          `UPDATE othertable INNER JOIN tabletest on othertable.i2=3 and tabletest.i=3 SET othertable.s2 = 'fourth'`,
 		ExpectedWriteResult: []sql.Row{{newUpdateResult(1, 1)}},
@@ -519,6 +520,18 @@ var GenericUpdateErrorTests = []GenericErrorQueryTest{
 }
 
 var UpdateErrorTests = []QueryErrorTest{
+	{
+		Query:       `UPDATE mytable SET (i, s) = (SELECT i2 FROM othertable WHERE othertable.i2 = mytable.i)`,
+		ExpectedErr: sql.ErrInvalidOperandColumns,
+	},
+	{
+		Query:       `UPDATE mytable SET (i, s) = ROW(1, 'one', 'two')`,
+		ExpectedErr: sql.ErrInvalidOperandColumns,
+	},
+	{
+		Query:       `UPDATE mytable SET (i, s) = (SELECT i2, s2 FROM othertable)`,
+		ExpectedErr: sql.ErrExpectedSingleRow,
+	},
 	{
 		Query:       `UPDATE keyless INNER JOIN one_pk on keyless.c0 = one_pk.pk SET keyless.c0 = keyless.c0 + 1`,
 		ExpectedErr: sql.ErrUnsupportedFeature,
@@ -320,6 +320,39 @@ var VariableQueries = []ScriptTest{
 				Query:    "select @@global.transaction_isolation, @@global.transaction_read_only",
 				Expected: []sql.Row{{"READ-UNCOMMITTED", 0}},
 			},
+			{
+				Query:    "select @@tx_isolation, @@tx_read_only, @@global.tx_isolation, @@global.tx_read_only",
+				Expected: []sql.Row{{"SERIALIZABLE", 1, "READ-UNCOMMITTED", 0}},
+			},
+		},
+	},
+	{
+		Name: "tx_isolation and transaction_isolation are the same variable",
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "set transaction isolation level read committed",
+				Expected: []sql.Row{{}},
+			},
+			{
+				Query:    "select @@transaction_isolation, @@tx_isolation",
+				Expected: []sql.Row{{"READ-COMMITTED", "READ-COMMITTED"}},
+			},
+			{
+				Query:    "set @@tx_isolation = 'serializable'",
+				Expected: []sql.Row{{}},
+			},
+			{
+				Query:    "select @@transaction_isolation, @@tx_isolation",
+				Expected: []sql.Row{{"SERIALIZABLE", "SERIALIZABLE"}},
+			},
+			{
+				Query:    "set session transaction_isolation = 'read-uncommitted', tx_read_only = 1",
+				Expected: []sql.Row{{}},
+			},
+			{
+				Query:    "select @@session.tx_isolation, @@session.transaction_read_only",
+				Expected: []sql.Row{{"READ-UNCOMMITTED", 1}},
+			},
 		},
 	},
 	//TODO: do not override tables with user-var-like names...but why would you do this??
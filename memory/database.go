@@ -23,7 +23,8 @@ import (
 // Database is an in-memory database.
 type Database struct {
 	*BaseDatabase
-	views map[string]string
+	// views maps the lowercased names of views to their definitions
+	views map[string]sql.ViewDefinition
 }
 
 type MemoryDatabase interface {
@@ -37,7 +38,9 @@ var _ sql.TableDropper = (*Database)(nil)
 var _ sql.TableRenamer = (*Database)(nil)
 var _ sql.TriggerDatabase = (*Database)(nil)
 var _ sql.StoredProcedureDatabase = (*Database)(nil)
+var _ sql.StoredFunctionDatabase = (*Database)(nil)
 var _ sql.ViewDatabase = (*Database)(nil)
+var _ sql.CollatedDatabase = (*Database)(nil)
 
 // BaseDatabase is an in-memory database that can't store views, only for testing the engine
 type BaseDatabase struct {
@@ -45,7 +48,9 @@ type BaseDatabase struct {
 	tables            map[string]sql.Table
 	triggers          []sql.TriggerDefinition
 	storedProcedures  []sql.StoredProcedureDetails
+	storedFunctions   []sql.StoredFunctionDetails
 	primaryKeyIndexes bool
+	collation         sql.Collation
 }
 
 var _ MemoryDatabase = (*Database)(nil)
@@ -55,15 +60,16 @@ var _ MemoryDatabase = (*BaseDatabase)(nil)
 func NewDatabase(name string) *Database {
 	return &Database{
 		BaseDatabase: NewViewlessDatabase(name),
-		views:        make(map[string]string),
+		views:        make(map[string]sql.ViewDefinition),
 	}
 }
 
 // NewViewlessDatabase creates a new database that doesn't persist views. Used only for testing. Use NewDatabase.
 func NewViewlessDatabase(name string) *BaseDatabase {
 	return &BaseDatabase{
-		name:   name,
-		tables: map[string]sql.Table{},
+		name:      name,
+		tables:    map[string]sql.Table{},
+		collation: sql.Collation_Default,
 	}
 }
 
@@ -77,6 +83,17 @@ func (d *BaseDatabase) Name() string {
 	return d.name
 }
 
+// GetCollation implements sql.CollatedDatabase.
+func (d *BaseDatabase) GetCollation(ctx *sql.Context) sql.Collation {
+	return d.collation
+}
+
+// SetCollation implements sql.CollatedDatabase.
+func (d *BaseDatabase) SetCollation(ctx *sql.Context, collation sql.Collation) error {
+	d.collation = collation
+	return nil
+}
+
 // Tables returns all tables in the database.
 func (d *BaseDatabase) Tables() map[string]sql.Table {
 	return d.tables
@@ -263,40 +280,72 @@ func (d *BaseDatabase) DropStoredProcedure(ctx *sql.Context, name string) error
 	return nil
 }
 
+// GetStoredFunctions implements sql.StoredFunctionDatabase
+func (d *BaseDatabase) GetStoredFunctions(ctx *sql.Context) ([]sql.StoredFunctionDetails, error) {
+	var sfds []sql.StoredFunctionDetails
+	for _, sfd := range d.storedFunctions {
+		sfds = append(sfds, sfd)
+	}
+	return sfds, nil
+}
+
+// SaveStoredFunction implements sql.StoredFunctionDatabase
+func (d *BaseDatabase) SaveStoredFunction(ctx *sql.Context, sfd sql.StoredFunctionDetails) error {
+	loweredName := strings.ToLower(sfd.Name)
+	for _, existingSfd := range d.storedFunctions {
+		if strings.ToLower(existingSfd.Name) == loweredName {
+			return sql.ErrStoredFunctionAlreadyExists.New(sfd.Name)
+		}
+	}
+	d.storedFunctions = append(d.storedFunctions, sfd)
+	return nil
+}
+
+// DropStoredFunction implements sql.StoredFunctionDatabase
+func (d *BaseDatabase) DropStoredFunction(ctx *sql.Context, name string) error {
+	loweredName := strings.ToLower(name)
+	for i, sfd := range d.storedFunctions {
+		if strings.ToLower(sfd.Name) == loweredName {
+			d.storedFunctions = append(d.storedFunctions[:i], d.storedFunctions[i+1:]...)
+			return nil
+		}
+	}
+	return sql.ErrStoredFunctionDoesNotExist.New(name)
+}
+
 func (d *Database) CreateView(ctx *sql.Context, name string, selectStatement string) error {
-	_, ok := d.views[name]
+	key := strings.ToLower(name)
+	_, ok := d.views[key]
 	if ok {
 		return sql.ErrExistingView.New(name)
 	}
 
-	d.views[name] = selectStatement
+	d.views[key] = sql.ViewDefinition{Name: name, TextDefinition: selectStatement}
 	return nil
 }
 
 func (d *Database) DropView(ctx *sql.Context, name string) error {
-	_, ok := d.views[name]
+	key := strings.ToLower(name)
+	_, ok := d.views[key]
 	if !ok {
-		return sql.ErrViewDoesNotExist.New(name)
+		return sql.ErrViewDoesNotExist.New(d.name, name)
 	}
 
-	delete(d.views, name)
+	delete(d.views, key)
 	return nil
 }
 
 func (d *Database) AllViews(ctx *sql.Context) ([]sql.ViewDefinition, error) {
 	var views []sql.ViewDefinition
-	for name, def := range d.views {
-		views = append(views, sql.ViewDefinition{
-			Name:           name,
-			TextDefinition: def,
-		})
+	for _, def := range d.views {
+		views = append(views, def)
 	}
 	return views, nil
 }
 
 func (d *Database) GetView(ctx *sql.Context, viewName string) (string, bool, error) {
-	viewDef, ok := d.views[viewName]
-	return viewDef, ok, nil
+	viewDef, ok := d.views[strings.ToLower(viewName)]
+	return viewDef.TextDefinition, ok, nil
 }
 
 type ReadOnlyDatabase struct {
@@ -79,6 +79,7 @@ var _ sql.StatisticsTable = (*Table)(nil)
 var _ sql.ProjectedTable = (*Table)(nil)
 var _ sql.PrimaryKeyAlterableTable = (*Table)(nil)
 var _ sql.PrimaryKeyTable = (*Table)(nil)
+var _ sql.Lockable = (*Table)(nil)
 
 // NewTable creates a new Table with the given name and schema.
 func NewTable(name string, schema sql.PrimaryKeySchema) *Table {
@@ -407,6 +408,16 @@ func (t *Table) Truncate(ctx *sql.Context) (int, error) {
 	return count, nil
 }
 
+// Lock implements sql.Lockable. Locks on in-memory tables are advisory only, so this is a no-op.
+func (t *Table) Lock(ctx *sql.Context, write bool) error {
+	return nil
+}
+
+// Unlock implements sql.Lockable.
+func (t *Table) Unlock(ctx *sql.Context, id uint32) error {
+	return nil
+}
+
 // Convenience method to avoid having to create an inserter in test setup
 func (t *Table) Insert(ctx *sql.Context, row sql.Row) error {
 	inserter := t.Inserter(ctx)
@@ -489,7 +500,11 @@ func (t *Table) GetNextAutoIncrementValue(ctx *sql.Context, insertVal interface{
 		t.autoIncVal = insertVal
 	}
 
-	return t.autoIncVal, nil
+	// The sequence advances as soon as a value is handed out, since the rows inserted in a batch are all evaluated before
+	// any of them is inserted
+	next := t.autoIncVal
+	t.autoIncVal = increment(next)
+	return next, nil
 }
 
 func (t *Table) AddColumn(ctx *sql.Context, column *sql.Column, order *sql.ColumnOrder) error {
@@ -876,6 +891,20 @@ func (t *Table) GetIndexes(ctx *sql.Context) ([]sql.Index, error) {
 	return append(indexes, nonPrimaryIndexes...), nil
 }
 
+// uniqueIndexes returns the unique indexes of the table other than its primary key, sorted by name.
+func (t *Table) uniqueIndexes() []*Index {
+	var indexes []*Index
+	for _, index := range t.indexes {
+		if index, ok := index.(*Index); ok && index.Unique {
+			indexes = append(indexes, index)
+		}
+	}
+	sort.Slice(indexes, func(i, j int) bool {
+		return indexes[i].Name < indexes[j].Name
+	})
+	return indexes
+}
+
 // GetForeignKeys implements sql.ForeignKeyTable
 func (t *Table) GetForeignKeys(_ *sql.Context) ([]sql.ForeignKeyConstraint, error) {
 	return t.foreignKeys, nil
@@ -1003,6 +1032,10 @@ func (t *Table) CreateIndex(ctx *sql.Context, indexName string, using sql.IndexU
 		t.indexes = make(map[string]sql.Index)
 	}
 
+	if indexName == "" {
+		indexName = t.generateIndexName(columns)
+	}
+
 	index, err := t.createIndex(indexName, columns, constraint, comment)
 	if err != nil {
 		return err
@@ -1088,6 +1121,16 @@ Top:
 	}
 }
 
+// generateIndexName returns a name for an index on the columns given that doesn't have one, which is the name of its
+// first column as in MySQL, followed by a number if another index already has that name.
+func (t *Table) generateIndexName(columns []sql.IndexColumn) string {
+	name := columns[0].Name
+	for i := 2; t.indexes[name] != nil; i++ {
+		name = fmt.Sprintf("%s_%d", columns[0].Name, i)
+	}
+	return name
+}
+
 // CreatePrimaryKey implements the PrimaryKeyAlterableTable
 func (t *Table) CreatePrimaryKey(ctx *sql.Context, columns []sql.IndexColumn) error {
 	// First check that a primary key already exists
@@ -1143,6 +1186,9 @@ func copyschema(sch sql.Schema) sql.Schema {
 			PrimaryKey:    c.PrimaryKey,
 			Comment:       c.Comment,
 			Extra:         c.Extra,
+			OnUpdate:      c.OnUpdate,
+			Generated:     c.Generated,
+			Virtual:       c.Virtual,
 		}
 	}
 
@@ -33,6 +33,7 @@ type tableEditor struct {
 var _ sql.RowReplacer = (*tableEditor)(nil)
 var _ sql.RowUpdater = (*tableEditor)(nil)
 var _ sql.RowInserter = (*tableEditor)(nil)
+var _ sql.InsertBatcher = (*tableEditor)(nil)
 var _ sql.RowDeleter = (*tableEditor)(nil)
 
 func (t *tableEditor) Close(ctx *sql.Context) error {
@@ -84,6 +85,14 @@ func (t *tableEditor) Insert(ctx *sql.Context, row sql.Row) error {
 		return sql.NewUniqueKeyErr(fmt.Sprint(vals), true, partitionRow)
 	}
 
+	existing, key, err := t.uniqueKeyConflict(ctx, row, nil)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return sql.NewUniqueKeyErr(key, false, existing)
+	}
+
 	err = t.ea.Insert(row)
 	if err != nil {
 		return err
@@ -96,12 +105,75 @@ func (t *tableEditor) Insert(ctx *sql.Context, row sql.Row) error {
 		if err != nil {
 			return err
 		}
-		if cmp > 0 {
-			t.table.autoIncVal = row[idx]
+		if cmp >= 0 {
+			t.table.autoIncVal = increment(row[idx])
+		}
+	}
+
+	return nil
+}
+
+// InsertBatch inserts the rows given into the table, or none of them if any of them can't be inserted.
+func (t *tableEditor) InsertBatch(ctx *sql.Context, rows []sql.Row) error {
+	pkColIdxes := t.pkColumnIndexes()
+	keys := make(map[string]struct{})
+	uniqueIndexes := t.table.uniqueIndexes()
+	uniqueKeys := make([]map[string]struct{}, len(uniqueIndexes))
+	for i := range uniqueKeys {
+		uniqueKeys[i] = make(map[string]struct{})
+	}
+	for _, row := range rows {
+		if err := checkRow(t.table.schema.Schema, row); err != nil {
+			return err
+		}
+
+		if len(pkColIdxes) > 0 {
+			vals := make([]interface{}, len(pkColIdxes))
+			for i := range pkColIdxes {
+				vals[i] = row[pkColIdxes[i]]
+			}
+			key := fmt.Sprint(vals)
+			if _, ok := keys[key]; ok {
+				return sql.NewUniqueKeyErr(key, true, row)
+			}
+			keys[key] = struct{}{}
+
+			partitionRow, added, err := t.ea.Get(row)
+			if err != nil {
+				return err
+			}
+			if added {
+				return sql.NewUniqueKeyErr(key, true, partitionRow)
+			}
+		}
+
+		existing, key, err := t.uniqueKeyConflict(ctx, row, nil)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			return sql.NewUniqueKeyErr(key, false, existing)
+		}
+		for i, index := range uniqueIndexes {
+			key, ok, err := uniqueKey(ctx, index, row)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				continue
+			}
+			if _, ok := uniqueKeys[i][key]; ok {
+				return sql.NewUniqueKeyErr(key, false, row)
+			}
+			uniqueKeys[i][key] = struct{}{}
 		}
-		t.table.autoIncVal = increment(t.table.autoIncVal)
 	}
 
+	for _, row := range rows {
+		if err := t.Insert(ctx, row); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -128,7 +200,15 @@ func (t *tableEditor) Update(ctx *sql.Context, oldRow sql.Row, newRow sql.Row) e
 		return err
 	}
 
-	err := t.ea.Delete(oldRow)
+	existing, key, err := t.uniqueKeyConflict(ctx, newRow, oldRow)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return sql.NewUniqueKeyErr(key, false, existing)
+	}
+
+	err = t.ea.Delete(oldRow)
 	if err != nil {
 		return err
 	}
@@ -174,6 +254,71 @@ func (t *tableEditor) pkColumnIndexes() []int {
 	return pkColIdxes
 }
 
+// uniqueKeyConflict returns the row of the table with the same key as the row given in a unique index, along with the
+// duplicate key, or nil if there's none. Unique indexes are checked in order of their names, so that the row returned
+// for a row conflicting with several rows is always the same. The row being replaced by the row given, if any, doesn't
+// conflict with it.
+func (t *tableEditor) uniqueKeyConflict(ctx *sql.Context, row, replaced sql.Row) (sql.Row, string, error) {
+	indexes := t.table.uniqueIndexes()
+	if len(indexes) == 0 {
+		return nil, "", nil
+	}
+
+	rows, err := t.ea.Rows()
+	if err != nil {
+		return nil, "", err
+	}
+	if replaced != nil {
+		for i, r := range rows {
+			equal, err := rowsAreEqual(ctx, t.table.schema.Schema, r, replaced)
+			if err != nil {
+				return nil, "", err
+			}
+			if equal {
+				rows = append(rows[:i], rows[i+1:]...)
+				break
+			}
+		}
+	}
+
+	for _, index := range indexes {
+		key, ok, err := uniqueKey(ctx, index, row)
+		if err != nil {
+			return nil, "", err
+		}
+		if !ok {
+			continue
+		}
+		for _, r := range rows {
+			existingKey, ok, err := uniqueKey(ctx, index, r)
+			if err != nil {
+				return nil, "", err
+			}
+			if ok && existingKey == key {
+				return r, key, nil
+			}
+		}
+	}
+	return nil, "", nil
+}
+
+// uniqueKey returns the key of the row given in the unique index given. Returns false if any of the values of the key
+// is NULL, as such keys never conflict.
+func uniqueKey(ctx *sql.Context, index *Index, row sql.Row) (string, bool, error) {
+	vals := make([]interface{}, len(index.Exprs))
+	for i, expr := range index.Exprs {
+		val, err := expr.Eval(ctx, row)
+		if err != nil {
+			return "", false, err
+		}
+		if val == nil {
+			return "", false, nil
+		}
+		vals[i] = val
+	}
+	return fmt.Sprint(vals), true, nil
+}
+
 func (t *tableEditor) pkColsDiffer(row, row2 sql.Row) bool {
 	pkColIdxes := t.pkColumnIndexes()
 	return !columnsMatch(pkColIdxes, row, row2)
@@ -201,6 +346,8 @@ type tableEditAccumulator interface {
 	// ApplyEdits takes a initialTable and runs through a sequence of inserts and deletes that have been stored in the
 	// accumulator.
 	ApplyEdits(ctx *sql.Context) error
+	// Rows returns the rows of the table with the edits stored in the accumulator applied.
+	Rows() ([]sql.Row, error)
 	// Clear wipes all of the stored inserts and deletes that may or may not have been applied.
 	Clear()
 }
@@ -294,6 +441,27 @@ func (pke *pkTableEditAccumulator) ApplyEdits(ctx *sql.Context) error {
 	return nil
 }
 
+// Rows implements the tableEditAccumulator interface.
+func (pke *pkTableEditAccumulator) Rows() ([]sql.Row, error) {
+	var rows []sql.Row
+	for _, partition := range pke.table.partitions {
+		for _, row := range partition {
+			rowKey := pke.getRowKey(row)
+			if _, ok := pke.adds[rowKey]; ok {
+				continue
+			}
+			if _, ok := pke.deletes[rowKey]; ok {
+				continue
+			}
+			rows = append(rows, row)
+		}
+	}
+	for _, row := range pke.adds {
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
 // Clear implements the tableEditAccumulator interface.
 func (pke *pkTableEditAccumulator) Clear() {
 	pke.adds = make(map[string]sql.Row)
@@ -458,6 +626,27 @@ func (k *keylessTableEditAccumulator) ApplyEdits(ctx *sql.Context) error {
 	return nil
 }
 
+// Rows implements the tableEditAccumulator interface.
+func (k *keylessTableEditAccumulator) Rows() ([]sql.Row, error) {
+	var rows []sql.Row
+	for _, partition := range k.table.partitions {
+		rows = append(rows, partition...)
+	}
+	for _, deleted := range k.deletes {
+		for i, row := range rows {
+			equal, err := deleted.Equals(row, k.table.schema.Schema)
+			if err != nil {
+				return nil, err
+			}
+			if equal {
+				rows = append(rows[:i], rows[i+1:]...)
+				break
+			}
+		}
+	}
+	return append(rows, k.adds...), nil
+}
+
 // Clear implements the tableEditAccumulator interface.
 func (k *keylessTableEditAccumulator) Clear() {
 	k.adds = make([]sql.Row, 0)
@@ -93,7 +93,8 @@ func (h *Handler) ComPrepare(c *mysql.Conn, query string) ([]*query.Field, error
 	}
 	schema, err := h.e.AnalyzeQuery(ctx, query)
 	if err != nil {
-		return nil, err
+		sqlErr, _, _ := sql.CastSQLError(err)
+		return nil, sqlErr
 	}
 	if sql.IsOkResultSchema(schema) {
 		return nil, nil
@@ -337,6 +337,48 @@ func TestHandlerTimeout(t *testing.T) {
 	require.NoError(err)
 }
 
+// Tests that boolean expression results are sent to clients as the integers 1 and 0
+func TestHandlerBooleanOutput(t *testing.T) {
+	require := require.New(t)
+
+	e := setupMemDB(require)
+	handler := NewHandler(
+		e,
+		NewSessionManager(
+			testSessionBuilder,
+			opentracing.NoopTracer{},
+			func(db string) bool { return db == "test" },
+			sql.NewMemoryManager(nil),
+			sqle.NewProcessList(),
+			"foo",
+		),
+		0,
+		false,
+	)
+	conn := newConn(1)
+	handler.NewConnection(conn)
+	handler.ComInitDB(conn, "test")
+
+	var rows [][]sqltypes.Value
+	var fields []*query.Field
+	err := handler.ComQuery(conn, "SELECT (1 < 2), (1 > 2), (1 < 2) + 1", func(res *sqltypes.Result) error {
+		if res.Fields != nil {
+			fields = res.Fields
+		}
+		rows = append(rows, res.Rows...)
+		return nil
+	})
+	require.NoError(err)
+	require.Len(rows, 1)
+
+	require.Equal(query.Type_INT8, fields[0].Type)
+	require.Equal(query.Type_INT8, fields[1].Type)
+	require.Equal(query.Type_INT64, fields[2].Type)
+	require.Equal("1", rows[0][0].ToString())
+	require.Equal("0", rows[0][1].ToString())
+	require.Equal("2", rows[0][2].ToString())
+}
+
 func TestOkClosedConnection(t *testing.T) {
 	require := require.New(t)
 	e := setupMemDB(require)
@@ -570,3 +612,73 @@ func TestHandlerFoundRowsCapabilities(t *testing.T) {
 		})
 	}
 }
+
+func TestHandlerErrorCodes(t *testing.T) {
+	e := setupMemDB(require.New(t))
+	dummyConn := &mysql.Conn{ConnectionID: 1}
+	handler := NewHandler(
+		e,
+		NewSessionManager(
+			testSessionBuilder,
+			opentracing.NoopTracer{},
+			func(db string) bool { return db == "test" },
+			sql.NewMemoryManager(nil),
+			sqle.NewProcessList(),
+			"foo",
+		),
+		0,
+		false,
+	)
+	handler.NewConnection(dummyConn)
+	handler.ComInitDB(dummyConn, "test")
+
+	for _, setup := range []string{
+		"CREATE TABLE pk_test (pk int PRIMARY KEY)",
+		"INSERT INTO pk_test VALUES (1)",
+	} {
+		err := handler.ComQuery(dummyConn, setup, func(res *sqltypes.Result) error {
+			return nil
+		})
+		require.NoError(t, err)
+	}
+
+	tests := []struct {
+		name     string
+		query    string
+		code     int
+		sqlState string
+	}{
+		{
+			name:     "duplicate primary key",
+			query:    "INSERT INTO pk_test VALUES (1)",
+			code:     mysql.ERDupEntry,
+			sqlState: "23000",
+		},
+		{
+			name:     "unknown table",
+			query:    "SELECT * FROM nope",
+			code:     mysql.ERNoSuchTable,
+			sqlState: "42S02",
+		},
+		{
+			name:     "unknown column",
+			query:    "SELECT nope FROM test",
+			code:     mysql.ERBadFieldError,
+			sqlState: "42S22",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := handler.ComQuery(dummyConn, test.query, func(res *sqltypes.Result) error {
+				return nil
+			})
+			require.Error(t, err)
+
+			sqlErr, ok := err.(*mysql.SQLError)
+			require.True(t, ok)
+			require.Equal(t, test.code, sqlErr.Number())
+			require.Equal(t, test.sqlState, sqlErr.SQLState())
+		})
+	}
+}
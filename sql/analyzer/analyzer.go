@@ -242,12 +242,13 @@ func (ab *Builder) Build() *Analyzer {
 	}
 
 	return &Analyzer{
-		Debug:          debug || ab.debug,
-		contextStack:   make([]string, 0),
-		Batches:        batches,
-		Catalog:        NewCatalog(ab.provider),
-		Parallelism:    ab.parallelism,
-		ProcedureCache: NewProcedureCache(),
+		Debug:               debug || ab.debug,
+		contextStack:        make([]string, 0),
+		Batches:             batches,
+		Catalog:             NewCatalog(ab.provider),
+		Parallelism:         ab.parallelism,
+		ProcedureCache:      NewProcedureCache(),
+		StoredFunctionCache: NewStoredFunctionCache(),
 	}
 }
 
@@ -267,6 +268,8 @@ type Analyzer struct {
 	Catalog sql.Catalog
 	// ProcedureCache is a cache of stored procedures.
 	ProcedureCache *ProcedureCache
+	// StoredFunctionCache is a cache of parsed stored functions.
+	StoredFunctionCache *StoredFunctionCache
 }
 
 // NewDefault creates a default Analyzer instance with all default Rules and configuration.
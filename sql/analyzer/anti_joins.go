@@ -0,0 +1,226 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analyzer
+
+import (
+	"reflect"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+// applyAntiJoins replaces the correlated NOT EXISTS conditions of filters with anti-joins between the
+// child of the filter and the decorrelated subquery, so that the subquery is read once instead of once per row. Only
+// subqueries that read a single table through filters, projections, sorts and distincts are decorrelated, and only
+// when the outer scope is referenced by their filters alone. Other NOT EXISTS conditions, and NOT IN conditions, whose
+// NULL semantics differ, are left to be evaluated as expressions.
+func applyAntiJoins(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.Node, error) {
+	// The rows of filters in subqueries are prepended with the outer scope, which isn't part of the schema of their child
+	if len(scope.Schema()) > 0 {
+		return n, nil
+	}
+
+	return plan.TransformUp(n, func(n sql.Node) (sql.Node, error) {
+		filter, ok := n.(*plan.Filter)
+		if !ok {
+			return n, nil
+		}
+
+		child := filter.Child
+		var remaining []sql.Expression
+		for _, e := range splitConjunction(filter.Expression) {
+			antiJoin, err := antiJoinForNotExists(child, e)
+			if err != nil {
+				return nil, err
+			}
+			if antiJoin != nil {
+				a.Log("replacing %s with an anti-join", e)
+				child = antiJoin
+				continue
+			}
+			remaining = append(remaining, e)
+		}
+
+		if child == filter.Child {
+			return filter, nil
+		}
+		if len(remaining) == 0 {
+			return child, nil
+		}
+		return plan.NewFilter(expression.JoinAnd(remaining...), child), nil
+	})
+}
+
+// antiJoinForNotExists returns the anti-join of the node given with the subquery of the NOT EXISTS condition given, or
+// nil if the condition isn't a NOT EXISTS whose subquery can be decorrelated.
+func antiJoinForNotExists(left sql.Node, e sql.Expression) (sql.Node, error) {
+	not, ok := e.(*expression.Not)
+	if !ok {
+		return nil, nil
+	}
+	exists, ok := not.Child.(*plan.ExistsSubquery)
+	if !ok {
+		return nil, nil
+	}
+	subquery, ok := exists.Children()[0].(*plan.Subquery)
+	if !ok {
+		return nil, nil
+	}
+
+	// The fields of the subquery are indexed after the fields of its scope, which is the row of the filter
+	scopeLen := len(left.Schema())
+
+	// Only the filters of the subquery matter for its existence, and they are the only nodes allowed to reference the
+	// outer scope
+	var conds []sql.Expression
+	right := subquery.Query
+	for peeling := true; peeling; {
+		switch n := right.(type) {
+		case *plan.Project:
+			right = n.Child
+		case *plan.Distinct:
+			right = n.Child
+		case *plan.OrderedDistinct:
+			right = n.Child
+		case *plan.Sort:
+			right = n.Child
+		case *plan.Filter:
+			conds = append(conds, splitConjunction(n.Expression)...)
+			right = n.Child
+		default:
+			peeling = false
+		}
+	}
+
+	if !isDecorrelatable(right, scopeLen) {
+		return nil, nil
+	}
+
+	var correlated, uncorrelated, leftKeys, rightKeys []sql.Expression
+	for _, cond := range conds {
+		if containsSubquery(cond) {
+			return nil, nil
+		}
+		if !referencesScope(cond, scopeLen) {
+			uncorrelated = append(uncorrelated, shiftFieldIndexes(cond, scopeLen))
+			continue
+		}
+
+		correlated = append(correlated, cond)
+		if eq, ok := cond.(*expression.Equals); ok {
+			if l, r, ok := antiJoinKeys(eq, scopeLen); ok {
+				leftKeys = append(leftKeys, l)
+				rightKeys = append(rightKeys, shiftFieldIndexes(r, scopeLen))
+			}
+		}
+	}
+
+	// Uncorrelated subqueries are cached instead
+	if len(correlated) == 0 {
+		return nil, nil
+	}
+
+	right, err := plan.TransformExpressionsUp(right, func(e sql.Expression) (sql.Expression, error) {
+		if gf, ok := e.(*expression.GetField); ok {
+			return gf.WithIndex(gf.Index() - scopeLen), nil
+		}
+		return e, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(uncorrelated) > 0 {
+		right = plan.NewFilter(expression.JoinAnd(uncorrelated...), right)
+	}
+
+	return plan.NewAntiJoin(left, right, leftKeys, rightKeys, expression.JoinAnd(correlated...)), nil
+}
+
+// isDecorrelatable returns whether the node given, the source of the rows of a subquery below its filters, can be read
+// without the outer scope: it must be a single table, possibly filtered and projected, and not reference the scope.
+func isDecorrelatable(n sql.Node, scopeLen int) bool {
+	ok := true
+	plan.Inspect(n, func(n sql.Node) bool {
+		switch n := n.(type) {
+		case *plan.ResolvedTable, *plan.IndexedTableAccess, *plan.TableAlias, *plan.DecoratedNode, *plan.Exchange,
+			*plan.Filter, *plan.Project, *plan.Distinct, *plan.OrderedDistinct, *plan.Sort:
+			if er, isExpressioner := n.(sql.Expressioner); isExpressioner {
+				for _, e := range er.Expressions() {
+					if containsSubquery(e) || referencesScope(e, scopeLen) {
+						ok = false
+					}
+				}
+			}
+		case nil:
+		default:
+			ok = false
+		}
+		return ok
+	})
+	return ok
+}
+
+// antiJoinKeys returns the side of the equality given that references the outer scope alone, and the side that
+// references the subquery alone, if they can be hashed by an anti-join.
+func antiJoinKeys(eq *expression.Equals, scopeLen int) (outer, inner sql.Expression, ok bool) {
+	outer, inner = eq.Left(), eq.Right()
+	if !onlyReferencesScope(outer, scopeLen) {
+		outer, inner = inner, outer
+	}
+	if !onlyReferencesScope(outer, scopeLen) || referencesScope(inner, scopeLen) || !hasGetField(inner) {
+		return nil, nil, false
+	}
+
+	// Equal values of other types, such as strings with a case-insensitive collation, may not hash the same
+	if !sql.IsInteger(outer.Type()) || !reflect.DeepEqual(outer.Type(), inner.Type()) {
+		return nil, nil, false
+	}
+	return outer, inner, true
+}
+
+// referencesScope returns whether the expression given has a field of the outer scope.
+func referencesScope(e sql.Expression, scopeLen int) bool {
+	return expression.InspectUp(e, func(e sql.Expression) bool {
+		gf, ok := e.(*expression.GetField)
+		return ok && gf.Index() < scopeLen
+	})
+}
+
+// onlyReferencesScope returns whether the expression given has fields, and all of them are of the outer scope.
+func onlyReferencesScope(e sql.Expression, scopeLen int) bool {
+	return hasGetField(e) && !expression.InspectUp(e, func(e sql.Expression) bool {
+		gf, ok := e.(*expression.GetField)
+		return ok && gf.Index() >= scopeLen
+	})
+}
+
+func hasGetField(e sql.Expression) bool {
+	return expression.InspectUp(e, func(e sql.Expression) bool {
+		_, ok := e.(*expression.GetField)
+		return ok
+	})
+}
+
+// shiftFieldIndexes returns the expression given with the outer scope removed from the indexes of its fields.
+func shiftFieldIndexes(e sql.Expression, scopeLen int) sql.Expression {
+	shifted, _ := expression.TransformUp(e, func(e sql.Expression) (sql.Expression, error) {
+		if gf, ok := e.(*expression.GetField); ok {
+			return gf.WithIndex(gf.Index() - scopeLen), nil
+		}
+		return e, nil
+	})
+	return shifted
+}
@@ -0,0 +1,163 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/memory"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+func TestApplyAntiJoins(t *testing.T) {
+	foo := plan.NewResolvedTable(memory.NewTable("foo", sql.NewPrimaryKeySchema(sql.Schema{
+		{Name: "a", Type: sql.Int64, Source: "foo"},
+		{Name: "b", Type: sql.Int64, Source: "foo"},
+	})), nil, nil)
+	bar := plan.NewResolvedTable(memory.NewTable("bar", sql.NewPrimaryKeySchema(sql.Schema{
+		{Name: "x", Type: sql.Int64, Source: "bar", Nullable: true},
+		{Name: "y", Type: sql.Int64, Source: "bar", Nullable: true},
+	})), nil, nil)
+
+	// Fields of foo, and of bar as seen from a subquery in a filter on foo
+	a := expression.NewGetFieldWithTable(0, sql.Int64, "foo", "a", false)
+	b := expression.NewGetFieldWithTable(1, sql.Int64, "foo", "b", false)
+	x := expression.NewGetFieldWithTable(2, sql.Int64, "bar", "x", true)
+	y := expression.NewGetFieldWithTable(3, sql.Int64, "bar", "y", true)
+
+	// Fields of bar as seen from its own rows
+	barX := expression.NewGetFieldWithTable(0, sql.Int64, "bar", "x", true)
+	barY := expression.NewGetFieldWithTable(1, sql.Int64, "bar", "y", true)
+
+	notExists := func(n sql.Node) sql.Expression {
+		return expression.NewNot(plan.NewExistsSubquery(plan.NewSubquery(n, "")))
+	}
+
+	tests := []analyzerFnTestCase{
+		{
+			name: "correlated equality",
+			node: plan.NewFilter(
+				notExists(plan.NewFilter(expression.NewEquals(x, a), bar)),
+				foo,
+			),
+			expected: plan.NewAntiJoin(
+				foo,
+				bar,
+				[]sql.Expression{a},
+				[]sql.Expression{barX},
+				expression.NewEquals(x, a),
+			),
+		},
+		{
+			name: "other conditions",
+			node: plan.NewFilter(
+				expression.NewAnd(
+					notExists(plan.NewProject(
+						[]sql.Expression{expression.NewLiteral(int8(1), sql.Int8)},
+						plan.NewFilter(
+							expression.JoinAnd(
+								expression.NewEquals(a, x),
+								expression.NewGreaterThan(y, b),
+								expression.NewLessThan(y, expression.NewLiteral(int64(10), sql.Int64)),
+							),
+							bar,
+						),
+					)),
+					expression.NewGreaterThan(b, expression.NewLiteral(int64(0), sql.Int64)),
+				),
+				foo,
+			),
+			expected: plan.NewFilter(
+				expression.NewGreaterThan(b, expression.NewLiteral(int64(0), sql.Int64)),
+				plan.NewAntiJoin(
+					foo,
+					plan.NewFilter(expression.NewLessThan(barY, expression.NewLiteral(int64(10), sql.Int64)), bar),
+					[]sql.Expression{a},
+					[]sql.Expression{barX},
+					expression.NewAnd(expression.NewEquals(a, x), expression.NewGreaterThan(y, b)),
+				),
+			),
+		},
+		{
+			name: "correlated inequality",
+			node: plan.NewFilter(
+				notExists(plan.NewFilter(expression.NewGreaterThan(x, a), bar)),
+				foo,
+			),
+			expected: plan.NewAntiJoin(
+				foo,
+				bar,
+				nil,
+				nil,
+				expression.NewGreaterThan(x, a),
+			),
+		},
+		{
+			name: "not in",
+			node: plan.NewFilter(
+				expression.NewNot(plan.NewInSubquery(a, plan.NewSubquery(plan.NewProject([]sql.Expression{barX}, bar), ""))),
+				foo,
+			),
+			expected: plan.NewFilter(
+				expression.NewNot(plan.NewInSubquery(a, plan.NewSubquery(plan.NewProject([]sql.Expression{barX}, bar), ""))),
+				foo,
+			),
+		},
+		{
+			name: "uncorrelated",
+			node: plan.NewFilter(
+				notExists(plan.NewFilter(expression.NewGreaterThan(x, y), bar)),
+				foo,
+			),
+			expected: plan.NewFilter(
+				notExists(plan.NewFilter(expression.NewGreaterThan(x, y), bar)),
+				foo,
+			),
+		},
+		{
+			name: "limit",
+			node: plan.NewFilter(
+				notExists(plan.NewLimit(
+					expression.NewLiteral(int64(1), sql.Int64),
+					plan.NewFilter(expression.NewEquals(x, a), bar),
+				)),
+				foo,
+			),
+			expected: plan.NewFilter(
+				notExists(plan.NewLimit(
+					expression.NewLiteral(int64(1), sql.Int64),
+					plan.NewFilter(expression.NewEquals(x, a), bar),
+				)),
+				foo,
+			),
+		},
+		{
+			name: "in a subquery",
+			node: plan.NewFilter(
+				notExists(plan.NewFilter(expression.NewEquals(x, a), bar)),
+				foo,
+			),
+			scope: (*Scope)(nil).newScope(plan.NewProject([]sql.Expression{a}, foo)),
+			expected: plan.NewFilter(
+				notExists(plan.NewFilter(expression.NewEquals(x, a), bar)),
+				foo,
+			),
+		},
+	}
+
+	runTestCases(t, sql.NewEmptyContext(), tests, NewDefault(sql.NewDatabaseProvider()), getRule("apply_anti_joins"))
+}
@@ -30,12 +30,18 @@ func applyHashIn(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.N
 		e, err := expression.TransformUp(filter.Expression, func(expr sql.Expression) (sql.Expression, error) {
 			switch e := expr.(type) {
 			case *expression.InTuple:
-				switch e.Left().(type) {
-				// cannot HASH IN *plan.Subquery
-				case expression.Tuple, *expression.Literal, *expression.GetField:
-					return expression.NewHashInTuple(e.Left(), e.Right())
-				default:
+				if !isHashableInOperand(e.Left()) {
+					return expr, nil
 				}
+				hit, err := expression.NewHashInTuple(e.Left(), e.Right())
+				if err != nil {
+					return nil, err
+				}
+				// Elements that can't be hashed would never be found, while InTuple compares them as it does any other
+				if hit.DroppedElements() {
+					return expr, nil
+				}
+				return hit, nil
 			default:
 			}
 			return expr, nil
@@ -46,3 +52,22 @@ func applyHashIn(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.N
 		return filter.WithExpressions(e)
 	})
 }
+
+// isHashableInOperand returns whether the left operand of an IN expression can be looked up in a hashed list, which
+// requires it to be a column or a literal, or a tuple of those. Other expressions, such as subqueries, are left to
+// InTuple.
+func isHashableInOperand(e sql.Expression) bool {
+	switch e := e.(type) {
+	case expression.Tuple:
+		for _, el := range e {
+			if !isHashableInOperand(el) {
+				return false
+			}
+		}
+		return true
+	case *expression.Literal, *expression.GetField:
+		return true
+	default:
+		return false
+	}
+}
@@ -89,6 +89,24 @@ func TestApplyHashIn(t *testing.T) {
 				child,
 			),
 		},
+		{
+			name: "filter with NOT IN converted to hash in",
+			node: plan.NewFilter(
+				expression.NewNotInTuple(
+					expression.NewGetField(0, sql.Int64, "foo", false),
+					expression.NewTuple(
+						expression.NewLiteral(int64(2), sql.Int64),
+						expression.NewLiteral(int64(1), sql.Int64),
+						expression.NewLiteral(int64(0), sql.Int64),
+					),
+				),
+				child,
+			),
+			expected: plan.NewFilter(
+				expression.NewNot(hitLiteral),
+				child,
+			),
+		},
 		{
 			name: "hash in preserves sibling expressions",
 			node: plan.NewFilter(
@@ -228,6 +246,87 @@ func TestApplyHashIn(t *testing.T) {
 			),
 			err: expression.ErrUnsupportedHashInSubexpression,
 		},
+		{
+			name: "filter with elements that can't be converted not selected",
+			node: plan.NewFilter(
+				expression.NewInTuple(
+					expression.NewGetField(0, sql.Date, "foo", false),
+					expression.NewTuple(
+						expression.NewLiteral("2020-01-01", sql.LongText),
+						expression.NewLiteral("notadate", sql.LongText),
+					),
+				),
+				child,
+			),
+			expected: plan.NewFilter(
+				expression.NewInTuple(
+					expression.NewGetField(0, sql.Date, "foo", false),
+					expression.NewTuple(
+						expression.NewLiteral("2020-01-01", sql.LongText),
+						expression.NewLiteral("notadate", sql.LongText),
+					),
+				),
+				child,
+			),
+		},
+		{
+			name: "filter with tuple compared to a column not selected",
+			node: plan.NewFilter(
+				expression.NewInTuple(
+					expression.NewGetField(0, sql.Int64, "foo", false),
+					expression.NewTuple(
+						expression.NewLiteral(int64(1), sql.Int64),
+						expression.NewTuple(expression.NewLiteral(int64(2), sql.Int64), expression.NewLiteral(int64(3), sql.Int64)),
+					),
+				),
+				child,
+			),
+			expected: plan.NewFilter(
+				expression.NewInTuple(
+					expression.NewGetField(0, sql.Int64, "foo", false),
+					expression.NewTuple(
+						expression.NewLiteral(int64(1), sql.Int64),
+						expression.NewTuple(expression.NewLiteral(int64(2), sql.Int64), expression.NewLiteral(int64(3), sql.Int64)),
+					),
+				),
+				child,
+			),
+		},
+		{
+			name: "filter with tuple of expressions not selected",
+			node: plan.NewFilter(
+				expression.NewInTuple(
+					expression.NewTuple(
+						expression.NewGetField(0, sql.Int64, "a", false),
+						expression.NewArithmetic(
+							expression.NewGetField(1, sql.Int64, "b", false),
+							expression.NewLiteral(int64(1), sql.Int64),
+							"+",
+						),
+					),
+					expression.NewTuple(
+						expression.NewTuple(expression.NewLiteral(int64(2), sql.Int64), expression.NewLiteral(int64(1), sql.Int64)),
+					),
+				),
+				child,
+			),
+			expected: plan.NewFilter(
+				expression.NewInTuple(
+					expression.NewTuple(
+						expression.NewGetField(0, sql.Int64, "a", false),
+						expression.NewArithmetic(
+							expression.NewGetField(1, sql.Int64, "b", false),
+							expression.NewLiteral(int64(1), sql.Int64),
+							"+",
+						),
+					),
+					expression.NewTuple(
+						expression.NewTuple(expression.NewLiteral(int64(2), sql.Int64), expression.NewLiteral(int64(1), sql.Int64)),
+					),
+				),
+				child,
+			),
+		},
 	}
 
 	runTestCases(t, sql.NewEmptyContext(), tests, NewDefault(sql.NewDatabaseProvider()), getRule("apply_hash_in"))
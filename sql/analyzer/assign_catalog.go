@@ -72,6 +72,10 @@ func assignCatalog(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql
 			nc := *node
 			nc.Catalog = a.Catalog
 			return &nc, nil
+		case *plan.AlterDB:
+			nc := *node
+			nc.Catalog = a.Catalog
+			return &nc, nil
 		case *plan.LockTables:
 			nc := *node
 			nc.Catalog = a.Catalog
@@ -80,6 +84,18 @@ func assignCatalog(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql
 			nc := *node
 			nc.Catalog = a.Catalog
 			return &nc, nil
+		case *plan.DropTable:
+			nc := *node
+			nc.Catalog = a.Catalog
+			return &nc, nil
+		case *plan.RenameTable:
+			nc := *node
+			nc.Catalog = a.Catalog
+			return &nc, nil
+		case *plan.DropView:
+			nc := *node
+			nc.Catalog = a.Catalog
+			return &nc, nil
 		case *plan.ResolvedTable:
 			nc := *node
 			ct, ok := nc.Table.(CatalogTable)
@@ -82,8 +82,12 @@ func getTablesToBeUpdated(node sql.Node) map[string]struct{} {
 	plan.InspectExpressions(node, func(e sql.Expression) bool {
 		switch e := e.(type) {
 		case *expression.SetField:
-			gf := e.Left.(*expression.GetField)
-			ret[gf.Table()] = struct{}{}
+			sql.Inspect(e.Left, func(e sql.Expression) bool {
+				if gf, ok := e.(*expression.GetField); ok {
+					ret[gf.Table()] = struct{}{}
+				}
+				return true
+			})
 			return false
 		}
 
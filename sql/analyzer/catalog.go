@@ -29,6 +29,8 @@ type Catalog struct {
 	builtInFunctions function.Registry
 	mu               sync.RWMutex
 	locks            sessionLocks
+	// lowerCaseTableNames is how names of databases, tables and columns are matched
+	lowerCaseTableNames sql.LowerCaseTableNames
 }
 
 type tableLocks map[string]struct{}
@@ -51,6 +53,7 @@ func NewDatabaseProvider(dbs ...sql.Database) sql.DatabaseProvider {
 }
 
 var _ sql.FunctionProvider = (*Catalog)(nil)
+var _ sql.LowerCaseTableNamesCatalog = (*Catalog)(nil)
 
 func (c *Catalog) AllDatabases() []sql.Database {
 	c.mu.RLock()
@@ -66,7 +69,7 @@ func (c *Catalog) CreateDatabase(ctx *sql.Context, dbName string) error {
 
 	mut, ok := c.provider.(sql.MutableDatabaseProvider)
 	if ok {
-		return mut.CreateDatabase(ctx, dbName)
+		return mut.CreateDatabase(ctx, c.lowerCaseTableNames.Fold(dbName))
 	} else {
 		return sql.ErrImmutableDatabaseProvider.New()
 	}
@@ -88,6 +91,11 @@ func (c *Catalog) RemoveDatabase(ctx *sql.Context, dbName string) error {
 func (c *Catalog) HasDB(db string) bool {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
+
+	if c.lowerCaseTableNames == sql.LowerCaseTableNames_Sensitive {
+		_, err := c.database(db)
+		return err == nil
+	}
 	return c.provider.HasDatabase(db)
 }
 
@@ -95,7 +103,38 @@ func (c *Catalog) HasDB(db string) bool {
 func (c *Catalog) Database(db string) (sql.Database, error) {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
-	return c.provider.Database(db)
+	return c.database(db)
+}
+
+// database returns the database with the given name, matching it as lowerCaseTableNames says to.
+func (c *Catalog) database(name string) (sql.Database, error) {
+	db, err := c.provider.Database(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if !c.matchesName(db, name, db.Name()) {
+		return nil, sql.ErrDatabaseNotFound.New(name)
+	}
+	return db, nil
+}
+
+// matchesName returns whether the name given matches the name of a database or of a table in the database given, as
+// lowerCaseTableNames says to.
+func (c *Catalog) matchesName(db sql.Database, name, stored string) bool {
+	return c.lowerCaseTableNames.MatchesName(db, name, stored)
+}
+
+// LowerCaseTableNames implements sql.LowerCaseTableNamesCatalog.
+func (c *Catalog) LowerCaseTableNames() sql.LowerCaseTableNames {
+	return c.lowerCaseTableNames
+}
+
+// SetLowerCaseTableNames sets how the names of databases, tables and columns are matched.
+func (c *Catalog) SetLowerCaseTableNames(l sql.LowerCaseTableNames) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.lowerCaseTableNames = l
 }
 
 // LockTable adds a lock for the given table and session client. It is assumed
@@ -158,7 +197,7 @@ func (c *Catalog) Table(ctx *sql.Context, dbName, tableName string) (sql.Table,
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 
-	db, err := c.Database(dbName)
+	db, err := c.database(dbName)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -166,7 +205,7 @@ func (c *Catalog) Table(ctx *sql.Context, dbName, tableName string) (sql.Table,
 	tbl, ok, err := db.GetTableInsensitive(ctx, tableName)
 	if err != nil {
 		return nil, nil, err
-	} else if !ok {
+	} else if !ok || !c.matchesName(db, tableName, tbl.Name()) {
 		return nil, nil, suggestSimilarTables(db, ctx, tableName)
 	}
 
@@ -179,7 +218,7 @@ func (c *Catalog) TableAsOf(ctx *sql.Context, dbName, tableName string, asOf int
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 
-	db, err := c.Database(dbName)
+	db, err := c.database(dbName)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -193,7 +232,7 @@ func (c *Catalog) TableAsOf(ctx *sql.Context, dbName, tableName string, asOf int
 
 	if err != nil {
 		return nil, nil, err
-	} else if !ok {
+	} else if !ok || !c.matchesName(db, tableName, tbl.Name()) {
 		return nil, nil, suggestSimilarTablesAsOf(versionedDb, ctx, tableName, asOf)
 	}
 
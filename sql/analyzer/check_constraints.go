@@ -129,12 +129,13 @@ func loadChecks(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.No
 				return node, nil
 			}
 
-			var err error
-			nn.Checks, err = loadChecksFromTable(ctx, table)
+			checks, err := loadChecksFromTable(ctx, table)
 			if err != nil {
 				return nil, err
 			}
 
+			// Any checks already present enforce the check option of a view being inserted into
+			nn.Checks = append(checks, nn.Checks...)
 			return &nn, nil
 		case *plan.Update:
 			rtable := getResolvedTable(node)
@@ -148,13 +149,14 @@ func loadChecks(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.No
 				return node, nil
 			}
 
-			var err error
-			nn := *node
-			nn.Checks, err = loadChecksFromTable(ctx, table)
+			checks, err := loadChecksFromTable(ctx, table)
 			if err != nil {
 				return nil, err
 			}
 
+			// Any checks already present enforce the check option of a view being updated
+			nn := *node
+			nn.Checks = append(checks, nn.Checks...)
 			return &nn, nil
 		case *plan.ShowCreateTable:
 			rtable := getResolvedTable(node)
@@ -95,6 +95,10 @@ func resolveDeclarationsInner(ctx *sql.Context, a *Analyzer, node sql.Node, scop
 				if err := scope.AddCondition(child); err != nil {
 					return nil, err
 				}
+			case *plan.DeclareVariables:
+				if !lastStatementDeclare {
+					return nil, sql.ErrDeclareOrderInvalid.New()
+				}
 			default:
 				lastStatementDeclare = false
 			}
@@ -102,7 +106,7 @@ func resolveDeclarationsInner(ctx *sql.Context, a *Analyzer, node sql.Node, scop
 	} else {
 		for _, child := range children {
 			switch child.(type) {
-			case *plan.DeclareCondition:
+			case *plan.DeclareCondition, *plan.DeclareVariables:
 				return nil, sql.ErrDeclareOrderInvalid.New()
 			}
 		}
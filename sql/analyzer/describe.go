@@ -26,10 +26,22 @@ func resolveDescribeQuery(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scop
 		return n, nil
 	}
 
-	q, err := a.Analyze(ctx, d.Query(), scope)
+	// The query is only analyzed to describe its plan, never executed, which the scope tells the rules analyzing it
+	q, err := a.Analyze(ctx, d.Query(), scope.memo(d))
 	if err != nil {
 		return nil, err
 	}
 
 	return d.WithQuery(StripQueryProcess(q)), nil
 }
+
+// describing returns whether the node being analyzed with the scope given is a query being described by a
+// DescribeQuery node. Such a query is never executed, so it can't write anything.
+func describing(scope *Scope) bool {
+	for _, n := range scope.MemoNodes() {
+		if _, ok := n.(*plan.DescribeQuery); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -81,8 +81,15 @@ func expandStarsForExpressions(a *Analyzer, exprs []sql.Expression, schema sql.S
 	var expressions []sql.Expression
 	for _, e := range exprs {
 		if star, ok := e.(*expression.Star); ok {
+			if star.Table == "" && isDualSchema(schema) {
+				return nil, sql.ErrNoTablesUsed.New()
+			}
+
 			var exprs []sql.Expression
 			for i, col := range schema {
+				if isHiddenJoinColumn(col) {
+					continue
+				}
 				lowerSource := strings.ToLower(col.Source)
 				lowerTable := strings.ToLower(star.Table)
 				if star.Table == "" || lowerTable == lowerSource {
@@ -105,3 +112,9 @@ func expandStarsForExpressions(a *Analyzer, exprs []sql.Expression, schema sql.S
 	a.Log("resolved * to expressions %s", expressions)
 	return expressions, nil
 }
+
+// isDualSchema returns whether the given schema is that of the dual table, which is used as the row source for
+// selects without a FROM clause.
+func isDualSchema(schema sql.Schema) bool {
+	return len(schema) == 1 && schema[0] == dualTable.Schema()[0]
+}
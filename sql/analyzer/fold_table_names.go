@@ -0,0 +1,47 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analyzer
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+// foldTableNames folds the names of tables being created or renamed to lower case, if the catalog stores names in
+// lower case. Names of databases are folded by the catalog when they're created.
+func foldTableNames(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.Node, error) {
+	l := sql.GetLowerCaseTableNames(a.Catalog)
+	if l != sql.LowerCaseTableNames_Lowercase {
+		return n, nil
+	}
+
+	return plan.TransformUp(n, func(n sql.Node) (sql.Node, error) {
+		switch n := n.(type) {
+		case *plan.CreateTable:
+			if name := l.Fold(n.Name()); name != n.Name() {
+				return n.WithName(name), nil
+			}
+			return n, nil
+		case *plan.RenameTable:
+			newNames := make([]string, len(n.NewNames()))
+			for i, name := range n.NewNames() {
+				newNames[i] = l.Fold(name)
+			}
+			return n.WithNewNames(newNames), nil
+		default:
+			return n, nil
+		}
+	})
+}
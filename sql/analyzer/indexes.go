@@ -184,6 +184,9 @@ func getIndexes(
 				if !ok {
 					return nil, errInvalidInRightEvaluation.New(value)
 				}
+				if outOfIntegerRange(cmp.Left().Type(), values...) {
+					return nil, nil
+				}
 
 				lookup, err := sql.NewIndexBuilder(ctx, idx).Equals(ctx, colExprs[0].String(), values...).Build(ctx)
 				if err != nil {
@@ -335,6 +338,9 @@ func getComparisonIndexLookup(
 			if err != nil {
 				return nil, err
 			}
+			if outOfIntegerRange(left.Type(), value) {
+				return nil, nil
+			}
 
 			var lookup sql.IndexLookup
 			switch e.(type) {
@@ -366,6 +372,24 @@ func getComparisonIndexLookup(
 	return nil, nil
 }
 
+// outOfIntegerRange returns whether any of the values given is beyond the range of the integer type given, such as a
+// negative number compared to an unsigned column. Such values can't be keys of an index on a column of that type, so
+// the comparisons that use them are evaluated without an index.
+func outOfIntegerRange(typ sql.Type, values ...interface{}) bool {
+	if !sql.IsInteger(typ) {
+		return false
+	}
+	for _, value := range values {
+		if value == nil {
+			continue
+		}
+		if _, err := typ.Convert(value); sql.ErrOutOfRange.Is(err) {
+			return true
+		}
+	}
+	return false
+}
+
 // Returns an equivalent expression to the one given with the left and right terms reversed. The new left and right side
 // of the expression are returned as well.
 func swapTermsOfExpression(e expression.Comparer) (left sql.Expression, right sql.Expression, newExpr expression.Comparer) {
@@ -849,7 +873,9 @@ func extractColumnExpr(e sql.Expression) (string, *joinColExpr) {
 		}
 
 		leftCol, rightCol := expression.ExtractGetField(left), expression.ExtractGetField(right)
-		if leftCol == nil {
+		// Index lookups are built on the column compared, so an expression of it, such as another comparison as in
+		// (a = 1) = 0, can't be looked up
+		if leftCol == nil || left != sql.Expression(leftCol) {
 			return "", nil
 		}
 
@@ -962,7 +988,21 @@ func containsSubquery(e sql.Expression) bool {
 }
 
 func isEvaluable(e sql.Expression) bool {
-	return !containsColumns(e) && !containsSubquery(e) && !containsBindvars(e)
+	return !containsColumns(e) && !containsSubquery(e) && !containsBindvars(e) && !containsProcedureParams(e)
+}
+
+// containsProcedureParams returns whether the expression given references a parameter or local variable of a stored
+// procedure or stored function, whose value is only known when the statement runs.
+func containsProcedureParams(e sql.Expression) bool {
+	var result bool
+	sql.Inspect(e, func(e sql.Expression) bool {
+		if _, ok := e.(*expression.ProcedureParam); ok {
+			result = true
+			return false
+		}
+		return true
+	})
+	return result
 }
 
 func containsBindvars(e sql.Expression) bool {
@@ -56,18 +56,6 @@ func resolveInsertRows(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope)
 			}
 		}
 
-		source := insert.Source
-		// TriggerExecutor has already been analyzed
-		if _, ok := insert.Source.(*plan.TriggerExecutor); !ok {
-			// Analyze the source of the insert independently
-			source, err = a.Analyze(ctx, insert.Source, scope)
-			if err != nil {
-				return nil, err
-			}
-
-			source = StripQueryProcess(source)
-		}
-
 		dstSchema := insertable.Schema()
 
 		// normalize the column name
@@ -77,7 +65,7 @@ func resolveInsertRows(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope)
 		}
 
 		// If no columns are given and value tuples are not all empty, use the full schema
-		if len(columnNames) == 0 && existsNonZeroValueCount(source) {
+		if len(columnNames) == 0 && existsNonZeroValueCount(insert.Source) {
 			columnNames = make([]string, len(dstSchema))
 			for i, f := range dstSchema {
 				columnNames[i] = f.Name
@@ -89,6 +77,34 @@ func resolveInsertRows(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope)
 			}
 		}
 
+		source := insert.Source
+		if values, ok := source.(*plan.Values); ok && valuesHaveDefaults(values) {
+			err = validateValueCount(columnNames, values)
+			if err != nil {
+				return nil, err
+			}
+
+			source, err = expandDefaultValues(values, dstSchema, columnNames)
+			if err != nil {
+				return nil, err
+			}
+			columnNames = make([]string, len(dstSchema))
+			for i, f := range dstSchema {
+				columnNames[i] = f.Name
+			}
+		}
+
+		// TriggerExecutor has already been analyzed
+		if _, ok := source.(*plan.TriggerExecutor); !ok {
+			// Analyze the source of the insert independently
+			source, err = a.Analyze(ctx, source, scope)
+			if err != nil {
+				return nil, err
+			}
+
+			source = StripQueryProcess(source)
+		}
+
 		err = validateValueCount(columnNames, source)
 		if err != nil {
 			return nil, err
@@ -118,6 +134,70 @@ func existsNonZeroValueCount(values sql.Node) bool {
 	return false
 }
 
+// valuesHaveDefaults returns whether any of the tuples of the values given has a DEFAULT value.
+func valuesHaveDefaults(values *plan.Values) bool {
+	for _, tuple := range values.ExpressionTuples {
+		for _, e := range tuple {
+			if _, ok := e.(*expression.DefaultColumn); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// expandDefaultValues returns the values given with every tuple expanded to the full schema of the destination table,
+// in the same order. The DEFAULT values of a tuple, and the columns it doesn't have a value for, are replaced with the
+// defaults of their columns, so that they are evaluated for every row on its own.
+func expandDefaultValues(values *plan.Values, dstSchema sql.Schema, columnNames []string) (*plan.Values, error) {
+	tuples := make([][]sql.Expression, len(values.ExpressionTuples))
+	for i, tuple := range values.ExpressionTuples {
+		tuples[i] = make([]sql.Expression, len(dstSchema))
+		for j, f := range dstSchema {
+			var value sql.Expression
+			for k, name := range columnNames {
+				if strings.EqualFold(f.Name, name) {
+					value = tuple[k]
+					break
+				}
+			}
+
+			col := f
+			if dc, ok := value.(*expression.DefaultColumn); ok {
+				if dc.Name() != "" {
+					idx := dstSchema.IndexOf(dc.Name(), f.Source)
+					if idx < 0 {
+						return nil, sql.ErrColumnNotFound.New(dc.Name())
+					}
+					col = dstSchema[idx]
+				}
+				value = nil
+			}
+
+			if value == nil {
+				var err error
+				value, err = columnDefaultValue(col)
+				if err != nil {
+					return nil, err
+				}
+			}
+			tuples[i][j] = value
+		}
+	}
+	return plan.NewValues(tuples), nil
+}
+
+// columnDefaultValue returns the expression for the default of the column given, or an error if it doesn't have one.
+func columnDefaultValue(f *sql.Column) (sql.Expression, error) {
+	if f.Default != nil {
+		return f.Default, nil
+	}
+	if !f.Nullable && !f.AutoIncrement {
+		return nil, sql.ErrInsertIntoNonNullableDefaultNullColumn.New(f.Name)
+	}
+	return expression.NewLiteral(nil, sql.Null), nil
+}
+
 // wrapRowSource wraps the original row source in a projection so that its schema matches the full schema of the
 // underlying table, in the same order.
 func wrapRowSource(ctx *sql.Context, insertSource sql.Node, destTbl sql.Table, columnNames []string) (sql.Node, error) {
@@ -62,14 +62,15 @@ func loadTriggers(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.
 			if err != nil {
 				return nil, err
 			}
-			lowercasedNames := make(map[string]struct{})
-			for _, tableName := range node.TableNames() {
-				lowercasedNames[strings.ToLower(tableName)] = struct{}{}
-			}
+			lowerCaseTableNames := sql.GetLowerCaseTableNames(a.Catalog)
 			var triggersForTable []string
 			for _, trigger := range loadedTriggers {
-				if _, ok := lowercasedNames[strings.ToLower(trigger.Table.(*plan.UnresolvedTable).Name())]; ok {
-					triggersForTable = append(triggersForTable, trigger.TriggerName)
+				triggerTable := trigger.Table.(*plan.UnresolvedTable).Name()
+				for _, tableName := range node.TableNames() {
+					if lowerCaseTableNames.Equal(tableName, triggerTable) {
+						triggersForTable = append(triggersForTable, trigger.TriggerName)
+						break
+					}
 				}
 			}
 			return node.WithTriggers(triggersForTable), nil
@@ -95,6 +96,11 @@ func loadTriggersFromDb(ctx *sql.Context, db sql.Database) ([]*plan.CreateTrigge
 			if !ok {
 				return nil, sql.ErrTriggerCreateStatementInvalid.New(trigger.CreateStatement)
 			}
+			triggerPlan.CreatedAt = trigger.CreatedAt
+			triggerPlan.SqlMode = trigger.SqlMode
+			if trigger.Definer != "" {
+				triggerPlan.Definer = trigger.Definer
+			}
 			loadedTriggers = append(loadedTriggers, triggerPlan)
 		}
 	}
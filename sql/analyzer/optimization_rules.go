@@ -43,32 +43,169 @@ func eraseProjection(ctx *sql.Context, a *Analyzer, node sql.Node, scope *Scope)
 
 // optimizeDistinct substitutes a Distinct node for an OrderedDistinct node when the child of Distinct is already
 // ordered. The OrderedDistinct node is much faster and uses much less memory, since it only has to compare the
-// previous row to the current one to determine its distinct-ness.
+// previous row to the current one to determine its distinct-ness. A Sort directly above a Distinct is moved beneath it
+// when it orders all the distinct columns, since the result is the same and the distinct can then be streamed. This
+// isn't done beneath a Limit, which can be better served by a TopN over the Distinct.
 func optimizeDistinct(ctx *sql.Context, a *Analyzer, node sql.Node, scope *Scope) (sql.Node, error) {
 	span, _ := ctx.Span("optimize_distinct")
 	defer span.Finish()
 
-	if n, ok := node.(*plan.Distinct); ok {
-		var sortField *expression.GetField
-		plan.Inspect(n, func(node sql.Node) bool {
-			// TODO: this is a bug. Every column in the output must be sorted in order for OrderedDistinct to produce a
-			//  correct result. This only checks one sort field
-			if sort, ok := node.(*plan.Sort); ok && sortField == nil {
-				if col, ok := sort.SortFields[0].Column.(*expression.GetField); ok {
-					sortField = col
-				}
-				return false
-			}
-			return true
-		})
+	if !node.Resolved() {
+		return node, nil
+	}
 
-		if sortField != nil && n.Schema().Contains(sortField.Name(), sortField.Table()) {
+	return plan.TransformUpCtx(node, nil, func(c plan.TransformContext) (sql.Node, error) {
+		switch n := c.Node.(type) {
+		case *plan.Sort:
+			d, ok := n.Child.(*plan.Distinct)
+			if !ok || !sortsAllColumns(n, d.Schema()) {
+				return n, nil
+			}
+			if _, ok := c.Parent.(*plan.Limit); ok {
+				return n, nil
+			}
+			a.Log("distinct optimized for ordered output by moving sort beneath it")
+			return plan.NewOrderedDistinct(plan.NewSort(n.SortFields, d.Child)), nil
+		case *plan.Distinct:
+			sort := findOrderingSort(n.Child)
+			if sort == nil || !sortsAllColumns(sort, n.Schema()) {
+				return n, nil
+			}
 			a.Log("distinct optimized for ordered output")
 			return plan.NewOrderedDistinct(n.Child), nil
+		default:
+			return c.Node, nil
+		}
+	})
+}
+
+// findOrderingSort returns the Sort node that determines the order of the rows of the node given, or nil if there
+// isn't one or the nodes above it don't keep its order.
+func findOrderingSort(n sql.Node) *plan.Sort {
+	switch n := n.(type) {
+	case *plan.Sort:
+		return n
+	case *plan.Project, *plan.Filter, *plan.Having, *plan.Limit, *plan.Offset:
+		return findOrderingSort(n.Children()[0])
+	default:
+		return nil
+	}
+}
+
+// sortsAllColumns returns whether the sort given places rows that are equal in all the columns of the schema given
+// next to each other. That's the case when its leading sort fields are exactly those columns.
+func sortsAllColumns(sort *plan.Sort, schema sql.Schema) bool {
+	covered := make(map[int]struct{})
+	for _, f := range sort.SortFields {
+		if len(covered) == len(schema) {
+			break
+		}
+		gf, ok := f.Column.(*expression.GetField)
+		if !ok {
+			return false
+		}
+		idx := schema.IndexOf(gf.Name(), gf.Table())
+		if idx < 0 {
+			return false
+		}
+		covered[idx] = struct{}{}
+	}
+	return len(covered) == len(schema)
+}
+
+// optimizeGroupBy substitutes a GroupBy node for an OrderedGroupBy node when the rows of its child are already sorted
+// on its grouping columns. The OrderedGroupBy node only has to keep one group in memory at a time, rather than every
+// group seen. This runs after all other rules, since they only know about GroupBy nodes.
+func optimizeGroupBy(ctx *sql.Context, a *Analyzer, node sql.Node, scope *Scope) (sql.Node, error) {
+	span, _ := ctx.Span("optimize_group_by")
+	defer span.Finish()
+
+	if !node.Resolved() {
+		return node, nil
+	}
+
+	return plan.TransformUp(node, func(node sql.Node) (sql.Node, error) {
+		gb, ok := node.(*plan.GroupBy)
+		if !ok || len(gb.GroupByExprs) == 0 || !groupsOrderedColumns(gb) {
+			return node, nil
+		}
+		a.Log("group by optimized for ordered input")
+		return plan.NewOrderedGroupBy(gb.SelectedExprs, gb.GroupByExprs, gb.Child), nil
+	})
+}
+
+// groupsOrderedColumns returns whether the grouping expressions of the group by given are all columns of its child,
+// and the rows of its child are sorted on exactly those columns before any others.
+func groupsOrderedColumns(gb *plan.GroupBy) bool {
+	schema := gb.Child.Schema()
+	grouped := make(map[int]struct{})
+	for _, e := range gb.GroupByExprs {
+		gf, ok := e.(*expression.GetField)
+		if !ok {
+			return false
+		}
+		idx := schema.IndexOf(gf.Name(), gf.Table())
+		if idx < 0 {
+			return false
+		}
+		grouped[idx] = struct{}{}
+	}
+
+	ordered := orderedColumns(gb.Child)
+	if len(ordered) < len(grouped) {
+		return false
+	}
+	for _, idx := range ordered[:len(grouped)] {
+		if _, ok := grouped[idx]; !ok {
+			return false
 		}
 	}
+	return true
+}
 
-	return node, nil
+// orderedColumns returns the indexes in the schema of the node given of the columns its rows are sorted on, in order of
+// precedence, as far as they can be determined. Returns nil if the rows of the node have no known order.
+func orderedColumns(n sql.Node) []int {
+	switch n := n.(type) {
+	case *plan.Sort:
+		var ordered []int
+		for _, f := range n.SortFields {
+			gf, ok := f.Column.(*expression.GetField)
+			if !ok {
+				break
+			}
+			idx := n.Schema().IndexOf(gf.Name(), gf.Table())
+			if idx < 0 {
+				break
+			}
+			ordered = append(ordered, idx)
+		}
+		return ordered
+	case *plan.Project:
+		var ordered []int
+		childSchema := n.Child.Schema()
+		for _, idx := range orderedColumns(n.Child) {
+			projected := -1
+			for i, e := range n.Projections {
+				if alias, ok := e.(*expression.Alias); ok {
+					e = alias.Child
+				}
+				if gf, ok := e.(*expression.GetField); ok && childSchema.IndexOf(gf.Name(), gf.Table()) == idx {
+					projected = i
+					break
+				}
+			}
+			if projected < 0 {
+				break
+			}
+			ordered = append(ordered, projected)
+		}
+		return ordered
+	case *plan.Filter, *plan.Having, *plan.Limit, *plan.Offset, *plan.TableAlias, *plan.SubqueryAlias:
+		return orderedColumns(n.Children()[0])
+	default:
+		return nil
+	}
 }
 
 // moveJoinConditionsToFilter looks for expressions in a join condition that reference only tables in the left or right
@@ -159,7 +296,8 @@ func moveJoinConditionsToFilter(ctx *sql.Context, a *Analyzer, n sql.Node, scope
 	})
 }
 
-// removeUnnecessaryConverts removes any Convert expressions that don't alter the type of the expression.
+// removeUnnecessaryConverts removes any Convert expressions that don't alter the type of the expression. Conversions
+// with a length are kept, since they may truncate the value.
 func removeUnnecessaryConverts(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.Node, error) {
 	span, _ := ctx.Span("remove_unnecessary_converts")
 	defer span.Finish()
@@ -169,7 +307,7 @@ func removeUnnecessaryConverts(ctx *sql.Context, a *Analyzer, n sql.Node, scope
 	}
 
 	return plan.TransformExpressionsUp(n, func(e sql.Expression) (sql.Expression, error) {
-		if c, ok := e.(*expression.Convert); ok && c.Child.Type() == c.Type() {
+		if c, ok := e.(*expression.Convert); ok && c.TypeLength() == 0 && c.Child.Type() == c.Type() {
 			return c.Child, nil
 		}
 
@@ -314,6 +452,79 @@ func evalFilter(ctx *sql.Context, a *Analyzer, node sql.Node, scope *Scope) (sql
 	})
 }
 
+// pushNotFilters pushes the negations in filters down to the expressions they negate. De Morgan's laws are applied to
+// the negated conjunctions and disjunctions on the way, double negations are removed, and negated comparisons are
+// replaced with the opposite comparisons, e.g. NOT (a < 1) with a >= 1. This lets the conjunctions be split and pushed
+// down, and the comparisons be used for index lookups. The rewritten filters have the same three-valued logic, as the
+// negation of an unknown value is unknown, just like the opposite comparison of NULL.
+func pushNotFilters(ctx *sql.Context, a *Analyzer, node sql.Node, scope *Scope) (sql.Node, error) {
+	if !node.Resolved() {
+		return node, nil
+	}
+
+	return plan.TransformUp(node, func(node sql.Node) (sql.Node, error) {
+		filter, ok := node.(*plan.Filter)
+		if !ok {
+			return node, nil
+		}
+
+		e, changed := pushNot(filter.Expression, false)
+		if !changed {
+			return node, nil
+		}
+		return plan.NewFilter(e, filter.Child), nil
+	})
+}
+
+// pushNot returns the boolean expression given, negated if negate is true, with its negations pushed down as far as
+// they go. Returns whether the expression was rewritten. Double negations are only removed in boolean contexts, where
+// NOT NOT x is as true as x, so the expressions negated aren't looked into.
+func pushNot(e sql.Expression, negate bool) (sql.Expression, bool) {
+	switch e := e.(type) {
+	case *expression.Not:
+		child, changed := pushNot(e.Child, !negate)
+		return child, changed || negate
+	case *expression.And:
+		left, leftChanged := pushNot(e.Left, negate)
+		right, rightChanged := pushNot(e.Right, negate)
+		if negate {
+			return expression.NewOr(left, right), true
+		}
+		if !leftChanged && !rightChanged {
+			return e, false
+		}
+		return expression.NewAnd(left, right), true
+	case *expression.Or:
+		left, leftChanged := pushNot(e.Left, negate)
+		right, rightChanged := pushNot(e.Right, negate)
+		if negate {
+			return expression.NewAnd(left, right), true
+		}
+		if !leftChanged && !rightChanged {
+			return e, false
+		}
+		return expression.NewOr(left, right), true
+	}
+
+	if !negate {
+		return e, false
+	}
+
+	switch e := e.(type) {
+	case *expression.LessThan:
+		return expression.NewGreaterThanOrEqual(e.Left(), e.Right()), true
+	case *expression.LessThanOrEqual:
+		return expression.NewGreaterThan(e.Left(), e.Right()), true
+	case *expression.GreaterThan:
+		return expression.NewLessThanOrEqual(e.Left(), e.Right()), true
+	case *expression.GreaterThanOrEqual:
+		return expression.NewLessThan(e.Left(), e.Right()), true
+	default:
+		// NOT (a = 1) is how a <> 1 is represented
+		return expression.NewNot(e), false
+	}
+}
+
 func isFalse(e sql.Expression) bool {
 	lit, ok := e.(*expression.Literal)
 	if ok && lit != nil && lit.Type() == sql.Boolean && lit.Value() != nil {
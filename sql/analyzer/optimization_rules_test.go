@@ -108,11 +108,48 @@ func TestOptimizeDistinct(t *testing.T) {
 			plan.NewSort(
 				[]sql.SortField{
 					{Column: gf(0, "foo", "a")},
+					{Column: gf(1, "foo", "b")},
 				},
 				plan.NewResolvedTable(t1, nil, nil),
 			),
 			true,
 		},
+		{
+			"sort on only some projected columns",
+			plan.NewSort(
+				[]sql.SortField{
+					{Column: gf(0, "foo", "a")},
+				},
+				plan.NewResolvedTable(t1, nil, nil),
+			),
+			false,
+		},
+		{
+			"sort on a column that isn't projected before the projected ones",
+			plan.NewSort(
+				[]sql.SortField{
+					{Column: gf(0, "foo", "a")},
+					{Column: gf(0, "foo", "c")},
+					{Column: gf(1, "foo", "b")},
+				},
+				plan.NewResolvedTable(t1, nil, nil),
+			),
+			false,
+		},
+		{
+			"sort beneath a projection",
+			plan.NewProject(
+				[]sql.Expression{gf(0, "foo", "b")},
+				plan.NewSort(
+					[]sql.SortField{
+						{Column: gf(1, "foo", "b")},
+						{Column: gf(0, "foo", "a")},
+					},
+					plan.NewResolvedTable(t1, nil, nil),
+				),
+			),
+			true,
+		},
 	}
 
 	rule := getRule("optimize_distinct")
@@ -128,6 +165,142 @@ func TestOptimizeDistinct(t *testing.T) {
 	}
 }
 
+func TestOptimizeDistinctSortAbove(t *testing.T) {
+	t1 := memory.NewTable("foo", sql.NewPrimaryKeySchema(sql.Schema{
+		{Name: "a", Source: "foo"},
+		{Name: "b", Source: "foo"},
+	}))
+	table := plan.NewResolvedTable(t1, nil, nil)
+	sortFields := []sql.SortField{
+		{Column: gf(1, "foo", "b")},
+		{Column: gf(0, "foo", "a")},
+	}
+
+	rule := getRule("optimize_distinct")
+
+	node, err := rule.Apply(sql.NewEmptyContext(), nil, plan.NewSort(sortFields, plan.NewDistinct(table)), nil)
+	require.NoError(t, err)
+	require.Equal(t, plan.NewOrderedDistinct(plan.NewSort(sortFields, table)), node)
+
+	// A sort beneath a limit is left for a TopN
+	limit := plan.NewLimit(expression.NewLiteral(1, sql.Int64), plan.NewSort(sortFields, plan.NewDistinct(table)))
+	node, err = rule.Apply(sql.NewEmptyContext(), nil, limit, nil)
+	require.NoError(t, err)
+	require.Equal(t, limit, node)
+
+	// A sort on only some of the distinct columns doesn't make equal rows adjacent
+	sort := plan.NewSort(sortFields[:1], plan.NewDistinct(table))
+	node, err = rule.Apply(sql.NewEmptyContext(), nil, sort, nil)
+	require.NoError(t, err)
+	require.Equal(t, sort, node)
+}
+
+func TestOptimizeGroupBy(t *testing.T) {
+	t1 := memory.NewTable("foo", sql.NewPrimaryKeySchema(sql.Schema{
+		{Name: "a", Source: "foo"},
+		{Name: "b", Source: "foo"},
+	}))
+
+	testCases := []struct {
+		name      string
+		grouping  []sql.Expression
+		child     sql.Node
+		optimized bool
+	}{
+		{
+			"without sort",
+			[]sql.Expression{gf(0, "foo", "a")},
+			plan.NewResolvedTable(t1, nil, nil),
+			false,
+		},
+		{
+			"sort on grouping column",
+			[]sql.Expression{gf(0, "foo", "a")},
+			plan.NewSort(
+				[]sql.SortField{
+					{Column: gf(0, "foo", "a")},
+				},
+				plan.NewResolvedTable(t1, nil, nil),
+			),
+			true,
+		},
+		{
+			"sort on grouping columns in another order",
+			[]sql.Expression{gf(0, "foo", "a"), gf(1, "foo", "b")},
+			plan.NewSort(
+				[]sql.SortField{
+					{Column: gf(1, "foo", "b")},
+					{Column: gf(0, "foo", "a")},
+				},
+				plan.NewResolvedTable(t1, nil, nil),
+			),
+			true,
+		},
+		{
+			"sort on another column first",
+			[]sql.Expression{gf(0, "foo", "a")},
+			plan.NewSort(
+				[]sql.SortField{
+					{Column: gf(1, "foo", "b")},
+					{Column: gf(0, "foo", "a")},
+				},
+				plan.NewResolvedTable(t1, nil, nil),
+			),
+			false,
+		},
+		{
+			"sort on only some grouping columns",
+			[]sql.Expression{gf(0, "foo", "a"), gf(1, "foo", "b")},
+			plan.NewSort(
+				[]sql.SortField{
+					{Column: gf(0, "foo", "a")},
+				},
+				plan.NewResolvedTable(t1, nil, nil),
+			),
+			false,
+		},
+		{
+			"grouping on an expression",
+			[]sql.Expression{expression.NewNot(gf(0, "foo", "a"))},
+			plan.NewSort(
+				[]sql.SortField{
+					{Column: gf(0, "foo", "a")},
+				},
+				plan.NewResolvedTable(t1, nil, nil),
+			),
+			false,
+		},
+		{
+			"sort beneath a subquery alias",
+			[]sql.Expression{gf(0, "sq", "b")},
+			plan.NewSubqueryAlias("sq", "",
+				plan.NewProject(
+					[]sql.Expression{gf(1, "foo", "b")},
+					plan.NewSort(
+						[]sql.SortField{
+							{Column: gf(1, "foo", "b")},
+						},
+						plan.NewResolvedTable(t1, nil, nil),
+					),
+				),
+			),
+			true,
+		},
+	}
+
+	rule := getRuleFrom(OnceAfterAll, "optimize_group_by")
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			node, err := rule.Apply(sql.NewEmptyContext(), nil, plan.NewGroupBy(tt.grouping, tt.grouping, tt.child), nil)
+			require.NoError(t, err)
+
+			_, ok := node.(*plan.OrderedGroupBy)
+			require.Equal(t, tt.optimized, ok)
+		})
+	}
+}
+
 func TestMoveJoinConditionsToFilter(t *testing.T) {
 	t1 := memory.NewTable("t1", sql.NewPrimaryKeySchema(sql.Schema{
 		{Name: "a", Source: "t1", Type: sql.Int64},
@@ -399,6 +572,51 @@ func TestEvalFilter(t *testing.T) {
 	}
 }
 
+func TestPushNotFilters(t *testing.T) {
+	inner := memory.NewTable("foo", sql.PrimaryKeySchema{})
+	rule := getRule("push_not_filters")
+
+	testCases := []struct {
+		filter   sql.Expression
+		expected sql.Expression
+	}{
+		{
+			not(lt(col(0, "foo", "a"), lit(1))),
+			gte(col(0, "foo", "a"), lit(1)),
+		},
+		{
+			not(gte(col(0, "foo", "a"), lit(1))),
+			lt(col(0, "foo", "a"), lit(1)),
+		},
+		{
+			not(and(eq(col(0, "foo", "a"), lit(1)), eq(col(1, "foo", "b"), lit(2)))),
+			or(not(eq(col(0, "foo", "a"), lit(1))), not(eq(col(1, "foo", "b"), lit(2)))),
+		},
+		{
+			not(or(lte(col(0, "foo", "a"), lit(1)), not(gt(col(1, "foo", "b"), lit(2))))),
+			and(gt(col(0, "foo", "a"), lit(1)), gt(col(1, "foo", "b"), lit(2))),
+		},
+		{
+			and(eq(col(0, "foo", "a"), lit(1)), not(not(not(lt(col(1, "foo", "b"), lit(2)))))),
+			and(eq(col(0, "foo", "a"), lit(1)), gte(col(1, "foo", "b"), lit(2))),
+		},
+		{
+			not(expression.NewIsNull(col(0, "foo", "a"))),
+			not(expression.NewIsNull(col(0, "foo", "a"))),
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.filter.String(), func(t *testing.T) {
+			require := require.New(t)
+			node := plan.NewFilter(tt.filter, plan.NewResolvedTable(inner, nil, nil))
+			result, err := rule.Apply(sql.NewEmptyContext(), NewDefault(nil), node, nil)
+			require.NoError(err)
+			require.Equal(plan.NewFilter(tt.expected, plan.NewResolvedTable(inner, nil, nil)), result)
+		})
+	}
+}
+
 func TestRemoveUnnecessaryConverts(t *testing.T) {
 	testCases := []struct {
 		name      string
@@ -446,3 +664,117 @@ func TestRemoveUnnecessaryConverts(t *testing.T) {
 		})
 	}
 }
+
+func TestInsertTopNBelowProjection(t *testing.T) {
+	require := require.New(t)
+	f := getRule("insert_topn")
+	ctx := sql.NewEmptyContext()
+
+	table := memory.NewTable("mytable", sql.NewPrimaryKeySchema(sql.Schema{{
+		Name: "i", Source: "mytable", Type: sql.Int64,
+	}}))
+	for i := int64(1); i <= 5; i++ {
+		require.NoError(table.Insert(ctx, sql.NewRow(i)))
+	}
+	dual := memory.NewTable("", sql.PrimaryKeySchema{})
+	require.NoError(dual.Insert(ctx, nil))
+
+	// The subquery selects the outer column i, counting how many times it's evaluated
+	var evaluations int
+	subquery := plan.NewSubquery(
+		plan.NewProject(
+			[]sql.Expression{&countingExpression{
+				UnaryExpression: expression.UnaryExpression{Child: expression.NewGetFieldWithTable(0, sql.Int64, "mytable", "i", false)},
+				count:           &evaluations,
+			}},
+			plan.NewResolvedTable(dual, nil, nil),
+		),
+		"select i",
+	)
+
+	node := plan.NewLimit(
+		expression.NewLiteral(2, sql.Int64),
+		plan.NewSort(
+			[]sql.SortField{{Column: expression.NewGetFieldWithTable(0, sql.Int64, "mytable", "i", false), Order: sql.Descending}},
+			plan.NewProject(
+				[]sql.Expression{
+					expression.NewGetFieldWithTable(0, sql.Int64, "mytable", "i", false),
+					expression.NewAlias("x", subquery),
+				},
+				plan.NewResolvedTable(table, nil, nil),
+			),
+		),
+	)
+
+	expected := plan.NewLimit(
+		expression.NewLiteral(2, sql.Int64),
+		plan.NewProject(
+			[]sql.Expression{
+				expression.NewGetFieldWithTable(0, sql.Int64, "mytable", "i", false),
+				expression.NewAlias("x", subquery),
+			},
+			plan.NewTopN(
+				[]sql.SortField{{Column: expression.NewGetFieldWithTable(0, sql.Int64, "mytable", "i", false), Order: sql.Descending}},
+				expression.NewLiteral(2, sql.Int64),
+				plan.NewResolvedTable(table, nil, nil),
+			),
+		),
+	)
+
+	result, err := f.Apply(ctx, NewDefault(nil), node, nil)
+	require.NoError(err)
+	require.Equal(expected, result)
+
+	rows, err := sql.NodeToRows(ctx, result)
+	require.NoError(err)
+	require.Equal([]sql.Row{{int64(5), int64(5)}, {int64(4), int64(4)}}, rows)
+	require.Equal(2, evaluations)
+
+	// Sorting on the subquery itself requires evaluating it for every row
+	node = plan.NewLimit(
+		expression.NewLiteral(2, sql.Int64),
+		plan.NewSort(
+			[]sql.SortField{{Column: expression.NewGetField(1, sql.Int64, "x", false), Order: sql.Descending}},
+			plan.NewProject(
+				[]sql.Expression{
+					expression.NewGetFieldWithTable(0, sql.Int64, "mytable", "i", false),
+					expression.NewAlias("x", subquery),
+				},
+				plan.NewResolvedTable(table, nil, nil),
+			),
+		),
+	)
+
+	result, err = f.Apply(ctx, NewDefault(nil), node, nil)
+	require.NoError(err)
+	limit, ok := result.(*plan.Limit)
+	require.True(ok)
+	_, ok = limit.Child.(*plan.TopN)
+	require.True(ok)
+}
+
+// countingExpression evaluates its child, counting how many times it's evaluated.
+type countingExpression struct {
+	expression.UnaryExpression
+	count *int
+}
+
+func (c *countingExpression) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	*c.count++
+	return c.Child.Eval(ctx, row)
+}
+
+func (c *countingExpression) Type() sql.Type {
+	return c.Child.Type()
+}
+
+func (c *countingExpression) String() string {
+	return c.Child.String()
+}
+
+func (c *countingExpression) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(c, len(children), 1)
+	}
+	return &countingExpression{UnaryExpression: expression.UnaryExpression{Child: children[0]}, count: c.count}, nil
+}
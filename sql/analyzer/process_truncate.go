@@ -64,7 +64,7 @@ func processTruncate(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (s
 
 func deleteToTruncate(ctx *sql.Context, a *Analyzer, deletePlan *plan.DeleteFrom) (sql.Node, error) {
 	tbl, ok := deletePlan.Child.(*plan.ResolvedTable)
-	if !ok {
+	if !ok || len(deletePlan.Targets) > 0 {
 		return deletePlan, nil
 	}
 	tblName := strings.ToLower(tbl.Name())
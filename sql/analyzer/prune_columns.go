@@ -50,9 +50,9 @@ func pruneColumns(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.
 	// Skip pruning columns for insert statements. For inserts involving a select (INSERT INTO table1 SELECT a,b FROM
 	// table2), all columns from the select are used for the insert, and error checking for schema compatibility
 	// happens at execution time. Otherwise the logic below will convert a Project to a ResolvedTable for the selected
-	// table, which can alter the column order of the select.
+	// table, which can alter the column order of the select. The same goes for the variables of SELECT ... INTO.
 	switch n := n.(type) {
-	case *plan.InsertInto, *plan.CreateTrigger:
+	case *plan.InsertInto, *plan.CreateTrigger, *plan.Into:
 		return n, nil
 	}
 
@@ -112,19 +112,20 @@ func pruneSubqueryColumns(
 	n *plan.SubqueryAlias,
 	parentColumns usedColumns,
 ) (sql.Node, error) {
+	// The columns of a subquery with a column list are named by their position, which pruning would change
+	if len(n.Columns) > 0 {
+		return n, nil
+	}
+
 	a.Log("pruning columns of subquery with alias %q", n.Name())
 
 	columns := make(usedColumns)
 
 	// The columns coming from the parent have the subquery alias name as the source. We need to find the real table in
-	// order to prune the subquery correctly. The columns might also have been renamed.
+	// order to prune the subquery correctly.
 	tableByCol := make(map[string]string)
-	for i, col := range n.Child.Schema() {
-		name := col.Name
-		if len(n.Columns) > 0 {
-			name = n.Columns[i]
-		}
-		tableByCol[name] = col.Source
+	for _, col := range n.Child.Schema() {
+		tableByCol[col.Name] = col.Source
 	}
 
 	for col := range parentColumns[n.Name()] {
@@ -233,6 +233,7 @@ var validColumnDefaultFuncs = map[string]struct{}{
 	"multipoint":                         {},
 	"multipolygon":                       {},
 	"name_const":                         {},
+	"nextval":                            {},
 	"now":                                {},
 	"nth_value":                          {},
 	"ntile":                              {},
@@ -555,6 +555,9 @@ func resolveColumnExpression(a *Analyzer, n sql.Node, e column, columns map[tabl
 	name := strings.ToLower(e.Name())
 	table := strings.ToLower(e.Table())
 	col, ok := columns[tableCol{table, name}]
+	if ok && !columnMatchesName(a, n, e, col) {
+		ok = false
+	}
 	if !ok {
 		switch uc := e.(type) {
 		case *expression.UnresolvedColumn:
@@ -589,6 +592,25 @@ func resolveColumnExpression(a *Analyzer, n sql.Node, e column, columns map[tabl
 	), nil
 }
 
+// columnMatchesName returns whether the column expression given matches the name of the column it was found to refer
+// to, as the catalog's LowerCaseTableNames says to. Columns of information_schema tables are always matched
+// case-insensitively, as in MySQL.
+func columnMatchesName(a *Analyzer, n sql.Node, e column, col indexedCol) bool {
+	if a == nil || sql.GetLowerCaseTableNames(a.Catalog) != sql.LowerCaseTableNames_Sensitive || col.Name == e.Name() {
+		return true
+	}
+
+	var infoSchemaTable bool
+	plan.Inspect(n, func(n sql.Node) bool {
+		if rt, ok := n.(*plan.ResolvedTable); ok && rt.Database != nil {
+			infoSchemaTable = strings.EqualFold(rt.Database.Name(), "information_schema") &&
+				strings.EqualFold(rt.Name(), col.Source)
+		}
+		return !infoSchemaTable
+	})
+	return infoSchemaTable
+}
+
 // pushdownGroupByAliases reorders the aggregation in a groupby so aliases defined in it can be resolved in the grouping
 // of the groupby. To do so, all aliases are pushed down to a projection node under the group by.
 func pushdownGroupByAliases(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.Node, error) {
@@ -599,12 +621,23 @@ func pushdownGroupByAliases(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Sc
 	// replacedAliases is a map of original expression string to alias that has been pushed down below the GroupBy in
 	// the new projection node.
 	replacedAliases := make(map[string]string)
+	// selectedAliases is the set of alias names defined by the GroupBy. Columns above the GroupBy that name one of them
+	// refer to the alias rather than to the column it may shadow, so they are never replaced.
+	selectedAliases := make(map[string]struct{})
 	return plan.TransformUp(n, func(n sql.Node) (sql.Node, error) {
 		// For any Expressioner node above the GroupBy, we need to apply the same alias replacement as we did in the
 		// GroupBy itself.
 		ex, ok := n.(sql.Expressioner)
 		if ok && len(replacedAliases) > 0 {
-			newExprs := replaceExpressionsWithAliases(ex.Expressions(), replacedAliases)
+			exprs := ex.Expressions()
+			newExprs := replaceExpressionsWithAliases(exprs, replacedAliases)
+			for i, e := range exprs {
+				if col, ok := e.(*expression.UnresolvedColumn); ok && col.Table() == "" {
+					if _, ok := selectedAliases[strings.ToLower(col.Name())]; ok {
+						newExprs[i] = e
+					}
+				}
+			}
 			return ex.WithExpressions(newExprs...)
 		}
 
@@ -650,6 +683,9 @@ func pushdownGroupByAliases(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Sc
 		var needsReorder bool
 		for _, expr := range g.SelectedExprs {
 			alias, ok := expr.(*expression.Alias)
+			if ok {
+				selectedAliases[strings.ToLower(alias.Name())] = struct{}{}
+			}
 			// Note that aliases of aggregations cannot be used in the grouping
 			// because the grouping is needed before computing the aggregation.
 			if !ok || containsAggregation(alias) {
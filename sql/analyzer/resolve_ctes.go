@@ -110,6 +110,14 @@ func stripWith(ctx *sql.Context, a *Analyzer, n sql.Node, ctes map[string]sql.No
 		cteName := cte.Subquery.Name()
 		subquery := cte.Subquery
 
+		if with.Recursive {
+			var err error
+			subquery, err = recursiveCte(subquery, cte.Columns)
+			if err != nil {
+				return nil, err
+			}
+		}
+
 		if len(cte.Columns) > 0 {
 			schemaLen := schemaLength(subquery)
 			if schemaLen != len(cte.Columns) {
@@ -125,6 +133,56 @@ func stripWith(ctx *sql.Context, a *Analyzer, n sql.Node, ctes map[string]sql.No
 	return with.Child, nil
 }
 
+// recursiveCte returns the definition given of a common table expression in a WITH RECURSIVE clause, with a RecursiveCte
+// node combining its parts if it refers to itself. In the recursive part, the references to the common table
+// expression are replaced with a RecursiveTable, whose schema is set once the anchor part is resolved.
+func recursiveCte(subquery *plan.SubqueryAlias, columns []string) (*plan.SubqueryAlias, error) {
+	name := subquery.Name()
+	if !refersToTable(subquery.Child, name) {
+		return subquery, nil
+	}
+
+	node, distinct := subquery.Child, false
+	if d, ok := node.(*plan.Distinct); ok {
+		node, distinct = d.Child, true
+	}
+	union, ok := node.(*plan.Union)
+	if !ok {
+		return nil, sql.ErrCteRecursionWithoutUnion.New(name)
+	}
+	if refersToTable(union.Left(), name) {
+		return nil, sql.ErrCteRecursiveAnchor.New(name)
+	}
+
+	recursive, err := transformUpWithOpaque(union.Right(), func(n sql.Node) (sql.Node, error) {
+		if t, ok := n.(*plan.UnresolvedTable); ok && t.Database == "" && strings.EqualFold(t.Name(), name) {
+			return plan.NewResolvedTable(plan.NewRecursiveTable(name, nil), nil, nil), nil
+		}
+		return n, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	child, err := subquery.WithChildren(plan.NewRecursiveCte(union.Left(), recursive, name, columns, distinct))
+	if err != nil {
+		return nil, err
+	}
+	return child.(*plan.SubqueryAlias), nil
+}
+
+// refersToTable returns whether the node given refers to the table named, without a database qualifier.
+func refersToTable(node sql.Node, name string) bool {
+	refers := false
+	plan.Inspect(node, func(n sql.Node) bool {
+		if t, ok := n.(*plan.UnresolvedTable); ok && t.Database == "" && strings.EqualFold(t.Name(), name) {
+			refers = true
+		}
+		return !refers
+	})
+	return refers
+}
+
 // transformUpWithOpaque applies a transformation function to the given tree from the bottom up, including through
 // opaque nodes. This method is generally not safe to use for a transformation. Opaque nodes need to be considered in
 // isolation except for very specific exceptions.
@@ -195,7 +253,7 @@ func liftCommonTableExpressions(ctx *sql.Context, a *Analyzer, n sql.Node, scope
 	return plan.TransformUp(n, func(n sql.Node) (sql.Node, error) {
 		if union, isUnion := n.(*plan.Union); isUnion {
 			if cte, isCTE := union.Left().(*plan.With); isCTE {
-				return plan.NewWith(plan.NewUnion(cte.Child, union.Right()), cte.CTEs), nil
+				return cte.WithChildren(plan.NewUnion(cte.Child, union.Right()))
 			}
 			l, err := liftCommonTableExpressions(ctx, a, union.Left(), scope)
 			if err != nil {
@@ -212,7 +270,7 @@ func liftCommonTableExpressions(ctx *sql.Context, a *Analyzer, n sql.Node, scope
 		}
 		if distinct, isDistinct := n.(*plan.Distinct); isDistinct {
 			if cte, isCTE := distinct.Child.(*plan.With); isCTE {
-				return plan.NewWith(plan.NewDistinct(cte.Child), cte.CTEs), nil
+				return cte.WithChildren(plan.NewDistinct(cte.Child))
 			}
 		}
 		return n, nil
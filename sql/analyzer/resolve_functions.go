@@ -17,6 +17,7 @@ package analyzer
 import (
 	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/expression/function/aggregation/window"
 	"github.com/dolthub/go-mysql-server/sql/plan"
 )
 
@@ -30,7 +31,23 @@ func resolveFunctions(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (
 			return n, nil
 		}
 
-		return plan.TransformExpressionsUp(n, resolveFunctionsInExpr(ctx, a))
+		n, err := plan.TransformExpressionsUp(n, resolveFunctionsInExpr(ctx, a))
+		if err != nil {
+			return nil, err
+		}
+
+		// The parser only recognizes the built-in aggregate functions, so a projection of a custom aggregate function
+		// is parsed as a Project, and needs to become a GroupBy with a single group.
+		if p, ok := n.(*plan.Project); ok {
+			for _, e := range p.Projections {
+				if containsAggregation(e) {
+					a.Log("projection contains aggregations, replacing with group by")
+					return plan.NewGroupBy(p.Projections, nil, p.Child), nil
+				}
+			}
+		}
+
+		return n, nil
 	})
 }
 
@@ -47,6 +64,17 @@ func resolveFunctionsInExpr(ctx *sql.Context, a *Analyzer) sql.TransformExprFunc
 
 		n := uf.Name()
 		f, err := a.Catalog.Function(n)
+		if sql.ErrFunctionNotFound.Is(err) {
+			// Built-in functions take precedence over the stored functions of the current database
+			sf, sfErr := resolveStoredFunction(ctx, a, uf)
+			if sfErr != nil {
+				return nil, sfErr
+			}
+			if sf != nil {
+				a.Log("resolved stored function %q", n)
+				return sf, nil
+			}
+		}
 		if err != nil {
 			return nil, err
 		}
@@ -64,6 +92,10 @@ func resolveFunctionsInExpr(ctx *sql.Context, a *Analyzer) sql.TransformExprFunc
 			if err != nil {
 				return nil, err
 			}
+		} else if agg, ok := rf.(sql.Aggregation); ok && uf.Window != nil && len(uf.Window.PartitionBy) > 0 {
+			// The Window node evaluates plain aggregations over all of its rows, which is only right for an empty
+			// OVER () clause. Partitioned ones are evaluated as window functions.
+			rf = window.NewAggregate(agg, uf.Window)
 		}
 
 		a.Log("resolved function %q", n)
@@ -420,6 +420,20 @@ func aggregationEquals(ctx *sql.Context, a, b sql.Expression) bool {
 			return false
 		}
 
+		return aggregationChildEquals(ctx, a.Child, b.Child)
+	case *aggregation.BitAggregation:
+		b, ok := b.(*aggregation.BitAggregation)
+		if !ok || a.FunctionName() != b.FunctionName() {
+			return false
+		}
+
+		return aggregationChildEquals(ctx, a.Child, b.Child)
+	case *aggregation.Variance:
+		b, ok := b.(*aggregation.Variance)
+		if !ok || a.IsSample() != b.IsSample() || a.IsStdDev() != b.IsStdDev() {
+			return false
+		}
+
 		return aggregationChildEquals(ctx, a.Child, b.Child)
 	case *aggregation.Min:
 		b, ok := b.(*aggregation.Min)
@@ -456,6 +470,14 @@ func aggregationEquals(ctx *sql.Context, a, b sql.Expression) bool {
 		}
 
 		return aggregationChildEquals(ctx, a.Child, b.Child)
+	case *aggregation.JSONObjectAgg:
+		b, ok := b.(*aggregation.JSONObjectAgg)
+		if !ok {
+			return false
+		}
+
+		ac, bc := a.Children(), b.Children()
+		return aggregationChildEquals(ctx, ac[0], bc[0]) && aggregationChildEquals(ctx, ac[1], bc[1])
 	default:
 		return false
 	}
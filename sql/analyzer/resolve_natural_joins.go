@@ -40,6 +40,17 @@ func resolveNaturalJoins(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope
 	})
 }
 
+// hiddenJoinColumnPrefix begins the names of the join columns of the second table of an outer natural join, which the
+// join projects after its own columns so that qualified references to them resolve, but which stars don't expand to.
+const hiddenJoinColumnPrefix = "__natural_join_"
+
+// resolveNaturalJoin transforms the natural join given into a join on the equality of the columns it joins by,
+// projecting a single coalesced column for each of them, followed by the other columns of the first and second tables,
+// as MySQL does. The first table is the right one of a right join, and the left one otherwise. The coalesced columns
+// come from the first table, which is never NULL-extended in an outer join, and are in its order. References to the
+// join columns of the second table of an inner join are replaced with references to the coalesced columns. The second
+// table of an outer join may be NULL-extended, so its join columns are projected as hidden columns instead, and
+// references to them are replaced with references to the hidden columns.
 func resolveNaturalJoin(
 	n *plan.NaturalJoin,
 	replacements map[tableCol]tableCol,
@@ -53,51 +64,75 @@ func resolveNaturalJoin(
 	leftSchema := n.Left().Schema()
 	rightSchema := n.Right().Schema()
 
-	var conditions, common, left, right []sql.Expression
-	for i, lcol := range leftSchema {
-		leftCol := expression.NewGetFieldWithTable(
-			i,
-			lcol.Type,
-			lcol.Source,
-			lcol.Name,
-			lcol.Nullable,
+	for _, name := range n.Using {
+		if _, col := findCol(leftSchema, name); col == nil {
+			return nil, sql.ErrColumnNotFound.New(name)
+		}
+		if _, col := findCol(rightSchema, name); col == nil {
+			return nil, sql.ErrColumnNotFound.New(name)
+		}
+	}
+
+	first, second := leftSchema, rightSchema
+	firstOffset, secondOffset := 0, len(leftSchema)
+	if n.Type == plan.JoinTypeRight {
+		first, second = rightSchema, leftSchema
+		firstOffset, secondOffset = len(leftSchema), 0
+	}
+
+	var conditions, common, firstOnly, secondOnly, hidden []sql.Expression
+	joined := make(map[string]bool)
+	for i, fcol := range first {
+		firstCol := expression.NewGetFieldWithTable(
+			firstOffset+i,
+			fcol.Type,
+			fcol.Source,
+			fcol.Name,
+			fcol.Nullable,
 		)
-		if idx, rcol := findCol(rightSchema, lcol.Name); rcol != nil {
-			common = append(common, leftCol)
-			replacements[tableCol{strings.ToLower(rcol.Source), strings.ToLower(rcol.Name)}] = tableCol{
-				strings.ToLower(lcol.Source), strings.ToLower(lcol.Name),
-			}
+		idx, scol := findCol(second, fcol.Name)
+		if scol == nil || (n.Using != nil && !containsColumnName(n.Using, fcol.Name)) {
+			firstOnly = append(firstOnly, firstCol)
+			continue
+		}
 
-			conditions = append(
-				conditions,
-				expression.NewEquals(
-					leftCol,
-					expression.NewGetFieldWithTable(
-						len(leftSchema)+idx,
-						rcol.Type,
-						rcol.Source,
-						rcol.Name,
-						rcol.Nullable,
-					),
-				),
-			)
+		common = append(common, firstCol)
+		joined[strings.ToLower(scol.Name)] = true
+
+		secondCol := expression.NewGetFieldWithTable(
+			secondOffset+idx,
+			scol.Type,
+			scol.Source,
+			scol.Name,
+			scol.Nullable,
+		)
+
+		secondKey := tableCol{strings.ToLower(scol.Source), strings.ToLower(scol.Name)}
+		if n.Type == plan.JoinTypeInner {
+			replacements[secondKey] = tableCol{strings.ToLower(fcol.Source), strings.ToLower(fcol.Name)}
 		} else {
-			left = append(left, leftCol)
+			name := hiddenJoinColumnPrefix + secondKey.table + "." + secondKey.col
+			replacements[secondKey] = tableCol{"", name}
+			hidden = append(hidden, expression.NewAlias(name, secondCol))
+		}
+
+		if n.Type == plan.JoinTypeRight {
+			conditions = append(conditions, expression.NewEquals(secondCol, firstCol))
+		} else {
+			conditions = append(conditions, expression.NewEquals(firstCol, secondCol))
 		}
 	}
 
-	if len(conditions) == 0 {
+	if len(conditions) == 0 && n.Type == plan.JoinTypeInner {
 		return plan.NewCrossJoin(n.Left(), n.Right()), nil
 	}
 
-	for i, col := range rightSchema {
-		source := strings.ToLower(col.Source)
-		name := strings.ToLower(col.Name)
-		if _, ok := replacements[tableCol{source, name}]; !ok {
-			right = append(
-				right,
+	for i, col := range second {
+		if !joined[strings.ToLower(col.Name)] {
+			secondOnly = append(
+				secondOnly,
 				expression.NewGetFieldWithTable(
-					len(leftSchema)+i,
+					secondOffset+i,
 					col.Type,
 					col.Source,
 					col.Name,
@@ -107,10 +142,40 @@ func resolveNaturalJoin(
 		}
 	}
 
-	return plan.NewProject(
-		append(append(common, left...), right...),
-		plan.NewInnerJoin(n.Left(), n.Right(), expression.JoinAnd(conditions...)),
-	), nil
+	var join sql.Node
+	cond := expression.JoinAnd(conditions...)
+	switch n.Type {
+	case plan.JoinTypeLeft:
+		if cond == nil {
+			cond = expression.NewLiteral(true, sql.Boolean)
+		}
+		join = plan.NewLeftJoin(n.Left(), n.Right(), cond)
+	case plan.JoinTypeRight:
+		if cond == nil {
+			cond = expression.NewLiteral(true, sql.Boolean)
+		}
+		join = plan.NewRightJoin(n.Left(), n.Right(), cond)
+	default:
+		join = plan.NewInnerJoin(n.Left(), n.Right(), cond)
+	}
+
+	return plan.NewProject(append(append(append(common, firstOnly...), secondOnly...), hidden...), join), nil
+}
+
+// isHiddenJoinColumn returns whether the column given is a join column of the second table of an outer natural join,
+// projected by the join only for references to it.
+func isHiddenJoinColumn(col *sql.Column) bool {
+	return col.Source == "" && strings.HasPrefix(col.Name, hiddenJoinColumnPrefix)
+}
+
+// containsColumnName returns whether the column names given contain the name given.
+func containsColumnName(names []string, name string) bool {
+	for _, n := range names {
+		if strings.EqualFold(n, name) {
+			return true
+		}
+	}
+	return false
 }
 
 func findCol(s sql.Schema, name string) (int, *sql.Column) {
@@ -77,6 +77,104 @@ func TestResolveNaturalJoins(t *testing.T) {
 	require.Equal(expected, result)
 }
 
+func TestResolveUsingJoins(t *testing.T) {
+	left := memory.NewTable("t1", sql.NewPrimaryKeySchema(sql.Schema{
+		{Name: "a", Type: sql.Int64, Source: "t1"},
+		{Name: "b", Type: sql.Int64, Source: "t1"},
+		{Name: "c", Type: sql.Int64, Source: "t1"},
+	}))
+
+	right := memory.NewTable("t2", sql.NewPrimaryKeySchema(sql.Schema{
+		{Name: "d", Type: sql.Int64, Source: "t2"},
+		{Name: "c", Type: sql.Int64, Source: "t2"},
+		{Name: "b", Type: sql.Int64, Source: "t2"},
+	}))
+
+	testCases := []struct {
+		name     string
+		node     sql.Node
+		expected sql.Node
+	}{
+		{
+			name: "left join using",
+			node: plan.NewUsingJoin(
+				plan.NewResolvedTable(left, nil, nil),
+				plan.NewResolvedTable(right, nil, nil),
+				plan.JoinTypeLeft,
+				[]string{"C"},
+			),
+			expected: plan.NewProject(
+				[]sql.Expression{
+					expression.NewGetFieldWithTable(2, sql.Int64, "t1", "c", false),
+					expression.NewGetFieldWithTable(0, sql.Int64, "t1", "a", false),
+					expression.NewGetFieldWithTable(1, sql.Int64, "t1", "b", false),
+					expression.NewGetFieldWithTable(3, sql.Int64, "t2", "d", false),
+					expression.NewGetFieldWithTable(5, sql.Int64, "t2", "b", false),
+					expression.NewAlias("__natural_join_t2.c", expression.NewGetFieldWithTable(4, sql.Int64, "t2", "c", false)),
+				},
+				plan.NewLeftJoin(
+					plan.NewResolvedTable(left, nil, nil),
+					plan.NewResolvedTable(right, nil, nil),
+					expression.NewEquals(
+						expression.NewGetFieldWithTable(2, sql.Int64, "t1", "c", false),
+						expression.NewGetFieldWithTable(4, sql.Int64, "t2", "c", false),
+					),
+				),
+			),
+		},
+		{
+			name: "natural right join",
+			node: plan.NewUsingJoin(
+				plan.NewResolvedTable(left, nil, nil),
+				plan.NewResolvedTable(right, nil, nil),
+				plan.JoinTypeRight,
+				nil,
+			),
+			expected: plan.NewProject(
+				[]sql.Expression{
+					expression.NewGetFieldWithTable(4, sql.Int64, "t2", "c", false),
+					expression.NewGetFieldWithTable(5, sql.Int64, "t2", "b", false),
+					expression.NewGetFieldWithTable(3, sql.Int64, "t2", "d", false),
+					expression.NewGetFieldWithTable(0, sql.Int64, "t1", "a", false),
+					expression.NewAlias("__natural_join_t1.c", expression.NewGetFieldWithTable(2, sql.Int64, "t1", "c", false)),
+					expression.NewAlias("__natural_join_t1.b", expression.NewGetFieldWithTable(1, sql.Int64, "t1", "b", false)),
+				},
+				plan.NewRightJoin(
+					plan.NewResolvedTable(left, nil, nil),
+					plan.NewResolvedTable(right, nil, nil),
+					expression.JoinAnd(
+						expression.NewEquals(
+							expression.NewGetFieldWithTable(2, sql.Int64, "t1", "c", false),
+							expression.NewGetFieldWithTable(4, sql.Int64, "t2", "c", false),
+						),
+						expression.NewEquals(
+							expression.NewGetFieldWithTable(1, sql.Int64, "t1", "b", false),
+							expression.NewGetFieldWithTable(5, sql.Int64, "t2", "b", false),
+						),
+					),
+				),
+			),
+		},
+	}
+
+	rule := getRule("resolve_natural_joins")
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := rule.Apply(sql.NewEmptyContext(), NewDefault(nil), tt.node, nil)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, result)
+		})
+	}
+
+	_, err := rule.Apply(sql.NewEmptyContext(), NewDefault(nil), plan.NewUsingJoin(
+		plan.NewResolvedTable(left, nil, nil),
+		plan.NewResolvedTable(right, nil, nil),
+		plan.JoinTypeInner,
+		[]string{"a"},
+	), nil)
+	require.True(t, sql.ErrColumnNotFound.Is(err))
+}
+
 func TestResolveNaturalJoinsColumns(t *testing.T) {
 	rule := getRule("resolve_natural_joins")
 	require := require.New(t)
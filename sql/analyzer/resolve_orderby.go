@@ -40,6 +40,16 @@ func pushdownSort(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.
 			return n, nil
 		}
 
+		// Aggregations can't be evaluated beneath the GroupBy that computes them, they are handled by
+		// resolveOrderByAggregations instead.
+		if findSortGroupBy(sort.Child) != nil {
+			for _, f := range sort.SortFields {
+				if containsAggregation(f.Column) {
+					return n, nil
+				}
+			}
+		}
+
 		childAliases := aliasesDefinedInNode(sort.Child)
 		var schemaCols []tableCol
 		for _, col := range sort.Child.Schema() {
@@ -49,6 +59,9 @@ func pushdownSort(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.
 			})
 		}
 
+		// The rows of a subquery begin with the columns of the outer scope, which are available at any depth
+		scopeLen := len(scope.Schema())
+
 		var colsFromChild []string
 		var missingCols []string
 		for _, f := range sort.SortFields {
@@ -56,7 +69,9 @@ func pushdownSort(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.
 
 			for _, n := range ns {
 				name := strings.ToLower(n.Name())
-				if stringContains(childAliases, name) {
+				if gf, ok := n.(*expression.GetField); ok && gf.Index() < scopeLen {
+					continue
+				} else if stringContains(childAliases, name) {
 					colsFromChild = append(colsFromChild, n.Name())
 				} else if !tableColsContains(schemaCols, tableColFromNameable(n)) {
 					missingCols = append(missingCols, n.Name())
@@ -208,6 +223,8 @@ func resolveOrderByLiterals(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Sc
 		schema := sort.Child.Schema()
 		var (
 			fields = make([]sql.SortField, len(sort.SortFields))
+			// aggregations over a GroupBy are handled by resolveOrderByAggregations
+			overGroupBy = findSortGroupBy(sort.Child) != nil
 		)
 		for i, f := range sort.SortFields {
 			if lit, ok := f.Column.(*expression.Literal); ok && sql.IsNumber(f.Column.Type()) {
@@ -244,7 +261,7 @@ func resolveOrderByLiterals(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Sc
 
 				a.Log("replaced order by column %d with %v", idx+1, schema[idx])
 			} else {
-				if agg, ok := f.Column.(sql.Aggregation); ok {
+				if agg, ok := f.Column.(sql.Aggregation); ok && !overGroupBy {
 					name := agg.String()
 					if nameable, ok := f.Column.(sql.Nameable); ok {
 						name = nameable.Name()
@@ -265,6 +282,130 @@ func resolveOrderByLiterals(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Sc
 	})
 }
 
+// resolveOrderByAggregations replaces the aggregations in the fields of a sort with references to the columns of the
+// GroupBy node beneath it. Aggregations that the GroupBy doesn't already compute are added to its selected expressions,
+// and the result of the sort is projected back to its original schema so that they don't escape it.
+func resolveOrderByAggregations(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.Node, error) {
+	return plan.TransformUp(n, func(n sql.Node) (sql.Node, error) {
+		sort, ok := n.(*plan.Sort)
+		if !ok {
+			return n, nil
+		}
+
+		// wait for the child to be resolved
+		if !sort.Child.Resolved() {
+			return n, nil
+		}
+
+		groupBy := findSortGroupBy(sort.Child)
+		if groupBy == nil {
+			return n, nil
+		}
+
+		var newAggregates []sql.Expression
+		fields := make([]sql.SortField, len(sort.SortFields))
+		for i, f := range sort.SortFields {
+			col, err := expression.TransformUp(f.Column, func(e sql.Expression) (sql.Expression, error) {
+				agg, ok := e.(sql.Aggregation)
+				if !ok {
+					return e, nil
+				}
+
+				exprs := append(append([]sql.Expression{}, groupBy.SelectedExprs...), newAggregates...)
+				for _, expr := range exprs {
+					if aggregationEquals(ctx, agg, expr) || strings.EqualFold(agg.String(), exprName(expr)) {
+						return expression.NewUnresolvedColumn(exprName(expr)), nil
+					}
+				}
+
+				// Columns in the aggregation may have been resolved against the output of the GroupBy, so they
+				// need to be resolved again against its child.
+				newAgg, err := expression.TransformUp(agg, func(e sql.Expression) (sql.Expression, error) {
+					if gf, ok := e.(*expression.GetField); ok {
+						return expression.NewUnresolvedQualifiedColumn(gf.Table(), gf.Name()), nil
+					}
+					return e, nil
+				})
+				if err != nil {
+					return nil, err
+				}
+
+				alias := expression.NewAlias(agg.String(), newAgg)
+				newAggregates = append(newAggregates, alias)
+				return expression.NewUnresolvedColumn(alias.Name()), nil
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			// Grouping columns used alongside aggregations might not be selected, in which case they need to be
+			// added as well.
+			if containsAggregation(f.Column) {
+				for _, name := range findMissingColumns(sort.Child, col) {
+					if !stringContains(exprNames(newAggregates), strings.ToLower(name)) {
+						newAggregates = append(newAggregates, expression.NewUnresolvedColumn(name))
+					}
+				}
+			}
+
+			fields[i] = sql.SortField{
+				Column:       col,
+				Order:        f.Order,
+				NullOrdering: f.NullOrdering,
+			}
+		}
+
+		if len(newAggregates) == 0 {
+			return plan.NewSort(fields, sort.Child), nil
+		}
+
+		a.Log("adding aggregations referenced only in order by to group by: %v", newAggregates)
+
+		child, err := addColumnsToGroupBy(sort.Child, newAggregates)
+		if err != nil {
+			return nil, err
+		}
+
+		schema := sort.Child.Schema()
+		projections := make([]sql.Expression, len(schema))
+		for i, col := range schema {
+			projections[i] = expression.NewGetFieldWithTable(i, col.Type, col.Source, col.Name, col.Nullable)
+		}
+
+		return plan.NewProject(projections, plan.NewSort(fields, child)), nil
+	})
+}
+
+// findSortGroupBy returns the GroupBy node that the sort child given is computed by, or nil if there isn't one or new
+// columns can't be added to it without changing the result of the nodes in between.
+func findSortGroupBy(n sql.Node) *plan.GroupBy {
+	switch n := n.(type) {
+	case *plan.GroupBy:
+		return n
+	case *plan.Having:
+		return findSortGroupBy(n.Child)
+	default:
+		return nil
+	}
+}
+
+// exprNames returns the lowercased names of the columns the expressions given produce in a schema.
+func exprNames(exprs []sql.Expression) []string {
+	names := make([]string, len(exprs))
+	for i, e := range exprs {
+		names[i] = strings.ToLower(exprName(e))
+	}
+	return names
+}
+
+// exprName returns the name of the column the expression given produces in a schema.
+func exprName(e sql.Expression) string {
+	if n, ok := e.(sql.Nameable); ok {
+		return n.Name()
+	}
+	return e.String()
+}
+
 // columnAliasRepeated returns whether the column in the schema given with the index given is an alias that is repeated
 // elsewhere in the schema, making it ambiguous
 func columnAliasRepeated(cols sql.Schema, idx int) bool {
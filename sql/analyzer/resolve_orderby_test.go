@@ -22,6 +22,7 @@ import (
 	"github.com/dolthub/go-mysql-server/memory"
 	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/expression/function/aggregation"
 	"github.com/dolthub/go-mysql-server/sql/expression/function/aggregation/window"
 	"github.com/dolthub/go-mysql-server/sql/plan"
 )
@@ -389,3 +390,80 @@ func TestResolveOrderByLiterals(t *testing.T) {
 	require.Error(err)
 	require.True(ErrOrderByColumnIndex.Is(err))
 }
+
+func TestResolveOrderByAggregations(t *testing.T) {
+	require := require.New(t)
+	f := getRule("resolve_orderby_aggregations")
+
+	table := memory.NewTable("t", sql.NewPrimaryKeySchema(sql.Schema{
+		{Name: "a", Type: sql.Int64, Source: "t"},
+		{Name: "b", Type: sql.Int64, Source: "t"},
+	}))
+
+	// An aggregation already computed by the group by is referenced by name
+	node := plan.NewSort(
+		[]sql.SortField{
+			{Column: aggregation.NewSum(expression.NewGetFieldWithTable(0, sql.Int64, "t", "b", false))},
+		},
+		plan.NewGroupBy(
+			[]sql.Expression{
+				expression.NewGetFieldWithTable(0, sql.Int64, "t", "a", false),
+				expression.NewAlias("s", aggregation.NewSum(expression.NewGetFieldWithTable(1, sql.Int64, "t", "b", false))),
+			},
+			[]sql.Expression{expression.NewGetFieldWithTable(0, sql.Int64, "t", "a", false)},
+			plan.NewResolvedTable(table, nil, nil),
+		),
+	)
+
+	result, err := f.Apply(sql.NewEmptyContext(), NewDefault(nil), node, nil)
+	require.NoError(err)
+	require.Equal(
+		plan.NewSort(
+			[]sql.SortField{{Column: expression.NewUnresolvedColumn("s")}},
+			node.Child,
+		),
+		result,
+	)
+
+	// An aggregation only referenced by the sort is added to the group by and projected away
+	node = plan.NewSort(
+		[]sql.SortField{
+			{Column: expression.NewArithmetic(
+				aggregation.NewMax(expression.NewUnresolvedQualifiedColumn("t", "b")),
+				expression.NewLiteral(int64(1), sql.Int64),
+				"+",
+			)},
+		},
+		plan.NewGroupBy(
+			[]sql.Expression{expression.NewGetFieldWithTable(0, sql.Int64, "t", "a", false)},
+			[]sql.Expression{expression.NewGetFieldWithTable(0, sql.Int64, "t", "a", false)},
+			plan.NewResolvedTable(table, nil, nil),
+		),
+	)
+
+	result, err = f.Apply(sql.NewEmptyContext(), NewDefault(nil), node, nil)
+	require.NoError(err)
+	require.Equal(
+		plan.NewProject(
+			[]sql.Expression{expression.NewGetFieldWithTable(0, sql.Int64, "t", "a", false)},
+			plan.NewSort(
+				[]sql.SortField{
+					{Column: expression.NewArithmetic(
+						expression.NewUnresolvedColumn("MAX(t.b)"),
+						expression.NewLiteral(int64(1), sql.Int64),
+						"+",
+					)},
+				},
+				plan.NewGroupBy(
+					[]sql.Expression{
+						expression.NewGetFieldWithTable(0, sql.Int64, "t", "a", false),
+						expression.NewAlias("MAX(t.b)", aggregation.NewMax(expression.NewUnresolvedQualifiedColumn("t", "b"))),
+					},
+					[]sql.Expression{expression.NewGetFieldWithTable(0, sql.Int64, "t", "a", false)},
+					plan.NewResolvedTable(table, nil, nil),
+				),
+			),
+		),
+		result,
+	)
+}
@@ -0,0 +1,55 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analyzer
+
+import (
+	"github.com/dolthub/go-mysql-server/auth"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+// resolveShowGrants sets the account and the privileges shown by a SHOW GRANTS statement from the users of the Auth
+// method of the engine running the query. Users are identified by their name alone, so an account matches any host, as
+// it does when checking permissions. Auth methods that don't keep users let every user do anything, and the user of
+// the session, with every privilege, is then the only account known.
+func resolveShowGrants(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.Node, error) {
+	showGrants, ok := n.(*plan.ShowGrants)
+	if !ok || showGrants.Resolved() {
+		return n, nil
+	}
+
+	user, host := showGrants.User, showGrants.Host
+	if user == "" {
+		user, host = ctx.Client().User, "%"
+	}
+	if host == "" {
+		host = "%"
+	}
+
+	au, _ := auth.FromContext(ctx)
+	users, ok := auth.UsersOf(au)
+	if !ok {
+		if user != ctx.Client().User {
+			return nil, sql.ErrNoSuchGrant.New(user, host)
+		}
+		return plan.NewShowGrants(user, host).WithPrivileges(auth.AllPermissions.Privileges(), true), nil
+	}
+
+	perm, ok := users.UserPermissions(user)
+	if !ok {
+		return nil, sql.ErrNoSuchGrant.New(user, host)
+	}
+	return plan.NewShowGrants(user, host).WithPrivileges(perm.Privileges(), false), nil
+}
@@ -134,6 +134,10 @@ func StripQueryProcess(n sql.Node) sql.Node {
 		case *plan.QueryProcess:
 			n = tn.Child
 		case *plan.StartTransaction:
+			// An explicit START TRANSACTION statement has no child, and is kept
+			if tn.Child == nil {
+				return n
+			}
 			n = tn.Child
 		default:
 			nodeIsPassthrough = false
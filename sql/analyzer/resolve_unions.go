@@ -24,7 +24,9 @@ import (
 	"github.com/dolthub/go-mysql-server/sql/plan"
 )
 
-// resolveUnions resolves the left and right side of a union node in isolation.
+// resolveUnions resolves the left and right side of a union node in isolation. The anchor and recursive parts of a
+// recursive common table expression are resolved in the same way, the anchor part first, since the schema of the table
+// read by the recursive part is the anchor part's.
 func resolveUnions(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.Node, error) {
 	if n.Resolved() {
 		return n, nil
@@ -51,12 +53,64 @@ func resolveUnions(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql
 			}
 
 			return n.WithChildren(StripQueryProcess(left), StripQueryProcess(right))
+		case *plan.RecursiveCte:
+			subqueryCtx, cancelFunc := ctx.NewSubContext()
+			defer cancelFunc()
+
+			anchor, err := a.analyzeThroughBatch(subqueryCtx, n.Left(), scope, "default-rules")
+			if err != nil {
+				return nil, err
+			}
+			anchor = StripQueryProcess(anchor)
+
+			table := plan.NewRecursiveTable(n.Name(), recursiveTableSchema(n, anchor.Schema()))
+			recursive, err := transformUpWithOpaque(n.Right(), func(n sql.Node) (sql.Node, error) {
+				if rt, ok := n.(*plan.ResolvedTable); ok {
+					if _, ok := rt.Table.(*plan.RecursiveTable); ok {
+						return plan.NewResolvedTable(table, nil, nil), nil
+					}
+				}
+				return n, nil
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			recursive, err = a.analyzeThroughBatch(subqueryCtx, recursive, scope, "default-rules")
+			if err != nil {
+				return nil, err
+			}
+
+			return n.WithTable(table).WithChildren(anchor, StripQueryProcess(recursive))
 		default:
 			return n, nil
 		}
 	})
 }
 
+// recursiveTableSchema returns the schema of the table read by the recursive part of the recursive common table
+// expression given, which is the schema of its anchor part given, with the columns it declares. Since the recursive
+// part often computes larger values than the anchor part's, as in a counter, integer columns are widened to 64 bits,
+// which is how MySQL types integer literals. All columns are nullable, since the recursive part may produce NULLs.
+func recursiveTableSchema(n *plan.RecursiveCte, anchor sql.Schema) sql.Schema {
+	schema := make(sql.Schema, len(anchor))
+	for i, col := range anchor {
+		c := *col
+		c.Source = n.Name()
+		if i < len(n.Columns()) {
+			c.Name = n.Columns()[i]
+		}
+		if sql.IsSigned(c.Type) {
+			c.Type = sql.Int64
+		} else if sql.IsUnsigned(c.Type) {
+			c.Type = sql.Uint64
+		}
+		c.Nullable = true
+		schema[i] = &c
+	}
+	return schema
+}
+
 func finalizeUnions(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.Node, error) {
 	// Procedures explicitly handle unions
 	if _, ok := n.(*plan.CreateProcedure); ok {
@@ -80,6 +134,21 @@ func finalizeUnions(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sq
 			}
 
 			return n.WithChildren(StripQueryProcess(left), StripQueryProcess(right))
+		case *plan.RecursiveCte:
+			subqueryCtx, cancelFunc := ctx.NewSubContext()
+			defer cancelFunc()
+
+			anchor, err := a.analyzeStartingAtBatch(subqueryCtx, n.Left(), scope, "default-rules")
+			if err != nil {
+				return nil, err
+			}
+
+			recursive, err := a.analyzeStartingAtBatch(subqueryCtx, n.Right(), scope, "default-rules")
+			if err != nil {
+				return nil, err
+			}
+
+			return n.WithChildren(StripQueryProcess(anchor), StripQueryProcess(recursive))
 		default:
 			return n, nil
 		}
@@ -0,0 +1,312 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analyzer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+// updatableView is a view that can be written through: one selecting columns of a single base table, optionally
+// filtered by a WHERE clause.
+type updatableView struct {
+	// name is the qualified name of the view.
+	name string
+	// table is the base table of the view.
+	table *plan.UnresolvedTable
+	// filter is the WHERE clause of the view in terms of the base table's columns, or nil if it has none.
+	filter sql.Expression
+	// columns are the names of the view's columns, or nil if it selects all the columns of the base table.
+	columns []string
+	// tableColumns maps the lowercase name of each of the view's columns to the base table column it selects.
+	tableColumns map[string]string
+	checkOption  sql.ViewCheckOption
+}
+
+// resolveUpdatableViews rewrites INSERT, UPDATE and DELETE statements targeting a view to target the view's base table
+// instead. Updates and deletes only affect the rows the view can see, and if the view was defined WITH CHECK OPTION,
+// inserts and updates must produce rows the view can see, which is enforced as an additional check constraint.
+func resolveUpdatableViews(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.Node, error) {
+	span, _ := ctx.Span("resolve_updatable_views")
+	defer span.Finish()
+
+	switch n := n.(type) {
+	case *plan.InsertInto:
+		urt, ok := n.Destination.(*plan.UnresolvedTable)
+		if !ok {
+			return n, nil
+		}
+
+		view, err := getUpdatableView(ctx, a, urt, "INSERT")
+		if err != nil || view == nil {
+			return n, err
+		}
+
+		a.Log("inserting through view %q", view.name)
+
+		nn := *n
+		nn.Destination = view.table
+		if view.table.Database != "" {
+			withDb, err := nn.WithDatabase(sql.UnresolvedDatabase(view.table.Database))
+			if err != nil {
+				return nil, err
+			}
+			nn = *withDb.(*plan.InsertInto)
+		}
+
+		if view.columns != nil {
+			columns := nn.ColumnNames
+			if len(columns) == 0 {
+				columns = view.columns
+			}
+			nn.ColumnNames = make([]string, len(columns))
+			for i, col := range columns {
+				nn.ColumnNames[i], ok = view.tableColumns[strings.ToLower(col)]
+				if !ok {
+					return nil, plan.ErrInsertIntoNonexistentColumn.New(col)
+				}
+			}
+
+			nn.OnDupExprs = make([]sql.Expression, len(n.OnDupExprs))
+			for i, expr := range n.OnDupExprs {
+				nn.OnDupExprs[i], err = expression.TransformUp(expr, view.toTableColumn)
+				if err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		nn.Checks = append(nn.Checks, view.checks()...)
+		return &nn, nil
+	case *plan.Update:
+		child, view, err := resolveUpdatableViewTarget(ctx, a, n.Child, "UPDATE")
+		if err != nil || view == nil {
+			return n, err
+		}
+
+		nn := *n
+		nn.Child = child
+		nn.Checks = append(nn.Checks, view.checks()...)
+		return &nn, nil
+	case *plan.DeleteFrom:
+		child, view, err := resolveUpdatableViewTarget(ctx, a, n.Child, "DELETE")
+		if err != nil || view == nil {
+			return n, err
+		}
+
+		return n.WithChildren(child)
+	default:
+		return n, nil
+	}
+}
+
+// resolveUpdatableViewTarget rewrites the source of an UPDATE or DELETE whose target is a view to read from the view's
+// base table instead, only returning the rows the view can see. Columns of the view named in the statement are replaced
+// with the base table columns they select. Returns the view written through, or nil if the target isn't a view.
+func resolveUpdatableViewTarget(ctx *sql.Context, a *Analyzer, n sql.Node, statement string) (sql.Node, *updatableView, error) {
+	var urt *plan.UnresolvedTable
+	var tables int
+	plan.Inspect(n, func(n sql.Node) bool {
+		if t, ok := n.(*plan.UnresolvedTable); ok {
+			urt = t
+			tables++
+		}
+		return true
+	})
+
+	// Views joined to other tables aren't supported
+	if tables != 1 {
+		return n, nil, nil
+	}
+
+	view, err := getUpdatableView(ctx, a, urt, statement)
+	if err != nil || view == nil {
+		return n, nil, err
+	}
+
+	a.Log("writing through view %q", view.name)
+
+	n, err = plan.TransformExpressionsUp(n, view.toTableColumn)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// The view's base table keeps the view's name, or the alias the statement gave it
+	var replaceTarget func(n sql.Node) (sql.Node, error)
+	replaceTarget = func(n sql.Node) (sql.Node, error) {
+		alias := urt.Name()
+		if ta, ok := n.(*plan.TableAlias); ok && ta.Child == sql.Node(urt) {
+			alias = ta.Name()
+		} else if n != sql.Node(urt) {
+			children := n.Children()
+			newChildren := make([]sql.Node, len(children))
+			for i, child := range children {
+				newChildren[i], err = replaceTarget(child)
+				if err != nil {
+					return nil, err
+				}
+			}
+			return n.WithChildren(newChildren...)
+		}
+
+		var table sql.Node = plan.NewTableAlias(alias, view.table)
+		if view.filter != nil {
+			table = plan.NewFilter(view.filter, table)
+		}
+		return table, nil
+	}
+
+	n, err = replaceTarget(n)
+	if err != nil {
+		return nil, nil, err
+	}
+	return n, view, nil
+}
+
+// getUpdatableView returns the view named by the table given as an updatableView, or nil if it doesn't name a view.
+// Returns an error if it names a view that can't be written through.
+func getUpdatableView(ctx *sql.Context, a *Analyzer, urt *plan.UnresolvedTable, statement string) (*updatableView, error) {
+	dbName := urt.Database
+	if dbName == "" {
+		dbName = ctx.GetCurrentDatabase()
+	}
+
+	view, err := getView(ctx, a, dbName, urt.Name())
+	if err != nil || view == nil {
+		return nil, err
+	}
+
+	uv := &updatableView{
+		name:         fmt.Sprintf("%s.%s", dbName, view.Name()),
+		tableColumns: make(map[string]string),
+		checkOption:  view.CheckOption(),
+	}
+	notUpdatable := sql.ErrNonUpdatableTable.New(view.Name(), statement)
+
+	query := view.Definition().Children()[0]
+	if sort, ok := query.(*plan.Sort); ok {
+		query = sort.Child
+	}
+
+	if project, ok := query.(*plan.Project); ok {
+		for _, expr := range project.Projections {
+			var name string
+			if alias, ok := expr.(*expression.Alias); ok {
+				name, expr = alias.Name(), alias.Child
+			}
+
+			switch e := expr.(type) {
+			case *expression.Star:
+				if len(project.Projections) > 1 {
+					return nil, notUpdatable
+				}
+				continue
+			case *expression.UnresolvedColumn:
+				if name == "" {
+					name = e.Name()
+				}
+				uv.columns = append(uv.columns, name)
+				uv.tableColumns[strings.ToLower(name)] = e.Name()
+			default:
+				return nil, notUpdatable
+			}
+		}
+		query = project.Child
+	}
+
+	if filter, ok := query.(*plan.Filter); ok {
+		// The base table is the only table in the view, so its columns need no qualifier
+		uv.filter, err = expression.TransformUp(filter.Expression, func(e sql.Expression) (sql.Expression, error) {
+			if col, ok := e.(*expression.UnresolvedColumn); ok && col.Table() != "" {
+				return expression.NewUnresolvedColumn(col.Name()), nil
+			}
+			return e, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		query = filter.Child
+	}
+
+	if alias, ok := query.(*plan.TableAlias); ok {
+		query = alias.Child
+	}
+
+	table, ok := query.(*plan.UnresolvedTable)
+	if !ok {
+		return nil, notUpdatable
+	}
+
+	// Views of other views aren't supported
+	tableDb := table.Database
+	if tableDb == "" {
+		tableDb = urt.Database
+	}
+	if tableDb == "" {
+		tableDb = ctx.GetCurrentDatabase()
+	}
+	if underlying, err := getView(ctx, a, tableDb, table.Name()); err != nil {
+		return nil, err
+	} else if underlying != nil {
+		return nil, notUpdatable
+	}
+
+	// If the view name was qualified with a database name, the same qualifier applies to its table
+	if table.Database == "" && urt.Database != "" {
+		table, err = table.WithDatabase(urt.Database)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if urt.AsOf != nil {
+		return nil, notUpdatable
+	}
+
+	uv.table = table
+	return uv, nil
+}
+
+// toTableColumn replaces a column of this view with the base table column it selects.
+func (v *updatableView) toTableColumn(e sql.Expression) (sql.Expression, error) {
+	col, ok := e.(*expression.UnresolvedColumn)
+	if !ok || v.columns == nil {
+		return e, nil
+	}
+
+	name, ok := v.tableColumns[strings.ToLower(col.Name())]
+	if !ok {
+		return nil, sql.ErrColumnNotFound.New(col.Name())
+	}
+	return expression.NewUnresolvedQualifiedColumn(col.Table(), name), nil
+}
+
+// checks returns the check constraints enforcing the check option of this view, if it has one.
+// TODO: CASCADED check options should also check the views this one selects from, but views of views can't be
+// written through yet
+func (v *updatableView) checks() sql.CheckConstraints {
+	if v.checkOption == sql.ViewCheckOption_None || v.filter == nil {
+		return nil
+	}
+	return sql.CheckConstraints{{
+		Name:     v.name,
+		Expr:     v.filter,
+		Enforced: true,
+		FromView: true,
+	}}
+}
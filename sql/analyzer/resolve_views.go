@@ -38,40 +38,11 @@ func resolveViews(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.
 			dbName = ctx.GetCurrentDatabase()
 		}
 
-		var view *sql.View
-
-		if dbName != "" {
-			db, err := a.Catalog.Database(dbName)
-			if err != nil {
-				return nil, err
-			}
-
-			if vdb, ok := db.(sql.ViewDatabase); ok {
-				viewDef, ok, err := vdb.GetView(ctx, viewName)
-				if err != nil {
-					return nil, err
-				}
-
-				if ok {
-					query, err := parse.Parse(ctx, viewDef)
-					if err != nil {
-						return nil, err
-					}
-
-					view = plan.NewSubqueryAlias(viewName, viewDef, query).AsView()
-				}
-			}
-		}
-
-		// If we didn't find the view from the database directly, use the in-session registry
-		var err error
-		if view == nil {
-			view, err = ctx.GetViewRegistry().View(dbName, viewName)
-			if sql.ErrViewDoesNotExist.Is(err) {
-				return n, nil
-			} else if err != nil {
-				return nil, err
-			}
+		view, err := getView(ctx, a, dbName, viewName)
+		if err != nil {
+			return nil, err
+		} else if view == nil {
+			return n, nil
 		}
 
 		a.Log("view resolved: %q", viewName)
@@ -98,6 +69,50 @@ func resolveViews(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.
 	})
 }
 
+// getView returns the view with the name given in the database given, or nil if there is no such view.
+func getView(ctx *sql.Context, a *Analyzer, dbName, viewName string) (*sql.View, error) {
+	if dbName != "" {
+		db, err := a.Catalog.Database(dbName)
+		if err != nil {
+			return nil, err
+		}
+
+		if lowerCaseTableNames := sql.GetLowerCaseTableNames(a.Catalog); lowerCaseTableNames == sql.LowerCaseTableNames_Sensitive {
+			if _, err := sql.StoredViewName(ctx, lowerCaseTableNames, db, viewName); sql.ErrViewDoesNotExist.Is(err) {
+				return nil, nil
+			} else if err != nil {
+				return nil, err
+			}
+		}
+
+		if vdb, ok := db.(sql.ViewDatabase); ok {
+			viewDef, ok, err := vdb.GetView(ctx, viewName)
+			if err != nil {
+				return nil, err
+			}
+
+			if ok {
+				// The definition begins with the view's attributes and ends in its check option, if it has them
+				attributes, selectStatement := sql.SplitViewAttributes(viewDef)
+				selectStatement, _ = sql.SplitViewCheckOption(selectStatement)
+				query, err := parse.Parse(ctx, selectStatement)
+				if err != nil {
+					return nil, err
+				}
+
+				return plan.NewSubqueryAlias(viewName, viewDef, query).WithColumns(attributes.Columns).AsView(), nil
+			}
+		}
+	}
+
+	// If we didn't find the view from the database directly, use the in-session registry
+	view, err := ctx.GetViewRegistry().View(dbName, viewName)
+	if sql.ErrViewDoesNotExist.Is(err) {
+		return nil, nil
+	}
+	return view, err
+}
+
 func applyAsOfToView(n sql.Node, a *Analyzer, asOf sql.Expression) (sql.Node, error) {
 	a.Log("applying AS OF clause to view definition")
 
@@ -25,9 +25,11 @@ var OnceBeforeDefault = []Rule{
 	{"load_stored_procedures", loadStoredProcedures},
 	{"resolve_variables", resolveVariables},
 	{"resolve_set_variables", resolveSetVariables},
+	{"resolve_updatable_views", resolveUpdatableViews},
 	{"resolve_views", resolveViews},
 	{"lift_common_table_expressions", liftCommonTableExpressions},
 	{"resolve_common_table_expressions", resolveCommonTableExpressions},
+	{"fold_table_names", foldTableNames},
 	{"resolve_tables", resolveTables},
 	{"resolve_drop_constraint", resolveDropConstraint},
 	{"validate_drop_constraint", validateDropConstraint},
@@ -37,6 +39,7 @@ var OnceBeforeDefault = []Rule{
 	{"resolve_subqueries", resolveSubqueries},
 	{"resolve_unions", resolveUnions},
 	{"resolve_describe_query", resolveDescribeQuery},
+	{"resolve_show_grants", resolveShowGrants},
 	{"check_unique_table_names", checkUniqueTableNames},
 	{"resolve_declarations", resolveDeclarations},
 	{"validate_create_trigger", validateCreateTrigger},
@@ -61,6 +64,7 @@ var DefaultRules = []Rule{
 	{"resolve_bareword_set_variables", resolveBarewordSetVariables},
 	{"resolve_database", resolveDatabase},
 	{"expand_stars", expandStars},
+	{"resolve_orderby_aggregations", resolveOrderByAggregations},
 	{"resolve_having", resolveHaving},
 	{"merge_union_schemas", mergeUnionSchemas},
 	{"flatten_aggregation_exprs", flattenAggregationExpressions},
@@ -69,6 +73,7 @@ var DefaultRules = []Rule{
 	{"replace_cross_joins", replaceCrossJoins},
 	{"move_join_conds_to_filter", moveJoinConditionsToFilter},
 	{"eval_filter", evalFilter},
+	{"push_not_filters", pushNotFilters},
 	{"optimize_distinct", optimizeDistinct},
 }
 
@@ -95,6 +100,7 @@ var OnceAfterDefault = []Rule{
 	// One final pass at analyzing subqueries to handle rewriting field indexes after changes to outer scope by
 	// previous rules.
 	{"resolve_subquery_exprs", resolveSubqueryExpressions},
+	{"apply_anti_joins", applyAntiJoins},
 	{"cache_subquery_results", cacheSubqueryResults},
 	{"cache_subquery_aliases_in_joins", cacheSubqueryAlisesInJoins},
 	{"apply_hash_lookups", applyHashLookups},
@@ -110,6 +116,7 @@ var OnceAfterDefault = []Rule{
 // rules have been applied.
 var OnceAfterAll = []Rule{
 	{"track_process", trackProcess},
+	{"optimize_group_by", optimizeGroupBy},
 	{"parallelize", parallelize},
 	//	{"begin_transaction", beginTransaction}, // Disabled for now, implicit transactions are handled before analysis in handler.go
 	{"clear_warnings", clearWarnings},
@@ -0,0 +1,80 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analyzer
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/parse"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+// StoredFunctionCache contains the parsed stored functions of each database, so that the CREATE FUNCTION statement of
+// a stored function is only parsed again once it changes.
+type StoredFunctionCache struct {
+	mu               sync.Mutex
+	dbToFunctionsMap map[string]map[string]cachedStoredFunction
+}
+
+// cachedStoredFunction is a stored function parsed from the details given.
+type cachedStoredFunction struct {
+	details  sql.StoredFunctionDetails
+	function *plan.StoredFunction
+}
+
+// NewStoredFunctionCache returns a *StoredFunctionCache.
+func NewStoredFunctionCache() *StoredFunctionCache {
+	return &StoredFunctionCache{
+		dbToFunctionsMap: make(map[string]map[string]cachedStoredFunction),
+	}
+}
+
+// Get returns the stored function of the given database with the details given, parsing its CREATE FUNCTION
+// statement if it isn't cached or has changed since it was cached. The database name is case-insensitive.
+func (fc *StoredFunctionCache) Get(ctx *sql.Context, dbName string, details sql.StoredFunctionDetails) (*plan.StoredFunction, error) {
+	dbName = strings.ToLower(dbName)
+	name := strings.ToLower(details.Name)
+
+	fc.mu.Lock()
+	cached, ok := fc.dbToFunctionsMap[dbName][name]
+	fc.mu.Unlock()
+	if ok && cached.details.CreateStatement == details.CreateStatement &&
+		cached.details.CreatedAt.Equal(details.CreatedAt) && cached.details.ModifiedAt.Equal(details.ModifiedAt) {
+		return cached.function, nil
+	}
+
+	parsed, err := parse.Parse(ctx, details.CreateStatement)
+	if err != nil {
+		return nil, err
+	}
+	cf, ok := parsed.(*plan.CreateFunction)
+	if !ok {
+		return nil, sql.ErrStoredFunctionCreateStatementInvalid.New(details.CreateStatement)
+	}
+	function := *cf.StoredFunction
+	function.CreatedAt, function.ModifiedAt = details.CreatedAt, details.ModifiedAt
+	cached = cachedStoredFunction{details: details, function: &function}
+
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	if functions, ok := fc.dbToFunctionsMap[dbName]; ok {
+		functions[name] = cached
+	} else {
+		fc.dbToFunctionsMap[dbName] = map[string]cachedStoredFunction{name: cached}
+	}
+	return cached.function, nil
+}
@@ -0,0 +1,58 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analyzer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+func TestStoredFunctionCache(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewContext(context.Background())
+	fc := NewStoredFunctionCache()
+
+	details := sql.StoredFunctionDetails{
+		Name:            "Add_One",
+		CreateStatement: "CREATE FUNCTION Add_One(x INT) RETURNS INT RETURN x + 1",
+	}
+	function, err := fc.Get(ctx, "db", details)
+	require.NoError(err)
+	require.Equal("add_one", function.Name)
+
+	// The same statement isn't parsed again, in any database name case
+	cached, err := fc.Get(ctx, "DB", details)
+	require.NoError(err)
+	require.Same(function, cached)
+
+	// A changed statement is
+	details.CreateStatement = "CREATE FUNCTION Add_One(x INT) RETURNS INT RETURN x + 2"
+	changed, err := fc.Get(ctx, "db", details)
+	require.NoError(err)
+	require.NotSame(function, changed)
+	require.Equal("x + 2", changed.BodyString[len("RETURN "):])
+
+	// Stored functions are cached per database
+	other, err := fc.Get(ctx, "db2", details)
+	require.NoError(err)
+	require.NotSame(changed, other)
+
+	_, err = fc.Get(ctx, "db", sql.StoredFunctionDetails{Name: "f", CreateStatement: "SELECT 1"})
+	require.True(sql.ErrStoredFunctionCreateStatementInvalid.Is(err))
+}
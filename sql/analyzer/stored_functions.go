@@ -0,0 +1,194 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analyzer
+
+import (
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/memory"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+// resolveStoredFunction returns a call of the stored function of the current database that the function given calls,
+// with the body of the stored function analyzed. Returns nil if the current database has no such stored function.
+func resolveStoredFunction(ctx *sql.Context, a *Analyzer, uf *expression.UnresolvedFunction) (sql.Expression, error) {
+	dbName := ctx.GetCurrentDatabase()
+	if dbName == "" {
+		return nil, nil
+	}
+	db, err := a.Catalog.Database(dbName)
+	if err != nil {
+		return nil, err
+	}
+	fdb, ok := db.(sql.StoredFunctionDatabase)
+	if !ok {
+		return nil, nil
+	}
+
+	functions, err := newStoredFunctionLoader(ctx, a, fdb)
+	if err != nil {
+		return nil, err
+	}
+	function, err := functions.load(uf.Name())
+	if err != nil || function == nil {
+		return nil, err
+	}
+
+	if err := validateStoredFunctionCalls(a, function, function, functions, make(map[string]bool)); err != nil {
+		return nil, err
+	}
+
+	if proc, ok := function.Body.(*plan.Procedure); ok {
+		return resolveStoredFunctionProcedure(ctx, a, function, proc, uf.Arguments)
+	}
+
+	// The body is analyzed as a subquery whose scope has the parameters as the columns of a table named after the
+	// function, which are given the values of the arguments when it's evaluated
+	schema := function.ParamsSchema()
+	columns := make([]sql.Expression, len(schema))
+	for i, col := range schema {
+		columns[i] = expression.NewGetFieldWithTable(i, col.Type, col.Source, col.Name, col.Nullable)
+	}
+	params := plan.NewProject(columns, plan.NewResolvedTable(memory.NewTable(function.Name, sql.NewPrimaryKeySchema(schema)), nil, nil))
+
+	bodyCtx, cancelFunc := ctx.NewSubContext()
+	defer cancelFunc()
+	body, err := a.Analyze(bodyCtx, function.Body, (*Scope)(nil).newScope(params))
+	if err != nil {
+		return nil, err
+	}
+
+	analyzed := *function
+	analyzed.Body = StripQueryProcess(body)
+	return plan.NewStoredFunctionCall(&analyzed, uf.Arguments...)
+}
+
+// resolveStoredFunctionProcedure returns a call of the stored function given, whose BEGIN ... END body is the
+// procedure given. The body is analyzed as the body of a stored procedure is, and its parameters and local variables
+// are given a reference of their own for the call.
+func resolveStoredFunctionProcedure(
+	ctx *sql.Context,
+	a *Analyzer,
+	function *plan.StoredFunction,
+	proc *plan.Procedure,
+	args []sql.Expression,
+) (sql.Expression, error) {
+	paramNames, err := validateStoredProcedure(ctx, proc)
+	if err != nil {
+		return nil, err
+	}
+	body, err := resolveDeclarations(ctx, a, proc, nil)
+	if err != nil {
+		return nil, err
+	}
+	body, err = resolveProcedureParams(ctx, paramNames, body)
+	if err != nil {
+		return nil, err
+	}
+	bodyCtx, cancelFunc := ctx.NewSubContext()
+	defer cancelFunc()
+	body, err = analyzeProcedureBodies(bodyCtx, a, body, false, nil)
+	if err != nil {
+		return nil, err
+	}
+	pRef := expression.NewProcedureParamReference()
+	body, err = assignProcedureParamReference(body, pRef)
+	if err != nil {
+		return nil, err
+	}
+
+	analyzed := *function
+	analyzed.Body = body
+	call, err := plan.NewStoredFunctionCall(&analyzed, args...)
+	if err != nil {
+		return nil, err
+	}
+	return call.WithParamReference(pRef), nil
+}
+
+// storedFunctionLoader loads the stored functions of a database by name, parsing only those that are called.
+type storedFunctionLoader struct {
+	ctx     *sql.Context
+	a       *Analyzer
+	dbName  string
+	details map[string]sql.StoredFunctionDetails
+}
+
+// newStoredFunctionLoader returns a loader of the stored functions of the database given.
+func newStoredFunctionLoader(ctx *sql.Context, a *Analyzer, fdb sql.StoredFunctionDatabase) (*storedFunctionLoader, error) {
+	functions, err := fdb.GetStoredFunctions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	details := make(map[string]sql.StoredFunctionDetails, len(functions))
+	for _, sfd := range functions {
+		details[strings.ToLower(sfd.Name)] = sfd
+	}
+	return &storedFunctionLoader{ctx: ctx, a: a, dbName: fdb.Name(), details: details}, nil
+}
+
+// load returns the stored function with the name given, which is case-insensitive. Returns nil if the database has no
+// such stored function.
+func (l *storedFunctionLoader) load(name string) (*plan.StoredFunction, error) {
+	sfd, ok := l.details[strings.ToLower(name)]
+	if !ok {
+		return nil, nil
+	}
+	return l.a.StoredFunctionCache.Get(l.ctx, l.dbName, sfd)
+}
+
+// validateStoredFunctionCalls returns an error if the stored function given calls the stored function being
+// resolved, directly or through the stored functions it calls, as stored functions can't be recursive.
+func validateStoredFunctionCalls(
+	a *Analyzer,
+	resolving, function *plan.StoredFunction,
+	functions *storedFunctionLoader,
+	visited map[string]bool,
+) error {
+	visited[function.Name] = true
+
+	var err error
+	var inspect func(n sql.Node)
+	inspect = func(n sql.Node) {
+		plan.InspectExpressions(n, func(e sql.Expression) bool {
+			if err != nil {
+				return false
+			}
+			switch e := e.(type) {
+			case *plan.Subquery:
+				inspect(e.Query)
+			case *expression.UnresolvedFunction:
+				if _, builtInErr := a.Catalog.Function(strings.ToLower(e.Name())); builtInErr == nil {
+					return true
+				}
+				var called *plan.StoredFunction
+				called, err = functions.load(e.Name())
+				if err != nil || called == nil {
+					break
+				}
+				if called.Name == resolving.Name {
+					err = sql.ErrStoredFunctionRecursiveCall.New(resolving.Name)
+				} else if !visited[called.Name] {
+					err = validateStoredFunctionCalls(a, resolving, called, functions, visited)
+				}
+			}
+			return err == nil
+		})
+	}
+	inspect(function.Body)
+	return err
+}
@@ -106,7 +106,7 @@ func analyzeProcedureBodies(ctx *sql.Context, a *Analyzer, node sql.Node, skipCa
 		var newChild sql.Node
 		switch child := child.(type) {
 		// Anything that may represent a collection of statements should go here
-		case *plan.Procedure, *plan.BeginEndBlock, *plan.Block, *plan.IfElseBlock, *plan.IfConditional:
+		case *plan.Procedure, *plan.BeginEndBlock, *plan.Block, *plan.IfElseBlock, *plan.IfConditional, *plan.Return:
 			newChild, err = analyzeProcedureBodies(ctx, a, child, skipCall, scope)
 		case *plan.Call:
 			if skipCall {
@@ -152,7 +152,6 @@ func validateCreateProcedure(ctx *sql.Context, a *Analyzer, node sql.Node, scope
 // validateStoredProcedure handles Procedure nodes, resolving references to the parameters, along with ensuring
 // that all logic contained within the stored procedure body is valid.
 func validateStoredProcedure(ctx *sql.Context, proc *plan.Procedure) (map[string]struct{}, error) {
-	//TODO: handle declared variables here as well
 	paramNames := make(map[string]struct{})
 	for _, param := range proc.Params {
 		paramName := strings.ToLower(param.Name)
@@ -161,6 +160,15 @@ func validateStoredProcedure(ctx *sql.Context, proc *plan.Procedure) (map[string
 		}
 		paramNames[paramName] = struct{}{}
 	}
+	// Local variables are referenced like parameters
+	plan.Inspect(proc, func(n sql.Node) bool {
+		if dv, ok := n.(*plan.DeclareVariables); ok {
+			for _, name := range dv.Names {
+				paramNames[name] = struct{}{}
+			}
+		}
+		return true
+	})
 
 	// For now, we don't support creating any of the following within stored procedures.
 	// These will be removed in the future, but cause issues with the current execution plan.
@@ -181,7 +189,7 @@ func validateStoredProcedure(ctx *sql.Context, proc *plan.Procedure) (map[string
 			err = spUnsupportedErr.New("foreign keys")
 		case *plan.CreateIndex:
 			err = spUnsupportedErr.New("indexes")
-		case *plan.CreateView:
+		case *plan.CreateView, *plan.AlterView:
 			err = spUnsupportedErr.New("views")
 		default:
 			return true
@@ -320,6 +328,41 @@ func applyProceduresCall(ctx *sql.Context, a *Analyzer, call *plan.Call, scope *
 		return nil, sql.ErrStoredProcedureDoesNotExist.New(call.Name)
 	}
 
+	transformedProcedure, err := assignProcedureParamReference(procedure, pRef)
+	if err != nil {
+		return nil, err
+	}
+
+	transformedProcedure, err = plan.TransformUpCtx(transformedProcedure, nil, func(c plan.TransformContext) (sql.Node, error) {
+		rt, ok := c.Node.(*plan.ResolvedTable)
+		if !ok {
+			return c.Node, nil
+		}
+		return plan.NewProcedureResolvedTable(rt), nil
+	})
+	transformedProcedure, err = applyProcedures(ctx, a, transformedProcedure, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	var ok bool
+	procedure, ok = transformedProcedure.(*plan.Procedure)
+	if !ok {
+		return nil, fmt.Errorf("expected `*plan.Procedure` but got `%T`", transformedProcedure)
+	}
+
+	if len(procedure.Params) != len(call.Params) {
+		return nil, sql.ErrCallIncorrectParameterCount.New(procedure.Name, len(procedure.Params), len(call.Params))
+	}
+
+	call = call.WithProcedure(procedure)
+	return call, nil
+}
+
+// assignProcedureParamReference returns the node given with its references to the parameters and local variables of
+// a stored procedure or stored function assigned the *expression.ProcedureParamReference given, which holds their
+// values for a single call.
+func assignProcedureParamReference(node sql.Node, pRef *expression.ProcedureParamReference) (sql.Node, error) {
 	var procParamTransformFunc sql.TransformExprFunc
 	procParamTransformFunc = func(e sql.Expression) (sql.Expression, error) {
 		switch expr := e.(type) {
@@ -337,13 +380,15 @@ func applyProceduresCall(ctx *sql.Context, a *Analyzer, call *plan.Call, scope *
 			return e, nil
 		}
 	}
-	transformedProcedure, err := plan.TransformExpressionsUp(procedure, procParamTransformFunc)
+	transformed, err := plan.TransformExpressionsUp(node, procParamTransformFunc)
 	if err != nil {
 		return nil, err
 	}
 	// Some nodes do not expose all of their children, so we need to handle them here.
-	transformedProcedure, err = plan.TransformUp(transformedProcedure, func(n sql.Node) (sql.Node, error) {
+	return plan.TransformUp(transformed, func(n sql.Node) (sql.Node, error) {
 		switch n := n.(type) {
+		case *plan.DeclareVariables:
+			return n.WithParamReference(pRef), nil
 		case *plan.InsertInto:
 			newSource, err := plan.TransformExpressionsUp(n.Source, procParamTransformFunc)
 			if err != nil {
@@ -364,34 +409,6 @@ func applyProceduresCall(ctx *sql.Context, a *Analyzer, call *plan.Call, scope *
 			return n, nil
 		}
 	})
-	if err != nil {
-		return nil, err
-	}
-
-	transformedProcedure, err = plan.TransformUpCtx(transformedProcedure, nil, func(c plan.TransformContext) (sql.Node, error) {
-		rt, ok := c.Node.(*plan.ResolvedTable)
-		if !ok {
-			return c.Node, nil
-		}
-		return plan.NewProcedureResolvedTable(rt), nil
-	})
-	transformedProcedure, err = applyProcedures(ctx, a, transformedProcedure, scope)
-	if err != nil {
-		return nil, err
-	}
-
-	var ok bool
-	procedure, ok = transformedProcedure.(*plan.Procedure)
-	if !ok {
-		return nil, fmt.Errorf("expected `*plan.Procedure` but got `%T`", transformedProcedure)
-	}
-
-	if len(procedure.Params) != len(call.Params) {
-		return nil, sql.ErrCallIncorrectParameterCount.New(procedure.Name, len(procedure.Params), len(call.Params))
-	}
-
-	call = call.WithProcedure(procedure)
-	return call, nil
 }
 
 // applyProceduresShowProcedure applies all of the stored procedures to the given *plan.ShowProcedureStatus.
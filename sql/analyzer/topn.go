@@ -21,9 +21,12 @@ import (
 )
 
 // insertTopNNodes replaces Limit(Sort(...)) and Limit(Offset(Sort(...))) with
-// a TopN node.
+// a TopN node. When the sorted node is a projection containing subqueries, the
+// TopN node is placed below it, so that the subqueries are only evaluated for
+// the rows within the limit.
 func insertTopNNodes(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.Node, error) {
 	var updateCalcFoundRows bool
+	scopeLen := len(scope.Schema())
 	return plan.TransformUpCtx(n, nil, func(tc plan.TransformContext) (sql.Node, error) {
 		if o, ok := tc.Node.(*plan.Offset); ok {
 			parentLimit, ok := tc.Parent.(*plan.Limit)
@@ -37,7 +40,7 @@ func insertTopNNodes(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (s
 			topn := plan.NewTopN(childSort.SortFields, expression.NewPlus(parentLimit.Limit, o.Offset), childSort.UnaryNode.Child)
 			topn = topn.WithCalcFoundRows(parentLimit.CalcFoundRows)
 			updateCalcFoundRows = true
-			return o.WithChildren(topn)
+			return o.WithChildren(pushTopNBelowProjection(topn, scopeLen))
 		} else if l, ok := tc.Node.(*plan.Limit); ok {
 			childSort, ok := l.UnaryNode.Child.(*plan.Sort)
 			if !ok {
@@ -49,8 +52,69 @@ func insertTopNNodes(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (s
 			}
 			topn := plan.NewTopN(childSort.SortFields, l.Limit, childSort.UnaryNode.Child)
 			topn = topn.WithCalcFoundRows(l.CalcFoundRows)
-			return l.WithCalcFoundRows(false).WithChildren(topn)
+			return l.WithCalcFoundRows(false).WithChildren(pushTopNBelowProjection(topn, scopeLen))
 		}
 		return tc.Node, nil
 	})
 }
+
+// pushTopNBelowProjection returns Project(TopN(...)) for a TopN node over a
+// projection containing subqueries, with the sort fields rewritten in terms of
+// the projected expressions. Returns the TopN node unchanged if the projection
+// doesn't contain subqueries, or if a sort field can't be computed below it.
+func pushTopNBelowProjection(topn *plan.TopN, scopeLen int) sql.Node {
+	project, ok := topn.Child.(*plan.Project)
+	if !ok {
+		return topn
+	}
+
+	var hasSubquery bool
+	for _, e := range project.Projections {
+		if containsSubquery(e) {
+			hasSubquery = true
+			break
+		}
+	}
+	if !hasSubquery {
+		return topn
+	}
+
+	fields := make(sql.SortFields, len(topn.Fields))
+	for i, field := range topn.Fields {
+		column, err := expression.TransformUp(field.Column, func(e sql.Expression) (sql.Expression, error) {
+			gf, ok := e.(*expression.GetField)
+			if !ok || gf.Index() < scopeLen {
+				return e, nil
+			}
+			idx := gf.Index() - scopeLen
+			if idx >= len(project.Projections) {
+				return nil, ErrOrderByColumnIndex.New(gf.Index())
+			}
+			if alias, ok := project.Projections[idx].(*expression.Alias); ok {
+				return alias.Child, nil
+			}
+			return project.Projections[idx], nil
+		})
+		if err != nil || containsSubquery(column) || isNonDeterministic(column) {
+			return topn
+		}
+		fields[i] = sql.SortField{Column: column, Order: field.Order, NullOrdering: field.NullOrdering}
+	}
+
+	pushed := plan.NewTopN(fields, topn.Limit, project.Child).WithCalcFoundRows(topn.CalcFoundRows)
+	return plan.NewProject(project.Projections, pushed)
+}
+
+// isNonDeterministic returns whether the expression given contains any
+// non-deterministic expression, whose results can't be computed twice.
+func isNonDeterministic(e sql.Expression) bool {
+	var result bool
+	sql.Inspect(e, func(e sql.Expression) bool {
+		if nd, ok := e.(sql.NonDeterministicExpression); ok && nd.IsNonDeterministic() {
+			result = true
+			return false
+		}
+		return true
+	})
+	return result
+}
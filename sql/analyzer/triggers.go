@@ -238,7 +238,10 @@ func applyTrigger(ctx *sql.Context, a *Analyzer, originalNode, n sql.Node, scope
 				})
 				return n.WithSource(triggerExecutor), nil
 			} else {
-				return plan.NewTriggerExecutor(n, triggerLogic, plan.InsertTrigger, plan.TriggerTime(trigger.TriggerTime), sql.TriggerDefinition{
+				// The trigger must see the rows inserted before the one it runs for, but not the ones after it
+				insert := *n
+				insert.NoBatching = true
+				return plan.NewTriggerExecutor(&insert, triggerLogic, plan.InsertTrigger, plan.TriggerTime(trigger.TriggerTime), sql.TriggerDefinition{
 					Name:            trigger.TriggerName,
 					CreateStatement: trigger.CreateTriggerString,
 				}), nil
@@ -246,9 +246,14 @@ func validateGroupBy(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (s
 			groupBys = append(groupBys, expr.String())
 		}
 
+		determined, err := tablesDeterminedByGroupBys(ctx, n)
+		if err != nil {
+			return nil, err
+		}
+
 		for _, expr := range n.SelectedExprs {
 			if _, ok := expr.(sql.Aggregation); !ok {
-				if !expressionReferencesOnlyGroupBys(groupBys, expr) {
+				if !expressionReferencesOnlyGroupBys(groupBys, determined, expr) {
 					return nil, ErrValidationGroupBy.New(expr.String())
 				}
 			}
@@ -260,11 +265,120 @@ func validateGroupBy(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (s
 	return n, nil
 }
 
-func expressionReferencesOnlyGroupBys(groupBys []string, expr sql.Expression) bool {
+// tablesDeterminedByGroupBys returns the lowercased names of the tables in the group by node whose rows are uniquely
+// identified by the grouping columns, because they include every column of the table's primary key or of a unique
+// index over non-nullable columns. Every column of these tables is functionally dependent on the grouping columns.
+func tablesDeterminedByGroupBys(ctx *sql.Context, n *plan.GroupBy) (map[string]bool, error) {
+	groupedCols := make(map[string]map[string]bool)
+	for _, expr := range n.GroupByExprs {
+		if gf, ok := expr.(*expression.GetField); ok {
+			table := strings.ToLower(gf.Table())
+			if groupedCols[table] == nil {
+				groupedCols[table] = make(map[string]bool)
+			}
+			groupedCols[table][strings.ToLower(gf.Name())] = true
+		}
+	}
+
+	determined := make(map[string]bool)
+	if len(groupedCols) == 0 {
+		return determined, nil
+	}
+
+	var err error
+	var checkTable = func(name string, rt *plan.ResolvedTable) {
+		name = strings.ToLower(name)
+		cols, ok := groupedCols[name]
+		if !ok {
+			return
+		}
+
+		var keyCols []string
+		for _, col := range rt.Schema() {
+			if col.PrimaryKey {
+				keyCols = append(keyCols, strings.ToLower(col.Name))
+			}
+		}
+		if len(keyCols) > 0 && allStringsIn(keyCols, cols) {
+			determined[name] = true
+			return
+		}
+
+		it, ok := rt.Table.(sql.IndexedTable)
+		if !ok {
+			return
+		}
+
+		idxes, idxErr := it.GetIndexes(ctx)
+		if idxErr != nil {
+			err = idxErr
+			return
+		}
+
+		for _, idx := range idxes {
+			if !idx.IsUnique() {
+				continue
+			}
+
+			keyCols = keyCols[:0]
+			nullable := false
+			for _, expr := range idx.Expressions() {
+				colName := strings.TrimPrefix(strings.ToLower(expr), strings.ToLower(idx.Table())+".")
+				i := rt.Schema().IndexOf(colName, rt.Name())
+				if i < 0 || rt.Schema()[i].Nullable {
+					nullable = true
+					break
+				}
+				keyCols = append(keyCols, colName)
+			}
+
+			if !nullable && len(keyCols) > 0 && allStringsIn(keyCols, cols) {
+				determined[name] = true
+				return
+			}
+		}
+	}
+
+	plan.Inspect(n.Child, func(n sql.Node) bool {
+		switch n := n.(type) {
+		case *plan.TableAlias:
+			if rt, ok := n.Child.(*plan.ResolvedTable); ok {
+				checkTable(n.Name(), rt)
+			}
+			return false
+		case *plan.ResolvedTable:
+			checkTable(n.Name(), n)
+		case *plan.IndexedTableAccess:
+			checkTable(n.Name(), n.ResolvedTable)
+		case *plan.SubqueryAlias:
+			// Keys of the tables in a subquery don't carry over to its result
+			return false
+		}
+		return err == nil
+	})
+
+	return determined, err
+}
+
+func allStringsIn(strs []string, set map[string]bool) bool {
+	for _, s := range strs {
+		if !set[s] {
+			return false
+		}
+	}
+	return true
+}
+
+func expressionReferencesOnlyGroupBys(groupBys []string, determined map[string]bool, expr sql.Expression) bool {
 	valid := true
 	sql.Inspect(expr, func(expr sql.Expression) bool {
 		switch expr := expr.(type) {
-		case nil, sql.Aggregation, *expression.Literal:
+		case nil, sql.Aggregation, *expression.Literal, *function.AnyValue:
+			return false
+		case *expression.GetField:
+			if !stringContains(groupBys, expr.String()) && !determined[strings.ToLower(expr.Table())] {
+				valid = false
+			}
 			return false
 		case *expression.Alias, sql.FunctionExpression:
 			if stringContains(groupBys, expr.String()) {
@@ -273,10 +387,11 @@ func expressionReferencesOnlyGroupBys(groupBys []string, expr sql.Expression) bo
 			return true
 		// cc: https://dev.mysql.com/doc/refman/8.0/en/group-by-handling.html
 		// Each part of the SelectExpr must refer to the aggregated columns in some way
-		// TODO: this isn't complete, it's overly restrictive. Dependant columns are fine to reference.
+		// Columns of tables whose key is grouped on are functionally dependent on the group by, and fine to reference.
 		default:
+			// An expression grouped on is valid as a whole, whatever the columns it references
 			if stringContains(groupBys, expr.String()) {
-				return true
+				return false
 			}
 
 			if len(expr.Children()) == 0 {
@@ -479,6 +594,8 @@ func validateOperands(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (
 	// * The following expression nodes are allowed to have `n` columns as
 	// long as `n` matches:
 	//   * *plan.InSubquery, *expression.{Equals,NullSafeEquals,GreaterThan,LessThan,GreaterThanOrEqual,LessThanOrEqual}
+	// * *plan.ExistsSubquery may have a subquery with any number of columns.
+	// * *expression.SetField may assign a value with `n` columns to a tuple of `n` columns.
 	// * *expression.InTuple must have a tuple on the right side, the # of
 	// columns for each element of the tuple must match the number of
 	// columns of the expression on the left.
@@ -493,8 +610,9 @@ func validateOperands(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (
 		}
 		if er, ok := n.(sql.Expressioner); ok {
 			for _, e := range er.Expressions() {
+				_, isSetField := e.(*expression.SetField)
 				nc := sql.NumColumns(e.Type())
-				if nc != 1 {
+				if nc != 1 && !isSetField {
 					err = sql.ErrInvalidOperandColumns.New(1, nc)
 					return false
 				}
@@ -507,7 +625,7 @@ func validateOperands(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (
 					}
 					switch e.(type) {
 					case *plan.InSubquery, *expression.Equals, *expression.NullSafeEquals, *expression.GreaterThan,
-						*expression.LessThan, *expression.GreaterThanOrEqual, *expression.LessThanOrEqual:
+						*expression.LessThan, *expression.GreaterThanOrEqual, *expression.LessThanOrEqual, *expression.SetField:
 						err = sql.ErrIfMismatchedColumns(e.Children()[0].Type(), e.Children()[1].Type())
 					case *expression.InTuple, *expression.HashInTuple:
 						t, ok := e.Children()[1].(expression.Tuple)
@@ -529,6 +647,8 @@ func validateOperands(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (
 						}
 					case expression.Tuple:
 						// Tuple expressions can contain tuples...
+					case *plan.ExistsSubquery:
+						// EXISTS only checks for rows, so its subquery can have any number of columns.
 					default:
 						for _, e := range e.Children() {
 							nc := sql.NumColumns(e.Type())
@@ -624,6 +744,11 @@ func tableColsContains(strs []tableCol, target tableCol) bool {
 
 // validateReadOnlyDatabase invalidates queries that attempt to write to ReadOnlyDatabases.
 func validateReadOnlyDatabase(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.Node, error) {
+	// Describing a write doesn't perform it
+	if describing(scope) {
+		return n, nil
+	}
+
 	valid := true
 	var readOnlyDB sql.ReadOnlyDatabase
 
@@ -692,7 +817,8 @@ func validateReadOnlyTransaction(ctx *sql.Context, a *Analyzer, n sql.Node, scop
 	}
 
 	// If this is a normal read write transaction don't enforce read-only. Otherwise we must prevent an invalid query.
-	if !t.IsReadOnly() {
+	// Describing a write doesn't perform it.
+	if !t.IsReadOnly() || describing(scope) {
 		return n, nil
 	}
 
@@ -145,6 +145,56 @@ func TestValidateGroupByErr(t *testing.T) {
 	require.Error(err)
 }
 
+func TestValidateGroupByFunctionalDependency(t *testing.T) {
+	require := require.New(t)
+
+	vr := getValidationRule(validateGroupByRule)
+
+	child := memory.NewTable("test", sql.NewPrimaryKeySchema(sql.Schema{
+		{Name: "pk", Type: sql.Int64, Source: "test", PrimaryKey: true},
+		{Name: "col1", Type: sql.Text, Source: "test", Nullable: true},
+	}))
+
+	other := memory.NewTable("other", sql.NewPrimaryKeySchema(sql.Schema{
+		{Name: "pk", Type: sql.Int64, Source: "other", PrimaryKey: true},
+		{Name: "col2", Type: sql.Text, Source: "other", Nullable: true},
+	}))
+
+	// col1 is determined by the primary key of its table
+	p := plan.NewGroupBy(
+		[]sql.Expression{
+			expression.NewGetFieldWithTable(0, sql.Int64, "test", "pk", false),
+			expression.NewGetFieldWithTable(1, sql.Text, "test", "col1", true),
+		},
+		[]sql.Expression{
+			expression.NewGetFieldWithTable(0, sql.Int64, "test", "pk", false),
+		},
+		plan.NewResolvedTable(child, nil, nil),
+	)
+
+	_, err := vr.Apply(sql.NewEmptyContext(), nil, p, nil)
+	require.NoError(err)
+
+	// col2 belongs to a table whose key isn't grouped on
+	p = plan.NewGroupBy(
+		[]sql.Expression{
+			expression.NewGetFieldWithTable(0, sql.Int64, "test", "pk", false),
+			expression.NewGetFieldWithTable(3, sql.Text, "other", "col2", true),
+		},
+		[]sql.Expression{
+			expression.NewGetFieldWithTable(0, sql.Int64, "test", "pk", false),
+		},
+		plan.NewCrossJoin(
+			plan.NewResolvedTable(child, nil, nil),
+			plan.NewResolvedTable(other, nil, nil),
+		),
+	)
+
+	_, err = vr.Apply(sql.NewEmptyContext(), nil, p, nil)
+	require.Error(err)
+	require.True(ErrValidationGroupBy.Is(err))
+}
+
 func TestValidateSchemaSource(t *testing.T) {
 	testCases := []struct {
 		name string
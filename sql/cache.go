@@ -24,10 +24,14 @@ import (
 	errors "gopkg.in/src-d/go-errors.v1"
 )
 
-// HashOf returns a hash of the given value to be used as key in a cache.
+// HashOf returns a hash of the given value to be used as key in a cache. All NULL values have the same hash, whether
+// they're nil or Null, which some expressions return.
 func HashOf(v Row) (uint64, error) {
 	hash := xxhash.New()
 	for _, x := range v {
+		if x == Null {
+			x = nil
+		}
 		// TODO: probably much faster to do this with a type switch
 		if _, err := hash.Write([]byte(fmt.Sprintf("%#v,", x))); err != nil {
 			return 0, err
@@ -14,6 +14,8 @@
 
 package sql
 
+import "strings"
+
 type Catalog interface {
 	// AllDatabases returns all databases known to this catalog
 	AllDatabases() []Database
@@ -49,3 +51,70 @@ type Catalog interface {
 	// UnlockTables unlocks all tables locked by the session id given
 	UnlockTables(ctx *Context, id uint32) error
 }
+
+// LowerCaseTableNamesCatalog is a Catalog that can be configured to match the names of databases, tables and columns
+// other than case-insensitively.
+type LowerCaseTableNamesCatalog interface {
+	Catalog
+
+	// LowerCaseTableNames returns how this catalog matches the names of databases, tables and columns
+	LowerCaseTableNames() LowerCaseTableNames
+}
+
+// GetLowerCaseTableNames returns how the catalog given matches the names of databases, tables and columns. Catalogs
+// that aren't a LowerCaseTableNamesCatalog, and nil catalogs, match them case-insensitively.
+func GetLowerCaseTableNames(c Catalog) LowerCaseTableNames {
+	if lc, ok := c.(LowerCaseTableNamesCatalog); ok {
+		return lc.LowerCaseTableNames()
+	}
+	return LowerCaseTableNames_Insensitive
+}
+
+// LowerCaseTableNames controls how the names of databases, tables and columns are matched, much like the
+// lower_case_table_names system variable in MySQL.
+type LowerCaseTableNames byte
+
+const (
+	// LowerCaseTableNames_Insensitive stores names as they're given and matches them case-insensitively, as with a
+	// lower_case_table_names of 2. This is the default.
+	LowerCaseTableNames_Insensitive LowerCaseTableNames = iota
+	// LowerCaseTableNames_Lowercase stores names in lower case and matches them case-insensitively, as with a
+	// lower_case_table_names of 1.
+	LowerCaseTableNames_Lowercase
+	// LowerCaseTableNames_Sensitive stores names as they're given and matches them case-sensitively, as with a
+	// lower_case_table_names of 0.
+	LowerCaseTableNames_Sensitive
+)
+
+// Equal returns whether the name given matches the stored name given.
+func (l LowerCaseTableNames) Equal(name, stored string) bool {
+	if l == LowerCaseTableNames_Sensitive {
+		return name == stored
+	}
+	return strings.EqualFold(name, stored)
+}
+
+// Key returns the name given in a form that's equal for any two names Equal matches, for use as a map key.
+func (l LowerCaseTableNames) Key(name string) string {
+	if l == LowerCaseTableNames_Sensitive {
+		return name
+	}
+	return strings.ToLower(name)
+}
+
+// MatchesName returns whether the name given matches the stored name of a database, or of a table or view in the
+// database given. The information_schema database and its tables are always matched case-insensitively, as in MySQL.
+func (l LowerCaseTableNames) MatchesName(db Database, name, stored string) bool {
+	if strings.EqualFold(db.Name(), "information_schema") {
+		return true
+	}
+	return l.Equal(name, stored)
+}
+
+// Fold returns the name given as it should be stored when creating a database or table.
+func (l LowerCaseTableNames) Fold(name string) string {
+	if l == LowerCaseTableNames_Lowercase {
+		return strings.ToLower(name)
+	}
+	return name
+}
@@ -0,0 +1,104 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/japanese"
+	"golang.org/x/text/encoding/korean"
+	"golang.org/x/text/encoding/simplifiedchinese"
+	"golang.org/x/text/encoding/traditionalchinese"
+)
+
+// characterSetEncodings are the encodings of the character sets that can't represent every Unicode character, which
+// are used to find the characters that they can't represent. Character sets without an encoding here are assumed to
+// represent any character.
+var characterSetEncodings = map[CharacterSet]encoding.Encoding{
+	CharacterSet_big5:     traditionalchinese.Big5,
+	CharacterSet_cp1250:   charmap.Windows1250,
+	CharacterSet_cp1251:   charmap.Windows1251,
+	CharacterSet_cp1256:   charmap.Windows1256,
+	CharacterSet_cp1257:   charmap.Windows1257,
+	CharacterSet_cp850:    charmap.CodePage850,
+	CharacterSet_cp852:    charmap.CodePage852,
+	CharacterSet_cp866:    charmap.CodePage866,
+	CharacterSet_cp932:    japanese.ShiftJIS,
+	CharacterSet_eucjpms:  japanese.EUCJP,
+	CharacterSet_euckr:    korean.EUCKR,
+	CharacterSet_gb18030:  simplifiedchinese.GB18030,
+	CharacterSet_gb2312:   simplifiedchinese.GBK,
+	CharacterSet_gbk:      simplifiedchinese.GBK,
+	CharacterSet_greek:    charmap.ISO8859_7,
+	CharacterSet_hebrew:   charmap.ISO8859_8,
+	CharacterSet_koi8r:    charmap.KOI8R,
+	CharacterSet_koi8u:    charmap.KOI8U,
+	CharacterSet_latin1:   charmap.Windows1252,
+	CharacterSet_latin2:   charmap.ISO8859_2,
+	CharacterSet_latin5:   charmap.ISO8859_9,
+	CharacterSet_latin7:   charmap.ISO8859_13,
+	CharacterSet_macroman: charmap.Macintosh,
+	CharacterSet_sjis:     japanese.ShiftJIS,
+	CharacterSet_ujis:     japanese.EUCJP,
+}
+
+// CanRepresent returns whether the CharacterSet has the character given.
+func (cs CharacterSet) CanRepresent(r rune) bool {
+	switch cs {
+	case CharacterSet_ascii:
+		return r < utf8.RuneSelf
+	case CharacterSet_ucs2, CharacterSet_utf8mb3:
+		// Only the characters of the Basic Multilingual Plane
+		return r <= 0xFFFF
+	}
+
+	enc, ok := characterSetEncodings[cs]
+	if !ok {
+		return true
+	}
+	_, err := enc.NewEncoder().String(string(r))
+	return err == nil
+}
+
+// ConvertString converts the string given, which holds UTF-8 text as every string value does, to the CharacterSet, as
+// CONVERT(x USING charset) does: its invalid byte sequences and the characters that the CharacterSet doesn't have are
+// replaced by '?'. The first invalid byte sequence found is returned along with the converted string, so that it can
+// be reported. The binary CharacterSet holds any byte, so strings converted to it are returned unchanged.
+func (cs CharacterSet) ConvertString(s string) (converted string, invalid string) {
+	if cs == CharacterSet_binary {
+		return s, ""
+	}
+
+	var sb strings.Builder
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		switch {
+		case r == utf8.RuneError && size <= 1:
+			if invalid == "" {
+				invalid = s[i : i+1]
+			}
+			sb.WriteByte('?')
+		case !cs.CanRepresent(r):
+			sb.WriteByte('?')
+		default:
+			sb.WriteString(s[i : i+size])
+		}
+		i += size
+	}
+	return sb.String(), invalid
+}
@@ -16,8 +16,13 @@ package sql
 
 import (
 	"fmt"
+	"sort"
 	"strings"
+	"unicode"
 
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
 	"gopkg.in/src-d/go-errors.v1"
 
 	"github.com/dolthub/go-mysql-server/internal/regex"
@@ -50,12 +55,73 @@ func sensitiveLikeMatcher(likeStr string) (regex.DisposableMatcher, error) {
 	return regex.NewDisposableMatcher("go", likeStr)
 }
 
+type binaryMatcher struct {
+	regex.DisposableMatcher
+}
+
+func (bm *binaryMatcher) Match(matchStr string) bool {
+	return bm.DisposableMatcher.Match(ByteRunes(matchStr))
+}
+
+// binaryLikeMatcher matches binary strings byte by byte, so that a single character wildcard matches exactly one
+// byte, and no letter case or accents are folded.
+func binaryLikeMatcher(likeStr string) (regex.DisposableMatcher, error) {
+	dm, err := regex.NewDisposableMatcher("go", ByteRunes(likeStr))
+	if err != nil {
+		return nil, err
+	}
+
+	return &binaryMatcher{dm}, nil
+}
+
+// ByteRunes returns a string with one rune for each byte of the string given, so that a regular expression matched
+// against it, itself converted the same way, matches the original string byte by byte as binary strings require.
+func ByteRunes(s string) string {
+	runes := make([]rune, len(s))
+	for i := 0; i < len(s); i++ {
+		runes[i] = rune(s[i])
+	}
+	return string(runes)
+}
+
+type accentInsensitiveMatcher struct {
+	regex.DisposableMatcher
+}
+
+func (am *accentInsensitiveMatcher) Match(matchStr string) bool {
+	return am.DisposableMatcher.Match(removeAccents(strings.ToLower(matchStr)))
+}
+
+func accentInsensitiveLikeMatcher(likeStr string) (regex.DisposableMatcher, error) {
+	dm, err := regex.NewDisposableMatcher("go", removeAccents(strings.ToLower(likeStr)))
+	if err != nil {
+		return nil, err
+	}
+
+	return &accentInsensitiveMatcher{dm}, nil
+}
+
 func insensitiveCompare(a, b string) int {
 	lowerA := strings.ToLower(a)
 	lowerB := strings.ToLower(b)
 	return strings.Compare(lowerA, lowerB)
 }
 
+func accentInsensitiveCompare(a, b string) int {
+	return strings.Compare(removeAccents(strings.ToLower(a)), removeAccents(strings.ToLower(b)))
+}
+
+// removeAccents returns the string given without the combining marks of its characters, so that accented letters are
+// replaced by their base letters, as in café becoming cafe.
+func removeAccents(s string) string {
+	t := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	result, _, err := transform.String(t, s)
+	if err != nil {
+		return s
+	}
+	return result
+}
+
 // Collation represents the collation of a string.
 type Collation struct {
 	Name        string
@@ -68,12 +134,18 @@ var Collations = map[string]Collation{}
 
 func newCollation(name string, cs CharacterSet) Collation {
 	c := Collation{Name: name, CharSet: cs, Compare: insensitiveCompare, LikeMatcher: insensitiveLikeMatcher}
+	if !c.IsAccentSensitive() {
+		c.Compare, c.LikeMatcher = accentInsensitiveCompare, accentInsensitiveLikeMatcher
+	}
 	Collations[name] = c
 	return c
 }
 
 func newCSCollation(name string, cs CharacterSet) Collation {
 	c := Collation{Name: name, CharSet: cs, Compare: strings.Compare, LikeMatcher: sensitiveLikeMatcher}
+	if cs == CharacterSet_binary {
+		c.LikeMatcher = binaryLikeMatcher
+	}
 	Collations[name] = c
 	return c
 }
@@ -690,10 +762,12 @@ var characterSetMaxLengths = map[CharacterSet]int64{
 
 var ErrCharacterSetNotSupported = errors.NewKind("Unknown character set: %v")
 var ErrCollationNotSupported = errors.NewKind("Unknown collation: %v")
+var ErrCollationIllegalMix = errors.NewKind("Illegal mix of collations (%v,%s) and (%v,%s) for operation '%s'")
+var ErrCollationInvalidForCharacterSet = errors.NewKind("COLLATION '%v' is not valid for CHARACTER SET '%v'")
 
 const (
 	Y        = "Yes"
-	N        = "No"
+	N        = ""
 	NoPad    = "NO PAD"
 	PadSpace = "PAD SPACE"
 )
@@ -985,6 +1059,18 @@ var SupportedCharsets = []CharacterSet{
 	CharacterSet_utf8mb4,
 }
 
+// CharacterSets returns all the registered character sets, sorted by name. Aliases such as utf8 aren't included.
+func CharacterSets() []CharacterSet {
+	charsets := make([]CharacterSet, 0, len(characterSetDescriptions))
+	for cs := range characterSetDescriptions {
+		charsets = append(charsets, cs)
+	}
+	sort.Slice(charsets, func(i, j int) bool {
+		return charsets[i] < charsets[j]
+	})
+	return charsets
+}
+
 // ParseCharacterSet takes in a string representing a CharacterSet and
 // returns the result if a match is found, or an error if not.
 func ParseCharacterSet(str string) (CharacterSet, error) {
@@ -1068,6 +1154,16 @@ func (cs CharacterSet) MaxLength() int64 {
 	return length
 }
 
+// IsUnicode returns whether the CharacterSet is one of the Unicode character sets.
+func (cs CharacterSet) IsUnicode() bool {
+	switch cs {
+	case CharacterSet_ucs2, CharacterSet_utf16, CharacterSet_utf16le, CharacterSet_utf32, CharacterSet_utf8mb3, CharacterSet_utf8mb4:
+		return true
+	default:
+		return false
+	}
+}
+
 // String returns the string representation of the CharacterSet.
 func (cs CharacterSet) String() string {
 	return string(cs)
@@ -1138,3 +1234,39 @@ func (c Collation) PadSpace() string {
 func (c Collation) Equals(other Collation) bool {
 	return c.Name == other.Name
 }
+
+// IsCaseSensitive returns whether two strings that only differ by letter case are distinct under this collation.
+func (c Collation) IsCaseSensitive() bool {
+	return c.CharSet == CharacterSet_binary || strings.HasSuffix(c.Name, "_bin") || strings.HasSuffix(c.Name, "_cs")
+}
+
+// IsAccentSensitive returns whether two strings that only differ by the accents of their letters are distinct under
+// this collation. Binary collations and those named _as are accent-sensitive, and those named _ai are not. Otherwise a
+// collation is accent-insensitive if it's case-insensitive, as in utf8mb4_general_ci, the way MySQL names them.
+func (c Collation) IsAccentSensitive() bool {
+	if c.CharSet == CharacterSet_binary {
+		return true
+	}
+	parts := strings.Split(c.Name, "_")
+	for _, part := range parts[1:] {
+		switch part {
+		case "bin", "as":
+			return true
+		case "ai":
+			return false
+		}
+	}
+	return parts[len(parts)-1] != "ci"
+}
+
+// SortKey returns a normalized form of the given string, such that two strings are equal under this collation if and
+// only if their sort keys are equal. Sort keys are suitable for hashing and deduplicating strings.
+func (c Collation) SortKey(s string) string {
+	if c.IsCaseSensitive() {
+		return s
+	}
+	if !c.IsAccentSensitive() {
+		return removeAccents(strings.ToLower(s))
+	}
+	return strings.ToLower(s)
+}
@@ -69,3 +69,57 @@ func testParseCollation(t *testing.T, charset *string, collation *string, binary
 		}
 	})
 }
+
+func TestCollationSortKey(t *testing.T) {
+	tests := []struct {
+		collation Collation
+		a         string
+		b         string
+		equal     bool
+	}{
+		{Collation_utf8mb4_general_ci, "Foo", "foo", true},
+		{Collation_utf8mb4_0900_ai_ci, "FOO", "foo", true},
+		{Collation_utf8mb4_bin, "Foo", "foo", false},
+		{Collation_utf8mb4_0900_as_cs, "Foo", "foo", false},
+		{Collation_binary, "Foo", "foo", false},
+		{Collation_utf8mb4_general_ci, "foo", "bar", false},
+		{Collation_utf8mb4_0900_ai_ci, "Café", "cafe", true},
+		{Collation_utf8mb4_0900_as_ci, "Café", "cafe", false},
+		{Collation_utf8mb4_0900_as_ci, "Café", "CAFÉ", true},
+		{Collation_utf8mb4_general_ci, "Café", "CAFE", true},
+		{Collation_utf8mb4_unicode_ci, "Café", "cafe", true},
+		{Collation_utf8mb4_0900_as_cs, "Café", "Cafe", false},
+		{Collation_utf8mb4_bin, "Café", "Cafe", false},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%s %s %s", test.collation, test.a, test.b), func(t *testing.T) {
+			assert.Equal(t, test.equal, test.collation.SortKey(test.a) == test.collation.SortKey(test.b))
+		})
+	}
+}
+
+func TestCollationIsAccentSensitive(t *testing.T) {
+	tests := []struct {
+		collation Collation
+		expected  bool
+	}{
+		{Collation_utf8mb4_0900_ai_ci, false},
+		{Collation_utf8mb4_general_ci, false},
+		{Collation_utf8mb4_unicode_ci, false},
+		{Collation_utf8mb4_cs_0900_ai_ci, false},
+		{Collation_latin1_swedish_ci, false},
+		{Collation_utf8mb4_0900_as_ci, true},
+		{Collation_utf8mb4_0900_as_cs, true},
+		{Collation_utf8mb4_cs_0900_as_cs, true},
+		{Collation_cp1250_czech_cs, true},
+		{Collation_utf8mb4_bin, true},
+		{Collation_binary, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.collation.String(), func(t *testing.T) {
+			assert.Equal(t, test.expected, test.collation.IsAccentSensitive())
+		})
+	}
+}
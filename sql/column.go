@@ -42,8 +42,49 @@ type Column struct {
 	PrimaryKey bool
 	// Comment contains the string comment for this column.
 	Comment string
-	// Extra contains any additional information to put in the `extra` column under `information_schema.columns`.
+	// Extra contains any additional information to put in the `extra` column under `information_schema.columns`. If
+	// empty, the information is derived from the other fields, as ExtraInfo describes.
 	Extra string
+	// OnUpdate contains the value the column is set to when its row is updated, as declared by an ON UPDATE clause, or nil
+	// if it has none.
+	OnUpdate *ColumnDefaultValue
+	// Generated contains the expression computing the value of a generated column, or nil if the column isn't generated.
+	Generated *ColumnDefaultValue
+	// Virtual is true if the column is a generated column computed when it's read, rather than stored.
+	Virtual bool
+}
+
+// ExtraInfo returns the additional information about this column reported in the `Extra` column of SHOW COLUMNS and
+// under `information_schema.columns`. Unless the Extra field is set, it's derived from the other fields as MySQL does:
+// auto_increment for auto-increment columns, DEFAULT_GENERATED for default values that are expressions, on update
+// CURRENT_TIMESTAMP for columns with an ON UPDATE clause, and VIRTUAL GENERATED or STORED GENERATED for generated
+// columns.
+func (c *Column) ExtraInfo() string {
+	if c.Extra != "" {
+		return c.Extra
+	}
+
+	var info []string
+	if c.AutoIncrement {
+		info = append(info, "auto_increment")
+	}
+	if c.Default != nil {
+		if _, isFunction := c.Default.Expression.(FunctionExpression); isFunction || !c.Default.IsLiteral() {
+			info = append(info, "DEFAULT_GENERATED")
+		}
+	}
+	if c.OnUpdate != nil {
+		// The only values columns may be set to on update are the current timestamp's
+		info = append(info, "on update CURRENT_TIMESTAMP")
+	}
+	if c.Generated != nil {
+		if c.Virtual {
+			info = append(info, "VIRTUAL GENERATED")
+		} else {
+			info = append(info, "STORED GENERATED")
+		}
+	}
+	return strings.Join(info, " ")
 }
 
 // Check ensures the value is correct for this column.
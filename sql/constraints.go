@@ -52,6 +52,23 @@ func (f *ForeignKeyConstraint) DebugString() string {
 	)
 }
 
+// ForeignKeyColumnTypesCompatible returns whether a foreign key column of the type given can reference a column of the
+// parent type. As in MySQL, integers and decimals must have the same size and sign, and character strings the same
+// collation, but strings don't need the same length.
+func ForeignKeyColumnTypesCompatible(child, parent Type) bool {
+	switch {
+	case IsTextOnly(child) && IsTextOnly(parent):
+		return child.(StringType).Collation().Name == parent.(StringType).Collation().Name
+	case IsBlob(child) && IsBlob(parent):
+		return true
+	case IsDecimal(child) && IsDecimal(parent):
+		c, p := child.(DecimalType), parent.(DecimalType)
+		return c.Precision() == p.Precision() && c.Scale() == p.Scale()
+	default:
+		return child.Type() == parent.Type()
+	}
+}
+
 // CheckDefinition defines a trigger. Integrators are not expected to parse or understand the trigger definitions,
 // but must store and return them when asked.
 type CheckDefinition struct {
@@ -65,6 +82,25 @@ type CheckConstraint struct {
 	Name     string
 	Expr     Expression
 	Enforced bool
+	// FromView is set when this constraint enforces the WITH CHECK OPTION of a view rather than a CHECK constraint of
+	// a table, in which case Name is the qualified name of the view and Expr is its WHERE clause.
+	FromView bool
+}
+
+// Violation returns the error for a row whose check expression evaluated to the result given, or nil if the row
+// satisfies this constraint. A row satisfies a table's CHECK constraint unless it evaluates to false, but a row written
+// through a view must be visible through it, so its WHERE clause must be true.
+func (c *CheckConstraint) Violation(result interface{}) error {
+	if c.FromView {
+		if !IsTrue(result) {
+			return ErrCheckOptionViolation.New(c.Name)
+		}
+		return nil
+	}
+	if IsFalse(result) {
+		return ErrCheckConstraintViolated.New(c.Name)
+	}
+	return nil
 }
 
 type CheckConstraints []*CheckConstraint
@@ -398,6 +398,18 @@ type RowInserter interface {
 	Closer
 }
 
+// InsertBatcher is a RowInserter that can insert many rows in a single call. When the RowInserter of a table implements
+// it, statements inserting a list of values hand their rows over in batches rather than one at a time.
+type InsertBatcher interface {
+	RowInserter
+	// InsertBatch inserts the rows given, in order. Either all of the rows are inserted, or none of them are and an error
+	// is returned, in which case the rows are inserted again one at a time with Insert, so that duplicate keys are
+	// handled by ON DUPLICATE KEY UPDATE or INSERT IGNORE and errors are reported for the row causing them. All the rows
+	// of a batch are evaluated before it's inserted, so tables with an AUTO_INCREMENT column must advance their sequence
+	// in GetNextAutoIncrementValue rather than waiting for the rows to be inserted.
+	InsertBatch(*Context, []Row) error
+}
+
 // DeleteableTable is a table that can process the deletion of rows
 type DeletableTable interface {
 	Table
@@ -551,6 +563,26 @@ type ReadOnlyDatabase interface {
 	IsReadOnly() bool
 }
 
+// CollatedDatabase is a Database that has a default character set and collation, which tables created within it
+// inherit when they don't specify their own.
+type CollatedDatabase interface {
+	Database
+
+	// GetCollation returns the default collation of this database.
+	GetCollation(ctx *Context) Collation
+	// SetCollation updates the default collation of this database.
+	SetCollation(ctx *Context, collation Collation) error
+}
+
+// GetDatabaseCollation returns the default collation of the given database, or the engine default if the database
+// does not track one.
+func GetDatabaseCollation(ctx *Context, db Database) Collation {
+	if collatedDb, ok := db.(CollatedDatabase); ok {
+		return collatedDb.GetCollation(ctx)
+	}
+	return Collation_Default
+}
+
 // VersionedDatabase is a Database that can return tables as they existed at different points in time. The engine
 // supports queries on historical table data via the AS OF construct introduced in SQL 2011.
 type VersionedDatabase interface {
@@ -573,6 +605,16 @@ const (
 	ReadOnly
 )
 
+// IsolationLevel is the isolation level of a transaction, named the same as the values of @@transaction_isolation.
+type IsolationLevel string
+
+const (
+	IsolationLevelReadUncommitted IsolationLevel = "READ-UNCOMMITTED"
+	IsolationLevelReadCommitted   IsolationLevel = "READ-COMMITTED"
+	IsolationLevelRepeatableRead  IsolationLevel = "REPEATABLE-READ"
+	IsolationLevelSerializable    IsolationLevel = "SERIALIZABLE"
+)
+
 // Transaction is an opaque type implemented by an integrator to record necessary information at the start of a
 // transaction. Active transactions will be recorded in the session.
 type Transaction interface {
@@ -605,11 +647,39 @@ type TransactionDatabase interface {
 	ReleaseSavepoint(ctx *Context, transaction Transaction, name string) error
 }
 
+// IsolationLevelTransactionDatabase is a TransactionDatabase that honors the isolation levels of the transactions it
+// starts. Databases that don't implement it are free to treat every transaction as REPEATABLE READ.
+type IsolationLevelTransactionDatabase interface {
+	TransactionDatabase
+
+	// StartTransactionWithIsolationLevel starts a new transaction with the isolation level given and returns it
+	StartTransactionWithIsolationLevel(ctx *Context, tCharacteristic TransactionCharacteristic, level IsolationLevel) (Transaction, error)
+}
+
+// StartTransaction starts a new transaction on the database given. Databases that implement
+// IsolationLevelTransactionDatabase are given the isolation level of the session, as set by SET TRANSACTION ISOLATION
+// LEVEL.
+func StartTransaction(ctx *Context, db TransactionDatabase, tCharacteristic TransactionCharacteristic) (Transaction, error) {
+	idb, ok := db.(IsolationLevelTransactionDatabase)
+	if !ok {
+		return db.StartTransaction(ctx, tCharacteristic)
+	}
+
+	level, err := ctx.GetTransactionIsolationLevel()
+	if err != nil {
+		return nil, err
+	}
+	return idb.StartTransactionWithIsolationLevel(ctx, tCharacteristic, level)
+}
+
 // TriggerDefinition defines a trigger. Integrators are not expected to parse or understand the trigger definitions,
 // but must store and return them when asked.
 type TriggerDefinition struct {
-	Name            string // The name of this trigger. Trigger names in a database are unique.
-	CreateStatement string // The text of the statement to create this trigger.
+	Name            string    // The name of this trigger. Trigger names in a database are unique.
+	CreateStatement string    // The text of the statement to create this trigger.
+	CreatedAt       time.Time // The time that the trigger was created.
+	SqlMode         string    // The SQL mode in effect when the trigger was created.
+	Definer         string    // The account the trigger was defined by, CURRENT_USER without a DEFINER clause.
 }
 
 // TriggerDatabase is a Database that supports the creation and execution of triggers. The engine handles all parsing
@@ -741,7 +811,8 @@ type ViewDefinition struct {
 // ViewDatabase is implemented by databases that persist view definitions
 type ViewDatabase interface {
 	// CreateView persists the definition a view with the name and select statement given. If a view with that name
-	// already exists, should return ErrExistingView
+	// already exists, should return ErrExistingView. The select statement ends in the view's WITH CHECK OPTION clause,
+	// if it has one, which should be stored along with it.
 	CreateView(ctx *Context, name string, selectStatement string) error
 
 	// DropView deletes the view named from persistent storage. If the view doesn't exist, should return
@@ -801,6 +872,58 @@ type Lockable interface {
 	Unlock(ctx *Context, id uint32) error
 }
 
+// RowLockStrength is the kind of lock taken on the rows read by a locking read.
+type RowLockStrength byte
+
+const (
+	// RowLockForUpdate is given by FOR UPDATE, and locks rows for writes.
+	RowLockForUpdate RowLockStrength = iota
+	// RowLockForShare is given by FOR SHARE or LOCK IN SHARE MODE, and locks rows for reads.
+	RowLockForShare
+)
+
+// RowLockWait is what a locking read does with a row that's already locked by another session.
+type RowLockWait byte
+
+const (
+	// RowLockWaitDefault waits for the lock on the row to be released.
+	RowLockWaitDefault RowLockWait = iota
+	// RowLockNoWait is given by the NOWAIT modifier, and fails the statement without waiting.
+	RowLockNoWait
+	// RowLockSkipLocked is given by the SKIP LOCKED modifier, and leaves the row out of the result.
+	RowLockSkipLocked
+)
+
+// RowLock is the locking clause of a locking read, as in SELECT ... FOR UPDATE SKIP LOCKED.
+type RowLock struct {
+	Strength RowLockStrength
+	Wait     RowLockWait
+}
+
+// String returns the locking clause of the lock, like FOR UPDATE SKIP LOCKED.
+func (l RowLock) String() string {
+	s := "FOR UPDATE"
+	if l.Strength == RowLockForShare {
+		s = "FOR SHARE"
+	}
+	switch l.Wait {
+	case RowLockNoWait:
+		s += " NOWAIT"
+	case RowLockSkipLocked:
+		s += " SKIP LOCKED"
+	}
+	return s
+}
+
+// LockableTable should be implemented by tables that can lock the rows read from them by a locking read, such as
+// SELECT ... FOR UPDATE. Tables that don't implement it are read as if no locking clause had been given.
+type LockableTable interface {
+	Table
+	// WithRowLock returns a version of the table that locks the rows read from it as the lock given describes. Rows
+	// locked by other sessions are waited for, skipped for SKIP LOCKED, or make reading them fail for NOWAIT.
+	WithRowLock(lock RowLock) Table
+}
+
 // StoredProcedureDetails are the details of the stored procedure. Integrators only need to store and retrieve the given
 // details for a stored procedure, as the engine handles all parsing and processing.
 type StoredProcedureDetails struct {
@@ -828,6 +951,33 @@ type StoredProcedureDatabase interface {
 	DropStoredProcedure(ctx *Context, name string) error
 }
 
+// StoredFunctionDetails are the details of the stored function. Integrators only need to store and retrieve the given
+// details for a stored function, as the engine handles all parsing and processing.
+type StoredFunctionDetails struct {
+	Name            string    // The name of this stored function. Names must be unique within a database.
+	CreateStatement string    // The CREATE statement for this stored function.
+	CreatedAt       time.Time // The time that the stored function was created.
+	ModifiedAt      time.Time // The time of the last modification to the stored function.
+}
+
+// StoredFunctionDatabase is a database that supports the creation of stored functions, which are called in expressions
+// like built-in functions. The engine will handle all parsing and execution logic for stored functions. Integrators
+// only need to store and retrieve StoredFunctionDetails, while verifying that all stored functions have a unique name
+// without regard to case-sensitivity.
+type StoredFunctionDatabase interface {
+	Database
+
+	// GetStoredFunctions returns all StoredFunctionDetails for the database.
+	GetStoredFunctions(ctx *Context) ([]StoredFunctionDetails, error)
+
+	// SaveStoredFunction stores the given StoredFunctionDetails to the database. The integrator should verify that
+	// the name of the new stored function is unique amongst existing stored functions.
+	SaveStoredFunction(ctx *Context, sfd StoredFunctionDetails) error
+
+	// DropStoredFunction removes the StoredFunctionDetails with the matching name from the database.
+	DropStoredFunction(ctx *Context, name string) error
+}
+
 // EvaluateCondition evaluates a condition, which is an expression whose value
 // will be nil or coerced boolean.
 func EvaluateCondition(ctx *Context, cond Expression, row Row) (interface{}, error) {
@@ -21,6 +21,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/dolthub/go-mysql-server/memory"
 	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/dolthub/go-mysql-server/sql/expression"
 )
@@ -62,3 +63,64 @@ func TestEvaluateCondition(t *testing.T) {
 		})
 	}
 }
+
+type isolationLevelTransaction sql.IsolationLevel
+
+func (t isolationLevelTransaction) String() string   { return string(t) }
+func (t isolationLevelTransaction) IsReadOnly() bool { return false }
+
+// isolationLevelDatabase is a database whose transactions are the isolation levels they're started with.
+type isolationLevelDatabase struct {
+	*memory.Database
+}
+
+var _ sql.IsolationLevelTransactionDatabase = isolationLevelDatabase{}
+
+func (d isolationLevelDatabase) StartTransaction(*sql.Context, sql.TransactionCharacteristic) (sql.Transaction, error) {
+	return isolationLevelTransaction(""), nil
+}
+
+func (d isolationLevelDatabase) StartTransactionWithIsolationLevel(_ *sql.Context, _ sql.TransactionCharacteristic, level sql.IsolationLevel) (sql.Transaction, error) {
+	return isolationLevelTransaction(level), nil
+}
+
+func (d isolationLevelDatabase) CommitTransaction(*sql.Context, sql.Transaction) error { return nil }
+func (d isolationLevelDatabase) Rollback(*sql.Context, sql.Transaction) error          { return nil }
+
+func (d isolationLevelDatabase) CreateSavepoint(*sql.Context, sql.Transaction, string) error {
+	return nil
+}
+
+func (d isolationLevelDatabase) RollbackToSavepoint(*sql.Context, sql.Transaction, string) error {
+	return nil
+}
+
+func (d isolationLevelDatabase) ReleaseSavepoint(*sql.Context, sql.Transaction, string) error {
+	return nil
+}
+
+func TestStartTransactionIsolationLevel(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewEmptyContext()
+	db := isolationLevelDatabase{memory.NewDatabase("mydb")}
+
+	tx, err := sql.StartTransaction(ctx, db, sql.ReadWrite)
+	require.NoError(err)
+	require.Equal(isolationLevelTransaction(sql.IsolationLevelRepeatableRead), tx)
+
+	for _, level := range []sql.IsolationLevel{
+		sql.IsolationLevelReadUncommitted,
+		sql.IsolationLevelReadCommitted,
+		sql.IsolationLevelRepeatableRead,
+		sql.IsolationLevelSerializable,
+	} {
+		require.NoError(ctx.SetSessionVariable(ctx, "tx_isolation", string(level)))
+		val, err := ctx.GetSessionVariable(ctx, sql.TransactionIsolationSessionVar)
+		require.NoError(err)
+		require.Equal(string(level), val)
+
+		tx, err := sql.StartTransaction(ctx, db, sql.ReadWrite)
+		require.NoError(err)
+		require.Equal(isolationLevelTransaction(level), tx)
+	}
+}
@@ -167,7 +167,7 @@ func (t decimalType) ConvertToDecimal(v interface{}) (decimal.NullDecimal, error
 			// The decimal library cannot handle all of the different formats
 			bf, _, err := new(big.Float).SetPrec(217).Parse(value, 0)
 			if err != nil {
-				return decimal.NullDecimal{}, err
+				return decimal.NullDecimal{}, ErrInvalidValue.New(value, t.String())
 			}
 			res, err = decimal.NewFromString(bf.Text('f', -1))
 			if err != nil {
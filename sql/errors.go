@@ -74,6 +74,9 @@ var (
 	// ErrInvalidJSONText is returned when a JSON string cannot be parsed or unmarshalled
 	ErrInvalidJSONText = errors.NewKind("Invalid JSON text: %s")
 
+	// ErrInvalidJSONPath is returned when a JSON path cannot be parsed
+	ErrInvalidJSONPath = errors.NewKind("Invalid JSON path expression. The error is around character position %d.")
+
 	// ErrDeleteRowNotFound
 	ErrDeleteRowNotFound = errors.NewKind("row was not found when attempting to delete")
 
@@ -107,6 +110,10 @@ var (
 	// ErrColumnDefaultDatetimeOnlyFunc is returned when a non datetime/timestamp column attempts to declare now/current_timestamp as a default value literal.
 	ErrColumnDefaultDatetimeOnlyFunc = errors.NewKind("only datetime/timestamp may declare default values of now()/current_timestamp() without surrounding parentheses")
 
+	// ErrInvalidOnUpdate is returned when a column declares an ON UPDATE clause other than the current timestamp, or a
+	// column that isn't a datetime/timestamp declares one.
+	ErrInvalidOnUpdate = errors.NewKind("Invalid ON UPDATE clause for '%s' column")
+
 	// ErrColumnDefaultSubquery is returned when a default value contains a subquery.
 	ErrColumnDefaultSubquery = errors.NewKind("default value on column `%s` may not contain subqueries")
 
@@ -116,6 +123,9 @@ var (
 	// ErrColumnDefaultReturnedNull is returned when a default expression evaluates to nil but the column is non-nullable.
 	ErrColumnDefaultReturnedNull = errors.NewKind(`default value attempted to return null but column is non-nullable`)
 
+	// ErrInvalidAutoIncrementDefault is returned when a default value is given to an auto_increment column.
+	ErrInvalidAutoIncrementDefault = errors.NewKind("Invalid default value for '%s'")
+
 	// ErrDropColumnReferencedInDefault is returned when a column cannot be dropped as it is referenced by another column's default value.
 	ErrDropColumnReferencedInDefault = errors.NewKind(`cannot drop column "%s" as default value of column "%s" references it`)
 
@@ -158,6 +168,36 @@ var (
 	// ErrCallIncorrectParameterCount is returned when a CALL statement has the incorrect number of parameters.
 	ErrCallIncorrectParameterCount = errors.NewKind("`%s` expected `%d` parameters but got `%d`")
 
+	// ErrStoredFunctionsNotSupported is returned when attempting to create a stored function on a database that doesn't support them.
+	ErrStoredFunctionsNotSupported = errors.NewKind(`database "%s" doesn't support stored functions`)
+
+	// ErrStoredFunctionAlreadyExists is returned when a stored function already exists.
+	ErrStoredFunctionAlreadyExists = errors.NewKind(`stored function "%s" already exists`)
+
+	// ErrStoredFunctionDoesNotExist is returned when a stored function does not exist.
+	ErrStoredFunctionDoesNotExist = errors.NewKind(`stored function "%s" does not exist`)
+
+	// ErrStoredFunctionCreateStatementInvalid is returned when a StoredFunctionDatabase returns a CREATE FUNCTION statement that is invalid.
+	ErrStoredFunctionCreateStatementInvalid = errors.NewKind(`Invalid CREATE FUNCTION statement: %s`)
+
+	// ErrStoredFunctionDuplicateParameterName is returned when a stored function has two (or more) parameters with the same name.
+	ErrStoredFunctionDuplicateParameterName = errors.NewKind("duplicate parameter name `%s` on stored function `%s`")
+
+	// ErrStoredFunctionRecursiveCall is returned when a stored function calls itself, directly or through other stored functions.
+	ErrStoredFunctionRecursiveCall = errors.NewKind("recursive call of stored function `%s`: recursive stored functions are not allowed")
+
+	// ErrStoredFunctionIncorrectArgumentCount is returned when a stored function is called with the incorrect number of arguments.
+	ErrStoredFunctionIncorrectArgumentCount = errors.NewKind("Incorrect number of arguments for FUNCTION %s; expected %d, got %d")
+
+	// ErrStoredFunctionNoReturn is returned when a stored function with a compound statement body has no RETURN statement.
+	ErrStoredFunctionNoReturn = errors.NewKind("No RETURN found in FUNCTION %s")
+
+	// ErrStoredFunctionEndedWithoutReturn is returned when the body of a stored function ends without running a RETURN statement.
+	ErrStoredFunctionEndedWithoutReturn = errors.NewKind("FUNCTION %s ended without RETURN")
+
+	// ErrStoredFunctionResultSet is returned when the body of a stored function has a statement that returns rows.
+	ErrStoredFunctionResultSet = errors.NewKind("Not allowed to return a result set from a function")
+
 	// ErrUnknownSystemVariable is returned when a query references a system variable that doesn't exist
 	ErrUnknownSystemVariable = errors.NewKind(`Unknown system variable '%s'`)
 
@@ -237,6 +277,13 @@ var (
 	// more than 1 row without an attached IN clause.
 	ErrExpectedSingleRow = errors.NewKind("the subquery returned more than 1 row")
 
+	// ErrSelectIntoMultipleRows is returned when a SELECT ... INTO statement selects more than one row.
+	ErrSelectIntoMultipleRows = errors.NewKind("Result consisted of more than one row")
+
+	// ErrSelectIntoColumnCount is returned when a SELECT ... INTO statement selects a number of columns other than the
+	// number of its variables.
+	ErrSelectIntoColumnCount = errors.NewKind("The used SELECT statements have a different number of columns")
+
 	// ErrUnknownConstraint is returned when a DROP CONSTRAINT statement refers to a constraint that doesn't exist
 	ErrUnknownConstraint = errors.NewKind("Constraint %q does not exist")
 
@@ -261,9 +308,18 @@ var (
 	// ErrForeignKeyColumnCountMismatch is called when the declared column and referenced column counts do not match.
 	ErrForeignKeyColumnCountMismatch = errors.NewKind("the foreign key must reference an equivalent number of columns")
 
+	// ErrUnknownForeignKeyColumn is returned when a foreign key names a column that its table doesn't have.
+	ErrUnknownForeignKeyColumn = errors.NewKind("unknown column: '%s' in foreign key '%s'")
+
+	// ErrForeignKeyColumnTypeMismatch is returned when a foreign key column can't reference a column of its type.
+	ErrForeignKeyColumnTypeMismatch = errors.NewKind("referencing column '%s' and referenced column '%s' in foreign key '%s' are incompatible")
+
 	// ErrForeignKeyNotResolved is called when an add or update is attempted on a foreign key that has not been resolved yet.
 	ErrForeignKeyNotResolved = errors.NewKind("cannot add or update a child row: a foreign key constraint fails (`%s`, CONSTRAINT `%s` FOREIGN KEY (`%s`) REFERENCES `%s` (`%s`))")
 
+	// ErrDuplicateIndexName is returned when a table is given two indexes with the same name.
+	ErrDuplicateIndexName = errors.NewKind("Duplicate key name '%s'")
+
 	// ErrDuplicateEntry is returns when a duplicate entry is placed on an index such as a UNIQUE or a Primary Key.
 	ErrDuplicateEntry = errors.NewKind("Duplicate entry for key '%s'")
 
@@ -303,6 +359,9 @@ var (
 	// ErrCantDropIndex is return when a table can't drop an index due to a foreign key relationship.
 	ErrCantDropIndex = errors.NewKind("error: can't drop index '%s': needed in a foreign key constraint")
 
+	// ErrTooLongIndexComment is returned when the comment given to an index is longer than MaxIndexCommentLength.
+	ErrTooLongIndexComment = errors.NewKind("Comment for index '%s' is too long (max = %d)")
+
 	// ErrImmutableDatabaseProvider is returned when attempting to edit an immutable database databaseProvider.
 	ErrImmutableDatabaseProvider = errors.NewKind("error: can't modify database databaseProvider")
 
@@ -319,12 +378,19 @@ var (
 	// function is different from the function arity.
 	ErrInvalidArgumentNumber = errors.NewKind("function '%s' expected %v arguments, %v received")
 
+	// ErrInvalidAggregateFunction is returned when a custom aggregate function is registered with invalid metadata, or
+	// when an instance of it doesn't match that metadata.
+	ErrInvalidAggregateFunction = errors.NewKind("invalid aggregate function '%s': %s")
+
 	// ErrDatabaseNotFound is thrown when a database is not found
 	ErrDatabaseNotFound = errors.NewKind("database not found: %s")
 
 	// ErrNoDatabaseSelected is thrown when a database is not selected and the query requires one
 	ErrNoDatabaseSelected = errors.NewKind("no database selected")
 
+	// ErrDatabaseCollationsNotSupported is returned when changing the collation of a database that doesn't track one
+	ErrDatabaseCollationsNotSupported = errors.NewKind("database %s does not support collation operations")
+
 	// ErrAsOfNotSupported is thrown when an AS OF query is run on a database that can't support it
 	ErrAsOfNotSupported = errors.NewKind("AS OF not supported for database %s")
 
@@ -350,10 +416,142 @@ var (
 	// ErrViewDoesNotExist is returned when a DROP VIEW statement drops a view that does not exist
 	ErrViewDoesNotExist = errors.NewKind("the view %s.%s does not exist")
 
+	// ErrViewsDoNotExist is returned when a DROP VIEW statement drops several views that do not exist
+	ErrViewsDoNotExist = errors.NewKind("the views %s do not exist")
+
+	// ErrNonUpdatableTable is returned when an INSERT, UPDATE or DELETE targets a view that can't be written through.
+	ErrNonUpdatableTable = errors.NewKind("The target table %s of the %s is not updatable")
+
+	// ErrCheckOptionViolation is returned when a write through a view defined WITH CHECK OPTION produces a row the
+	// view cannot see.
+	ErrCheckOptionViolation = errors.NewKind("CHECK OPTION failed '%s'")
+
 	// ErrSessionDoesNotSupportPersistence is thrown when a feature is not already supported
 	ErrSessionDoesNotSupportPersistence = errors.NewKind("session does not support persistence")
+
+	// ErrNoTablesUsed is returned when a SELECT * has no tables to select from, such as a SELECT without a FROM clause.
+	ErrNoTablesUsed = errors.NewKind("No tables used")
+
+	// ErrCteRecursionLimit is returned when the recursive part of a recursive common table expression keeps producing
+	// rows after @@cte_max_recursion_depth iterations.
+	ErrCteRecursionLimit = errors.NewKind("Recursive common table expression '%s' aborted after %d iterations. Try increasing @@cte_max_recursion_depth to a larger value")
+
+	// ErrCteRecursionWithoutUnion is returned when a recursive common table expression isn't a UNION of its
+	// non-recursive and recursive parts.
+	ErrCteRecursionWithoutUnion = errors.NewKind("Recursive Common Table Expression '%s' should contain a UNION")
+
+	// ErrCteRecursiveAnchor is returned when the non-recursive part of a recursive common table expression refers to
+	// the expression itself.
+	ErrCteRecursiveAnchor = errors.NewKind("Recursive Common Table Expression '%s' should have one or more non-recursive query blocks followed by one or more recursive ones")
+
+	// ErrGroupConcatDistinctOrderBy is returned when a GROUP_CONCAT(DISTINCT ...) orders by an expression that is not
+	// one of its concatenated expressions.
+	ErrGroupConcatDistinctOrderBy = errors.NewKind("expression #%d of ORDER BY clause is not in GROUP_CONCAT(DISTINCT ...) list")
+
+	// ErrWindowDistinctFrame is returned when an aggregate function with DISTINCT is used as a window function whose
+	// frame isn't the whole partition, as it is when the window is ordered.
+	ErrWindowDistinctFrame = errors.NewKind("This version of MySQL doesn't yet support '<window function>(DISTINCT ..)' with a window frame")
+
+	// ErrDataTooLong is returned in strict SQL mode when a string value is too long for the column it's stored in.
+	ErrDataTooLong = errors.NewKind("Data too long for column '%s' at row %d")
+
+	// ErrDataOutOfRange is returned in strict SQL mode when a number is out of the range of the column it's stored in.
+	ErrDataOutOfRange = errors.NewKind("Out of range value for column '%s' at row %d")
+
+	// ErrInvalidDate is returned in strict SQL mode when a value stored in a date or time column isn't a valid one.
+	ErrInvalidDate = errors.NewKind("Incorrect %s value: '%v' for column '%s' at row %d")
+
+	// ErrIncorrectValue is returned in strict SQL mode when a string stored in a number column isn't a number.
+	ErrIncorrectValue = errors.NewKind("Incorrect %s value: '%v' for column '%s' at row %d")
+
+	// ErrNoSuchGrant is returned when SHOW GRANTS names an account that doesn't exist.
+	ErrNoSuchGrant = errors.NewKind("There is no such grant defined for user '%s' on host '%s'")
 )
 
+// sqlErrorCode is the MySQL error code and SQLSTATE value sent to clients for a kind of error.
+type sqlErrorCode struct {
+	kind     *errors.Kind
+	code     int
+	sqlState string
+}
+
+// sqlErrorCodes maps the kinds of errors returned by the engine to the MySQL error codes and SQLSTATE values sent to
+// clients for them, so that clients can tell them apart. Errors of other kinds are sent as mysql.ERUnknownError, with
+// the SQLSTATE value mysql.SSUnknownSQLState.
+var sqlErrorCodes = []sqlErrorCode{
+	{ErrSyntaxError, mysql.ERParseError, "42000"},
+	{ErrUnsupportedFeature, mysql.ERNotSupportedYet, "42000"},
+	{ErrNoDatabaseSelected, mysql.ERNoDb, "3D000"},
+	{ErrDatabaseNotFound, mysql.ERBadDb, "42000"},
+	{ErrDatabaseExists, mysql.ERDbCreateExists, mysql.SSUnknownSQLState},
+	{ErrTableNotFound, mysql.ERNoSuchTable, "42S02"},
+	{ErrTableAlreadyExists, mysql.ERTableExists, "42S01"},
+	{ErrViewDoesNotExist, mysql.ERBadTable, "42S02"},
+	{ErrViewsDoNotExist, mysql.ERBadTable, "42S02"},
+	{ErrExistingView, mysql.ERTableExists, "42S01"},
+	{ErrColumnNotFound, mysql.ERBadFieldError, mysql.SSBadFieldError},
+	{ErrTableColumnNotFound, mysql.ERBadFieldError, mysql.SSBadFieldError},
+	{ErrAmbiguousColumnName, mysql.ERNonUniq, "23000"},
+	{ErrDuplicateAliasOrTable, mysql.ERNonUniqTable, "42000"},
+	{ErrFunctionNotFound, 1305, "42000"},                            // TODO: Needs to be added to vitess
+	{ErrStoredProcedureDoesNotExist, 1305, "42000"},                 // TODO: Needs to be added to vitess
+	{ErrStoredFunctionDoesNotExist, 1305, "42000"},                  // TODO: Needs to be added to vitess
+	{ErrStoredFunctionAlreadyExists, 1304, "42000"},                 // TODO: Needs to be added to vitess
+	{ErrStoredFunctionIncorrectArgumentCount, 1318, "42000"},        // TODO: Needs to be added to vitess
+	{ErrStoredFunctionRecursiveCall, 1424, mysql.SSUnknownSQLState}, // TODO: Needs to be added to vitess
+	{ErrStoredFunctionNoReturn, 1320, "42000"},                      // TODO: Needs to be added to vitess
+	{ErrStoredFunctionEndedWithoutReturn, 1321, "2F005"},            // TODO: Needs to be added to vitess
+	{ErrStoredFunctionResultSet, 1415, "0A000"},                     // TODO: Needs to be added to vitess
+	{ErrInvalidArgumentNumber, 1582, "42000"},                       // TODO: Needs to be added to vitess
+	{ErrTriggerDoesNotExist, 1360, mysql.SSUnknownSQLState},         // TODO: Needs to be added to vitess
+	{ErrExpectedSingleRow, mysql.ERSubqueryNo1Row, "21000"},
+	{ErrSelectIntoMultipleRows, mysql.ERTooManyRows, "42000"},
+	{ErrSelectIntoColumnCount, mysql.ERWrongNumberOfColumnsInSelect, "21000"},
+	{ErrInvalidOperandColumns, mysql.EROperandColumns, "21000"},
+	{ErrInsertIntoNonNullableProvidedNull, mysql.ERBadNullError, mysql.SSBadNullError},
+	{ErrPrimaryKeyViolation, mysql.ERDupEntry, mysql.SSDupKey},
+	{ErrUniqueKeyViolation, mysql.ERDupEntry, mysql.SSDupKey},
+	{ErrDuplicateEntry, mysql.ERDupEntry, mysql.SSDupKey},
+	{ErrDuplicateIndexName, mysql.ERDupKeyName, "42000"},
+	{ErrForeignKeyChildViolation, mysql.ErNoReferencedRow2, "23000"},  // test with mysql returns 1452 vs 1216
+	{ErrForeignKeyParentViolation, mysql.ERRowIsReferenced2, "23000"}, // test with mysql returns 1451 vs 1215
+	{ErrCheckConstraintViolated, 3819, mysql.SSUnknownSQLState},       // TODO: Needs to be added to vitess
+	{ErrCheckOptionViolation, 1369, mysql.SSUnknownSQLState},          // TODO: Needs to be added to vitess
+	{ErrPartitionNotFound, 1526, mysql.SSUnknownSQLState},             // TODO: Needs to be added to vitess
+	{ErrInvalidJSONText, 3141, "22032"},                               // TODO: Needs to be added to vitess
+	{ErrInvalidJSONPath, 3143, "42000"},                               // TODO: Needs to be added to vitess
+	{ErrMultiplePrimaryKeysDefined, mysql.ERMultiplePriKey, "42000"},
+	{ErrWrongAutoKey, mysql.ERWrongAutoKey, "42000"},
+	{ErrKeyColumnDoesNotExist, mysql.ERKeyColumnDoesNotExist, "42000"},
+	{ErrUnknownForeignKeyColumn, mysql.ERKeyColumnDoesNotExist, "42000"},
+	{ErrForeignKeyColumnTypeMismatch, 3780, mysql.SSUnknownSQLState}, // TODO: Needs to be added to vitess
+	{ErrCantDropFieldOrKey, mysql.ERCantDropFieldOrKey, "42000"},
+	{ErrCantDropIndex, 1553, mysql.SSUnknownSQLState},       // TODO: Needs to be added to vitess
+	{ErrTooLongIndexComment, 1688, mysql.SSUnknownSQLState}, // TODO: Needs to be added to vitess
+	{ErrReadOnlyTransaction, 1792, "25006"},                 // TODO: Needs to be added to vitess
+	{ErrNoTablesUsed, mysql.ERNoTablesUsed, mysql.SSUnknownSQLState},
+	{ErrNonUpdatableTable, mysql.ERNonUpdateableTable, mysql.SSUnknownSQLState},
+	{ErrUnknownSystemVariable, mysql.ERUnknownSystemVariable, mysql.SSUnknownSQLState},
+	{ErrSystemVariableReadOnly, mysql.ERIncorrectGlobalLocalVar, mysql.SSUnknownSQLState},
+	{ErrInvalidSystemVariableValue, mysql.ERWrongValueForVar, "42000"},
+	{ErrCharacterSetNotSupported, mysql.ERUnknownCharacterSet, "42000"},
+	{ErrCollationIllegalMix, mysql.ERCantAggregate2Collations, mysql.SSUnknownSQLState},
+	{ErrCollationInvalidForCharacterSet, mysql.ERCollationCharsetMismatch, "42000"},
+	{ErrInvalidOnUpdate, mysql.ERInvalidOnUpdate, mysql.SSUnknownSQLState},
+	{ErrWindowDistinctFrame, mysql.ERNotSupportedYet, "42000"},
+	{ErrInvalidGISData, 3037, "22023"},                           // TODO: Needs to be added to vitess
+	{ErrCteRecursionWithoutUnion, 3573, mysql.SSUnknownSQLState}, // TODO: Needs to be added to vitess
+	{ErrCteRecursiveAnchor, 3574, mysql.SSUnknownSQLState},       // TODO: Needs to be added to vitess
+	{ErrCteRecursionLimit, 3636, mysql.SSUnknownSQLState},        // TODO: Needs to be added to vitess
+	{ErrDataTooLong, mysql.ERDataTooLong, mysql.SSDataTooLong},
+	{ErrDataOutOfRange, 1264, mysql.SSDataOutOfRange}, // TODO: Needs to be added to vitess
+	{ErrNoSuchGrant, mysql.ERNonExistingGrant, "42000"},
+	{ErrInvalidDate, mysql.ERTruncatedWrongValue, "22007"},
+	{ErrIncorrectValue, mysql.ERTruncatedWrongValueForField, mysql.SSUnknownSQLState},
+}
+
+// CastSQLError returns the error given as a *mysql.SQLError carrying the MySQL error code and SQLSTATE value for its
+// kind, along with the original error. Returns true if the error given is nil.
 func CastSQLError(err error) (*mysql.SQLError, error, bool) {
 	if err == nil {
 		return nil, nil, true
@@ -362,55 +560,19 @@ func CastSQLError(err error) (*mysql.SQLError, error, bool) {
 		return mysqlErr, nil, false
 	}
 
-	var code int
-	var sqlState string = ""
-
 	if w, ok := err.(WrappedInsertError); ok {
 		return CastSQLError(w.Cause)
 	}
 
-	switch {
-	case ErrTableNotFound.Is(err):
-		code = mysql.ERNoSuchTable
-	case ErrDatabaseExists.Is(err):
-		code = mysql.ERDbCreateExists
-	case ErrExpectedSingleRow.Is(err):
-		code = mysql.ERSubqueryNo1Row
-	case ErrInvalidOperandColumns.Is(err):
-		code = mysql.EROperandColumns
-	case ErrInsertIntoNonNullableProvidedNull.Is(err):
-		code = mysql.ERBadNullError
-	case ErrPrimaryKeyViolation.Is(err):
-		code = mysql.ERDupEntry
-	case ErrUniqueKeyViolation.Is(err):
-		code = mysql.ERDupEntry
-	case ErrPartitionNotFound.Is(err):
-		code = 1526 // TODO: Needs to be added to vitess
-	case ErrForeignKeyChildViolation.Is(err):
-		code = mysql.ErNoReferencedRow2 // test with mysql returns 1452 vs 1216
-	case ErrForeignKeyParentViolation.Is(err):
-		code = mysql.ERRowIsReferenced2 // test with mysql returns 1451 vs 1215
-	case ErrDuplicateEntry.Is(err):
-		code = mysql.ERDupEntry
-	case ErrInvalidJSONText.Is(err):
-		code = 3141 // TODO: Needs to be added to vitess
-	case ErrMultiplePrimaryKeysDefined.Is(err):
-		code = mysql.ERMultiplePriKey
-	case ErrWrongAutoKey.Is(err):
-		code = mysql.ERWrongAutoKey
-	case ErrKeyColumnDoesNotExist.Is(err):
-		code = mysql.ERKeyColumnDoesNotExist
-	case ErrCantDropFieldOrKey.Is(err):
-		code = mysql.ERCantDropFieldOrKey
-	case ErrReadOnlyTransaction.Is(err):
-		code = 1792 // TODO: Needs to be added to vitess
-	case ErrCantDropIndex.Is(err):
-		code = 1553 // TODO: Needs to be added to vitess
-	default:
-		code = mysql.ERUnknownError
+	code, sqlState := mysql.ERUnknownError, mysql.SSUnknownSQLState
+	for _, c := range sqlErrorCodes {
+		if c.kind.Is(err) {
+			code, sqlState = c.code, c.sqlState
+			break
+		}
 	}
 
-	return mysql.NewSQLError(code, sqlState, err.Error()), err, false // return the original error as well
+	return mysql.NewSQLError(code, sqlState, "%s", err.Error()), err, false // return the original error as well
 }
 
 type UniqueKeyError struct {
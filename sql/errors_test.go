@@ -13,13 +13,18 @@ import (
 func TestSQLErrorCast(t *testing.T) {
 
 	tests := []struct {
-		err  error
-		code int
+		err      error
+		code     int
+		sqlState string
 	}{
-		{ErrTableNotFound.New("table not found err"), mysql.ERNoSuchTable},
-		{ErrInvalidType.New("unhandled mysql error"), mysql.ERUnknownError},
-		{fmt.Errorf("generic error"), mysql.ERUnknownError},
-		{nil, mysql.ERUnknownError},
+		{ErrTableNotFound.New("table not found err"), mysql.ERNoSuchTable, "42S02"},
+		{ErrColumnNotFound.New("c"), mysql.ERBadFieldError, mysql.SSBadFieldError},
+		{ErrPrimaryKeyViolation.New("[1]"), mysql.ERDupEntry, mysql.SSDupKey},
+		{NewUniqueKeyErr("[1]", false, nil), mysql.ERDupEntry, mysql.SSDupKey},
+		{ErrIncorrectValue.New("integer", "12abc", "c", 1), mysql.ERTruncatedWrongValueForField, mysql.SSUnknownSQLState},
+		{ErrInvalidType.New("unhandled mysql error"), mysql.ERUnknownError, mysql.SSUnknownSQLState},
+		{fmt.Errorf("generic error"), mysql.ERUnknownError, mysql.SSUnknownSQLState},
+		{nil, mysql.ERUnknownError, ""},
 	}
 
 	for _, test := range tests {
@@ -29,6 +34,7 @@ func TestSQLErrorCast(t *testing.T) {
 			if !ok {
 				require.Error(t, err)
 				assert.Equal(t, err.Number(), test.code)
+				assert.Equal(t, err.SQLState(), test.sqlState)
 			} else {
 				assert.Equal(t, err, nilErr)
 			}
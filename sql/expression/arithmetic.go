@@ -245,6 +245,19 @@ func (a *Arithmetic) evalLeftRight(ctx *sql.Context, row sql.Row) (interface{},
 }
 
 func (a *Arithmetic) convertLeftRight(left interface{}, right interface{}) (interface{}, interface{}, error) {
+	switch strings.ToLower(a.Op) {
+	case sqlparser.ShiftLeftStr, sqlparser.ShiftRightStr:
+		l, err := ConvertToUint64(left)
+		if err != nil {
+			return nil, nil, err
+		}
+		r, err := ConvertToUint64(right)
+		if err != nil {
+			return nil, nil, err
+		}
+		return l, r, nil
+	}
+
 	var err error
 	typ := a.Type()
 
@@ -447,6 +460,21 @@ func bitXor(lval, rval interface{}) (interface{}, error) {
 	return nil, errUnableToCast.New(lval, rval)
 }
 
+// ConvertToUint64 converts the value given to an unsigned 64-bit integer, as the operands of shifts and of BIT_COUNT
+// are. Negative values wrap around, as in MySQL.
+func ConvertToUint64(v interface{}) (uint64, error) {
+	u, err := sql.Uint64.Convert(v)
+	if err == nil {
+		return u.(uint64), nil
+	}
+	i, ierr := sql.Int64.Convert(v)
+	if ierr != nil {
+		return 0, err
+	}
+	return uint64(i.(int64)), nil
+}
+
+// shiftLeft shifts lval left by rval bits. Shifting by 64 or more bits yields 0.
 func shiftLeft(lval, rval interface{}) (interface{}, error) {
 	switch l := lval.(type) {
 	case uint64:
@@ -459,6 +487,7 @@ func shiftLeft(lval, rval interface{}) (interface{}, error) {
 	return nil, errUnableToCast.New(lval, rval)
 }
 
+// shiftRight shifts lval right by rval bits. Shifting by 64 or more bits yields 0.
 func shiftRight(lval, rval interface{}) (interface{}, error) {
 	switch l := lval.(type) {
 	case uint64:
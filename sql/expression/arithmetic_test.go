@@ -15,6 +15,7 @@
 package expression
 
 import (
+	"math"
 	"testing"
 	"time"
 
@@ -249,6 +250,12 @@ func TestShiftLeft(t *testing.T) {
 		{"1 << 3", 1, 3, 8},
 		{"1024 << 0", 1024, 0, 1024},
 		{"0 << 1024", 0, 1024, 0},
+		{"1 << 63", 1, 63, 1 << 63},
+		{"1 << 64", 1, 64, 0},
+		{"1 << 100", 1, 100, 0},
+		{"max << 0", math.MaxUint64, 0, math.MaxUint64},
+		{"max << 1", math.MaxUint64, 1, math.MaxUint64 - 1},
+		{"max << 63", math.MaxUint64, 63, 1 << 63},
 	}
 
 	for _, tt := range testCases {
@@ -275,6 +282,10 @@ func TestShiftRight(t *testing.T) {
 		{"3 >> 1", 3, 1, 1},
 		{"1024 >> 0", 1024, 0, 1024},
 		{"0 >> 1024", 0, 1024, 0},
+		{"max >> 0", math.MaxUint64, 0, math.MaxUint64},
+		{"max >> 63", math.MaxUint64, 63, 1},
+		{"max >> 64", math.MaxUint64, 64, 0},
+		{"max >> 100", math.MaxUint64, 100, 0},
 	}
 
 	for _, tt := range testCases {
@@ -290,6 +301,30 @@ func TestShiftRight(t *testing.T) {
 	}
 }
 
+func TestShiftConversion(t *testing.T) {
+	var testCases = []struct {
+		name     string
+		expr     sql.Expression
+		expected interface{}
+	}{
+		{"-1 >> 60", NewShiftRight(NewLiteral(int64(-1), sql.Int64), NewLiteral(int64(60), sql.Int64)), uint64(15)},
+		{"-1 << 1", NewShiftLeft(NewLiteral(int64(-1), sql.Int64), NewLiteral(int64(1), sql.Int64)), uint64(math.MaxUint64 - 1)},
+		{"1 << -1", NewShiftLeft(NewLiteral(int64(1), sql.Int64), NewLiteral(int64(-1), sql.Int64)), uint64(0)},
+		{"'3' << 2", NewShiftLeft(NewLiteral("3", sql.LongText), NewLiteral(int8(2), sql.Int8)), uint64(12)},
+		{"NULL << 1", NewShiftLeft(NewLiteral(nil, sql.Null), NewLiteral(int8(1), sql.Int8)), nil},
+		{"1 >> NULL", NewShiftRight(NewLiteral(int8(1), sql.Int8), NewLiteral(nil, sql.Null)), nil},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			result, err := tt.expr.Eval(sql.NewEmptyContext(), sql.NewRow())
+			require.NoError(err)
+			require.Equal(tt.expected, result)
+		})
+	}
+}
+
 func TestBitAnd(t *testing.T) {
 	var testCases = []struct {
 		name        string
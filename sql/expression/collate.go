@@ -0,0 +1,85 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression
+
+import (
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// CollatedExpression is an expression with a COLLATE clause, whose strings compare, sort and deduplicate under the
+// collation given instead of the collation of the expression.
+//
+// cc: https://dev.mysql.com/doc/refman/8.0/en/charset-collate.html
+type CollatedExpression struct {
+	UnaryExpression
+	// collationName is the name of the collation rather than the collation itself, which has functions in it and so
+	// would never be deeply equal to itself.
+	collationName string
+}
+
+var _ sql.Expression = (*CollatedExpression)(nil)
+
+// NewCollatedExpression creates a new CollatedExpression.
+func NewCollatedExpression(e sql.Expression, collation sql.Collation) *CollatedExpression {
+	return &CollatedExpression{UnaryExpression{Child: e}, collation.Name}
+}
+
+// Collation returns the collation of the expression.
+func (c *CollatedExpression) Collation() sql.Collation {
+	return sql.Collations[c.collationName]
+}
+
+func (c *CollatedExpression) String() string {
+	return fmt.Sprintf("%s COLLATE %s", c.Child.String(), c.collationName)
+}
+
+// Type implements the sql.Expression interface. It's a string type with the collation of the expression.
+func (c *CollatedExpression) Type() sql.Type {
+	if st, ok := c.Child.Type().(sql.StringType); ok {
+		if typ, err := sql.CreateString(st.Type(), st.MaxCharacterLength(), c.Collation()); err == nil {
+			return typ
+		}
+	}
+	return sql.CreateLongText(c.Collation())
+}
+
+// Eval implements the sql.Expression interface. Strings of a character set that the collation doesn't apply to are an
+// error, and other values are converted to strings.
+func (c *CollatedExpression) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	if st, ok := c.Child.Type().(sql.StringType); ok {
+		if cs := st.Collation().CharacterSet(); !c.Collation().WorksWithCharacterSet(cs) {
+			return nil, sql.ErrCollationInvalidForCharacterSet.New(c.collationName, cs)
+		}
+	}
+
+	val, err := c.Child.Eval(ctx, row)
+	if err != nil || val == nil {
+		return nil, err
+	}
+	if _, ok := c.Child.Type().(sql.StringType); ok {
+		return val, nil
+	}
+	return sql.LongText.Convert(val)
+}
+
+// WithChildren implements the sql.Expression interface.
+func (c *CollatedExpression) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(c, len(children), 1)
+	}
+	return NewCollatedExpression(children[0], c.Collation()), nil
+}
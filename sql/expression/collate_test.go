@@ -0,0 +1,49 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression
+
+import (
+	"testing"
+
+	"github.com/dolthub/vitess/go/vt/proto/query"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+func TestCollatedExpression(t *testing.T) {
+	require := require.New(t)
+	ciType := sql.MustCreateString(query.Type_VARCHAR, 20, sql.Collation_utf8mb4_general_ci)
+
+	e := NewCollatedExpression(NewGetField(0, ciType, "name", true), sql.Collation_utf8mb4_bin)
+	require.Equal(sql.MustCreateString(query.Type_VARCHAR, 20, sql.Collation_utf8mb4_bin), e.Type())
+	require.Equal("abc", eval(t, e, sql.NewRow("abc")))
+	require.Nil(eval(t, e, sql.NewRow(nil)))
+
+	// The collation of the type is used to compare the values
+	cmp, err := e.Type().Compare("abc", "ABC")
+	require.NoError(err)
+	require.NotEqual(0, cmp)
+
+	// Values that aren't strings are converted to strings
+	e = NewCollatedExpression(NewLiteral(int64(5), sql.Int64), sql.Collation_utf8mb4_bin)
+	require.Equal(sql.CreateLongText(sql.Collation_utf8mb4_bin), e.Type())
+	require.Equal("5", eval(t, e, sql.NewRow()))
+
+	// The collation must apply to the character set of the strings
+	e = NewCollatedExpression(NewGetField(0, ciType, "name", true), sql.Collation_latin1_swedish_ci)
+	_, err = e.Eval(sql.NewEmptyContext(), sql.NewRow("abc"))
+	require.True(sql.ErrCollationInvalidForCharacterSet.Is(err))
+}
@@ -39,10 +39,12 @@ var ErrNilOperand = errors.NewKind("nil operand found in comparison")
 
 type comparison struct {
 	BinaryExpression
+	// op is the operator of the comparison, as it appears in error messages.
+	op string
 }
 
-func newComparison(left, right sql.Expression) comparison {
-	return comparison{BinaryExpression{left, right}}
+func newComparison(left, right sql.Expression, op string) comparison {
+	return comparison{BinaryExpression{left, right}, op}
 }
 
 // Compare the two given values using the types of the expressions in the comparison.
@@ -192,6 +194,17 @@ func (c *comparison) castLeftAndRight(left, right interface{}) (interface{}, int
 			return l, r, sql.Float64, nil
 		}
 
+		// A signed and an unsigned integer are compared by their values, so that negative numbers are less than any
+		// unsigned number and unsigned numbers beyond the range of BIGINT are greater than any signed number
+		if sql.IsInteger(leftType) && sql.IsInteger(rightType) && sql.IsSigned(leftType) != sql.IsSigned(rightType) {
+			l, r, err := convertLeftAndRight(left, right, ConvertToDecimal)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+
+			return l, r, sql.InternalDecimalType, nil
+		}
+
 		if sql.IsSigned(leftType) || sql.IsSigned(rightType) {
 			l, r, err := convertLeftAndRight(left, right, ConvertToSigned)
 			if err != nil {
@@ -218,12 +231,150 @@ func (c *comparison) castLeftAndRight(left, right interface{}) (interface{}, int
 		return l, r, sql.Datetime, nil
 	}
 
+	compareType := sql.LongText
+	if sql.IsText(leftType) && sql.IsText(rightType) {
+		leftCollation := leftType.(sql.StringType).Collation()
+		rightCollation := rightType.(sql.StringType).Collation()
+		if leftCollation.CharacterSet() != rightCollation.CharacterSet() {
+			collation, err := comparisonCollation(c.Left(), leftCollation, c.Right(), rightCollation, c.op)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			compareType = collatedText{sql.CreateLongText(collation)}
+		} else if coercibility(c.Left()) == coercibilityExplicit || coercibility(c.Right()) == coercibilityExplicit {
+			// Strings of the same character set are compared as binary strings, unless a COLLATE clause names the
+			// collation to compare them with, which is picked as it is for LIKE
+			collation, err := patternCollation(c.Left(), c.Right(), c.op)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			compareType = collatedText{sql.CreateLongText(collation)}
+		}
+	}
+
 	left, right, err := convertLeftAndRight(left, right, ConvertToChar)
 	if err != nil {
 		return nil, nil, nil, err
 	}
 
-	return left, right, sql.LongText, nil
+	return left, right, compareType, nil
+}
+
+// collatedText is a string type that compares strings using its collation.
+type collatedText struct {
+	sql.StringType
+}
+
+// Compare implements the sql.Type interface.
+func (t collatedText) Compare(a interface{}, b interface{}) (int, error) {
+	if a == nil || b == nil {
+		return t.StringType.Compare(a, b)
+	}
+
+	as, err := t.Convert(a)
+	if err != nil {
+		return 0, err
+	}
+	bs, err := t.Convert(b)
+	if err != nil {
+		return 0, err
+	}
+	return t.Collation().Compare(as.(string), bs.(string)), nil
+}
+
+// Coercibility levels of string expressions, from the strongest to the weakest. When strings of different character
+// sets are compared, the collation of the expression with the strongest coercibility is used.
+const (
+	coercibilityExplicit = iota
+	coercibilityNone
+	coercibilityImplicit
+	coercibilitySysconst
+	coercibilityCoercible
+	coercibilityNumeric
+	coercibilityIgnorable
+)
+
+var coercibilityNames = []string{"EXPLICIT", "NONE", "IMPLICIT", "SYSCONST", "COERCIBLE", "NUMERIC", "IGNORABLE"}
+
+// coercibility returns the coercibility of the collation of the string expression given.
+func coercibility(e sql.Expression) int {
+	switch e := e.(type) {
+	case *Literal:
+		if e.Value() == nil {
+			return coercibilityIgnorable
+		}
+		return coercibilityCoercible
+	case *CollatedExpression:
+		return coercibilityExplicit
+	case *SystemVar:
+		return coercibilitySysconst
+	default:
+		return coercibilityImplicit
+	}
+}
+
+// comparisonCollation returns the collation to compare the strings of the expressions given with, whose collations
+// belong to different character sets, following MySQL's rules: binary strings are compared as such, otherwise the
+// collation of the expression with the strongest coercibility wins, and between expressions of the same coercibility,
+// a Unicode collation wins over a non-Unicode one. Any other mix of collations is illegal.
+func comparisonCollation(left sql.Expression, leftCollation sql.Collation, right sql.Expression, rightCollation sql.Collation, op string) (sql.Collation, error) {
+	leftCoercibility, rightCoercibility := coercibility(left), coercibility(right)
+	switch {
+	case leftCollation.CharacterSet() == sql.CharacterSet_binary:
+		return leftCollation, nil
+	case rightCollation.CharacterSet() == sql.CharacterSet_binary:
+		return rightCollation, nil
+	case leftCoercibility < rightCoercibility:
+		return leftCollation, nil
+	case rightCoercibility < leftCoercibility:
+		return rightCollation, nil
+	case leftCollation.CharacterSet().IsUnicode() && !rightCollation.CharacterSet().IsUnicode():
+		return leftCollation, nil
+	case rightCollation.CharacterSet().IsUnicode() && !leftCollation.CharacterSet().IsUnicode():
+		return rightCollation, nil
+	default:
+		return sql.Collation{}, sql.ErrCollationIllegalMix.New(leftCollation, coercibilityNames[leftCoercibility],
+			rightCollation, coercibilityNames[rightCoercibility], op)
+	}
+}
+
+// RegexpCollation returns the collation that matching the text given against the regular expression pattern given
+// uses, which decides whether the match is case-sensitive, as returned by patternCollation.
+func RegexpCollation(text, pattern sql.Expression) (sql.Collation, error) {
+	return patternCollation(text, pattern, "regexp")
+}
+
+// patternCollation returns the collation that matching the text given against the pattern given with the operator
+// given uses. Like comparisons, the collation of the operand with the strongest coercibility wins, so an explicit
+// COLLATE clause on either operand decides it, and a binary string always makes the match binary. Between operands
+// of the same coercibility, a case-sensitive collation wins. Operands that aren't strings don't take part, and the
+// default collation is used when neither is a string.
+func patternCollation(text, pattern sql.Expression, op string) (sql.Collation, error) {
+	textType, textOk := text.Type().(sql.StringType)
+	patternType, patternOk := pattern.Type().(sql.StringType)
+	switch {
+	case textOk && patternOk:
+		textCollation, patternCollation := textType.Collation(), patternType.Collation()
+		if textCollation.Equals(patternCollation) {
+			return textCollation, nil
+		}
+		if textCollation.CharacterSet() != patternCollation.CharacterSet() {
+			return comparisonCollation(text, textCollation, pattern, patternCollation, op)
+		}
+		// Between collations of the same character set and coercibility, a binary collation wins
+		textCoercibility, patternCoercibility := coercibility(text), coercibility(pattern)
+		if patternCoercibility < textCoercibility ||
+			(patternCoercibility == textCoercibility && patternCollation.IsCaseSensitive()) {
+			return patternCollation, nil
+		}
+		return textCollation, nil
+	case textOk:
+		return textType.Collation(), nil
+	case patternOk:
+		return patternType.Collation(), nil
+	default:
+		return sql.Collation_Default, nil
+	}
 }
 
 func convertLeftAndRight(left, right interface{}, convertTo string) (interface{}, interface{}, error) {
@@ -258,7 +409,7 @@ type Equals struct {
 
 // NewEquals returns a new Equals expression.
 func NewEquals(left sql.Expression, right sql.Expression) *Equals {
-	return &Equals{newComparison(left, right)}
+	return &Equals{newComparison(left, right, "=")}
 }
 
 // Eval implements the Expression interface.
@@ -300,7 +451,7 @@ type NullSafeEquals struct {
 
 // NewNullSafeEquals returns a new NullSafeEquals expression.
 func NewNullSafeEquals(left sql.Expression, right sql.Expression) *NullSafeEquals {
-	return &NullSafeEquals{newComparison(left, right)}
+	return &NullSafeEquals{newComparison(left, right, "<=>")}
 }
 
 // Type implements the Expression interface.
@@ -383,7 +534,7 @@ func NewRegexp(left sql.Expression, right sql.Expression) *Regexp {
 	})
 
 	return &Regexp{
-		comparison: newComparison(left, right),
+		comparison: newComparison(left, right, "REGEXP"),
 		pool:       nil,
 		cached:     cached,
 		once:       sync.Once{},
@@ -423,6 +574,23 @@ func (re *Regexp) compareRegexp(ctx *sql.Context, row sql.Row) (interface{}, err
 		return nil, err
 	}
 
+	collation, err := RegexpCollation(re.Left(), re.Right())
+	if err != nil {
+		return nil, err
+	}
+	flags := ""
+	if !collation.IsCaseSensitive() {
+		flags = "(?i)"
+	}
+	// Binary strings are matched byte by byte, rather than character by character
+	binary := collation.CharacterSet() == sql.CharacterSet_binary
+	newMatcher := func(pattern string) (regex.DisposableMatcher, error) {
+		if binary {
+			pattern = sql.ByteRunes(pattern)
+		}
+		return regex.NewDisposableMatcher(regex.Default(), flags+pattern)
+	}
+
 	var matcher regex.DisposableMatcher
 
 	if !re.cached {
@@ -430,7 +598,7 @@ func (re *Regexp) compareRegexp(ctx *sql.Context, row sql.Row) (interface{}, err
 		if rerr != nil || right == nil {
 			return right, rerr
 		}
-		matcher, err = regex.NewDisposableMatcher(regex.Default(), *right)
+		matcher, err = newMatcher(*right)
 	} else {
 		re.once.Do(func() {
 			right, err := re.evalRight(ctx, row)
@@ -439,7 +607,7 @@ func (re *Regexp) compareRegexp(ctx *sql.Context, row sql.Row) (interface{}, err
 					if err != nil || right == nil {
 						return matcherErrTuple{nil, err}
 					}
-					m, e := regex.NewDisposableMatcher(regex.Default(), *right)
+					m, e := newMatcher(*right)
 					return matcherErrTuple{m, e}
 				},
 			}
@@ -454,7 +622,11 @@ func (re *Regexp) compareRegexp(ctx *sql.Context, row sql.Row) (interface{}, err
 		return nil, nil
 	}
 
-	ok := matcher.Match(left.(string))
+	text := left.(string)
+	if binary {
+		text = sql.ByteRunes(text)
+	}
+	ok := matcher.Match(text)
 
 	if !re.cached {
 		matcher.Dispose()
@@ -503,7 +675,7 @@ type GreaterThan struct {
 
 // NewGreaterThan creates a new GreaterThan expression.
 func NewGreaterThan(left sql.Expression, right sql.Expression) *GreaterThan {
-	return &GreaterThan{newComparison(left, right)}
+	return &GreaterThan{newComparison(left, right, ">")}
 }
 
 // Eval implements the Expression interface.
@@ -544,7 +716,7 @@ type NullSafeGreaterThan struct {
 
 // NewNullSafeGreaterThan creates a new NullSafeGreaterThan expression.
 func NewNullSafeGreaterThan(left sql.Expression, right sql.Expression) *NullSafeGreaterThan {
-	return &NullSafeGreaterThan{newComparison(left, right)}
+	return &NullSafeGreaterThan{newComparison(left, right, ">")}
 }
 
 // Eval implements the Expression interface.
@@ -580,7 +752,7 @@ type LessThan struct {
 
 // NewLessThan creates a new LessThan expression.
 func NewLessThan(left sql.Expression, right sql.Expression) *LessThan {
-	return &LessThan{newComparison(left, right)}
+	return &LessThan{newComparison(left, right, "<")}
 }
 
 // Eval implements the expression interface.
@@ -621,7 +793,7 @@ type NullSafeLessThan struct {
 
 // NewNullSafeLessThan creates a new NullSafeLessThan expression.
 func NewNullSafeLessThan(left sql.Expression, right sql.Expression) *NullSafeLessThan {
-	return &NullSafeLessThan{newComparison(left, right)}
+	return &NullSafeLessThan{newComparison(left, right, "<")}
 }
 
 // Eval implements the expression interface.
@@ -658,7 +830,7 @@ type GreaterThanOrEqual struct {
 
 // NewGreaterThanOrEqual creates a new GreaterThanOrEqual
 func NewGreaterThanOrEqual(left sql.Expression, right sql.Expression) *GreaterThanOrEqual {
-	return &GreaterThanOrEqual{newComparison(left, right)}
+	return &GreaterThanOrEqual{newComparison(left, right, ">=")}
 }
 
 // Eval implements the Expression interface.
@@ -699,7 +871,7 @@ type NullSafeGreaterThanOrEqual struct {
 
 // NewNullSafeGreaterThanOrEqual creates a new NullSafeGreaterThanOrEqual
 func NewNullSafeGreaterThanOrEqual(left sql.Expression, right sql.Expression) *NullSafeGreaterThanOrEqual {
-	return &NullSafeGreaterThanOrEqual{newComparison(left, right)}
+	return &NullSafeGreaterThanOrEqual{newComparison(left, right, ">=")}
 }
 
 // Eval implements the Expression interface.
@@ -736,7 +908,7 @@ type LessThanOrEqual struct {
 
 // NewLessThanOrEqual creates a LessThanOrEqual expression.
 func NewLessThanOrEqual(left sql.Expression, right sql.Expression) *LessThanOrEqual {
-	return &LessThanOrEqual{newComparison(left, right)}
+	return &LessThanOrEqual{newComparison(left, right, "<=")}
 }
 
 // Eval implements the Expression interface.
@@ -777,7 +949,7 @@ type NullSafeLessThanOrEqual struct {
 
 // NewNullSafeLessThanOrEqual creates a NullSafeLessThanOrEqual expression.
 func NewNullSafeLessThanOrEqual(left sql.Expression, right sql.Expression) *NullSafeLessThanOrEqual {
-	return &NullSafeLessThanOrEqual{newComparison(left, right)}
+	return &NullSafeLessThanOrEqual{newComparison(left, right, "<=")}
 }
 
 // Eval implements the Expression interface.
@@ -15,8 +15,11 @@
 package expression_test
 
 import (
+	"fmt"
+	"math"
 	"testing"
 
+	"github.com/dolthub/vitess/go/sqltypes"
 	"github.com/stretchr/testify/require"
 
 	"github.com/dolthub/go-mysql-server/internal/regex"
@@ -255,6 +258,35 @@ func testRegexpCases(t *testing.T) {
 	}
 }
 
+func TestRegexpBinary(t *testing.T) {
+	ciText := sql.MustCreateString(sqltypes.VarChar, 20, sql.Collation_utf8mb4_general_ci)
+	binary := sql.MustCreateBinary(sqltypes.VarBinary, 20)
+
+	testCases := []struct {
+		left, right    sql.Type
+		value, pattern string
+		ok             bool
+	}{
+		{ciText, ciText, "ABC", "^a", true},
+		{binary, ciText, "ABC", "^a", false},
+		{ciText, binary, "ABC", "^a", false},
+		{binary, ciText, "abc", "^a", true},
+		{ciText, ciText, "é", "^.$", true},
+		{binary, ciText, "é", "^.$", false},
+		{binary, ciText, "é", "^..$", true},
+	}
+
+	for _, tt := range testCases {
+		t.Run(fmt.Sprintf("%s %q REGEXP %s %q", tt.left, tt.value, tt.right, tt.pattern), func(t *testing.T) {
+			r := expression.NewRegexp(
+				expression.NewGetField(0, tt.left, "col1", true),
+				expression.NewLiteral(tt.pattern, tt.right),
+			)
+			require.Equal(t, tt.ok, eval(t, r, sql.NewRow(tt.value)))
+		})
+	}
+}
+
 func TestInvalidRegexp(t *testing.T) {
 	t.Helper()
 	require := require.New(t)
@@ -268,6 +300,90 @@ func TestInvalidRegexp(t *testing.T) {
 	require.Error(err)
 }
 
+func TestCompareCharacterSets(t *testing.T) {
+	latin1 := sql.CreateLongText(sql.Collation_latin1_swedish_ci)
+	ascii := sql.CreateLongText(sql.Collation_ascii_general_ci)
+	utf8mb4 := sql.CreateLongText(sql.Collation_utf8mb4_0900_ai_ci)
+	ctx := sql.NewEmptyContext()
+
+	testCases := []struct {
+		name        string
+		left, right sql.Expression
+		expected    interface{}
+		err         bool
+	}{
+		{
+			"unicode literal wins",
+			expression.NewLiteral("a", utf8mb4),
+			expression.NewLiteral("A", latin1),
+			true,
+			false,
+		},
+		{
+			"column wins over literal",
+			expression.NewGetField(0, latin1, "col1", true),
+			expression.NewLiteral("ABC", ascii),
+			true,
+			false,
+		},
+		{
+			"binary wins",
+			expression.NewLiteral("abc", sql.LongBlob),
+			expression.NewLiteral("ABC", latin1),
+			false,
+			false,
+		},
+		{
+			"illegal mix",
+			expression.NewLiteral("abc", latin1),
+			expression.NewLiteral("abc", ascii),
+			nil,
+			true,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := expression.NewEquals(tt.left, tt.right).Eval(ctx, sql.NewRow("abc"))
+			if tt.err {
+				require.True(t, sql.ErrCollationIllegalMix.Is(err), "unexpected error %v", err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestCompareSignedAndUnsigned(t *testing.T) {
+	signed := expression.NewGetField(0, sql.Int64, "s", true)
+	unsigned := expression.NewGetField(1, sql.Uint64, "u", true)
+
+	testCases := []struct {
+		s        int64
+		u        uint64
+		expected int
+	}{
+		{-1, math.MaxUint64, -1},
+		{math.MaxInt64, math.MaxInt64 + 1, -1},
+		{math.MaxInt64, math.MaxInt64, 0},
+		{math.MinInt64, 0, -1},
+		{-1, 0, -1},
+		{5, 5, 0},
+		{6, 5, 1},
+	}
+
+	for _, tt := range testCases {
+		t.Run(fmt.Sprintf("%d and %d", tt.s, tt.u), func(t *testing.T) {
+			row := sql.NewRow(tt.s, tt.u)
+			require.Equal(t, tt.expected == 0, eval(t, expression.NewEquals(signed, unsigned), row))
+			require.Equal(t, tt.expected < 0, eval(t, expression.NewLessThan(signed, unsigned), row))
+			require.Equal(t, tt.expected > 0, eval(t, expression.NewGreaterThan(signed, unsigned), row))
+			require.Equal(t, tt.expected < 0, eval(t, expression.NewGreaterThan(unsigned, signed), row))
+		})
+	}
+}
+
 func eval(t *testing.T, e sql.Expression, row sql.Row) interface{} {
 	t.Helper()
 	v, err := e.Eval(sql.NewEmptyContext(), row)
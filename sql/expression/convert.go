@@ -16,9 +16,13 @@ package expression
 
 import (
 	"fmt"
+	"math"
+	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
 
+	"github.com/shopspring/decimal"
 	"gopkg.in/src-d/go-errors.v1"
 
 	"github.com/dolthub/go-mysql-server/sql"
@@ -42,6 +46,8 @@ const (
 	ConvertToDecimal = "decimal"
 	// ConvertToDouble is a conversion to double.
 	ConvertToDouble = "double"
+	// ConvertToFloat is a conversion to float.
+	ConvertToFloat = "float"
 	// ConvertToJSON is a conversion to json.
 	ConvertToJSON = "json"
 	// ConvertToReal is a conversion to double.
@@ -52,27 +58,65 @@ const (
 	ConvertToTime = "time"
 	// ConvertToUnsigned is a conversion to unsigned.
 	ConvertToUnsigned = "unsigned"
+	// ConvertToYear is a conversion to year.
+	ConvertToYear = "year"
 )
 
+// incorrectTemporalValueCode is the MySQL warning code for a value that can't be converted to a date or time.
+const incorrectTemporalValueCode = 1292
+
+// truncatedValueCode is the MySQL warning code for a value that is truncated by a conversion.
+const truncatedValueCode = 1292
+
+// outOfRangeValueCode is the MySQL warning code for a value that is out of the range of the type it's converted to.
+const outOfRangeValueCode = 1264
+
+// maxTemporalPrecision is the maximum number of fractional seconds digits of a DATETIME or TIME value.
+const maxTemporalPrecision = 6
+
 // Convert represent a CAST(x AS T) or CONVERT(x, T) operation that casts x expression to type T.
 type Convert struct {
 	UnaryExpression
 	// Type to cast
 	castToType string
+	// Length given for the type to cast to, which is the fractional seconds precision for DATETIME and TIME, the
+	// precision for DECIMAL, and the maximum length for CHAR and BINARY
+	typeLength int
+	// Scale given for the type to cast to, which is only used for DECIMAL
+	typeScale int
 }
 
 // NewConvert creates a new Convert expression.
 func NewConvert(expr sql.Expression, castToType string) *Convert {
+	return NewConvertWithLength(expr, castToType, 0)
+}
+
+// NewConvertWithLength creates a new Convert expression with the length given for the type to cast to, as in
+// CAST(x AS DATETIME(3)).
+func NewConvertWithLength(expr sql.Expression, castToType string, typeLength int) *Convert {
+	return NewConvertWithLengthAndScale(expr, castToType, typeLength, 0)
+}
+
+// NewConvertWithLengthAndScale creates a new Convert expression with the length and scale given for the type to cast
+// to, as in CAST(x AS DECIMAL(10, 2)).
+func NewConvertWithLengthAndScale(expr sql.Expression, castToType string, typeLength, typeScale int) *Convert {
 	return &Convert{
 		UnaryExpression: UnaryExpression{Child: expr},
 		castToType:      strings.ToLower(castToType),
+		typeLength:      typeLength,
+		typeScale:       typeScale,
 	}
 }
 
+// TypeLength returns the length given for the type to cast to, or 0 if there's none.
+func (c *Convert) TypeLength() int {
+	return c.typeLength
+}
+
 // IsNullable implements the Expression interface.
 func (c *Convert) IsNullable() bool {
 	switch c.castToType {
-	case ConvertToDate, ConvertToDatetime:
+	case ConvertToDate, ConvertToDatetime, ConvertToTime, ConvertToYear:
 		return true
 	default:
 		return c.Child.IsNullable()
@@ -91,10 +135,15 @@ func (c *Convert) Type() sql.Type {
 	case ConvertToDatetime:
 		return sql.Datetime
 	case ConvertToDecimal:
-		//TODO: these values are completely arbitrary, we need to get the given precision/scale and store it
+		if c.typeLength > 0 {
+			return sql.MustCreateDecimalType(uint8(c.typeLength), uint8(c.typeScale))
+		}
+		//TODO: these values are completely arbitrary, MySQL uses DECIMAL(10, 0) when no precision is given
 		return sql.MustCreateDecimalType(65, 10)
 	case ConvertToDouble, ConvertToReal:
 		return sql.Float64
+	case ConvertToFloat:
+		return sql.Float32
 	case ConvertToJSON:
 		return sql.JSON
 	case ConvertToSigned:
@@ -103,6 +152,8 @@ func (c *Convert) Type() sql.Type {
 		return sql.Time
 	case ConvertToUnsigned:
 		return sql.Uint64
+	case ConvertToYear:
+		return sql.Year
 	default:
 		return sql.Null
 	}
@@ -110,6 +161,12 @@ func (c *Convert) Type() sql.Type {
 
 // Name implements the Expression interface.
 func (c *Convert) String() string {
+	if c.typeScale > 0 {
+		return fmt.Sprintf("convert(%v, %v(%d, %d))", c.Child, c.castToType, c.typeLength, c.typeScale)
+	}
+	if c.typeLength > 0 {
+		return fmt.Sprintf("convert(%v, %v(%d))", c.Child, c.castToType, c.typeLength)
+	}
 	return fmt.Sprintf("convert(%v, %v)", c.Child, c.castToType)
 }
 
@@ -118,7 +175,7 @@ func (c *Convert) WithChildren(children ...sql.Expression) (sql.Expression, erro
 	if len(children) != 1 {
 		return nil, sql.ErrInvalidChildrenNumber.New(c, len(children), 1)
 	}
-	return NewConvert(children[0], c.castToType), nil
+	return NewConvertWithLengthAndScale(children[0], c.castToType, c.typeLength, c.typeScale), nil
 }
 
 // Eval implements the Expression interface.
@@ -132,14 +189,119 @@ func (c *Convert) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 		return nil, nil
 	}
 
+	switch c.castToType {
+	case ConvertToDate, ConvertToDatetime, ConvertToTime, ConvertToYear:
+		casted, err := convertToTemporal(val, c.castToType, c.typeLength)
+		if err != nil {
+			ctx.Warn(incorrectTemporalValueCode, "Incorrect %s value: '%v'", c.castToType, val)
+			return nil, nil
+		}
+		return casted, nil
+	case ConvertToDouble, ConvertToReal, ConvertToFloat:
+		return c.convertToFloat(ctx, val)
+	case ConvertToDecimal:
+		if c.typeLength > 0 {
+			return c.convertToDecimal(ctx, val)
+		}
+	}
+
 	casted, err := convertValue(val, c.castToType)
 	if err != nil {
 		return nil, ErrConvertExpression.Wrap(err, c.String(), c.castToType)
 	}
 
+	if c.typeLength > 0 && casted != nil {
+		switch c.castToType {
+		case ConvertToChar, ConvertToNChar:
+			if s := casted.(string); utf8.RuneCountInString(s) > c.typeLength {
+				ctx.Warn(truncatedValueCode, "Truncated incorrect %s(%d) value: '%s'", strings.ToUpper(c.castToType), c.typeLength, s)
+				casted = string([]rune(s)[:c.typeLength])
+			}
+		case ConvertToBinary:
+			if s := casted.(string); len(s) > c.typeLength {
+				ctx.Warn(truncatedValueCode, "Truncated incorrect BINARY(%d) value: '%s'", c.typeLength, s)
+				casted = s[:c.typeLength]
+			}
+		}
+	}
+
 	return casted, nil
 }
 
+// convertToFloat converts the value given to a DOUBLE, or to a FLOAT, rounding it once to the precision of the type.
+// Strings that aren't numbers are converted to their numeric prefix, or to 0 if they don't have one, and values that
+// are out of the range of the type are clamped to its bounds, with a warning, as in MySQL.
+func (c *Convert) convertToFloat(ctx *sql.Context, val interface{}) (interface{}, error) {
+	bitSize, typeName := 64, "DOUBLE"
+	if c.castToType == ConvertToFloat {
+		bitSize, typeName = 32, "FLOAT"
+	}
+
+	var s string
+	switch v := val.(type) {
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	case decimal.Decimal:
+		s = v.String()
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		s = fmt.Sprint(v)
+	default:
+		d, err := sql.Float64.Convert(val)
+		if err != nil {
+			d = sql.Float64.Zero()
+		}
+		s = strconv.FormatFloat(d.(float64), 'g', -1, 64)
+	}
+
+	// Parsing the digits of the value rounds it directly to the precision of the type
+	s = strings.TrimSpace(s)
+	f, err := strconv.ParseFloat(s, bitSize)
+	if numErr, ok := err.(*strconv.NumError); ok && numErr.Err == strconv.ErrSyntax {
+		ctx.Warn(truncatedValueCode, "Truncated incorrect %s value: '%s'", typeName, val)
+		// The prefix may still be out of range, which is handled below
+		prefix, _ := sql.NumericPrefix(s)
+		f, _ = strconv.ParseFloat(prefix, bitSize)
+	}
+
+	max := math.MaxFloat64
+	if bitSize == 32 {
+		max = math.MaxFloat32
+	}
+	if math.IsInf(f, 0) {
+		ctx.Warn(outOfRangeValueCode, "Out of range value for column '%s' at row 1", c.String())
+		f = math.Copysign(max, f)
+	}
+
+	if bitSize == 32 {
+		return float32(f), nil
+	}
+	return f, nil
+}
+
+// convertToDecimal converts the value given to a DECIMAL of the precision and scale of the conversion. Values that
+// are out of its range are clamped to its bounds, and values that aren't numbers are 0, with a warning, as in MySQL.
+func (c *Convert) convertToDecimal(ctx *sql.Context, val interface{}) (interface{}, error) {
+	typ := c.Type().(sql.DecimalType)
+	dec, err := sql.InternalDecimalType.ConvertToDecimal(val)
+	if err != nil {
+		ctx.Warn(truncatedValueCode, "Truncated incorrect DECIMAL value: '%v'", val)
+		return typ.Convert(0)
+	}
+
+	casted, err := typ.Convert(dec.Decimal)
+	if sql.ErrConvertToDecimalLimit.Is(err) {
+		ctx.Warn(outOfRangeValueCode, "Out of range value for column '%s' at row 1", c.String())
+		max := typ.ExclusiveUpperBound().Sub(decimal.New(1, -int32(typ.Scale())))
+		if dec.Decimal.Sign() < 0 {
+			max = max.Neg()
+		}
+		return typ.Convert(max)
+	}
+	return casted, err
+}
+
 // convertValue only returns an error if converting to JSON, and returns the zero value for float types.
 // Nil is returned in all other cases.
 func convertValue(val interface{}, castTo string) (interface{}, error) {
@@ -156,24 +318,8 @@ func convertValue(val interface{}, castTo string) (interface{}, error) {
 			return nil, nil
 		}
 		return s, nil
-	case ConvertToDate:
-		_, isTime := val.(time.Time)
-		_, isString := val.(string)
-		if !(isTime || isString) {
-			return nil, nil
-		}
-		d, err := sql.Date.Convert(val)
-		if err != nil {
-			return nil, nil
-		}
-		return d, nil
-	case ConvertToDatetime:
-		_, isTime := val.(time.Time)
-		_, isString := val.(string)
-		if !(isTime || isString) {
-			return nil, nil
-		}
-		d, err := sql.Datetime.Convert(val)
+	case ConvertToDate, ConvertToDatetime:
+		d, err := convertToTemporal(val, castTo, -1)
 		if err != nil {
 			return nil, nil
 		}
@@ -204,7 +350,7 @@ func convertValue(val interface{}, castTo string) (interface{}, error) {
 
 		return num, nil
 	case ConvertToTime:
-		t, err := sql.Time.Convert(val)
+		t, err := convertToTemporal(val, castTo, -1)
 		if err != nil {
 			return nil, nil
 		}
@@ -219,7 +365,167 @@ func convertValue(val interface{}, castTo string) (interface{}, error) {
 			return uint64(num.(int64)), nil
 		}
 		return num, nil
+	case ConvertToYear:
+		y, err := convertToTemporal(val, castTo, -1)
+		if err != nil {
+			return nil, nil
+		}
+		return y, nil
 	default:
 		return nil, nil
 	}
 }
+
+// convertToTemporal converts the value given to a DATE, DATETIME, TIME or YEAR value, rounding the fractional seconds
+// of DATETIME and TIME values to the precision given, unless it's negative. A DATETIME value converted to a DATE loses
+// its time, and one converted to a TIME loses its date. Integers are read as dates and times without separators, like
+// 20210314 or 123456, but as years when converted to a YEAR. Returns an error if the value can't be converted.
+func convertToTemporal(val interface{}, castTo string, precision int) (interface{}, error) {
+	if castTo == ConvertToYear {
+		y, err := sql.Year.Convert(val)
+		if s, ok := val.(string); ok && err != nil {
+			// The year of a date and time string
+			if t, dateErr := sql.Datetime.Convert(s); dateErr == nil {
+				return sql.Year.Convert(t)
+			}
+		}
+		return y, err
+	}
+
+	switch v := val.(type) {
+	case int8, int16, int32, int64, uint8, uint16, uint32, uint64, int, uint:
+		if castTo != ConvertToTime {
+			val = fmt.Sprint(v)
+		}
+	case time.Time, string:
+	case sql.JSONValue:
+		s, err := v.ToString(nil)
+		if err != nil {
+			return nil, err
+		}
+		val = s
+	default:
+		if castTo != ConvertToTime {
+			return nil, ErrConvertExpression.New(val, castTo)
+		}
+	}
+
+	if precision > maxTemporalPrecision {
+		precision = maxTemporalPrecision
+	}
+
+	switch castTo {
+	case ConvertToDate:
+		return sql.Date.Convert(val)
+	case ConvertToDatetime:
+		d, err := sql.Datetime.Convert(val)
+		if err != nil || precision < 0 {
+			return d, err
+		}
+		return sql.Datetime.Convert(d.(time.Time).Round(fractionalSecondsUnit(precision)))
+	default:
+		duration, err := sql.Time.ConvertToTimeDuration(val)
+		if err != nil {
+			// The time of a date and time
+			t, ok := val.(time.Time)
+			if !ok {
+				s, isString := val.(string)
+				if !isString {
+					return nil, err
+				}
+				t, err = sql.Datetime.ConvertWithoutRangeCheck(s)
+				if err != nil {
+					return nil, err
+				}
+			}
+			duration = t.Sub(t.Truncate(24 * time.Hour))
+		}
+		if precision >= 0 {
+			duration = duration.Round(fractionalSecondsUnit(precision))
+		}
+		return sql.Time.Convert(duration)
+	}
+}
+
+// fractionalSecondsUnit returns the smallest duration that can be represented with the number of fractional seconds
+// digits given.
+func fractionalSecondsUnit(precision int) time.Duration {
+	unit := time.Second
+	for i := 0; i < precision; i++ {
+		unit /= 10
+	}
+	return unit
+}
+
+// invalidCharacterStringCode is the MySQL warning code for a string with bytes that aren't valid in its character set.
+const invalidCharacterStringCode = 1300
+
+// ConvertUsing represents a CONVERT(x USING charset) operation, which converts the string x to the character set
+// given. Characters that the character set doesn't have, and invalid byte sequences, are replaced by '?'.
+type ConvertUsing struct {
+	UnaryExpression
+	charset sql.CharacterSet
+}
+
+var _ sql.Expression = (*ConvertUsing)(nil)
+
+// NewConvertUsing creates a new ConvertUsing expression.
+func NewConvertUsing(expr sql.Expression, charset sql.CharacterSet) *ConvertUsing {
+	return &ConvertUsing{
+		UnaryExpression: UnaryExpression{Child: expr},
+		charset:         charset,
+	}
+}
+
+// CharacterSet returns the character set that the expression converts to.
+func (c *ConvertUsing) CharacterSet() sql.CharacterSet {
+	return c.charset
+}
+
+// IsNullable implements the Expression interface.
+func (c *ConvertUsing) IsNullable() bool {
+	return c.Child.IsNullable()
+}
+
+// Type implements the Expression interface.
+func (c *ConvertUsing) Type() sql.Type {
+	if c.charset == sql.CharacterSet_binary {
+		return sql.LongBlob
+	}
+	return sql.CreateLongText(c.charset.DefaultCollation())
+}
+
+func (c *ConvertUsing) String() string {
+	return fmt.Sprintf("convert(%v using %s)", c.Child, c.charset)
+}
+
+// WithChildren implements the Expression interface.
+func (c *ConvertUsing) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(c, len(children), 1)
+	}
+	return NewConvertUsing(children[0], c.charset), nil
+}
+
+// Eval implements the Expression interface.
+func (c *ConvertUsing) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	val, err := c.Child.Eval(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+
+	if val == nil {
+		return nil, nil
+	}
+
+	s, err := sql.LongText.Convert(val)
+	if err != nil {
+		return nil, ErrConvertExpression.Wrap(err, c.String(), c.charset)
+	}
+
+	converted, invalid := c.charset.ConvertString(s.(string))
+	if invalid != "" {
+		ctx.Warn(invalidCharacterStringCode, "Invalid %s character string: '%X'", c.charset, invalid)
+	}
+	return converted, nil
+}
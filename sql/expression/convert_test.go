@@ -15,9 +15,11 @@
 package expression
 
 import (
+	"math"
 	"testing"
 	"time"
 
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/require"
 
 	"github.com/dolthub/go-mysql-server/sql"
@@ -29,6 +31,8 @@ func TestConvert(t *testing.T) {
 		row         sql.Row
 		expression  sql.Expression
 		castTo      string
+		length      int
+		scale       int
 		expected    interface{}
 		expectedErr bool
 	}{
@@ -120,6 +124,134 @@ func TestConvert(t *testing.T) {
 			expected:    nil,
 			expectedErr: false,
 		},
+		{
+			name:        "string with fractional seconds to datetime",
+			castTo:      ConvertToDatetime,
+			expression:  NewLiteral("2017-12-12 11:12:13.5678", sql.LongText),
+			expected:    time.Date(2017, time.December, 12, 11, 12, 14, 0, time.UTC),
+			expectedErr: false,
+		},
+		{
+			name:        "string to datetime with precision",
+			castTo:      ConvertToDatetime,
+			length:      2,
+			expression:  NewLiteral("2017-12-12 11:12:13.5678", sql.LongText),
+			expected:    time.Date(2017, time.December, 12, 11, 12, 13, 570000000, time.UTC),
+			expectedErr: false,
+		},
+		{
+			name:        "date to datetime",
+			castTo:      ConvertToDatetime,
+			expression:  NewLiteral(time.Date(2017, time.December, 12, 0, 0, 0, 0, time.UTC), sql.Date),
+			expected:    time.Date(2017, time.December, 12, 0, 0, 0, 0, time.UTC),
+			expectedErr: false,
+		},
+		{
+			name:        "int to datetime",
+			castTo:      ConvertToDatetime,
+			expression:  NewLiteral(int64(20171212111213), sql.Int64),
+			expected:    time.Date(2017, time.December, 12, 11, 12, 13, 0, time.UTC),
+			expectedErr: false,
+		},
+		{
+			name:        "datetime to date",
+			castTo:      ConvertToDate,
+			expression:  NewLiteral(time.Date(2017, time.December, 12, 11, 12, 13, 0, time.UTC), sql.Datetime),
+			expected:    time.Date(2017, time.December, 12, 0, 0, 0, 0, time.UTC),
+			expectedErr: false,
+		},
+		{
+			name:        "int to date",
+			castTo:      ConvertToDate,
+			expression:  NewLiteral(int32(20171212), sql.Int32),
+			expected:    time.Date(2017, time.December, 12, 0, 0, 0, 0, time.UTC),
+			expectedErr: false,
+		},
+		{
+			name:        "unparseable string to date",
+			castTo:      ConvertToDate,
+			expression:  NewLiteral("not a date", sql.LongText),
+			expected:    nil,
+			expectedErr: false,
+		},
+		{
+			name:        "string to time",
+			castTo:      ConvertToTime,
+			expression:  NewLiteral("11:12:13.5678", sql.LongText),
+			expected:    "11:12:14",
+			expectedErr: false,
+		},
+		{
+			name:        "string to time with precision",
+			castTo:      ConvertToTime,
+			length:      3,
+			expression:  NewLiteral("11:12:13.5678", sql.LongText),
+			expected:    "11:12:13.568000",
+			expectedErr: false,
+		},
+		{
+			name:        "datetime string to time",
+			castTo:      ConvertToTime,
+			expression:  NewLiteral("2017-12-12 11:12:13", sql.LongText),
+			expected:    "11:12:13",
+			expectedErr: false,
+		},
+		{
+			name:        "datetime to time",
+			castTo:      ConvertToTime,
+			expression:  NewLiteral(time.Date(2017, time.December, 12, 11, 12, 13, 0, time.UTC), sql.Datetime),
+			expected:    "11:12:13",
+			expectedErr: false,
+		},
+		{
+			name:        "date to time",
+			castTo:      ConvertToTime,
+			expression:  NewLiteral(time.Date(2017, time.December, 12, 0, 0, 0, 0, time.UTC), sql.Date),
+			expected:    "00:00:00",
+			expectedErr: false,
+		},
+		{
+			name:        "unparseable string to time",
+			castTo:      ConvertToTime,
+			expression:  NewLiteral("not a time", sql.LongText),
+			expected:    nil,
+			expectedErr: false,
+		},
+		{
+			name:        "int to year",
+			castTo:      ConvertToYear,
+			expression:  NewLiteral(int8(69), sql.Int8),
+			expected:    int16(2069),
+			expectedErr: false,
+		},
+		{
+			name:        "zero to year",
+			castTo:      ConvertToYear,
+			expression:  NewLiteral(int8(0), sql.Int8),
+			expected:    int16(0),
+			expectedErr: false,
+		},
+		{
+			name:        "zero string to year",
+			castTo:      ConvertToYear,
+			expression:  NewLiteral("0", sql.LongText),
+			expected:    int16(2000),
+			expectedErr: false,
+		},
+		{
+			name:        "datetime to year",
+			castTo:      ConvertToYear,
+			expression:  NewLiteral(time.Date(2017, time.December, 12, 11, 12, 13, 0, time.UTC), sql.Datetime),
+			expected:    int16(2017),
+			expectedErr: false,
+		},
+		{
+			name:        "out of range int to year",
+			castTo:      ConvertToYear,
+			expression:  NewLiteral(int16(2156), sql.Int16),
+			expected:    nil,
+			expectedErr: false,
+		},
 		{
 			name:        "float to binary",
 			row:         nil,
@@ -168,12 +300,115 @@ func TestConvert(t *testing.T) {
 			expected:    nil,
 			expectedErr: false,
 		},
+		{
+			name:        "string with a numeric prefix to double",
+			row:         nil,
+			castTo:      ConvertToDouble,
+			expression:  NewLiteral(" 1.5e2abc", sql.LongText),
+			expected:    float64(150),
+			expectedErr: false,
+		},
+		{
+			name:        "invalid string to real",
+			row:         nil,
+			castTo:      ConvertToReal,
+			expression:  NewLiteral("abc", sql.LongText),
+			expected:    float64(0),
+			expectedErr: false,
+		},
+		{
+			name:        "large decimal to double",
+			row:         nil,
+			castTo:      ConvertToDouble,
+			expression:  NewLiteral(decimal.RequireFromString("12345678901234567890123456789.5"), sql.MustCreateDecimalType(30, 1)),
+			expected:    float64(1.2345678901234568e28),
+			expectedErr: false,
+		},
+		{
+			name:        "large decimal to float",
+			row:         nil,
+			castTo:      ConvertToFloat,
+			expression:  NewLiteral(decimal.RequireFromString("12345678901234567890123456789.5"), sql.MustCreateDecimalType(30, 1)),
+			expected:    float32(1.2345679e28),
+			expectedErr: false,
+		},
+		{
+			name:        "int to float",
+			row:         nil,
+			castTo:      ConvertToFloat,
+			expression:  NewLiteral(int64(16777217), sql.Int64),
+			expected:    float32(16777216),
+			expectedErr: false,
+		},
+		{
+			name:        "out of range string to double",
+			row:         nil,
+			castTo:      ConvertToDouble,
+			expression:  NewLiteral("-1e400", sql.LongText),
+			expected:    -math.MaxFloat64,
+			expectedErr: false,
+		},
+		{
+			name:        "out of range double to float",
+			row:         nil,
+			castTo:      ConvertToFloat,
+			expression:  NewLiteral(float64(1e39), sql.Float64),
+			expected:    float32(math.MaxFloat32),
+			expectedErr: false,
+		},
+		{
+			name:        "string to decimal with precision and scale",
+			row:         nil,
+			castTo:      ConvertToDecimal,
+			expression:  NewLiteral("123.456", sql.LongText),
+			length:      5,
+			scale:       2,
+			expected:    "123.46",
+			expectedErr: false,
+		},
+		{
+			name:        "out of range decimal",
+			row:         nil,
+			castTo:      ConvertToDecimal,
+			expression:  NewLiteral(-12345.6, sql.Float64),
+			length:      4,
+			scale:       1,
+			expected:    "-999.9",
+			expectedErr: false,
+		},
+		{
+			name:        "invalid string to decimal",
+			row:         nil,
+			castTo:      ConvertToDecimal,
+			expression:  NewLiteral("abc", sql.LongText),
+			length:      3,
+			expected:    "0",
+			expectedErr: false,
+		},
+		{
+			name:        "truncated char",
+			row:         nil,
+			castTo:      ConvertToChar,
+			expression:  NewLiteral("añbc", sql.LongText),
+			length:      2,
+			expected:    "añ",
+			expectedErr: false,
+		},
+		{
+			name:        "truncated binary",
+			row:         nil,
+			castTo:      ConvertToBinary,
+			expression:  NewLiteral("añbc", sql.LongText),
+			length:      2,
+			expected:    "a\xc3",
+			expectedErr: false,
+		},
 	}
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			require := require.New(t)
-			convert := NewConvert(test.expression, test.castTo)
+			convert := NewConvertWithLengthAndScale(test.expression, test.castTo, test.length, test.scale)
 			val, err := convert.Eval(sql.NewEmptyContext(), test.row)
 			if test.expectedErr {
 				require.Error(err)
@@ -185,3 +420,34 @@ func TestConvert(t *testing.T) {
 		})
 	}
 }
+
+func TestConvertUsing(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    interface{}
+		charset  sql.CharacterSet
+		expected interface{}
+		warning  bool
+	}{
+		{"null", nil, sql.CharacterSet_utf8mb4, nil, false},
+		{"number", 12, sql.CharacterSet_utf8mb4, "12", false},
+		{"valid string", "añ€", sql.CharacterSet_utf8mb4, "añ€", false},
+		{"invalid bytes", "\xc3\xa9\xffA", sql.CharacterSet_utf8mb4, "é?A", true},
+		{"character missing from latin1", "añ€ř", sql.CharacterSet_latin1, "añ€?", false},
+		{"character missing from ascii", "añb", sql.CharacterSet_ascii, "a?b", false},
+		{"character missing from utf8mb3", "a😀", sql.CharacterSet_utf8mb3, "a?", false},
+		{"binary", "\xc3\xa9\xff", sql.CharacterSet_binary, "\xc3\xa9\xff", false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+			ctx := sql.NewEmptyContext()
+			convert := NewConvertUsing(NewLiteral(test.value, sql.LongText), test.charset)
+			val, err := convert.Eval(ctx, nil)
+			require.NoError(err)
+			require.Equal(test.expected, val)
+			require.Equal(test.warning, len(ctx.Warnings()) > 0)
+		})
+	}
+}
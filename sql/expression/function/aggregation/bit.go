@@ -0,0 +1,157 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aggregation
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+// BitAggregation node to calculate the bitwise AND, OR or XOR of a column, as unsigned 64-bit integers. It backs
+// BIT_AND, BIT_OR and BIT_XOR. NULL values are ignored, and the result for a group without other values is the
+// identity of the operation: all bits set for BIT_AND, and zero for BIT_OR and BIT_XOR.
+type BitAggregation struct {
+	expression.UnaryExpression
+	name string
+}
+
+var _ sql.FunctionExpression = (*BitAggregation)(nil)
+var _ sql.Aggregation = (*BitAggregation)(nil)
+
+// NewBitAnd creates a new BitAggregation node for BIT_AND.
+func NewBitAnd(e sql.Expression) *BitAggregation {
+	return &BitAggregation{expression.UnaryExpression{Child: e}, "bit_and"}
+}
+
+// NewBitOr creates a new BitAggregation node for BIT_OR.
+func NewBitOr(e sql.Expression) *BitAggregation {
+	return &BitAggregation{expression.UnaryExpression{Child: e}, "bit_or"}
+}
+
+// NewBitXor creates a new BitAggregation node for BIT_XOR.
+func NewBitXor(e sql.Expression) *BitAggregation {
+	return &BitAggregation{expression.UnaryExpression{Child: e}, "bit_xor"}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (b *BitAggregation) FunctionName() string {
+	return b.name
+}
+
+func (b *BitAggregation) String() string {
+	return fmt.Sprintf("%s(%s)", strings.ToUpper(b.name), b.Child)
+}
+
+// Type implements Expression interface.
+func (b *BitAggregation) Type() sql.Type {
+	return sql.Uint64
+}
+
+// IsNullable implements Expression interface.
+func (b *BitAggregation) IsNullable() bool {
+	return false
+}
+
+// Eval implements Expression interface.
+func (b *BitAggregation) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	return nil, ErrEvalUnsupportedOnAggregation.New("BitAggregation")
+}
+
+// WithChildren implements the Expression interface.
+func (b *BitAggregation) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(b, len(children), 1)
+	}
+	return &BitAggregation{expression.UnaryExpression{Child: children[0]}, b.name}, nil
+}
+
+// NewBuffer implements Aggregation interface.
+func (b *BitAggregation) NewBuffer() (sql.AggregationBuffer, error) {
+	bufferChild, err := expression.Clone(b.UnaryExpression.Child)
+	if err != nil {
+		return nil, err
+	}
+
+	var identity uint64
+	if b.name == "bit_and" {
+		identity = math.MaxUint64
+	}
+	return &bitBuffer{b, identity, bufferChild}, nil
+}
+
+type bitBuffer struct {
+	b    *BitAggregation
+	val  uint64
+	expr sql.Expression
+}
+
+// Update implements the AggregationBuffer interface.
+func (b *bitBuffer) Update(ctx *sql.Context, row sql.Row) error {
+	v, err := b.expr.Eval(ctx, row)
+	if err != nil {
+		return err
+	}
+
+	if v == nil {
+		return nil
+	}
+
+	u := bitOperand(v)
+
+	switch b.b.name {
+	case "bit_and":
+		b.val &= u
+	case "bit_or":
+		b.val |= u
+	case "bit_xor":
+		b.val ^= u
+	}
+	return nil
+}
+
+// Eval implements the AggregationBuffer interface.
+func (b *bitBuffer) Eval(ctx *sql.Context) (interface{}, error) {
+	return b.val, nil
+}
+
+// Dispose implements the Disposable interface.
+func (b *bitBuffer) Dispose() {
+	expression.Dispose(b.expr)
+}
+
+// bitOperand converts the value given to an unsigned 64-bit integer as MySQL does for bit operations: fractional
+// numbers are rounded, negative values are taken as their two's complement, and values that aren't numbers are 0.
+func bitOperand(v interface{}) uint64 {
+	switch n := v.(type) {
+	case float32:
+		v = math.Round(float64(n))
+	case float64:
+		v = math.Round(n)
+	case decimal.Decimal:
+		v = n.Round(0)
+	}
+
+	u, err := expression.ConvertToUint64(v)
+	if err != nil {
+		return 0
+	}
+	return u
+}
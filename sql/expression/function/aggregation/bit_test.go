@@ -0,0 +1,79 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aggregation
+
+import (
+	"math"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+func TestBitAggregation_String(t *testing.T) {
+	require := require.New(t)
+
+	col := expression.NewGetField(0, sql.Int64, "col1", true)
+	require.Equal("BIT_AND(col1)", NewBitAnd(col).String())
+	require.Equal("BIT_OR(col1)", NewBitOr(col).String())
+	require.Equal("BIT_XOR(col1)", NewBitXor(col).String())
+}
+
+func TestBitAggregation(t *testing.T) {
+	col := expression.NewGetField(0, sql.Int64, "col1", true)
+
+	testCases := []struct {
+		name string
+		rows []interface{}
+		and  uint64
+		or   uint64
+		xor  uint64
+	}{
+		{"no rows", nil, math.MaxUint64, 0, 0},
+		{"only nulls", []interface{}{nil, nil}, math.MaxUint64, 0, 0},
+		{"one row", []interface{}{nil, int64(6)}, 6, 6, 6},
+		{"several rows", []interface{}{int64(12), int64(10), nil, int64(14)}, 8, 14, 8},
+		{"mixed signs", []interface{}{int64(-1), int64(6)}, 6, math.MaxUint64, math.MaxUint64 - 6},
+		{"negative", []interface{}{int64(-2), int64(-3)}, math.MaxUint64 - 3, math.MaxUint64, 3},
+		{"unsigned", []interface{}{uint64(math.MaxUint64), uint64(1 << 63)}, 1 << 63, math.MaxUint64, 1<<63 - 1},
+		{"rounded numbers", []interface{}{2.5, float32(1.2), decimal.NewFromFloat(-1.5)}, 0, math.MaxUint64, math.MaxUint64 - 3},
+		{"strings", []interface{}{"5", "3", "abc"}, 0, 7, 6},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := sql.NewEmptyContext()
+
+			for _, v := range []struct {
+				agg      *BitAggregation
+				expected uint64
+			}{
+				{NewBitAnd(col), tt.and},
+				{NewBitOr(col), tt.or},
+				{NewBitXor(col), tt.xor},
+			} {
+				buf, err := v.agg.NewBuffer()
+				require.NoError(t, err)
+				for _, r := range tt.rows {
+					require.NoError(t, buf.Update(ctx, sql.NewRow(r)))
+				}
+				require.Equal(t, v.expected, evalBuffer(t, buf), v.agg.String())
+			}
+		})
+	}
+}
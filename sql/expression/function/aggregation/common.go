@@ -15,7 +15,34 @@
 package aggregation
 
 import (
+	"strings"
+
 	"gopkg.in/src-d/go-errors.v1"
+
+	"github.com/dolthub/go-mysql-server/sql"
 )
 
 var ErrEvalUnsupportedOnAggregation = errors.NewKind("Unimplemented %s.Eval(). The code should have used AggregationBuffer.Eval(ctx).")
+
+// compareValues compares two non-NULL values of the type given, as MIN and MAX order them. Strings are compared using
+// the collation of their type, and all other values using the type's Compare.
+func compareValues(t sql.Type, a, b interface{}) (int, error) {
+	if !sql.IsText(t) {
+		return t.Compare(a, b)
+	}
+
+	as, err := t.Convert(a)
+	if err != nil {
+		return 0, err
+	}
+	bs, err := t.Convert(b)
+	if err != nil {
+		return 0, err
+	}
+
+	collation := t.(sql.StringType).Collation()
+	if collation.IsCaseSensitive() {
+		return strings.Compare(as.(string), bs.(string)), nil
+	}
+	return collation.Compare(as.(string), bs.(string)), nil
+}
@@ -166,6 +166,13 @@ func (c *countDistinctBuffer) Update(ctx *sql.Context, row sql.Row) error {
 		}
 
 		value = v
+
+		// Strings that are equal under the collation of the expression, which may be given by COLLATE, are counted once
+		if str, ok := v.(string); ok {
+			if st, ok := c.expr.Type().(sql.StringType); ok {
+				value = st.Collation().SortKey(str)
+			}
+		}
 	}
 
 	hash, err := hashstructure.Hash(value, nil)
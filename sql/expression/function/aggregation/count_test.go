@@ -17,6 +17,7 @@ package aggregation
 import (
 	"testing"
 
+	"github.com/dolthub/vitess/go/vt/proto/query"
 	"github.com/stretchr/testify/require"
 
 	"github.com/dolthub/go-mysql-server/sql"
@@ -118,3 +119,23 @@ func TestCountDistinctEvalString(t *testing.T) {
 	require.NoError(b.Update(ctx, sql.NewRow("bar")))
 	require.Equal(int64(2), evalBuffer(t, b))
 }
+
+func TestCountDistinctEvalCollation(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewEmptyContext()
+	typ := sql.MustCreateString(query.Type_VARCHAR, 20, sql.Collation_utf8mb4_general_ci)
+
+	c := NewCountDistinct(expression.NewGetField(0, typ, "", true))
+	b, _ := c.NewBuffer()
+	for _, row := range []sql.Row{{"foo"}, {"FOO"}, {"Foo"}, {"bar"}} {
+		require.NoError(b.Update(ctx, row))
+	}
+	require.Equal(int64(2), evalBuffer(t, b))
+
+	c = NewCountDistinct(expression.NewCollatedExpression(expression.NewGetField(0, typ, "", true), sql.Collation_utf8mb4_bin))
+	b, _ = c.NewBuffer()
+	for _, row := range []sql.Row{{"foo"}, {"FOO"}, {"Foo"}, {"bar"}} {
+		require.NoError(b.Update(ctx, row))
+	}
+	require.Equal(int64(4), evalBuffer(t, b))
+}
@@ -17,6 +17,7 @@ package aggregation
 import (
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/dolthub/vitess/go/vt/proto/query"
@@ -159,6 +160,22 @@ func (g *GroupConcat) FunctionName() string {
 	return "group_concat"
 }
 
+// distinctKey returns the key used to deduplicate the values of a row when DISTINCT is specified. String values
+// compare using the collation of their expression, so equal values under that collation share the same key. Each value
+// is prefixed with its length, so that different values never share a key once concatenated.
+func (g *GroupConcat) distinctKey(vals []string) string {
+	sb := strings.Builder{}
+	for i, v := range vals {
+		if st, ok := g.selectExprs[i].Type().(sql.StringType); ok {
+			v = st.Collation().SortKey(v)
+		}
+		sb.WriteString(strconv.Itoa(len(v)))
+		sb.WriteByte(':')
+		sb.WriteString(v)
+	}
+	return sb.String()
+}
+
 type groupConcatBuffer struct {
 	gc          *GroupConcat
 	rows        []sql.Row
@@ -174,42 +191,45 @@ func (g *groupConcatBuffer) Update(ctx *sql.Context, originalRow sql.Row) error
 
 	g.gc.returnType = retType
 
-	// Skip if this is a null row
-	if evalRow == nil {
-		return nil
-	}
+	// The values of the expressions are concatenated, and rows with a NULL value are skipped
+	vals := make([]string, len(evalRow))
+	for i, ev := range evalRow {
+		var v interface{}
+		if retType == sql.Blob {
+			v, err = sql.Blob.Convert(ev)
+		} else {
+			v, err = sql.LongText.Convert(ev)
+		}
 
-	var v interface{}
-	if retType == sql.Blob {
-		v, err = sql.Blob.Convert(evalRow[0])
-	} else {
-		v, err = sql.LongText.Convert(evalRow[0])
-	}
+		if err != nil {
+			return err
+		}
 
-	if err != nil {
-		return err
-	}
+		if v == nil {
+			return nil
+		}
 
-	if v == nil {
-		return nil
+		vals[i] = v.(string)
 	}
-
-	vs := v.(string)
+	vs := strings.Join(vals, "")
 
 	// Get the current array of rows and the map
 	// Check if distinct is active if so look at and update our map
 	if g.gc.distinct != "" {
+		// Values are deduplicated by their collation's sort key, so that values which compare as equal are only
+		// concatenated once.
+		key := g.gc.distinctKey(vals)
 		// If this value exists go ahead and return nil
-		if _, ok := g.distinctSet[vs]; ok {
+		if _, ok := g.distinctSet[key]; ok {
 			return nil
 		} else {
-			g.distinctSet[vs] = true
+			g.distinctSet[key] = true
 		}
 	}
 
 	// Append the current value to the end of the row. We want to preserve the row's original structure for
 	// for sort ordering in the final step.
-	g.rows = append(g.rows, append(originalRow, nil, vs))
+	g.rows = append(g.rows, append(originalRow.Copy(), nil, vs))
 
 	return nil
 }
@@ -237,27 +257,33 @@ func (g *groupConcatBuffer) Eval(ctx *sql.Context) (interface{}, error) {
 		}
 	}
 
+	// Like in MySQL, a truncated value is reported with the number within the group of the first row that was cut
 	sb := strings.Builder{}
+	cutRow := 0
 	for i, row := range rows {
 		lastIdx := len(row) - 1
-		if i == 0 {
-			sb.WriteString(row[lastIdx].(string))
-		} else {
+		if i > 0 {
 			sb.WriteString(g.gc.separator)
-			sb.WriteString(row[lastIdx].(string))
 		}
+		sb.WriteString(row[lastIdx].(string))
 
 		// Don't allow the string to cross maxlen
-		if sb.Len() >= g.gc.maxLen {
+		if sb.Len() > g.gc.maxLen {
+			cutRow = i + 1
+			break
+		}
+		if sb.Len() == g.gc.maxLen && i < len(rows)-1 {
+			cutRow = i + 2
 			break
 		}
 	}
 
 	ret := sb.String()
-
-	// There might be a couple of character differences even if we broke early in the loop
-	if len(ret) > g.gc.maxLen {
-		ret = ret[:g.gc.maxLen]
+	if cutRow > 0 {
+		if len(ret) > g.gc.maxLen {
+			ret = ret[:g.gc.maxLen]
+		}
+		ctx.Warn(1260, "Row %d was cut by GROUP_CONCAT()", cutRow)
 	}
 
 	// Add this to handle any one off errors.
@@ -76,6 +76,29 @@ func TestGroupConcat_PastMaxLen(t *testing.T) {
 	require.Equal(t, int(maxLen), len(rs))
 }
 
+// Validates that each group reports the number of its first row that was cut when its value is truncated
+func TestGroupConcat_TruncatedWarning(t *testing.T) {
+	ctx := sql.NewEmptyContext()
+	gc, err := NewGroupConcat("", nil, ",", []sql.Expression{expression.NewGetField(0, sql.LongText, "str", true)}, 5)
+	require.NoError(t, err)
+
+	for _, rows := range [][]sql.Row{{{"ab"}, {"cd"}, {"ef"}}, {{"abc"}, {"def"}}} {
+		buf, err := gc.NewBuffer()
+		require.NoError(t, err)
+		for _, row := range rows {
+			require.NoError(t, buf.Update(ctx, row))
+		}
+		_, err = buf.Eval(ctx)
+		require.NoError(t, err)
+	}
+
+	// The latest warning comes first
+	warnings := ctx.Warnings()
+	require.Len(t, warnings, 2)
+	require.Equal(t, "Row 2 was cut by GROUP_CONCAT()", warnings[0].Message)
+	require.Equal(t, "Row 3 was cut by GROUP_CONCAT()", warnings[1].Message)
+}
+
 // Validate that group_concat returns the correct return type
 func TestGroupConcat_ReturnType(t *testing.T) {
 	ctx := sql.NewEmptyContext()
@@ -107,3 +130,107 @@ func TestGroupConcat_ReturnType(t *testing.T) {
 		require.Equal(t, tt.returnType, gc.Type())
 	}
 }
+
+// Validates that GROUP_CONCAT(DISTINCT ...) deduplicates values using the collation of its expression
+func TestGroupConcat_DistinctCollation(t *testing.T) {
+	ctx := sql.NewEmptyContext()
+
+	testCases := []struct {
+		collation sql.Collation
+		expected  string
+	}{
+		{sql.Collation_utf8mb4_general_ci, "Alice;bob"},
+		{sql.Collation_utf8mb4_bin, "Alice;BOB;alice;bob"},
+	}
+
+	for _, tt := range testCases {
+		typ := sql.MustCreateString(query.Type_VARCHAR, 20, tt.collation)
+		sf := sql.SortFields{{Column: expression.NewGetField(0, typ, "name", true), Order: sql.Ascending}}
+		gc, err := NewGroupConcat("distinct ", sf, ";", []sql.Expression{expression.NewGetField(0, typ, "name", true)}, 1024)
+		require.NoError(t, err)
+
+		buf, _ := gc.NewBuffer()
+		for _, row := range []sql.Row{{"bob"}, {"Alice"}, {"alice"}, {"BOB"}, {nil}} {
+			require.NoError(t, buf.Update(ctx, row))
+		}
+
+		result, err := buf.Eval(ctx)
+		require.NoError(t, err)
+		require.Equal(t, tt.expected, result)
+	}
+}
+
+// Validates that a COLLATE on the values of GROUP_CONCAT overrides the collation of their column for DISTINCT and
+// ORDER BY
+func TestGroupConcat_CollatedExpression(t *testing.T) {
+	ctx := sql.NewEmptyContext()
+	typ := sql.MustCreateString(query.Type_VARCHAR, 20, sql.Collation_utf8mb4_general_ci)
+	collated := expression.NewCollatedExpression(expression.NewGetField(0, typ, "name", true), sql.Collation_utf8mb4_bin)
+
+	sf := sql.SortFields{{Column: collated, Order: sql.Descending}}
+	gc, err := NewGroupConcat("distinct ", sf, ";", []sql.Expression{collated}, 1024)
+	require.NoError(t, err)
+
+	buf, _ := gc.NewBuffer()
+	for _, row := range []sql.Row{{"bob"}, {"Alice"}, {"alice"}, {"BOB"}, {"bob"}} {
+		require.NoError(t, buf.Update(ctx, row))
+	}
+
+	result, err := buf.Eval(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "bob;alice;BOB;Alice", result)
+}
+
+// Validates the concatenation of the values of several expressions, with DISTINCT, ORDER BY and separators
+func TestGroupConcat_Separators(t *testing.T) {
+	ctx := sql.NewEmptyContext()
+
+	a := expression.NewGetField(0, sql.Int64, "a", true)
+	b := expression.NewGetField(1, sql.LongText, "b", true)
+	rows := []sql.Row{
+		{int64(2), "x"},
+		{int64(1), "y"},
+		{nil, "z"},
+		{int64(2), "x"},
+		{int64(3), nil},
+		{int64(1), "w"},
+	}
+
+	testCases := []struct {
+		name      string
+		distinct  string
+		sf        sql.SortFields
+		separator string
+		exprs     []sql.Expression
+		expected  interface{}
+	}{
+		{"default separator", "", nil, ",", []sql.Expression{a}, "2,1,2,3,1"},
+		{"empty separator", "", nil, "", []sql.Expression{a}, "21231"},
+		{"multiple character separator", "", nil, " | ", []sql.Expression{b}, "x | y | z | x | w"},
+		{"rows with a NULL are skipped", "", nil, ";", []sql.Expression{a, b}, "2x;1y;2x;1w"},
+		{
+			"distinct and order by",
+			"distinct ",
+			sql.SortFields{{Column: a, Order: sql.Ascending}, {Column: b, Order: sql.Descending}},
+			"",
+			[]sql.Expression{a, b},
+			"1y1w2x",
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			gc, err := NewGroupConcat(tt.distinct, tt.sf, tt.separator, tt.exprs, 1024)
+			require.NoError(t, err)
+
+			buf, _ := gc.NewBuffer()
+			for _, row := range rows {
+				require.NoError(t, buf.Update(ctx, row))
+			}
+
+			result, err := buf.Eval(ctx)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, result)
+		})
+	}
+}
@@ -54,8 +54,11 @@ func (j *JSONArrayAgg) FunctionName() string {
 
 // NewBuffer creates a new buffer for the aggregation.
 func (j *JSONArrayAgg) NewBuffer() (sql.AggregationBuffer, error) {
-	var row []interface{}
-	return &jsonArrayBuffer{row, j}, nil
+	bufferChild, err := expression.Clone(j.Child)
+	if err != nil {
+		return nil, err
+	}
+	return &jsonArrayBuffer{nil, bufferChild}, nil
 }
 
 // Type returns the type of the result.
@@ -96,12 +99,12 @@ func (j *JSONArrayAgg) Eval(ctx *sql.Context, row sql.Row) (interface{}, error)
 
 type jsonArrayBuffer struct {
 	vals []interface{}
-	jaa  *JSONArrayAgg
+	expr sql.Expression
 }
 
 // Update implements the AggregationBuffer interface.
 func (j *jsonArrayBuffer) Update(ctx *sql.Context, row sql.Row) error {
-	v, err := j.jaa.Child.Eval(ctx, row)
+	v, err := j.expr.Eval(ctx, row)
 	if err != nil {
 		return err
 	}
@@ -122,11 +125,17 @@ func (j *jsonArrayBuffer) Update(ctx *sql.Context, row sql.Row) error {
 
 // Eval implements the AggregationBuffer interface.
 func (j *jsonArrayBuffer) Eval(ctx *sql.Context) (interface{}, error) {
+	// When no rows are present return NULL
+	if len(j.vals) == 0 {
+		return nil, nil
+	}
+
 	return sql.JSONDocument{Val: j.vals}, nil
 }
 
 // Dispose implements the Disposable interface.
 func (j *jsonArrayBuffer) Dispose() {
+	expression.Dispose(j.expr)
 }
 
 // JSON_OBJECTAGG(key, value) [over_clause]
@@ -134,6 +143,7 @@ func (j *jsonArrayBuffer) Dispose() {
 // JSONObjectAgg Takes two column names or expressions as arguments, the first of these being used as a key and the
 // second as a value, and returns a JSON object containing key-value pairs. Returns NULL if the result contains no rows,
 // or in the event of an error. An error occurs if any key name is NULL or the number of arguments is not equal to 2.
+// When a key appears more than once, its last value is the one kept.
 //
 // https://dev.mysql.com/doc/refman/8.0/en/aggregate-functions.html#function_json-objectagg
 //
@@ -143,45 +153,45 @@ type JSONObjectAgg struct {
 	value sql.Expression
 }
 
-var _ sql.FunctionExpression = JSONObjectAgg{}
-var _ sql.Aggregation = JSONObjectAgg{}
+var _ sql.FunctionExpression = &JSONObjectAgg{}
+var _ sql.Aggregation = &JSONObjectAgg{}
 
-// NewJSONObjectAgg creates a new JSONArrayAgg function.
+// NewJSONObjectAgg creates a new JSONObjectAgg function.
 func NewJSONObjectAgg(key, value sql.Expression) sql.Expression {
-	return JSONObjectAgg{key: key, value: value}
+	return &JSONObjectAgg{key: key, value: value}
 }
 
 // FunctionName implements sql.FunctionExpression
-func (j JSONObjectAgg) FunctionName() string {
+func (j *JSONObjectAgg) FunctionName() string {
 	return "json_objectagg"
 }
 
 // Resolved implements the Expression interface.
-func (j JSONObjectAgg) Resolved() bool {
+func (j *JSONObjectAgg) Resolved() bool {
 	return j.key.Resolved() && j.value.Resolved()
 }
 
-func (j JSONObjectAgg) String() string {
+func (j *JSONObjectAgg) String() string {
 	return fmt.Sprintf("JSON_OBJECTAGG(%s, %s)", j.key, j.value)
 }
 
 // Type implements the Expression interface.
-func (j JSONObjectAgg) Type() sql.Type {
+func (j *JSONObjectAgg) Type() sql.Type {
 	return sql.JSON
 }
 
 // IsNullable implements the Expression interface.
-func (j JSONObjectAgg) IsNullable() bool {
+func (j *JSONObjectAgg) IsNullable() bool {
 	return false
 }
 
 // Children implements the Expression interface.
-func (j JSONObjectAgg) Children() []sql.Expression {
+func (j *JSONObjectAgg) Children() []sql.Expression {
 	return []sql.Expression{j.key, j.value}
 }
 
 // WithChildren implements the Expression interface.
-func (j JSONObjectAgg) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+func (j *JSONObjectAgg) WithChildren(children ...sql.Expression) (sql.Expression, error) {
 	if len(children) != 2 {
 		return nil, sql.ErrInvalidChildrenNumber.New(j, len(children), 2)
 	}
@@ -190,24 +200,32 @@ func (j JSONObjectAgg) WithChildren(children ...sql.Expression) (sql.Expression,
 }
 
 // NewBuffer implements the Aggregation interface.
-func (j JSONObjectAgg) NewBuffer() (sql.AggregationBuffer, error) {
-	row := make(map[string]interface{})
-	return &jsonObjectBuffer{row, &j}, nil
+func (j *JSONObjectAgg) NewBuffer() (sql.AggregationBuffer, error) {
+	key, err := expression.Clone(j.key)
+	if err != nil {
+		return nil, err
+	}
+	value, err := expression.Clone(j.value)
+	if err != nil {
+		return nil, err
+	}
+	return &jsonObjectBuffer{make(map[string]interface{}), key, value}, nil
 }
 
 // Eval implements the Expression interface.
-func (j JSONObjectAgg) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+func (j *JSONObjectAgg) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 	return nil, ErrEvalUnsupportedOnAggregation.New("JSONObjectAgg")
 }
 
 type jsonObjectBuffer struct {
-	vals map[string]interface{}
-	joa  *JSONObjectAgg
+	vals  map[string]interface{}
+	key   sql.Expression
+	value sql.Expression
 }
 
 // Update implements the AggregationBuffer interface.
 func (j *jsonObjectBuffer) Update(ctx *sql.Context, row sql.Row) error {
-	key, err := j.joa.key.Eval(ctx, row)
+	key, err := j.key.Eval(ctx, row)
 	if err != nil {
 		return err
 	}
@@ -217,7 +235,7 @@ func (j *jsonObjectBuffer) Update(ctx *sql.Context, row sql.Row) error {
 		return sql.ErrJSONObjectAggNullKey.New()
 	}
 
-	val, err := j.joa.value.Eval(ctx, row)
+	val, err := j.value.Eval(ctx, row)
 	if err != nil {
 		return err
 	}
@@ -231,10 +249,10 @@ func (j *jsonObjectBuffer) Update(ctx *sql.Context, row sql.Row) error {
 		val = doc.Val
 	}
 
-	// Update the map.
+	// Update the map, where the last value of a key wins.
 	keyAsString, err := sql.LongText.Convert(key)
 	if err != nil {
-		return nil
+		return err
 	}
 	j.vals[keyAsString.(string)] = val
 
@@ -253,4 +271,6 @@ func (j *jsonObjectBuffer) Eval(ctx *sql.Context) (interface{}, error) {
 
 // Dispose implements the Disposable interface.
 func (j *jsonObjectBuffer) Dispose() {
+	expression.Dispose(j.key)
+	expression.Dispose(j.value)
 }
@@ -69,7 +69,7 @@ func TestJsonArrayAgg_Empty(t *testing.T) {
 
 	v, err := b.Eval(ctx)
 	assert.NoError(err)
-	assert.Equal(sql.JSONDocument{Val: []interface{}(nil)}, v)
+	assert.Nil(v)
 }
 
 func TestJsonArrayAgg_JSON(t *testing.T) {
@@ -84,3 +84,86 @@ func TestJsonArrayAgg_JSON(t *testing.T) {
 	assert.NoError(err)
 	assert.Equal(sql.MustJSON(`[{"key1": "value1", "key2": "value2"}]`), v)
 }
+
+func TestJsonArrayAgg_Nulls(t *testing.T) {
+	assert := require.New(t)
+	ctx := sql.NewEmptyContext()
+
+	j := NewJSONArrayAgg(expression.NewGetField(0, sql.JSON, "field", true))
+	b, _ := j.NewBuffer()
+	assert.NoError(b.Update(ctx, sql.NewRow(nil)))
+	assert.NoError(b.Update(ctx, sql.NewRow(sql.MustJSON(`[1, {"a": null}]`))))
+
+	v, err := b.Eval(ctx)
+	assert.NoError(err)
+	assert.Equal(sql.MustJSON(`[null, [1, {"a": null}]]`), v)
+}
+
+func TestJsonObjectAgg_Name(t *testing.T) {
+	assert := require.New(t)
+
+	m := NewJSONObjectAgg(expression.NewGetField(0, sql.Int32, "k", true), expression.NewGetField(1, sql.JSON, "v", true))
+	assert.Equal("JSON_OBJECTAGG(k, v)", m.String())
+}
+
+func TestJsonObjectAgg(t *testing.T) {
+	j := NewJSONObjectAgg(expression.NewGetField(0, sql.LongText, "k", true), expression.NewGetField(1, sql.JSON, "v", true)).(*JSONObjectAgg)
+
+	testCases := []struct {
+		name     string
+		rows     []sql.Row
+		expected interface{}
+		err      bool
+	}{
+		{
+			name:     "no rows",
+			rows:     nil,
+			expected: nil,
+		},
+		{
+			name:     "null values",
+			rows:     []sql.Row{{"a", nil}, {1, "x"}},
+			expected: sql.MustJSON(`{"a": null, "1": "x"}`),
+		},
+		{
+			name:     "nested json values",
+			rows:     []sql.Row{{"a", sql.MustJSON(`{"b": [1, 2]}`)}, {"c", sql.MustJSON(`[]`)}},
+			expected: sql.MustJSON(`{"a": {"b": [1, 2]}, "c": []}`),
+		},
+		{
+			name:     "duplicate keys",
+			rows:     []sql.Row{{"a", float64(1)}, {"b", float64(2)}, {"a", float64(3)}},
+			expected: sql.MustJSON(`{"a": 3, "b": 2}`),
+		},
+		{
+			name: "null key",
+			rows: []sql.Row{{"a", int64(1)}, {nil, int64(2)}},
+			err:  true,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			ctx := sql.NewEmptyContext()
+
+			b, err := j.NewBuffer()
+			require.NoError(err)
+			for _, row := range tt.rows {
+				err = b.Update(ctx, row)
+				if err != nil {
+					break
+				}
+			}
+			if tt.err {
+				require.Error(err)
+				return
+			}
+			require.NoError(err)
+
+			v, err := b.Eval(ctx)
+			require.NoError(err)
+			require.Equal(tt.expected, v)
+		})
+	}
+}
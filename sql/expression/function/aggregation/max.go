@@ -102,7 +102,7 @@ func (m *maxBuffer) Update(ctx *sql.Context, row sql.Row) error {
 		return nil
 	}
 
-	cmp, err := m.expr.Type().Compare(v, m.val)
+	cmp, err := compareValues(m.expr.Type(), v, m.val)
 	if err != nil {
 		return err
 	}
@@ -18,6 +18,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/dolthub/vitess/go/sqltypes"
 	"github.com/stretchr/testify/require"
 
 	"github.com/dolthub/go-mysql-server/sql"
@@ -62,6 +63,50 @@ func TestMax_Eval_Text(t *testing.T) {
 	assert.Equal("b", v)
 }
 
+func TestMax_Eval_Collation(t *testing.T) {
+	assert := require.New(t)
+	ctx := sql.NewEmptyContext()
+
+	for _, test := range []struct {
+		collation sql.Collation
+		expected  string
+	}{
+		{sql.Collation_utf8mb4_0900_ai_ci, "c"},
+		{sql.Collation_utf8mb4_bin, "c"},
+	} {
+		typ := sql.MustCreateString(sqltypes.VarChar, 10, test.collation)
+		m := NewMax(expression.NewGetField(0, typ, "field", true))
+		b, _ := m.NewBuffer()
+
+		b.Update(ctx, sql.NewRow("a"))
+		b.Update(ctx, sql.NewRow("B"))
+		b.Update(ctx, sql.NewRow("c"))
+
+		v, err := b.Eval(ctx)
+		assert.NoError(err)
+		assert.Equal(test.expected, v, test.collation.Name)
+		assert.Equal(typ.String(), m.Type().String())
+	}
+}
+
+func TestMax_Eval_Enum(t *testing.T) {
+	assert := require.New(t)
+	ctx := sql.NewEmptyContext()
+
+	typ := sql.MustCreateEnumType([]string{"z", "a", "m"}, sql.Collation_Default)
+	m := NewMax(expression.NewGetField(0, typ, "field", true))
+	b, _ := m.NewBuffer()
+
+	b.Update(ctx, sql.NewRow("a"))
+	b.Update(ctx, sql.NewRow("z"))
+	b.Update(ctx, sql.NewRow("m"))
+
+	v, err := b.Eval(ctx)
+	assert.NoError(err)
+	assert.Equal("m", v)
+	assert.Equal(typ.String(), m.Type().String())
+}
+
 func TestMax_Eval_Timestamp(t *testing.T) {
 	assert := require.New(t)
 	ctx := sql.NewEmptyContext()
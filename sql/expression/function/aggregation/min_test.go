@@ -18,6 +18,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/dolthub/vitess/go/sqltypes"
 	"github.com/stretchr/testify/require"
 
 	"github.com/dolthub/go-mysql-server/sql"
@@ -63,6 +64,50 @@ func TestMin_Eval_Text(t *testing.T) {
 	assert.Equal("A", v)
 }
 
+func TestMin_Eval_Collation(t *testing.T) {
+	assert := require.New(t)
+	ctx := sql.NewEmptyContext()
+
+	for _, test := range []struct {
+		collation sql.Collation
+		expected  string
+	}{
+		{sql.Collation_utf8mb4_0900_ai_ci, "a"},
+		{sql.Collation_utf8mb4_bin, "B"},
+	} {
+		typ := sql.MustCreateString(sqltypes.VarChar, 10, test.collation)
+		m := NewMin(expression.NewGetField(0, typ, "field", true))
+		b, _ := m.NewBuffer()
+
+		b.Update(ctx, sql.NewRow("a"))
+		b.Update(ctx, sql.NewRow("B"))
+		b.Update(ctx, sql.NewRow("c"))
+
+		v, err := b.Eval(ctx)
+		assert.NoError(err)
+		assert.Equal(test.expected, v, test.collation.Name)
+		assert.Equal(typ.String(), m.Type().String())
+	}
+}
+
+func TestMin_Eval_Enum(t *testing.T) {
+	assert := require.New(t)
+	ctx := sql.NewEmptyContext()
+
+	typ := sql.MustCreateEnumType([]string{"z", "a", "m"}, sql.Collation_Default)
+	m := NewMin(expression.NewGetField(0, typ, "field", true))
+	b, _ := m.NewBuffer()
+
+	b.Update(ctx, sql.NewRow("a"))
+	b.Update(ctx, sql.NewRow("z"))
+	b.Update(ctx, sql.NewRow("m"))
+
+	v, err := b.Eval(ctx)
+	assert.NoError(err)
+	assert.Equal("z", v)
+	assert.Equal(typ.String(), m.Type().String())
+}
+
 func TestMin_Eval_Timestamp(t *testing.T) {
 	assert := require.New(t)
 	ctx := sql.NewEmptyContext()
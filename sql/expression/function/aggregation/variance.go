@@ -0,0 +1,186 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aggregation
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+// Variance node to calculate the population or sample variance, or standard deviation, of a numeric column. It
+// backs VAR_POP, VAR_SAMP, STDDEV_POP and STDDEV_SAMP, and their aliases VARIANCE, STD and STDDEV.
+type Variance struct {
+	expression.UnaryExpression
+	name   string
+	sample bool
+	stdDev bool
+}
+
+var _ sql.FunctionExpression = (*Variance)(nil)
+var _ sql.Aggregation = (*Variance)(nil)
+
+// NewVarPop creates a new Variance node for VAR_POP.
+func NewVarPop(e sql.Expression) *Variance {
+	return newVariance("var_pop", e, false, false)
+}
+
+// NewVarSamp creates a new Variance node for VAR_SAMP.
+func NewVarSamp(e sql.Expression) *Variance {
+	return newVariance("var_samp", e, true, false)
+}
+
+// NewVariance creates a new Variance node for VARIANCE, a synonym of VAR_POP.
+func NewVariance(e sql.Expression) *Variance {
+	return newVariance("variance", e, false, false)
+}
+
+// NewStdDevPop creates a new Variance node for STDDEV_POP.
+func NewStdDevPop(e sql.Expression) *Variance {
+	return newVariance("stddev_pop", e, false, true)
+}
+
+// NewStdDevSamp creates a new Variance node for STDDEV_SAMP.
+func NewStdDevSamp(e sql.Expression) *Variance {
+	return newVariance("stddev_samp", e, true, true)
+}
+
+// NewStdDev creates a new Variance node for STDDEV, a synonym of STDDEV_POP.
+func NewStdDev(e sql.Expression) *Variance {
+	return newVariance("stddev", e, false, true)
+}
+
+// NewStd creates a new Variance node for STD, a synonym of STDDEV_POP.
+func NewStd(e sql.Expression) *Variance {
+	return newVariance("std", e, false, true)
+}
+
+func newVariance(name string, e sql.Expression, sample, stdDev bool) *Variance {
+	return &Variance{expression.UnaryExpression{Child: e}, name, sample, stdDev}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (v *Variance) FunctionName() string {
+	return v.name
+}
+
+// IsSample returns whether the node calculates the sample variance or standard deviation, rather than the population
+// one.
+func (v *Variance) IsSample() bool {
+	return v.sample
+}
+
+// IsStdDev returns whether the node calculates the standard deviation, rather than the variance.
+func (v *Variance) IsStdDev() bool {
+	return v.stdDev
+}
+
+func (v *Variance) String() string {
+	return fmt.Sprintf("%s(%s)", strings.ToUpper(v.name), v.Child)
+}
+
+// Type implements Expression interface.
+func (v *Variance) Type() sql.Type {
+	return sql.Float64
+}
+
+// IsNullable implements Expression interface.
+func (v *Variance) IsNullable() bool {
+	return true
+}
+
+// Eval implements Expression interface.
+func (v *Variance) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	return nil, ErrEvalUnsupportedOnAggregation.New("Variance")
+}
+
+// WithChildren implements the Expression interface.
+func (v *Variance) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(v, len(children), 1)
+	}
+	return newVariance(v.name, children[0], v.sample, v.stdDev), nil
+}
+
+// NewBuffer implements Aggregation interface.
+func (v *Variance) NewBuffer() (sql.AggregationBuffer, error) {
+	bufferChild, err := expression.Clone(v.UnaryExpression.Child)
+	if err != nil {
+		return nil, err
+	}
+
+	return &varianceBuffer{v: v, expr: bufferChild}, nil
+}
+
+// varianceBuffer computes the variance with Welford's online algorithm, which updates the mean and the sum of the
+// squared differences from it with each value, instead of subtracting the squared sum from the sum of squares, which
+// loses all precision when the values are large compared to their spread.
+type varianceBuffer struct {
+	v    *Variance
+	rows int64
+	mean float64
+	m2   float64
+	expr sql.Expression
+}
+
+// Update implements the AggregationBuffer interface.
+func (b *varianceBuffer) Update(ctx *sql.Context, row sql.Row) error {
+	v, err := b.expr.Eval(ctx, row)
+	if err != nil {
+		return err
+	}
+
+	if v == nil {
+		return nil
+	}
+
+	v, err = sql.Float64.Convert(v)
+	if err != nil {
+		v = float64(0)
+	}
+	f := v.(float64)
+
+	b.rows++
+	delta := f - b.mean
+	b.mean += delta / float64(b.rows)
+	b.m2 += delta * (f - b.mean)
+
+	return nil
+}
+
+// Eval implements the AggregationBuffer interface.
+func (b *varianceBuffer) Eval(ctx *sql.Context) (interface{}, error) {
+	n := b.rows
+	if b.v.sample {
+		n--
+	}
+	if n <= 0 {
+		return nil, nil
+	}
+
+	variance := b.m2 / float64(n)
+	if b.v.stdDev {
+		return math.Sqrt(variance), nil
+	}
+	return variance, nil
+}
+
+// Dispose implements the Disposable interface.
+func (b *varianceBuffer) Dispose() {
+	expression.Dispose(b.expr)
+}
@@ -0,0 +1,130 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aggregation
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+func TestVariance_String(t *testing.T) {
+	require := require.New(t)
+
+	col := expression.NewGetField(0, sql.Int32, "col1", true)
+	require.Equal("VAR_POP(col1)", NewVarPop(col).String())
+	require.Equal("STDDEV_SAMP(col1)", NewStdDevSamp(col).String())
+	require.Equal("STD(col1)", NewStd(col).String())
+}
+
+func TestVariance(t *testing.T) {
+	col := expression.NewGetField(0, sql.Float64, "col1", true)
+
+	testCases := []struct {
+		name     string
+		rows     []interface{}
+		varPop   interface{}
+		varSamp  interface{}
+		stdPop   interface{}
+		stdSamp  interface{}
+		tolerant bool
+	}{
+		{
+			name:    "no rows",
+			rows:    nil,
+			varPop:  nil,
+			varSamp: nil,
+			stdPop:  nil,
+			stdSamp: nil,
+		},
+		{
+			name:    "only nulls",
+			rows:    []interface{}{nil, nil},
+			varPop:  nil,
+			varSamp: nil,
+			stdPop:  nil,
+			stdSamp: nil,
+		},
+		{
+			name:    "one row",
+			rows:    []interface{}{nil, int32(42), nil},
+			varPop:  float64(0),
+			varSamp: nil,
+			stdPop:  float64(0),
+			stdSamp: nil,
+		},
+		{
+			name:    "integers",
+			rows:    []interface{}{int32(2), int32(4), int32(4), nil, int32(4), int32(5), int32(5), int32(7), int32(9)},
+			varPop:  float64(4),
+			varSamp: float64(32) / 7,
+			stdPop:  float64(2),
+			stdSamp: math.Sqrt(float64(32) / 7),
+		},
+		{
+			name:     "large values with a small spread",
+			rows:     []interface{}{1e9 + 4, 1e9 + 7, 1e9 + 13, 1e9 + 16},
+			varPop:   22.5,
+			varSamp:  float64(30),
+			stdPop:   math.Sqrt(22.5),
+			stdSamp:  math.Sqrt(30),
+			tolerant: true,
+		},
+		{
+			name:    "strings",
+			rows:    []interface{}{"1", "3"},
+			varPop:  float64(1),
+			varSamp: float64(2),
+			stdPop:  float64(1),
+			stdSamp: math.Sqrt(2),
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := sql.NewEmptyContext()
+
+			for _, v := range []struct {
+				agg      *Variance
+				expected interface{}
+			}{
+				{NewVarPop(col), tt.varPop},
+				{NewVariance(col), tt.varPop},
+				{NewVarSamp(col), tt.varSamp},
+				{NewStdDevPop(col), tt.stdPop},
+				{NewStdDev(col), tt.stdPop},
+				{NewStd(col), tt.stdPop},
+				{NewStdDevSamp(col), tt.stdSamp},
+			} {
+				buf, err := v.agg.NewBuffer()
+				require.NoError(t, err)
+				for _, r := range tt.rows {
+					require.NoError(t, buf.Update(ctx, sql.NewRow(r)))
+				}
+
+				result := evalBuffer(t, buf)
+				if v.expected == nil || !tt.tolerant {
+					require.Equal(t, v.expected, result, v.agg.String())
+				} else {
+					require.InDelta(t, v.expected, result, 1e-9, v.agg.String())
+				}
+			}
+		})
+	}
+}
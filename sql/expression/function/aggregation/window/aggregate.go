@@ -0,0 +1,213 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package window
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql/expression"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// Aggregate is an aggregate function used as a window function, as in COUNT(DISTINCT x) OVER (PARTITION BY y). Its
+// value for every row is the aggregation of the whole partition of the row. Windows with an ORDER BY clause, which
+// limits the frame of a row to the rows before it, aren't supported.
+type Aggregate struct {
+	window *sql.Window
+	agg    sql.Aggregation
+}
+
+var _ sql.FunctionExpression = (*Aggregate)(nil)
+var _ sql.WindowAggregation = (*Aggregate)(nil)
+
+// NewAggregate returns a window function evaluating the aggregation given over the partitions of the window given.
+func NewAggregate(agg sql.Aggregation, window *sql.Window) *Aggregate {
+	return &Aggregate{window: window, agg: agg}
+}
+
+// Aggregation returns the aggregation evaluated over every partition.
+func (a *Aggregate) Aggregation() sql.Aggregation {
+	return a.agg
+}
+
+// Window implements sql.WindowExpression
+func (a *Aggregate) Window() *sql.Window {
+	return a.window
+}
+
+// Resolved implements sql.Expression
+func (a *Aggregate) Resolved() bool {
+	return a.agg.Resolved() && windowResolved(a.window)
+}
+
+// NewBuffer implements sql.WindowAggregation. The buffer holds the rows added, and the value of every row once the
+// aggregation is finished.
+func (a *Aggregate) NewBuffer() sql.Row {
+	return sql.NewRow(make([]sql.Row, 0), nil)
+}
+
+func (a *Aggregate) String() string {
+	sb := strings.Builder{}
+	sb.WriteString(a.agg.String())
+	if a.window != nil {
+		sb.WriteString(" ")
+		sb.WriteString(a.window.String())
+	}
+	return sb.String()
+}
+
+func (a *Aggregate) DebugString() string {
+	sb := strings.Builder{}
+	sb.WriteString(sql.DebugString(a.agg))
+	if a.window != nil {
+		sb.WriteString(" ")
+		sb.WriteString(sql.DebugString(a.window))
+	}
+	return sb.String()
+}
+
+// FunctionName implements sql.FunctionExpression
+func (a *Aggregate) FunctionName() string {
+	if fn, ok := a.agg.(sql.FunctionExpression); ok {
+		return fn.FunctionName()
+	}
+	return a.agg.String()
+}
+
+// Type implements sql.Expression
+func (a *Aggregate) Type() sql.Type {
+	return a.agg.Type()
+}
+
+// IsNullable implements sql.Expression
+func (a *Aggregate) IsNullable() bool {
+	return a.agg.IsNullable()
+}
+
+// Eval implements sql.Expression
+func (a *Aggregate) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	panic("eval called on window function")
+}
+
+// Children implements sql.Expression. The children of the aggregation come before the expressions of the window.
+func (a *Aggregate) Children() []sql.Expression {
+	return append(a.agg.Children(), a.window.ToExpressions()...)
+}
+
+// WithChildren implements sql.Expression
+func (a *Aggregate) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	aggChildren := len(a.agg.Children())
+	if len(children) < aggChildren {
+		return nil, sql.ErrInvalidChildrenNumber.New(a, len(children), aggChildren+len(a.window.ToExpressions()))
+	}
+
+	agg, err := a.agg.WithChildren(children[:aggChildren]...)
+	if err != nil {
+		return nil, err
+	}
+	window, err := a.window.FromExpressions(children[aggChildren:])
+	if err != nil {
+		return nil, err
+	}
+
+	return NewAggregate(agg.(sql.Aggregation), window), nil
+}
+
+// WithWindow implements sql.WindowAggregation
+func (a *Aggregate) WithWindow(window *sql.Window) (sql.WindowAggregation, error) {
+	return NewAggregate(a.agg, window), nil
+}
+
+// Add implements sql.WindowAggregation
+func (a *Aggregate) Add(ctx *sql.Context, buffer, row sql.Row) error {
+	rows := buffer[0].([]sql.Row)
+	buffer[0] = append(rows, append(row.Copy(), len(rows)))
+	return nil
+}
+
+// Finish implements sql.WindowAggregation. Rows are sorted by their partition, and the aggregation is evaluated with a
+// new buffer for every partition, so that the values a DISTINCT aggregation has seen are forgotten between them.
+func (a *Aggregate) Finish(ctx *sql.Context, buffer sql.Row) error {
+	rows := buffer[0].([]sql.Row)
+	values := make([]interface{}, len(rows))
+	buffer[1] = values
+	if len(rows) == 0 {
+		return nil
+	}
+
+	var partitionBy []sql.Expression
+	if a.window != nil {
+		partitionBy = a.window.PartitionBy
+	}
+
+	sorted := make([]sql.Row, len(rows))
+	copy(sorted, rows)
+	sorter := &expression.Sorter{
+		SortFields: partitionsToSortFields(partitionBy),
+		Rows:       sorted,
+		Ctx:        ctx,
+	}
+	sort.Stable(sorter)
+	if sorter.LastError != nil {
+		return sorter.LastError
+	}
+
+	originalOrderIdx := len(rows[0]) - 1
+	aggregatePartition := func(partition []sql.Row) error {
+		b, err := a.agg.NewBuffer()
+		if err != nil {
+			return err
+		}
+		defer b.Dispose()
+
+		for _, row := range partition {
+			if err := b.Update(ctx, row[:originalOrderIdx]); err != nil {
+				return err
+			}
+		}
+
+		value, err := b.Eval(ctx)
+		if err != nil {
+			return err
+		}
+		for _, row := range partition {
+			values[row[originalOrderIdx].(int)] = value
+		}
+		return nil
+	}
+
+	start := 0
+	for i := 1; i < len(sorted); i++ {
+		isNew, err := isNewPartition(ctx, partitionBy, sorted[i-1], sorted[i])
+		if err != nil {
+			return err
+		}
+		if isNew {
+			if err := aggregatePartition(sorted[start:i]); err != nil {
+				return err
+			}
+			start = i
+		}
+	}
+
+	return aggregatePartition(sorted[start:])
+}
+
+// EvalRow implements sql.WindowAggregation
+func (a *Aggregate) EvalRow(i int, buffer sql.Row) (interface{}, error) {
+	return buffer[1].([]interface{})[i], nil
+}
@@ -0,0 +1,131 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package window
+
+import (
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// DenseRank is the DENSE_RANK window function, which ranks the rows of each partition of its window in the order of the
+// window. Rows that are equal in that order are peers with the same rank, and the rank of the rows after a group of
+// peers is the next one, so that no ranks are skipped after ties.
+type DenseRank struct {
+	window *sql.Window
+}
+
+var _ sql.FunctionExpression = (*DenseRank)(nil)
+var _ sql.WindowAggregation = (*DenseRank)(nil)
+
+func NewDenseRank() sql.Expression {
+	return &DenseRank{}
+}
+
+// Window implements sql.WindowExpression
+func (d *DenseRank) Window() *sql.Window {
+	return d.window
+}
+
+// IsNullable implements sql.Expression
+func (d *DenseRank) Resolved() bool {
+	return windowResolved(d.window)
+}
+
+func (d *DenseRank) NewBuffer() sql.Row {
+	return sql.NewRow(make([]sql.Row, 0))
+}
+
+func (d *DenseRank) String() string {
+	sb := strings.Builder{}
+	sb.WriteString("dense_rank()")
+	if d.window != nil {
+		sb.WriteString(" ")
+		sb.WriteString(d.window.String())
+	}
+	return sb.String()
+}
+
+func (d *DenseRank) DebugString() string {
+	sb := strings.Builder{}
+	sb.WriteString("dense_rank()")
+	if d.window != nil {
+		sb.WriteString(" ")
+		sb.WriteString(sql.DebugString(d.window))
+	}
+	return sb.String()
+}
+
+// FunctionName implements sql.FunctionExpression
+func (d *DenseRank) FunctionName() string {
+	return "DENSE_RANK"
+}
+
+// Type implements sql.Expression
+func (d *DenseRank) Type() sql.Type {
+	return sql.Int64
+}
+
+// IsNullable implements sql.Expression
+func (d *DenseRank) IsNullable() bool {
+	return false
+}
+
+// Eval implements sql.Expression
+func (d *DenseRank) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	panic("eval called on window function")
+}
+
+// Children implements sql.Expression
+func (d *DenseRank) Children() []sql.Expression {
+	return d.window.ToExpressions()
+}
+
+// WithChildren implements sql.Expression
+func (d *DenseRank) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	window, err := d.window.FromExpressions(children)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.WithWindow(window)
+}
+
+// WithWindow implements sql.WindowAggregation
+func (d *DenseRank) WithWindow(window *sql.Window) (sql.WindowAggregation, error) {
+	nd := *d
+	nd.window = window
+	return &nd, nil
+}
+
+// Add implements sql.WindowAggregation
+func (d *DenseRank) Add(ctx *sql.Context, buffer, row sql.Row) error {
+	bufferRow(buffer, row)
+	return nil
+}
+
+// Finish implements sql.WindowAggregation
+func (d *DenseRank) Finish(ctx *sql.Context, buffer sql.Row) error {
+	return sortPartitions(ctx, d.window, buffer, func(partition []sql.Row) error {
+		return rankPeers(ctx, d.window, partition, func(rank int64, _ int) int64 {
+			return rank + 1
+		})
+	})
+}
+
+// EvalRow implements sql.WindowAggregation
+func (d *DenseRank) EvalRow(i int, buffer sql.Row) (interface{}, error) {
+	return bufferedValue(buffer, i), nil
+}
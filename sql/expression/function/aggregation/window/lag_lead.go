@@ -0,0 +1,272 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package window
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+// offsetValue is the logic shared by LAG and LEAD, which return the value of an expression for the row a number of
+// rows before or after each row in its partition, in the order of the window. The arguments are the expression, then
+// optionally the offset, which defaults to 1, and the default value for rows whose offset row falls outside their
+// partition, which defaults to NULL. The offset must be a non-negative constant, and the default may refer to the
+// columns of the row.
+type offsetValue struct {
+	window *sql.Window
+	args   []sql.Expression
+	name   string
+	// direction is -1 for LAG, which looks back, and 1 for LEAD, which looks ahead.
+	direction int
+}
+
+func newOffsetValue(name string, direction int, args []sql.Expression) (offsetValue, error) {
+	if len(args) < 1 || len(args) > 3 {
+		return offsetValue{}, sql.ErrInvalidArgumentNumber.New(name, "1, 2 or 3", len(args))
+	}
+	return offsetValue{args: args, name: name, direction: direction}, nil
+}
+
+// Window implements sql.WindowExpression
+func (o *offsetValue) Window() *sql.Window {
+	return o.window
+}
+
+// Resolved implements sql.Expression
+func (o *offsetValue) Resolved() bool {
+	return windowResolved(o.window) && expression.ExpressionsResolved(o.args...)
+}
+
+func (o *offsetValue) NewBuffer() sql.Row {
+	return sql.NewRow(make([]sql.Row, 0))
+}
+
+func (o *offsetValue) String() string {
+	args := make([]string, len(o.args))
+	for i, arg := range o.args {
+		args[i] = arg.String()
+	}
+
+	sb := strings.Builder{}
+	sb.WriteString(fmt.Sprintf("%s(%s)", strings.ToLower(o.name), strings.Join(args, ", ")))
+	if o.window != nil {
+		sb.WriteString(" ")
+		sb.WriteString(o.window.String())
+	}
+	return sb.String()
+}
+
+func (o *offsetValue) DebugString() string {
+	args := make([]string, len(o.args))
+	for i, arg := range o.args {
+		args[i] = sql.DebugString(arg)
+	}
+
+	sb := strings.Builder{}
+	sb.WriteString(fmt.Sprintf("%s(%s)", strings.ToLower(o.name), strings.Join(args, ", ")))
+	if o.window != nil {
+		sb.WriteString(" ")
+		sb.WriteString(sql.DebugString(o.window))
+	}
+	return sb.String()
+}
+
+// FunctionName implements sql.FunctionExpression
+func (o *offsetValue) FunctionName() string {
+	return o.name
+}
+
+// Type implements sql.Expression
+func (o *offsetValue) Type() sql.Type {
+	return o.args[0].Type()
+}
+
+// IsNullable implements sql.Expression
+func (o *offsetValue) IsNullable() bool {
+	return true
+}
+
+// Eval implements sql.Expression
+func (o *offsetValue) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	panic("eval called on window function")
+}
+
+// Children implements sql.Expression
+func (o *offsetValue) Children() []sql.Expression {
+	return append(o.window.ToExpressions(), o.args...)
+}
+
+// withChildren returns a copy of the function with the children given, which are the expressions of its window
+// followed by its arguments.
+func (o offsetValue) withChildren(children []sql.Expression) (offsetValue, error) {
+	if len(children) < len(o.args) {
+		return offsetValue{}, sql.ErrInvalidChildrenNumber.New(o.name, len(children), len(o.args))
+	}
+
+	split := len(children) - len(o.args)
+	window, err := o.window.FromExpressions(children[:split])
+	if err != nil {
+		return offsetValue{}, err
+	}
+
+	o.window = window
+	o.args = children[split:]
+	return o, nil
+}
+
+// Add implements sql.WindowAggregation
+func (o *offsetValue) Add(ctx *sql.Context, buffer, row sql.Row) error {
+	bufferRow(buffer, row)
+	return nil
+}
+
+// offset evaluates the offset argument, which defaults to 1.
+func (o *offsetValue) offset(ctx *sql.Context) (int64, error) {
+	if len(o.args) < 2 {
+		return 1, nil
+	}
+
+	var refersToRow bool
+	sql.Inspect(o.args[1], func(e sql.Expression) bool {
+		if _, ok := e.(*expression.GetField); ok {
+			refersToRow = true
+		}
+		return !refersToRow
+	})
+	if refersToRow {
+		return 0, sql.ErrInvalidArgument.New(strings.ToLower(o.name))
+	}
+
+	val, err := o.args[1].Eval(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	if val == nil {
+		return 0, sql.ErrInvalidArgument.New(strings.ToLower(o.name))
+	}
+	offset, err := sql.Int64.Convert(val)
+	if err != nil {
+		return 0, err
+	}
+	if offset.(int64) < 0 {
+		return 0, sql.ErrInvalidArgument.New(strings.ToLower(o.name))
+	}
+	return offset.(int64), nil
+}
+
+// Finish implements sql.WindowAggregation
+func (o *offsetValue) Finish(ctx *sql.Context, buffer sql.Row) error {
+	offset, err := o.offset(ctx)
+	if err != nil {
+		return err
+	}
+
+	return sortPartitions(ctx, o.window, buffer, func(partition []sql.Row) error {
+		for i, row := range partition {
+			// An offset larger than the partition is checked first, so that it can't overflow
+			j := -1
+			if offset < int64(len(partition)) {
+				j = i + o.direction*int(offset)
+			}
+
+			var val interface{}
+			var err error
+			if j >= 0 && j < len(partition) {
+				val, err = o.args[0].Eval(ctx, partition[j])
+			} else if len(o.args) > 2 {
+				val, err = o.args[2].Eval(ctx, row)
+			}
+			if err != nil {
+				return err
+			}
+			setBufferedValue(row, val)
+		}
+		return nil
+	})
+}
+
+// EvalRow implements sql.WindowAggregation
+func (o *offsetValue) EvalRow(i int, buffer sql.Row) (interface{}, error) {
+	return bufferedValue(buffer, i), nil
+}
+
+// Lag is the LAG window function, which returns the value of its expression for the row a number of rows before each
+// row in its partition.
+type Lag struct {
+	offsetValue
+}
+
+var _ sql.FunctionExpression = (*Lag)(nil)
+var _ sql.WindowAggregation = (*Lag)(nil)
+
+func NewLag(args ...sql.Expression) (sql.Expression, error) {
+	o, err := newOffsetValue("LAG", -1, args)
+	if err != nil {
+		return nil, err
+	}
+	return &Lag{o}, nil
+}
+
+// WithChildren implements sql.Expression
+func (l *Lag) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	o, err := l.withChildren(children)
+	if err != nil {
+		return nil, err
+	}
+	return &Lag{o}, nil
+}
+
+// WithWindow implements sql.WindowAggregation
+func (l *Lag) WithWindow(window *sql.Window) (sql.WindowAggregation, error) {
+	nl := *l
+	nl.window = window
+	return &nl, nil
+}
+
+// Lead is the LEAD window function, which returns the value of its expression for the row a number of rows after each
+// row in its partition.
+type Lead struct {
+	offsetValue
+}
+
+var _ sql.FunctionExpression = (*Lead)(nil)
+var _ sql.WindowAggregation = (*Lead)(nil)
+
+func NewLead(args ...sql.Expression) (sql.Expression, error) {
+	o, err := newOffsetValue("LEAD", 1, args)
+	if err != nil {
+		return nil, err
+	}
+	return &Lead{o}, nil
+}
+
+// WithChildren implements sql.Expression
+func (l *Lead) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	o, err := l.withChildren(children)
+	if err != nil {
+		return nil, err
+	}
+	return &Lead{o}, nil
+}
+
+// WithWindow implements sql.WindowAggregation
+func (l *Lead) WithWindow(window *sql.Window) (sql.WindowAggregation, error) {
+	nl := *l
+	nl.window = window
+	return &nl, nil
+}
@@ -0,0 +1,150 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package window
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+func TestLagAndLead(t *testing.T) {
+	k := expression.NewGetField(0, sql.LongText, "k", false)
+	ts := expression.NewGetField(1, sql.Int64, "ts", false)
+	v := expression.NewGetField(2, sql.Int64, "v", true)
+
+	rows := []sql.Row{
+		{"a", int64(3), int64(30)},
+		{"b", int64(1), int64(40)},
+		{"a", int64(1), int64(10)},
+		{"a", int64(2), nil},
+		{"b", int64(2), int64(50)},
+	}
+	window := sql.NewWindow([]sql.Expression{k}, sql.SortFields{{Column: ts, Order: sql.Ascending}})
+	lit := func(v interface{}) sql.Expression {
+		return expression.NewLiteral(v, sql.Int64)
+	}
+
+	testCases := []struct {
+		name     string
+		fn       func(args ...sql.Expression) (sql.Expression, error)
+		args     []sql.Expression
+		window   *sql.Window
+		expected []interface{}
+	}{
+		{
+			name:     "lag",
+			fn:       NewLag,
+			args:     []sql.Expression{v},
+			window:   window,
+			expected: []interface{}{nil, nil, nil, int64(10), int64(40)},
+		},
+		{
+			name:     "lead",
+			fn:       NewLead,
+			args:     []sql.Expression{v},
+			window:   window,
+			expected: []interface{}{nil, int64(50), nil, int64(30), nil},
+		},
+		{
+			name:     "lag with offset and default",
+			fn:       NewLag,
+			args:     []sql.Expression{v, lit(int64(2)), lit(int64(0))},
+			window:   window,
+			expected: []interface{}{int64(10), int64(0), int64(0), int64(0), int64(0)},
+		},
+		{
+			name:     "lead with default from the row",
+			fn:       NewLead,
+			args:     []sql.Expression{v, lit(int64(1)), ts},
+			window:   window,
+			expected: []interface{}{int64(3), int64(50), nil, int64(30), int64(2)},
+		},
+		{
+			name:     "offset 0",
+			fn:       NewLag,
+			args:     []sql.Expression{v, lit(int64(0)), lit(int64(-1))},
+			window:   window,
+			expected: []interface{}{int64(30), int64(40), int64(10), nil, int64(50)},
+		},
+		{
+			name:     "offset larger than the partitions",
+			fn:       NewLead,
+			args:     []sql.Expression{v, lit(int64(math.MaxInt64)), lit(int64(-1))},
+			window:   window,
+			expected: []interface{}{int64(-1), int64(-1), int64(-1), int64(-1), int64(-1)},
+		},
+		{
+			name:     "no window",
+			fn:       NewLag,
+			args:     []sql.Expression{v},
+			window:   nil,
+			expected: []interface{}{nil, int64(30), int64(40), int64(10), nil},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			ctx := sql.NewEmptyContext()
+
+			fn, err := tt.fn(tt.args...)
+			require.NoError(err)
+			agg, err := fn.(sql.WindowAggregation).WithWindow(tt.window)
+			require.NoError(err)
+
+			buffer := agg.NewBuffer()
+			for _, row := range rows {
+				require.NoError(agg.Add(ctx, buffer, row))
+			}
+			require.NoError(agg.Finish(ctx, buffer))
+
+			var result []interface{}
+			for i := range rows {
+				v, err := agg.EvalRow(i, buffer)
+				require.NoError(err)
+				result = append(result, v)
+			}
+			require.Equal(tt.expected, result)
+		})
+	}
+}
+
+func TestLagAndLeadErrors(t *testing.T) {
+	v := expression.NewGetField(0, sql.Int64, "v", true)
+	ctx := sql.NewEmptyContext()
+
+	_, err := NewLag()
+	require.True(t, sql.ErrInvalidArgumentNumber.Is(err))
+	_, err = NewLead(v, v, v, v)
+	require.True(t, sql.ErrInvalidArgumentNumber.Is(err))
+
+	for _, offset := range []sql.Expression{
+		expression.NewLiteral(int64(-1), sql.Int64),
+		expression.NewLiteral(nil, sql.Null),
+		v,
+	} {
+		fn, err := NewLag(v, offset)
+		require.NoError(t, err)
+		agg := fn.(sql.WindowAggregation)
+		buffer := agg.NewBuffer()
+		require.NoError(t, agg.Add(ctx, buffer, sql.Row{int64(1)}))
+		require.True(t, sql.ErrInvalidArgument.Is(agg.Finish(ctx, buffer)))
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package window
+
+import (
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// Rank is the RANK window function, which ranks the rows of each partition of its window in the order of the window.
+// Rows that are equal in that order are peers with the same rank, and the rank of the rows after a group of peers is
+// their position in the partition, so that ranks are skipped after ties.
+type Rank struct {
+	window *sql.Window
+}
+
+var _ sql.FunctionExpression = (*Rank)(nil)
+var _ sql.WindowAggregation = (*Rank)(nil)
+
+func NewRank() sql.Expression {
+	return &Rank{}
+}
+
+// Window implements sql.WindowExpression
+func (r *Rank) Window() *sql.Window {
+	return r.window
+}
+
+// IsNullable implements sql.Expression
+func (r *Rank) Resolved() bool {
+	return windowResolved(r.window)
+}
+
+func (r *Rank) NewBuffer() sql.Row {
+	return sql.NewRow(make([]sql.Row, 0))
+}
+
+func (r *Rank) String() string {
+	sb := strings.Builder{}
+	sb.WriteString("rank()")
+	if r.window != nil {
+		sb.WriteString(" ")
+		sb.WriteString(r.window.String())
+	}
+	return sb.String()
+}
+
+func (r *Rank) DebugString() string {
+	sb := strings.Builder{}
+	sb.WriteString("rank()")
+	if r.window != nil {
+		sb.WriteString(" ")
+		sb.WriteString(sql.DebugString(r.window))
+	}
+	return sb.String()
+}
+
+// FunctionName implements sql.FunctionExpression
+func (r *Rank) FunctionName() string {
+	return "RANK"
+}
+
+// Type implements sql.Expression
+func (r *Rank) Type() sql.Type {
+	return sql.Int64
+}
+
+// IsNullable implements sql.Expression
+func (r *Rank) IsNullable() bool {
+	return false
+}
+
+// Eval implements sql.Expression
+func (r *Rank) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	panic("eval called on window function")
+}
+
+// Children implements sql.Expression
+func (r *Rank) Children() []sql.Expression {
+	return r.window.ToExpressions()
+}
+
+// WithChildren implements sql.Expression
+func (r *Rank) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	window, err := r.window.FromExpressions(children)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.WithWindow(window)
+}
+
+// WithWindow implements sql.WindowAggregation
+func (r *Rank) WithWindow(window *sql.Window) (sql.WindowAggregation, error) {
+	nr := *r
+	nr.window = window
+	return &nr, nil
+}
+
+// Add implements sql.WindowAggregation
+func (r *Rank) Add(ctx *sql.Context, buffer, row sql.Row) error {
+	bufferRow(buffer, row)
+	return nil
+}
+
+// Finish implements sql.WindowAggregation
+func (r *Rank) Finish(ctx *sql.Context, buffer sql.Row) error {
+	return sortPartitions(ctx, r.window, buffer, func(partition []sql.Row) error {
+		return rankPeers(ctx, r.window, partition, func(_ int64, pos int) int64 {
+			return int64(pos)
+		})
+	})
+}
+
+// EvalRow implements sql.WindowAggregation
+func (r *Rank) EvalRow(i int, buffer sql.Row) (interface{}, error) {
+	return bufferedValue(buffer, i), nil
+}
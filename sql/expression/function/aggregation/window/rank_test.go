@@ -0,0 +1,95 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package window
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+func TestRankAndDenseRank(t *testing.T) {
+	dept := expression.NewGetField(0, sql.LongText, "dept", false)
+	salary := expression.NewGetField(1, sql.Int64, "salary", true)
+
+	rows := []sql.Row{
+		{"a", int64(100)},
+		{"a", int64(200)},
+		{"b", int64(150)},
+		{"a", int64(200)},
+		{"b", nil},
+		{"a", int64(50)},
+		{"b", nil},
+		{"a", int64(100)},
+	}
+
+	testCases := []struct {
+		name      string
+		window    *sql.Window
+		rank      []interface{}
+		denseRank []interface{}
+	}{
+		{
+			name:      "no order",
+			window:    sql.NewWindow([]sql.Expression{dept}, nil),
+			rank:      []interface{}{int64(1), int64(1), int64(1), int64(1), int64(1), int64(1), int64(1), int64(1)},
+			denseRank: []interface{}{int64(1), int64(1), int64(1), int64(1), int64(1), int64(1), int64(1), int64(1)},
+		},
+		{
+			name:      "ties without partition",
+			window:    sql.NewWindow(nil, sql.SortFields{{Column: salary, Order: sql.Descending}}),
+			rank:      []interface{}{int64(4), int64(1), int64(3), int64(1), int64(7), int64(6), int64(7), int64(4)},
+			denseRank: []interface{}{int64(3), int64(1), int64(2), int64(1), int64(5), int64(4), int64(5), int64(3)},
+		},
+		{
+			name:      "ties in partitions",
+			window:    sql.NewWindow([]sql.Expression{dept}, sql.SortFields{{Column: salary, Order: sql.Ascending}}),
+			rank:      []interface{}{int64(2), int64(4), int64(3), int64(4), int64(1), int64(1), int64(1), int64(2)},
+			denseRank: []interface{}{int64(2), int64(3), int64(2), int64(3), int64(1), int64(1), int64(1), int64(2)},
+		},
+	}
+
+	eval := func(t *testing.T, agg sql.WindowAggregation) []interface{} {
+		ctx := sql.NewEmptyContext()
+		buffer := agg.NewBuffer()
+		for _, row := range rows {
+			require.NoError(t, agg.Add(ctx, buffer, row))
+		}
+		require.NoError(t, agg.Finish(ctx, buffer))
+
+		var result []interface{}
+		for i := range rows {
+			v, err := agg.EvalRow(i, buffer)
+			require.NoError(t, err)
+			result = append(result, v)
+		}
+		return result
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			rank, err := NewRank().(*Rank).WithWindow(tt.window)
+			require.NoError(t, err)
+			require.Equal(t, tt.rank, eval(t, rank))
+
+			denseRank, err := NewDenseRank().(*DenseRank).WithWindow(tt.window)
+			require.NoError(t, err)
+			require.Equal(t, tt.denseRank, eval(t, denseRank))
+		})
+	}
+}
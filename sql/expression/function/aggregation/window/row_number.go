@@ -15,17 +15,15 @@
 package window
 
 import (
-	"sort"
 	"strings"
 
-	"github.com/dolthub/go-mysql-server/sql/expression"
-
 	"github.com/dolthub/go-mysql-server/sql"
 )
 
+// RowNumber is the ROW_NUMBER window function, which numbers the rows of each partition of its window sequentially,
+// starting at 1, in the order of the window.
 type RowNumber struct {
 	window *sql.Window
-	pos    int
 }
 
 var _ sql.FunctionExpression = (*RowNumber)(nil)
@@ -113,57 +111,21 @@ func (r *RowNumber) WithWindow(window *sql.Window) (sql.WindowAggregation, error
 
 // Add implements sql.WindowAggregation
 func (r *RowNumber) Add(ctx *sql.Context, buffer, row sql.Row) error {
-	rows := buffer[0].([]sql.Row)
-	buffer[0] = append(rows, append(row, nil, r.pos))
-	r.pos++
+	bufferRow(buffer, row)
 	return nil
 }
 
 // Finish implements sql.WindowAggregation
 func (r *RowNumber) Finish(ctx *sql.Context, buffer sql.Row) error {
-	rows := buffer[0].([]sql.Row)
-	if len(rows) > 0 && r.window != nil && r.window.OrderBy != nil {
-		sorter := &expression.Sorter{
-			SortFields: append(partitionsToSortFields(r.Window().PartitionBy), r.Window().OrderBy...),
-			Rows:       rows,
-			Ctx:        ctx,
-		}
-		sort.Stable(sorter)
-		if sorter.LastError != nil {
-			return sorter.LastError
-		}
-
-		// Now that we have the rows in sorted order, number them
-		rowNumIdx := len(rows[0]) - 2
-		originalOrderIdx := len(rows[0]) - 1
-		var last sql.Row
-		var rowNum int
-		for _, row := range rows {
-			// every time we encounter a new partition, start the count over
-			isNew, err := isNewPartition(ctx, r.window.PartitionBy, last, row)
-			if err != nil {
-				return err
-			}
-			if isNew {
-				rowNum = 1
-			}
-
-			row[rowNumIdx] = rowNum
-
-			rowNum++
-			last = row
+	return sortPartitions(ctx, r.window, buffer, func(partition []sql.Row) error {
+		for i, row := range partition {
+			setBufferedValue(row, int64(i+1))
 		}
-
-		// And finally sort again by the original order
-		sort.SliceStable(rows, func(i, j int) bool {
-			return rows[i][originalOrderIdx].(int) < rows[j][originalOrderIdx].(int)
-		})
-	}
-	return nil
+		return nil
+	})
 }
 
 // EvalRow implements sql.WindowAggregation
 func (r *RowNumber) EvalRow(i int, buffer sql.Row) (interface{}, error) {
-	rows := buffer[0].([]sql.Row)
-	return rows[i][len(rows[i])-2], nil
+	return bufferedValue(buffer, i), nil
 }
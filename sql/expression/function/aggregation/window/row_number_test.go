@@ -0,0 +1,109 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package window
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+func TestRowNumber(t *testing.T) {
+	dept := expression.NewGetField(0, sql.LongText, "dept", false)
+	team := expression.NewGetField(1, sql.Int64, "team", false)
+	salary := expression.NewGetField(2, sql.Int64, "salary", true)
+	id := expression.NewGetField(3, sql.Int64, "id", false)
+
+	rows := []sql.Row{
+		{"a", int64(1), int64(100), int64(1)},
+		{"a", int64(1), int64(200), int64(2)},
+		{"b", int64(1), int64(150), int64(3)},
+		{"a", int64(2), int64(200), int64(4)},
+		{"b", int64(1), nil, int64(5)},
+		{"a", int64(1), int64(200), int64(6)},
+	}
+
+	testCases := []struct {
+		name     string
+		window   *sql.Window
+		expected []interface{}
+	}{
+		{
+			name:     "no window",
+			window:   nil,
+			expected: []interface{}{int64(1), int64(2), int64(3), int64(4), int64(5), int64(6)},
+		},
+		{
+			name:     "empty window",
+			window:   sql.NewWindow(nil, nil),
+			expected: []interface{}{int64(1), int64(2), int64(3), int64(4), int64(5), int64(6)},
+		},
+		{
+			name:     "partition without order",
+			window:   sql.NewWindow([]sql.Expression{dept}, nil),
+			expected: []interface{}{int64(1), int64(2), int64(1), int64(3), int64(2), int64(4)},
+		},
+		{
+			name: "order without partition",
+			window: sql.NewWindow(nil, sql.SortFields{
+				{Column: salary, Order: sql.Descending},
+				{Column: id, Order: sql.Ascending},
+			}),
+			expected: []interface{}{int64(5), int64(1), int64(4), int64(2), int64(6), int64(3)},
+		},
+		{
+			name: "multiple partition keys",
+			window: sql.NewWindow([]sql.Expression{dept, team}, sql.SortFields{
+				{Column: salary, Order: sql.Descending},
+			}),
+			expected: []interface{}{int64(3), int64(1), int64(1), int64(1), int64(2), int64(2)},
+		},
+		{
+			name: "multiple order keys",
+			window: sql.NewWindow([]sql.Expression{dept}, sql.SortFields{
+				{Column: salary, Order: sql.Descending},
+				{Column: id, Order: sql.Descending},
+			}),
+			expected: []interface{}{int64(4), int64(3), int64(1), int64(2), int64(2), int64(1)},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			ctx := sql.NewEmptyContext()
+
+			agg, err := NewRowNumber().(*RowNumber).WithWindow(tt.window)
+			require.NoError(err)
+
+			buffer := agg.NewBuffer()
+			for _, row := range rows {
+				require.NoError(agg.Add(ctx, buffer, row))
+			}
+			require.NoError(agg.Finish(ctx, buffer))
+
+			var result []interface{}
+			for i := range rows {
+				v, err := agg.EvalRow(i, buffer)
+				require.NoError(err)
+				result = append(result, v)
+			}
+			require.Equal(tt.expected, result)
+		})
+	}
+}
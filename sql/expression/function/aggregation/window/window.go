@@ -15,6 +15,8 @@
 package window
 
 import (
+	"sort"
+
 	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/dolthub/go-mysql-server/sql/expression"
 )
@@ -26,6 +28,106 @@ func windowResolved(window *sql.Window) bool {
 	return expression.ExpressionsResolved(append(window.OrderBy.ToExpressions(), window.PartitionBy...)...)
 }
 
+// bufferRow adds the row given to the rows buffered by a ranking window function, with two more columns: the value of
+// the function for the row, which is set by the function once all the rows are buffered, and the index of the row in
+// the buffer, which sortPartitions uses to restore the original order of the rows.
+func bufferRow(buffer, row sql.Row) {
+	rows := buffer[0].([]sql.Row)
+	buffer[0] = append(rows, append(row.Copy(), nil, len(rows)))
+}
+
+// bufferedValue returns the value set for the buffered row at the index given.
+func bufferedValue(buffer sql.Row, i int) interface{} {
+	row := buffer[0].([]sql.Row)[i]
+	return row[len(row)-2]
+}
+
+// sortPartitions sorts the rows buffered by bufferRow by the partitions of the window given and by its order, and calls
+// the function given with the rows of each partition in order, so that it can set the value of each row with
+// setBufferedValue. Rows that are equal in the order of the window keep the order they were buffered in. The rows are
+// then sorted back in the order they were buffered in, for EvalRow. A nil window, as for an empty OVER clause, has a
+// single partition with no order.
+func sortPartitions(ctx *sql.Context, window *sql.Window, buffer sql.Row, f func(partition []sql.Row) error) error {
+	rows := buffer[0].([]sql.Row)
+	if len(rows) == 0 {
+		return nil
+	}
+
+	var partitionBy []sql.Expression
+	var orderBy sql.SortFields
+	if window != nil {
+		partitionBy, orderBy = window.PartitionBy, window.OrderBy
+	}
+
+	sortFields := append(partitionsToSortFields(partitionBy), orderBy...)
+	if len(sortFields) > 0 {
+		sorter := &expression.Sorter{
+			SortFields: sortFields,
+			Rows:       rows,
+			Ctx:        ctx,
+		}
+		sort.Stable(sorter)
+		if sorter.LastError != nil {
+			return sorter.LastError
+		}
+	}
+
+	start := 0
+	for i := 1; i <= len(rows); i++ {
+		if i < len(rows) {
+			isNew, err := isNewPartition(ctx, partitionBy, rows[i-1], rows[i])
+			if err != nil {
+				return err
+			}
+			if !isNew {
+				continue
+			}
+		}
+
+		if err := f(rows[start:i]); err != nil {
+			return err
+		}
+		start = i
+	}
+
+	bufferedIdx := len(rows[0]) - 1
+	sort.SliceStable(rows, func(i, j int) bool {
+		return rows[i][bufferedIdx].(int) < rows[j][bufferedIdx].(int)
+	})
+	return nil
+}
+
+// setBufferedValue sets the value of the function for a row buffered by bufferRow.
+func setBufferedValue(row sql.Row, v interface{}) {
+	row[len(row)-2] = v
+}
+
+// rankPeers sets the rank of the rows of a partition sorted by sortPartitions. Rows that are equal in the order of the
+// window, including rows whose order values are NULL, are peers with the same rank, and all the rows are peers when the
+// window has no order. The first group of peers has rank 1, and each following group the rank returned by the function
+// given for the rank of the previous group and the position of the group's first row in the partition, starting at 1.
+func rankPeers(ctx *sql.Context, window *sql.Window, partition []sql.Row, next func(rank int64, pos int) int64) error {
+	var orderBy []sql.Expression
+	if window != nil {
+		orderBy = window.OrderBy.ToExpressions()
+	}
+
+	var rank int64
+	var last sql.Row
+	for i, row := range partition {
+		isNew, err := isNewOrderValue(ctx, orderBy, last, row)
+		if err != nil {
+			return err
+		}
+		if isNew {
+			rank = next(rank, i+1)
+		}
+		setBufferedValue(row, rank)
+		last = row
+	}
+	return nil
+}
+
 func partitionsToSortFields(partitionExprs []sql.Expression) sql.SortFields {
 	sfs := make(sql.SortFields, len(partitionExprs))
 	for i, expr := range partitionExprs {
@@ -60,23 +162,7 @@ func isNewPartition(ctx *sql.Context, partitionBy []sql.Expression, last sql.Row
 		return false, nil
 	}
 
-	lastExp, err := evalExprs(ctx, partitionBy, last)
-	if err != nil {
-		return false, err
-	}
-
-	thisExp, err := evalExprs(ctx, partitionBy, row)
-	if err != nil {
-		return false, err
-	}
-
-	for i := range lastExp {
-		if lastExp[i] != thisExp[i] {
-			return true, nil
-		}
-	}
-
-	return false, nil
+	return differentValues(ctx, partitionBy, last, row)
 }
 
 // isNewOrderValue compares the order by columns between two rows, returning true when the last row is null or
@@ -86,18 +172,28 @@ func isNewOrderValue(ctx *sql.Context, orderByExprs []sql.Expression, last sql.R
 		return true, nil
 	}
 
-	lastExp, err := evalExprs(ctx, orderByExprs, last)
+	return differentValues(ctx, orderByExprs, last, row)
+}
+
+// differentValues returns whether the expressions given evaluate to different values for the two rows given, comparing
+// them with their types. NULL values are equal to each other.
+func differentValues(ctx *sql.Context, exprs []sql.Expression, a, b sql.Row) (bool, error) {
+	aVals, err := evalExprs(ctx, exprs, a)
 	if err != nil {
 		return false, err
 	}
 
-	thisExp, err := evalExprs(ctx, orderByExprs, row)
+	bVals, err := evalExprs(ctx, exprs, b)
 	if err != nil {
 		return false, err
 	}
 
-	for i := range lastExp {
-		if lastExp[i] != thisExp[i] {
+	for i := range aVals {
+		cmp, err := exprs[i].Type().Compare(aVals[i], bVals[i])
+		if err != nil {
+			return false, err
+		}
+		if cmp != 0 {
 			return true, nil
 		}
 	}
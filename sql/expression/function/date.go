@@ -448,3 +448,137 @@ func (c CurrDate) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 func (c CurrDate) WithChildren(children ...sql.Expression) (sql.Expression, error) {
 	return NoArgFuncWithChildren(c, children)
 }
+
+// LastDay implements the LAST_DAY function, which returns the date of the last day of the month of a date.
+type LastDay struct {
+	*UnaryDatetimeFunc
+}
+
+var _ sql.FunctionExpression = (*LastDay)(nil)
+
+// NewLastDay creates a new LastDay expression.
+func NewLastDay(arg sql.Expression) sql.Expression {
+	return &LastDay{NewUnaryDatetimeFunc(arg, "LAST_DAY", sql.Date)}
+}
+
+// IsNullable implements the sql.Expression interface.
+func (l *LastDay) IsNullable() bool {
+	return true
+}
+
+// Eval implements the sql.Expression interface. Invalid dates evaluate to NULL.
+func (l *LastDay) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	val, err := l.Child.Eval(ctx, row)
+	if err != nil || val == nil {
+		return nil, err
+	}
+
+	date, err := sql.Datetime.Convert(val)
+	if err != nil {
+		return nil, nil
+	}
+
+	t := date.(time.Time)
+	if t.Equal(sql.Datetime.Zero().(time.Time)) {
+		return nil, nil
+	}
+
+	// The zeroth day of the next month is the last day of this one
+	return time.Date(t.Year(), t.Month()+1, 0, 0, 0, 0, 0, time.UTC), nil
+}
+
+// WithChildren implements the sql.Expression interface.
+func (l *LastDay) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(l, len(children), 1)
+	}
+	return NewLastDay(children[0]), nil
+}
+
+// maxMakeDateDays is an upper bound on the day of the year given to MAKEDATE that results in a valid date.
+const maxMakeDateDays = 366 * 10000
+
+// MakeDate implements the MAKEDATE function, which returns the date of the given day of the given year. Days past the
+// end of the year roll over into the following years.
+type MakeDate struct {
+	expression.BinaryExpression
+}
+
+var _ sql.FunctionExpression = (*MakeDate)(nil)
+
+// NewMakeDate creates a new MakeDate expression.
+func NewMakeDate(year, dayOfYear sql.Expression) sql.Expression {
+	return &MakeDate{expression.BinaryExpression{Left: year, Right: dayOfYear}}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (m *MakeDate) FunctionName() string {
+	return "makedate"
+}
+
+func (m *MakeDate) String() string {
+	return fmt.Sprintf("MAKEDATE(%s, %s)", m.Left, m.Right)
+}
+
+// Type implements the sql.Expression interface.
+func (m *MakeDate) Type() sql.Type { return sql.Date }
+
+// IsNullable implements the sql.Expression interface.
+func (m *MakeDate) IsNullable() bool { return true }
+
+// Eval implements the sql.Expression interface. A day of the year that isn't positive, or a date past the maximum
+// date, evaluates to NULL.
+func (m *MakeDate) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	year, err := evalInt64Arg(ctx, row, m.Left)
+	if err != nil || year == nil {
+		return nil, err
+	}
+
+	day, err := evalInt64Arg(ctx, row, m.Right)
+	if err != nil || day == nil {
+		return nil, err
+	}
+
+	y, d := *year, *day
+	if y < 0 || y > 9999 || d <= 0 || d > maxMakeDateDays {
+		return nil, nil
+	}
+
+	// Two digit years are interpreted like they are in dates
+	if y < 70 {
+		y += 2000
+	} else if y < 100 {
+		y += 1900
+	}
+
+	t := time.Date(int(y), time.January, 1, 0, 0, 0, 0, time.UTC).AddDate(0, 0, int(d-1))
+	if t.Year() > 9999 {
+		return nil, nil
+	}
+	return t, nil
+}
+
+// WithChildren implements the sql.Expression interface.
+func (m *MakeDate) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 2 {
+		return nil, sql.ErrInvalidChildrenNumber.New(m, len(children), 2)
+	}
+	return NewMakeDate(children[0], children[1]), nil
+}
+
+// evalInt64Arg evaluates the given function argument as an integer. Returns nil if the argument is NULL or isn't a
+// number.
+func evalInt64Arg(ctx *sql.Context, row sql.Row, arg sql.Expression) (*int64, error) {
+	val, err := arg.Eval(ctx, row)
+	if err != nil || val == nil {
+		return nil, err
+	}
+
+	val, err = sql.Int64.Convert(val)
+	if err != nil {
+		return nil, nil
+	}
+
+	i := val.(int64)
+	return &i, nil
+}
@@ -158,3 +158,69 @@ func TestFromUnixtime(t *testing.T) {
 	_, err = NewUnixTimestamp(expression.NewLiteral(1447430881, sql.Int64))
 	require.NoError(err)
 }
+
+func TestLastDay(t *testing.T) {
+	ctx := sql.NewEmptyContext()
+	f := NewLastDay(expression.NewGetField(0, sql.LongText, "foo", true))
+
+	testCases := []struct {
+		name     string
+		row      sql.Row
+		expected interface{}
+	}{
+		{"null date", sql.NewRow(nil), nil},
+		{"invalid date", sql.NewRow("not a date"), nil},
+		{"zero date", sql.NewRow("0000-00-00"), nil},
+		{"leap year february", sql.NewRow("2020-02-10"), time.Date(2020, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"century leap year february", sql.NewRow("2000-02-01"), time.Date(2000, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"non-leap year february", sql.NewRow("2021-02-10"), time.Date(2021, 2, 28, 0, 0, 0, 0, time.UTC)},
+		{"non-leap century february", sql.NewRow("1900-02-10"), time.Date(1900, 2, 28, 0, 0, 0, 0, time.UTC)},
+		{"datetime at end of year", sql.NewRow("2021-12-31 23:59:59"), time.Date(2021, 12, 31, 0, 0, 0, 0, time.UTC)},
+		{"date as time", sql.NewRow(time.Date(2021, 4, 1, 10, 0, 0, 0, time.UTC)), time.Date(2021, 4, 30, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			val, err := f.Eval(ctx, tt.row)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, val)
+		})
+	}
+}
+
+func TestMakeDate(t *testing.T) {
+	ctx := sql.NewEmptyContext()
+	f := NewMakeDate(
+		expression.NewGetField(0, sql.Int64, "year", true),
+		expression.NewGetField(1, sql.Int64, "day", true),
+	)
+
+	testCases := []struct {
+		name     string
+		row      sql.Row
+		expected interface{}
+	}{
+		{"null year", sql.NewRow(nil, 1), nil},
+		{"null day", sql.NewRow(2021, nil), nil},
+		{"zero day", sql.NewRow(2021, 0), nil},
+		{"negative day", sql.NewRow(2021, -1), nil},
+		{"first day", sql.NewRow(2021, 1), time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"leap day", sql.NewRow(2020, 60), time.Date(2020, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"last day of leap year", sql.NewRow(2020, 366), time.Date(2020, 12, 31, 0, 0, 0, 0, time.UTC)},
+		{"rolls over into next year", sql.NewRow(2021, 366), time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"rolls over several years", sql.NewRow(2021, 1000), time.Date(2023, 9, 27, 0, 0, 0, 0, time.UTC)},
+		{"two digit year", sql.NewRow(21, 32), time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{"two digit year in last century", sql.NewRow(99, 1), time.Date(1999, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"string arguments", sql.NewRow("2021", "10"), time.Date(2021, 1, 10, 0, 0, 0, 0, time.UTC)},
+		{"past maximum date", sql.NewRow(9999, 366), nil},
+		{"invalid year", sql.NewRow(10000, 1), nil},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			val, err := f.Eval(ctx, tt.row)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, val)
+		})
+	}
+}
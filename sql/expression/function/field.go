@@ -0,0 +1,100 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+// Field returns the 1-based index of its first argument among the rest of its arguments, or 0 if it isn't found.
+// Arguments are compared as they would be with the = operator, so a NULL argument never matches.
+type Field struct {
+	args []sql.Expression
+}
+
+var _ sql.FunctionExpression = (*Field)(nil)
+
+// NewField creates a new Field expression.
+func NewField(args ...sql.Expression) (sql.Expression, error) {
+	if len(args) < 2 {
+		return nil, sql.ErrInvalidArgumentNumber.New("FIELD", "2 or more", len(args))
+	}
+
+	return &Field{args}, nil
+}
+
+// FunctionName implements sql.FunctionExpression
+func (f *Field) FunctionName() string {
+	return "field"
+}
+
+// Type implements the Expression interface.
+func (f *Field) Type() sql.Type { return sql.Int64 }
+
+// IsNullable implements the Expression interface.
+func (f *Field) IsNullable() bool { return false }
+
+func (f *Field) String() string {
+	var args = make([]string, len(f.args))
+	for i, arg := range f.args {
+		args[i] = arg.String()
+	}
+	return fmt.Sprintf("field(%s)", strings.Join(args, ", "))
+}
+
+// WithChildren implements the Expression interface.
+func (*Field) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	return NewField(children...)
+}
+
+// Resolved implements the Expression interface.
+func (f *Field) Resolved() bool {
+	for _, arg := range f.args {
+		if !arg.Resolved() {
+			return false
+		}
+	}
+	return true
+}
+
+// Children implements the Expression interface.
+func (f *Field) Children() []sql.Expression { return f.args }
+
+// Eval implements the Expression interface.
+func (f *Field) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	needle, err := f.args[0].Eval(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+	if needle == nil {
+		return int64(0), nil
+	}
+
+	for i, arg := range f.args[1:] {
+		val, err := expression.NewEquals(f.args[0], arg).Eval(ctx, row)
+		if err != nil {
+			return nil, err
+		}
+		if b, ok := val.(bool); ok && b {
+			return int64(i + 1), nil
+		}
+	}
+
+	return int64(0), nil
+}
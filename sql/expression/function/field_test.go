@@ -0,0 +1,49 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+func TestField(t *testing.T) {
+	testCases := []struct {
+		name     string
+		args     []sql.Expression
+		expected interface{}
+	}{
+		{"first", []sql.Expression{lit("a", sql.LongText), lit("a", sql.LongText), lit("b", sql.LongText)}, int64(1)},
+		{"later", []sql.Expression{lit("b", sql.LongText), lit("a", sql.LongText), lit("b", sql.LongText), lit("b", sql.LongText)}, int64(2)},
+		{"not found", []sql.Expression{lit("c", sql.LongText), lit("a", sql.LongText), lit("b", sql.LongText)}, int64(0)},
+		{"null needle", []sql.Expression{lit(nil, sql.Null), lit("a", sql.LongText), lit(nil, sql.Null)}, int64(0)},
+		{"null argument", []sql.Expression{lit("a", sql.LongText), lit(nil, sql.Null), lit("a", sql.LongText)}, int64(2)},
+		{"numbers", []sql.Expression{lit(int64(2), sql.Int64), lit("1", sql.LongText), lit(2.0, sql.Float64)}, int64(2)},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := NewField(tt.args...)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, eval(t, f, nil))
+		})
+	}
+
+	_, err := NewField(lit("a", sql.LongText))
+	require.Error(t, err)
+}
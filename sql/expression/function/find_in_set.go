@@ -0,0 +1,105 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+// FindInSet returns the 1-based position of a string in a list of strings separated by commas, or 0 if it isn't
+// found. Strings are compared with the collation of the string searched for.
+type FindInSet struct {
+	expression.BinaryExpression
+}
+
+var _ sql.FunctionExpression = (*FindInSet)(nil)
+
+// NewFindInSet creates a new FindInSet expression.
+func NewFindInSet(str, strList sql.Expression) sql.Expression {
+	return &FindInSet{
+		expression.BinaryExpression{
+			Left:  str,
+			Right: strList,
+		},
+	}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (f *FindInSet) FunctionName() string {
+	return "find_in_set"
+}
+
+// Type implements the Expression interface.
+func (f *FindInSet) Type() sql.Type { return sql.Int64 }
+
+func (f *FindInSet) String() string {
+	return fmt.Sprintf("find_in_set(%s, %s)", f.Left, f.Right)
+}
+
+// WithChildren implements the Expression interface.
+func (f *FindInSet) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 2 {
+		return nil, sql.ErrInvalidChildrenNumber.New(f, len(children), 2)
+	}
+	return NewFindInSet(children[0], children[1]), nil
+}
+
+// Eval implements the Expression interface.
+func (f *FindInSet) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	str, err := f.Left.Eval(ctx, row)
+	if err != nil || str == nil {
+		return nil, err
+	}
+
+	strList, err := f.Right.Eval(ctx, row)
+	if err != nil || strList == nil {
+		return nil, err
+	}
+
+	str, err = sql.LongText.Convert(str)
+	if err != nil {
+		return nil, err
+	}
+
+	strList, err = sql.LongText.Convert(strList)
+	if err != nil {
+		return nil, err
+	}
+
+	// A string containing a comma can never be one of the elements of the list
+	if strList.(string) == "" || strings.Contains(str.(string), ",") {
+		return int64(0), nil
+	}
+
+	collation := sql.Collation_Default
+	if st, ok := f.Left.Type().(sql.StringType); ok {
+		collation = st.Collation()
+	} else if st, ok := f.Right.Type().(sql.StringType); ok {
+		collation = st.Collation()
+	}
+
+	key := collation.SortKey(str.(string))
+	for i, elem := range strings.Split(strList.(string), ",") {
+		if collation.SortKey(elem) == key {
+			return int64(i + 1), nil
+		}
+	}
+
+	return int64(0), nil
+}
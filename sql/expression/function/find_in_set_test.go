@@ -0,0 +1,58 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"testing"
+
+	"github.com/dolthub/vitess/go/sqltypes"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+func TestFindInSet(t *testing.T) {
+	testCases := []struct {
+		str, strList interface{}
+		expected     interface{}
+	}{
+		{"b", "a,b,c", int64(2)},
+		{"d", "a,b,c", int64(0)},
+		{"a", "", int64(0)},
+		{"", "a,,c", int64(2)},
+		{"a,b", "a,b", int64(0)},
+		{"B", "a,b", int64(0)},
+		{nil, "a,b", nil},
+		{"a", nil, nil},
+	}
+
+	f := NewFindInSet(
+		expression.NewGetField(0, sql.LongText, "str", true),
+		expression.NewGetField(1, sql.LongText, "strlist", true),
+	)
+	require.Equal(t, sql.Int64, f.Type())
+
+	for _, tt := range testCases {
+		require.Equal(t, tt.expected, eval(t, f, sql.NewRow(tt.str, tt.strList)))
+	}
+
+	ci := sql.MustCreateString(sqltypes.VarChar, 10, sql.Collation_utf8mb4_general_ci)
+	f = NewFindInSet(
+		expression.NewGetField(0, ci, "str", true),
+		expression.NewGetField(1, sql.LongText, "strlist", true),
+	)
+	require.Equal(t, int64(2), eval(t, f, sql.NewRow("B", "a,b")))
+}
@@ -16,9 +16,8 @@ package function
 
 import (
 	"fmt"
-	"strconv"
+	"math"
 	"strings"
-	"time"
 
 	"gopkg.in/src-d/go-errors.v1"
 
@@ -28,113 +27,54 @@ import (
 var ErrUintOverflow = errors.NewKind(
 	"Unsigned integer too big to fit on signed integer")
 
-// compEval is used to implement Greatest/Least Eval() using a comparison function
+// compEval is used to implement Greatest/Least Eval(). It converts every argument to the return type and returns the
+// one that compares to all the others with the result given, as the Compare of the type returns it.
 func compEval(
 	returnType sql.Type,
 	args []sql.Expression,
 	ctx *sql.Context,
 	row sql.Row,
-	cmp compareFn,
+	cmp int,
 ) (interface{}, error) {
 
 	if returnType == sql.Null {
 		return nil, nil
 	}
 
-	var selectedNum float64
-	var selectedString string
-	var selectedTime time.Time
-
-	for i, arg := range args {
+	var selected interface{}
+	for _, arg := range args {
 		val, err := arg.Eval(ctx, row)
 		if err != nil {
 			return nil, err
 		}
-
-		switch t := val.(type) {
-		case int, int8, int16, int32, int64, uint,
-			uint8, uint16, uint32, uint64:
-			switch x := t.(type) {
-			case int:
-				t = int64(x)
-			case int8:
-				t = int64(x)
-			case int16:
-				t = int64(x)
-			case int32:
-				t = int64(x)
-			case uint:
-				i := int64(x)
-				if i < 0 {
-					return nil, ErrUintOverflow.New()
-				}
-				t = i
-			case uint64:
-				i := int64(x)
-				if i < 0 {
-					return nil, ErrUintOverflow.New()
-				}
-				t = i
-			case uint8:
-				t = int64(x)
-			case uint16:
-				t = int64(x)
-			case uint32:
-				t = int64(x)
-			}
-			ival := t.(int64)
-			if i == 0 || cmp(ival, int64(selectedNum)) {
-				selectedNum = float64(ival)
-			}
-		case float32, float64:
-			if x, ok := t.(float32); ok {
-				t = float64(x)
-			}
-
-			fval := t.(float64)
-			if i == 0 || cmp(fval, float64(selectedNum)) {
-				selectedNum = fval
-			}
-
-		case string:
-			if sql.IsTextOnly(returnType) && (i == 0 || cmp(t, selectedString)) {
-				selectedString = t
-			}
-
-			fval, err := strconv.ParseFloat(t, 64)
-			if err != nil {
-				// MySQL just ignores non numerically convertible string arguments
-				// when mixed with numeric ones
-				continue
-			}
-
-			if i == 0 || cmp(fval, selectedNum) {
-				selectedNum = fval
-			}
-		case time.Time:
-			// Since we deviate from MySQL with int -> time handling, we only set the selectedTime variable
-			if i == 0 || cmp(t, selectedTime) {
-				selectedTime = t
-			}
-		case nil:
+		if val == nil {
 			return nil, nil
-		default:
-			return nil, ErrUnsupportedType.New(t)
 		}
 
-	}
+		if returnType == sql.Int64 {
+			if u, ok := val.(uint64); ok && u > math.MaxInt64 {
+				return nil, ErrUintOverflow.New()
+			}
+		}
+		val, err = returnType.Convert(val)
+		if err != nil {
+			return nil, err
+		}
 
-	switch returnType {
-	case sql.Int64:
-		return int64(selectedNum), nil
-	case sql.LongText:
-		return selectedString, nil
-	case sql.Datetime:
-		return selectedTime, nil
+		if selected == nil {
+			selected = val
+			continue
+		}
+		res, err := returnType.Compare(val, selected)
+		if err != nil {
+			return nil, err
+		}
+		if res == cmp {
+			selected = val
+		}
 	}
 
-	// sql.Float64
-	return float64(selectedNum), nil
+	return selected, nil
 }
 
 // compRetType is used to determine the type from args based on the rules described for
@@ -144,9 +84,11 @@ func compRetType(args ...sql.Expression) (sql.Type, error) {
 		return nil, sql.ErrInvalidArgumentNumber.New("LEAST", "1 or more", 0)
 	}
 
-	allString := true
+	allNumber := true
 	allInt := true
+	anyFloat := false
 	allDatetime := true
+	var scale uint8
 
 	for _, arg := range args {
 		if !arg.Resolved() {
@@ -156,17 +98,22 @@ func compRetType(args ...sql.Expression) (sql.Type, error) {
 		if sql.IsTuple(argType) {
 			return nil, sql.ErrInvalidType.New("tuple")
 		} else if sql.IsNumber(argType) {
-			allString = false
 			allDatetime = false
 			if sql.IsFloat(argType) {
-				allString = false
 				allInt = false
+				anyFloat = true
+			} else if sql.IsDecimal(argType) {
+				allInt = false
+				if s := argType.(sql.DecimalType).Scale(); s > scale {
+					scale = s
+				}
 			}
 		} else if sql.IsText(argType) {
+			allNumber = false
 			allInt = false
 			allDatetime = false
 		} else if sql.IsTime(argType) {
-			allString = false
+			allNumber = false
 			allInt = false
 		} else if argType == sql.Null {
 			// When a Null is present the return will always be Null
@@ -176,22 +123,23 @@ func compRetType(args ...sql.Expression) (sql.Type, error) {
 		}
 	}
 
-	if allString {
-		return sql.LongText, nil
-	} else if allInt {
+	if allInt {
 		return sql.Int64, nil
+	} else if allNumber && anyFloat {
+		return sql.Float64, nil
+	} else if allNumber {
+		return sql.MustCreateDecimalType(sql.DecimalTypeMaxPrecision, scale), nil
 	} else if allDatetime {
 		return sql.Datetime, nil
 	} else {
-		return sql.Float64, nil
+		return sql.LongText, nil
 	}
 }
 
-// Greatest returns the argument with the greatest numerical or string value. It allows for
-// numeric (ints and floats) and string arguments and will return the used type
-// when all arguments are of the same type or floats if there are numerically
-// convertible strings or integers mixed with floats. When ints or floats
-// are mixed with non numerically convertible strings, those are ignored.
+// Greatest returns the argument with the greatest value, or NULL if any argument is NULL. Like in MySQL, the arguments
+// are compared as integers when they're all integers, as floats when they're all numbers and one of them is a float,
+// as decimals when they're all numbers otherwise, as datetimes when they're all datetimes, and as strings in every
+// other case, such as when numbers are mixed with strings. The result has the type the arguments were compared as.
 type Greatest struct {
 	Args       []sql.Expression
 	returnType sql.Type
@@ -260,46 +208,13 @@ func (f *Greatest) Resolved() bool {
 // Children implements the Expression interface.
 func (f *Greatest) Children() []sql.Expression { return f.Args }
 
-type compareFn func(interface{}, interface{}) bool
-
-func greaterThan(a, b interface{}) bool {
-	switch i := a.(type) {
-	case int64:
-		return i > b.(int64)
-	case float64:
-		return i > b.(float64)
-	case string:
-		return i > b.(string)
-	case time.Time:
-		return i.After(b.(time.Time))
-	}
-	panic("Implementation error on greaterThan")
-}
-
-func lessThan(a, b interface{}) bool {
-	switch i := a.(type) {
-	case int64:
-		return i < b.(int64)
-	case float64:
-		return i < b.(float64)
-	case string:
-		return i < b.(string)
-	case time.Time:
-		return i.Before(b.(time.Time))
-	}
-	panic("Implementation error on lessThan")
-}
-
 // Eval implements the Expression interface.
 func (f *Greatest) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
-	return compEval(f.returnType, f.Args, ctx, row, greaterThan)
+	return compEval(f.returnType, f.Args, ctx, row, 1)
 }
 
-// Least returns the argument with the least numerical or string value. It allows for
-// numeric (ints anf floats) and string arguments and will return the used type
-// when all arguments are of the same type or floats if there are numerically
-// convertible strings or integers mixed with floats. When ints or floats
-// are mixed with non numerically convertible strings, those are ignored.
+// Least returns the argument with the least value, or NULL if any argument is NULL. The arguments are compared the
+// same way as the ones of Greatest.
 type Least struct {
 	Args       []sql.Expression
 	returnType sql.Type
@@ -367,5 +282,5 @@ func (f *Least) Children() []sql.Expression { return f.Args }
 
 // Eval implements the Expression interface.
 func (f *Least) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
-	return compEval(f.returnType, f.Args, ctx, row, lessThan)
+	return compEval(f.returnType, f.Args, ctx, row, -1)
 }
@@ -55,17 +55,44 @@ func TestGreatest(t *testing.T) {
 				expression.NewLiteral(int64(5), sql.Int64),
 				expression.NewLiteral(int64(1), sql.Int64),
 			},
-			float64(9),
+			"9",
 		},
 		{
-			"unconvertible string mixed ignored",
+			"string mixed compared as strings",
+			[]sql.Expression{
+				expression.NewLiteral(string("10"), sql.LongText),
+				expression.NewLiteral(int64(5), sql.Int64),
+				expression.NewLiteral(int64(1), sql.Int64),
+			},
+			"5",
+		},
+		{
+			"unconvertible string mixed",
 			[]sql.Expression{
 				expression.NewLiteral(string("10.5"), sql.LongText),
 				expression.NewLiteral(string("foobar"), sql.Int64),
 				expression.NewLiteral(int64(5), sql.Int64),
 				expression.NewLiteral(int64(1), sql.Int64),
 			},
-			float64(10.5),
+			"foobar",
+		},
+		{
+			"decimal mixed",
+			[]sql.Expression{
+				expression.NewLiteral("2.75", sql.MustCreateDecimalType(10, 2)),
+				expression.NewLiteral(int64(2), sql.Int64),
+				expression.NewLiteral("2.5", sql.MustCreateDecimalType(10, 1)),
+			},
+			"2.75",
+		},
+		{
+			"null after the other arguments",
+			[]sql.Expression{
+				expression.NewLiteral(int64(5), sql.Int64),
+				expression.NewLiteral(int64(1), sql.Int64),
+				expression.NewConvert(expression.NewLiteral(nil, sql.Null), expression.ConvertToSigned),
+			},
+			nil,
 		},
 		{
 			"float mixed",
@@ -188,17 +215,17 @@ func TestLeast(t *testing.T) {
 				expression.NewLiteral(int64(5), sql.Int64),
 				expression.NewLiteral(int64(1), sql.Int64),
 			},
-			float64(1),
+			"1",
 		},
 		{
-			"unconvertible string mixed ignored",
+			"unconvertible string mixed",
 			[]sql.Expression{
 				expression.NewLiteral(string("10.5"), sql.LongText),
 				expression.NewLiteral(string("foobar"), sql.Int64),
 				expression.NewLiteral(int64(5), sql.Int64),
 				expression.NewLiteral(int64(1), sql.Int64),
 			},
-			float64(1),
+			"1",
 		},
 		{
 			"float mixed",
@@ -0,0 +1,199 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/src-d/go-errors.v1"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// ErrOneOrAllArgument is returned when the one_or_all argument of a JSON search function is not 'one' or 'all'.
+var ErrOneOrAllArgument = errors.NewKind("The oneOrAll argument to %s may take these values: 'one' or 'all'.")
+
+// JSON_CONTAINS_PATH(json_doc, one_or_all, path[, path] ...)
+//
+// JSONContainsPath Returns 0 or 1 to indicate whether a JSON document contains data at a given path or paths. Returns
+// NULL if any argument is NULL. An error occurs if the json_doc argument is not a valid JSON document, any path
+// argument is not a valid path expression, or one_or_all is not 'one' or 'all'. To check for a specific value at a
+// path, use JSON_CONTAINS() instead.
+//
+// The return value is 0 if no specified path exists within the document. Otherwise, the return value depends on the
+// one_or_all argument:
+//   - 'one': 1 if at least one path exists within the document, 0 otherwise.
+//   - 'all': 1 if all paths exist within the document, 0 otherwise.
+//
+// https://dev.mysql.com/doc/refman/8.0/en/json-search-functions.html#function_json-contains-path
+type JSONContainsPath struct {
+	JSON     sql.Expression
+	OneOrAll sql.Expression
+	Paths    []sql.Expression
+}
+
+var _ sql.FunctionExpression = (*JSONContainsPath)(nil)
+
+// NewJSONContainsPath creates a new JSONContainsPath function.
+func NewJSONContainsPath(args ...sql.Expression) (sql.Expression, error) {
+	if len(args) < 3 {
+		return nil, sql.ErrInvalidArgumentNumber.New("JSON_CONTAINS_PATH", "3 or more", len(args))
+	}
+
+	return &JSONContainsPath{args[0], args[1], args[2:]}, nil
+}
+
+// FunctionName implements sql.FunctionExpression
+func (j *JSONContainsPath) FunctionName() string {
+	return "json_contains_path"
+}
+
+// Resolved implements the sql.Expression interface.
+func (j *JSONContainsPath) Resolved() bool {
+	for _, child := range j.Children() {
+		if !child.Resolved() {
+			return false
+		}
+	}
+	return true
+}
+
+func (j *JSONContainsPath) String() string {
+	children := j.Children()
+	var parts = make([]string, len(children))
+	for i, c := range children {
+		parts[i] = c.String()
+	}
+	return fmt.Sprintf("JSON_CONTAINS_PATH(%s)", strings.Join(parts, ", "))
+}
+
+// Type implements the sql.Expression interface.
+func (j *JSONContainsPath) Type() sql.Type {
+	return sql.Boolean
+}
+
+// IsNullable implements the sql.Expression interface.
+func (j *JSONContainsPath) IsNullable() bool {
+	for _, child := range j.Children() {
+		if child.IsNullable() {
+			return true
+		}
+	}
+	return false
+}
+
+// Eval implements the sql.Expression interface.
+func (j *JSONContainsPath) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	span, ctx := ctx.Span("function.JSONContainsPath")
+	defer span.Finish()
+
+	doc, err := getJSONDocument(ctx, row, j.JSON)
+	if err != nil || doc == nil {
+		return nil, err
+	}
+
+	all, err := evalOneOrAll(ctx, row, j.OneOrAll, j.FunctionName())
+	if err != nil || all == nil {
+		return nil, err
+	}
+
+	found := false
+	for _, p := range j.Paths {
+		path, err := p.Eval(ctx, row)
+		if err != nil || path == nil {
+			return nil, err
+		}
+
+		path, err = sql.LongText.Convert(path)
+		if err != nil {
+			return nil, err
+		}
+
+		parsed, err := sql.ParseJSONPath(path.(string))
+		if err != nil {
+			return nil, err
+		}
+
+		exists := parsed.Lookup(doc.Val) != nil
+
+		if exists && !*all {
+			return true, nil
+		}
+		if !exists && *all {
+			return false, nil
+		}
+		found = found || exists
+	}
+
+	return found, nil
+}
+
+// Children implements the sql.Expression interface.
+func (j *JSONContainsPath) Children() []sql.Expression {
+	return append([]sql.Expression{j.JSON, j.OneOrAll}, j.Paths...)
+}
+
+// WithChildren implements the sql.Expression interface.
+func (j *JSONContainsPath) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != len(j.Children()) {
+		return nil, sql.ErrInvalidChildrenNumber.New(j, len(children), len(j.Children()))
+	}
+	return NewJSONContainsPath(children...)
+}
+
+// getJSONDocument evaluates the given expression as a JSON document, returning nil if it evaluates to NULL.
+func getJSONDocument(ctx *sql.Context, row sql.Row, expr sql.Expression) (*sql.JSONDocument, error) {
+	js, err := expr.Eval(ctx, row)
+	if err != nil || js == nil {
+		return nil, err
+	}
+
+	converted, err := sql.JSON.Convert(js)
+	if err != nil {
+		return nil, sql.ErrInvalidJSONText.New(js)
+	}
+
+	doc, err := converted.(sql.JSONValue).Unmarshall(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &doc, nil
+}
+
+// evalOneOrAll evaluates the one_or_all argument of a JSON search function, returning whether 'all' was given, or nil
+// if the argument evaluates to NULL.
+func evalOneOrAll(ctx *sql.Context, row sql.Row, expr sql.Expression, funcName string) (*bool, error) {
+	v, err := expr.Eval(ctx, row)
+	if err != nil || v == nil {
+		return nil, err
+	}
+
+	v, err = sql.LongText.Convert(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var all bool
+	switch strings.ToLower(v.(string)) {
+	case "one":
+		all = false
+	case "all":
+		all = true
+	default:
+		return nil, ErrOneOrAllArgument.New(funcName)
+	}
+	return &all, nil
+}
@@ -0,0 +1,85 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+func TestJSONContainsPath(t *testing.T) {
+	_, err := NewJSONContainsPath(
+		expression.NewGetField(0, sql.JSON, "arg1", false),
+		expression.NewGetField(1, sql.LongText, "arg2", false),
+	)
+	require.Error(t, err)
+
+	f, err := NewJSONContainsPath(
+		expression.NewGetField(0, sql.JSON, "arg1", false),
+		expression.NewGetField(1, sql.LongText, "arg2", false),
+		expression.NewGetField(2, sql.LongText, "arg3", false),
+	)
+	require.NoError(t, err)
+
+	f2, err := NewJSONContainsPath(
+		expression.NewGetField(0, sql.JSON, "arg1", false),
+		expression.NewGetField(1, sql.LongText, "arg2", false),
+		expression.NewGetField(2, sql.LongText, "arg3", false),
+		expression.NewGetField(3, sql.LongText, "arg4", false),
+	)
+	require.NoError(t, err)
+
+	doc := `{"a": 1, "b": 2, "c": {"d": 4}, "e": null}`
+
+	testCases := []struct {
+		f        sql.Expression
+		row      sql.Row
+		expected interface{}
+		err      bool
+	}{
+		{f, sql.Row{doc, "one", "$.a"}, true, false},
+		{f, sql.Row{doc, "all", "$.a"}, true, false},
+		{f, sql.Row{doc, "one", "$.c.d"}, true, false},
+		{f, sql.Row{doc, "one", "$.e"}, true, false},
+		{f, sql.Row{doc, "one", "$.x"}, false, false},
+		{f, sql.Row{doc, "ONE", "$.a"}, true, false},
+		{f2, sql.Row{doc, "one", "$.a", "$.x"}, true, false},
+		{f2, sql.Row{doc, "all", "$.a", "$.x"}, false, false},
+		{f2, sql.Row{doc, "all", "$.a", "$.c.d"}, true, false},
+		{f, sql.Row{nil, "one", "$.a"}, nil, false},
+		{f, sql.Row{doc, nil, "$.a"}, nil, false},
+		{f, sql.Row{doc, "one", nil}, nil, false},
+		{f, sql.Row{doc, "some", "$.a"}, nil, true},
+		{f, sql.Row{doc, "one", "a"}, nil, true},
+		{f, sql.Row{"[1,2", "one", "$"}, nil, true},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.f.String(), func(t *testing.T) {
+			require := require.New(t)
+			result, err := tt.f.Eval(sql.NewEmptyContext(), tt.row)
+			if tt.err {
+				require.Error(err)
+			} else {
+				require.NoError(err)
+			}
+			require.Equal(tt.expected, result)
+		})
+	}
+}
@@ -15,7 +15,6 @@
 package function
 
 import (
-	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -84,12 +83,12 @@ func TestJSONContains(t *testing.T) {
 		expected interface{}
 		err      error
 	}{
-		{f, sql.Row{json, json, "FOO"}, nil, errors.New("should start with '$'")},
+		{f, sql.Row{json, json, "FOO"}, nil, sql.ErrInvalidJSONPath.New(1)},
 		{f, sql.Row{nil, json, "$.b.c"}, nil, nil},
 		{f, sql.Row{json, nil, "$.b.c"}, nil, nil},
 		{f, sql.Row{json, json, "$.foo"}, nil, nil},
 		{f, sql.Row{json, `"foo"`, "$.b.c"}, true, nil},
-		{f, sql.Row{json, 1, "$.e[0][*]"}, false, nil},
+		{f, sql.Row{json, 1, "$.e[0][*]"}, true, nil},
 		{f, sql.Row{json, []float64{1, 2}, "$.e[0][*]"}, true, nil},
 		{f, sql.Row{json, json, "$"}, true, nil}, // reflexivity
 		{f, sql.Row{json, json["e"], "$.e"}, true, nil},
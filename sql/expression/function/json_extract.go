@@ -70,7 +70,7 @@ func (j *JSONExtract) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 	}
 
 	js, err = j.Type().Convert(js)
-	if err != nil {
+	if err != nil || js == nil {
 		return nil, err
 	}
 
@@ -82,10 +82,12 @@ func (j *JSONExtract) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 		}
 	}
 
-	var results = make([]sql.JSONValue, len(j.Paths))
-	for i, p := range j.Paths {
+	// The values matched by every path are returned in an array when there's more than one path, with the ones
+	// matched by a wildcard flattened into it. Paths that match no value are skipped.
+	var results []sql.JSONValue
+	for _, p := range j.Paths {
 		path, err := p.Eval(ctx, row)
-		if err != nil {
+		if err != nil || path == nil {
 			return nil, err
 		}
 
@@ -94,13 +96,41 @@ func (j *JSONExtract) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 			return nil, err
 		}
 
-		results[i], err = searchable.Extract(ctx, path.(string))
+		parsed, err := sql.ParseJSONPath(path.(string))
 		if err != nil {
 			return nil, err
 		}
+
+		// Documents are looked up with the path parsed above, rather than parsing it again
+		var result sql.JSONValue
+		if doc, ok := searchable.(sql.JSONDocument); ok {
+			result = doc.ExtractPath(parsed)
+		} else if result, err = searchable.Extract(ctx, path.(string)); err != nil {
+			return nil, err
+		}
+		if result == nil {
+			continue
+		}
+
+		if len(j.Paths) > 1 && parsed.HasWildcard() {
+			doc, err := result.Unmarshall(ctx)
+			if err != nil {
+				return nil, err
+			}
+			if vals, ok := doc.Val.([]interface{}); ok {
+				for _, val := range vals {
+					results = append(results, sql.JSONDocument{Val: val})
+				}
+				continue
+			}
+		}
+		results = append(results, result)
 	}
 
-	if len(results) == 1 {
+	if len(results) == 0 {
+		return nil, nil
+	}
+	if len(j.Paths) == 1 {
 		return results[0], nil
 	}
 
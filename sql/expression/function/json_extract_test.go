@@ -15,7 +15,6 @@
 package function
 
 import (
-	"errors"
 	"strings"
 	"testing"
 
@@ -72,16 +71,30 @@ func TestJSONExtract(t *testing.T) {
 		expected interface{}
 		err      error
 	}{
-		{f2, sql.Row{json, "FOO"}, nil, errors.New("should start with '$'")},
-		{f2, sql.Row{nil, "$.b.c"}, sql.JSONDocument{Val: nil}, nil},
-		{f2, sql.Row{json, "$.foo"}, sql.JSONDocument{Val: nil}, nil},
+		{f2, sql.Row{json, "FOO"}, nil, sql.ErrInvalidJSONPath.New(1)},
+		{f2, sql.Row{json, "$.b["}, nil, sql.ErrInvalidJSONPath.New(4)},
+		{f2, sql.Row{nil, "$.b.c"}, nil, nil},
+		{f2, sql.Row{json, "$.foo"}, nil, nil},
+		{f2, sql.Row{json, "$.a[4]"}, nil, nil},
+		{f3, sql.Row{json, "$.foo", "$.bar"}, nil, nil},
+		{f3, sql.Row{json, "$.foo", "$.b.c"}, sql.JSONDocument{Val: []interface{}{"foo"}}, nil},
 		{f2, sql.Row{json, "$.b.c"}, sql.JSONDocument{Val: "foo"}, nil},
 		{f3, sql.Row{json, "$.b.c", "$.b.d"}, sql.JSONDocument{Val: []interface{}{"foo", true}}, nil},
 		{f4, sql.Row{json, "$.b.c", "$.b.d", "$.e[0][*]"}, sql.JSONDocument{Val: []interface{}{
 			"foo",
 			true,
-			[]interface{}{1., 2.},
+			1.,
+			2.,
 		}}, nil},
+		{f2, sql.Row{json, "$.a[1]"}, sql.JSONDocument{Val: 2.}, nil},
+		{f2, sql.Row{json, "$.a[last]"}, sql.JSONDocument{Val: 4.}, nil},
+		{f2, sql.Row{json, "$.a[last - 1]"}, sql.JSONDocument{Val: 3.}, nil},
+		{f2, sql.Row{json, "$.a[1 to 2]"}, sql.JSONDocument{Val: []interface{}{2., 3.}}, nil},
+		{f2, sql.Row{json, "$.e[*][0]"}, sql.JSONDocument{Val: []interface{}{1., 3.}}, nil},
+		{f2, sql.Row{json, "$.e[1][1]"}, sql.JSONDocument{Val: 4.}, nil},
+		{f2, sql.Row{json, "$.b.*"}, sql.JSONDocument{Val: []interface{}{"foo", true}}, nil},
+		{f2, sql.Row{json, "$.b.c[0]"}, sql.JSONDocument{Val: "foo"}, nil},
+		{f2, sql.Row{json, "$**.d"}, sql.JSONDocument{Val: []interface{}{true}}, nil},
 
 		{f2, sql.Row{json, `$.f."key.with.dots"`}, sql.JSONDocument{Val: 0}, nil},
 		{f2, sql.Row{json, `$.f."key with spaces"`}, sql.JSONDocument{Val: 1}, nil},
@@ -108,7 +121,7 @@ func TestJSONExtract(t *testing.T) {
 			if tt.err == nil {
 				require.NoError(err)
 			} else {
-				require.Error(tt.err, err)
+				require.EqualError(err, tt.err.Error())
 			}
 
 			require.Equal(tt.expected, result)
@@ -0,0 +1,184 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+// JSON_PRETTY(json_val)
+//
+// JSONPretty Provides pretty-printing of JSON values similar to that implemented in PHP and by other languages and
+// database systems. The value supplied must be a JSON value or a valid string representation of a JSON value.
+// Extraneous whitespaces and newlines present in this value have no effect on the output. For a NULL value, the
+// function returns NULL. If the value is not a JSON document, or if it cannot be parsed as one, the function fails
+// with an error. Formatting of the output from this function adheres to the following rules:
+//   - Each array element or object member appears on a separate line, indented by one additional level as compared to
+//     its parent.
+//   - Each level of indentation adds two leading spaces.
+//   - A comma separating individual array elements or object members is printed before the newline that separates the
+//     two elements or members.
+//   - The key and the value of an object member are separated by a colon followed by a space (': ').
+//   - An empty object or array is printed on a single line. No space is printed between the opening and closing brace.
+//   - Special characters in string scalars and key names are escaped employing the same rules used by JSONQuote.
+//
+// The members of objects are printed in the order MySQL stores them in: shorter keys first, and keys of the same
+// length in byte order.
+//
+// https://dev.mysql.com/doc/refman/8.0/en/json-utility-functions.html#function_json-pretty
+type JSONPretty struct {
+	expression.UnaryExpression
+}
+
+var _ sql.FunctionExpression = (*JSONPretty)(nil)
+
+// NewJSONPretty creates a new JSONPretty function.
+func NewJSONPretty(args ...sql.Expression) (sql.Expression, error) {
+	if len(args) != 1 {
+		return nil, sql.ErrInvalidArgumentNumber.New("JSON_PRETTY", 1, len(args))
+	}
+	return &JSONPretty{expression.UnaryExpression{Child: args[0]}}, nil
+}
+
+// FunctionName implements sql.FunctionExpression
+func (j *JSONPretty) FunctionName() string {
+	return "json_pretty"
+}
+
+func (j *JSONPretty) String() string {
+	return fmt.Sprintf("JSON_PRETTY(%s)", j.Child)
+}
+
+// Type implements the sql.Expression interface.
+func (j *JSONPretty) Type() sql.Type {
+	return sql.LongText
+}
+
+// Eval implements the sql.Expression interface.
+func (j *JSONPretty) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	span, ctx := ctx.Span("function.JSONPretty")
+	defer span.Finish()
+
+	doc, err := getJSONDocument(ctx, row, j.Child)
+	if err != nil || doc == nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := writePrettyJSON(&buf, doc.Val, 0); err != nil {
+		return nil, err
+	}
+	return buf.String(), nil
+}
+
+// WithChildren implements the sql.Expression interface.
+func (j *JSONPretty) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(j, len(children), 1)
+	}
+	return NewJSONPretty(children...)
+}
+
+// writePrettyJSON writes the JSON value given to the buffer given, formatted as JSON_PRETTY does at the indentation
+// level given.
+func writePrettyJSON(buf *bytes.Buffer, v interface{}, level int) error {
+	switch v := v.(type) {
+	case []interface{}:
+		if len(v) == 0 {
+			buf.WriteString("[]")
+			return nil
+		}
+		buf.WriteString("[")
+		for i, e := range v {
+			if i > 0 {
+				buf.WriteString(",")
+			}
+			writePrettyJSONIndent(buf, level+1)
+			if err := writePrettyJSON(buf, e, level+1); err != nil {
+				return err
+			}
+		}
+		writePrettyJSONIndent(buf, level)
+		buf.WriteString("]")
+		return nil
+	case map[string]interface{}:
+		if len(v) == 0 {
+			buf.WriteString("{}")
+			return nil
+		}
+		buf.WriteString("{")
+		for i, k := range jsonObjectKeysInStorageOrder(v) {
+			if i > 0 {
+				buf.WriteString(",")
+			}
+			writePrettyJSONIndent(buf, level+1)
+			if err := writeJSONScalar(buf, k); err != nil {
+				return err
+			}
+			buf.WriteString(": ")
+			if err := writePrettyJSON(buf, v[k], level+1); err != nil {
+				return err
+			}
+		}
+		writePrettyJSONIndent(buf, level)
+		buf.WriteString("}")
+		return nil
+	default:
+		return writeJSONScalar(buf, v)
+	}
+}
+
+// writePrettyJSONIndent starts a new line at the indentation level given.
+func writePrettyJSONIndent(buf *bytes.Buffer, level int) {
+	buf.WriteString("\n")
+	buf.WriteString(strings.Repeat("  ", level))
+}
+
+// writeJSONScalar writes the JSON scalar given to the buffer given. Unlike json.Marshal, HTML characters in strings
+// aren't escaped, as JSON_QUOTE doesn't escape them either.
+func writeJSONScalar(buf *bytes.Buffer, v interface{}) error {
+	var scalar bytes.Buffer
+	enc := json.NewEncoder(&scalar)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return err
+	}
+	// Encode terminates the value with a newline
+	buf.Write(bytes.TrimSuffix(scalar.Bytes(), []byte("\n")))
+	return nil
+}
+
+// jsonObjectKeysInStorageOrder returns the keys of the JSON object given in the order MySQL stores them in: shorter
+// keys first, and keys of the same length in byte order.
+func jsonObjectKeysInStorageOrder(obj map[string]interface{}) []string {
+	keys := make([]string, 0, len(obj))
+	for k := range obj {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if len(keys[i]) != len(keys[j]) {
+			return len(keys[i]) < len(keys[j])
+		}
+		return keys[i] < keys[j]
+	})
+	return keys
+}
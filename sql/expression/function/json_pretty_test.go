@@ -0,0 +1,61 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+func TestJSONPretty(t *testing.T) {
+	_, err := NewJSONPretty()
+	require.Error(t, err)
+
+	f, err := NewJSONPretty(expression.NewGetField(0, sql.JSON, "arg1", true))
+	require.NoError(t, err)
+
+	testCases := []struct {
+		row      sql.Row
+		expected interface{}
+		err      bool
+	}{
+		{sql.Row{`{"a": 1, "b": [2, 3], "c": {"d": {}}}`}, "{\n  \"a\": 1,\n  \"b\": [\n    2,\n    3\n  ],\n  \"c\": {\n    \"d\": {}\n  }\n}", false},
+		{sql.Row{`{"ccc": 1, "bb": 2, "b": 3, "a": 4}`}, "{\n  \"a\": 4,\n  \"b\": 3,\n  \"bb\": 2,\n  \"ccc\": 1\n}", false},
+		{sql.Row{`[["a<b"], []]`}, "[\n  [\n    \"a<b\"\n  ],\n  []\n]", false},
+		{sql.Row{`  "abc" `}, `"abc"`, false},
+		{sql.Row{`null`}, "null", false},
+		{sql.Row{sql.JSONDocument{Val: map[string]interface{}{"a": true}}}, "{\n  \"a\": true\n}", false},
+		{sql.Row{nil}, nil, false},
+		{sql.Row{`{"a": 1`}, nil, true},
+	}
+
+	for _, tt := range testCases {
+		t.Run(fmt.Sprint(tt.row[0]), func(t *testing.T) {
+			require := require.New(t)
+			result, err := f.Eval(sql.NewEmptyContext(), tt.row)
+			if tt.err {
+				require.Error(err)
+			} else {
+				require.NoError(err)
+			}
+			require.Equal(tt.expected, result)
+		})
+	}
+}
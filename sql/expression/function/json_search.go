@@ -0,0 +1,242 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"gopkg.in/src-d/go-errors.v1"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// ErrInvalidEscapeChar is returned when the escape_char argument of JSON_SEARCH is longer than one character.
+var ErrInvalidEscapeChar = errors.NewKind("Incorrect arguments to ESCAPE")
+
+// JSON_SEARCH(json_doc, one_or_all, search_str[, escape_char[, path] ...])
+//
+// JSONSearch Returns the path to the given string within a JSON document. Returns NULL if any of the json_doc,
+// search_str, or path arguments are NULL; no path exists within the document; or search_str is not found. An error
+// occurs if the json_doc argument is not a valid JSON document, any path argument is not a valid path expression,
+// one_or_all is not 'one' or 'all', or escape_char is not a constant expression.
+// The one_or_all argument affects the search as follows:
+//   - 'one': The search terminates after the first match and returns one path string. It is undefined which match is
+//     considered first.
+//   - 'all': The search returns all matching path strings such that no duplicate paths are included. If there are
+//     multiple strings, they are autowrapped as an array. The order of the array elements is undefined.
+//
+// Within the search_str search string argument, the % and _ characters work as for the LIKE operator: % matches any
+// number of characters (including zero characters), and _ matches exactly one character.
+//
+// To specify a literal % or _ character in the search string, precede it by the escape character. The default is \ if
+// the escape_char argument is missing or NULL. Otherwise, escape_char must be a constant that is empty or one character.
+//
+// https://dev.mysql.com/doc/refman/8.0/en/json-search-functions.html#function_json-search
+type JSONSearch struct {
+	JSON      sql.Expression
+	OneOrAll  sql.Expression
+	SearchStr sql.Expression
+	Escape    sql.Expression
+	Paths     []sql.Expression
+}
+
+var _ sql.FunctionExpression = (*JSONSearch)(nil)
+
+// NewJSONSearch creates a new JSONSearch function.
+func NewJSONSearch(args ...sql.Expression) (sql.Expression, error) {
+	if len(args) < 3 {
+		return nil, sql.ErrInvalidArgumentNumber.New("JSON_SEARCH", "3 or more", len(args))
+	}
+
+	j := &JSONSearch{JSON: args[0], OneOrAll: args[1], SearchStr: args[2]}
+	if len(args) > 3 {
+		j.Escape = args[3]
+		j.Paths = args[4:]
+	}
+	return j, nil
+}
+
+// FunctionName implements sql.FunctionExpression
+func (j *JSONSearch) FunctionName() string {
+	return "json_search"
+}
+
+// Resolved implements the sql.Expression interface.
+func (j *JSONSearch) Resolved() bool {
+	for _, child := range j.Children() {
+		if !child.Resolved() {
+			return false
+		}
+	}
+	return true
+}
+
+func (j *JSONSearch) String() string {
+	children := j.Children()
+	var parts = make([]string, len(children))
+	for i, c := range children {
+		parts[i] = c.String()
+	}
+	return fmt.Sprintf("JSON_SEARCH(%s)", strings.Join(parts, ", "))
+}
+
+// Type implements the sql.Expression interface.
+func (j *JSONSearch) Type() sql.Type {
+	return sql.JSON
+}
+
+// IsNullable implements the sql.Expression interface.
+func (j *JSONSearch) IsNullable() bool {
+	return true
+}
+
+// Eval implements the sql.Expression interface.
+func (j *JSONSearch) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	span, ctx := ctx.Span("function.JSONSearch")
+	defer span.Finish()
+
+	doc, err := getJSONDocument(ctx, row, j.JSON)
+	if err != nil || doc == nil {
+		return nil, err
+	}
+
+	all, err := evalOneOrAll(ctx, row, j.OneOrAll, j.FunctionName())
+	if err != nil || all == nil {
+		return nil, err
+	}
+
+	search, err := j.SearchStr.Eval(ctx, row)
+	if err != nil || search == nil {
+		return nil, err
+	}
+	search, err = sql.LongText.Convert(search)
+	if err != nil {
+		return nil, err
+	}
+
+	escape := '\\'
+	if j.Escape != nil {
+		e, err := j.Escape.Eval(ctx, row)
+		if err != nil {
+			return nil, err
+		}
+		if e != nil {
+			e, err = sql.LongText.Convert(e)
+			if err != nil {
+				return nil, err
+			}
+			runes := []rune(e.(string))
+			if len(runes) > 1 {
+				return nil, ErrInvalidEscapeChar.New()
+			}
+			if len(runes) == 1 {
+				escape = runes[0]
+			}
+		}
+	}
+
+	re, err := regexp.Compile(jsonSearchPatternToRegex(search.(string), escape))
+	if err != nil {
+		return nil, err
+	}
+
+	scopes := []sql.JSONPath{{}}
+	if len(j.Paths) > 0 {
+		scopes = make([]sql.JSONPath, len(j.Paths))
+		for i, p := range j.Paths {
+			path, err := p.Eval(ctx, row)
+			if err != nil || path == nil {
+				return nil, err
+			}
+			path, err = sql.LongText.Convert(path)
+			if err != nil {
+				return nil, err
+			}
+			if scopes[i], err = sql.ParseJSONPath(path.(string)); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// The scopes may overlap, so the strings within more than one of them are only matched once
+	var matches []interface{}
+	seen := make(map[string]bool)
+	for _, scope := range scopes {
+		complete := scope.Walk(doc.Val, func(path string, val interface{}) bool {
+			s, ok := val.(string)
+			if !ok || !re.MatchString(s) || seen[path] {
+				return true
+			}
+			seen[path] = true
+			matches = append(matches, path)
+			return *all
+		})
+		if !complete {
+			break
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, nil
+	case 1:
+		return sql.JSONDocument{Val: matches[0]}, nil
+	default:
+		return sql.JSONDocument{Val: matches}, nil
+	}
+}
+
+// Children implements the sql.Expression interface.
+func (j *JSONSearch) Children() []sql.Expression {
+	children := []sql.Expression{j.JSON, j.OneOrAll, j.SearchStr}
+	if j.Escape != nil {
+		children = append(children, j.Escape)
+	}
+	return append(children, j.Paths...)
+}
+
+// WithChildren implements the sql.Expression interface.
+func (j *JSONSearch) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != len(j.Children()) {
+		return nil, sql.ErrInvalidChildrenNumber.New(j, len(children), len(j.Children()))
+	}
+	return NewJSONSearch(children...)
+}
+
+// jsonSearchPatternToRegex converts a LIKE style search string into an anchored regular expression, where % matches
+// any number of characters, _ matches exactly one character, and the escape character makes the next one literal.
+func jsonSearchPatternToRegex(pattern string, escape rune) string {
+	var sb strings.Builder
+	sb.WriteString("(?s)^")
+	runes := []rune(pattern)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case r == escape && i+1 < len(runes):
+			i++
+			sb.WriteString(regexp.QuoteMeta(string(runes[i])))
+		case r == '%':
+			sb.WriteString(".*")
+		case r == '_':
+			sb.WriteString(".")
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	sb.WriteString("$")
+	return sb.String()
+}
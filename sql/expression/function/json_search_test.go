@@ -0,0 +1,104 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+func TestJSONSearch(t *testing.T) {
+	_, err := NewJSONSearch(
+		expression.NewGetField(0, sql.JSON, "arg1", false),
+		expression.NewGetField(1, sql.LongText, "arg2", false),
+	)
+	require.Error(t, err)
+
+	f, err := NewJSONSearch(
+		expression.NewGetField(0, sql.JSON, "arg1", false),
+		expression.NewGetField(1, sql.LongText, "arg2", false),
+		expression.NewGetField(2, sql.LongText, "arg3", false),
+	)
+	require.NoError(t, err)
+
+	f2, err := NewJSONSearch(
+		expression.NewGetField(0, sql.JSON, "arg1", false),
+		expression.NewGetField(1, sql.LongText, "arg2", false),
+		expression.NewGetField(2, sql.LongText, "arg3", false),
+		expression.NewGetField(3, sql.LongText, "arg4", false),
+	)
+	require.NoError(t, err)
+
+	f3, err := NewJSONSearch(
+		expression.NewGetField(0, sql.JSON, "arg1", false),
+		expression.NewGetField(1, sql.LongText, "arg2", false),
+		expression.NewGetField(2, sql.LongText, "arg3", false),
+		expression.NewGetField(3, sql.LongText, "arg4", false),
+		expression.NewGetField(4, sql.LongText, "arg5", false),
+	)
+	require.NoError(t, err)
+
+	doc := `["abc", [{"k": "10"}, "def"], {"x": "abc"}, {"y": "bcd"}, {"first name": "abc"}]`
+
+	testCases := []struct {
+		f        sql.Expression
+		row      sql.Row
+		expected interface{}
+		err      bool
+	}{
+		{f, sql.Row{doc, "one", "abc"}, sql.JSONDocument{Val: "$[0]"}, false},
+		{f, sql.Row{doc, "all", "abc"}, sql.JSONDocument{Val: []interface{}{"$[0]", "$[2].x", `$[4]."first name"`}}, false},
+		{f, sql.Row{doc, "all", "ghi"}, nil, false},
+		{f, sql.Row{doc, "all", "10"}, sql.JSONDocument{Val: "$[1][0].k"}, false},
+		{f, sql.Row{doc, "all", "%b%"}, sql.JSONDocument{Val: []interface{}{"$[0]", "$[2].x", "$[3].y", `$[4]."first name"`}}, false},
+		{f, sql.Row{doc, "all", "_bc"}, sql.JSONDocument{Val: []interface{}{"$[0]", "$[2].x", `$[4]."first name"`}}, false},
+		{f, sql.Row{doc, "all", "a%"}, sql.JSONDocument{Val: []interface{}{"$[0]", "$[2].x", `$[4]."first name"`}}, false},
+		{f, sql.Row{`["a%c", "abc"]`, "all", `a\%c`}, sql.JSONDocument{Val: "$[0]"}, false},
+		{f2, sql.Row{`["a%c", "abc"]`, "all", `a|%c`, "|"}, sql.JSONDocument{Val: "$[0]"}, false},
+		{f2, sql.Row{`["a%c", "abc"]`, "all", `a%c`, nil}, sql.JSONDocument{Val: []interface{}{"$[0]", "$[1]"}}, false},
+		{f2, sql.Row{`["a%c", "abc"]`, "all", `a%c`, "||"}, nil, true},
+		{f3, sql.Row{doc, "all", "abc", nil, "$[2]"}, sql.JSONDocument{Val: "$[2].x"}, false},
+		{f3, sql.Row{doc, "all", "%b%", nil, "$[3]"}, sql.JSONDocument{Val: "$[3].y"}, false},
+		{f3, sql.Row{doc, "all", "abc", nil, "$[1]"}, nil, false},
+		{f3, sql.Row{doc, "all", "abc", nil, "$[*].x"}, sql.JSONDocument{Val: "$[2].x"}, false},
+		{f3, sql.Row{doc, "all", "abc", nil, `$[4]."first name"`}, sql.JSONDocument{Val: `$[4]."first name"`}, false},
+		{f3, sql.Row{doc, "all", "10", nil, "$**.k"}, sql.JSONDocument{Val: "$[1][0].k"}, false},
+		{f3, sql.Row{doc, "all", "abc", nil, "$[last]"}, sql.JSONDocument{Val: `$[4]."first name"`}, false},
+		{f3, sql.Row{`[{"a":"x"},{"a":"x"}]`, "all", "x", nil, "$[*].a"}, sql.JSONDocument{Val: []interface{}{"$[0].a", "$[1].a"}}, false},
+		{f3, sql.Row{`{"a b":"x"}`, "all", "x", nil, `$."a b"`}, sql.JSONDocument{Val: `$."a b"`}, false},
+		{f3, sql.Row{`{"a":"x"}`, "all", "x", nil, "$.a[0]"}, sql.JSONDocument{Val: "$.a"}, false},
+		{f3, sql.Row{doc, "all", "abc", nil, "bad"}, nil, true},
+		{f, sql.Row{nil, "all", "abc"}, nil, false},
+		{f, sql.Row{doc, "all", nil}, nil, false},
+		{f, sql.Row{doc, "some", "abc"}, nil, true},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.f.String(), func(t *testing.T) {
+			require := require.New(t)
+			result, err := tt.f.Eval(sql.NewEmptyContext(), tt.row)
+			if tt.err {
+				require.Error(err)
+			} else {
+				require.NoError(err)
+			}
+			require.Equal(tt.expected, result)
+		})
+	}
+}
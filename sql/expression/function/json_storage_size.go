@@ -0,0 +1,249 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+// JSON_STORAGE_SIZE(json_val)
+//
+// JSONStorageSize This function returns the number of bytes used to store the binary representation of a JSON document.
+// When the argument is a JSON column, this is the space used to store the JSON document as it was inserted into the
+// column, prior to any partial updates that may have been performed on it afterwards. json_val must be a valid JSON
+// document or a string which can be parsed as one. In the case where it is string, the function returns the amount of
+// storage space in the JSON binary representation that is created by parsing the string as JSON and converting it to
+// binary. It returns NULL if the argument is NULL. An error results when json_val is not NULL, and is not—or cannot be
+// successfully parsed as—a JSON document.
+//
+// The size is the one of MySQL's binary JSON format, whatever the format the document is stored in. JSON documents
+// that aren't parsed from text don't tell integers from doubles, so their numbers are sized as integers when they
+// have an integer value.
+//
+// https://dev.mysql.com/doc/refman/8.0/en/json-utility-functions.html#function_json-storage-size
+type JSONStorageSize struct {
+	expression.UnaryExpression
+}
+
+var _ sql.FunctionExpression = (*JSONStorageSize)(nil)
+
+// NewJSONStorageSize creates a new JSONStorageSize function.
+func NewJSONStorageSize(args ...sql.Expression) (sql.Expression, error) {
+	if len(args) != 1 {
+		return nil, sql.ErrInvalidArgumentNumber.New("JSON_STORAGE_SIZE", 1, len(args))
+	}
+	return &JSONStorageSize{expression.UnaryExpression{Child: args[0]}}, nil
+}
+
+// FunctionName implements sql.FunctionExpression
+func (j *JSONStorageSize) FunctionName() string {
+	return "json_storage_size"
+}
+
+func (j *JSONStorageSize) String() string {
+	return fmt.Sprintf("JSON_STORAGE_SIZE(%s)", j.Child)
+}
+
+// Type implements the sql.Expression interface.
+func (j *JSONStorageSize) Type() sql.Type {
+	return sql.Int64
+}
+
+// Eval implements the sql.Expression interface.
+func (j *JSONStorageSize) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	span, ctx := ctx.Span("function.JSONStorageSize")
+	defer span.Finish()
+
+	js, err := j.Child.Eval(ctx, row)
+	if err != nil || js == nil {
+		return nil, err
+	}
+
+	var val interface{}
+	switch js := js.(type) {
+	case string:
+		val, err = decodeJSONWithNumbers([]byte(js))
+	case []byte:
+		val, err = decodeJSONWithNumbers(js)
+	default:
+		var converted interface{}
+		converted, err = sql.JSON.Convert(js)
+		if err != nil {
+			return nil, sql.ErrInvalidJSONText.New(js)
+		}
+		var doc sql.JSONDocument
+		doc, err = converted.(sql.JSONValue).Unmarshall(ctx)
+		val = doc.Val
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// The type of the document takes a byte of its own
+	return int64(1 + jsonBinarySize(val)), nil
+}
+
+// WithChildren implements the sql.Expression interface.
+func (j *JSONStorageSize) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(j, len(children), 1)
+	}
+	return NewJSONStorageSize(children...)
+}
+
+// decodeJSONWithNumbers decodes the JSON text given, keeping its numbers as json.Number so that integers can be told
+// from doubles.
+func decodeJSONWithNumbers(text []byte) (interface{}, error) {
+	if !json.Valid(text) {
+		return nil, sql.ErrInvalidJSONText.New(string(text))
+	}
+	var val interface{}
+	dec := json.NewDecoder(bytes.NewReader(text))
+	dec.UseNumber()
+	if err := dec.Decode(&val); err != nil {
+		return nil, sql.ErrInvalidJSONText.New(string(text))
+	}
+	return val, nil
+}
+
+// Sizes of the headers and entries of the containers of MySQL's binary JSON format. Containers are stored in the small
+// format, with 2-byte counts and offsets, unless they're too large for it.
+const (
+	jsonSmallContainerMaxSize = math.MaxUint16
+	jsonSmallHeaderSize       = 4
+	jsonSmallKeyEntrySize     = 4
+	jsonSmallValueEntrySize   = 3
+	jsonLargeHeaderSize       = 8
+	jsonLargeKeyEntrySize     = 6
+	jsonLargeValueEntrySize   = 5
+)
+
+// jsonBinarySize returns the number of bytes the JSON value given takes in MySQL's binary JSON format, not counting
+// the byte of its type.
+func jsonBinarySize(v interface{}) int {
+	switch v := v.(type) {
+	case []interface{}:
+		size := jsonContainerSize(nil, v, false)
+		if size > jsonSmallContainerMaxSize {
+			size = jsonContainerSize(nil, v, true)
+		}
+		return size
+	case map[string]interface{}:
+		keys := jsonObjectKeysInStorageOrder(v)
+		values := make([]interface{}, len(keys))
+		for i, k := range keys {
+			values[i] = v[k]
+		}
+		size := jsonContainerSize(keys, values, false)
+		if size > jsonSmallContainerMaxSize {
+			size = jsonContainerSize(keys, values, true)
+		}
+		return size
+	case string:
+		return jsonStringLengthSize(len(v)) + len(v)
+	case nil, bool:
+		return 1
+	default:
+		return jsonNumberSize(v)
+	}
+}
+
+// jsonContainerSize returns the size of the JSON object with the keys and values given, or of the JSON array with the
+// values given if there are no keys, in the small or large format.
+func jsonContainerSize(keys []string, values []interface{}, large bool) int {
+	headerSize, keyEntrySize, valueEntrySize := jsonSmallHeaderSize, jsonSmallKeyEntrySize, jsonSmallValueEntrySize
+	if large {
+		headerSize, keyEntrySize, valueEntrySize = jsonLargeHeaderSize, jsonLargeKeyEntrySize, jsonLargeValueEntrySize
+	}
+
+	size := headerSize + len(keys)*keyEntrySize + len(values)*valueEntrySize
+	for _, k := range keys {
+		size += len(k)
+	}
+	for _, v := range values {
+		if !jsonValueInlined(v, large) {
+			size += jsonBinarySize(v)
+		}
+	}
+	return size
+}
+
+// jsonValueInlined returns whether the JSON value given is stored in its entry of a container in the small or large
+// format, rather than after the entries.
+func jsonValueInlined(v interface{}, large bool) bool {
+	switch v.(type) {
+	case nil, bool:
+		return true
+	case []interface{}, map[string]interface{}, string:
+		return false
+	default:
+		size := jsonNumberSize(v)
+		return size == 2 || (large && size == 4)
+	}
+}
+
+// jsonNumberSize returns the size of the JSON number given: integers take 2, 4 or 8 bytes, depending on their value,
+// and doubles take 8.
+func jsonNumberSize(v interface{}) int {
+	var i int64
+	switch v := v.(type) {
+	case json.Number:
+		n, err := strconv.ParseInt(string(v), 10, 64)
+		if err != nil {
+			return 8
+		}
+		i = n
+	case float64:
+		if v != math.Trunc(v) || v < math.MinInt64 || v >= math.MaxInt64 {
+			return 8
+		}
+		i = int64(v)
+	case float32:
+		return jsonNumberSize(float64(v))
+	default:
+		n, err := sql.Int64.Convert(v)
+		if err != nil {
+			return 8
+		}
+		i = n.(int64)
+	}
+
+	switch {
+	case i >= math.MinInt16 && i <= math.MaxInt16:
+		return 2
+	case i >= math.MinInt32 && i <= math.MaxInt32:
+		return 4
+	default:
+		return 8
+	}
+}
+
+// jsonStringLengthSize returns the number of bytes taken by the length of a string of the length given, which is
+// stored with 7 bits in every byte.
+func jsonStringLengthSize(length int) int {
+	size := 1
+	for length >= 1<<7 {
+		length >>= 7
+		size++
+	}
+	return size
+}
@@ -0,0 +1,77 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+func TestJSONStorageSize(t *testing.T) {
+	_, err := NewJSONStorageSize()
+	require.Error(t, err)
+
+	f, err := NewJSONStorageSize(expression.NewGetField(0, sql.JSON, "arg1", true))
+	require.NoError(t, err)
+
+	largeString := strings.Repeat("x", 70000)
+
+	testCases := []struct {
+		row      sql.Row
+		expected interface{}
+		err      bool
+	}{
+		{sql.Row{`[100, "sakila", [1, 3, 5], 425.05]`}, int64(45), false},
+		{sql.Row{`{"a": 1000, "b": "wxyz", "c": "[1, 3, 5, 7]"}`}, int64(47), false},
+		{sql.Row{`{"a": 4.55, "b": "wxyz", "c": "[true, false]"}`}, int64(56), false},
+		{sql.Row{`null`}, int64(2), false},
+		{sql.Row{`1`}, int64(3), false},
+		{sql.Row{`100000`}, int64(5), false},
+		{sql.Row{`10000000000`}, int64(9), false},
+		{sql.Row{`1.0`}, int64(9), false},
+		{sql.Row{`"abc"`}, int64(5), false},
+		{sql.Row{[]byte(`[true]`)}, int64(8), false},
+		{sql.Row{`[100000]`}, int64(12), false},
+		{sql.Row{`["` + largeString + `"]`}, int64(1 + 8 + 5 + 3 + 70000), false},
+		{sql.Row{sql.JSONDocument{Val: map[string]interface{}{"a": float64(1)}}}, int64(13), false},
+		{sql.Row{sql.JSONDocument{Val: []interface{}{1.5}}}, int64(16), false},
+		{sql.Row{nil}, nil, false},
+		{sql.Row{`[1`}, nil, true},
+		{sql.Row{`[1] 2`}, nil, true},
+	}
+
+	for _, tt := range testCases {
+		name := fmt.Sprint(tt.row[0])
+		if len(name) > 50 {
+			name = name[:50]
+		}
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+			result, err := f.Eval(sql.NewEmptyContext(), tt.row)
+			if tt.err {
+				require.Error(err)
+			} else {
+				require.NoError(err)
+			}
+			require.Equal(tt.expected, result)
+		})
+	}
+}
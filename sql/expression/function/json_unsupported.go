@@ -27,35 +27,6 @@ var ErrUnsupportedJSONFunction = errors.NewKind("unsupported JSON function: %s")
 // JSON search functions //
 ///////////////////////////
 
-// JSON_CONTAINS_PATH(json_doc, one_or_all, path[, path] ...)
-//
-// JSONContainsPath Returns 0 or 1 to indicate whether a JSON document contains data at a given path or paths. Returns
-// NULL if any argument is NULL. An error occurs if the json_doc argument is not a valid JSON document, any path
-// argument is not a valid path expression, or one_or_all is not 'one' or 'all'. To check for a specific value at a
-// path, use JSON_CONTAINS() instead.
-//
-// The return value is 0 if no specified path exists within the document. Otherwise, the return value depends on the
-// one_or_all argument:
-//   - 'one': 1 if at least one path exists within the document, 0 otherwise.
-//   - 'all': 1 if all paths exist within the document, 0 otherwise.
-//
-// https://dev.mysql.com/doc/refman/8.0/en/json-search-functions.html#function_json-contains-path
-type JSONContainsPath struct {
-	sql.Expression
-}
-
-var _ sql.FunctionExpression = JSONContainsPath{}
-
-// NewJSONContainsPath creates a new JSONContainsPath function.
-func NewJSONContainsPath(args ...sql.Expression) (sql.Expression, error) {
-	return nil, ErrUnsupportedJSONFunction.New(JSONContainsPath{}.FunctionName())
-}
-
-// FunctionName implements sql.FunctionExpression
-func (j JSONContainsPath) FunctionName() string {
-	return "json_contains_path"
-}
-
 // JSON_KEYS(json_doc[, path])
 //
 // JSONKeys Returns the keys from the top-level value of a JSON object as a JSON array, or, if a path argument is given,
@@ -111,47 +82,6 @@ func (j JSONOverlaps) FunctionName() string {
 	return "json_overlaps"
 }
 
-// JSON_SEARCH(json_doc, one_or_all, search_str[, escape_char[, path] ...])
-//
-// JSONSearch Returns the path to the given string within a JSON document. Returns NULL if any of the json_doc,
-// search_str, or path arguments are NULL; no path exists within the document; or search_str is not found. An error
-// occurs if the json_doc argument is not a valid JSON document, any path argument is not a valid path expression,
-// one_or_all is not 'one' or 'all', or escape_char is not a constant expression.
-// The one_or_all argument affects the search as follows:
-//   - 'one': The search terminates after the first match and returns one path string. It is undefined which match is
-//     considered first.
-//   - 'all': The search returns all matching path strings such that no duplicate paths are included. If there are
-//     multiple strings, they are autowrapped as an array. The order of the array elements is undefined.
-//
-// Within the search_str search string argument, the % and _ characters work as for the LIKE operator: % matches any
-// number of characters (including zero characters), and _ matches exactly one character.
-//
-// To specify a literal % or _ character in the search string, precede it by the escape character. The default is \ if
-// the escape_char argument is missing or NULL. Otherwise, escape_char must be a constant that is empty or one character.
-// For more information about matching and escape character behavior, see the description of LIKE in Section 12.8.1,
-// “String Comparison Functions and Operators”: https://dev.mysql.com/doc/refman/8.0/en/string-comparison-functions.html
-// For escape character handling, a difference from the LIKE behavior is that the escape character for JSON_SEARCH()
-// must evaluate to a constant at compile time, not just at execution time. For example, if JSON_SEARCH() is used in a
-// prepared statement and the escape_char argument is supplied using a ? parameter, the parameter value might be
-// constant at execution time, but is not at compile time.
-//
-// https://dev.mysql.com/doc/refman/8.0/en/json-search-functions.html#function_json-search
-type JSONSearch struct {
-	sql.Expression
-}
-
-var _ sql.FunctionExpression = JSONSearch{}
-
-// NewJSONSearch creates a new NewJSONSearch function.
-func NewJSONSearch(args ...sql.Expression) (sql.Expression, error) {
-	return nil, ErrUnsupportedJSONFunction.New(JSONSearch{}.FunctionName())
-}
-
-// FunctionName implements sql.FunctionExpression
-func (j JSONSearch) FunctionName() string {
-	return "json_search"
-}
-
 // JSON_VALUE(json_doc, path)
 //
 // JSONValue Extracts a value from a JSON document at the path given in the specified document, and returns the
@@ -682,39 +612,6 @@ func (j JSONSchemaValidationReport) FunctionName() string {
 // JSON utility functions //
 ////////////////////////////
 
-// JSON_PRETTY(json_val)
-//
-// JSONPretty Provides pretty-printing of JSON values similar to that implemented in PHP and by other languages and
-// database systems. The value supplied must be a JSON value or a valid string representation of a JSON value.
-// Extraneous whitespaces and newlines present in this value have no effect on the output. For a NULL value, the
-// function returns NULL. If the value is not a JSON document, or if it cannot be parsed as one, the function fails
-// with an error. Formatting of the output from this function adheres to the following rules:
-//   - Each array element or object member appears on a separate line, indented by one additional level as compared to
-//     its parent.
-//   - Each level of indentation adds two leading spaces.
-//   - A comma separating individual array elements or object members is printed before the newline that separates the
-//     two elements or members.
-//   - The key and the value of an object member are separated by a colon followed by a space (': ').
-//   - An empty object or array is printed on a single line. No space is printed between the opening and closing brace.
-//   - Special characters in string scalars and key names are escaped employing the same rules used by JSONQuote.
-//
-// https://dev.mysql.com/doc/refman/8.0/en/json-utility-functions.html#function_json-pretty
-type JSONPretty struct {
-	sql.Expression
-}
-
-var _ sql.FunctionExpression = JSONPretty{}
-
-// NewJSONPretty creates a new JSONPretty function.
-func NewJSONPretty(args ...sql.Expression) (sql.Expression, error) {
-	return nil, ErrUnsupportedJSONFunction.New(JSONPretty{}.FunctionName())
-}
-
-// FunctionName implements sql.FunctionExpression
-func (j JSONPretty) FunctionName() string {
-	return "json_pretty"
-}
-
 // JSON_STORAGE_FREE(json_val)
 //
 // JSONStorageFree For a JSON column value, this function shows how much storage space was freed in its binary
@@ -743,30 +640,3 @@ func NewJSONStorageFree(args ...sql.Expression) (sql.Expression, error) {
 func (j JSONStorageFree) FunctionName() string {
 	return "json_storage_free"
 }
-
-// JSON_STORAGE_SIZE(json_val)
-//
-// JSONStorageSize This function returns the number of bytes used to store the binary representation of a JSON document.
-// When the argument is a JSON column, this is the space used to store the JSON document as it was inserted into the
-// column, prior to any partial updates that may have been performed on it afterwards. json_val must be a valid JSON
-// document or a string which can be parsed as one. In the case where it is string, the function returns the amount of
-// storage space in the JSON binary representation that is created by parsing the string as JSON and converting it to
-// binary. It returns NULL if the argument is NULL. An error results when json_val is not NULL, and is not—or cannot be
-// successfully parsed as—a JSON document.
-//
-// https://dev.mysql.com/doc/refman/8.0/en/json-utility-functions.html#function_json-storage-size
-type JSONStorageSize struct {
-	sql.Expression
-}
-
-var _ sql.FunctionExpression = JSONStorageSize{}
-
-// NewJSONStorageSize creates a new JSONStorageSize function.
-func NewJSONStorageSize(args ...sql.Expression) (sql.Expression, error) {
-	return nil, ErrUnsupportedJSONFunction.New(JSONStorageSize{}.FunctionName())
-}
-
-// FunctionName implements sql.FunctionExpression
-func (j JSONStorageSize) FunctionName() string {
-	return "json_storage_size"
-}
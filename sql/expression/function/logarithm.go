@@ -19,16 +19,20 @@ import (
 	"math"
 	"reflect"
 
+	"github.com/dolthub/vitess/go/mysql"
 	"gopkg.in/src-d/go-errors.v1"
 
 	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/dolthub/go-mysql-server/sql/expression"
 )
 
-// ErrInvalidArgumentForLogarithm is returned when an invalid argument value is passed to a
+// ErrInvalidArgumentForLogarithm is the warning given when an invalid argument value is passed to a
 // logarithm function
 var ErrInvalidArgumentForLogarithm = errors.NewKind("invalid argument value for logarithm: %v")
 
+// invalidArgumentForLogarithmCode is the MySQL warning code for ErrInvalidArgumentForLogarithm
+const invalidArgumentForLogarithmCode = 3020
+
 // NewLogBaseFunc returns LogBase creator function with a specific base.
 func NewLogBaseFunc(base float64) func(e sql.Expression) sql.Expression {
 	return func(e sql.Expression) sql.Expression {
@@ -89,9 +93,9 @@ func (l *LogBase) Type() sql.Type {
 	return sql.Float64
 }
 
-// IsNullable implements the sql.Expression interface.
+// IsNullable implements the sql.Expression interface. Values outside the domain of the logarithm give NULL.
 func (l *LogBase) IsNullable() bool {
-	return l.base == float64(1) || l.base <= float64(0) || l.Child.IsNullable()
+	return true
 }
 
 // Eval implements the Expression interface.
@@ -112,7 +116,7 @@ func (l *LogBase) Eval(
 	if err != nil {
 		return nil, sql.ErrInvalidType.New(reflect.TypeOf(v))
 	}
-	return computeLog(val.(float64), l.base)
+	return computeLog(ctx, val.(float64), l.base), nil
 }
 
 // Log is a function that returns the natural logarithm of a value.
@@ -160,9 +164,9 @@ func (l *Log) Type() sql.Type {
 	return sql.Float64
 }
 
-// IsNullable implements the Expression interface.
+// IsNullable implements the Expression interface. Values outside the domain of the logarithm give NULL.
 func (l *Log) IsNullable() bool {
-	return l.Left.IsNullable() || l.Right.IsNullable()
+	return true
 }
 
 // Eval implements the Expression interface.
@@ -199,25 +203,96 @@ func (l *Log) Eval(
 	}
 
 	// rhs becomes value, lhs becomes base
-	return computeLog(rhs.(float64), lhs.(float64))
+	return computeLog(ctx, rhs.(float64), lhs.(float64)), nil
 }
 
-func computeLog(v float64, base float64) (float64, error) {
+// computeLog returns the logarithm of v in the base given. As in MySQL, a value or base outside the domain of the
+// logarithm gives NULL and a warning rather than an error.
+func computeLog(ctx *sql.Context, v float64, base float64) interface{} {
 	if v <= 0 {
-		return float64(0), ErrInvalidArgumentForLogarithm.New(v)
+		ctx.Warn(invalidArgumentForLogarithmCode, "%s", ErrInvalidArgumentForLogarithm.New(v).Error())
+		return nil
 	}
 	if base == float64(1) || base <= float64(0) {
-		return float64(0), ErrInvalidArgumentForLogarithm.New(base)
+		ctx.Warn(invalidArgumentForLogarithmCode, "%s", ErrInvalidArgumentForLogarithm.New(base).Error())
+		return nil
 	}
 	switch base {
 	case float64(2):
-		return math.Log2(v), nil
+		return math.Log2(v)
 	case float64(10):
-		return math.Log10(v), nil
+		return math.Log10(v)
 	case math.E:
-		return math.Log(v), nil
+		return math.Log(v)
 	default:
 		// LOG(BASE,V) is equivalent to LOG(V) / LOG(BASE).
-		return float64(math.Log(v) / math.Log(base)), nil
+		return float64(math.Log(v) / math.Log(base))
+	}
+}
+
+// Exp is a function that returns the value of e raised to the power of the number provided.
+type Exp struct {
+	expression.UnaryExpression
+}
+
+var _ sql.FunctionExpression = (*Exp)(nil)
+
+// NewExp creates a new Exp expression.
+func NewExp(e sql.Expression) sql.Expression {
+	return &Exp{expression.UnaryExpression{Child: e}}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (e *Exp) FunctionName() string {
+	return "exp"
+}
+
+func (e *Exp) String() string {
+	return fmt.Sprintf("exp(%s)", e.Child)
+}
+
+// Type implements the Expression interface.
+func (e *Exp) Type() sql.Type {
+	return sql.Float64
+}
+
+// IsNullable implements the Expression interface. Results too large for a DOUBLE give NULL.
+func (e *Exp) IsNullable() bool {
+	return true
+}
+
+// WithChildren implements the Expression interface.
+func (e *Exp) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(e, len(children), 1)
+	}
+	return NewExp(children[0]), nil
+}
+
+// Eval implements the Expression interface.
+func (e *Exp) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	v, err := e.Child.Eval(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+
+	if v == nil {
+		return nil, nil
+	}
+
+	val, err := sql.Float64.Convert(v)
+	if err != nil {
+		return nil, sql.ErrInvalidType.New(reflect.TypeOf(v))
+	}
+
+	return checkDoubleRange(ctx, math.Exp(val.(float64)), e), nil
+}
+
+// checkDoubleRange returns the result given, or NULL and a warning if it overflowed the range of a DOUBLE.
+func checkDoubleRange(ctx *sql.Context, result float64, e sql.Expression) interface{} {
+	if math.IsInf(result, 0) || math.IsNaN(result) {
+		ctx.Warn(mysql.ERDataOutOfRange, "DOUBLE value is out of range in '%s'", e)
+		return nil
 	}
+	return result
 }
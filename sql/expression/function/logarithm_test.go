@@ -36,8 +36,8 @@ func TestLn(t *testing.T) {
 		expected interface{}
 		err      *errors.Kind
 	}{
-		{"Input value is zero", sql.Float64, sql.NewRow(0), nil, ErrInvalidArgumentForLogarithm},
-		{"Input value is negative", sql.Float64, sql.NewRow(-1), nil, ErrInvalidArgumentForLogarithm},
+		{"Input value is zero", sql.Float64, sql.NewRow(0), nil, nil},
+		{"Input value is negative", sql.Float64, sql.NewRow(-1), nil, nil},
 		{"Input value is valid string", sql.Float64, sql.NewRow("2"), float64(0.6931471805599453), nil},
 		{"Input value is invalid string", sql.Float64, sql.NewRow("aaa"), nil, sql.ErrInvalidType},
 		{"Input value is valid float64", sql.Float64, sql.NewRow(3), float64(1.0986122886681096), nil},
@@ -55,6 +55,9 @@ func TestLn(t *testing.T) {
 			if tt.err != nil {
 				require.Error(err)
 				require.True(tt.err.Is(err))
+			} else if tt.expected == nil {
+				require.NoError(err)
+				require.Nil(result)
 			} else {
 				require.NoError(err)
 				require.InEpsilonf(tt.expected, result, epsilon, fmt.Sprintf("Actual is: %v", result))
@@ -79,8 +82,8 @@ func TestLog2(t *testing.T) {
 		expected interface{}
 		err      *errors.Kind
 	}{
-		{"Input value is zero", sql.Float64, sql.NewRow(0), nil, ErrInvalidArgumentForLogarithm},
-		{"Input value is negative", sql.Float64, sql.NewRow(-1), nil, ErrInvalidArgumentForLogarithm},
+		{"Input value is zero", sql.Float64, sql.NewRow(0), nil, nil},
+		{"Input value is negative", sql.Float64, sql.NewRow(-1), nil, nil},
 		{"Input value is valid string", sql.Float64, sql.NewRow("2"), float64(1), nil},
 		{"Input value is invalid string", sql.Float64, sql.NewRow("aaa"), nil, sql.ErrInvalidType},
 		{"Input value is valid float64", sql.Float64, sql.NewRow(3), float64(1.5849625007211563), nil},
@@ -98,6 +101,9 @@ func TestLog2(t *testing.T) {
 			if tt.err != nil {
 				require.Error(err)
 				require.True(tt.err.Is(err))
+			} else if tt.expected == nil {
+				require.NoError(err)
+				require.Nil(result)
 			} else {
 				require.NoError(err)
 				require.InEpsilonf(tt.expected, result, epsilon, fmt.Sprintf("Actual is: %v", result))
@@ -122,8 +128,8 @@ func TestLog10(t *testing.T) {
 		expected interface{}
 		err      *errors.Kind
 	}{
-		{"Input value is zero", sql.Float64, sql.NewRow(0), nil, ErrInvalidArgumentForLogarithm},
-		{"Input value is negative", sql.Float64, sql.NewRow(-1), nil, ErrInvalidArgumentForLogarithm},
+		{"Input value is zero", sql.Float64, sql.NewRow(0), nil, nil},
+		{"Input value is negative", sql.Float64, sql.NewRow(-1), nil, nil},
 		{"Input value is valid string", sql.Float64, sql.NewRow("2"), float64(0.3010299956639812), nil},
 		{"Input value is invalid string", sql.Float64, sql.NewRow("aaa"), nil, sql.ErrInvalidType},
 		{"Input value is valid float64", sql.Float64, sql.NewRow(3), float64(0.4771212547196624), nil},
@@ -141,6 +147,9 @@ func TestLog10(t *testing.T) {
 			if tt.err != nil {
 				require.Error(err)
 				require.True(tt.err.Is(err))
+			} else if tt.expected == nil {
+				require.NoError(err)
+				require.Nil(result)
 			} else {
 				require.NoError(err)
 				require.InEpsilonf(tt.expected, result, epsilon, fmt.Sprintf("Actual is: %v", result))
@@ -176,14 +185,14 @@ func TestLog(t *testing.T) {
 		expected interface{}
 		err      *errors.Kind
 	}{
-		{"Input base is 1", []sql.Expression{expression.NewLiteral(float64(1), sql.Float64), expression.NewLiteral(float64(10), sql.Float64)}, nil, ErrInvalidArgumentForLogarithm},
-		{"Input base is zero", []sql.Expression{expression.NewLiteral(float64(0), sql.Float64), expression.NewLiteral(float64(10), sql.Float64)}, nil, ErrInvalidArgumentForLogarithm},
-		{"Input base is negative", []sql.Expression{expression.NewLiteral(float64(-5), sql.Float64), expression.NewLiteral(float64(10), sql.Float64)}, nil, ErrInvalidArgumentForLogarithm},
+		{"Input base is 1", []sql.Expression{expression.NewLiteral(float64(1), sql.Float64), expression.NewLiteral(float64(10), sql.Float64)}, nil, nil},
+		{"Input base is zero", []sql.Expression{expression.NewLiteral(float64(0), sql.Float64), expression.NewLiteral(float64(10), sql.Float64)}, nil, nil},
+		{"Input base is negative", []sql.Expression{expression.NewLiteral(float64(-5), sql.Float64), expression.NewLiteral(float64(10), sql.Float64)}, nil, nil},
 		{"Input base is valid string", []sql.Expression{expression.NewLiteral("4", sql.LongText), expression.NewLiteral(float64(10), sql.Float64)}, float64(1.6609640474436813), nil},
 		{"Input base is invalid string", []sql.Expression{expression.NewLiteral("bbb", sql.LongText), expression.NewLiteral(float64(10), sql.Float64)}, nil, sql.ErrInvalidType},
 
-		{"Input value is zero", []sql.Expression{expression.NewLiteral(float64(0), sql.Float64)}, nil, ErrInvalidArgumentForLogarithm},
-		{"Input value is negative", []sql.Expression{expression.NewLiteral(float64(-9), sql.Float64)}, nil, ErrInvalidArgumentForLogarithm},
+		{"Input value is zero", []sql.Expression{expression.NewLiteral(float64(0), sql.Float64)}, nil, nil},
+		{"Input value is negative", []sql.Expression{expression.NewLiteral(float64(-9), sql.Float64)}, nil, nil},
 		{"Input value is valid string", []sql.Expression{expression.NewLiteral("7", sql.LongText)}, float64(1.9459101490553132), nil},
 		{"Input value is invalid string", []sql.Expression{expression.NewLiteral("766j", sql.LongText)}, nil, sql.ErrInvalidType},
 
@@ -207,6 +216,9 @@ func TestLog(t *testing.T) {
 			if tt.err != nil {
 				require.Error(err)
 				require.True(tt.err.Is(err))
+			} else if tt.expected == nil {
+				require.NoError(err)
+				require.Nil(result)
 			} else {
 				require.NoError(err)
 				require.InEpsilonf(tt.expected, result, epsilon, fmt.Sprintf("Actual is: %v", result))
@@ -222,3 +234,51 @@ func TestLog(t *testing.T) {
 	require.Nil(result)
 	require.True(f.IsNullable())
 }
+
+func TestLogDomainWarnings(t *testing.T) {
+	ctx := sql.NewEmptyContext()
+	f, err := NewLog(expression.NewLiteral(float64(2), sql.Float64), expression.NewLiteral(float64(-8), sql.Float64))
+	require.NoError(t, err)
+
+	result, err := f.Eval(ctx, nil)
+	require.NoError(t, err)
+	require.Nil(t, result)
+	require.Equal(t, uint16(1), ctx.WarningCount())
+	require.Equal(t, invalidArgumentForLogarithmCode, ctx.Warnings()[0].Code)
+}
+
+func TestExp(t *testing.T) {
+	testCases := []struct {
+		name     string
+		row      sql.Row
+		expected interface{}
+		warning  bool
+	}{
+		{"nil", sql.NewRow(nil), nil, false},
+		{"zero", sql.NewRow(0), float64(1), false},
+		{"one", sql.NewRow(1), math.E, false},
+		{"negative", sql.NewRow(-1), 1 / math.E, false},
+		{"valid string", sql.NewRow("2"), math.Exp(2), false},
+		{"overflow", sql.NewRow(1000), nil, true},
+	}
+
+	f := NewExp(expression.NewGetField(0, sql.Float64, "", true))
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			ctx := sql.NewEmptyContext()
+			result, err := f.Eval(ctx, tt.row)
+			require.NoError(err)
+			if tt.expected == nil {
+				require.Nil(result)
+			} else {
+				require.InEpsilon(tt.expected, result, epsilon)
+			}
+			if tt.warning {
+				require.Equal(uint16(1), ctx.WarningCount())
+			} else {
+				require.Equal(uint16(0), ctx.WarningCount())
+			}
+		})
+	}
+}
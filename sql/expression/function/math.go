@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"hash/crc32"
 	"math"
+	"math/bits"
 	"math/rand"
 	"regexp"
 	"strconv"
@@ -27,6 +28,7 @@ import (
 	"github.com/shopspring/decimal"
 
 	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
 )
 
 // Rand returns a random float 0 <= x < 1. If it has an argument, that argument will be used to seed the random number
@@ -636,3 +638,43 @@ func (s *Sign) WithChildren(children ...sql.Expression) (sql.Expression, error)
 	}
 	return NewSign(children[0]), nil
 }
+
+// BitCount is the BIT_COUNT function, which returns the number of bits set in its argument, as an unsigned 64-bit
+// integer.
+type BitCount struct {
+	*UnaryFunc
+}
+
+var _ sql.FunctionExpression = (*BitCount)(nil)
+
+// NewBitCount returns a new BIT_COUNT function expression
+func NewBitCount(arg sql.Expression) sql.Expression {
+	return &BitCount{NewUnaryFunc(arg, "BIT_COUNT", sql.Int64)}
+}
+
+// Eval implements sql.Expression
+func (b *BitCount) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	arg, err := b.EvalChild(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+
+	if arg == nil {
+		return nil, nil
+	}
+
+	n, err := expression.ConvertToUint64(arg)
+	if err != nil {
+		return nil, err
+	}
+
+	return int64(bits.OnesCount64(n)), nil
+}
+
+// WithChildren implements sql.Expression
+func (b *BitCount) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(b, len(children), 1)
+	}
+	return NewBitCount(children[0]), nil
+}
@@ -169,6 +169,33 @@ func TestCRC32(t *testing.T) {
 	assert.Equal(t, nil, res)
 }
 
+func TestBitCount(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    interface{}
+		expected interface{}
+	}{
+		{"BIT_COUNT(0)", int64(0), int64(0)},
+		{"BIT_COUNT(7)", int64(7), int64(3)},
+		{"BIT_COUNT(uint8 255)", uint8(255), int64(8)},
+		{"BIT_COUNT(-1)", int64(-1), int64(64)},
+		{"BIT_COUNT(min int64)", int64(math.MinInt64), int64(1)},
+		{"BIT_COUNT(max int64)", int64(math.MaxInt64), int64(63)},
+		{"BIT_COUNT(max uint64)", uint64(math.MaxUint64), int64(64)},
+		{"BIT_COUNT(1 << 63)", uint64(1 << 63), int64(1)},
+		{"BIT_COUNT('5')", "5", int64(2)},
+		{"BIT_COUNT(NULL)", nil, nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := NewBitCount(expression.NewLiteral(test.input, nil)).Eval(sql.NewEmptyContext(), nil)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, res)
+		})
+	}
+}
+
 func TestTrigFunctions(t *testing.T) {
 	asin := sql.Function1{Name: "asin", Fn: NewAsin}
 	acos := sql.Function1{Name: "acos", Fn: NewAcos}
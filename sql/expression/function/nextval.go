@@ -0,0 +1,83 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+// NextVal is the NEXTVAL(name) function, which returns the next value of the sequence named and advances it. It can be
+// used in column defaults to give a column increasing values without AUTO_INCREMENT.
+type NextVal struct {
+	expression.UnaryExpression
+	sequences *sql.Sequences
+}
+
+var _ sql.FunctionExpression = (*NextVal)(nil)
+var _ sql.NonDeterministicExpression = (*NextVal)(nil)
+
+// NewNextVal returns a function creating NEXTVAL expressions that draw their values from the sequences given.
+func NewNextVal(sequences *sql.Sequences) sql.CreateFunc1Args {
+	return func(e sql.Expression) sql.Expression {
+		return &NextVal{UnaryExpression: expression.UnaryExpression{Child: e}, sequences: sequences}
+	}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (n *NextVal) FunctionName() string {
+	return "nextval"
+}
+
+// IsNonDeterministic implements sql.NonDeterministicExpression
+func (n *NextVal) IsNonDeterministic() bool {
+	return true
+}
+
+// Type implements sql.Expression
+func (n *NextVal) Type() sql.Type {
+	return sql.Int64
+}
+
+func (n *NextVal) String() string {
+	return fmt.Sprintf("NEXTVAL(%s)", n.Child)
+}
+
+// WithChildren implements sql.Expression
+func (n *NextVal) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(n, len(children), 1)
+	}
+	return NewNextVal(n.sequences)(children[0]), nil
+}
+
+// Eval implements sql.Expression
+func (n *NextVal) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	val, err := n.Child.Eval(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+	if val == nil {
+		return nil, nil
+	}
+
+	name, err := sql.LongText.Convert(val)
+	if err != nil {
+		return nil, err
+	}
+	return n.sequences.NextVal(name.(string))
+}
@@ -108,10 +108,26 @@ func (r *RegexpLike) String() string {
 	return fmt.Sprintf("regexp_like(%s)", strings.Join(args, ", "))
 }
 
-func (r *RegexpLike) compile(ctx *sql.Context) {
+// patternCanBeCached returns whether the pattern and the match type are the same for every row, so that the regular
+// expression only needs to be compiled once.
+func (r *RegexpLike) patternCanBeCached() bool {
+	return canBeCached(r.Pattern) && (r.Flags == nil || canBeCached(r.Flags))
+}
+
+func (r *RegexpLike) compile(ctx *sql.Context, row sql.Row) (*regexp.Regexp, error) {
+	collation, err := expression.RegexpCollation(r.Text, r.Pattern)
+	if err != nil {
+		return nil, err
+	}
+	caseSensitive := collation.IsCaseSensitive()
+
+	if !r.patternCanBeCached() {
+		return compileRegex(ctx, r.Pattern, r.Flags, r.FunctionName(), caseSensitive, row)
+	}
 	r.compileOnce.Do(func() {
-		r.re, r.compileErr = compileRegex(ctx, r.Pattern, r.Flags, r.FunctionName(), nil)
+		r.re, r.compileErr = compileRegex(ctx, r.Pattern, r.Flags, r.FunctionName(), caseSensitive, row)
 	})
+	return r.re, r.compileErr
 }
 
 // Eval implements the sql.Expression interface.
@@ -124,11 +140,11 @@ func (r *RegexpLike) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 		return cached, nil
 	}
 
-	r.compile(ctx)
-	if r.compileErr != nil {
-		return nil, r.compileErr
+	re, err := r.compile(ctx, row)
+	if err != nil {
+		return nil, err
 	}
-	if r.re == nil {
+	if re == nil {
 		return nil, nil
 	}
 
@@ -145,19 +161,22 @@ func (r *RegexpLike) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 	}
 
 	var outVal int8
-	if r.re.MatchString(text.(string)) {
+	if re.MatchString(text.(string)) {
 		outVal = int8(1)
 	} else {
 		outVal = int8(0)
 	}
 
-	if canBeCached(r.Text) {
+	if canBeCached(r.Text) && r.patternCanBeCached() {
 		r.cachedVal.Store(outVal)
 	}
 	return outVal, nil
 }
 
-func compileRegex(ctx *sql.Context, pattern, flags sql.Expression, funcName string, row sql.Row) (*regexp.Regexp, error) {
+// compileRegex compiles the pattern given with the match type given, which is a string of flags as described for
+// REGEXP_LIKE. Without flags, the match is case-sensitive depending on the argument given, which callers take from
+// the collation of their text and pattern. Returns nil if the pattern or the match type are NULL.
+func compileRegex(ctx *sql.Context, pattern, flags sql.Expression, funcName string, caseSensitive bool, row sql.Row) (*regexp.Regexp, error) {
 	patternVal, err := pattern.Eval(ctx, row)
 	if err != nil {
 		return nil, err
@@ -175,7 +194,11 @@ func compileRegex(ctx *sql.Context, pattern, flags sql.Expression, funcName stri
 		return nil, errors.NewKind("Illegal argument to regular expression.").New()
 	}
 
-	flagsStr := "(?i)"
+	// The match type given overrides the case sensitivity of the collation, as later flags override earlier ones
+	flagsStr := "i"
+	if caseSensitive {
+		flagsStr = "c"
+	}
 	if flags != nil {
 		f, err := flags.Eval(ctx, row)
 		if err != nil {
@@ -188,23 +211,32 @@ func compileRegex(ctx *sql.Context, pattern, flags sql.Expression, funcName stri
 		if err != nil {
 			return nil, err
 		}
+		flagsStr += f.(string)
+	}
+	flagsStr, err = consolidateRegexpFlags(flagsStr, funcName)
+	if err != nil {
+		return nil, err
+	}
 
-		flagsStr = f.(string)
-		flagsStr, err = consolidateRegexpFlags(flagsStr, funcName)
-		if err != nil {
-			return nil, err
+	prefix := ""
+	if flagsStr != "" {
+		prefix = fmt.Sprintf("(?%s)", flagsStr)
+	}
+	re, err := regexp.Compile(prefix + patternVal.(string))
+	if err != nil {
+		// The flags are valid, so the error is in the pattern, which is reported as the user wrote it
+		if _, patternErr := regexp.Compile(patternVal.(string)); patternErr != nil {
+			err = patternErr
 		}
-		flagsStr = fmt.Sprintf("(?%s)", flagsStr)
-		flagsStr = strings.Replace(flagsStr, "c", `\c`, -1)
+		return nil, expression.ErrInvalidRegexp.New(err.Error())
 	}
-	return regexp.Compile(flagsStr + patternVal.(string))
+	return re, nil
 }
 
 // consolidateRegexpFlags consolidates regexp flags by removing duplicates, resolving order of conflicting flags, and
 // verifying that all flags are valid.
 func consolidateRegexpFlags(flags, funcName string) (string, error) {
 	flagSet := make(map[string]struct{})
-	// The flag 'u' is unsupported for now, as there isn't an equivalent flag in golang's regexp library
 	for _, flag := range flags {
 		switch flag {
 		case 'c':
@@ -217,6 +249,8 @@ func consolidateRegexpFlags(flags, funcName string) (string, error) {
 			flagSet["m"] = struct{}{}
 		case 'n':
 			flagSet["s"] = struct{}{}
+		case 'u':
+			// Unix-only line endings are all golang's regexp library knows, as it only treats \n as a line terminator
 		default:
 			return "", sql.ErrInvalidArgument.New(funcName)
 		}
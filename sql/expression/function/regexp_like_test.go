@@ -263,6 +263,36 @@ func TestRegexpLikeWithFlags(t *testing.T) {
 			"ic",
 			0,
 		},
+		{
+			"fo\nfo",
+			"fo.fo",
+			"",
+			0,
+		},
+		{
+			"fo\nfo",
+			"fo.fo",
+			"n",
+			1,
+		},
+		{
+			"fo\nfo",
+			"^fo$",
+			"mu",
+			1,
+		},
+		{
+			"fofo",
+			"FOFO",
+			"u",
+			0,
+		},
+		{
+			"FO\nfo",
+			"^fo.FO$",
+			"nim",
+			1,
+		},
 	}
 
 	for _, test := range testCases {
@@ -279,6 +309,70 @@ func TestRegexpLikeWithFlags(t *testing.T) {
 	}
 }
 
+func TestRegexpLikeCollation(t *testing.T) {
+	ctx := sql.NewEmptyContext()
+	insensitive := sql.CreateLongText(sql.Collation_utf8mb4_0900_ai_ci)
+	testCases := []struct {
+		textType    sql.Type
+		patternType sql.Type
+		flags       string
+		expected    int8
+	}{
+		{sql.LongText, sql.LongText, "", 0},
+		{insensitive, sql.LongText, "", 0},
+		{sql.LongText, insensitive, "", 0},
+		{insensitive, sql.LongText, "i", 1},
+		{insensitive, insensitive, "", 1},
+		{insensitive, insensitive, "c", 0},
+		{sql.LongText, sql.LongText, "i", 1},
+		{sql.LongBlob, insensitive, "", 0},
+	}
+
+	for _, test := range testCases {
+		t.Run(fmt.Sprintf("%v|%v|%s", test.textType, test.patternType, test.flags), func(t *testing.T) {
+			args := []sql.Expression{
+				expression.NewLiteral("fofo", test.textType),
+				expression.NewLiteral("FOFO", test.patternType),
+			}
+			if test.flags != "" {
+				args = append(args, expression.NewLiteral(test.flags, sql.LongText))
+			}
+			f, err := NewRegexpLike(args...)
+			require.NoError(t, err)
+			res, err := f.Eval(ctx, nil)
+			require.NoError(t, err)
+			require.Equal(t, test.expected, res)
+		})
+	}
+}
+
+func TestRegexpLikePatternPerRow(t *testing.T) {
+	ctx := sql.NewEmptyContext()
+	f, err := NewRegexpLike(
+		expression.NewGetField(0, sql.LongText, "text", true),
+		expression.NewGetField(1, sql.LongText, "pattern", true),
+	)
+	require.NoError(t, err)
+
+	for _, test := range []struct {
+		row      sql.Row
+		expected interface{}
+	}{
+		{sql.NewRow("foo", "^f"), int8(1)},
+		{sql.NewRow("foo", "^o"), int8(0)},
+		{sql.NewRow("foo", "o$"), int8(1)},
+		{sql.NewRow("foo", nil), nil},
+		{sql.NewRow(nil, "o$"), nil},
+	} {
+		res, err := f.Eval(ctx, test.row)
+		require.NoError(t, err)
+		require.Equal(t, test.expected, res)
+	}
+
+	_, err = f.Eval(ctx, sql.NewRow("foo", "("))
+	require.True(t, expression.ErrInvalidRegexp.Is(err))
+}
+
 func TestRegexpLikeNilAndErrors(t *testing.T) {
 	ctx := sql.NewEmptyContext()
 
@@ -304,6 +398,18 @@ func TestRegexpLikeNilAndErrors(t *testing.T) {
 	_, err = f.Eval(ctx, nil)
 	require.True(t, sql.ErrInvalidArgument.Is(err))
 
+	for _, flags := range []string{"c", "i"} {
+		f, err = NewRegexpLike(
+			expression.NewLiteral("foo", sql.LongText),
+			expression.NewLiteral("(foo", sql.LongText),
+			expression.NewLiteral(flags, sql.LongText),
+		)
+		require.NoError(t, err)
+		_, err = f.Eval(ctx, nil)
+		require.True(t, expression.ErrInvalidRegexp.Is(err))
+		require.Equal(t, "Invalid regular expression: error parsing regexp: missing closing ): `(foo`", err.Error())
+	}
+
 	f, err = NewRegexpLike(
 		expression.NewLiteral(nil, sql.Null),
 		expression.NewLiteral("foo", sql.LongText),
@@ -107,7 +107,7 @@ func (r *RegexpReplace) Eval(ctx *sql.Context, row sql.Row) (interface{}, error)
 	}
 
 	// Create regex, should handle null pattern and null flags
-	re, compileErr := compileRegex(ctx, r.args[1], flags, r.FunctionName(), row)
+	re, compileErr := compileRegex(ctx, r.args[1], flags, r.FunctionName(), false, row)
 	if compileErr != nil {
 		return nil, compileErr
 	}
@@ -159,14 +159,10 @@ func (r *RegexpReplace) Eval(ctx *sql.Context, row sql.Row) (interface{}, error)
 		_pos = int(pos.(int32))
 	}
 
-	// Non-positive position throws incorrect parameter
-	if _pos <= 0 {
-		return nil, ErrInvalidArgument.New(r.FunctionName(), fmt.Sprintf("%d", _pos))
-	}
-
-	// Handle out of bounds
-	if _pos > len(_str) {
-		return nil, errors.NewKind("Index out of bounds for regular expression search.").New()
+	// Positions are counted in characters
+	offset, err := regexpOffset(_str, _pos, r.FunctionName())
+	if err != nil {
+		return nil, err
 	}
 
 	// Default occurrence is 0 (replace all occurrences)
@@ -195,47 +191,75 @@ func (r *RegexpReplace) Eval(ctx *sql.Context, row sql.Row) (interface{}, error)
 	// MySQL interprets negative occurrences as first for some reason
 	if _occ < 0 {
 		_occ = 1
-	} else if _occ == 0 {
-		// Replace everything
-		return _str[:_pos-1] + re.ReplaceAllString(_str[_pos-1:], _replaceStr), nil
 	}
 
-	// Split string into prefix and suffix
-	prefix := _str[:_pos-1]
-	suffix := _str[_pos-1:]
-
-	// Extract all matches
-	matches := re.FindAllString(suffix, -1)
-	indexes := re.FindAllStringIndex(suffix, -1)
-
-	// No matches, return original string
-	if len(matches) == 0 {
-		return _str, nil
-	}
+	// Replace the nth occurrence in the suffix, or all of them if it's 0
+	prefix := _str[:offset]
+	suffix := _str[offset:]
+	template := regexpReplacement(_replaceStr)
 
-	// If there aren't enough occurrences
-	if _occ > len(matches) {
-		return _str, nil
+	var res []byte
+	last := 0
+	for i, match := range re.FindAllStringSubmatchIndex(suffix, -1) {
+		if _occ != 0 && i != _occ-1 {
+			continue
+		}
+		res = append(res, suffix[last:match[0]]...)
+		res = re.ExpandString(res, template, suffix, match)
+		last = match[1]
 	}
+	res = append(res, suffix[last:]...)
 
-	// Replace only the nth occurrence
-	matches[_occ-1] = _replaceStr
+	return prefix + string(res), nil
+}
 
-	// Initialize result string
-	res := prefix                 // attach prefix
-	res += suffix[:indexes[0][0]] // attach text before first match
-	res += matches[0]             // attach first match
+// regexpOffset returns the byte offset of the character at the position given, which starts at 1, in the string
+// given, or an error if the position is out of its bounds.
+func regexpOffset(str string, pos int, funcName string) (int, error) {
+	// Non-positive position throws incorrect parameter
+	if pos <= 0 {
+		return 0, ErrInvalidArgument.New(funcName, fmt.Sprintf("%d", pos))
+	}
 
-	// Recombine rest of matches
-	for i := 1; i < len(matches); i++ {
-		// Attach text before match
-		res += suffix[indexes[i-1][1]:indexes[i][0]] // end of prev to start of curr match
-		// Attach match
-		res += matches[i]
+	chars := 0
+	for offset := range str {
+		chars++
+		if chars == pos {
+			return offset, nil
+		}
 	}
 
-	// Append text after last match
-	res += suffix[indexes[len(indexes)-1][1]:]
+	// Handle out of bounds
+	return 0, errors.NewKind("Index out of bounds for regular expression search.").New()
+}
 
-	return res, nil
+// regexpReplacement converts the replacement string given to a template of the regexp package. In the replacement
+// string, as in MySQL, $n refers to the nth capture group, and a backslash escapes the character that follows it.
+func regexpReplacement(repl string) string {
+	var sb strings.Builder
+	for i := 0; i < len(repl); i++ {
+		switch c := repl[i]; {
+		case c == '\\' && i+1 < len(repl):
+			i++
+			if repl[i] == '$' {
+				sb.WriteString("$$")
+			} else {
+				sb.WriteByte(repl[i])
+			}
+		case c == '$':
+			j := i + 1
+			for j < len(repl) && repl[j] >= '0' && repl[j] <= '9' {
+				j++
+			}
+			if j == i+1 {
+				sb.WriteString("$$")
+			} else {
+				sb.WriteString("${" + repl[i+1:j] + "}")
+				i = j - 1
+			}
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	return sb.String()
 }
@@ -375,3 +375,67 @@ func TestRegexpReplaceWithFlags(t *testing.T) {
 		})
 	}
 }
+
+func TestRegexpReplaceWithBackreferences(t *testing.T) {
+	f, err := NewRegexpReplace(
+		expression.NewGetField(0, sql.LongText, "str", true),
+		expression.NewGetField(1, sql.LongText, "pattern", true),
+		expression.NewGetField(2, sql.LongText, "replaceStr", true),
+		expression.NewGetField(3, sql.LongText, "position", true),
+		expression.NewGetField(4, sql.LongText, "occurrence", true),
+	)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		row      sql.Row
+		expected interface{}
+	}{
+		{
+			"swapped groups",
+			sql.NewRow("abc def ghi", `([a-z])([a-z]+)`, "$2$1", 1, 0),
+			"bca efd hig",
+		},
+		{
+			"group followed by text",
+			sql.NewRow("abc def ghi", `([a-z]+)`, "$1x", 1, 0),
+			"abcx defx ghix",
+		},
+		{
+			"whole match",
+			sql.NewRow("abc def ghi", `[a-z]+`, "<$0>", 1, 2),
+			"abc <def> ghi",
+		},
+		{
+			"escaped dollar and backslash",
+			sql.NewRow("abc def", `([a-z]+)`, `\$1\\`, 1, 1),
+			`$1\ def`,
+		},
+		{
+			"missing group",
+			sql.NewRow("abc def", `([a-z]+)`, "$2", 1, 0),
+			" ",
+		},
+		{
+			"occurrence after a position",
+			sql.NewRow("a1 b2 c3 d4", `([a-z])([0-9])`, "$2$1", 4, 2),
+			"a1 b2 3c d4",
+		},
+		{
+			"position in characters",
+			sql.NewRow("héllo wörld", `([lo])`, "[$1]", 4, 0),
+			"hél[l][o] wör[l]d",
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			ctx := sql.NewEmptyContext()
+
+			val, err := f.Eval(ctx, tt.row)
+			require.NoError(err)
+			require.Equal(tt.expected, val)
+		})
+	}
+}
@@ -0,0 +1,150 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// RegexpSubstr implements the REGEXP_SUBSTR function.
+// https://dev.mysql.com/doc/refman/8.0/en/regexp.html#function_regexp-substr
+type RegexpSubstr struct {
+	args []sql.Expression
+}
+
+var _ sql.FunctionExpression = (*RegexpSubstr)(nil)
+
+// NewRegexpSubstr creates a new RegexpSubstr expression.
+func NewRegexpSubstr(args ...sql.Expression) (sql.Expression, error) {
+	if len(args) < 2 || len(args) > 5 {
+		return nil, sql.ErrInvalidArgumentNumber.New("regexp_substr", "2,3,4 or 5", len(args))
+	}
+
+	return &RegexpSubstr{args: args}, nil
+}
+
+// FunctionName implements sql.FunctionExpression
+func (r *RegexpSubstr) FunctionName() string {
+	return "regexp_substr"
+}
+
+// Type implements the sql.Expression interface.
+func (r *RegexpSubstr) Type() sql.Type { return sql.LongText }
+
+// IsNullable implements the sql.Expression interface.
+func (r *RegexpSubstr) IsNullable() bool { return true }
+
+// Children implements the sql.Expression interface.
+func (r *RegexpSubstr) Children() []sql.Expression {
+	return r.args
+}
+
+// Resolved implements the sql.Expression interface.
+func (r *RegexpSubstr) Resolved() bool {
+	for _, arg := range r.args {
+		if !arg.Resolved() {
+			return false
+		}
+	}
+	return true
+}
+
+// WithChildren implements the sql.Expression interface.
+func (r *RegexpSubstr) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != len(r.args) {
+		return nil, sql.ErrInvalidChildrenNumber.New(r, len(children), len(r.args))
+	}
+	return NewRegexpSubstr(children...)
+}
+
+func (r *RegexpSubstr) String() string {
+	var args []string
+	for _, e := range r.args {
+		args = append(args, e.String())
+	}
+	return fmt.Sprintf("regexp_substr(%s)", strings.Join(args, ", "))
+}
+
+// Eval implements the sql.Expression interface.
+func (r *RegexpSubstr) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	str, err := r.args[0].Eval(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+	if str == nil {
+		return nil, nil
+	}
+	str, err = sql.LongText.Convert(str)
+	if err != nil {
+		return nil, err
+	}
+	s := str.(string)
+
+	var flags sql.Expression = nil
+	if len(r.args) == 5 {
+		flags = r.args[4]
+	}
+
+	re, err := compileRegex(ctx, r.args[1], flags, r.FunctionName(), false, row)
+	if err != nil {
+		return nil, err
+	}
+	if re == nil {
+		return nil, nil
+	}
+
+	// An empty string has no substring to match
+	if len(s) == 0 {
+		return nil, nil
+	}
+
+	pos := int64(1)
+	if len(r.args) >= 3 {
+		p, err := evalInt64Arg(ctx, row, r.args[2])
+		if err != nil || p == nil {
+			return nil, err
+		}
+		pos = *p
+	}
+
+	// Positions are counted in characters
+	offset, err := regexpOffset(s, int(pos), r.FunctionName())
+	if err != nil {
+		return nil, err
+	}
+
+	occ := int64(1)
+	if len(r.args) >= 4 {
+		o, err := evalInt64Arg(ctx, row, r.args[3])
+		if err != nil || o == nil {
+			return nil, err
+		}
+		occ = *o
+	}
+
+	// Non-positive occurrences are the first one, as in MySQL
+	if occ < 1 {
+		occ = 1
+	}
+
+	matches := re.FindAllString(s[offset:], int(occ))
+	if int64(len(matches)) < occ {
+		return nil, nil
+	}
+	return matches[occ-1], nil
+}
@@ -0,0 +1,102 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+func TestRegexpSubstrInvalidArgNumber(t *testing.T) {
+	_, err := NewRegexpSubstr()
+	require.Error(t, err)
+
+	_, err = NewRegexpSubstr(
+		expression.NewGetField(0, sql.LongText, "str", true),
+	)
+	require.Error(t, err)
+
+	_, err = NewRegexpSubstr(
+		expression.NewGetField(0, sql.LongText, "str", true),
+		expression.NewGetField(1, sql.LongText, "pattern", true),
+		expression.NewGetField(2, sql.LongText, "position", true),
+		expression.NewGetField(3, sql.LongText, "occurrence", true),
+		expression.NewGetField(4, sql.LongText, "flags", true),
+		expression.NewGetField(5, sql.LongText, "???", true),
+	)
+	require.Error(t, err)
+}
+
+func TestRegexpSubstr(t *testing.T) {
+	f, err := NewRegexpSubstr(
+		expression.NewGetField(0, sql.LongText, "str", true),
+		expression.NewGetField(1, sql.LongText, "pattern", true),
+		expression.NewGetField(2, sql.LongText, "position", true),
+		expression.NewGetField(3, sql.LongText, "occurrence", true),
+		expression.NewGetField(4, sql.LongText, "flags", true),
+	)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		row      sql.Row
+		expected interface{}
+		err      bool
+	}{
+		{"nil str", sql.NewRow(nil, `[a-z]+`, 1, 1, "c"), nil, false},
+		{"nil pattern", sql.NewRow("abc def", nil, 1, 1, "c"), nil, false},
+		{"nil position", sql.NewRow("abc def", `[a-z]+`, nil, 1, "c"), nil, false},
+		{"nil occurrence", sql.NewRow("abc def", `[a-z]+`, 1, nil, "c"), nil, false},
+		{"nil flags", sql.NewRow("abc def", `[a-z]+`, 1, 1, nil), nil, false},
+		{"empty str", sql.NewRow("", `[a-z]+`, 1, 1, "c"), nil, false},
+		{"empty pattern", sql.NewRow("abc def", ``, 1, 1, "c"), nil, true},
+		{"zero position", sql.NewRow("abc def", `[a-z]+`, 0, 1, "c"), nil, true},
+		{"too large position", sql.NewRow("abc def", `[a-z]+`, 100, 1, "c"), nil, true},
+		{"invalid flags", sql.NewRow("abc def", `[a-z]+`, 1, 1, "x"), nil, true},
+		{"first occurrence", sql.NewRow("abc def ghi", `[a-z]+`, 1, 1, "c"), "abc", false},
+		{"zero occurrence", sql.NewRow("abc def ghi", `[a-z]+`, 1, 0, "c"), "abc", false},
+		{"later occurrence", sql.NewRow("abc def ghi", `[a-z]+`, 1, 3, "c"), "ghi", false},
+		{"too large occurrence", sql.NewRow("abc def ghi", `[a-z]+`, 1, 4, "c"), nil, false},
+		{"position", sql.NewRow("abc def ghi", `[a-z]+`, 2, 2, "c"), "def", false},
+		{"position in characters", sql.NewRow("héllo wörld", `[a-zö]+`, 3, 2, "c"), "wörld", false},
+		{"no match", sql.NewRow("abc def", `[0-9]+`, 1, 1, "c"), nil, false},
+		{"case-sensitive", sql.NewRow("ABC def", `[a-z]+`, 1, 1, "c"), "def", false},
+		{"case-insensitive", sql.NewRow("ABC def", `[a-z]+`, 1, 1, "i"), "ABC", false},
+		{"last case flag wins", sql.NewRow("ABC def", `[a-z]+`, 1, 1, "ic"), "def", false},
+		{"multiline", sql.NewRow("abc\ndef", `^d.*$`, 1, 1, "m"), "def", false},
+		{"not multiline", sql.NewRow("abc\ndef", `^d.*$`, 1, 1, "c"), nil, false},
+		{"dot matches newlines", sql.NewRow("abc\ndef", `c.d`, 1, 1, "n"), "c\nd", false},
+		{"dot doesn't match newlines", sql.NewRow("abc\ndef", `c.d`, 1, 1, "c"), nil, false},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			ctx := sql.NewEmptyContext()
+
+			val, err := f.Eval(ctx, tt.row)
+			if tt.err {
+				require.Error(err)
+			} else {
+				require.NoError(err)
+				require.Equal(tt.expected, val)
+			}
+		})
+	}
+}
@@ -30,9 +30,10 @@ var ErrFunctionAlreadyRegistered = errors.NewKind("function '%s' is already regi
 
 // BuiltIns is the set of built-in functions any integrator can use
 var BuiltIns = []sql.Function{
-	// elt, find_in_set, insert, load_file, locate
+	// elt, insert, load_file, locate
 	sql.Function1{Name: "abs", Fn: NewAbsVal},
 	sql.Function1{Name: "acos", Fn: NewAcos},
+	sql.Function1{Name: "any_value", Fn: NewAnyValue},
 	sql.Function1{Name: "array_length", Fn: NewArrayLength},
 	sql.Function1{Name: "ascii", Fn: NewAscii},
 	sql.Function1{Name: "asin", Fn: NewAsin},
@@ -40,7 +41,11 @@ var BuiltIns = []sql.Function{
 	sql.Function1{Name: "avg", Fn: func(e sql.Expression) sql.Expression { return aggregation.NewAvg(e) }},
 	sql.Function1{Name: "bin", Fn: NewBin},
 	sql.FunctionN{Name: "bin_to_uuid", Fn: NewBinToUUID},
+	sql.Function1{Name: "bit_and", Fn: func(e sql.Expression) sql.Expression { return aggregation.NewBitAnd(e) }},
+	sql.Function1{Name: "bit_count", Fn: NewBitCount},
 	sql.Function1{Name: "bit_length", Fn: NewBitlength},
+	sql.Function1{Name: "bit_or", Fn: func(e sql.Expression) sql.Expression { return aggregation.NewBitOr(e) }},
+	sql.Function1{Name: "bit_xor", Fn: func(e sql.Expression) sql.Expression { return aggregation.NewBitXor(e) }},
 	sql.Function1{Name: "ceil", Fn: NewCeil},
 	sql.Function1{Name: "ceiling", Fn: NewCeil},
 	sql.Function1{Name: "char_length", Fn: NewCharLength},
@@ -73,7 +78,10 @@ var BuiltIns = []sql.Function{
 	sql.Function1{Name: "dayofweek", Fn: NewDayOfWeek},
 	sql.Function1{Name: "dayofyear", Fn: NewDayOfYear},
 	sql.Function1{Name: "degrees", Fn: NewDegrees},
+	sql.Function1{Name: "exp", Fn: NewExp},
 	sql.Function1{Name: "explode", Fn: NewExplode},
+	sql.FunctionN{Name: "field", Fn: NewField},
+	sql.Function2{Name: "find_in_set", Fn: NewFindInSet},
 	sql.Function1{Name: "first", Fn: func(e sql.Expression) sql.Expression { return aggregation.NewFirst(e) }},
 	sql.Function1{Name: "floor", Fn: NewFloor},
 	sql.Function0{Name: "found_rows", Fn: NewFoundRows},
@@ -129,6 +137,7 @@ var BuiltIns = []sql.Function{
 	sql.FunctionN{Name: "json_valid", Fn: NewJSONValid},
 	sql.FunctionN{Name: "json_value", Fn: NewJSONValue},
 	sql.Function1{Name: "last", Fn: func(e sql.Expression) sql.Expression { return aggregation.NewLast(e) }},
+	sql.Function1{Name: "last_day", Fn: NewLastDay},
 	sql.Function0{Name: "last_insert_id", Fn: NewLastInsertId},
 	sql.Function1{Name: "lcase", Fn: NewLower},
 	sql.FunctionN{Name: "least", Fn: NewLeast},
@@ -142,6 +151,8 @@ var BuiltIns = []sql.Function{
 	sql.Function1{Name: "lower", Fn: NewLower},
 	sql.FunctionN{Name: "lpad", Fn: NewLeftPad},
 	sql.Function1{Name: "ltrim", Fn: NewLeftTrim},
+	sql.Function2{Name: "makedate", Fn: NewMakeDate},
+	sql.Function3{Name: "maketime", Fn: NewMakeTime},
 	sql.Function1{Name: "max", Fn: func(e sql.Expression) sql.Expression { return aggregation.NewMax(e) }},
 	sql.Function1{Name: "md5", Fn: NewMD5},
 	sql.Function1{Name: "microsecond", Fn: NewMicrosecond},
@@ -152,12 +163,14 @@ var BuiltIns = []sql.Function{
 	sql.Function1{Name: "monthname", Fn: NewMonthName},
 	sql.FunctionN{Name: "now", Fn: NewNow},
 	sql.Function2{Name: "nullif", Fn: NewNullIf},
+	sql.Function2{Name: "point", Fn: NewPoint},
 	sql.Function2{Name: "pow", Fn: NewPower},
 	sql.Function2{Name: "power", Fn: NewPower},
 	sql.Function1{Name: "radians", Fn: NewRadians},
 	sql.FunctionN{Name: "rand", Fn: NewRand},
 	sql.FunctionN{Name: "regexp_like", Fn: NewRegexpLike},
 	sql.FunctionN{Name: "regexp_replace", Fn: NewRegexpReplace},
+	sql.FunctionN{Name: "regexp_substr", Fn: NewRegexpSubstr},
 	sql.Function2{Name: "repeat", Fn: NewRepeat},
 	sql.Function3{Name: "replace", Fn: NewReplace},
 	sql.Function1{Name: "reverse", Fn: NewReverse},
@@ -165,8 +178,12 @@ var BuiltIns = []sql.Function{
 	sql.FunctionN{Name: "round", Fn: NewRound},
 	sql.Function0{Name: "row_count", Fn: NewRowCount},
 	sql.Function0{Name: "row_number", Fn: window.NewRowNumber},
+	sql.Function0{Name: "rank", Fn: window.NewRank},
+	sql.Function0{Name: "dense_rank", Fn: window.NewDenseRank},
 	sql.Function0{Name: "percent_rank", Fn: window.NewPercentRank},
 	sql.Function1{Name: "first_value", Fn: window.NewFirstValue},
+	sql.FunctionN{Name: "lag", Fn: window.NewLag},
+	sql.FunctionN{Name: "lead", Fn: window.NewLead},
 	sql.FunctionN{Name: "rpad", Fn: NewRightPad},
 	sql.Function1{Name: "rtrim", Fn: NewRightTrim},
 	sql.Function0{Name: "schema", Fn: NewDatabase},
@@ -180,6 +197,15 @@ var BuiltIns = []sql.Function{
 	sql.Function1{Name: "soundex", Fn: NewSoundex},
 	sql.Function2{Name: "split", Fn: NewSplit},
 	sql.Function1{Name: "sqrt", Fn: NewSqrt},
+	sql.Function1{Name: "st_astext", Fn: NewAsText},
+	sql.Function2{Name: "st_contains", Fn: NewSTContains},
+	sql.Function1{Name: "st_geomfromtext", Fn: NewGeomFromText},
+	sql.Function2{Name: "st_intersects", Fn: NewSTIntersects},
+	sql.Function2{Name: "st_within", Fn: NewSTWithin},
+	sql.Function1{Name: "std", Fn: func(e sql.Expression) sql.Expression { return aggregation.NewStd(e) }},
+	sql.Function1{Name: "stddev", Fn: func(e sql.Expression) sql.Expression { return aggregation.NewStdDev(e) }},
+	sql.Function1{Name: "stddev_pop", Fn: func(e sql.Expression) sql.Expression { return aggregation.NewStdDevPop(e) }},
+	sql.Function1{Name: "stddev_samp", Fn: func(e sql.Expression) sql.Expression { return aggregation.NewStdDevSamp(e) }},
 	sql.FunctionN{Name: "substr", Fn: NewSubstring},
 	sql.FunctionN{Name: "substring", Fn: NewSubstring},
 	sql.Function3{Name: "substring_index", Fn: NewSubstringIndex},
@@ -200,6 +226,9 @@ var BuiltIns = []sql.Function{
 	sql.FunctionN{Name: "uuid_to_bin", Fn: NewUUIDToBin},
 	sql.FunctionN{Name: "week", Fn: NewWeek},
 	sql.Function1{Name: "values", Fn: NewValues},
+	sql.Function1{Name: "var_pop", Fn: func(e sql.Expression) sql.Expression { return aggregation.NewVarPop(e) }},
+	sql.Function1{Name: "var_samp", Fn: func(e sql.Expression) sql.Expression { return aggregation.NewVarSamp(e) }},
+	sql.Function1{Name: "variance", Fn: func(e sql.Expression) sql.Expression { return aggregation.NewVariance(e) }},
 	sql.Function1{Name: "weekday", Fn: NewWeekday},
 	sql.Function1{Name: "weekofyear", Fn: NewWeekOfYear},
 	sql.Function1{Name: "year", Fn: NewYear},
@@ -216,6 +245,13 @@ func GetLockingFuncs(ls *sql.LockSubsystem) []sql.Function {
 	}
 }
 
+// GetSequenceFuncs returns the functions using the sequences given
+func GetSequenceFuncs(sequences *sql.Sequences) []sql.Function {
+	return []sql.Function{
+		sql.Function1{Name: "nextval", Fn: NewNextVal(sequences)},
+	}
+}
+
 // Registry is used to register functions
 type Registry map[string]sql.Function
 
@@ -228,12 +264,18 @@ func NewRegistry() Registry {
 	return fr
 }
 
-// Register registers functions, returning an error if it's already registered
+// Register registers functions, returning an error if it's already registered or if it's an aggregate function with
+// invalid metadata
 func (r Registry) Register(fn ...sql.Function) error {
 	for _, f := range fn {
 		if _, ok := r[f.FunctionName()]; ok {
 			return ErrFunctionAlreadyRegistered.New(f.FunctionName())
 		}
+		if af, ok := f.(sql.AggregateFunction); ok {
+			if err := af.Validate(); err != nil {
+				return err
+			}
+		}
 		r[f.FunctionName()] = f
 	}
 	return nil
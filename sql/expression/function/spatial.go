@@ -0,0 +1,279 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+// Point(x, y)
+//
+// Point constructs a Point using its coordinates. Returns NULL if any argument is NULL.
+type Point struct {
+	expression.BinaryExpression
+}
+
+var _ sql.FunctionExpression = (*Point)(nil)
+
+// NewPoint creates a new Point function.
+func NewPoint(x, y sql.Expression) sql.Expression {
+	return &Point{expression.BinaryExpression{Left: x, Right: y}}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (p *Point) FunctionName() string {
+	return "point"
+}
+
+// Type implements the sql.Expression interface.
+func (p *Point) Type() sql.Type {
+	return sql.Geometry
+}
+
+// IsNullable implements the sql.Expression interface.
+func (p *Point) IsNullable() bool {
+	return p.Left.IsNullable() || p.Right.IsNullable()
+}
+
+func (p *Point) String() string {
+	return fmt.Sprintf("point(%s, %s)", p.Left, p.Right)
+}
+
+// Eval implements the sql.Expression interface.
+func (p *Point) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	x, err := p.Left.Eval(ctx, row)
+	if err != nil || x == nil {
+		return nil, err
+	}
+	y, err := p.Right.Eval(ctx, row)
+	if err != nil || y == nil {
+		return nil, err
+	}
+
+	x, err = sql.Float64.Convert(x)
+	if err != nil {
+		return nil, err
+	}
+	y, err = sql.Float64.Convert(y)
+	if err != nil {
+		return nil, err
+	}
+	return sql.Point{X: x.(float64), Y: y.(float64)}, nil
+}
+
+// WithChildren implements the sql.Expression interface.
+func (p *Point) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 2 {
+		return nil, sql.ErrInvalidChildrenNumber.New(p, len(children), 2)
+	}
+	return NewPoint(children[0], children[1]), nil
+}
+
+// ST_GEOMFROMTEXT(wkt)
+//
+// GeomFromText constructs a geometry from its well-known text representation, which must be a POINT or a POLYGON.
+// Returns NULL if the argument is NULL. An error occurs if the argument isn't a valid representation of a geometry.
+type GeomFromText struct {
+	expression.UnaryExpression
+}
+
+var _ sql.FunctionExpression = (*GeomFromText)(nil)
+
+// NewGeomFromText creates a new GeomFromText function.
+func NewGeomFromText(e sql.Expression) sql.Expression {
+	return &GeomFromText{expression.UnaryExpression{Child: e}}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (g *GeomFromText) FunctionName() string {
+	return "st_geomfromtext"
+}
+
+// Type implements the sql.Expression interface.
+func (g *GeomFromText) Type() sql.Type {
+	return sql.Geometry
+}
+
+func (g *GeomFromText) String() string {
+	return fmt.Sprintf("st_geomfromtext(%s)", g.Child)
+}
+
+// Eval implements the sql.Expression interface.
+func (g *GeomFromText) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	val, err := g.Child.Eval(ctx, row)
+	if err != nil || val == nil {
+		return nil, err
+	}
+	val, err = sql.LongText.Convert(val)
+	if err != nil {
+		return nil, err
+	}
+
+	geometry, ok := parseWKT(val.(string))
+	if !ok {
+		return nil, sql.ErrInvalidGISData.New(g.FunctionName())
+	}
+	return geometry, nil
+}
+
+// WithChildren implements the sql.Expression interface.
+func (g *GeomFromText) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(g, len(children), 1)
+	}
+	return NewGeomFromText(children[0]), nil
+}
+
+// ST_ASTEXT(g)
+//
+// AsText returns the well-known text representation of a geometry. Returns NULL if the argument is NULL. An error
+// occurs if the argument isn't a geometry.
+type AsText struct {
+	expression.UnaryExpression
+}
+
+var _ sql.FunctionExpression = (*AsText)(nil)
+
+// NewAsText creates a new AsText function.
+func NewAsText(e sql.Expression) sql.Expression {
+	return &AsText{expression.UnaryExpression{Child: e}}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (a *AsText) FunctionName() string {
+	return "st_astext"
+}
+
+// Type implements the sql.Expression interface.
+func (a *AsText) Type() sql.Type {
+	return sql.LongText
+}
+
+func (a *AsText) String() string {
+	return fmt.Sprintf("st_astext(%s)", a.Child)
+}
+
+// Eval implements the sql.Expression interface.
+func (a *AsText) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	geometry, err := evalGeometry(ctx, row, a.Child, a.FunctionName())
+	if err != nil || geometry == nil {
+		return nil, err
+	}
+	return geometry.WKT(), nil
+}
+
+// WithChildren implements the sql.Expression interface.
+func (a *AsText) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(a, len(children), 1)
+	}
+	return NewAsText(children[0]), nil
+}
+
+// evalGeometry evaluates the expression given, which must return a geometry, for the spatial function named. Returns
+// nil if the expression returns NULL.
+func evalGeometry(ctx *sql.Context, row sql.Row, e sql.Expression, function string) (sql.GeometryValue, error) {
+	val, err := e.Eval(ctx, row)
+	if err != nil || val == nil {
+		return nil, err
+	}
+	geometry, ok := val.(sql.GeometryValue)
+	if !ok {
+		return nil, sql.ErrInvalidGISData.New(function)
+	}
+	return geometry, nil
+}
+
+// parseWKT parses the well-known text representation of a POINT or a POLYGON, as in POINT(1 2) or
+// POLYGON((0 0,1 0,1 1,0 0)). Returns false if it isn't a valid representation of either.
+func parseWKT(wkt string) (sql.GeometryValue, bool) {
+	wkt = strings.TrimSpace(wkt)
+	paren := strings.IndexByte(wkt, '(')
+	if paren < 0 || !strings.HasSuffix(wkt, ")") {
+		return nil, false
+	}
+	body := wkt[paren+1 : len(wkt)-1]
+
+	switch strings.ToUpper(strings.TrimSpace(wkt[:paren])) {
+	case "POINT":
+		return parseWKTPoint(body)
+	case "POLYGON":
+		var polygon sql.Polygon
+		for _, ring := range splitWKTRings(body) {
+			ring = strings.TrimSpace(ring)
+			if !strings.HasPrefix(ring, "(") || !strings.HasSuffix(ring, ")") {
+				return nil, false
+			}
+			var points []sql.Point
+			for _, coordinates := range strings.Split(ring[1:len(ring)-1], ",") {
+				point, ok := parseWKTPoint(coordinates)
+				if !ok {
+					return nil, false
+				}
+				points = append(points, point)
+			}
+			// Rings must be closed, which takes at least four points
+			if len(points) < 4 || points[0] != points[len(points)-1] {
+				return nil, false
+			}
+			polygon.Rings = append(polygon.Rings, points)
+		}
+		return polygon, len(polygon.Rings) > 0
+	default:
+		return nil, false
+	}
+}
+
+// parseWKTPoint parses the coordinates of a point in well-known text, as in 1 2.
+func parseWKTPoint(coordinates string) (sql.Point, bool) {
+	fields := strings.Fields(coordinates)
+	if len(fields) != 2 {
+		return sql.Point{}, false
+	}
+	x, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return sql.Point{}, false
+	}
+	y, err := strconv.ParseFloat(fields[1], 64)
+	if err != nil {
+		return sql.Point{}, false
+	}
+	return sql.Point{X: x, Y: y}, true
+}
+
+// splitWKTRings splits the rings of a polygon in well-known text on the commas between them.
+func splitWKTRings(rings string) []string {
+	var split []string
+	depth, start := 0, 0
+	for i, c := range rings {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				split = append(split, rings[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(split, rings[start:])
+}
@@ -0,0 +1,289 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+// SpatialRelation is a function returning whether two geometries are in a spatial relation, computed with planar
+// geometry for points and polygons. Returns NULL if any argument is NULL. An error occurs if any argument isn't a
+// geometry.
+type SpatialRelation struct {
+	expression.BinaryExpression
+	relation spatialRelation
+}
+
+var _ sql.FunctionExpression = (*SpatialRelation)(nil)
+
+// spatialRelation is a relation between two geometries tested by a SpatialRelation.
+type spatialRelation int
+
+const (
+	relationContains spatialRelation = iota
+	relationWithin
+	relationIntersects
+)
+
+// ST_CONTAINS(g1, g2)
+//
+// NewSTContains returns whether g1 completely contains g2: no point of g2 lies outside of g1, and at least one point of
+// the interior of g2 lies in the interior of g1. A polygon doesn't contain the points on its boundary.
+func NewSTContains(g1, g2 sql.Expression) sql.Expression {
+	return &SpatialRelation{expression.BinaryExpression{Left: g1, Right: g2}, relationContains}
+}
+
+// ST_WITHIN(g1, g2)
+//
+// NewSTWithin returns whether g1 is within g2, which is the case when g2 contains g1.
+func NewSTWithin(g1, g2 sql.Expression) sql.Expression {
+	return &SpatialRelation{expression.BinaryExpression{Left: g1, Right: g2}, relationWithin}
+}
+
+// ST_INTERSECTS(g1, g2)
+//
+// NewSTIntersects returns whether g1 and g2 have any point in common, including the points on their boundaries.
+func NewSTIntersects(g1, g2 sql.Expression) sql.Expression {
+	return &SpatialRelation{expression.BinaryExpression{Left: g1, Right: g2}, relationIntersects}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (s *SpatialRelation) FunctionName() string {
+	switch s.relation {
+	case relationContains:
+		return "st_contains"
+	case relationWithin:
+		return "st_within"
+	default:
+		return "st_intersects"
+	}
+}
+
+// Type implements the sql.Expression interface.
+func (s *SpatialRelation) Type() sql.Type {
+	return sql.Boolean
+}
+
+// IsNullable implements the sql.Expression interface.
+func (s *SpatialRelation) IsNullable() bool {
+	return s.Left.IsNullable() || s.Right.IsNullable()
+}
+
+func (s *SpatialRelation) String() string {
+	return fmt.Sprintf("%s(%s, %s)", s.FunctionName(), s.Left, s.Right)
+}
+
+// Eval implements the sql.Expression interface.
+func (s *SpatialRelation) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	g1, err := evalGeometry(ctx, row, s.Left, s.FunctionName())
+	if err != nil || g1 == nil {
+		return nil, err
+	}
+	g2, err := evalGeometry(ctx, row, s.Right, s.FunctionName())
+	if err != nil || g2 == nil {
+		return nil, err
+	}
+
+	switch s.relation {
+	case relationContains:
+		return contains(g1, g2), nil
+	case relationWithin:
+		return contains(g2, g1), nil
+	default:
+		return intersects(g1, g2), nil
+	}
+}
+
+// WithChildren implements the sql.Expression interface.
+func (s *SpatialRelation) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 2 {
+		return nil, sql.ErrInvalidChildrenNumber.New(s, len(children), 2)
+	}
+	return &SpatialRelation{expression.BinaryExpression{Left: children[0], Right: children[1]}, s.relation}, nil
+}
+
+// location is where a point lies relative to a polygon.
+type location int
+
+const (
+	exterior location = iota
+	boundary
+	interior
+)
+
+// contains returns whether the geometry g1 contains the geometry g2.
+func contains(g1, g2 sql.GeometryValue) bool {
+	switch g1 := g1.(type) {
+	case sql.Point:
+		p2, ok := g2.(sql.Point)
+		return ok && g1 == p2
+	case sql.Polygon:
+		switch g2 := g2.(type) {
+		case sql.Point:
+			return locate(g2, g1) == interior
+		case sql.Polygon:
+			return polygonContainsPolygon(g1, g2)
+		}
+	}
+	return false
+}
+
+// intersects returns whether the geometries g1 and g2 have any point in common.
+func intersects(g1, g2 sql.GeometryValue) bool {
+	switch g1 := g1.(type) {
+	case sql.Point:
+		switch g2 := g2.(type) {
+		case sql.Point:
+			return g1 == g2
+		case sql.Polygon:
+			return locate(g1, g2) != exterior
+		}
+	case sql.Polygon:
+		switch g2 := g2.(type) {
+		case sql.Point:
+			return locate(g2, g1) != exterior
+		case sql.Polygon:
+			return polygonsIntersect(g1, g2)
+		}
+	}
+	return false
+}
+
+// polygonContainsPolygon returns whether the polygon p1 contains the polygon p2: none of the vertices of p2 or the
+// midpoints of its edges lie outside p1, the boundaries of the polygons don't cross, and no vertex of p1 lies inside
+// p2, as one of a hole of p1 would.
+func polygonContainsPolygon(p1, p2 sql.Polygon) bool {
+	for _, ring := range p2.Rings {
+		for i := 0; i < len(ring)-1; i++ {
+			midpoint := sql.Point{X: (ring[i].X + ring[i+1].X) / 2, Y: (ring[i].Y + ring[i+1].Y) / 2}
+			if locate(ring[i], p1) == exterior || locate(midpoint, p1) == exterior {
+				return false
+			}
+		}
+	}
+	for _, ring := range p1.Rings {
+		for _, vertex := range ring {
+			if locate(vertex, p2) == interior {
+				return false
+			}
+		}
+	}
+	return !forEachEdgePair(p1, p2, segmentsCross)
+}
+
+// polygonsIntersect returns whether the polygons p1 and p2 have any point in common: their boundaries touch or cross,
+// or one of them lies inside the other.
+func polygonsIntersect(p1, p2 sql.Polygon) bool {
+	return forEachEdgePair(p1, p2, segmentsIntersect) ||
+		locate(p1.Rings[0][0], p2) != exterior ||
+		locate(p2.Rings[0][0], p1) != exterior
+}
+
+// forEachEdgePair returns whether the function given returns true for any edge of the polygon p1 and any edge of the
+// polygon p2.
+func forEachEdgePair(p1, p2 sql.Polygon, f func(a1, a2, b1, b2 sql.Point) bool) bool {
+	for _, r1 := range p1.Rings {
+		for i := 0; i < len(r1)-1; i++ {
+			for _, r2 := range p2.Rings {
+				for j := 0; j < len(r2)-1; j++ {
+					if f(r1[i], r1[i+1], r2[j], r2[j+1]) {
+						return true
+					}
+				}
+			}
+		}
+	}
+	return false
+}
+
+// locate returns where the point given lies relative to the polygon given.
+func locate(p sql.Point, polygon sql.Polygon) location {
+	for i, ring := range polygon.Rings {
+		switch locateInRing(p, ring) {
+		case boundary:
+			return boundary
+		case interior:
+			// Points inside holes are outside the polygon
+			if i > 0 {
+				return exterior
+			}
+		case exterior:
+			if i == 0 {
+				return exterior
+			}
+		}
+	}
+	return interior
+}
+
+// locateInRing returns where the point given lies relative to the area enclosed by the ring given, by counting the
+// edges of the ring crossed by a ray going from the point towards positive x.
+func locateInRing(p sql.Point, ring []sql.Point) location {
+	inside := false
+	for i := 0; i < len(ring)-1; i++ {
+		a, b := ring[i], ring[i+1]
+		if orientation(a, b, p) == 0 && onSegment(a, b, p) {
+			return boundary
+		}
+		if (a.Y > p.Y) != (b.Y > p.Y) && p.X < a.X+(p.Y-a.Y)*(b.X-a.X)/(b.Y-a.Y) {
+			inside = !inside
+		}
+	}
+	if inside {
+		return interior
+	}
+	return exterior
+}
+
+// orientation returns whether the point r lies to the left (1) of, right (-1) of, or on (0) the line through the
+// points p and q.
+func orientation(p, q, r sql.Point) int {
+	cross := (q.X-p.X)*(r.Y-p.Y) - (q.Y-p.Y)*(r.X-p.X)
+	switch {
+	case cross > 0:
+		return 1
+	case cross < 0:
+		return -1
+	default:
+		return 0
+	}
+}
+
+// onSegment returns whether the point r, collinear with the points p and q, lies on the segment between them.
+func onSegment(p, q, r sql.Point) bool {
+	return r.X >= math.Min(p.X, q.X) && r.X <= math.Max(p.X, q.X) && r.Y >= math.Min(p.Y, q.Y) && r.Y <= math.Max(p.Y, q.Y)
+}
+
+// segmentsIntersect returns whether the segments a1-a2 and b1-b2 have any point in common.
+func segmentsIntersect(a1, a2, b1, b2 sql.Point) bool {
+	o1, o2 := orientation(a1, a2, b1), orientation(a1, a2, b2)
+	o3, o4 := orientation(b1, b2, a1), orientation(b1, b2, a2)
+	if o1 != o2 && o3 != o4 {
+		return true
+	}
+	return (o1 == 0 && onSegment(a1, a2, b1)) || (o2 == 0 && onSegment(a1, a2, b2)) ||
+		(o3 == 0 && onSegment(b1, b2, a1)) || (o4 == 0 && onSegment(b1, b2, a2))
+}
+
+// segmentsCross returns whether the segments a1-a2 and b1-b2 cross each other at a single point inside both of them.
+func segmentsCross(a1, a2, b1, b2 sql.Point) bool {
+	o1, o2 := orientation(a1, a2, b1), orientation(a1, a2, b2)
+	o3, o4 := orientation(b1, b2, a1), orientation(b1, b2, a2)
+	return o1*o2 < 0 && o3*o4 < 0
+}
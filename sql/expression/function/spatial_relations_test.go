@@ -0,0 +1,102 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+func TestSpatialRelations(t *testing.T) {
+	square := func(x1, y1, x2, y2 float64) []sql.Point {
+		return []sql.Point{{X: x1, Y: y1}, {X: x2, Y: y1}, {X: x2, Y: y2}, {X: x1, Y: y2}, {X: x1, Y: y1}}
+	}
+	box := sql.Polygon{Rings: [][]sql.Point{square(0, 0, 10, 10)}}
+	boxWithHole := sql.Polygon{Rings: [][]sql.Point{square(0, 0, 10, 10), square(4, 4, 6, 6)}}
+	inner := sql.Polygon{Rings: [][]sql.Point{square(1, 1, 3, 3)}}
+	overlapping := sql.Polygon{Rings: [][]sql.Point{square(5, 5, 15, 15)}}
+	touching := sql.Polygon{Rings: [][]sql.Point{square(10, 0, 20, 10)}}
+	disjoint := sql.Polygon{Rings: [][]sql.Point{square(11, 11, 20, 20)}}
+	// A U shape, whose notch is between x = 4 and x = 6 above y = 4
+	u := sql.Polygon{Rings: [][]sql.Point{{
+		{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 10, Y: 10}, {X: 6, Y: 10}, {X: 6, Y: 4},
+		{X: 4, Y: 4}, {X: 4, Y: 10}, {X: 0, Y: 10}, {X: 0, Y: 0},
+	}}}
+	notch := sql.Polygon{Rings: [][]sql.Point{square(4, 4, 6, 10)}}
+
+	testCases := []struct {
+		name                         string
+		g1, g2                       sql.GeometryValue
+		contains, within, intersects bool
+	}{
+		{"point in box", box, sql.Point{X: 5, Y: 5}, true, false, true},
+		{"point on boundary", box, sql.Point{X: 10, Y: 5}, false, false, true},
+		{"point on vertex", box, sql.Point{X: 0, Y: 0}, false, false, true},
+		{"point outside", box, sql.Point{X: 11, Y: 5}, false, false, false},
+		{"point in hole", boxWithHole, sql.Point{X: 5, Y: 5}, false, false, false},
+		{"point on hole boundary", boxWithHole, sql.Point{X: 4, Y: 5}, false, false, true},
+		{"point around hole", boxWithHole, sql.Point{X: 2, Y: 5}, true, false, true},
+		{"point within box", sql.Point{X: 5, Y: 5}, box, false, true, true},
+		{"same points", sql.Point{X: 1, Y: 2}, sql.Point{X: 1, Y: 2}, true, true, true},
+		{"different points", sql.Point{X: 1, Y: 2}, sql.Point{X: 2, Y: 1}, false, false, false},
+		{"inner box", box, inner, true, false, true},
+		{"same box", box, box, true, true, true},
+		{"box around hole", boxWithHole, sql.Polygon{Rings: [][]sql.Point{square(2, 2, 8, 8)}}, false, false, true},
+		{"box beside hole", boxWithHole, inner, true, false, true},
+		{"overlapping boxes", box, overlapping, false, false, true},
+		{"touching boxes", box, touching, false, false, true},
+		{"disjoint boxes", box, disjoint, false, false, false},
+		{"notch of u", u, notch, false, false, true},
+		{"u in box", box, u, true, false, true},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			g1 := expression.NewLiteral(tt.g1, sql.Geometry)
+			g2 := expression.NewLiteral(tt.g2, sql.Geometry)
+			for _, f := range []struct {
+				expr     sql.Expression
+				expected bool
+			}{
+				{NewSTContains(g1, g2), tt.contains},
+				{NewSTWithin(g1, g2), tt.within},
+				{NewSTIntersects(g1, g2), tt.intersects},
+			} {
+				v, err := f.expr.Eval(sql.NewEmptyContext(), nil)
+				require.NoError(t, err)
+				require.Equal(t, f.expected, v, f.expr.String())
+			}
+		})
+	}
+}
+
+func TestSpatialRelationArguments(t *testing.T) {
+	point := expression.NewLiteral(sql.Point{X: 1, Y: 1}, sql.Geometry)
+	for _, newRelation := range []func(g1, g2 sql.Expression) sql.Expression{NewSTContains, NewSTWithin, NewSTIntersects} {
+		f := newRelation(point, expression.NewLiteral(nil, sql.Null))
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(t, err)
+		require.Nil(t, v)
+
+		f = newRelation(expression.NewLiteral(1, sql.Int64), point)
+		_, err = f.Eval(sql.NewEmptyContext(), nil)
+		require.True(t, sql.ErrInvalidGISData.Is(err))
+		require.Equal(t, sql.ErrInvalidGISData.New(f.(sql.FunctionExpression).FunctionName()).Error(), err.Error())
+	}
+}
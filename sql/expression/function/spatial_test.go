@@ -0,0 +1,107 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+func TestPoint(t *testing.T) {
+	f := NewPoint(
+		expression.NewGetField(0, sql.Float64, "x", true),
+		expression.NewGetField(1, sql.LongText, "y", true),
+	)
+	require.Equal(t, sql.Geometry, f.Type())
+
+	testCases := []struct {
+		x, y     interface{}
+		expected interface{}
+	}{
+		{1.5, "2", sql.Point{X: 1.5, Y: 2}},
+		{-1, "0", sql.Point{X: -1, Y: 0}},
+		{nil, "2", nil},
+		{1, nil, nil},
+	}
+	for _, tt := range testCases {
+		v, err := f.Eval(sql.NewEmptyContext(), sql.NewRow(tt.x, tt.y))
+		require.NoError(t, err)
+		require.Equal(t, tt.expected, v)
+	}
+}
+
+func TestGeomFromText(t *testing.T) {
+	square := sql.Polygon{Rings: [][]sql.Point{{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 1, Y: 1}, {X: 0, Y: 0}}}}
+	testCases := []struct {
+		wkt      interface{}
+		expected interface{}
+		err      bool
+	}{
+		{"POINT(1 2)", sql.Point{X: 1, Y: 2}, false},
+		{" point ( -1.5  2e1 ) ", sql.Point{X: -1.5, Y: 20}, false},
+		{"POLYGON((0 0,1 0,1 1,0 0))", square, false},
+		{"polygon(( 0 0 , 1 0, 1 1, 0 0 ))", square, false},
+		{
+			"POLYGON((0 0,10 0,10 10,0 0),(1 1,2 1,2 2,1 1))",
+			sql.Polygon{Rings: [][]sql.Point{
+				{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 10, Y: 10}, {X: 0, Y: 0}},
+				{{X: 1, Y: 1}, {X: 2, Y: 1}, {X: 2, Y: 2}, {X: 1, Y: 1}},
+			}},
+			false,
+		},
+		{nil, nil, false},
+		{"POINT(1)", nil, true},
+		{"POINT(a b)", nil, true},
+		{"POLYGON((0 0,1 0,1 1))", nil, true},
+		{"POLYGON((0 0,1 0,1 1,0 0)", nil, true},
+		{"LINESTRING(0 0,1 1)", nil, true},
+		{"", nil, true},
+	}
+
+	f := NewGeomFromText(expression.NewGetField(0, sql.LongText, "wkt", true))
+	for _, tt := range testCases {
+		v, err := f.Eval(sql.NewEmptyContext(), sql.NewRow(tt.wkt))
+		if tt.err {
+			require.True(t, sql.ErrInvalidGISData.Is(err), "%v: unexpected error %v", tt.wkt, err)
+			continue
+		}
+		require.NoError(t, err)
+		require.Equal(t, tt.expected, v)
+	}
+}
+
+func TestAsText(t *testing.T) {
+	f := NewAsText(expression.NewGetField(0, sql.Geometry, "g", true))
+	testCases := []struct {
+		g        interface{}
+		expected interface{}
+	}{
+		{sql.Point{X: 1, Y: -2.5}, "POINT(1 -2.5)"},
+		{sql.Polygon{Rings: [][]sql.Point{{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 1, Y: 1}, {X: 0, Y: 0}}}}, "POLYGON((0 0,1 0,1 1,0 0))"},
+		{nil, nil},
+	}
+	for _, tt := range testCases {
+		v, err := f.Eval(sql.NewEmptyContext(), sql.NewRow(tt.g))
+		require.NoError(t, err)
+		require.Equal(t, tt.expected, v)
+	}
+
+	_, err := f.Eval(sql.NewEmptyContext(), sql.NewRow("POINT(1 2)"))
+	require.True(t, sql.ErrInvalidGISData.Is(err))
+}
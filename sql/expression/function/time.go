@@ -16,6 +16,7 @@ package function
 
 import (
 	"fmt"
+	"math"
 	"strings"
 	"time"
 
@@ -1347,3 +1348,98 @@ func (c *CurrTimestamp) Eval(ctx *sql.Context, row sql.Row) (interface{}, error)
 
 	return _t, nil
 }
+
+// MakeTime implements the MAKETIME function, which returns the time with the given hour, minute and second. The hour
+// may be outside of the range of a time of day, up to the limits of the TIME type.
+type MakeTime struct {
+	Hour   sql.Expression
+	Minute sql.Expression
+	Second sql.Expression
+}
+
+var _ sql.FunctionExpression = (*MakeTime)(nil)
+
+// NewMakeTime creates a new MakeTime expression.
+func NewMakeTime(hour, minute, second sql.Expression) sql.Expression {
+	return &MakeTime{Hour: hour, Minute: minute, Second: second}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (m *MakeTime) FunctionName() string {
+	return "maketime"
+}
+
+func (m *MakeTime) String() string {
+	return fmt.Sprintf("MAKETIME(%s, %s, %s)", m.Hour, m.Minute, m.Second)
+}
+
+// Type implements the sql.Expression interface.
+func (m *MakeTime) Type() sql.Type { return sql.Time }
+
+// IsNullable implements the sql.Expression interface.
+func (m *MakeTime) IsNullable() bool { return true }
+
+// Resolved implements the sql.Expression interface.
+func (m *MakeTime) Resolved() bool {
+	return m.Hour.Resolved() && m.Minute.Resolved() && m.Second.Resolved()
+}
+
+// Children implements the sql.Expression interface.
+func (m *MakeTime) Children() []sql.Expression {
+	return []sql.Expression{m.Hour, m.Minute, m.Second}
+}
+
+// Eval implements the sql.Expression interface. A minute or second outside of the range of a time of day evaluates to
+// NULL.
+func (m *MakeTime) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	hour, err := evalInt64Arg(ctx, row, m.Hour)
+	if err != nil || hour == nil {
+		return nil, err
+	}
+
+	minute, err := evalInt64Arg(ctx, row, m.Minute)
+	if err != nil || minute == nil {
+		return nil, err
+	}
+
+	second, err := m.Second.Eval(ctx, row)
+	if err != nil || second == nil {
+		return nil, err
+	}
+	second, err = sql.Float64.Convert(second)
+	if err != nil {
+		return nil, nil
+	}
+
+	h, min, sec := *hour, *minute, second.(float64)
+	if min < 0 || min > 59 || sec < 0 || sec >= 60 {
+		return nil, nil
+	}
+
+	// Hours past the maximum of the TIME type are clamped to it
+	if h > 838 || h < -838 {
+		h = h / int64Abs(h) * 839
+	}
+
+	d := time.Duration(int64Abs(h))*time.Hour + time.Duration(min)*time.Minute +
+		time.Duration(math.Round(sec*float64(time.Second/time.Microsecond)))*time.Microsecond
+	if h < 0 {
+		d = -d
+	}
+	return sql.Time.Convert(d)
+}
+
+// WithChildren implements the sql.Expression interface.
+func (m *MakeTime) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 3 {
+		return nil, sql.ErrInvalidChildrenNumber.New(m, len(children), 3)
+	}
+	return NewMakeTime(children[0], children[1], children[2]), nil
+}
+
+func int64Abs(v int64) int64 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
@@ -581,8 +581,8 @@ func TestTimeDiff(t *testing.T) {
 		},
 		{
 			"time types 1",
-			expression.NewConvert(expression.NewLiteral("00:00:00.1", sql.Text), expression.ConvertToTime),
-			expression.NewConvert(expression.NewLiteral("00:00:00.2", sql.Text), expression.ConvertToTime),
+			expression.NewConvertWithLength(expression.NewLiteral("00:00:00.1", sql.Text), expression.ConvertToTime, 1),
+			expression.NewConvertWithLength(expression.NewLiteral("00:00:00.2", sql.Text), expression.ConvertToTime, 1),
 			"-00:00:00.100000",
 			false,
 		},
@@ -683,3 +683,39 @@ func TestCurrentTimestamp(t *testing.T) {
 		})
 	}
 }
+
+func TestMakeTime(t *testing.T) {
+	ctx := sql.NewEmptyContext()
+	f := NewMakeTime(
+		expression.NewGetField(0, sql.Int64, "hour", true),
+		expression.NewGetField(1, sql.Int64, "minute", true),
+		expression.NewGetField(2, sql.Float64, "second", true),
+	)
+
+	testCases := []struct {
+		name     string
+		row      sql.Row
+		expected interface{}
+	}{
+		{"null hour", sql.NewRow(nil, 0, 0), nil},
+		{"null minute", sql.NewRow(1, nil, 0), nil},
+		{"null second", sql.NewRow(1, 0, nil), nil},
+		{"time of day", sql.NewRow(12, 15, 30), "12:15:30"},
+		{"fractional seconds", sql.NewRow(12, 15, 30.25), "12:15:30.250000"},
+		{"hours past a day", sql.NewRow(100, 1, 2), "100:01:02"},
+		{"negative hours", sql.NewRow(-1, 30, 0), "-01:30:00"},
+		{"hours past maximum", sql.NewRow(1000, 0, 0), "838:59:59"},
+		{"hours past minimum", sql.NewRow(-1000, 0, 0), "-838:59:59"},
+		{"invalid minute", sql.NewRow(1, 60, 0), nil},
+		{"negative minute", sql.NewRow(1, -1, 0), nil},
+		{"invalid second", sql.NewRow(1, 0, 60), nil},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			val, err := f.Eval(ctx, tt.row)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, val)
+		})
+	}
+}
@@ -16,8 +16,14 @@ package expression
 
 import (
 	"fmt"
+	"io"
+	"strconv"
+	"sync"
+	"time"
 
 	"github.com/cespare/xxhash"
+	"github.com/dolthub/vitess/go/mysql"
+	"github.com/shopspring/decimal"
 	"gopkg.in/src-d/go-errors.v1"
 
 	"github.com/dolthub/go-mysql-server/sql"
@@ -65,6 +71,10 @@ func (in *InTuple) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 		return nil, err
 	}
 
+	if right, ok := in.Right().(Tuple); ok {
+		typ = inCompareType(in.Left().Type(), right)
+	}
+
 	if left == nil {
 		return nil, nil
 	}
@@ -75,11 +85,6 @@ func (in *InTuple) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 	// also if no match is found in the list and one of the expressions in the list is NULL.
 	rightNull := false
 
-	left, err = typ.Convert(left)
-	if err != nil {
-		return nil, err
-	}
-
 	switch right := in.Right().(type) {
 	case Tuple:
 		for _, el := range right {
@@ -88,6 +93,19 @@ func (in *InTuple) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 			}
 		}
 
+		if _, ok := in.Left().Type().(sql.TupleType); ok {
+			return in.evalTuples(ctx, row, left, right)
+		}
+
+		converted, truncated, err := convertInValue(left, typ)
+		if err != nil {
+			return nil, err
+		}
+		if truncated {
+			warnTruncatedInValue(ctx, left, typ)
+		}
+		left = converted
+
 		for _, el := range right {
 			right, err := el.Eval(ctx, row)
 			if err != nil {
@@ -99,10 +117,16 @@ func (in *InTuple) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 				continue
 			}
 
-			right, err = typ.Convert(right)
+			converted, truncated, err := convertInValue(right, typ)
 			if err != nil {
-				return nil, err
+				// Values that can't be compared to the left operand never match it
+				ctx.Warn(mysql.ERTruncatedWrongValue, "Incorrect %s value: '%v'", typ, right)
+				continue
 			}
+			if truncated {
+				warnTruncatedInValue(ctx, right, typ)
+			}
+			right = converted
 
 			cmp, err := typ.Compare(left, right)
 			if err != nil {
@@ -124,6 +148,49 @@ func (in *InTuple) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 	}
 }
 
+// evalTuples evaluates the expression for a tuple on the left, comparing it to the tuples of the list element by
+// element. A tuple with NULLs in it might equal another, if all their other elements are equal, in which case the
+// comparison gives NULL: (1, NULL) IN ((1, 2)) is NULL, but (2, NULL) IN ((1, 2)) is false.
+func (in *InTuple) evalTuples(ctx *sql.Context, row sql.Row, left interface{}, right Tuple) (interface{}, error) {
+	rightNull := false
+	for _, el := range right {
+		val, err := el.Eval(ctx, row)
+		if err != nil {
+			return nil, err
+		}
+
+		typ := inSetCompareType(in.Left().Type(), el.Type())
+		l, leftHasNull, err := convertInSetValue(ctx, left, typ)
+		if err != nil {
+			return nil, err
+		}
+		r, rightHasNull, err := convertInSetValue(ctx, val, typ)
+		if err != nil {
+			// Values that can't be compared to the left operand never match it
+			ctx.Warn(mysql.ERTruncatedWrongValue, "Incorrect %s value: '%v'", typ, val)
+			continue
+		}
+
+		mayEqual, err := inSetValuesMayEqual(l, r, typ)
+		if err != nil {
+			return nil, err
+		}
+		if !mayEqual {
+			continue
+		}
+		if leftHasNull || rightHasNull {
+			rightNull = true
+			continue
+		}
+		return true, nil
+	}
+
+	if rightNull {
+		return nil, nil
+	}
+	return false, nil
+}
+
 // WithChildren implements the Expression interface.
 func (in *InTuple) WithChildren(children ...sql.Expression) (sql.Expression, error) {
 	if len(children) != 2 {
@@ -145,6 +212,88 @@ func (in *InTuple) Children() []sql.Expression {
 	return []sql.Expression{in.Left(), in.Right()}
 }
 
+// inCompareType returns the type the left operand and the elements of an IN list are converted to before they're
+// compared. Like other comparisons in MySQL, strings are compared as strings under the collation of the left operand, integers of the same signedness as
+// integers, decimals and integers as decimals, and any other mix of numbers and strings as doubles. Values of other
+// types are compared as the type of the left operand.
+func inCompareType(left sql.Type, right Tuple) sql.Type {
+	types := make([]sql.Type, len(right))
+	for i, el := range right {
+		types[i] = el.Type()
+	}
+	return compareTypeOf(left, types...)
+}
+
+// compareTypeOf returns the type a value of the type left is converted to before it's compared to values of the types
+// given, following the rules of inCompareType.
+func compareTypeOf(left sql.Type, right ...sql.Type) sql.Type {
+	typ := left.Promote()
+	if !isNumberOrText(typ) {
+		return typ
+	}
+
+	for _, t := range right {
+		elType := t.Promote()
+		if !isNumberOrText(elType) {
+			continue
+		}
+
+		switch {
+		case sql.IsText(typ) && sql.IsText(elType):
+		case sql.IsInteger(typ) && sql.IsInteger(elType):
+			if sql.IsSigned(typ) != sql.IsSigned(elType) {
+				typ = sql.InternalDecimalType
+			}
+		case (sql.IsDecimal(typ) || sql.IsInteger(typ)) && (sql.IsDecimal(elType) || sql.IsInteger(elType)):
+			typ = sql.InternalDecimalType
+		default:
+			typ = sql.Float64
+		}
+	}
+
+	if st, ok := typ.(sql.StringType); ok && sql.IsText(typ) && !st.Collation().IsCaseSensitive() {
+		return collatedText{st}
+	}
+	return typ
+}
+
+func isNumberOrText(t sql.Type) bool {
+	return sql.IsNumber(t) || sql.IsText(t)
+}
+
+// convertInValue converts a value to the type an IN list is compared as. Strings that aren't numbers are truncated to
+// their numeric prefix when compared as numbers, as MySQL does, in which case truncated is true.
+func convertInValue(val interface{}, typ sql.Type) (converted interface{}, truncated bool, err error) {
+	converted, err = typ.Convert(val)
+	if err == nil {
+		return converted, false, nil
+	}
+
+	s, ok := val.(string)
+	if !ok || !sql.IsNumber(typ) {
+		return nil, false, err
+	}
+
+	prefix, _ := sql.NumericPrefix(s)
+	converted, err = typ.Convert(prefix)
+	if err != nil {
+		return nil, false, err
+	}
+	return converted, true, nil
+}
+
+func warnTruncatedInValue(ctx *sql.Context, val interface{}, typ sql.Type) {
+	ctx.Warn(mysql.ERTruncatedWrongValue, "%s", truncatedInValueMessage(val, typ))
+}
+
+func truncatedInValueMessage(val interface{}, typ sql.Type) string {
+	typeName := "DOUBLE"
+	if sql.IsDecimal(typ) {
+		typeName = "DECIMAL"
+	}
+	return fmt.Sprintf("Truncated incorrect %s value: '%v'", typeName, val)
+}
+
 // NewNotInTuple creates a new NotInTuple expression.
 func NewNotInTuple(left sql.Expression, right sql.Expression) sql.Expression {
 	return NewNot(NewInTuple(left, right))
@@ -155,25 +304,61 @@ type HashInTuple struct {
 	InTuple
 	cmp     map[uint64]sql.Expression
 	hasNull bool
+	// typ is the type the left operand and the elements of the list are compared as.
+	typ sql.Type
+	// warnings are reported the first time the expression is evaluated, for elements of the list that had to be
+	// truncated or couldn't be compared to the left operand at all.
+	warnings []inWarning
+	warnOnce *sync.Once
+	// dropped is whether some elements of the list couldn't be converted to typ, and were left out of cmp.
+	dropped bool
+}
+
+// inWarning is a warning about the elements of an IN list, found before the list is evaluated.
+type inWarning struct {
+	code    int
+	message string
 }
 
 var _ Comparer = (*InTuple)(nil)
 
 // NewHashInTuple creates an InTuple expression.
 func NewHashInTuple(left, right sql.Expression) (*HashInTuple, error) {
-	cmp, hasNull, err := newInMap(right, left.Type())
+	typ := left.Type()
+	if tup, ok := right.(Tuple); ok {
+		typ = inCompareType(typ, tup)
+	}
+
+	cmp, hasNull, dropped, warnings, err := newInMap(right, typ)
 	if err != nil {
 		return nil, err
 	}
 
-	return &HashInTuple{InTuple: *NewInTuple(left, right), cmp: cmp, hasNull: hasNull}, nil
+	return &HashInTuple{
+		InTuple:  *NewInTuple(left, right),
+		cmp:      cmp,
+		hasNull:  hasNull,
+		typ:      typ,
+		warnings: warnings,
+		warnOnce: &sync.Once{},
+		dropped:  dropped,
+	}, nil
+}
+
+// DroppedElements returns whether some elements of the list couldn't be converted to the type they're compared as,
+// and were left out of the hash map. Such elements are never found, while InTuple compares them to the left operand
+// as it would any other element, so the results of the two may differ.
+func (hit *HashInTuple) DroppedElements() bool {
+	return hit.dropped
 }
 
 // Eval implements the Expression interface.
 func (hit *HashInTuple) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
-	if hit.hasNull {
-		return nil, nil
-	}
+	hit.warnOnce.Do(func() {
+		for _, w := range hit.warnings {
+			ctx.Warn(w.code, "%s", w.message)
+		}
+	})
 
 	// convert GetField to Literal, necessary for hashing
 	left, err := normalizeLeft(ctx, hit.Left(), row)
@@ -193,13 +378,27 @@ func (hit *HashInTuple) Eval(ctx *sql.Context, row sql.Row) (interface{}, error)
 		return nil, nil
 	}
 
-	key, err := hashOf(left, hit.Left().Type())
+	if _, ok := leftVal.(string); ok && sql.IsNumber(hit.typ) {
+		if _, truncated, err := convertInValue(leftVal, hit.typ); err == nil && truncated {
+			warnTruncatedInValue(ctx, leftVal, hit.typ)
+		}
+	}
+
+	key, err := hashOf(left, hit.typ)
 	if err != nil {
 		return nil, err
 	}
 
 	right, ok := hit.cmp[key]
 	if !ok {
+		// Like an unhashed IN, a value that isn't found in a list with NULLs in it gives NULL, which NOT doesn't change.
+		// Tuples with NULLs in them aren't hashed, so whether they could equal a tuple is left to InTuple.
+		if hit.hasNull {
+			if _, ok := left.(Tuple); ok {
+				return hit.InTuple.Eval(ctx, row)
+			}
+			return nil, nil
+		}
 		return false, nil
 	}
 
@@ -218,36 +417,99 @@ func (hit *HashInTuple) DebugString() string {
 	return fmt.Sprintf("(%s HASH IN %s)", sql.DebugString(hit.Left()), sql.DebugString(hit.Right()))
 }
 
-// newInMap will hash Literal and Tuple expressions, and return a map of the hash to original expression
-func newInMap(expr sql.Expression, lType sql.Type) (map[uint64]sql.Expression, bool, error) {
+// newInMap will hash Literal and Tuple expressions, and return a map of the hash to original expression. Literals are
+// hashed as the type given, and those that can't be converted to it are left out of the map with a warning, as are
+// tuples compared to a value that isn't one, which is reported by the second boolean returned. NULLs, and tuples with
+// NULLs in them or compared to one, are left out of the map too, and reported by the first boolean returned.
+func newInMap(expr sql.Expression, lType sql.Type) (map[uint64]sql.Expression, bool, bool, []inWarning, error) {
 	if lType == sql.Null {
-		return nil, true, nil
+		return nil, true, false, nil, nil
 	}
 
 	elements := make(map[uint64]sql.Expression)
 	hasNull := false
+	dropped := false
+	var warnings []inWarning
 	switch right := expr.(type) {
 	case Tuple:
 		for _, el := range right {
 			switch l := el.(type) {
-			case *Literal, Tuple:
+			case *Literal:
+				if l.value == nil {
+					hasNull = true
+					continue
+				}
+
+				_, truncated, err := convertInValue(l.value, lType.Promote())
+				if err != nil {
+					warnings = append(warnings, inWarning{
+						code:    mysql.ERTruncatedWrongValue,
+						message: fmt.Sprintf("Incorrect %s value: '%v'", lType, l.value),
+					})
+					dropped = true
+					continue
+				}
+				if truncated {
+					warnings = append(warnings, inWarning{
+						code:    mysql.ERTruncatedWrongValue,
+						message: truncatedInValueMessage(l.value, lType),
+					})
+				}
+
+				key, err := hashOfLiteral(l, lType)
+				if err != nil {
+					return nil, hasNull, dropped, nil, err
+				}
+				elements[key] = el
+			case Tuple:
+				if tupleHasNull(l) || tupleTypeHasNull(lType) {
+					hasNull = true
+					continue
+				}
+
 				key, err := hashOf(l, lType)
 				if sql.ErrInvalidType.Is(err) {
 					// TODO: can't convert a tuple in right expr to left literal type, and vice versa, echo warning?
+					dropped = true
 					continue
 				}
 				if err != nil {
-					return nil, hasNull, err
+					return nil, hasNull, dropped, nil, err
 				}
 				elements[key] = el
 			default:
-				return nil, hasNull, ErrUnsupportedHashInSubexpression.New(el)
+				return nil, hasNull, dropped, nil, ErrUnsupportedHashInSubexpression.New(el)
 			}
 		}
 	default:
-		return nil, hasNull, ErrUnsupportedHashInOperand.New(right)
+		return nil, hasNull, dropped, nil, ErrUnsupportedHashInOperand.New(right)
+	}
+	return elements, hasNull, dropped, warnings, nil
+}
+
+// tupleHasNull returns whether any of the literals of a tuple is NULL.
+func tupleHasNull(tup Tuple) bool {
+	for _, el := range tup {
+		if l, ok := el.(*Literal); ok && l.value == nil {
+			return true
+		}
 	}
-	return elements, hasNull, nil
+	return false
+}
+
+// tupleTypeHasNull returns whether any of the elements of a tuple type is the NULL type, as for the tuple (a, NULL),
+// whose values can't be hashed as they can't be converted to it.
+func tupleTypeHasNull(t sql.Type) bool {
+	tupType, ok := t.(sql.TupleType)
+	if !ok {
+		return false
+	}
+	for _, el := range tupType {
+		if el == sql.Null {
+			return true
+		}
+	}
+	return false
 }
 
 func hashOf(e sql.Expression, t sql.Type) (uint64, error) {
@@ -267,11 +529,11 @@ func hashOf(e sql.Expression, t sql.Type) (uint64, error) {
 
 func hashOfLiteral(l *Literal, t sql.Type) (uint64, error) {
 	hash := xxhash.New()
-	i, err := t.Promote().Convert(l.value)
+	i, _, err := convertInValue(l.value, t.Promote())
 	if err != nil {
 		return 0, sql.ErrInvalidType.New(l.value)
 	}
-	if _, err := hash.Write([]byte(fmt.Sprintf("%#v,", i))); err != nil {
+	if err := writeHashValue(hash, collationSortKey(i, t)); err != nil {
 		return 0, err
 	}
 	return hash.Sum64(), nil
@@ -287,7 +549,7 @@ func hashOfTuple(tup Tuple, t sql.TupleType) (uint64, error) {
 			if err != nil {
 				return 0, err
 			}
-			if _, err := hash.Write([]byte(fmt.Sprintf("%#v,", converted))); err != nil {
+			if err := writeHashValue(hash, collationSortKey(converted, t[i])); err != nil {
 				return 0, err
 			}
 		default:
@@ -297,6 +559,47 @@ func hashOfTuple(tup Tuple, t sql.TupleType) (uint64, error) {
 	return hash.Sum64(), nil
 }
 
+// collationSortKey returns the value to hash for a value of the type given, which is the sort key of strings under the
+// collation of the type, so that strings equal under a case-insensitive collation hash equally.
+func collationSortKey(v interface{}, t sql.Type) interface{} {
+	s, ok := v.(string)
+	if !ok {
+		return v
+	}
+	if st, ok := t.(sql.StringType); ok {
+		return st.Collation().SortKey(s)
+	}
+	return s
+}
+
+// writeHashValue writes a canonical representation of the given value to the hash, so that values comparing equal hash
+// equally however they're represented: times are written in UTC without their monotonic clock reading, decimals without
+// trailing zeros, and floating point zeros without their sign.
+func writeHashValue(hash io.Writer, v interface{}) error {
+	var s string
+	switch v := v.(type) {
+	case time.Time:
+		s = v.UTC().Format(time.RFC3339Nano)
+	case decimal.Decimal:
+		s = v.String()
+	case float64:
+		if v == 0 {
+			v = 0
+		}
+		s = strconv.FormatFloat(v, 'g', -1, 64)
+	case float32:
+		if v == 0 {
+			v = 0
+		}
+		s = strconv.FormatFloat(float64(v), 'g', -1, 32)
+	default:
+		s = fmt.Sprintf("%#v", v)
+	}
+
+	_, err := hash.Write([]byte(fmt.Sprintf("%T:%s,", v, s)))
+	return err
+}
+
 func normalizeLeft(ctx *sql.Context, expr sql.Expression, row sql.Row) (sql.Expression, error) {
 	switch e := expr.(type) {
 	case Tuple:
@@ -324,3 +627,189 @@ func normalizeLeft(ctx *sql.Context, expr sql.Expression, row sql.Row) (sql.Expr
 		return nil, ErrUnsupportedHashInOperand.New(e)
 	}
 }
+
+// InSet is a hash set of the values the left operand of an IN expression is compared to, like the rows of an
+// uncorrelated subquery, which is built once to find the left operand of every row in constant time. Values are hashed
+// as the type they're compared as. Values with NULLs in them can't be found by hashing, so they're also kept apart.
+type InSet struct {
+	// typ is the type the left operand and the values of the set are compared as.
+	typ      sql.Type
+	elements map[uint64]struct{}
+	// values are all the values of the set, and nullValues those with NULLs in them, converted to typ.
+	values     []interface{}
+	nullValues []interface{}
+}
+
+// NewInSet returns a set of the values given, which are values of the type right, to be compared to values of the type
+// left. Values of tuple types are []interface{}. Values that can't be compared to the left operand are left out of the
+// set with a warning.
+func NewInSet(ctx *sql.Context, left, right sql.Type, values []interface{}) (*InSet, error) {
+	typ := inSetCompareType(left, right)
+	set := &InSet{typ: typ, elements: make(map[uint64]struct{}, len(values))}
+	for _, v := range values {
+		converted, hasNull, err := convertInSetValue(ctx, v, typ)
+		if sql.ErrInvalidOperandColumns.Is(err) {
+			return nil, err
+		}
+		if err != nil {
+			ctx.Warn(mysql.ERTruncatedWrongValue, "Incorrect %s value: '%v'", typ, v)
+			continue
+		}
+
+		set.values = append(set.values, converted)
+		if hasNull {
+			set.nullValues = append(set.nullValues, converted)
+			continue
+		}
+
+		key, err := hashOfInSetValue(converted, typ)
+		if err != nil {
+			return nil, err
+		}
+		set.elements[key] = struct{}{}
+	}
+	return set, nil
+}
+
+// Contains returns whether the set contains the value given, which is a value of the type of the left operand. Like an
+// IN expression, it returns NULL instead of false when the value isn't found, but either it or a value of the set it
+// might be equal to has NULLs in it. Nothing is ever found in an empty set, not even NULL.
+func (s *InSet) Contains(ctx *sql.Context, v interface{}) (interface{}, error) {
+	converted, hasNull, err := convertInSetValue(ctx, v, s.typ)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := s.values
+	if !hasNull {
+		key, err := hashOfInSetValue(converted, s.typ)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := s.elements[key]; ok {
+			return true, nil
+		}
+		candidates = s.nullValues
+	}
+
+	for _, candidate := range candidates {
+		mayEqual, err := inSetValuesMayEqual(converted, candidate, s.typ)
+		if err != nil {
+			return nil, err
+		}
+		if mayEqual {
+			return nil, nil
+		}
+	}
+	return false, nil
+}
+
+// inSetCompareType returns the type values of the type left are compared to values of the type right as. Tuples are
+// compared element by element, and NULL as the type it's compared to.
+func inSetCompareType(left, right sql.Type) sql.Type {
+	if left == sql.Null {
+		return right.Promote()
+	}
+	leftTuple, ok := left.(sql.TupleType)
+	if !ok {
+		return compareTypeOf(left, right)
+	}
+	rightTuple, ok := right.(sql.TupleType)
+	if !ok || len(leftTuple) != len(rightTuple) {
+		return left
+	}
+
+	typ := make(sql.TupleType, len(leftTuple))
+	for i := range leftTuple {
+		typ[i] = inSetCompareType(leftTuple[i], rightTuple[i])
+	}
+	return typ
+}
+
+// convertInSetValue converts a value to the type the values of an InSet are compared as, and returns whether it has
+// NULLs in it.
+func convertInSetValue(ctx *sql.Context, v interface{}, typ sql.Type) (interface{}, bool, error) {
+	if v == nil {
+		return nil, true, nil
+	}
+
+	tupType, ok := typ.(sql.TupleType)
+	if !ok {
+		converted, truncated, err := convertInValue(v, typ)
+		if err != nil {
+			return nil, false, err
+		}
+		if truncated {
+			warnTruncatedInValue(ctx, v, typ)
+		}
+		return converted, false, nil
+	}
+
+	vals, ok := v.([]interface{})
+	if !ok {
+		return nil, false, sql.ErrInvalidOperandColumns.New(len(tupType), 1)
+	}
+	if len(vals) != len(tupType) {
+		return nil, false, sql.ErrInvalidOperandColumns.New(len(tupType), len(vals))
+	}
+
+	converted := make([]interface{}, len(vals))
+	hasNull := false
+	for i, val := range vals {
+		c, null, err := convertInSetValue(ctx, val, tupType[i])
+		if err != nil {
+			return nil, false, err
+		}
+		converted[i] = c
+		hasNull = hasNull || null
+	}
+	return converted, hasNull, nil
+}
+
+func hashOfInSetValue(v interface{}, typ sql.Type) (uint64, error) {
+	hash := xxhash.New()
+	if err := writeInSetValue(hash, v, typ); err != nil {
+		return 0, err
+	}
+	return hash.Sum64(), nil
+}
+
+func writeInSetValue(hash io.Writer, v interface{}, typ sql.Type) error {
+	vals, ok := v.([]interface{})
+	if !ok {
+		return writeHashValue(hash, collationSortKey(v, typ))
+	}
+	tupType := typ.(sql.TupleType)
+	for i, val := range vals {
+		if err := writeInSetValue(hash, val, tupType[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// inSetValuesMayEqual returns whether two values converted to the type given might be equal, which is when every
+// element of them is either equal or NULL.
+func inSetValuesMayEqual(a, b interface{}, typ sql.Type) (bool, error) {
+	if a == nil || b == nil {
+		return true, nil
+	}
+
+	tupType, ok := typ.(sql.TupleType)
+	if !ok {
+		cmp, err := typ.Compare(a, b)
+		if err != nil {
+			return false, err
+		}
+		return cmp == 0, nil
+	}
+
+	as, bs := a.([]interface{}), b.([]interface{})
+	for i := range tupType {
+		mayEqual, err := inSetValuesMayEqual(as[i], bs[i], tupType[i])
+		if err != nil || !mayEqual {
+			return false, err
+		}
+	}
+	return true, nil
+}
@@ -15,9 +15,12 @@
 package expression_test
 
 import (
+	"math"
 	"testing"
+	"time"
 
 	"github.com/dolthub/vitess/go/sqltypes"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/require"
 	"gopkg.in/src-d/go-errors.v1"
 
@@ -280,6 +283,72 @@ func TestHashInTuple(t *testing.T) {
 			nil,
 			nil,
 		},
+		{
+			"left is in right with NULL",
+			expression.NewGetField(0, sql.Int64, "foo", false),
+			expression.NewTuple(
+				expression.NewLiteral(int64(1), sql.Int64),
+				expression.NewLiteral(nil, sql.Null),
+			),
+			sql.NewRow(int64(1)),
+			true,
+			nil,
+			nil,
+		},
+		{
+			"left is not in right with NULL",
+			expression.NewGetField(0, sql.Int64, "foo", false),
+			expression.NewTuple(
+				expression.NewLiteral(int64(1), sql.Int64),
+				expression.NewLiteral(nil, sql.Null),
+			),
+			sql.NewRow(int64(3)),
+			nil,
+			nil,
+			nil,
+		},
+		{
+			"left tuple might equal right tuple with NULL",
+			expression.NewTuple(
+				expression.NewGetField(0, sql.Int64, "a", false),
+				expression.NewLiteral(int64(1), sql.Int64),
+			),
+			expression.NewTuple(
+				expression.NewTuple(
+					expression.NewLiteral(int64(1), sql.Int64),
+					expression.NewLiteral(nil, sql.Null),
+				),
+				expression.NewTuple(
+					expression.NewLiteral(int64(2), sql.Int64),
+					expression.NewLiteral(int64(1), sql.Int64),
+				),
+			),
+			sql.NewRow(int64(1)),
+			nil,
+			nil,
+			nil,
+		},
+		{
+			"left tuple can't equal right tuple with NULL",
+			expression.NewTuple(
+				expression.NewGetField(0, sql.Int64, "a", false),
+				expression.NewLiteral(int64(1), sql.Int64),
+			),
+			expression.NewTuple(
+				expression.NewTuple(
+					expression.NewLiteral(int64(1), sql.Int64),
+					expression.NewLiteral(nil, sql.Null),
+				),
+				expression.NewTuple(
+					expression.NewLiteral(int64(2), sql.Int64),
+					expression.NewLiteral(int64(1), sql.Int64),
+				),
+			),
+			sql.NewRow(int64(3)),
+			false,
+			nil,
+			nil,
+		},
 		{
 			"left tuple is in right",
 			expression.NewTuple(
@@ -396,3 +465,337 @@ func TestHashInTuple(t *testing.T) {
 		})
 	}
 }
+
+func TestHashNotInTuple(t *testing.T) {
+	right := expression.NewTuple(
+		expression.NewLiteral(int64(1), sql.Int64),
+		expression.NewLiteral(int64(2), sql.Int64),
+	)
+	rightWithNull := expression.NewTuple(
+		expression.NewLiteral(int64(1), sql.Int64),
+		expression.NewLiteral(nil, sql.Null),
+	)
+
+	testCases := []struct {
+		name   string
+		right  expression.Tuple
+		row    sql.Row
+		result interface{}
+	}{
+		{"left is in right", right, sql.NewRow(int64(1)), false},
+		{"left is not in right", right, sql.NewRow(int64(3)), true},
+		{"left is nil", right, sql.NewRow(nil), nil},
+		{"left is in right with NULL", rightWithNull, sql.NewRow(int64(1)), false},
+		{"left is not in right with NULL", rightWithNull, sql.NewRow(int64(3)), nil},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			hit, err := expression.NewHashInTuple(expression.NewGetField(0, sql.Int64, "foo", true), tt.right)
+			require.NoError(err)
+			result, err := expression.NewNot(hit).Eval(sql.NewEmptyContext(), tt.row)
+			require.NoError(err)
+			require.Equal(tt.result, result)
+		})
+	}
+}
+
+func TestHashInTupleDroppedElements(t *testing.T) {
+	testCases := []struct {
+		name    string
+		left    sql.Expression
+		right   expression.Tuple
+		dropped bool
+	}{
+		{
+			"all elements converted",
+			expression.NewGetField(0, sql.Int64, "foo", true),
+			expression.NewTuple(expression.NewLiteral(int64(1), sql.Int64), expression.NewLiteral("2", sql.LongText)),
+			false,
+		},
+		{
+			"NULL elements aren't dropped",
+			expression.NewGetField(0, sql.Int64, "foo", true),
+			expression.NewTuple(expression.NewLiteral(int64(1), sql.Int64), expression.NewLiteral(nil, sql.Null)),
+			false,
+		},
+		{
+			"element that can't be converted",
+			expression.NewGetField(0, sql.Date, "foo", true),
+			expression.NewTuple(expression.NewLiteral("2020-01-01", sql.LongText), expression.NewLiteral("notadate", sql.LongText)),
+			true,
+		},
+		{
+			"tuple compared to a value",
+			expression.NewGetField(0, sql.Int64, "foo", true),
+			expression.NewTuple(
+				expression.NewLiteral(int64(1), sql.Int64),
+				expression.NewTuple(expression.NewLiteral(int64(2), sql.Int64), expression.NewLiteral(int64(3), sql.Int64)),
+			),
+			true,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			hit, err := expression.NewHashInTuple(tt.left, tt.right)
+			require.NoError(t, err)
+			require.Equal(t, tt.dropped, hit.DroppedElements())
+		})
+	}
+}
+
+func TestInTupleTypeCoercion(t *testing.T) {
+	varchar := sql.MustCreateStringWithDefaults(sqltypes.VarChar, 20)
+	testCases := []struct {
+		name     string
+		left     sql.Expression
+		right    expression.Tuple
+		row      sql.Row
+		result   interface{}
+		warnings int
+	}{
+		{
+			"integer in mixed literals",
+			expression.NewGetField(0, sql.Int64, "foo", false),
+			expression.NewTuple(
+				expression.NewLiteral(int8(1), sql.Int8),
+				expression.NewLiteral("2", varchar),
+				expression.NewLiteral(3.0, sql.Float64),
+			),
+			sql.NewRow(int64(2)),
+			true,
+			0,
+		},
+		{
+			"integer doesn't match a fraction",
+			expression.NewGetField(0, sql.Int64, "foo", false),
+			expression.NewTuple(
+				expression.NewLiteral(3.5, sql.Float64),
+				expression.NewLiteral("4.5", varchar),
+			),
+			sql.NewRow(int64(3)),
+			false,
+			0,
+		},
+		{
+			"string compared as a number is truncated",
+			expression.NewGetField(0, sql.Int64, "foo", false),
+			expression.NewTuple(
+				expression.NewLiteral("7 apples", varchar),
+				expression.NewLiteral(int8(8), sql.Int8),
+			),
+			sql.NewRow(int64(7)),
+			true,
+			1,
+		},
+		{
+			"unsigned and negative integers",
+			expression.NewGetField(0, sql.Uint64, "foo", false),
+			expression.NewTuple(
+				expression.NewLiteral(int8(-1), sql.Int8),
+				expression.NewLiteral(uint64(18446744073709551615), sql.Uint64),
+			),
+			sql.NewRow(uint64(18446744073709551615)),
+			true,
+			0,
+		},
+		{
+			"strings compare as strings",
+			expression.NewGetField(0, varchar, "foo", false),
+			expression.NewTuple(
+				expression.NewLiteral("1.0", varchar),
+				expression.NewLiteral("b", varchar),
+			),
+			sql.NewRow("1"),
+			false,
+			0,
+		},
+		{
+			"incomparable elements are skipped",
+			expression.NewGetField(0, sql.Datetime, "foo", false),
+			expression.NewTuple(
+				expression.NewLiteral("not a date", varchar),
+				expression.NewLiteral("2021-01-01", varchar),
+			),
+			sql.NewRow(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)),
+			true,
+			1,
+		},
+		{
+			"datetimes in different locations",
+			expression.NewGetField(0, sql.Datetime, "foo", false),
+			expression.NewTuple(
+				expression.NewLiteral(time.Date(2021, 1, 1, 9, 0, 0, 0, time.FixedZone("KST", 9*60*60)), sql.Datetime),
+				expression.NewLiteral("2021-01-02", varchar),
+			),
+			sql.NewRow(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)),
+			true,
+			0,
+		},
+		{
+			"decimals with different scales",
+			expression.NewGetField(0, sql.MustCreateDecimalType(10, 2), "foo", false),
+			expression.NewTuple(
+				expression.NewLiteral("1.5", sql.MustCreateDecimalType(10, 1)),
+				expression.NewLiteral(decimal.NewFromInt(2), sql.MustCreateDecimalType(10, 0)),
+			),
+			sql.NewRow("1.50"),
+			true,
+			0,
+		},
+		{
+			"negative zero matches zero",
+			expression.NewGetField(0, sql.Float64, "foo", false),
+			expression.NewTuple(
+				expression.NewLiteral(0.0, sql.Float64),
+				expression.NewLiteral(3.0, sql.Float64),
+			),
+			sql.NewRow(math.Copysign(0, -1)),
+			true,
+			0,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			ctx := sql.NewEmptyContext()
+			result, err := expression.NewInTuple(tt.left, tt.right).Eval(ctx, tt.row)
+			require.NoError(err)
+			require.Equal(tt.result, result)
+			require.Len(ctx.Session.Warnings(), tt.warnings)
+
+			hashIn, err := expression.NewHashInTuple(tt.left, tt.right)
+			require.NoError(err)
+
+			ctx = sql.NewEmptyContext()
+			result, err = hashIn.Eval(ctx, tt.row)
+			require.NoError(err)
+			require.Equal(tt.result, result)
+			require.Len(ctx.Session.Warnings(), tt.warnings)
+		})
+	}
+}
+
+func TestInSet(t *testing.T) {
+	varchar := sql.MustCreateStringWithDefaults(sqltypes.VarChar, 20)
+	pair := sql.CreateTuple(sql.Int64, varchar)
+	testCases := []struct {
+		name   string
+		left   sql.Type
+		right  sql.Type
+		values []interface{}
+		value  interface{}
+		result interface{}
+	}{
+		{"value in set", sql.Int64, sql.Int64, []interface{}{int64(1), int64(2)}, int64(2), true},
+		{"value not in set", sql.Int64, sql.Int64, []interface{}{int64(1), int64(2)}, int64(3), false},
+		{"integer in strings", sql.Int64, varchar, []interface{}{"1", "2.0"}, int64(2), true},
+		{"value not in set with NULL", sql.Int64, sql.Int64, []interface{}{int64(1), nil}, int64(3), nil},
+		{"value in set with NULL", sql.Int64, sql.Int64, []interface{}{int64(1), nil}, int64(1), true},
+		{"NULL in set", sql.Int64, sql.Int64, []interface{}{int64(1)}, nil, nil},
+		{"NULL in empty set", sql.Int64, sql.Int64, nil, nil, false},
+		{"NULL of type NULL in set", sql.Null, sql.Int64, []interface{}{int64(1)}, nil, nil},
+		{"tuple in set", pair, pair, []interface{}{[]interface{}{int64(1), "a"}, []interface{}{int64(2), "b"}}, []interface{}{int64(2), "b"}, true},
+		{"tuple not in set", pair, pair, []interface{}{[]interface{}{int64(1), "a"}, []interface{}{int64(2), "b"}}, []interface{}{int64(2), "a"}, false},
+		{"tuple might equal a tuple with NULL", pair, pair, []interface{}{[]interface{}{int64(1), nil}}, []interface{}{int64(1), "a"}, nil},
+		{"tuple can't equal a tuple with NULL", pair, pair, []interface{}{[]interface{}{int64(1), nil}}, []interface{}{int64(2), "a"}, false},
+		{"tuple with NULL might equal a tuple", pair, pair, []interface{}{[]interface{}{int64(1), "a"}}, []interface{}{nil, "a"}, nil},
+		{"tuple with NULL can't equal a tuple", pair, pair, []interface{}{[]interface{}{int64(1), "a"}}, []interface{}{nil, "b"}, false},
+		{"tuple with NULL in empty set", pair, pair, nil, []interface{}{nil, "b"}, false},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			ctx := sql.NewEmptyContext()
+			set, err := expression.NewInSet(ctx, tt.left, tt.right, tt.values)
+			require.NoError(err)
+			result, err := set.Contains(ctx, tt.value)
+			require.NoError(err)
+			require.Equal(tt.result, result)
+		})
+	}
+}
+
+func TestInTupleCollation(t *testing.T) {
+	ci := sql.MustCreateString(sqltypes.VarChar, 20, sql.Collation_utf8mb4_0900_ai_ci)
+	cs := sql.MustCreateString(sqltypes.VarChar, 20, sql.Collation_utf8mb4_0900_bin)
+	varchar := sql.MustCreateStringWithDefaults(sqltypes.VarChar, 20)
+	testCases := []struct {
+		name   string
+		left   sql.Expression
+		right  expression.Tuple
+		row    sql.Row
+		result interface{}
+	}{
+		{
+			"case-insensitive collation ignores case",
+			expression.NewGetField(0, ci, "foo", false),
+			expression.NewTuple(
+				expression.NewLiteral("foo", varchar),
+				expression.NewLiteral("bar", varchar),
+			),
+			sql.NewRow("FOO"),
+			true,
+		},
+		{
+			"case-sensitive collation doesn't ignore case",
+			expression.NewGetField(0, cs, "foo", false),
+			expression.NewTuple(
+				expression.NewLiteral("foo", varchar),
+				expression.NewLiteral("bar", varchar),
+			),
+			sql.NewRow("FOO"),
+			false,
+		},
+		{
+			"case-insensitive collation in tuples",
+			expression.NewTuple(
+				expression.NewGetField(0, ci, "foo", false),
+				expression.NewLiteral(int64(1), sql.Int64),
+			),
+			expression.NewTuple(
+				expression.NewTuple(expression.NewLiteral("Bar", varchar), expression.NewLiteral(int64(1), sql.Int64)),
+				expression.NewTuple(expression.NewLiteral("foo", varchar), expression.NewLiteral(int64(2), sql.Int64)),
+			),
+			sql.NewRow("bAR"),
+			true,
+		},
+		{
+			"case-sensitive collation in tuples",
+			expression.NewTuple(
+				expression.NewGetField(0, cs, "foo", false),
+				expression.NewLiteral(int64(1), sql.Int64),
+			),
+			expression.NewTuple(
+				expression.NewTuple(expression.NewLiteral("Bar", varchar), expression.NewLiteral(int64(1), sql.Int64)),
+				expression.NewTuple(expression.NewLiteral("foo", varchar), expression.NewLiteral(int64(2), sql.Int64)),
+			),
+			sql.NewRow("bAR"),
+			false,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			ctx := sql.NewEmptyContext()
+			result, err := expression.NewInTuple(tt.left, tt.right).Eval(ctx, tt.row)
+			require.NoError(err)
+			require.Equal(tt.result, result)
+
+			hit, err := expression.NewHashInTuple(tt.left, tt.right)
+			require.NoError(err)
+			result, err = hit.Eval(ctx, tt.row)
+			require.NoError(err)
+			require.Equal(tt.result, result)
+		})
+	}
+}
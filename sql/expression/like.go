@@ -25,10 +25,6 @@ import (
 	"github.com/dolthub/go-mysql-server/sql"
 )
 
-func newDefaultLikeMatcher(likeStr string) (regex.DisposableMatcher, error) {
-	return regex.NewDisposableMatcher("go", likeStr)
-}
-
 // Like performs pattern matching against two strings.
 type Like struct {
 	BinaryExpression
@@ -74,12 +70,11 @@ func (l *Like) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 		return nil, err
 	}
 
-	createMatcher := newDefaultLikeMatcher
-	lType := l.Left.Type()
-	lm, likeOK := lType.(sql.LikeMatcher)
-	if likeOK {
-		createMatcher = lm.CreateMatcher
+	collation, err := patternCollation(l.Left, l.Right, "like")
+	if err != nil {
+		return nil, err
 	}
+	createMatcher := collation.LikeMatcher
 
 	var likeMatcher regex.DisposableMatcher
 	if !l.cached {
@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/dolthub/vitess/go/sqltypes"
 	"github.com/stretchr/testify/require"
 
 	"github.com/dolthub/go-mysql-server/sql"
@@ -106,3 +107,111 @@ func TestLike(t *testing.T) {
 		})
 	}
 }
+
+func TestLikeAccentSensitivity(t *testing.T) {
+	testCases := []struct {
+		collation      sql.Collation
+		pattern, value string
+		ok             bool
+	}{
+		{sql.Collation_utf8mb4_0900_ai_ci, "cafe%", "café au lait", true},
+		{sql.Collation_utf8mb4_0900_ai_ci, "café%", "cafe au lait", true},
+		{sql.Collation_utf8mb4_0900_ai_ci, "CAF_", "Cafè", true},
+		{sql.Collation_utf8mb4_0900_ai_ci, "%creme%", "Crème brûlée", true},
+		{sql.Collation_utf8mb4_0900_ai_ci, "cafe%", "tea", false},
+		{sql.Collation_utf8mb4_0900_as_ci, "cafe%", "café au lait", false},
+		{sql.Collation_utf8mb4_0900_as_ci, "CAFÉ%", "café au lait", true},
+		{sql.Collation_utf8mb4_0900_bin, "cafe%", "café au lait", false},
+		{sql.Collation_utf8mb4_0900_bin, "café%", "café au lait", true},
+		{sql.Collation_binary, "cafe%", "café au lait", false},
+	}
+
+	for _, tt := range testCases {
+		t.Run(fmt.Sprintf("%q LIKE %q COLLATE %s", tt.value, tt.pattern, tt.collation), func(t *testing.T) {
+			f := NewLike(
+				NewGetField(0, sql.CreateLongText(tt.collation), "", false),
+				NewLiteral(tt.pattern, sql.LongText),
+				nil,
+			)
+			value, err := f.Eval(sql.NewEmptyContext(), sql.NewRow(tt.value))
+			require.NoError(t, err)
+			require.Equal(t, tt.ok, value)
+		})
+	}
+}
+
+func TestLikeBinary(t *testing.T) {
+	text := sql.MustCreateStringWithDefaults(sqltypes.VarChar, 20)
+	ciText := sql.MustCreateString(sqltypes.VarChar, 20, sql.Collation_utf8mb4_general_ci)
+	binary := sql.MustCreateBinary(sqltypes.VarBinary, 20)
+
+	testCases := []struct {
+		left, right    sql.Type
+		value, pattern string
+		ok             bool
+	}{
+		{ciText, text, "ABC", "a%", true},
+		{binary, text, "ABC", "a%", false},
+		{binary, text, "abc", "a%", true},
+		{ciText, binary, "ABC", "a%", false},
+		{ciText, text, "é", "_", true},
+		{binary, text, "é", "_", false},
+		{binary, text, "é", "__", true},
+		{text, binary, "aé", "a__", true},
+	}
+
+	for _, tt := range testCases {
+		t.Run(fmt.Sprintf("%s %q LIKE %s %q", tt.left, tt.value, tt.right, tt.pattern), func(t *testing.T) {
+			f := NewLike(
+				NewGetField(0, tt.left, "", false),
+				NewLiteral(tt.pattern, tt.right),
+				nil,
+			)
+			value, err := f.Eval(sql.NewEmptyContext(), sql.NewRow(tt.value))
+			require.NoError(t, err)
+			require.Equal(t, tt.ok, value)
+		})
+	}
+}
+
+func TestLikeCollationCoercibility(t *testing.T) {
+	aiCollation := sql.Collation_utf8mb4_0900_ai_ci
+	testCases := []struct {
+		name        string
+		left, right sql.Expression
+		ok          bool
+	}{
+		{
+			"explicit collation of the pattern",
+			NewLiteral("café", sql.LongText),
+			NewCollatedExpression(NewLiteral("cafe%", sql.LongText), aiCollation),
+			true,
+		},
+		{
+			"explicit collation of the text",
+			NewCollatedExpression(NewLiteral("café", sql.LongText), aiCollation),
+			NewLiteral("CAFE%", sql.LongText),
+			true,
+		},
+		{
+			"explicit collation of the pattern over the one of the column",
+			NewGetField(0, sql.CreateLongText(sql.Collation_utf8mb4_0900_bin), "", false),
+			NewCollatedExpression(NewLiteral("cafe%", sql.LongText), aiCollation),
+			true,
+		},
+		{
+			"collation of the column over the one of the pattern",
+			NewGetField(0, sql.CreateLongText(sql.Collation_utf8mb4_0900_bin), "", false),
+			NewLiteral("cafe%", sql.CreateLongText(aiCollation)),
+			false,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			value, err := NewLike(tt.left, tt.right, nil).Eval(sql.NewEmptyContext(), sql.NewRow("café"))
+			require.NoError(t, err)
+			require.Equal(t, tt.ok, value)
+		})
+	}
+}
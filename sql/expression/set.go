@@ -50,6 +50,10 @@ func (s *SetField) Type() sql.Type {
 // Eval implements the Expression interface.
 // Returns a copy of the given row with an updated value.
 func (s *SetField) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	if tuple, ok := s.Left.(Tuple); ok {
+		return s.evalTuple(ctx, row, tuple)
+	}
+
 	getField, ok := s.Left.(*GetField)
 	if !ok {
 		return nil, errCannotSetField.New(s.Left)
@@ -63,7 +67,8 @@ func (s *SetField) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 		return nil, err
 	}
 	if val != nil {
-		val, err = getField.fieldType.Convert(val)
+		// TODO: the number of the row updated isn't known here
+		val, err = sql.ConvertColumnValue(ctx, getField.name, getField.fieldType, val, 1, sql.IsStrictSQLMode(ctx))
 		if err != nil {
 			return nil, err
 		}
@@ -73,6 +78,48 @@ func (s *SetField) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 	return updatedRow, nil
 }
 
+// evalTuple returns a copy of the given row with each field of the tuple given updated to the value in the same
+// position of the row value assigned to it. All the fields are set to NULL if the row value is NULL.
+func (s *SetField) evalTuple(ctx *sql.Context, row sql.Row, tuple Tuple) (interface{}, error) {
+	if nc := sql.NumColumns(s.Right.Type()); nc != len(tuple) {
+		return nil, sql.ErrInvalidOperandColumns.New(len(tuple), nc)
+	}
+
+	val, err := s.Right.Eval(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+	var vals []interface{}
+	if val != nil {
+		var ok bool
+		vals, ok = val.([]interface{})
+		if !ok || len(vals) != len(tuple) {
+			return nil, sql.ErrInvalidOperandColumns.New(len(tuple), 1)
+		}
+	}
+
+	updatedRow := row.Copy()
+	for i, e := range tuple {
+		getField, ok := e.(*GetField)
+		if !ok {
+			return nil, errCannotSetField.New(e)
+		}
+		if getField.fieldIndex < 0 || getField.fieldIndex >= len(row) {
+			return nil, ErrIndexOutOfBounds.New(getField.fieldIndex, len(row))
+		}
+
+		var v interface{}
+		if vals != nil && vals[i] != nil {
+			v, err = sql.ConvertColumnValue(ctx, getField.name, getField.fieldType, vals[i], 1, sql.IsStrictSQLMode(ctx))
+			if err != nil {
+				return nil, err
+			}
+		}
+		updatedRow[getField.fieldIndex] = v
+	}
+	return updatedRow, nil
+}
+
 // WithChildren implements the Expression interface.
 func (s *SetField) WithChildren(children ...sql.Expression) (sql.Expression, error) {
 	if len(children) != 2 {
@@ -20,11 +20,15 @@ import (
 	"github.com/dolthub/go-mysql-server/sql"
 )
 
+// Sorter sorts rows by the sort fields given. Errors, including the error of the context once it's done, are set in
+// LastError, after which all rows compare as equal so that the sort ends quickly.
 type Sorter struct {
 	SortFields []sql.SortField
 	Rows       []sql.Row
 	LastError  error
 	Ctx        *sql.Context
+
+	comparisons int
 }
 
 func (s *Sorter) Len() int {
@@ -40,6 +44,12 @@ func (s *Sorter) Less(i, j int) bool {
 		return false
 	}
 
+	if err := sql.CheckCancellation(s.Ctx, s.comparisons); err != nil {
+		s.LastError = err
+		return false
+	}
+	s.comparisons++
+
 	a := s.Rows[i]
 	b := s.Rows[j]
 	for _, sf := range s.SortFields {
@@ -91,16 +101,35 @@ func (s *Sorter) Less(i, j int) bool {
 // seeing all the rows, call Rows(). Rows() will return the rows which come
 // back from heap.Pop() in reverse order, correctly restoring the order for the
 // TopN elements.
+//
+// Rows that sort as equal are ordered by the order in which they were pushed,
+// so the resulting rows are the same as those from a stable sort of all the
+// rows followed by a limit.
 type TopRowsHeap struct {
 	Sorter
+	seqs    []uint64
+	nextSeq uint64
 }
 
 func (h *TopRowsHeap) Less(i, j int) bool {
-	return !h.Sorter.Less(i, j)
+	if h.Sorter.Less(i, j) {
+		return false
+	}
+	if h.Sorter.Less(j, i) {
+		return true
+	}
+	return h.seqs[i] > h.seqs[j]
+}
+
+func (h *TopRowsHeap) Swap(i, j int) {
+	h.Sorter.Swap(i, j)
+	h.seqs[i], h.seqs[j] = h.seqs[j], h.seqs[i]
 }
 
 func (h *TopRowsHeap) Push(x interface{}) {
 	h.Sorter.Rows = append(h.Sorter.Rows, x.(sql.Row))
+	h.seqs = append(h.seqs, h.nextSeq)
+	h.nextSeq++
 }
 
 func (h *TopRowsHeap) Pop() interface{} {
@@ -108,6 +137,7 @@ func (h *TopRowsHeap) Pop() interface{} {
 	n := len(old)
 	res := old[n-1]
 	h.Sorter.Rows = old[0 : n-1]
+	h.seqs = h.seqs[0 : n-1]
 	return res
 }
 
@@ -132,3 +132,47 @@ func (v *UserVar) WithChildren(children ...sql.Expression) (sql.Expression, erro
 	}
 	return v, nil
 }
+
+// UserVarAssignment is the := operator, as in @v := @v + 1, which assigns the value of an expression to a user variable
+// and returns it. The variable is assigned every time the expression is evaluated.
+type UserVarAssignment struct {
+	UnaryExpression
+	Name string
+}
+
+var _ sql.NonDeterministicExpression = (*UserVarAssignment)(nil)
+
+// NewUserVarAssignment creates a new UserVarAssignment expression.
+func NewUserVarAssignment(name string, value sql.Expression) *UserVarAssignment {
+	return &UserVarAssignment{UnaryExpression{value}, name}
+}
+
+// Eval implements the sql.Expression interface.
+func (a *UserVarAssignment) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	val, err := a.Child.Eval(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+	if err := ctx.SetUserVariable(ctx, a.Name, val); err != nil {
+		return nil, err
+	}
+	return val, nil
+}
+
+// Type implements the sql.Expression interface.
+func (a *UserVarAssignment) Type() sql.Type { return a.Child.Type() }
+
+// IsNonDeterministic implements the sql.NonDeterministicExpression interface. The expression changes the value of its
+// variable, so it can't be evaluated once for many rows.
+func (a *UserVarAssignment) IsNonDeterministic() bool { return true }
+
+// String implements the sql.Expression interface.
+func (a *UserVarAssignment) String() string { return fmt.Sprintf("@%s := %s", a.Name, a.Child) }
+
+// WithChildren implements the Expression interface.
+func (a *UserVarAssignment) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(a, len(children), 1)
+	}
+	return NewUserVarAssignment(a.Name, children[0]), nil
+}
@@ -14,6 +14,12 @@
 
 package sql
 
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
 // Function is a function defined by the user that can be applied in a SQL query.
 type Function interface {
 	// NewInstance returns a new instance of the function to evaluate against rows
@@ -33,6 +39,7 @@ type CreateFunc5Args func(e1, e2, e3, e4, e5 Expression) Expression
 type CreateFunc6Args func(e1, e2, e3, e4, e5, e6 Expression) Expression
 type CreateFunc7Args func(e1, e2, e3, e4, e5, e6, e7 Expression) Expression
 type CreateFuncNArgs func(args ...Expression) (Expression, error)
+type CreateAggregateFunc func(args ...Expression) (Aggregation, error)
 
 type (
 	// Function0 is a function with 0 arguments.
@@ -82,6 +89,19 @@ type (
 		Name string
 		Fn   CreateFuncNArgs
 	}
+	// AggregateFunction is a user-provided aggregate function. Its instances are Aggregations, which are grouped and
+	// windowed like the built-in aggregate functions. Its arity and return type are validated on registration, and
+	// every instance is checked against them.
+	AggregateFunction struct {
+		Name string
+		// MinArgs is the minimum number of arguments the function accepts.
+		MinArgs int
+		// MaxArgs is the maximum number of arguments the function accepts, or -1 if there is no maximum.
+		MaxArgs int
+		// ReturnType is the type of the function's result.
+		ReturnType Type
+		Fn         CreateAggregateFunc
+	}
 )
 
 var _ Function = Function0{}
@@ -93,6 +113,7 @@ var _ Function = Function5{}
 var _ Function = Function6{}
 var _ Function = Function7{}
 var _ Function = FunctionN{}
+var _ Function = AggregateFunction{}
 
 func NewFunction0(name string, fn func() Expression) Function0 {
 	return Function0{
@@ -169,22 +190,77 @@ func (fn FunctionN) NewInstance(args []Expression) (Expression, error) {
 	return fn.Fn(args...)
 }
 
-func (fn Function0) FunctionName() string { return fn.Name }
-func (fn Function1) FunctionName() string { return fn.Name }
-func (fn Function2) FunctionName() string { return fn.Name }
-func (fn Function3) FunctionName() string { return fn.Name }
-func (fn Function4) FunctionName() string { return fn.Name }
-func (fn Function5) FunctionName() string { return fn.Name }
-func (fn Function6) FunctionName() string { return fn.Name }
-func (fn Function7) FunctionName() string { return fn.Name }
-func (fn FunctionN) FunctionName() string { return fn.Name }
-
-func (Function0) isFunction() {}
-func (Function1) isFunction() {}
-func (Function2) isFunction() {}
-func (Function3) isFunction() {}
-func (Function4) isFunction() {}
-func (Function5) isFunction() {}
-func (Function6) isFunction() {}
-func (Function7) isFunction() {}
-func (FunctionN) isFunction() {}
+// NewInstance implements the Function interface. It returns an error if the number of arguments or the type of the
+// aggregation returned doesn't match the function's metadata.
+func (fn AggregateFunction) NewInstance(args []Expression) (Expression, error) {
+	if len(args) < fn.MinArgs || (fn.MaxArgs >= 0 && len(args) > fn.MaxArgs) {
+		return nil, ErrInvalidArgumentNumber.New(fn.Name, fn.arity(), len(args))
+	}
+
+	agg, err := fn.Fn(args...)
+	if err != nil {
+		return nil, err
+	}
+
+	if !TypesEqual(agg.Type(), fn.ReturnType) {
+		return nil, ErrInvalidAggregateFunction.New(fn.Name,
+			fmt.Sprintf("returned type %s, expected %s", agg.Type(), fn.ReturnType))
+	}
+
+	return agg, nil
+}
+
+// Validate returns an error if the function's metadata is invalid.
+func (fn AggregateFunction) Validate() error {
+	switch {
+	case fn.Name == "":
+		return ErrInvalidAggregateFunction.New(fn.Name, "missing name")
+	case strings.ToLower(fn.Name) != fn.Name:
+		return ErrInvalidAggregateFunction.New(fn.Name, "name must be lowercase")
+	case fn.Fn == nil:
+		return ErrInvalidAggregateFunction.New(fn.Name, "missing constructor")
+	case fn.ReturnType == nil:
+		return ErrInvalidAggregateFunction.New(fn.Name, "missing return type")
+	case fn.MinArgs < 0:
+		return ErrInvalidAggregateFunction.New(fn.Name, "negative minimum number of arguments")
+	case fn.MaxArgs >= 0 && fn.MaxArgs < fn.MinArgs:
+		return ErrInvalidAggregateFunction.New(fn.Name, "maximum number of arguments is less than the minimum")
+	case fn.MaxArgs < -1:
+		return ErrInvalidAggregateFunction.New(fn.Name, "invalid maximum number of arguments")
+	}
+	return nil
+}
+
+// arity returns a description of the number of arguments the function accepts.
+func (fn AggregateFunction) arity() string {
+	switch {
+	case fn.MaxArgs < 0:
+		return fmt.Sprintf("%d or more", fn.MinArgs)
+	case fn.MaxArgs == fn.MinArgs:
+		return strconv.Itoa(fn.MinArgs)
+	default:
+		return fmt.Sprintf("%d to %d", fn.MinArgs, fn.MaxArgs)
+	}
+}
+
+func (fn Function0) FunctionName() string         { return fn.Name }
+func (fn Function1) FunctionName() string         { return fn.Name }
+func (fn Function2) FunctionName() string         { return fn.Name }
+func (fn Function3) FunctionName() string         { return fn.Name }
+func (fn Function4) FunctionName() string         { return fn.Name }
+func (fn Function5) FunctionName() string         { return fn.Name }
+func (fn Function6) FunctionName() string         { return fn.Name }
+func (fn Function7) FunctionName() string         { return fn.Name }
+func (fn FunctionN) FunctionName() string         { return fn.Name }
+func (fn AggregateFunction) FunctionName() string { return fn.Name }
+
+func (Function0) isFunction()         {}
+func (Function1) isFunction()         {}
+func (Function2) isFunction()         {}
+func (Function3) isFunction()         {}
+func (Function4) isFunction()         {}
+func (Function5) isFunction()         {}
+func (Function6) isFunction()         {}
+func (Function7) isFunction()         {}
+func (FunctionN) isFunction()         {}
+func (AggregateFunction) isFunction() {}
@@ -0,0 +1,69 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/expression/function/aggregation"
+)
+
+func TestAggregateFunction(t *testing.T) {
+	fn := sql.AggregateFunction{
+		Name:       "my_sum",
+		MinArgs:    1,
+		MaxArgs:    1,
+		ReturnType: sql.Float64,
+		Fn: func(args ...sql.Expression) (sql.Aggregation, error) {
+			return aggregation.NewSum(args[0]), nil
+		},
+	}
+	require.NoError(t, fn.Validate())
+
+	col := expression.NewGetField(0, sql.Int64, "a", true)
+	agg, err := fn.NewInstance([]sql.Expression{col})
+	require.NoError(t, err)
+	require.Equal(t, aggregation.NewSum(col), agg)
+
+	_, err = fn.NewInstance(nil)
+	require.True(t, sql.ErrInvalidArgumentNumber.Is(err))
+	_, err = fn.NewInstance([]sql.Expression{col, col})
+	require.True(t, sql.ErrInvalidArgumentNumber.Is(err))
+
+	wrongType := fn
+	wrongType.ReturnType = sql.Int64
+	_, err = wrongType.NewInstance([]sql.Expression{col})
+	require.True(t, sql.ErrInvalidAggregateFunction.Is(err))
+
+	for name, invalid := range map[string]func(fn *sql.AggregateFunction){
+		"no name":           func(fn *sql.AggregateFunction) { fn.Name = "" },
+		"uppercase name":    func(fn *sql.AggregateFunction) { fn.Name = "My_Sum" },
+		"no constructor":    func(fn *sql.AggregateFunction) { fn.Fn = nil },
+		"no return type":    func(fn *sql.AggregateFunction) { fn.ReturnType = nil },
+		"negative min":      func(fn *sql.AggregateFunction) { fn.MinArgs = -1 },
+		"max less than min": func(fn *sql.AggregateFunction) { fn.MinArgs, fn.MaxArgs = 2, 1 },
+		"invalid max":       func(fn *sql.AggregateFunction) { fn.MaxArgs = -2 },
+	} {
+		t.Run(name, func(t *testing.T) {
+			fn := fn
+			invalid(&fn)
+			require.True(t, sql.ErrInvalidAggregateFunction.Is(fn.Validate()))
+		})
+	}
+}
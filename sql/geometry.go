@@ -0,0 +1,199 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+import (
+	"bytes"
+	"encoding/binary"
+	"strconv"
+	"strings"
+
+	"github.com/dolthub/vitess/go/sqltypes"
+	"github.com/dolthub/vitess/go/vt/proto/query"
+	"gopkg.in/src-d/go-errors.v1"
+)
+
+// ErrInvalidGISData is returned when a spatial function is given a value that isn't a valid geometry.
+var ErrInvalidGISData = errors.NewKind("Invalid GIS data provided to function %s.")
+
+// ErrNotGeometry is returned when a value that isn't a geometry is converted to the GEOMETRY type.
+var ErrNotGeometry = errors.NewKind("value %v is not a geometry")
+
+// Geometry is the type of spatial values. Only points and polygons on a plane are supported.
+var Geometry GeometryType = geometryType{}
+
+type GeometryType interface {
+	Type
+}
+
+type geometryType struct{}
+
+// GeometryValue is a value of the GEOMETRY type.
+type GeometryValue interface {
+	// WKT returns the geometry in the well-known text format, as in POINT(1 2).
+	WKT() string
+	// WKB returns the geometry in the well-known binary format.
+	WKB() []byte
+}
+
+// Point is a GeometryValue made of a single position.
+type Point struct {
+	X, Y float64
+}
+
+var _ GeometryValue = Point{}
+
+// Polygon is a GeometryValue made of closed rings of points, whose last point is the same as their first one. The first
+// ring is the exterior boundary of the polygon, any others are the boundaries of holes in it.
+type Polygon struct {
+	Rings [][]Point
+}
+
+var _ GeometryValue = Polygon{}
+
+// Well-known binary geometry types
+const (
+	wkbPoint   uint32 = 1
+	wkbPolygon uint32 = 3
+)
+
+// WKT implements the GeometryValue interface.
+func (p Point) WKT() string {
+	return "POINT(" + p.coordinates() + ")"
+}
+
+// WKB implements the GeometryValue interface.
+func (p Point) WKB() []byte {
+	var buf bytes.Buffer
+	writeWKBHeader(&buf, wkbPoint)
+	p.writeWKB(&buf)
+	return buf.Bytes()
+}
+
+func (p Point) coordinates() string {
+	return strconv.FormatFloat(p.X, 'f', -1, 64) + " " + strconv.FormatFloat(p.Y, 'f', -1, 64)
+}
+
+func (p Point) writeWKB(buf *bytes.Buffer) {
+	_ = binary.Write(buf, binary.LittleEndian, p.X)
+	_ = binary.Write(buf, binary.LittleEndian, p.Y)
+}
+
+// WKT implements the GeometryValue interface.
+func (p Polygon) WKT() string {
+	var sb strings.Builder
+	sb.WriteString("POLYGON(")
+	for i, ring := range p.Rings {
+		if i > 0 {
+			sb.WriteString(",")
+		}
+		sb.WriteString("(")
+		for j, point := range ring {
+			if j > 0 {
+				sb.WriteString(",")
+			}
+			sb.WriteString(point.coordinates())
+		}
+		sb.WriteString(")")
+	}
+	sb.WriteString(")")
+	return sb.String()
+}
+
+// WKB implements the GeometryValue interface.
+func (p Polygon) WKB() []byte {
+	var buf bytes.Buffer
+	writeWKBHeader(&buf, wkbPolygon)
+	_ = binary.Write(&buf, binary.LittleEndian, uint32(len(p.Rings)))
+	for _, ring := range p.Rings {
+		_ = binary.Write(&buf, binary.LittleEndian, uint32(len(ring)))
+		for _, point := range ring {
+			point.writeWKB(&buf)
+		}
+	}
+	return buf.Bytes()
+}
+
+// writeWKBHeader writes the start of the well-known binary format of a geometry of the type given: its byte order,
+// which is always little endian, followed by its type.
+func writeWKBHeader(buf *bytes.Buffer, geometryType uint32) {
+	buf.WriteByte(1)
+	_ = binary.Write(buf, binary.LittleEndian, geometryType)
+}
+
+// Compare implements Type interface. Geometries are compared by their binary format.
+func (t geometryType) Compare(a interface{}, b interface{}) (int, error) {
+	if hasNulls, res := compareNulls(a, b); hasNulls {
+		return res, nil
+	}
+
+	ag, err := t.Convert(a)
+	if err != nil {
+		return 0, err
+	}
+	bg, err := t.Convert(b)
+	if err != nil {
+		return 0, err
+	}
+	return bytes.Compare(ag.(GeometryValue).WKB(), bg.(GeometryValue).WKB()), nil
+}
+
+// Convert implements Type interface.
+func (t geometryType) Convert(v interface{}) (interface{}, error) {
+	switch v := v.(type) {
+	case nil:
+		return nil, nil
+	case GeometryValue:
+		return v, nil
+	default:
+		return nil, ErrNotGeometry.New(v)
+	}
+}
+
+// Promote implements the Type interface.
+func (t geometryType) Promote() Type {
+	return t
+}
+
+// SQL implements Type interface. Geometries are sent in MySQL's internal format: their SRID, which is always 0, followed
+// by their well-known binary format.
+func (t geometryType) SQL(v interface{}) (sqltypes.Value, error) {
+	if v == nil {
+		return sqltypes.NULL, nil
+	}
+
+	g, err := t.Convert(v)
+	if err != nil {
+		return sqltypes.Value{}, err
+	}
+
+	val := append(make([]byte, 4), g.(GeometryValue).WKB()...)
+	return sqltypes.MakeTrusted(sqltypes.Geometry, val), nil
+}
+
+// String implements Type interface.
+func (t geometryType) String() string {
+	return "GEOMETRY"
+}
+
+// Type implements Type interface.
+func (t geometryType) Type() query.Type {
+	return sqltypes.Geometry
+}
+
+// Zero implements Type interface.
+func (t geometryType) Zero() interface{} {
+	return nil
+}
@@ -0,0 +1,65 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+import (
+	"testing"
+
+	"github.com/dolthub/vitess/go/sqltypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeometrySQL(t *testing.T) {
+	val, err := Geometry.SQL(Point{X: 1, Y: -2})
+	require.NoError(t, err)
+	assert.Equal(t, sqltypes.Geometry, val.Type())
+	assert.Equal(t, []byte{
+		0, 0, 0, 0, // SRID
+		1,          // little endian
+		1, 0, 0, 0, // point
+		0, 0, 0, 0, 0, 0, 0xf0, 0x3f, // 1
+		0, 0, 0, 0, 0, 0, 0, 0xc0, // -2
+	}, val.Raw())
+
+	val, err = Geometry.SQL(Polygon{Rings: [][]Point{{{0, 0}, {1, 0}, {1, 1}, {0, 0}}}})
+	require.NoError(t, err)
+	assert.Equal(t, 4+1+4+4+4+4*16, len(val.Raw()))
+	assert.Equal(t, []byte{1, 3, 0, 0, 0, 1, 0, 0, 0, 4, 0, 0, 0}, val.Raw()[4:17])
+
+	val, err = Geometry.SQL(nil)
+	require.NoError(t, err)
+	assert.True(t, val.IsNull())
+
+	_, err = Geometry.SQL("POINT(1 2)")
+	assert.True(t, ErrNotGeometry.Is(err))
+}
+
+func TestGeometryCompare(t *testing.T) {
+	cmp, err := Geometry.Compare(Point{X: 1, Y: 2}, Point{X: 1, Y: 2})
+	require.NoError(t, err)
+	assert.Equal(t, 0, cmp)
+
+	cmp, err = Geometry.Compare(Point{X: 1, Y: 2}, Polygon{Rings: [][]Point{{{0, 0}, {1, 0}, {1, 1}, {0, 0}}}})
+	require.NoError(t, err)
+	assert.Equal(t, -1, cmp)
+
+	cmp, err = Geometry.Compare(nil, Point{X: 1, Y: 2})
+	require.NoError(t, err)
+	assert.Equal(t, 1, cmp)
+
+	_, err = Geometry.Compare(1, Point{X: 1, Y: 2})
+	assert.True(t, ErrNotGeometry.Is(err))
+}
@@ -18,6 +18,9 @@ import (
 	"fmt"
 )
 
+// MaxIndexCommentLength is the maximum number of characters in the comment of an index.
+const MaxIndexCommentLength = 1024
+
 // Index is the representation of an index, and also creates an IndexLookup when given a collection of ranges.
 type Index interface {
 	// ID returns the identifier of the index.
@@ -18,6 +18,7 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"sort"
 	"strings"
 	"time"
 
@@ -210,13 +211,13 @@ var schemataSchema = Schema{
 }
 
 var collationsSchema = Schema{
-	{Name: "collation_name", Type: LongText, Default: nil, Nullable: false, Source: CollationsTableName},
-	{Name: "character_set_name", Type: LongText, Default: nil, Nullable: false, Source: CollationsTableName},
-	{Name: "id", Type: LongText, Default: nil, Nullable: false, Source: CollationsTableName},
-	{Name: "is_default", Type: LongText, Default: nil, Nullable: false, Source: CollationsTableName},
-	{Name: "is_compiled", Type: LongText, Default: nil, Nullable: true, Source: CollationsTableName},
-	{Name: "sortlen", Type: LongText, Default: nil, Nullable: false, Source: CollationsTableName},
-	{Name: "pad_attribute", Type: LongText, Default: nil, Nullable: false, Source: CollationsTableName},
+	{Name: "collation_name", Type: MustCreateStringWithDefaults(sqltypes.VarChar, 64), Default: nil, Nullable: false, Source: CollationsTableName},
+	{Name: "character_set_name", Type: MustCreateStringWithDefaults(sqltypes.VarChar, 64), Default: nil, Nullable: false, Source: CollationsTableName},
+	{Name: "id", Type: Uint64, Default: nil, Nullable: false, Source: CollationsTableName},
+	{Name: "is_default", Type: MustCreateStringWithDefaults(sqltypes.VarChar, 3), Default: nil, Nullable: false, Source: CollationsTableName},
+	{Name: "is_compiled", Type: MustCreateStringWithDefaults(sqltypes.VarChar, 3), Default: nil, Nullable: false, Source: CollationsTableName},
+	{Name: "sortlen", Type: Uint32, Default: nil, Nullable: false, Source: CollationsTableName},
+	{Name: "pad_attribute", Type: MustCreateStringWithDefaults(sqltypes.VarChar, 9), Default: nil, Nullable: false, Source: CollationsTableName},
 }
 
 var statisticsSchema = Schema{
@@ -530,18 +531,22 @@ func columnsRowIter(ctx *Context, cat Catalog) (RowIter, error) {
 		err := DBTableIter(ctx, db, func(t Table) (cont bool, err error) {
 			for i, c := range t.Schema() {
 				var (
-					nullable string
-					charName interface{}
-					collName interface{}
+					nullable   string
+					charName   interface{}
+					collName   interface{}
+					generation string
 				)
 				if c.Nullable {
 					nullable = "YES"
 				} else {
 					nullable = "NO"
 				}
-				if IsText(c.Type) {
-					charName = Collation_Default.CharacterSet().String()
-					collName = Collation_Default.String()
+				if st, ok := c.Type.(StringType); ok && IsText(c.Type) {
+					charName = st.CharacterSet().String()
+					collName = st.Collation().String()
+				}
+				if c.Generated != nil {
+					generation = c.Generated.Expression.String()
 				}
 				rows = append(rows, Row{
 					"def",                            // table_catalog
@@ -561,10 +566,10 @@ func columnsRowIter(ctx *Context, cat Catalog) (RowIter, error) {
 					collName,                         // collation_name
 					strings.ToLower(c.Type.String()), // column_type
 					"",                               // column_key
-					c.Extra,                          // extra
+					c.ExtraInfo(),                    // extra
 					"select",                         // privileges
 					c.Comment,                        // column_comment
-					"",                               // generation_expression
+					generation,                       // generation_expression
 				})
 			}
 			return true, nil
@@ -582,11 +587,12 @@ func schemataRowIter(ctx *Context, c Catalog) (RowIter, error) {
 
 	var rows []Row
 	for _, db := range dbs {
+		collation := GetDatabaseCollation(ctx, db)
 		rows = append(rows, Row{
 			"def",
 			db.Name(),
-			Collation_Default.CharacterSet().String(),
-			Collation_Default.String(),
+			collation.CharacterSet().String(),
+			collation.String(),
 			nil,
 		})
 	}
@@ -595,8 +601,14 @@ func schemataRowIter(ctx *Context, c Catalog) (RowIter, error) {
 }
 
 func collationsRowIter(ctx *Context, c Catalog) (RowIter, error) {
-	var rows []Row
+	names := make([]string, 0, len(CollationToMySQLVals))
 	for cName := range CollationToMySQLVals {
+		names = append(names, cName)
+	}
+	sort.Strings(names)
+
+	var rows []Row
+	for _, cName := range names {
 		c := Collations[cName]
 		rows = append(rows, Row{
 			c.String(),
@@ -613,7 +625,7 @@ func collationsRowIter(ctx *Context, c Catalog) (RowIter, error) {
 
 func charsetRowIter(ctx *Context, c Catalog) (RowIter, error) {
 	var rows []Row
-	for _, c := range SupportedCharsets {
+	for _, c := range CharacterSets() {
 		rows = append(rows, Row{
 			c.String(),
 			c.DefaultCollation().String(),
@@ -658,6 +670,11 @@ func triggersRowIter(ctx *Context, c Catalog) (RowIter, error) {
 				if !ok {
 					return nil, ErrTriggerCreateStatementInvalid.New(trigger.CreateStatement)
 				}
+				triggerPlan.CreatedAt = trigger.CreatedAt
+				triggerPlan.SqlMode = trigger.SqlMode
+				if trigger.Definer != "" {
+					triggerPlan.Definer = trigger.Definer
+				}
 				triggerPlans = append(triggerPlans, triggerPlan)
 			}
 
@@ -708,6 +725,11 @@ func triggersRowIter(ctx *Context, c Catalog) (RowIter, error) {
 					if err != nil {
 						return nil, err
 					}
+					// Triggers that were stored without their creation time are shown as created at the epoch
+					created := triggerPlan.CreatedAt
+					if created.IsZero() {
+						created = time.Unix(0, 0)
+					}
 					rows = append(rows, Row{
 						"def",                   // trigger_catalog
 						triggerDb.Name(),        // trigger_schema
@@ -725,9 +747,9 @@ func triggersRowIter(ctx *Context, c Catalog) (RowIter, error) {
 						nil,                     // action_reference_new_table
 						"OLD",                   // action_reference_old_row
 						"NEW",                   // action_reference_new_row
-						time.Unix(0, 0).UTC(),   // created
-						"",                      // sql_mode
-						"",                      // definer
+						created.UTC(),           // created
+						triggerPlan.SqlMode,     // sql_mode
+						triggerPlan.Definer,     // definer
 						characterSetClient,      // character_set_client
 						collationConnection,     // collation_connection
 						collationServer,         // database_collation
@@ -856,6 +878,87 @@ func getColumnNamesFromIndex(idx Index, table Table) []string {
 	return indexCols
 }
 
+// statisticsRowIter returns a row for each expression of each index of the tables of all databases, like SHOW INDEXES
+// does for a single table.
+func statisticsRowIter(ctx *Context, c Catalog) (RowIter, error) {
+	var rows []Row
+	for _, db := range c.AllDatabases() {
+		tableNames, err := db.GetTableNames(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, tableName := range tableNames {
+			tbl, _, err := c.Table(ctx, db.Name(), tableName)
+			if err != nil {
+				return nil, err
+			}
+
+			indexTable, ok := tbl.(IndexedTable)
+			if !ok {
+				continue
+			}
+			indexes, err := indexTable.GetIndexes(ctx)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, index := range indexes {
+				if index.IsGenerated() {
+					continue
+				}
+
+				nonUnique := int64(1)
+				if index.IsUnique() {
+					nonUnique = 0
+				}
+
+				visible := "YES"
+				if x, ok := index.(DriverIndex); ok && len(x.Driver()) > 0 {
+					if !ctx.GetIndexRegistry().CanUseIndex(x) {
+						visible = "NO"
+					}
+				}
+
+				for i, expr := range index.Expressions() {
+					var columnName, expression interface{}
+					expression = expr
+					nullable := ""
+					if col := plan.GetColumnFromIndexExpr(expr, tbl); col != nil {
+						columnName, expression = col.Name, nil
+						if col.Nullable {
+							nullable = "YES"
+						}
+					}
+
+					rows = append(rows, Row{
+						"def",             // table_catalog
+						db.Name(),         // table_schema
+						tbl.Name(),        // table_name
+						nonUnique,         // non_unique
+						db.Name(),         // index_schema
+						index.ID(),        // index_name
+						int64(i + 1),      // seq_in_index
+						columnName,        // column_name
+						nil,               // collation
+						int64(0),          // cardinality
+						nil,               // sub_part
+						nil,               // packed
+						nullable,          // nullable
+						index.IndexType(), // index_type
+						"",                // comment
+						index.Comment(),   // index_comment
+						visible,           // is_visible
+						expression,        // expression
+					})
+				}
+			}
+		}
+	}
+
+	return RowsToRowIter(rows...), nil
+}
+
 func keyColumnConstraintRowIter(ctx *Context, c Catalog) (RowIter, error) {
 	var rows []Row
 	for _, db := range c.AllDatabases() {
@@ -991,7 +1094,7 @@ func NewInformationSchemaDatabase() Database {
 			StatisticsTableName: &informationSchemaTable{
 				name:    StatisticsTableName,
 				schema:  statisticsSchema,
-				rowIter: emptyRowIter,
+				rowIter: statisticsRowIter,
 			},
 			TableConstraintsTableName: &informationSchemaTable{
 				name:    TableConstraintsTableName,
@@ -1068,15 +1171,25 @@ func viewRowIter(context *Context, catalog Catalog) (RowIter, error) {
 		}
 
 		for _, view := range views {
+			attributes, definition := SplitViewAttributes(view.TextDefinition)
+			definition, checkOption := SplitViewCheckOption(definition)
+			definer := strings.ReplaceAll(DefaultViewDefiner, "`", "")
+			if attributes.DefinerUser != "" {
+				definer = attributes.DefinerUser + "@" + attributes.DefinerHost
+			}
+			security := attributes.Security
+			if security == "" {
+				security = "DEFINER"
+			}
 			rows = append(rows, Row{
 				"def",
 				dbName,
 				view.Name,
-				view.TextDefinition,
-				"NONE",
+				definition,
+				checkOption.String(),
 				"YES",
-				"",
-				"DEFINER",
+				definer,
+				security,
 				Collation_Default.CharacterSet().String(),
 				Collation_Default.String(),
 			})
@@ -0,0 +1,436 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// JSONPath is a parsed MySQL JSON path, such as $.a.b[2], $.*, $[*].c, $[last - 1], $[1 to 3] or $**.c.
+// cc: https://dev.mysql.com/doc/refman/8.0/en/json.html#json-path-syntax
+type JSONPath struct {
+	legs []jsonPathLeg
+}
+
+type jsonPathLegKind byte
+
+const (
+	jsonPathMember jsonPathLegKind = iota
+	jsonPathMemberWildcard
+	jsonPathArrayIndex
+	jsonPathArrayWildcard
+	jsonPathArrayRange
+	jsonPathDoubleWildcard
+)
+
+// jsonPathLeg is a step of a JSON path. The key is set for members, and the indexes for array indexes and ranges.
+type jsonPathLeg struct {
+	kind     jsonPathLegKind
+	key      string
+	from, to jsonArrayIndex
+}
+
+// jsonArrayIndex is an index of a JSON path leg, which counts back from the last element when fromLast is set.
+type jsonArrayIndex struct {
+	n        int
+	fromLast bool
+}
+
+// resolve returns the position of the index in an array of the length given.
+func (i jsonArrayIndex) resolve(length int) int {
+	if i.fromLast {
+		return length - 1 - i.n
+	}
+	return i.n
+}
+
+// ParseJSONPath parses the JSON path given, returning ErrInvalidJSONPath if it's not a valid MySQL JSON path.
+func ParseJSONPath(path string) (JSONPath, error) {
+	p := &jsonPathParser{path: path}
+	p.skipSpaces()
+	if !p.consume("$") {
+		return JSONPath{}, p.err()
+	}
+
+	var legs []jsonPathLeg
+	for {
+		p.skipSpaces()
+		if p.pos == len(p.path) {
+			break
+		}
+
+		var leg jsonPathLeg
+		var err error
+		switch p.path[p.pos] {
+		case '.':
+			p.pos++
+			leg, err = p.parseMember()
+		case '[':
+			p.pos++
+			leg, err = p.parseArrayLeg()
+		case '*':
+			if !p.consume("**") {
+				return JSONPath{}, p.err()
+			}
+			leg = jsonPathLeg{kind: jsonPathDoubleWildcard}
+		default:
+			return JSONPath{}, p.err()
+		}
+		if err != nil {
+			return JSONPath{}, err
+		}
+		legs = append(legs, leg)
+	}
+
+	// A path can't end with **
+	if len(legs) > 0 && legs[len(legs)-1].kind == jsonPathDoubleWildcard {
+		return JSONPath{}, p.err()
+	}
+	return JSONPath{legs: legs}, nil
+}
+
+// HasWildcard returns whether the path can match more than one value, which is the case when it has a wildcard or a
+// range.
+func (p JSONPath) HasWildcard() bool {
+	for _, leg := range p.legs {
+		if leg.kind != jsonPathMember && leg.kind != jsonPathArrayIndex {
+			return true
+		}
+	}
+	return false
+}
+
+// Lookup returns the values of the unmarshalled JSON document given matched by the path, in document order. The
+// members of objects are visited in MySQL's key order. Returns nil if no value matches.
+func (p JSONPath) Lookup(doc interface{}) []interface{} {
+	matches := p.lookup(doc, false)
+	if matches == nil {
+		return nil
+	}
+	vals := make([]interface{}, len(matches))
+	for i, m := range matches {
+		vals[i] = m.val
+	}
+	return vals
+}
+
+// Walk visits the values of the unmarshalled JSON document given matched by the path and every value within them, in
+// document order, passing each value's path in the form MySQL shows it in, as in $[0].a or $."a b". Object members are
+// visited in MySQL's key order: shorter keys first, then by their bytes. Walking stops once the visitor returns false,
+// in which case Walk returns false.
+func (p JSONPath) Walk(doc interface{}, visit func(path string, val interface{}) bool) bool {
+	for _, m := range p.lookup(doc, true) {
+		if !walkJSON(m.val, m.path, visit) {
+			return false
+		}
+	}
+	return true
+}
+
+// jsonPathMatch is a value matched by a JSON path, along with its own path if it was asked for.
+type jsonPathMatch struct {
+	val  interface{}
+	path string
+}
+
+// lookup returns the values of the document given matched by the path, along with their paths if withPaths is set.
+func (p JSONPath) lookup(doc interface{}, withPaths bool) []jsonPathMatch {
+	matches := []jsonPathMatch{{val: doc, path: "$"}}
+	for _, leg := range p.legs {
+		var next []jsonPathMatch
+		for _, m := range matches {
+			next = leg.lookup(m, next, withPaths)
+		}
+		if len(next) == 0 {
+			return nil
+		}
+		matches = next
+	}
+	return matches
+}
+
+// member returns the match of the member of the matched object given with the key given.
+func (m jsonPathMatch) member(obj map[string]interface{}, key string, withPaths bool) jsonPathMatch {
+	member := jsonPathMatch{val: obj[key]}
+	if withPaths {
+		member.path = m.path + "." + jsonPathKey(key)
+	}
+	return member
+}
+
+// element returns the match of the element of the matched array given at the index given.
+func (m jsonPathMatch) element(arr []interface{}, i int, withPaths bool) jsonPathMatch {
+	elem := jsonPathMatch{val: arr[i]}
+	if withPaths {
+		elem.path = fmt.Sprintf("%s[%d]", m.path, i)
+	}
+	return elem
+}
+
+// lookup appends the values matched by the leg in the match given to the matches given.
+func (leg jsonPathLeg) lookup(m jsonPathMatch, matches []jsonPathMatch, withPaths bool) []jsonPathMatch {
+	switch leg.kind {
+	case jsonPathMember:
+		if obj, ok := m.val.(map[string]interface{}); ok {
+			if _, ok := obj[leg.key]; ok {
+				matches = append(matches, m.member(obj, leg.key, withPaths))
+			}
+		}
+	case jsonPathMemberWildcard:
+		if obj, ok := m.val.(map[string]interface{}); ok {
+			for _, key := range sortedJSONKeys(obj) {
+				matches = append(matches, m.member(obj, key, withPaths))
+			}
+		}
+	case jsonPathArrayIndex, jsonPathArrayRange:
+		// A value that isn't an array is treated like an array holding only that value, whose path is its own
+		arr, isArray := m.val.([]interface{})
+		if !isArray {
+			arr = []interface{}{m.val}
+		}
+		element := func(i int) jsonPathMatch {
+			if !isArray {
+				return m
+			}
+			return m.element(arr, i, withPaths)
+		}
+		from := leg.from.resolve(len(arr))
+		if leg.kind == jsonPathArrayIndex {
+			if from >= 0 && from < len(arr) {
+				matches = append(matches, element(from))
+			}
+			break
+		}
+		to := leg.to.resolve(len(arr))
+		if from < 0 {
+			from = 0
+		}
+		if to >= len(arr) {
+			to = len(arr) - 1
+		}
+		for i := from; i <= to; i++ {
+			matches = append(matches, element(i))
+		}
+	case jsonPathArrayWildcard:
+		if arr, ok := m.val.([]interface{}); ok {
+			for i := range arr {
+				matches = append(matches, m.element(arr, i, withPaths))
+			}
+		}
+	case jsonPathDoubleWildcard:
+		matches = append(matches, m)
+		switch val := m.val.(type) {
+		case map[string]interface{}:
+			for _, key := range sortedJSONKeys(val) {
+				matches = leg.lookup(m.member(val, key, withPaths), matches, withPaths)
+			}
+		case []interface{}:
+			for i := range val {
+				matches = leg.lookup(m.element(val, i, withPaths), matches, withPaths)
+			}
+		}
+	}
+	return matches
+}
+
+// walkJSON visits the value given and every value within it in document order, passing each value's path to the
+// visitor. Walking stops once the visitor returns false.
+func walkJSON(val interface{}, path string, visit func(path string, val interface{}) bool) bool {
+	if !visit(path, val) {
+		return false
+	}
+
+	switch v := val.(type) {
+	case []interface{}:
+		for i, el := range v {
+			if !walkJSON(el, fmt.Sprintf("%s[%d]", path, i), visit) {
+				return false
+			}
+		}
+	case map[string]interface{}:
+		for _, k := range sortedJSONKeys(v) {
+			if !walkJSON(v[k], path+"."+jsonPathKey(k), visit) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// jsonPathKey returns the given object key as it appears in a JSON path, quoting it if it isn't a valid identifier.
+func jsonPathKey(key string) string {
+	for i, r := range key {
+		if !(r == '_' || r == '$' || unicode.IsLetter(r) || (i > 0 && unicode.IsDigit(r))) {
+			quoted, err := json.Marshal(key)
+			if err != nil {
+				return strconv.Quote(key)
+			}
+			return string(quoted)
+		}
+	}
+	if key == "" {
+		return `""`
+	}
+	return key
+}
+
+// sortedJSONKeys returns the keys of the JSON object given in MySQL's key order: shorter keys first, then by their
+// bytes.
+func sortedJSONKeys(obj map[string]interface{}) []string {
+	keys := make([]string, 0, len(obj))
+	for key := range obj {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if len(keys[i]) != len(keys[j]) {
+			return len(keys[i]) < len(keys[j])
+		}
+		return keys[i] < keys[j]
+	})
+	return keys
+}
+
+type jsonPathParser struct {
+	path string
+	pos  int
+}
+
+// err returns the error for the path of the parser failing to parse at its current position.
+func (p *jsonPathParser) err() error {
+	pos := p.pos
+	if pos < 1 {
+		pos = 1
+	}
+	return ErrInvalidJSONPath.New(pos)
+}
+
+func (p *jsonPathParser) skipSpaces() {
+	for p.pos < len(p.path) && unicode.IsSpace(rune(p.path[p.pos])) {
+		p.pos++
+	}
+}
+
+// consume skips the text given if the path continues with it, and returns whether it did.
+func (p *jsonPathParser) consume(text string) bool {
+	if strings.HasPrefix(p.path[p.pos:], text) {
+		p.pos += len(text)
+		return true
+	}
+	return false
+}
+
+// parseMember parses the member leg following a period, which is either a wildcard, a quoted key or an identifier.
+func (p *jsonPathParser) parseMember() (jsonPathLeg, error) {
+	p.skipSpaces()
+	if p.consume("*") {
+		return jsonPathLeg{kind: jsonPathMemberWildcard}, nil
+	}
+
+	if p.pos < len(p.path) && p.path[p.pos] == '"' {
+		end := p.pos + 1
+		for end < len(p.path) && p.path[end] != '"' {
+			if p.path[end] == '\\' {
+				end++
+			}
+			end++
+		}
+		if end >= len(p.path) {
+			return jsonPathLeg{}, p.err()
+		}
+		var key string
+		if err := json.Unmarshal([]byte(p.path[p.pos:end+1]), &key); err != nil {
+			return jsonPathLeg{}, p.err()
+		}
+		p.pos = end + 1
+		return jsonPathLeg{kind: jsonPathMember, key: key}, nil
+	}
+
+	// Unquoted keys are read up to the next leg, so that they can hold any character but periods, brackets and
+	// asterisks, which can be escaped with a backslash.
+	var sb strings.Builder
+	for p.pos < len(p.path) && !strings.ContainsRune(".[*", rune(p.path[p.pos])) {
+		if p.path[p.pos] == '\\' && p.pos+1 < len(p.path) {
+			p.pos++
+		}
+		sb.WriteByte(p.path[p.pos])
+		p.pos++
+	}
+	key := strings.TrimRightFunc(sb.String(), unicode.IsSpace)
+	if key == "" {
+		return jsonPathLeg{}, p.err()
+	}
+	return jsonPathLeg{kind: jsonPathMember, key: key}, nil
+}
+
+// parseArrayLeg parses the array leg following an opening bracket, which is either a wildcard, an index or a range.
+func (p *jsonPathParser) parseArrayLeg() (jsonPathLeg, error) {
+	p.skipSpaces()
+	leg := jsonPathLeg{kind: jsonPathArrayWildcard}
+	if !p.consume("*") {
+		from, err := p.parseArrayIndex()
+		if err != nil {
+			return jsonPathLeg{}, err
+		}
+		leg = jsonPathLeg{kind: jsonPathArrayIndex, from: from}
+
+		p.skipSpaces()
+		if p.consume("to") {
+			to, err := p.parseArrayIndex()
+			if err != nil {
+				return jsonPathLeg{}, err
+			}
+			leg.kind, leg.to = jsonPathArrayRange, to
+		}
+	}
+
+	p.skipSpaces()
+	if !p.consume("]") {
+		return jsonPathLeg{}, p.err()
+	}
+	return leg, nil
+}
+
+// parseArrayIndex parses an array index, which is either a number, last, or last minus a number.
+func (p *jsonPathParser) parseArrayIndex() (jsonArrayIndex, error) {
+	p.skipSpaces()
+	if p.consume("last") {
+		p.skipSpaces()
+		if !p.consume("-") {
+			return jsonArrayIndex{fromLast: true}, nil
+		}
+		p.skipSpaces()
+		n, err := p.parseNumber()
+		return jsonArrayIndex{n: n, fromLast: true}, err
+	}
+	n, err := p.parseNumber()
+	return jsonArrayIndex{n: n}, err
+}
+
+func (p *jsonPathParser) parseNumber() (int, error) {
+	start := p.pos
+	for p.pos < len(p.path) && p.path[p.pos] >= '0' && p.path[p.pos] <= '9' {
+		p.pos++
+	}
+	n, err := strconv.Atoi(p.path[start:p.pos])
+	if err != nil {
+		return 0, p.err()
+	}
+	return n, nil
+}
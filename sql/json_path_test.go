@@ -0,0 +1,128 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONPathLookup(t *testing.T) {
+	doc := `{"a": [1, 2, 3, 4], "ab": 6, "b": {"c": "x", "d": [{"c": "y"}]}, "e f": 5}`
+	tests := []struct {
+		path     string
+		expected string
+		wildcard bool
+	}{
+		{`$`, `[` + doc + `]`, false},
+		{`$.a`, `[[1, 2, 3, 4]]`, false},
+		{`$.a[0]`, `[1]`, false},
+		{`$.a[last]`, `[4]`, false},
+		{`$.a[last - 1]`, `[3]`, false},
+		{`$.a[9]`, `null`, false},
+		{`$.a[1 to 2]`, `[2, 3]`, true},
+		{`$.a[2 to 9]`, `[3, 4]`, true},
+		{`$.a[*]`, `[1, 2, 3, 4]`, true},
+		{`$.b.c`, `["x"]`, false},
+		{`$.b.c[0]`, `["x"]`, false},
+		{`$.b.c[1]`, `null`, false},
+		{`$.b.*`, `["x", [{"c": "y"}]]`, true},
+		{`$**.c`, `["x", "y"]`, true},
+		{`$.b.d[*].c`, `["y"]`, true},
+		{`$."e f"`, `[5]`, false},
+		{` $ . b . c `, `["x"]`, false},
+		{`$.z`, `null`, false},
+		{`$.a.z`, `null`, false},
+		{`$.*`, `[[1, 2, 3, 4], {"c": "x", "d": [{"c": "y"}]}, 6, 5]`, true},
+	}
+
+	var val interface{}
+	require.NoError(t, json.Unmarshal([]byte(doc), &val))
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			path, err := ParseJSONPath(test.path)
+			require.NoError(t, err)
+
+			var expected []interface{}
+			require.NoError(t, json.Unmarshal([]byte(test.expected), &expected))
+			assert.Equal(t, expected, path.Lookup(val))
+			assert.Equal(t, test.wildcard, path.HasWildcard())
+		})
+	}
+}
+
+func TestJSONPathWalk(t *testing.T) {
+	doc := `{"a": [1, "x"], "b": {"c": "x"}, "e f": 5}`
+	tests := []struct {
+		path     string
+		expected []string
+	}{
+		{`$`, []string{`$`, `$.a`, `$.a[0]`, `$.a[1]`, `$.b`, `$.b.c`, `$."e f"`}},
+		{`$.a`, []string{`$.a`, `$.a[0]`, `$.a[1]`}},
+		{`$.a[last]`, []string{`$.a[1]`}},
+		{`$.*`, []string{`$.a`, `$.a[0]`, `$.a[1]`, `$.b`, `$.b.c`, `$."e f"`}},
+		{`$.b[0]`, []string{`$.b`, `$.b.c`}},
+		{`$**.c`, []string{`$.b.c`}},
+		{`$."e f"`, []string{`$."e f"`}},
+		{`$.z`, nil},
+	}
+
+	var val interface{}
+	require.NoError(t, json.Unmarshal([]byte(doc), &val))
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			path, err := ParseJSONPath(test.path)
+			require.NoError(t, err)
+
+			var paths []string
+			require.True(t, path.Walk(val, func(path string, _ interface{}) bool {
+				paths = append(paths, path)
+				return true
+			}))
+			assert.Equal(t, test.expected, paths)
+		})
+	}
+}
+
+func TestParseJSONPathErrors(t *testing.T) {
+	tests := []struct {
+		path string
+		pos  int
+	}{
+		{``, 1},
+		{`a`, 1},
+		{`$a`, 1},
+		{`$.`, 2},
+		{`$[`, 2},
+		{`$[a]`, 2},
+		{`$[1`, 3},
+		{`$[last -]`, 8},
+		{`$."a`, 2},
+		{`$*`, 1},
+		{`$**`, 3},
+	}
+
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			_, err := ParseJSONPath(test.path)
+			require.Error(t, err)
+			assert.True(t, ErrInvalidJSONPath.Is(err))
+			assert.Equal(t, ErrInvalidJSONPath.New(test.pos).Error(), err.Error())
+		})
+	}
+}
@@ -16,8 +16,10 @@ package sql
 
 import (
 	"fmt"
+	"math"
 	"testing"
 
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -33,12 +35,14 @@ func TestJsonCompare(t *testing.T) {
 		{`[0]`, `{"a": 0}`, 1},
 		{`{"a": 0}`, `"a"`, 1},
 		{`"a"`, `0`, 1},
-		{`0`, `null`, -1},
+		{`0`, `null`, 1},
 
 		// null
-		{`null`, `0`, 1},
-		{`0`, `null`, -1},
+		{`null`, `0`, -1},
+		{`0`, `null`, 1},
 		{`null`, `null`, 0},
+		{`null`, `""`, -1},
+		{`[null]`, `[0]`, -1},
 
 		// boolean
 		{`true`, `false`, 1},
@@ -93,6 +97,33 @@ func TestJsonCompare(t *testing.T) {
 	}
 }
 
+func TestJsonCompareNumbers(t *testing.T) {
+	tests := []struct {
+		left  interface{}
+		right interface{}
+		cmp   int
+	}{
+		{int8(1), float64(1), 0},
+		{int64(2), float64(1.5), 1},
+		{uint64(math.MaxUint64), int64(math.MaxInt64), 1},
+		{int32(-1), uint8(0), -1},
+		{float32(2.5), decimal.NewFromFloat(2.5), 0},
+		{map[string]interface{}{"a": int8(1)}, map[string]interface{}{"a": float64(2)}, -1},
+		{[]interface{}{int64(1), int64(2)}, []interface{}{float64(1), float64(2)}, 0},
+		{int8(1), "1", -1},
+		{nil, int8(1), -1},
+	}
+
+	for _, test := range tests {
+		name := fmt.Sprintf("%v_%v__%d", test.left, test.right, test.cmp)
+		t.Run(name, func(t *testing.T) {
+			cmp, err := JSON.Compare(JSONDocument{Val: test.left}, JSONDocument{Val: test.right})
+			require.NoError(t, err)
+			assert.Equal(t, test.cmp, cmp)
+		})
+	}
+}
+
 func TestJsonConvert(t *testing.T) {
 	tests := []struct {
 		val         interface{}
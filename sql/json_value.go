@@ -16,11 +16,12 @@ package sql
 
 import (
 	"encoding/json"
+	"math/big"
 	"reflect"
 	"sort"
 	"strings"
 
-	"github.com/oliveagle/jsonpath"
+	"github.com/shopspring/decimal"
 )
 
 // JSONValue is an integrator specific implementation of a JSON field value.
@@ -42,7 +43,7 @@ type SearchableJSONValue interface {
 
 	// Contains is value-specific implementation of JSON_Contains()
 	Contains(ctx *Context, candidate JSONValue) (val interface{}, err error)
-	// Extract is value-specific implementation of JSON_Extract()
+	// Extract is value-specific implementation of JSON_Extract(). Returns nil if no value matches the path.
 	Extract(ctx *Context, path string) (val JSONValue, err error)
 	// Keys is value-specific implementation of JSON_Keys()
 	Keys(ctx *Context, path string) (val JSONValue, err error)
@@ -88,15 +89,24 @@ func (doc JSONDocument) Contains(ctx *Context, candidate JSONValue) (val interfa
 }
 
 func (doc JSONDocument) Extract(ctx *Context, path string) (JSONValue, error) {
-	c, err := jsonpath.Compile(path)
+	p, err := ParseJSONPath(path)
 	if err != nil {
 		return nil, err
 	}
+	return doc.ExtractPath(p), nil
+}
 
-	// TODO(andy) handle error
-	val, _ := c.Lookup(doc.Val) // err ignored
-
-	return JSONDocument{Val: val}, nil
+// ExtractPath is like Extract, with the path already parsed.
+func (doc JSONDocument) ExtractPath(p JSONPath) JSONValue {
+	vals := p.Lookup(doc.Val)
+	if vals == nil {
+		return nil
+	}
+	// The values matched by a wildcard are always returned in an array
+	if p.HasWildcard() {
+		return JSONDocument{Val: vals}
+	}
+	return JSONDocument{Val: vals[0]}
 }
 
 func (doc JSONDocument) Keys(ctx *Context, path string) (val JSONValue, err error) {
@@ -262,7 +272,7 @@ func containsJSONNumber(a float64, b interface{}) (bool, error) {
 //
 // 		BLOB, BIT, OPAQUE, DATETIME, TIME, DATE, BOOLEAN, ARRAY, OBJECT, STRING, INTEGER, DOUBLE, NULL
 // 		TODO(andy): implement BLOB BIT OPAQUE DATETIME TIME DATE
-//      current precedence: BOOLEAN, ARRAY, OBJECT, STRING, DOUBLE, NULL
+//      current precedence: BOOLEAN, ARRAY, OBJECT, STRING, INTEGER/DOUBLE, NULL
 //
 // For JSON values of the same precedence, the comparison rules are type specific:
 //
@@ -301,14 +311,23 @@ func containsJSONNumber(a float64, b interface{}) (bool, error) {
 //             e.g.   9223372036854775805 < 9223372036854775806 < 9223372036854775807 < 9.223372036854776e18
 //                    = 9223372036854776000 < 9223372036854776001
 //   - NULL
-//       For comparison of any JSON value to SQL NULL, the result is UNKNOWN.
+//       The JSON null literal is less than any other JSON value. For comparison of any JSON value to SQL NULL, the
+//       result is UNKNOWN.
 //
-//   TODO(andy): BLOB, BIT, OPAQUE, DATETIME, TIME, DATE, INTEGER
+//   TODO(andy): BLOB, BIT, OPAQUE, DATETIME, TIME, DATE
 //
 // https://dev.mysql.com/doc/refman/8.0/en/json.html#json-comparison
 func compareJSON(a, b interface{}) (int, error) {
-	if hasNulls, res := compareNulls(a, b); hasNulls {
-		return res, nil
+	// The JSON null literal has the lowest precedence
+	if a == nil || b == nil {
+		switch {
+		case a == b:
+			return 0, nil
+		case a == nil:
+			return -1, nil
+		default:
+			return 1, nil
+		}
 	}
 
 	switch a := a.(type) {
@@ -320,9 +339,10 @@ func compareJSON(a, b interface{}) (int, error) {
 		return compareJSONObject(a, b)
 	case string:
 		return compareJSONString(a, b)
-	case float64:
-		return compareJSONNumber(a, b)
 	default:
+		if n, ok := jsonNumber(a); ok {
+			return compareJSONNumber(n, b)
+		}
 		return 0, ErrInvalidType.New(a)
 	}
 }
@@ -435,7 +455,7 @@ func compareJSONString(a string, b interface{}) (int, error) {
 	}
 }
 
-func compareJSONNumber(a float64, b interface{}) (int, error) {
+func compareJSONNumber(a decimal.Decimal, b interface{}) (int, error) {
 	switch b := b.(type) {
 	case
 		bool,
@@ -445,18 +465,47 @@ func compareJSONNumber(a float64, b interface{}) (int, error) {
 		// a is lower precedence
 		return -1, nil
 
-	case float64:
-		if a > b {
-			return 1, nil
-		}
-		if a < b {
-			return -1, nil
+	default:
+		// Integers and doubles have the same precedence, and are compared as exact values
+		if n, ok := jsonNumber(b); ok {
+			return a.Cmp(n), nil
 		}
-		return 0, nil
+		return 0, ErrInvalidType.New(b)
+	}
+}
 
+// jsonNumber returns the exact value of the JSON number given, which may be of any numeric type. Returns false if the
+// value isn't a number.
+func jsonNumber(v interface{}) (decimal.Decimal, bool) {
+	switch v := v.(type) {
+	case float64:
+		return decimal.NewFromFloat(v), true
+	case float32:
+		return decimal.NewFromFloat32(v), true
+	case int:
+		return decimal.NewFromInt(int64(v)), true
+	case int8:
+		return decimal.NewFromInt(int64(v)), true
+	case int16:
+		return decimal.NewFromInt(int64(v)), true
+	case int32:
+		return decimal.NewFromInt(int64(v)), true
+	case int64:
+		return decimal.NewFromInt(v), true
+	case uint:
+		return decimal.NewFromBigInt(new(big.Int).SetUint64(uint64(v)), 0), true
+	case uint8:
+		return decimal.NewFromInt(int64(v)), true
+	case uint16:
+		return decimal.NewFromInt(int64(v)), true
+	case uint32:
+		return decimal.NewFromInt(int64(v)), true
+	case uint64:
+		return decimal.NewFromBigInt(new(big.Int).SetUint64(v), 0), true
+	case decimal.Decimal:
+		return v, true
 	default:
-		// a is higher precedence
-		return 1, nil
+		return decimal.Decimal{}, false
 	}
 }
 
@@ -17,8 +17,11 @@ package sql
 import (
 	"fmt"
 	"math"
+	"regexp"
 	"strconv"
+	"strings"
 	"time"
+	"unicode"
 
 	"github.com/shopspring/decimal"
 	"gopkg.in/src-d/go-errors.v1"
@@ -445,6 +448,19 @@ func (t numberTypeImpl) IsSigned() bool {
 	return false
 }
 
+var numericPrefixRegex = regexp.MustCompile(`^\s*([-+]?(?:\d+(?:\.\d*)?|\.\d+)(?:[eE][-+]?\d+)?)`)
+
+// NumericPrefix returns the number the string given starts with, which is the value MySQL takes from a string that
+// isn't a number, or 0 if it doesn't start with one. Returns true if the whole string, but for surrounding spaces, is
+// that number.
+func NumericPrefix(s string) (string, bool) {
+	match := numericPrefixRegex.FindStringSubmatch(s)
+	if match == nil {
+		return "0", false
+	}
+	return match[1], len(match[0]) == len(strings.TrimRightFunc(s, unicode.IsSpace))
+}
+
 func convertToInt64(t numberTypeImpl, v interface{}) (int64, error) {
 	switch v := v.(type) {
 	case int:
@@ -658,6 +674,12 @@ func mustInt64(v interface{}) int64 {
 		return int64(tv)
 	case int64:
 		return int64(tv)
+	case bool:
+		// Boolean expressions produce Go bools, which MySQL represents as the integers 1 and 0
+		if tv {
+			return 1
+		}
+		return 0
 	default:
 		panic("unexpected type")
 	}
@@ -675,6 +697,11 @@ func mustUint64(v interface{}) uint64 {
 		return uint64(tv)
 	case uint64:
 		return uint64(tv)
+	case bool:
+		if tv {
+			return 1
+		}
+		return 0
 	default:
 		panic("unexpected type")
 	}
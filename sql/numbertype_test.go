@@ -255,3 +255,27 @@ func TestNumberString(t *testing.T) {
 		})
 	}
 }
+
+func TestNumberSQL(t *testing.T) {
+	tests := []struct {
+		typ         Type
+		val         interface{}
+		expectedStr string
+	}{
+		{Boolean, true, "1"},
+		{Boolean, false, "0"},
+		{Int8, int8(-5), "-5"},
+		{Int64, true, "1"},
+		{Uint8, true, "1"},
+		{Uint64, false, "0"},
+		{Uint32, uint32(7), "7"},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%v %v", test.typ, test.val), func(t *testing.T) {
+			val, err := test.typ.SQL(test.val)
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedStr, val.ToString())
+		})
+	}
+}
@@ -0,0 +1,327 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parse
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/dolthub/vitess/go/vt/sqlparser"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+var (
+	// createFunctionRegex matches the start of a CREATE FUNCTION statement, which the parser doesn't accept, up to the
+	// FUNCTION keyword.
+	createFunctionRegex = regexp.MustCompile(`(?is)^(create\s+(?:definer\s*=\s*\S+\s+)?)function\s`)
+	// dropFunctionRegex matches the start of a DROP FUNCTION statement, which the parser doesn't accept.
+	dropFunctionRegex = regexp.MustCompile(`(?is)^drop\s+function\s`)
+)
+
+// functionCharacteristicKeywords are the words that can start a characteristic of a stored function, which end its
+// return type.
+var functionCharacteristicKeywords = map[string]bool{
+	"comment": true, "language": true, "not": true, "deterministic": true, "contains": true, "no": true, "reads": true,
+	"modifies": true, "sql": true,
+}
+
+// functionToken is a word of a CREATE FUNCTION statement outside of quotes and parentheses.
+type functionToken struct {
+	word       string
+	start, end int
+}
+
+// functionTokens returns the words of the query given that aren't quoted or in parentheses, lowercased.
+func functionTokens(query string) []functionToken {
+	var tokens []functionToken
+	var quote byte
+	depth := 0
+	for i := 0; i < len(query); i++ {
+		c := query[i]
+		switch {
+		case quote != 0:
+			if c == '\\' && quote != '`' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"' || c == '`':
+			quote = c
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+		case depth == 0 && isIdentRune(c):
+			start := i
+			for i < len(query) && isIdentRune(query[i]) {
+				i++
+			}
+			tokens = append(tokens, functionToken{strings.ToLower(query[start:i]), start, i})
+			i--
+		}
+	}
+	return tokens
+}
+
+func isIdentRune(c byte) bool {
+	return c == '_' || c == '$' || (c >= '0' && c <= '9') || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// parseCreateFunction parses a CREATE FUNCTION statement, which the parser doesn't accept. The name, parameters and
+// characteristics are those of a CREATE PROCEDURE statement, so they're parsed as one. A body that is a single RETURN
+// statement is kept as a SELECT of its expression, and a BEGIN ... END body as a procedure with the same parameters,
+// whose RETURN statements are *plan.Return nodes.
+func parseCreateFunction(ctx *sql.Context, query string) (sql.Node, error) {
+	header := createFunctionRegex.FindStringSubmatchIndex(query)
+	signatureStart := header[3] + len("function")
+
+	// The name and the parameters are followed by RETURNS, the return type, the characteristics and the body
+	tokens := functionTokens(query[signatureStart:])
+	returns := -1
+	for i, token := range tokens {
+		if token.word == "returns" {
+			returns = i
+			break
+		}
+	}
+	if returns == -1 {
+		return nil, sql.ErrSyntaxError.New("expected RETURNS after the parameters of the function")
+	}
+	returnsStart, returnsEnd := signatureStart+tokens[returns].start, signatureStart+tokens[returns].end
+
+	typeEnd, bodyStart, isCompound := -1, -1, false
+	for _, token := range tokens[returns+1:] {
+		isBody := token.word == "return" || token.word == "begin"
+		if typeEnd == -1 && (isBody || functionCharacteristicKeywords[token.word]) {
+			typeEnd = signatureStart + token.start
+		}
+		if isBody {
+			bodyStart, isCompound = signatureStart+token.start, token.word == "begin"
+			break
+		}
+	}
+	if bodyStart == -1 {
+		return nil, sql.ErrSyntaxError.New("expected RETURN in the body of the function")
+	}
+
+	// Everything but the return type and the body makes a CREATE PROCEDURE statement
+	procedure := query[:header[3]] + "PROCEDURE" + query[signatureStart:returnsStart] + query[typeEnd:bodyStart]
+	bodyStr := strings.TrimSpace(query[bodyStart:])
+	if isCompound {
+		compound, err := rewriteReturnStatements(bodyStr)
+		if err != nil {
+			return nil, err
+		}
+		procedure += compound
+	} else {
+		procedure += " SELECT 1"
+	}
+	rewrite, err := rewriteQuery(procedure)
+	if err != nil {
+		return nil, err
+	}
+	stmt, err := rewrite.parse(sqlparser.Parse)
+	if err != nil {
+		return nil, sql.ErrSyntaxError.New(err.Error())
+	}
+	spec := stmt.(*sqlparser.DDL).ProcedureSpec
+
+	params, err := convertProcedureParams(spec.Params)
+	if err != nil {
+		return nil, err
+	}
+	paramNames := make(map[string]bool)
+	for _, param := range params {
+		if param.Direction != plan.ProcedureParamDirection_In {
+			return nil, sql.ErrSyntaxError.New("the parameters of a function can't be OUT or INOUT parameters")
+		}
+		if paramNames[strings.ToLower(param.Name)] {
+			return nil, sql.ErrStoredFunctionDuplicateParameterName.New(param.Name, spec.Name)
+		}
+		paramNames[strings.ToLower(param.Name)] = true
+	}
+
+	characteristics, securityType, comment, err := convertCharacteristics(spec.Characteristics)
+	if err != nil {
+		return nil, err
+	}
+
+	returnType, err := parseColumnType(strings.TrimSpace(query[returnsEnd:typeEnd]))
+	if err != nil {
+		return nil, err
+	}
+
+	var body sql.Node
+	if isCompound {
+		body, err = parseCompoundFunctionBody(ctx, spec.Name, procedure)
+	} else {
+		body, err = Parse(ctx, "SELECT "+strings.TrimSpace(bodyStr[len("return"):]))
+		if err == nil && !isReturnSelect(body) {
+			err = sql.ErrSyntaxError.New("the body of a function must be a RETURN statement of a single expression")
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return plan.NewCreateFunction(plan.NewStoredFunction(
+		spec.Name,
+		spec.Definer,
+		params,
+		returnType,
+		securityType,
+		comment,
+		characteristics,
+		query,
+		body,
+		bodyStr,
+		now,
+		now,
+	)), nil
+}
+
+// rewriteReturnStatements returns the BEGIN ... END body of a stored function given with its RETURN statements, which
+// the parser doesn't accept, as SELECT statements, which the body of a function can't otherwise have as it can't return
+// rows. Only SELECT ... INTO statements, which return no rows, are allowed.
+func rewriteReturnStatements(body string) (string, error) {
+	tokens, ok := scanTokens(body)
+	if !ok {
+		return "", sql.ErrSyntaxError.New(body)
+	}
+	q := tokenizedQuery{query: body, tokens: tokens}
+	var sb strings.Builder
+	last := 0
+	for i, token := range tokens {
+		switch {
+		case token.typ == sqlparser.SELECT && i > 0 && isStatementStart(tokens[i-1]) && q.selectInto(i) == -1:
+			return "", sql.ErrStoredFunctionResultSet.New()
+		case token.typ == sqlparser.ID && strings.EqualFold(token.val, "return") && body[token.start] != '`':
+			sb.WriteString(body[last:token.start])
+			sb.WriteString("SELECT")
+			last = token.end
+		}
+	}
+	sb.WriteString(body[last:])
+	return sb.String(), nil
+}
+
+// parseCompoundFunctionBody parses the CREATE PROCEDURE statement given, whose body is the BEGIN ... END body of a
+// stored function with its RETURN statements rewritten as SELECT statements, and returns its procedure with those
+// statements as *plan.Return nodes.
+func parseCompoundFunctionBody(ctx *sql.Context, name string, procedure string) (sql.Node, error) {
+	parsed, err := Parse(ctx, procedure)
+	if err != nil {
+		return nil, err
+	}
+	cp, ok := parsed.(*plan.CreateProcedure)
+	if !ok {
+		return nil, sql.ErrSyntaxError.New(procedure)
+	}
+
+	returns := 0
+	var withReturns func(n sql.Node) (sql.Node, error)
+	withReturns = func(n sql.Node) (sql.Node, error) {
+		switch n.(type) {
+		case *plan.Procedure, *plan.BeginEndBlock, *plan.Block, *plan.IfElseBlock, *plan.IfConditional:
+			children := n.Children()
+			newChildren := make([]sql.Node, len(children))
+			for i, child := range children {
+				newChild, err := withReturns(child)
+				if err != nil {
+					return nil, err
+				}
+				newChildren[i] = newChild
+			}
+			return n.WithChildren(newChildren...)
+		case *plan.Project:
+			if !isReturnSelect(n) {
+				return nil, sql.ErrSyntaxError.New("a RETURN statement must have a single expression")
+			}
+			returns++
+			return plan.NewReturn(n), nil
+		default:
+			return n, nil
+		}
+	}
+	body, err := withReturns(cp.Procedure)
+	if err != nil {
+		return nil, err
+	}
+	if returns == 0 {
+		return nil, sql.ErrStoredFunctionNoReturn.New(name)
+	}
+	return body, nil
+}
+
+// isStatementStart returns whether the token given can be followed by the start of a statement in a compound
+// statement.
+func isStatementStart(token queryToken) bool {
+	switch token.typ {
+	case ';', sqlparser.BEGIN, sqlparser.THEN, sqlparser.ELSE:
+		return true
+	}
+	return false
+}
+
+// isReturnSelect returns whether the node given is the SELECT of the expression of a RETURN statement, which has a
+// single expression and can't read a table outside of a subquery.
+func isReturnSelect(n sql.Node) bool {
+	p, ok := n.(*plan.Project)
+	if !ok || len(p.Projections) != 1 {
+		return false
+	}
+	t, ok := p.Child.(*plan.UnresolvedTable)
+	return ok && t.Name() == "dual"
+}
+
+// parseColumnType parses the SQL type given, such as the return type of a function.
+func parseColumnType(typ string) (sql.Type, error) {
+	stmt, err := sqlparser.Parse("CREATE TABLE t (c " + typ + ")")
+	if err != nil {
+		return nil, sql.ErrSyntaxError.New(err.Error())
+	}
+	ddl, ok := stmt.(*sqlparser.DDL)
+	if !ok || ddl.TableSpec == nil || len(ddl.TableSpec.Columns) != 1 {
+		return nil, sql.ErrSyntaxError.New(typ)
+	}
+	return sql.ColumnTypeToType(&ddl.TableSpec.Columns[0].Type)
+}
+
+// parseDropFunction parses a DROP FUNCTION [IF EXISTS] [db.]name statement, which the parser doesn't accept.
+func parseDropFunction(query string) (sql.Node, error) {
+	tokens, ok := scanTokens(query)
+	if !ok {
+		return nil, sql.ErrSyntaxError.New(query)
+	}
+	q := tokenizedQuery{query: query, tokens: tokens}
+
+	i, ifExists := 2, false
+	if q.isWord(i, "if") && q.isWord(i+1, "exists") {
+		i, ifExists = i+2, true
+	}
+	var dbName string
+	if q.isName(i) && q.typ(i+1) == '.' {
+		dbName = tokens[i].val
+		i += 2
+	}
+	if !q.isName(i) || i+1 != len(tokens) {
+		return nil, sql.ErrSyntaxError.New(query)
+	}
+	return plan.NewDropFunction(sql.UnresolvedDatabase(dbName), tokens[i].val, ifExists), nil
+}
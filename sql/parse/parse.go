@@ -15,12 +15,15 @@
 package parse
 
 import (
+	"encoding/hex"
 	"fmt"
+	"math"
 	"regexp"
 	"strconv"
 	"strings"
 	"time"
 	"unicode"
+	"unicode/utf8"
 
 	"github.com/dolthub/vitess/go/vt/sqlparser"
 	"github.com/opentracing/opentracing-go"
@@ -49,7 +52,9 @@ var (
 	// errInvalidDescribeFormat is returned when an invalid format string is used for DESCRIBE statements
 	errInvalidDescribeFormat = errors.NewKind("invalid format %q for DESCRIBE, supported formats: %s")
 
-	ErrInvalidIndexPrefix = errors.NewKind("invalid index prefix: %v")
+	ErrInvalidIndexPrefix = errors.NewKind("invalid index prefix length for column '%s': %v")
+
+	ErrIndexPrefixRequired = errors.NewKind("BLOB/TEXT column '%s' used in key specification without a key length")
 
 	ErrUnknownIndexColumn = errors.NewKind("unknown column: '%s' in %s index '%s'")
 
@@ -67,6 +72,8 @@ var (
 	showWarningsRegex    = regexp.MustCompile(`^show\s+warnings\s*`)
 	fullProcessListRegex = regexp.MustCompile(`^show\s+(full\s+)?processlist$`)
 	setRegex             = regexp.MustCompile(`^set\s+`)
+	alterDatabaseRegex   = regexp.MustCompile(`^alter\s+(database|schema)\b`)
+	describeColumnRegex  = regexp.MustCompile("(?is)^(?:describe|desc|explain)\\s+((?:`[^`]+`|\\w+)(?:\\.(?:`[^`]+`|\\w+))?)\\s+(`[^`]+`|'[^']*'|\"[^\"]*\"|\\w+)$")
 )
 
 var describeSupportedFormats = []string{"tree"}
@@ -129,9 +136,21 @@ func Parse(ctx *sql.Context, query string) (sql.Node, error) {
 		return plan.NewShowProcessList(), nil
 	case setRegex.MatchString(lowerQuery):
 		s = fixSetQuery(s)
+	case alterDatabaseRegex.MatchString(lowerQuery):
+		return parseAlterDatabase(ctx, s)
+	case createFunctionRegex.MatchString(s):
+		return parseCreateFunction(ctx, s)
+	case dropFunctionRegex.MatchString(s):
+		return parseDropFunction(s)
+	case isDescribeColumn(s):
+		return parseDescribeColumn(ctx, s)
 	}
 
-	stmt, err := sqlparser.Parse(s)
+	rewrite, err := rewriteQuery(s)
+	if err != nil {
+		return nil, err
+	}
+	stmt, err := rewrite.parse(sqlparser.Parse)
 	if err != nil {
 		if err.Error() == "empty statement" {
 			ctx.Warn(0, "query was empty after trimming comments, so it will be ignored")
@@ -140,7 +159,197 @@ func Parse(ctx *sql.Context, query string) (sql.Node, error) {
 		return nil, sql.ErrSyntaxError.New(err.Error())
 	}
 
-	return convert(ctx, stmt, s)
+	node, err := convert(withRewriteMarker(ctx, rewrite), stmt, s)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rewrite.tupleTargets) > 0 {
+		node, err = restoreTupleAssignments(ctx, node, rewrite.tupleTargets)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(rewrite.recursiveWiths) > 0 {
+		node, err = markRecursiveWiths(node, rewrite.recursiveWiths)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	switch n := node.(type) {
+	case *plan.InsertInto:
+		n.Priority = rewrite.priority
+	case *plan.Update:
+		n.Priority = rewrite.priority
+	case *plan.DeleteFrom:
+		n.Priority = rewrite.priority
+	case *plan.CreateView:
+		if len(rewrite.viewAttributes.Columns) > 0 {
+			n.Columns = rewrite.viewAttributes.Columns
+			n.Definition.Columns = rewrite.viewAttributes.Columns
+		}
+		n.Definition.TextDefinition = rewrite.viewAttributes.String() + n.Definition.TextDefinition
+		if rewrite.checkOption != sql.ViewCheckOption_None {
+			n.Definition.TextDefinition += fmt.Sprintf(" WITH %s CHECK OPTION", rewrite.checkOption)
+		}
+		if rewrite.isAlterView {
+			return plan.NewAlterView(n.Database(), n.Name, n.Definition), nil
+		}
+	}
+
+	return node, nil
+}
+
+// lockWaitModifiers are the modifiers setLockWait adds to the locking clauses of the parser for each wait policy.
+var lockWaitModifiers = map[sql.RowLockWait]string{
+	sql.RowLockNoWait:     " nowait",
+	sql.RowLockSkipLocked: " skip locked",
+}
+
+// setLockWait adds the modifier of the wait policy given to the locking clauses of the statement given, including
+// those of its subqueries, for convertLock to read.
+func setLockWait(stmt sqlparser.Statement, wait sql.RowLockWait) {
+	modifier := lockWaitModifiers[wait]
+	_ = sqlparser.Walk(func(node sqlparser.SQLNode) (bool, error) {
+		switch n := node.(type) {
+		case *sqlparser.Select:
+			if n.Lock != "" {
+				n.Lock += modifier
+			}
+		case *sqlparser.Union:
+			if n.Lock != "" {
+				n.Lock += modifier
+			}
+		case *sqlparser.Explain:
+			setLockWait(n.Statement, wait)
+		}
+		return true, nil
+	}, stmt)
+}
+
+// convertLock returns the row lock of a locking clause of the parser, with the modifier added by setLockWait, if any.
+func convertLock(lock string) sql.RowLock {
+	var rowLock sql.RowLock
+	if strings.HasPrefix(lock, sqlparser.ShareModeStr) {
+		rowLock.Strength = sql.RowLockForShare
+	}
+	for wait, modifier := range lockWaitModifiers {
+		if strings.HasSuffix(lock, modifier) {
+			rowLock.Wait = wait
+		}
+	}
+	return rowLock
+}
+
+// restoreTupleAssignments replaces the assignments to placeholder columns in the node given, made by
+// rewriteTupleAssignments, with assignments to the tuples of columns they stand for.
+func restoreTupleAssignments(ctx *sql.Context, node sql.Node, targets map[string]string) (sql.Node, error) {
+	return plan.TransformExpressionsUp(node, func(e sql.Expression) (sql.Expression, error) {
+		sf, ok := e.(*expression.SetField)
+		if !ok {
+			return e, nil
+		}
+		placeholder, ok := sf.Left.(*expression.UnresolvedColumn)
+		if !ok || placeholder.Table() != "" {
+			return e, nil
+		}
+		columns, ok := targets[placeholder.Name()]
+		if !ok {
+			return e, nil
+		}
+
+		stmt, err := sqlparser.Parse("SELECT " + columns)
+		if err != nil {
+			return nil, sql.ErrSyntaxError.New(err.Error())
+		}
+
+		selectExprs := stmt.(*sqlparser.Select).SelectExprs
+		tuple := make(expression.Tuple, len(selectExprs))
+		for i, selectExpr := range selectExprs {
+			ae, ok := selectExpr.(*sqlparser.AliasedExpr)
+			if ok && ae.As.IsEmpty() {
+				tuple[i], err = ExprToExpression(ctx, ae.Expr)
+				if err != nil {
+					return nil, err
+				}
+			}
+			if _, ok := tuple[i].(*expression.UnresolvedColumn); !ok {
+				return nil, sql.ErrSyntaxError.New(fmt.Sprintf("expected a column to assign to but got %s", sqlparser.String(selectExpr)))
+			}
+		}
+		return expression.NewSetField(tuple, sf.Right), nil
+	})
+}
+
+// withKey returns the key of a WITH clause defining the common table expressions with the names given.
+func withKey(names []string) string {
+	return strings.ToLower(strings.Join(names, ","))
+}
+
+// markRecursiveWiths marks the With nodes in the node given, including those in subqueries, whose keys are among those
+// given, as recursive.
+func markRecursiveWiths(node sql.Node, recursiveWiths map[string]bool) (sql.Node, error) {
+	switch n := node.(type) {
+	case *plan.DescribeQuery:
+		query, err := markRecursiveWiths(n.Query(), recursiveWiths)
+		if err != nil {
+			return nil, err
+		}
+		return n.WithQuery(query), nil
+	case *plan.InsertInto:
+		source, err := markRecursiveWiths(n.Source, recursiveWiths)
+		if err != nil {
+			return nil, err
+		}
+		node = n.WithSource(source)
+	case *plan.With:
+		names := make([]string, len(n.CTEs))
+		ctes := make([]*plan.CommonTableExpression, len(n.CTEs))
+		for i, cte := range n.CTEs {
+			names[i] = cte.Subquery.Name()
+			subquery, err := markRecursiveWiths(cte.Subquery, recursiveWiths)
+			if err != nil {
+				return nil, err
+			}
+			ctes[i] = plan.NewCommonTableExpression(subquery.(*plan.SubqueryAlias), cte.Columns)
+		}
+		if recursiveWiths[withKey(names)] {
+			node = plan.NewRecursiveWith(n.Child, ctes)
+		} else {
+			node = plan.NewWith(n.Child, ctes)
+		}
+	}
+
+	// Opaque nodes like SubqueryAlias aren't traversed by plan.TransformUp, so children are traversed here
+	if children := node.Children(); len(children) > 0 {
+		newChildren := make([]sql.Node, len(children))
+		for i, child := range children {
+			var err error
+			newChildren[i], err = markRecursiveWiths(child, recursiveWiths)
+			if err != nil {
+				return nil, err
+			}
+		}
+		var err error
+		node, err = node.WithChildren(newChildren...)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return plan.TransformExpressions(node, func(e sql.Expression) (sql.Expression, error) {
+		subquery, ok := e.(*plan.Subquery)
+		if !ok {
+			return e, nil
+		}
+		query, err := markRecursiveWiths(subquery.Query, recursiveWiths)
+		if err != nil {
+			return nil, err
+		}
+		return subquery.WithQuery(query), nil
+	})
 }
 
 // ParseColumnTypeString will return a SQL type for the given string that represents a column type.
@@ -182,19 +391,19 @@ func convert(ctx *sql.Context, stmt sqlparser.Statement, query string) (sql.Node
 	case *sqlparser.DDL:
 		// unlike other statements, DDL statements have loose parsing by default
 		// TODO: fix this
-		ddl, err := sqlparser.ParseStrictDDL(query)
+		ctx, ddl, err := parseStrictDDL(ctx, query)
 		if err != nil {
 			return nil, err
 		}
 		return convertDDL(ctx, query, ddl.(*sqlparser.DDL))
 	case *sqlparser.MultiAlterDDL:
-		multiAlterDdl, err := sqlparser.ParseStrictDDL(query)
+		ctx, multiAlterDdl, err := parseStrictDDL(ctx, query)
 		if err != nil {
 			return nil, err
 		}
 		return convertMultiAlterDDL(ctx, query, multiAlterDdl.(*sqlparser.MultiAlterDDL))
 	case *sqlparser.DBDDL:
-		return convertDBDDL(n)
+		return convertDBDDL(n, query)
 	case *sqlparser.Explain:
 		return convertExplain(ctx, n)
 	case *sqlparser.Insert:
@@ -426,7 +635,17 @@ func convertShow(ctx *sql.Context, s *sqlparser.Show, query string) (sql.Node, e
 			s.Table.Name.String(),
 		), nil
 	case "grants":
-		return plan.NewShowGrants(), nil
+		// The parser skips the FOR clause naming the account, so it's read from the tokens of the query
+		tokens, _ := scanTokens(query)
+		q := tokenizedQuery{query: query, tokens: tokens}
+		if !q.isWord(2, "for") {
+			return plan.NewShowGrants("", ""), nil
+		}
+		user, host, end := q.account(3)
+		if end != len(tokens) {
+			return nil, sql.ErrSyntaxError.New(fmt.Sprintf("invalid account name: %s", query[tokens[3].start:]))
+		}
+		return plan.NewShowGrants(user, host), nil
 	case "triggers":
 		var dbName string
 		var filter sql.Expression
@@ -563,8 +782,9 @@ func convertShow(ctx *sql.Context, s *sqlparser.Show, query string) (sql.Node, e
 		// show collation statements are functionally identical to selecting from the collations table in
 		// information_schema, with slightly different syntax and with some columns aliased.
 		// TODO: install information_schema automatically for all catalogs
-		infoSchemaSelect, err := Parse(ctx, "select collation_name as `collation`, character_set_name as charset, id,"+
-			"is_default as `default`, is_compiled as compiled, sortlen, pad_attribute from information_schema.collations")
+		infoSchemaSelect, err := Parse(ctx, "select collation_name as `Collation`, character_set_name as `Charset`, id as `Id`,"+
+			"is_default as `Default`, is_compiled as `Compiled`, sortlen as `Sortlen`, pad_attribute as `Pad_attribute` "+
+			"from information_schema.collations")
 		if err != nil {
 			return nil, err
 		}
@@ -592,11 +812,18 @@ func convertShow(ctx *sql.Context, s *sqlparser.Show, query string) (sql.Node, e
 
 		return infoSchemaSelect, nil
 	case sqlparser.KeywordString(sqlparser.CHARSET):
+		// show charset statements are functionally identical to selecting from the character_sets table in
+		// information_schema, with the columns reordered and aliased.
+		infoSchemaSelect, err := Parse(ctx, "select character_set_name as `Charset`, description as `Description`, "+
+			"default_collate_name as `Default collation`, maxlen as `Maxlen` from information_schema.character_sets")
+		if err != nil {
+			return nil, err
+		}
+
 		var filter sql.Expression
 
 		if s.Filter != nil {
 			if s.Filter.Filter != nil {
-				var err error
 				filter, err = ExprToExpression(ctx, s.Filter.Filter)
 				if err != nil {
 					return nil, err
@@ -610,13 +837,13 @@ func convertShow(ctx *sql.Context, s *sqlparser.Show, query string) (sql.Node, e
 			}
 		}
 
-		var node sql.Node = plan.NewShowCharset()
 		if filter != nil {
-			node = plan.NewFilter(filter, node)
+			return plan.NewFilter(filter, infoSchemaSelect), nil
 		}
-		return node, nil
+		return infoSchemaSelect, nil
 	case sqlparser.KeywordString(sqlparser.ENGINES):
-		infoSchemaSelect, err := Parse(ctx, "select * from information_schema.engines")
+		infoSchemaSelect, err := Parse(ctx, "select engine as `Engine`, support as `Support`, comment as `Comment`, "+
+			"transactions as `Transactions`, xa as `XA`, savepoints as `Savepoints` from information_schema.engines")
 		if err != nil {
 			return nil, err
 		}
@@ -644,18 +871,29 @@ func convertUnion(ctx *sql.Context, u *sqlparser.Union) (sql.Node, error) {
 		return nil, err
 	}
 
+	var node sql.Node
 	if u.Type == sqlparser.UnionAllStr {
-		return plan.NewUnion(left, right), nil
+		node = plan.NewUnion(left, right)
 	} else { // default is DISTINCT (either explicit or implicit)
 		// TODO: this creates redundant Distinct nodes that we can't easily remove after the fact. With this construct,
 		//  we can't in all cases tell the difference between `union distinct (select ...)` and
 		//  `union (select distinct ...)`. We need something like a Distinct property on Union nodes to be able to prune
 		//  redundant Distinct nodes and thereby avoid doing extra work.
-		return plan.NewDistinct(plan.NewUnion(left, right)), nil
+		node = plan.NewDistinct(plan.NewUnion(left, right))
+	}
+
+	if u.Lock != "" {
+		node = plan.NewLockingRead(convertLock(u.Lock), node)
 	}
+	return node, nil
 }
 
 func convertSelect(ctx *sql.Context, s *sqlparser.Select) (sql.Node, error) {
+	s, intoVars, err := selectIntoVariables(ctx, s)
+	if err != nil {
+		return nil, err
+	}
+
 	node, err := tableExprsToTable(ctx, s.From)
 	if err != nil {
 		return nil, err
@@ -718,6 +956,10 @@ func convertSelect(ctx *sql.Context, s *sqlparser.Select) (sql.Node, error) {
 		node = plan.NewLimit(expression.NewLiteral(limit, sql.Int64), node)
 	}
 
+	if s.Lock != "" {
+		node = plan.NewLockingRead(convertLock(s.Lock), node)
+	}
+
 	// Finally, if common table expressions were provided, wrap the top-level node in a With node to capture them
 	if len(s.CommonTableExprs) > 0 {
 		node, err = ctesToWith(ctx, s.CommonTableExprs, node)
@@ -726,9 +968,50 @@ func convertSelect(ctx *sql.Context, s *sqlparser.Select) (sql.Node, error) {
 		}
 	}
 
+	if intoVars != nil {
+		node = plan.NewInto(node, intoVars)
+	}
+
 	return node, nil
 }
 
+// selectIntoVariables returns the SELECT given without the call rewriteSelectInto replaced its INTO clause with, along
+// with the variables of the clause. The variables are nil if the SELECT has no INTO clause.
+func selectIntoVariables(ctx *sql.Context, s *sqlparser.Select) (*sqlparser.Select, []sql.Expression, error) {
+	if len(s.SelectExprs) < 2 {
+		return s, nil, nil
+	}
+	last, ok := s.SelectExprs[len(s.SelectExprs)-1].(*sqlparser.AliasedExpr)
+	if !ok {
+		return s, nil, nil
+	}
+	call, ok := last.Expr.(*sqlparser.FuncExpr)
+	if !ok || !call.Qualifier.IsEmpty() || !isRewrittenName(ctx, call.Name.String(), selectIntoFunction) {
+		return s, nil, nil
+	}
+
+	vars := make([]sql.Expression, len(call.Exprs))
+	for i, e := range call.Exprs {
+		expr, err := selectExprToExpression(ctx, e)
+		if err != nil {
+			return nil, nil, err
+		}
+		col, ok := expr.(*expression.UnresolvedColumn)
+		if !ok || col.Table() != "" || strings.HasPrefix(col.Name(), "@@") {
+			return nil, nil, ErrUnsupportedSyntax.New(sqlparser.String(e))
+		}
+		if strings.HasPrefix(col.Name(), "@") {
+			vars[i] = expression.NewUserVar(strings.TrimPrefix(col.Name(), "@"))
+		} else {
+			vars[i] = col
+		}
+	}
+
+	withoutInto := *s
+	withoutInto.SelectExprs = s.SelectExprs[:len(s.SelectExprs)-1]
+	return &withoutInto, vars, nil
+}
+
 func ctesToWith(ctx *sql.Context, cteExprs sqlparser.TableExprs, node sql.Node) (sql.Node, error) {
 	ctes := make([]*plan.CommonTableExpression, len(cteExprs))
 	for i, cteExpr := range cteExprs {
@@ -785,7 +1068,7 @@ func convertDDL(ctx *sql.Context, query string, c *sqlparser.DDL) (sql.Node, err
 			return plan.NewDropProcedure(sql.UnresolvedDatabase(""), c.ProcedureSpec.Name, c.IfExists), nil
 		}
 		if len(c.FromViews) != 0 {
-			return convertDropView(ctx, c)
+			return convertDropView(ctx, query, c)
 		}
 		return convertDropTable(ctx, c)
 	case sqlparser.AlterStr:
@@ -815,10 +1098,15 @@ func convertMultiAlterDDL(ctx *sql.Context, query string, c *sqlparser.MultiAlte
 	return plan.NewBlock(statements), nil
 }
 
-func convertDBDDL(c *sqlparser.DBDDL) (sql.Node, error) {
+func convertDBDDL(c *sqlparser.DBDDL, query string) (sql.Node, error) {
 	switch strings.ToLower(c.Action) {
 	case sqlparser.CreateStr:
-		return plan.NewCreateDatabase(c.DBName, c.IfNotExists), nil
+		// The parser skips the options of CREATE DATABASE, so we read the character set and collation from the query
+		collation, err := parseCharsetCollationOptions(query)
+		if err != nil {
+			return nil, err
+		}
+		return plan.NewCreateDatabaseWithCollation(c.DBName, c.IfNotExists, collation), nil
 	case sqlparser.DropStr:
 		return plan.NewDropDatabase(c.DBName, c.IfExists), nil
 	default:
@@ -826,6 +1114,83 @@ func convertDBDDL(c *sqlparser.DBDDL) (sql.Node, error) {
 	}
 }
 
+// describeStatementKeywords are the keywords that start the statements DESCRIBE can explain, which can't be the name
+// of a table described by DESCRIBE tbl_name col_name.
+var describeStatementKeywords = map[string]bool{
+	"select":  true,
+	"insert":  true,
+	"update":  true,
+	"delete":  true,
+	"replace": true,
+	"with":    true,
+	"table":   true,
+	"format":  true,
+}
+
+// isDescribeColumn returns whether the query given is a DESCRIBE tbl_name col_name statement, which the parser
+// doesn't support.
+func isDescribeColumn(query string) bool {
+	match := describeColumnRegex.FindStringSubmatch(query)
+	return match != nil && !describeStatementKeywords[strings.ToLower(match[1])]
+}
+
+// parseDescribeColumn parses a DESCRIBE tbl_name col_name statement, which describes the columns of the table whose
+// names match the column name given. Like in MySQL, the name may contain the % and _ wildcards of LIKE.
+func parseDescribeColumn(ctx *sql.Context, query string) (sql.Node, error) {
+	match := describeColumnRegex.FindStringSubmatch(query)
+
+	node, err := Parse(ctx, "SHOW COLUMNS FROM "+match[1])
+	if err != nil {
+		return nil, err
+	}
+
+	pattern := match[2]
+	switch pattern[0] {
+	case '`', '\'', '"':
+		pattern = pattern[1 : len(pattern)-1]
+	}
+
+	return plan.NewFilter(
+		expression.NewLike(
+			expression.NewUnresolvedColumn("Field"),
+			expression.NewLiteral(pattern, sql.LongText),
+			nil,
+		),
+		node,
+	), nil
+}
+
+// parseAlterDatabase parses an ALTER DATABASE statement, which the parser doesn't support. Only the character set and
+// collation options are handled.
+func parseAlterDatabase(ctx *sql.Context, query string) (sql.Node, error) {
+	tokens, ok := scanTokens(query)
+	q := tokenizedQuery{query: query, tokens: tokens}
+	if !ok || len(tokens) < 3 {
+		return nil, sql.ErrSyntaxError.New(query)
+	}
+
+	// The database name is optional, and defaults to the current database
+	var dbName string
+	options := 2
+	if q.isName(2) && !q.isWord(2, "default", "character", "charset", "collate", "encryption", "read") {
+		dbName = tokens[2].val
+		options = 3
+	}
+	if options >= len(tokens) {
+		return nil, sql.ErrSyntaxError.New(query)
+	}
+
+	collation, err := parseCharsetCollationOptions(query[tokens[options].start:])
+	if err != nil {
+		return nil, err
+	}
+	if collation.Name == "" {
+		return nil, ErrUnsupportedSyntax.New(query)
+	}
+
+	return plan.NewAlterDatabase(dbName, collation), nil
+}
+
 func convertCreateTrigger(ctx *sql.Context, query string, c *sqlparser.DDL) (sql.Node, error) {
 	var triggerOrder *plan.TriggerOrder
 	if c.TriggerSpec.Order != nil {
@@ -847,12 +1212,60 @@ func convertCreateTrigger(ctx *sql.Context, query string, c *sqlparser.DDL) (sql
 		return nil, err
 	}
 
-	return plan.NewCreateTrigger(c.TriggerSpec.Name, c.TriggerSpec.Time, c.TriggerSpec.Event, triggerOrder, tableNameToUnresolvedTable(c.Table), body, query, bodyStr), nil
+	return plan.NewCreateTrigger(c.TriggerSpec.Name, triggerDefiner(query), c.TriggerSpec.Time, c.TriggerSpec.Event, triggerOrder, tableNameToUnresolvedTable(c.Table), body, query, bodyStr), nil
+}
+
+// triggerDefiner returns the user named by the DEFINER clause of the CREATE TRIGGER statement given, which the parser
+// doesn't keep, or an empty string if it doesn't have one.
+func triggerDefiner(query string) string {
+	tokens, ok := scanTokens(query)
+	if !ok {
+		return ""
+	}
+	q := tokenizedQuery{query: query, tokens: tokens}
+	if !q.isWord(0, "create") || !q.isWord(1, "definer") || q.typ(2) != '=' || len(tokens) < 4 {
+		return ""
+	}
+	end := q.accountEnd(3)
+	return strings.Trim(query[tokens[3].start:tokens[end-1].end], "`")
 }
 
 func convertCreateProcedure(ctx *sql.Context, query string, c *sqlparser.DDL) (sql.Node, error) {
+	params, err := convertProcedureParams(c.ProcedureSpec.Params)
+	if err != nil {
+		return nil, err
+	}
+
+	characteristics, securityType, comment, err := convertCharacteristics(c.ProcedureSpec.Characteristics)
+	if err != nil {
+		return nil, err
+	}
+
+	bodyStr := strings.TrimSpace(query[c.SubStatementPositionStart:c.SubStatementPositionEnd])
+	body, err := convert(ctx, c.ProcedureSpec.Body, bodyStr)
+	if err != nil {
+		return nil, err
+	}
+
+	return plan.NewCreateProcedure(
+		c.ProcedureSpec.Name,
+		c.ProcedureSpec.Definer,
+		params,
+		time.Now(),
+		time.Now(),
+		securityType,
+		characteristics,
+		body,
+		comment,
+		query,
+		bodyStr,
+	), nil
+}
+
+// convertProcedureParams converts the parameters of a stored procedure or stored function.
+func convertProcedureParams(spParams []sqlparser.ProcedureParam) ([]plan.ProcedureParam, error) {
 	var params []plan.ProcedureParam
-	for _, param := range c.ProcedureSpec.Params {
+	for _, param := range spParams {
 		var direction plan.ProcedureParamDirection
 		switch param.Direction {
 		case sqlparser.ProcedureParamDirection_In:
@@ -874,11 +1287,16 @@ func convertCreateProcedure(ctx *sql.Context, query string, c *sqlparser.DDL) (s
 			Type:      internalTyp,
 		})
 	}
+	return params, nil
+}
 
+// convertCharacteristics converts the characteristics of a stored procedure or stored function, returning the
+// security context and the comment they give apart from the others.
+func convertCharacteristics(spCharacteristics []sqlparser.Characteristic) ([]plan.Characteristic, plan.ProcedureSecurityContext, string, error) {
 	var characteristics []plan.Characteristic
 	securityType := plan.ProcedureSecurityContext_Definer // Default Security Context
 	comment := ""
-	for _, characteristic := range c.ProcedureSpec.Characteristics {
+	for _, characteristic := range spCharacteristics {
 		switch characteristic.Type {
 		case sqlparser.CharacteristicValue_Comment:
 			comment = characteristic.Comment
@@ -901,29 +1319,10 @@ func convertCreateProcedure(ctx *sql.Context, query string, c *sqlparser.DDL) (s
 		case sqlparser.CharacteristicValue_SqlSecurityInvoker:
 			securityType = plan.ProcedureSecurityContext_Invoker
 		default:
-			return nil, fmt.Errorf("unknown procedure characteristic: `%s`", string(characteristic.Type))
+			return nil, 0, "", fmt.Errorf("unknown procedure characteristic: `%s`", string(characteristic.Type))
 		}
 	}
-
-	bodyStr := strings.TrimSpace(query[c.SubStatementPositionStart:c.SubStatementPositionEnd])
-	body, err := convert(ctx, c.ProcedureSpec.Body, bodyStr)
-	if err != nil {
-		return nil, err
-	}
-
-	return plan.NewCreateProcedure(
-		c.ProcedureSpec.Name,
-		c.ProcedureSpec.Definer,
-		params,
-		time.Now(),
-		time.Now(),
-		securityType,
-		characteristics,
-		body,
-		comment,
-		query,
-		bodyStr,
-	), nil
+	return characteristics, securityType, comment, nil
 }
 
 func convertCall(ctx *sql.Context, c *sqlparser.Call) (sql.Node, error) {
@@ -941,10 +1340,25 @@ func convertCall(ctx *sql.Context, c *sqlparser.Call) (sql.Node, error) {
 func convertDeclare(ctx *sql.Context, d *sqlparser.Declare) (sql.Node, error) {
 	if d.Condition != nil {
 		return convertDeclareCondition(ctx, d)
+	} else if d.Variables != nil {
+		return convertDeclareVariables(ctx, d)
 	}
 	return nil, ErrUnsupportedSyntax.New(sqlparser.String(d))
 }
 
+func convertDeclareVariables(ctx *sql.Context, d *sqlparser.Declare) (sql.Node, error) {
+	dv := d.Variables
+	names := make([]string, len(dv.Names))
+	for i, name := range dv.Names {
+		names[i] = name.String()
+	}
+	typ, err := sql.ColumnTypeToType(&dv.VarType)
+	if err != nil {
+		return nil, err
+	}
+	return plan.NewDeclareVariables(names, typ), nil
+}
+
 func convertDeclareCondition(ctx *sql.Context, d *sqlparser.Declare) (sql.Node, error) {
 	dc := d.Condition
 	if dc.SqlStateValue != "" {
@@ -1083,7 +1497,26 @@ func convertRenameTable(ctx *sql.Context, ddl *sqlparser.DDL) (sql.Node, error)
 		toTables = append(toTables, table.Name.String())
 	}
 
-	return plan.NewRenameTable(sql.UnresolvedDatabase(""), fromTables, toTables), nil
+	// Tables can only be renamed within a single database. Unqualified tables are in the current database.
+	var dbName string
+	var unqualified bool
+	for _, tables := range []sqlparser.TableNames{ddl.FromTables, ddl.ToTables} {
+		for _, table := range tables {
+			qualifier := table.Qualifier.String()
+			if qualifier == "" {
+				unqualified = true
+			} else if dbName == "" {
+				dbName = qualifier
+			} else if !strings.EqualFold(dbName, qualifier) {
+				return nil, ErrUnsupportedFeature.New("renaming tables across databases")
+			}
+		}
+	}
+	if dbName != "" && unqualified && !strings.EqualFold(dbName, ctx.GetCurrentDatabase()) {
+		return nil, ErrUnsupportedFeature.New("renaming tables across databases")
+	}
+
+	return plan.NewRenameTable(sql.UnresolvedDatabase(dbName), fromTables, toTables), nil
 }
 
 func convertAlterTable(ctx *sql.Context, ddl *sqlparser.DDL) (sql.Node, error) {
@@ -1191,7 +1624,7 @@ func convertAlterIndex(ctx *sql.Context, ddl *sqlparser.DDL) (sql.Node, error) {
 						return nil, err
 					}
 					if length < 1 {
-						return nil, ErrInvalidIndexPrefix.New(length)
+						return nil, ErrInvalidIndexPrefix.New(col.Column.String(), length)
 					}
 				}
 			}
@@ -1201,11 +1634,9 @@ func convertAlterIndex(ctx *sql.Context, ddl *sqlparser.DDL) (sql.Node, error) {
 			}
 		}
 
-		var comment string
-		for _, option := range ddl.IndexSpec.Options {
-			if strings.ToLower(option.Name) == strings.ToLower(sqlparser.KeywordString(sqlparser.COMMENT_KEYWORD)) {
-				comment = string(option.Value.Val)
-			}
+		comment, err := indexComment(ddl.IndexSpec.ToName.String(), ddl.IndexSpec.Options)
+		if err != nil {
+			return nil, err
 		}
 
 		if constraint == sql.IndexConstraint_Primary {
@@ -1379,7 +1810,7 @@ func convertCreateTable(ctx *sql.Context, c *sqlparser.DDL) (sql.Node, error) {
 						return nil, err
 					}
 					if length < 1 {
-						return nil, ErrInvalidIndexPrefix.New(length)
+						return nil, ErrInvalidIndexPrefix.New(col.Column.String(), length)
 					}
 				}
 			}
@@ -1389,11 +1820,9 @@ func convertCreateTable(ctx *sql.Context, c *sqlparser.DDL) (sql.Node, error) {
 			}
 		}
 
-		var comment string
-		for _, option := range idxDef.Options {
-			if strings.ToLower(option.Name) == strings.ToLower(sqlparser.KeywordString(sqlparser.COMMENT_KEYWORD)) {
-				comment = string(option.Value.Val)
-			}
+		comment, err := indexComment(idxDef.Info.Name.String(), idxDef.Options)
+		if err != nil {
+			return nil, err
 		}
 		idxDefs = append(idxDefs, &plan.IndexDefinition{
 			IndexName:  idxDef.Info.Name.String(),
@@ -1426,11 +1855,22 @@ func convertCreateTable(ctx *sql.Context, c *sqlparser.DDL) (sql.Node, error) {
 		return nil, err
 	}
 
+	err = validateForeignKeys(c.Table.Name.String(), schema.Schema, fkDefs)
+	if err != nil {
+		return nil, err
+	}
+
+	explicitCollationCols, err := applyTableCollation(c.TableSpec, schema)
+	if err != nil {
+		return nil, err
+	}
+
 	tableSpec := &plan.TableSpec{
-		Schema:  schema,
-		IdxDefs: idxDefs,
-		FkDefs:  fkDefs,
-		ChDefs:  chDefs,
+		Schema:                schema,
+		IdxDefs:               idxDefs,
+		FkDefs:                fkDefs,
+		ChDefs:                chDefs,
+		ExplicitCollationCols: explicitCollationCols,
 	}
 
 	if c.OptSelect != nil {
@@ -1446,6 +1886,104 @@ func convertCreateTable(ctx *sql.Context, c *sqlparser.DDL) (sql.Node, error) {
 		sql.UnresolvedDatabase(qualifier), c.Table.Name.String(), plan.IfNotExistsOption(c.IfNotExists), plan.TempTableOption(c.Temporary), tableSpec), nil
 }
 
+// indexComment returns the comment given in the options of the index named, if any. Returns an error if the comment is
+// longer than MySQL allows.
+func indexComment(name string, options []*sqlparser.IndexOption) (string, error) {
+	var comment string
+	for _, option := range options {
+		if strings.ToLower(option.Name) == strings.ToLower(sqlparser.KeywordString(sqlparser.COMMENT_KEYWORD)) {
+			comment = string(option.Value.Val)
+		}
+	}
+	if utf8.RuneCountInString(comment) > sql.MaxIndexCommentLength {
+		return "", sql.ErrTooLongIndexComment.New(name, sql.MaxIndexCommentLength)
+	}
+	return comment, nil
+}
+
+// applyTableCollation gives the string columns of the schema given that don't declare a character set or collation
+// the default collation from the table options, if any. Returns the names of the string columns whose collation was
+// set by either the column definition or the table options. The remaining string columns inherit the default
+// collation of the database.
+func applyTableCollation(tableSpec *sqlparser.TableSpec, schema sql.PrimaryKeySchema) ([]string, error) {
+	collation, err := parseCharsetCollationOptions(tableSpec.Options)
+	if err != nil {
+		return nil, err
+	}
+
+	var explicitCollationCols []string
+	for i, cd := range tableSpec.Columns {
+		col := schema.Schema[i]
+		st, ok := col.Type.(sql.StringType)
+		if !ok {
+			continue
+		}
+
+		if cd.Type.Charset != "" || cd.Type.Collate != "" {
+			explicitCollationCols = append(explicitCollationCols, col.Name)
+			continue
+		}
+
+		if collation.Name == "" || st.Collation().Equals(sql.Collation_binary) {
+			continue
+		}
+
+		col.Type, err = sql.CreateString(st.Type(), st.MaxCharacterLength(), collation)
+		if err != nil {
+			return nil, err
+		}
+		explicitCollationCols = append(explicitCollationCols, col.Name)
+	}
+
+	return explicitCollationCols, nil
+}
+
+// parseCharsetCollationOptions returns the collation named by the CHARACTER SET and COLLATE options in the text
+// given, which holds the options of a CREATE TABLE or CREATE / ALTER DATABASE statement. Comments, strings and
+// parenthesized text, such as the definitions of columns, are skipped. Returns the zero collation if neither option is
+// present.
+func parseCharsetCollationOptions(options string) (sql.Collation, error) {
+	// The parser joins the table options without escaping the quotes of their strings, so only the tokens before such a
+	// quote are read
+	tokens, _ := scanTokens(options)
+
+	var charset, collation string
+	depth := 0
+	for i := 0; i < len(tokens); i++ {
+		var value *string
+		switch tokens[i].typ {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case sqlparser.CHARSET:
+			value = &charset
+		case sqlparser.CHARACTER:
+			if i+1 < len(tokens) && tokens[i+1].typ == sqlparser.SET {
+				value = &charset
+				i++
+			}
+		case sqlparser.COLLATE:
+			value = &collation
+		}
+		if value == nil || depth > 0 {
+			continue
+		}
+		if i+1 < len(tokens) && tokens[i+1].typ == '=' {
+			i++
+		}
+		if i+1 < len(tokens) {
+			*value = strings.ToLower(tokens[i+1].val)
+			i++
+		}
+	}
+
+	if charset == "" && collation == "" {
+		return sql.Collation{}, nil
+	}
+	return sql.ParseCollation(&charset, &collation, false)
+}
+
 type namedConstraint struct {
 	name string
 }
@@ -1517,21 +2055,62 @@ func convertCreateView(ctx *sql.Context, query string, c *sqlparser.DDL) (sql.No
 		return nil, err
 	}
 
+	name := c.View.Name.String()
+	if names := viewNamesAsWritten(query); len(names) == 1 && strings.EqualFold(names[0], name) {
+		name = names[0]
+	}
+
 	selectStr := query[c.SubStatementPositionStart:c.SubStatementPositionEnd]
-	queryAlias := plan.NewSubqueryAlias(c.View.Name.String(), selectStr, queryNode)
+	queryAlias := plan.NewSubqueryAlias(name, selectStr, queryNode)
 
 	return plan.NewCreateView(
-		sql.UnresolvedDatabase(""), c.View.Name.String(), []string{}, queryAlias, c.OrReplace), nil
+		sql.UnresolvedDatabase(""), name, []string{}, queryAlias, c.OrReplace), nil
 }
 
-func convertDropView(ctx *sql.Context, c *sqlparser.DDL) (sql.Node, error) {
+func convertDropView(ctx *sql.Context, query string, c *sqlparser.DDL) (sql.Node, error) {
+	names := viewNamesAsWritten(query)
 	plans := make([]sql.Node, len(c.FromViews))
 	for i, v := range c.FromViews {
-		plans[i] = plan.NewSingleDropView(sql.UnresolvedDatabase(""), v.Name.String())
+		name := v.Name.String()
+		if len(names) == len(c.FromViews) && strings.EqualFold(names[i], name) {
+			name = names[i]
+		}
+		plans[i] = plan.NewSingleDropView(sql.UnresolvedDatabase(""), name)
 	}
 	return plan.NewDropView(plans, c.IfExists), nil
 }
 
+// viewNamesAsWritten returns the names of the views named by the CREATE VIEW or DROP VIEW statement given, as they're
+// written in it. The parser folds the names of views to lower case, but they're matched as the catalog says to.
+func viewNamesAsWritten(query string) []string {
+	tokens, ok := scanTokens(query)
+	if !ok {
+		return nil
+	}
+
+	i := 0
+	for i < len(tokens) && tokens[i].typ != sqlparser.VIEW {
+		i++
+	}
+	i++
+	if i+1 < len(tokens) && tokens[i].typ == sqlparser.IF && tokens[i+1].typ == sqlparser.EXISTS {
+		i += 2
+	}
+
+	var names []string
+	for ; i < len(tokens); i++ {
+		if i+2 < len(tokens) && tokens[i+1].typ == '.' {
+			i += 2
+		}
+		names = append(names, tokens[i].val)
+		if i+1 >= len(tokens) || tokens[i+1].typ != ',' {
+			break
+		}
+		i++
+	}
+	return names
+}
+
 func convertInsert(ctx *sql.Context, i *sqlparser.Insert) (sql.Node, error) {
 	onDupExprs, err := assignmentExprsToExpressions(ctx, sqlparser.AssignmentExprs(i.OnDup))
 	if err != nil {
@@ -1551,40 +2130,7 @@ func convertInsert(ctx *sql.Context, i *sqlparser.Insert) (sql.Node, error) {
 		ignore = true
 	}
 
-	columnWithDefaultValues := make(map[int]bool)
-
-	if e, ok := src.(*plan.Values); ok {
-		for i, tuple := range e.ExpressionTuples {
-			var needCols []sql.Expression
-			for j, s := range tuple {
-				if _, ok := s.(*expression.DefaultColumn); ok {
-					columnWithDefaultValues[j] = true
-				} else {
-					needCols = append(needCols, s)
-				}
-			}
-
-			// Only re-assign if found column with default values
-			if len(columnWithDefaultValues) == 0 {
-				break
-			}
-
-			e.ExpressionTuples[i] = needCols
-		}
-	}
-
-	var columns []string
-	if len(columnWithDefaultValues) > 0 {
-		for i, c := range columnsToStrings(i.Columns) {
-			if _, found := columnWithDefaultValues[i]; !found {
-				columns = append(columns, c)
-			}
-		}
-	} else {
-		columns = columnsToStrings(i.Columns)
-	}
-
-	return plan.NewInsertInto(sql.UnresolvedDatabase(i.Table.Qualifier.String()), tableNameToUnresolvedTable(i.Table), src, isReplace, columns, onDupExprs, ignore), nil
+	return plan.NewInsertInto(sql.UnresolvedDatabase(i.Table.Qualifier.String()), tableNameToUnresolvedTable(i.Table), src, isReplace, columnsToStrings(i.Columns), onDupExprs, ignore), nil
 }
 
 func convertDelete(ctx *sql.Context, d *sqlparser.Delete) (sql.Node, error) {
@@ -1622,7 +2168,11 @@ func convertDelete(ctx *sql.Context, d *sqlparser.Delete) (sql.Node, error) {
 		}
 	}
 
-	return plan.NewDeleteFrom(node), nil
+	del := plan.NewDeleteFrom(node)
+	for _, target := range d.Targets {
+		del.Targets = append(del.Targets, target.Name.String())
+	}
+	return del, nil
 }
 
 func convertUpdate(ctx *sql.Context, d *sqlparser.Update) (sql.Node, error) {
@@ -1749,16 +2299,133 @@ func TableSpecToSchema(ctx *sql.Context, tableSpec *sqlparser.TableSpec) (sql.Pr
 }
 
 func validateIndexes(tableSpec *sqlparser.TableSpec) error {
-	lwrNames := make(map[string]bool)
+	lwrNames := make(map[string]*sqlparser.ColumnDefinition)
 	for _, col := range tableSpec.Columns {
-		lwrNames[col.Name.Lowered()] = true
+		lwrNames[col.Name.Lowered()] = col
 	}
 
 	for _, idx := range tableSpec.Indexes {
+		fulltext := strings.Contains(strings.ToLower(idx.Info.Type), sqlparser.FulltextStr)
 		for _, col := range idx.Columns {
-			if !lwrNames[col.Column.Lowered()] {
+			colDef, ok := lwrNames[col.Column.Lowered()]
+			if !ok {
 				return ErrUnknownIndexColumn.New(col.Column.String(), idx.Info.Type, idx.Info.Name.String())
 			}
+			if fulltext || idx.Info.Spatial {
+				continue
+			}
+			if err := validateIndexPrefix(colDef, col.Length); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, col := range tableSpec.Columns {
+		switch col.Type.KeyOpt {
+		case colKeyPrimary, colKeyUnique, colKeyUniqueKey:
+			if err := validateIndexPrefix(col, nil); err != nil {
+				return err
+			}
+		}
+	}
+
+	return validateIndexNames(tableSpec)
+}
+
+// validateIndexPrefix checks the prefix length given for an index on the column given, which is nil when the index
+// has none. Only string columns can have a prefix, which can't be longer than the column, and BLOB and TEXT columns
+// must have one.
+func validateIndexPrefix(colDef *sqlparser.ColumnDefinition, prefix *sqlparser.SQLVal) error {
+	typ, err := sql.ColumnTypeToType(&colDef.Type)
+	if err != nil {
+		return err
+	}
+
+	if prefix == nil || prefix.Type != sqlparser.IntVal {
+		if sql.IsTextBlob(typ) {
+			return ErrIndexPrefixRequired.New(colDef.Name.String())
+		}
+		return nil
+	}
+
+	length, err := strconv.ParseInt(string(prefix.Val), 10, 64)
+	if err != nil {
+		return err
+	}
+	st, ok := typ.(sql.StringType)
+	if !ok || length < 1 || length > st.MaxCharacterLength() {
+		return ErrInvalidIndexPrefix.New(colDef.Name.String(), length)
+	}
+	return nil
+}
+
+// validateIndexNames checks that no two indexes of the table spec given have the same name. Besides the names given
+// explicitly, this includes the PRIMARY name of the primary key, and the names of the unnamed UNIQUE constraints,
+// which are named after their first column.
+func validateIndexNames(tableSpec *sqlparser.TableSpec) error {
+	lwrIdxNames := make(map[string]bool)
+	var implicitNames []string
+	for _, idx := range tableSpec.Indexes {
+		if idx.Info.Primary {
+			implicitNames = append(implicitNames, "PRIMARY")
+			continue
+		}
+		name := idx.Info.Name.String()
+		if name == "" {
+			if idx.Info.Unique && len(idx.Columns) > 0 {
+				implicitNames = append(implicitNames, idx.Columns[0].Column.String())
+			}
+			continue
+		}
+		if lwrIdxNames[strings.ToLower(name)] {
+			return sql.ErrDuplicateIndexName.New(name)
+		}
+		lwrIdxNames[strings.ToLower(name)] = true
+	}
+
+	for _, col := range tableSpec.Columns {
+		switch col.Type.KeyOpt {
+		case colKeyPrimary:
+			implicitNames = append(implicitNames, "PRIMARY")
+		case colKeyUnique, colKeyUniqueKey:
+			implicitNames = append(implicitNames, col.Name.String())
+		}
+	}
+	for _, name := range implicitNames {
+		if lwrIdxNames[strings.ToLower(name)] {
+			return sql.ErrDuplicateIndexName.New(name)
+		}
+	}
+
+	return nil
+}
+
+// validateForeignKeys checks that the foreign keys given only name columns of the table being created, and reference
+// as many columns as they name. The types of the columns are checked as well for the foreign keys that reference the
+// table itself, since the other tables are only known once the table is created.
+func validateForeignKeys(tableName string, schema sql.Schema, fkDefs []*sql.ForeignKeyConstraint) error {
+	for _, fkDef := range fkDefs {
+		for _, col := range fkDef.Columns {
+			if !schema.Contains(col, "") {
+				return sql.ErrUnknownForeignKeyColumn.New(col, fkDef.Name)
+			}
+		}
+		if len(fkDef.Columns) != len(fkDef.ReferencedColumns) {
+			return sql.ErrForeignKeyColumnCountMismatch.New()
+		}
+
+		if !strings.EqualFold(fkDef.ReferencedTable, tableName) {
+			continue
+		}
+		for i, refCol := range fkDef.ReferencedColumns {
+			refIdx := schema.IndexOf(refCol, "")
+			if refIdx < 0 {
+				return sql.ErrUnknownForeignKeyColumn.New(refCol, fkDef.Name)
+			}
+			col := schema[schema.IndexOf(fkDef.Columns[i], "")]
+			if !sql.ForeignKeyColumnTypesCompatible(col.Type, schema[refIdx].Type) {
+				return sql.ErrForeignKeyColumnTypeMismatch.New(col.Name, schema[refIdx].Name, fkDef.Name)
+			}
 		}
 	}
 
@@ -1777,6 +2444,10 @@ func validateAutoIncrement(schema sql.Schema) error {
 				// AUTO_INCREMENT col cannot have default
 				return ErrInvalidAutoIncCols.New()
 			}
+			if !sql.IsNumber(col.Type) || sql.IsDecimal(col.Type) {
+				// AUTO_INCREMENT col must be an integer or a float
+				return ErrInvalidAutoIncCols.New()
+			}
 			if seen {
 				// there can be at most one AUTO_INCREMENT col
 				return ErrInvalidAutoIncCols.New()
@@ -1822,9 +2493,9 @@ func columnDefinitionToColumn(ctx *sql.Context, cd *sqlparser.ColumnDefinition,
 		return nil, err
 	}
 
-	extra := ""
-	if cd.Type.Autoincrement {
-		extra = "auto_increment"
+	onUpdate, err := convertOnUpdateExpression(ctx, cd.Name.String(), internalTyp, cd.Type.OnUpdate)
+	if err != nil {
+		return nil, err
 	}
 
 	return &sql.Column{
@@ -1835,10 +2506,30 @@ func columnDefinitionToColumn(ctx *sql.Context, cd *sqlparser.ColumnDefinition,
 		Default:       defaultVal,
 		AutoIncrement: bool(cd.Type.Autoincrement),
 		Comment:       comment,
-		Extra:         extra,
+		OnUpdate:      onUpdate,
 	}, nil
 }
 
+// convertOnUpdateExpression returns the value declared by the ON UPDATE clause of the column named, which MySQL only
+// allows to be the current timestamp, for DATETIME and TIMESTAMP columns.
+func convertOnUpdateExpression(ctx *sql.Context, column string, typ sql.Type, onUpdateExpr sqlparser.Expr) (*sql.ColumnDefaultValue, error) {
+	if onUpdateExpr == nil {
+		return nil, nil
+	}
+
+	f, ok := onUpdateExpr.(*sqlparser.FuncExpr)
+	if !ok || !sql.IsTime(typ) || typ == sql.Date {
+		return nil, sql.ErrInvalidOnUpdate.New(column)
+	}
+	switch f.Name.Lowered() {
+	case "current_timestamp", "localtime", "localtimestamp", "now":
+	default:
+		return nil, sql.ErrInvalidOnUpdate.New(column)
+	}
+
+	return convertDefaultExpression(ctx, onUpdateExpr)
+}
+
 func convertDefaultExpression(ctx *sql.Context, defaultExpr sqlparser.Expr) (*sql.ColumnDefaultValue, error) {
 	if defaultExpr == nil {
 		return nil, nil
@@ -1990,12 +2681,6 @@ func tableExprToTable(
 			return nil, ErrUnsupportedSyntax.New(sqlparser.String(te))
 		}
 	case *sqlparser.JoinTableExpr:
-		// TODO: add support for using, once we have proper table
-		// qualification of fields
-		if len(t.Condition.Using) > 0 {
-			return nil, ErrUnsupportedFeature.New("USING clause on join")
-		}
-
 		left, err := tableExprToTable(ctx, t.LeftExpr)
 		if err != nil {
 			return nil, err
@@ -2006,8 +2691,27 @@ func tableExprToTable(
 			return nil, err
 		}
 
-		if t.Join == sqlparser.NaturalJoinStr {
+		switch strings.ToLower(t.Join) {
+		case sqlparser.NaturalJoinStr:
 			return plan.NewNaturalJoin(left, right), nil
+		case sqlparser.NaturalLeftJoinStr:
+			return plan.NewUsingJoin(left, right, plan.JoinTypeLeft, nil), nil
+		case sqlparser.NaturalRightJoinStr:
+			return plan.NewUsingJoin(left, right, plan.JoinTypeRight, nil), nil
+		}
+
+		if len(t.Condition.Using) > 0 {
+			using := columnsToStrings(t.Condition.Using)
+			switch strings.ToLower(t.Join) {
+			case sqlparser.JoinStr:
+				return plan.NewUsingJoin(left, right, plan.JoinTypeInner, using), nil
+			case sqlparser.LeftJoinStr:
+				return plan.NewUsingJoin(left, right, plan.JoinTypeLeft, using), nil
+			case sqlparser.RightJoinStr:
+				return plan.NewUsingJoin(left, right, plan.JoinTypeRight, using), nil
+			default:
+				return nil, ErrUnsupportedFeature.New("USING clause on " + t.Join)
+			}
 		}
 
 		if t.Condition.On == nil {
@@ -2074,6 +2778,30 @@ func orderByToSortFields(ctx *sql.Context, ob sqlparser.OrderBy) (sql.SortFields
 	return sortFields, nil
 }
 
+// validateGroupConcatDistinctOrderBy returns an error if any of the given sort fields orders a GROUP_CONCAT(DISTINCT ...)
+// by an expression that isn't being concatenated. Values are deduplicated before they are sorted, so there would be no
+// single value of such an expression to sort each distinct value by.
+func validateGroupConcatDistinctOrderBy(sortFields sql.SortFields, exprs []sql.Expression) error {
+	for i, sf := range sortFields {
+		if _, ok := sf.Column.(*expression.Literal); ok {
+			continue
+		}
+
+		found := false
+		for _, e := range exprs {
+			if strings.ToLower(e.String()) == strings.ToLower(sf.Column.String()) {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return sql.ErrGroupConcatDistinctOrderBy.New(i + 1)
+		}
+	}
+	return nil
+}
+
 func limitToLimit(
 	ctx *sql.Context,
 	limit sqlparser.Expr,
@@ -2195,8 +2923,8 @@ func selectToSelectionNode(
 			if isAggregateExpr(e) {
 				sql.Inspect(e, func(e sql.Expression) bool {
 					if uf, ok := e.(*expression.UnresolvedFunction); ok {
-						if uf.Window == nil || len(uf.Window.PartitionBy) > 0 || len(uf.Window.OrderBy) > 0 {
-							err = ErrUnsupportedFeature.New("aggregate functions appearing alongside window functions must have an empty OVER () clause")
+						if uf.IsAggregate && (uf.Window == nil || len(uf.Window.OrderBy) > 0) {
+							err = ErrUnsupportedFeature.New("aggregate functions appearing alongside window functions must have an OVER clause without ORDER BY")
 							return false
 						}
 					}
@@ -2403,8 +3131,24 @@ func ExprToExpression(ctx *sql.Context, e sqlparser.Expr) (sql.Expression, error
 			return nil, err
 		}
 
+		if v.Qualifier.IsEmpty() && isRewrittenName(ctx, v.Name.String(), userVarAssignmentFunction) {
+			if len(exprs) != 2 {
+				return nil, sql.ErrInvalidArgumentNumber.New(v.Name.String(), 2, len(exprs))
+			}
+			variable, ok := exprs[0].(*expression.UnresolvedColumn)
+			if !ok || variable.Table() != "" || !strings.HasPrefix(variable.Name(), "@") {
+				return nil, ErrUnsupportedSyntax.New(sqlparser.String(v))
+			}
+			return expression.NewUserVarAssignment(strings.TrimPrefix(variable.Name(), "@"), exprs[1]), nil
+		}
+
+		// The frame of an ordered window ends at the current row, so it isn't the whole partition
+		if v.Distinct && v.Over != nil && len(v.Over.OrderBy) > 0 {
+			return nil, sql.ErrWindowDistinctFrame.New()
+		}
+
 		// NOTE: The count distinct expressions work differently due to the * syntax. eg. COUNT(*)
-		if v.Distinct && v.Name.Lowered() == "count" {
+		if v.Distinct && v.Name.Lowered() == "count" && v.Over == nil {
 			if len(exprs) != 1 {
 				return nil, ErrUnsupportedSyntax.New("more than one expression in COUNT")
 			}
@@ -2431,7 +3175,9 @@ func ExprToExpression(ctx *sql.Context, e sqlparser.Expr) (sql.Expression, error
 		}
 
 		separatorS := ","
-		if v.Separator != "" {
+		if isRewrittenName(ctx, v.Separator, emptySeparator) {
+			separatorS = ""
+		} else if v.Separator != "" {
 			separatorS = v.Separator
 		}
 
@@ -2440,6 +3186,12 @@ func ExprToExpression(ctx *sql.Context, e sqlparser.Expr) (sql.Expression, error
 			return nil, err
 		}
 
+		if v.Distinct != "" {
+			if err := validateGroupConcatDistinctOrderBy(sortFields, exprs); err != nil {
+				return nil, err
+			}
+		}
+
 		//TODO: this should be acquired at runtime, not at parse time, so fix this
 		gcml, err := ctx.GetSessionVariable(ctx, "group_concat_max_len")
 		if err != nil {
@@ -2480,7 +3232,67 @@ func ExprToExpression(ctx *sql.Context, e sqlparser.Expr) (sql.Expression, error
 			return nil, err
 		}
 
-		return expression.NewConvert(expr, v.Type.Type), nil
+		var length, scale int64
+		if v.Type.Length != nil {
+			length, err = strconv.ParseInt(string(v.Type.Length.Val), 10, 64)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if v.Type.Scale != nil {
+			scale, err = strconv.ParseInt(string(v.Type.Scale.Val), 10, 64)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		// Conversions to the types the parser doesn't accept are rewritten as conversions to CHAR by rewriteCastTypes
+		if castTo, ok := rewrittenName(ctx, v.Type.Charset); ok && strings.EqualFold(v.Type.Type, expression.ConvertToChar) {
+			castTo = strings.ToLower(castTo)
+			if castTypes[castTo] {
+				// FLOAT(p) is a FLOAT if p is at most 24, and a DOUBLE otherwise
+				if castTo == expression.ConvertToFloat && length > 24 {
+					if length > 53 {
+						return nil, sql.ErrInvalidType.New(fmt.Sprintf("FLOAT(%d): Valid range for precision is 0-24 or 25-53", length))
+					}
+					castTo = expression.ConvertToDouble
+				}
+				return expression.NewConvert(expr, castTo), nil
+			}
+		}
+
+		if strings.EqualFold(v.Type.Type, expression.ConvertToDecimal) && length > 0 {
+			// Validates the precision and scale
+			if length > math.MaxUint8 || scale > math.MaxUint8 {
+				return nil, sql.ErrInvalidType.New(fmt.Sprintf("DECIMAL(%d, %d)", length, scale))
+			}
+			if _, err := sql.CreateDecimalType(uint8(length), uint8(scale)); err != nil {
+				return nil, sql.ErrInvalidType.New(fmt.Sprintf("DECIMAL(%d, %d): %s", length, scale, err))
+			}
+		}
+
+		convert := expression.NewConvertWithLengthAndScale(expr, v.Type.Type, int(length), int(scale))
+		if v.Type.Charset != "" {
+			// CONVERT(x, CHAR CHARACTER SET charset) is a conversion to a string followed by one to the character set
+			charset, err := sql.ParseCharacterSet(strings.ToLower(v.Type.Charset))
+			if err != nil {
+				return nil, err
+			}
+			return expression.NewConvertUsing(convert, charset), nil
+		}
+		return convert, nil
+	case *sqlparser.ConvertUsingExpr:
+		expr, err := ExprToExpression(ctx, v.Expr)
+		if err != nil {
+			return nil, err
+		}
+
+		charset, err := sql.ParseCharacterSet(strings.ToLower(v.Type))
+		if err != nil {
+			return nil, err
+		}
+
+		return expression.NewConvertUsing(expr, charset), nil
 	case *sqlparser.RangeCond:
 		val, err := ExprToExpression(ctx, v.Left)
 		if err != nil {
@@ -2534,8 +3346,21 @@ func ExprToExpression(ctx *sql.Context, e sqlparser.Expr) (sql.Expression, error
 	case *sqlparser.IntervalExpr:
 		return intervalExprToExpression(ctx, v)
 	case *sqlparser.CollateExpr:
-		// TODO: handle collation
-		return ExprToExpression(ctx, v.Expr)
+		// Character set introducers are rewritten as collations by rewriteIntroducers
+		if val, ok := v.Expr.(*sqlparser.SQLVal); ok {
+			if charset, ok := rewrittenName(ctx, v.Charset); ok {
+				return introducedLiteral(charset, val)
+			}
+		}
+		expr, err := ExprToExpression(ctx, v.Expr)
+		if err != nil {
+			return nil, err
+		}
+		collation, err := sql.ParseCollation(nil, &v.Charset, false)
+		if err != nil {
+			return nil, err
+		}
+		return expression.NewCollatedExpression(expr, collation), nil
 	case *sqlparser.ValuesFuncExpr:
 		col, err := ExprToExpression(ctx, v.Name)
 		if err != nil {
@@ -2844,11 +3669,57 @@ func unaryExprToExpression(ctx *sql.Context, e *sqlparser.UnaryExpr) (sql.Expres
 			return expression.NewLiteral(exprLiteral.Value(), sql.LongBlob), nil
 		}
 		return expr, nil
+	case sqlparser.Utf8mb4Str:
+		if val, ok := e.Expr.(*sqlparser.SQLVal); ok {
+			return introducedLiteral(sql.CharacterSet_utf8mb4.String(), val)
+		}
+		return ExprToExpression(ctx, e.Expr)
 	default:
 		return nil, ErrUnsupportedFeature.New("unary operator: " + e.Operator)
 	}
 }
 
+// introducedLiteral returns the string, hexadecimal or bit literal given, preceded by an introducer of the character
+// set given, as a string of that character set, with its default collation.
+func introducedLiteral(charset string, v *sqlparser.SQLVal) (sql.Expression, error) {
+	cs, err := sql.ParseCharacterSet(strings.ToLower(charset))
+	if err != nil {
+		return nil, err
+	}
+
+	var val []byte
+	switch v.Type {
+	case sqlparser.StrVal:
+		val = v.Val
+	case sqlparser.HexVal:
+		val, err = v.HexDecode()
+	case sqlparser.HexNum:
+		digits := strings.TrimPrefix(strings.ToLower(string(v.Val)), "0x")
+		if len(digits)%2 != 0 {
+			digits = "0" + digits
+		}
+		val, err = hex.DecodeString(digits)
+	case sqlparser.BitVal:
+		bits := string(v.Val)
+		for len(bits)%8 != 0 {
+			bits = "0" + bits
+		}
+		val = make([]byte, len(bits)/8)
+		for i := range val {
+			var b uint64
+			b, err = strconv.ParseUint(bits[i*8:i*8+8], 2, 8)
+			val[i] = byte(b)
+		}
+	default:
+		return nil, ErrInvalidSQLValType.New(v.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return expression.NewLiteral(string(val), sql.CreateLongText(cs.DefaultCollation())), nil
+}
+
 func binaryExprToExpression(ctx *sql.Context, be *sqlparser.BinaryExpr) (sql.Expression, error) {
 	switch strings.ToLower(be.Operator) {
 	case
@@ -2888,13 +3759,36 @@ func binaryExprToExpression(ctx *sql.Context, be *sqlparser.BinaryExpr) (sql.Exp
 	case
 		sqlparser.JSONExtractOp,
 		sqlparser.JSONUnquoteExtractOp:
-		return nil, ErrUnsupportedFeature.New(fmt.Sprintf("(%s) JSON operators not supported", be.Operator))
+		return jsonOperatorToExpression(ctx, be)
 
 	default:
 		return nil, ErrUnsupportedFeature.New(be.Operator)
 	}
 }
 
+// jsonOperatorToExpression converts the column->path and column->>path operators given to JSON_EXTRACT(column, path)
+// and JSON_UNQUOTE(JSON_EXTRACT(column, path)) respectively.
+func jsonOperatorToExpression(ctx *sql.Context, be *sqlparser.BinaryExpr) (sql.Expression, error) {
+	l, err := ExprToExpression(ctx, be.Left)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := ExprToExpression(ctx, be.Right)
+	if err != nil {
+		return nil, err
+	}
+
+	extract, err := function.NewJSONExtract(l, r)
+	if err != nil {
+		return nil, err
+	}
+	if be.Operator == sqlparser.JSONUnquoteExtractOp {
+		return function.NewJSONUnquote(extract), nil
+	}
+	return extract, nil
+}
+
 func caseExprToExpression(ctx *sql.Context, e *sqlparser.CaseExpr) (sql.Expression, error) {
 	var expr sql.Expression
 	var err error
@@ -2958,19 +3852,19 @@ func setExprsToExpressions(ctx *sql.Context, e sqlparser.SetVarExprs) ([]sql.Exp
 			switch strings.ToLower(expr.String()) {
 			case "'isolation level repeatable read'":
 				varToSet := expression.NewSystemVar("transaction_isolation", scope)
-				res[i] = expression.NewSetField(varToSet, expression.NewLiteral("REPEATABLE-READ", sql.LongText))
+				res[i] = expression.NewSetField(varToSet, expression.NewLiteral(string(sql.IsolationLevelRepeatableRead), sql.LongText))
 				continue
 			case "'isolation level read committed'":
 				varToSet := expression.NewSystemVar("transaction_isolation", scope)
-				res[i] = expression.NewSetField(varToSet, expression.NewLiteral("READ-COMMITTED", sql.LongText))
+				res[i] = expression.NewSetField(varToSet, expression.NewLiteral(string(sql.IsolationLevelReadCommitted), sql.LongText))
 				continue
 			case "'isolation level read uncommitted'":
 				varToSet := expression.NewSystemVar("transaction_isolation", scope)
-				res[i] = expression.NewSetField(varToSet, expression.NewLiteral("READ-UNCOMMITTED", sql.LongText))
+				res[i] = expression.NewSetField(varToSet, expression.NewLiteral(string(sql.IsolationLevelReadUncommitted), sql.LongText))
 				continue
 			case "'isolation level serializable'":
 				varToSet := expression.NewSystemVar("transaction_isolation", scope)
-				res[i] = expression.NewSetField(varToSet, expression.NewLiteral("SERIALIZABLE", sql.LongText))
+				res[i] = expression.NewSetField(varToSet, expression.NewLiteral(string(sql.IsolationLevelSerializable), sql.LongText))
 				continue
 			case "'read write'":
 				varToSet := expression.NewSystemVar("transaction_read_only", scope)
@@ -34,13 +34,13 @@ import (
 )
 
 var showCollationProjection = plan.NewProject([]sql.Expression{
-	expression.NewAlias("collation", expression.NewUnresolvedColumn("collation_name")),
-	expression.NewAlias("charset", expression.NewUnresolvedColumn("character_set_name")),
-	expression.NewUnresolvedColumn("id"),
-	expression.NewAlias("default", expression.NewUnresolvedColumn("is_default")),
-	expression.NewAlias("compiled", expression.NewUnresolvedColumn("is_compiled")),
-	expression.NewUnresolvedColumn("sortlen"),
-	expression.NewUnresolvedColumn("pad_attribute"),
+	expression.NewAlias("Collation", expression.NewUnresolvedColumn("collation_name")),
+	expression.NewAlias("Charset", expression.NewUnresolvedColumn("character_set_name")),
+	expression.NewAlias("Id", expression.NewUnresolvedColumn("id")),
+	expression.NewAlias("Default", expression.NewUnresolvedColumn("is_default")),
+	expression.NewAlias("Compiled", expression.NewUnresolvedColumn("is_compiled")),
+	expression.NewAlias("Sortlen", expression.NewUnresolvedColumn("sortlen")),
+	expression.NewAlias("Pad_attribute", expression.NewUnresolvedColumn("pad_attribute")),
 },
 	plan.NewUnresolvedTable("collations", "information_schema"),
 )
@@ -146,7 +146,7 @@ var fixtures = map[string]sql.Node{
 			}}),
 		},
 	),
-	`CREATE TABLE t1(a INTEGER, b TEXT, PRIMARY KEY (a, b))`: plan.NewCreateTable(
+	`CREATE TABLE t1(a INTEGER, b TEXT, PRIMARY KEY (a, b(10)))`: plan.NewCreateTable(
 		sql.UnresolvedDatabase(""),
 		"t1",
 		plan.IfNotExistsAbsent,
@@ -165,7 +165,7 @@ var fixtures = map[string]sql.Node{
 			}}),
 		},
 	),
-	`CREATE TABLE IF NOT EXISTS t1(a INTEGER, b TEXT, PRIMARY KEY (a, b))`: plan.NewCreateTable(
+	`CREATE TABLE IF NOT EXISTS t1(a INTEGER, b TEXT, PRIMARY KEY (a, b(10)))`: plan.NewCreateTable(
 		sql.UnresolvedDatabase(""),
 		"t1",
 		plan.IfNotExists,
@@ -864,6 +864,12 @@ CREATE TABLE t2
 	`RENAME TABLE foo TO bar, baz TO qux`: plan.NewRenameTable(
 		sql.UnresolvedDatabase(""), []string{"foo", "baz"}, []string{"bar", "qux"},
 	),
+	`RENAME TABLE db1.foo TO db1.bar, db1.baz TO db1.qux`: plan.NewRenameTable(
+		sql.UnresolvedDatabase("db1"), []string{"foo", "baz"}, []string{"bar", "qux"},
+	),
+	`ALTER TABLE db1.foo RENAME db1.bar`: plan.NewRenameTable(
+		sql.UnresolvedDatabase("db1"), []string{"foo"}, []string{"bar"},
+	),
 	`ALTER TABLE foo RENAME bar`: plan.NewRenameTable(
 		sql.UnresolvedDatabase(""), []string{"foo"}, []string{"bar"},
 	),
@@ -1155,6 +1161,18 @@ CREATE TABLE t2
 		},
 		plan.NewUnresolvedTable("foo", ""),
 	),
+	`SELECT foo IS UNKNOWN, bar IS NOT UNKNOWN, 'is unknown' FROM foo;`: plan.NewProject(
+		[]sql.Expression{
+			expression.NewAlias("foo IS UNKNOWN",
+				expression.NewIsNull(expression.NewUnresolvedColumn("foo")),
+			),
+			expression.NewAlias("bar IS NOT UNKNOWN",
+				expression.NewNot(expression.NewIsNull(expression.NewUnresolvedColumn("bar"))),
+			),
+			expression.NewAlias("is unknown", expression.NewLiteral("is unknown", sql.LongText)),
+		},
+		plan.NewUnresolvedTable("foo", ""),
+	),
 	`SELECT foo AS bar FROM foo;`: plan.NewProject(
 		[]sql.Expression{
 			expression.NewAlias("bar", expression.NewUnresolvedColumn("foo")),
@@ -1505,7 +1523,15 @@ CREATE TABLE t2
 	}}), false, []string{}, []sql.Expression{}, false),
 	`INSERT INTO t1 (col1, col2) VALUES ('a', DEFAULT)`: plan.NewInsertInto(sql.UnresolvedDatabase(""), plan.NewUnresolvedTable("t1", ""), plan.NewValues([][]sql.Expression{{
 		expression.NewLiteral("a", sql.LongText),
-	}}), false, []string{"col1"}, []sql.Expression{}, false),
+		expression.NewDefaultColumn(""),
+	}}), false, []string{"col1", "col2"}, []sql.Expression{}, false),
+	`INSERT INTO t1 (col1, col2) VALUES (DEFAULT, 'a'), ('b', DEFAULT)`: plan.NewInsertInto(sql.UnresolvedDatabase(""), plan.NewUnresolvedTable("t1", ""), plan.NewValues([][]sql.Expression{{
+		expression.NewDefaultColumn(""),
+		expression.NewLiteral("a", sql.LongText),
+	}, {
+		expression.NewLiteral("b", sql.LongText),
+		expression.NewDefaultColumn(""),
+	}}), false, []string{"col1", "col2"}, []sql.Expression{}, false),
 	`UPDATE t1 SET col1 = ?, col2 = ? WHERE id = ?`: plan.NewUpdate(
 		plan.NewFilter(
 			expression.NewEquals(expression.NewUnresolvedColumn("id"), expression.NewBindVar("v3")),
@@ -1516,6 +1542,19 @@ CREATE TABLE t2
 			expression.NewSetField(expression.NewUnresolvedColumn("col2"), expression.NewBindVar("v2")),
 		},
 	),
+	`UPDATE t1 SET (col1, col2) = ROW(1, 2), col3 = 'a,b' WHERE id = 3`: plan.NewUpdate(
+		plan.NewFilter(
+			expression.NewEquals(expression.NewUnresolvedColumn("id"), expression.NewLiteral(int8(3), sql.Int8)),
+			plan.NewUnresolvedTable("t1", ""),
+		),
+		[]sql.Expression{
+			expression.NewSetField(
+				expression.NewTuple(expression.NewUnresolvedColumn("col1"), expression.NewUnresolvedColumn("col2")),
+				expression.NewTuple(expression.NewLiteral(int8(1), sql.Int8), expression.NewLiteral(int8(2), sql.Int8)),
+			),
+			expression.NewSetField(expression.NewUnresolvedColumn("col3"), expression.NewLiteral("a,b", sql.LongText)),
+		},
+	),
 	`REPLACE INTO t1 (col1, col2) VALUES ('a', 1)`: plan.NewInsertInto(sql.UnresolvedDatabase(""), plan.NewUnresolvedTable("t1", ""), plan.NewValues([][]sql.Expression{{
 		expression.NewLiteral("a", sql.LongText),
 		expression.NewLiteral(int8(1), sql.Int8),
@@ -1828,6 +1867,117 @@ CREATE TABLE t2
 		},
 		plan.NewUnresolvedTable("foo", ""),
 	),
+	`SELECT CAST(a AS DOUBLE), CAST(a AS double precision), CONVERT(a, REAL), CAST(a AS FLOAT), CAST(a AS FLOAT(24)), CAST(a AS FLOAT(25)) FROM foo`: plan.NewProject(
+		[]sql.Expression{
+			expression.NewAlias("CAST(a AS DOUBLE)",
+				expression.NewConvert(expression.NewUnresolvedColumn("a"), expression.ConvertToDouble),
+			),
+			expression.NewAlias("CAST(a AS double precision)",
+				expression.NewConvert(expression.NewUnresolvedColumn("a"), expression.ConvertToDouble),
+			),
+			expression.NewAlias("CONVERT(a, REAL)",
+				expression.NewConvert(expression.NewUnresolvedColumn("a"), expression.ConvertToReal),
+			),
+			expression.NewAlias("CAST(a AS FLOAT)",
+				expression.NewConvert(expression.NewUnresolvedColumn("a"), expression.ConvertToFloat),
+			),
+			expression.NewAlias("CAST(a AS FLOAT(24))",
+				expression.NewConvert(expression.NewUnresolvedColumn("a"), expression.ConvertToFloat),
+			),
+			expression.NewAlias("CAST(a AS FLOAT(25))",
+				expression.NewConvert(expression.NewUnresolvedColumn("a"), expression.ConvertToDouble),
+			),
+		},
+		plan.NewUnresolvedTable("foo", ""),
+	),
+	`SELECT a = b = c, a < b <> c >= d, NOT a BETWEEN b AND c, a IS NOT NULL = b FROM foo WHERE a = 1 = 1`: plan.NewProject(
+		[]sql.Expression{
+			expression.NewAlias("a = b = c",
+				expression.NewEquals(
+					expression.NewEquals(expression.NewUnresolvedColumn("a"), expression.NewUnresolvedColumn("b")),
+					expression.NewUnresolvedColumn("c"),
+				),
+			),
+			expression.NewAlias("a < b <> c >= d",
+				expression.NewGreaterThanOrEqual(
+					expression.NewNot(expression.NewEquals(
+						expression.NewLessThan(expression.NewUnresolvedColumn("a"), expression.NewUnresolvedColumn("b")),
+						expression.NewUnresolvedColumn("c"),
+					)),
+					expression.NewUnresolvedColumn("d"),
+				),
+			),
+			expression.NewAlias("NOT a BETWEEN b AND c",
+				expression.NewNot(expression.NewBetween(
+					expression.NewUnresolvedColumn("a"),
+					expression.NewUnresolvedColumn("b"),
+					expression.NewUnresolvedColumn("c"),
+				)),
+			),
+			expression.NewAlias("a IS NOT NULL = b",
+				expression.NewEquals(
+					expression.NewNot(expression.NewIsNull(expression.NewUnresolvedColumn("a"))),
+					expression.NewUnresolvedColumn("b"),
+				),
+			),
+		},
+		plan.NewFilter(
+			expression.NewEquals(
+				expression.NewEquals(expression.NewUnresolvedColumn("a"), expression.NewLiteral(int8(1), sql.Int8)),
+				expression.NewLiteral(int8(1), sql.Int8),
+			),
+			plan.NewUnresolvedTable("foo", ""),
+		),
+	),
+	`SHOW GRANTS`:                      plan.NewShowGrants("", ""),
+	`SHOW GRANTS FOR root@localhost`:   plan.NewShowGrants("root", "localhost"),
+	"SHOW GRANTS FOR 'bob'@'10.0.0.%'": plan.NewShowGrants("bob", "10.0.0.%"),
+	"show grants for `alice`":          plan.NewShowGrants("alice", "%"),
+	`SHOW GRANTS FOR CURRENT_USER()`:   plan.NewShowGrants("", ""),
+	"SHOW GRANTS FOR 'it''s' -- x":     plan.NewShowGrants("it's", "%"),
+	`SELECT CAST(year AS YEAR), convert(_latin1'99',year), 1 as year FROM foo`: plan.NewProject(
+		[]sql.Expression{
+			expression.NewAlias("CAST(year AS YEAR)",
+				expression.NewConvert(expression.NewUnresolvedColumn("year"), expression.ConvertToYear),
+			),
+			expression.NewAlias("convert(_latin1'99',year)",
+				expression.NewConvert(expression.NewLiteral("99", sql.CreateLongText(sql.Collation_latin1_swedish_ci)), expression.ConvertToYear),
+			),
+			expression.NewAlias("year", expression.NewLiteral(int8(1), sql.Int8)),
+		},
+		plan.NewUnresolvedTable("foo", ""),
+	),
+	"SELECT @v := @v + 1 AS n, @w:=(SELECT 1) FROM foo WHERE (@x := bar) > 1": plan.NewProject(
+		[]sql.Expression{
+			expression.NewAlias("n",
+				expression.NewUserVarAssignment("v",
+					expression.NewArithmetic(expression.NewUnresolvedColumn("@v"), expression.NewLiteral(int8(1), sql.Int8), "+"),
+				),
+			),
+			expression.NewAlias("@w:=(SELECT 1)",
+				expression.NewUserVarAssignment("w", plan.NewSubquery(
+					plan.NewProject(
+						[]sql.Expression{expression.NewLiteral(int8(1), sql.Int8)},
+						plan.NewUnresolvedTable("dual", ""),
+					), "select 1 from dual"),
+				),
+			),
+		},
+		plan.NewFilter(
+			expression.NewGreaterThan(
+				expression.NewUserVarAssignment("x", expression.NewUnresolvedColumn("bar")),
+				expression.NewLiteral(int8(1), sql.Int8),
+			),
+			plan.NewUnresolvedTable("foo", ""),
+		),
+	),
+	"SET @a := 1, @b := @c := 2": plan.NewSet(
+		[]sql.Expression{
+			expression.NewSetField(expression.NewUserVar("a"), expression.NewLiteral(int8(1), sql.Int8)),
+			expression.NewSetField(expression.NewUserVar("b"),
+				expression.NewUserVarAssignment("c", expression.NewLiteral(int8(2), sql.Int8))),
+		},
+	),
 	`SELECT 2 = 2 FROM foo`: plan.NewProject(
 		[]sql.Expression{
 			expression.NewAlias("2 = 2",
@@ -2102,6 +2252,33 @@ CREATE TABLE t2
 			plan.NewUnresolvedTable("baz", ""),
 		),
 	),
+	`SELECT * FROM foo NATURAL LEFT JOIN bar`: plan.NewProject(
+		[]sql.Expression{expression.NewStar()},
+		plan.NewUsingJoin(
+			plan.NewUnresolvedTable("foo", ""),
+			plan.NewUnresolvedTable("bar", ""),
+			plan.JoinTypeLeft,
+			nil,
+		),
+	),
+	`SELECT * FROM foo JOIN bar USING (a, b)`: plan.NewProject(
+		[]sql.Expression{expression.NewStar()},
+		plan.NewUsingJoin(
+			plan.NewUnresolvedTable("foo", ""),
+			plan.NewUnresolvedTable("bar", ""),
+			plan.JoinTypeInner,
+			[]string{"a", "b"},
+		),
+	),
+	`SELECT * FROM foo RIGHT JOIN bar USING (a)`: plan.NewProject(
+		[]sql.Expression{expression.NewStar()},
+		plan.NewUsingJoin(
+			plan.NewUnresolvedTable("foo", ""),
+			plan.NewUnresolvedTable("bar", ""),
+			plan.JoinTypeRight,
+			[]string{"a"},
+		),
+	),
 	`DROP INDEX foo ON bar`: plan.NewAlterDropIndex(
 		plan.NewUnresolvedTable("bar", ""),
 		"foo",
@@ -2293,6 +2470,22 @@ CREATE TABLE t2
 	`DESC foo.bar`: plan.NewShowColumns(false,
 		plan.NewUnresolvedTable("bar", "foo"),
 	),
+	`DESCRIBE foo bar`: plan.NewFilter(
+		expression.NewLike(
+			expression.NewUnresolvedColumn("Field"),
+			expression.NewLiteral("bar", sql.LongText),
+			nil,
+		),
+		plan.NewShowColumns(false, plan.NewUnresolvedTable("foo", "")),
+	),
+	"DESC foo.bar 'b%'": plan.NewFilter(
+		expression.NewLike(
+			expression.NewUnresolvedColumn("Field"),
+			expression.NewLiteral("b%", sql.LongText),
+			nil,
+		),
+		plan.NewShowColumns(false, plan.NewUnresolvedTable("bar", "foo")),
+	),
 	`SELECT * FROM foo.bar`: plan.NewProject(
 		[]sql.Expression{
 			expression.NewStar(),
@@ -2906,6 +3099,73 @@ CREATE TABLE t2
 			),
 		},
 	),
+	`with recursive cte1 (x) as (select 1 union all select x + 1 from cte1 where x < 5) select x from cte1`: plan.NewRecursiveWith(
+		plan.NewProject(
+			[]sql.Expression{
+				expression.NewUnresolvedColumn("x"),
+			},
+			plan.NewUnresolvedTable("cte1", "")),
+		[]*plan.CommonTableExpression{
+			plan.NewCommonTableExpression(
+				plan.NewSubqueryAlias("cte1", "select 1 from dual union all select x + 1 from cte1 where x < 5",
+					plan.NewUnion(
+						plan.NewProject(
+							[]sql.Expression{expression.NewLiteral(int8(1), sql.Int8)},
+							plan.NewUnresolvedTable("dual", ""),
+						),
+						plan.NewProject(
+							[]sql.Expression{
+								expression.NewArithmetic(
+									expression.NewUnresolvedColumn("x"),
+									expression.NewLiteral(int8(1), sql.Int8),
+									"+",
+								),
+							},
+							plan.NewFilter(
+								expression.NewLessThan(
+									expression.NewUnresolvedColumn("x"),
+									expression.NewLiteral(int8(5), sql.Int8),
+								),
+								plan.NewUnresolvedTable("cte1", ""),
+							),
+						),
+					),
+				),
+				[]string{"x"},
+			),
+		},
+	),
+	`select * from (with recursive c (n) as (select 1 union all select n from c) select n from c) x`: plan.NewProject(
+		[]sql.Expression{
+			expression.NewStar(),
+		},
+		plan.NewSubqueryAlias("x", "with c (n) as (select 1 from dual union all select n from c) select n from c",
+			plan.NewRecursiveWith(
+				plan.NewProject(
+					[]sql.Expression{
+						expression.NewUnresolvedColumn("n"),
+					},
+					plan.NewUnresolvedTable("c", "")),
+				[]*plan.CommonTableExpression{
+					plan.NewCommonTableExpression(
+						plan.NewSubqueryAlias("c", "select 1 from dual union all select n from c",
+							plan.NewUnion(
+								plan.NewProject(
+									[]sql.Expression{expression.NewLiteral(int8(1), sql.Int8)},
+									plan.NewUnresolvedTable("dual", ""),
+								),
+								plan.NewProject(
+									[]sql.Expression{expression.NewUnresolvedColumn("n")},
+									plan.NewUnresolvedTable("c", ""),
+								),
+							),
+						),
+						[]string{"n"},
+					),
+				},
+			),
+		),
+	),
 	`SELECT -128, 127, 255, -32768, 32767, 65535, -2147483648, 2147483647, 4294967295, -9223372036854775808, 9223372036854775807, 18446744073709551615`: plan.NewProject(
 		[]sql.Expression{
 			expression.NewLiteral(int8(math.MinInt8), sql.Int8),
@@ -2952,6 +3212,51 @@ CREATE TABLE t2
 		),
 		false,
 	),
+	`CREATE VIEW v AS SELECT * FROM foo WHERE a > 1 WITH LOCAL CHECK OPTION`: plan.NewCreateView(
+		sql.UnresolvedDatabase(""),
+		"v",
+		[]string{},
+		plan.NewSubqueryAlias(
+			"v", "SELECT * FROM foo WHERE a > 1 WITH LOCAL CHECK OPTION",
+			plan.NewProject(
+				[]sql.Expression{expression.NewStar()},
+				plan.NewFilter(
+					expression.NewGreaterThan(
+						expression.NewUnresolvedColumn("a"),
+						expression.NewLiteral(int8(1), sql.Int8),
+					),
+					plan.NewUnresolvedTable("foo", ""),
+				),
+			),
+		),
+		false,
+	),
+	`CREATE VIEW v AS SELECT * FROM foo with check option`: plan.NewCreateView(
+		sql.UnresolvedDatabase(""),
+		"v",
+		[]string{},
+		plan.NewSubqueryAlias(
+			"v", "SELECT * FROM foo WITH CASCADED CHECK OPTION",
+			plan.NewProject(
+				[]sql.Expression{expression.NewStar()},
+				plan.NewUnresolvedTable("foo", ""),
+			),
+		),
+		false,
+	),
+	"CREATE OR REPLACE DEFINER='bob'@'%' SQL SECURITY INVOKER VIEW v (a, `b c`) AS SELECT x, y FROM foo": plan.NewCreateView(
+		sql.UnresolvedDatabase(""),
+		"v",
+		[]string{"a", "b c"},
+		plan.NewSubqueryAlias(
+			"v", "DEFINER=`bob`@`%` SQL SECURITY INVOKER (`a`,`b c`) AS SELECT x, y FROM foo",
+			plan.NewProject(
+				[]sql.Expression{expression.NewUnresolvedColumn("x"), expression.NewUnresolvedColumn("y")},
+				plan.NewUnresolvedTable("foo", ""),
+			),
+		).WithColumns([]string{"a", "b c"}),
+		true,
+	),
 	`CREATE OR REPLACE VIEW v AS SELECT * FROM foo`: plan.NewCreateView(
 		sql.UnresolvedDatabase(""),
 		"v",
@@ -2965,12 +3270,45 @@ CREATE TABLE t2
 		),
 		true,
 	),
+	`ALTER VIEW v AS SELECT * FROM foo`: plan.NewAlterView(
+		sql.UnresolvedDatabase(""),
+		"v",
+		plan.NewSubqueryAlias(
+			"v", "SELECT * FROM foo",
+			plan.NewProject(
+				[]sql.Expression{expression.NewStar()},
+				plan.NewUnresolvedTable("foo", ""),
+			),
+		),
+	),
+	"ALTER ALGORITHM = MERGE DEFINER = `root`@`localhost` SQL SECURITY INVOKER VIEW v AS SELECT * FROM foo WITH LOCAL CHECK OPTION": plan.NewAlterView(
+		sql.UnresolvedDatabase(""),
+		"v",
+		plan.NewSubqueryAlias(
+			"v", "ALGORITHM=MERGE DEFINER=`root`@`localhost` SQL SECURITY INVOKER AS SELECT * FROM foo WITH LOCAL CHECK OPTION",
+			plan.NewProject(
+				[]sql.Expression{expression.NewStar()},
+				plan.NewUnresolvedTable("foo", ""),
+			),
+		),
+	),
+	`alter definer = current_user view v as select 1`: plan.NewAlterView(
+		sql.UnresolvedDatabase(""),
+		"v",
+		plan.NewSubqueryAlias(
+			"v", "select 1",
+			plan.NewProject(
+				[]sql.Expression{expression.NewLiteral(int8(1), sql.Int8)},
+				plan.NewUnresolvedTable("dual", ""),
+			),
+		),
+	),
 	`CREATE TRIGGER myTrigger BEFORE UPDATE ON foo FOR EACH ROW 
    BEGIN 
      UPDATE bar SET x = old.y WHERE z = new.y;
 		 DELETE FROM baz WHERE a = old.b;
 		 INSERT INTO zzz (a,b) VALUES (old.a, old.b);
-   END`: plan.NewCreateTrigger("myTrigger", "before", "update", nil,
+   END`: plan.NewCreateTrigger("myTrigger", "", "before", "update", nil,
 		plan.NewUnresolvedTable("foo", ""),
 		plan.NewBeginEndBlock(
 			plan.NewBlock([]sql.Node{
@@ -3008,7 +3346,7 @@ CREATE TABLE t2
 		 INSERT INTO zzz (a,b) VALUES (old.a, old.b);
    END`,
 	),
-	`CREATE TRIGGER myTrigger BEFORE UPDATE ON foo FOR EACH ROW INSERT INTO zzz (a,b) VALUES (old.a, old.b)`: plan.NewCreateTrigger("myTrigger", "before", "update", nil,
+	`CREATE TRIGGER myTrigger BEFORE UPDATE ON foo FOR EACH ROW INSERT INTO zzz (a,b) VALUES (old.a, old.b)`: plan.NewCreateTrigger("myTrigger", "", "before", "update", nil,
 		plan.NewUnresolvedTable("foo", ""),
 		plan.NewInsertInto(sql.UnresolvedDatabase(""), plan.NewUnresolvedTable("zzz", ""), plan.NewValues([][]sql.Expression{{
 			expression.NewUnresolvedQualifiedColumn("old", "a"),
@@ -3018,7 +3356,7 @@ CREATE TABLE t2
 		`CREATE TRIGGER myTrigger BEFORE UPDATE ON foo FOR EACH ROW INSERT INTO zzz (a,b) VALUES (old.a, old.b)`,
 		`INSERT INTO zzz (a,b) VALUES (old.a, old.b)`,
 	),
-	`CREATE TRIGGER myTrigger BEFORE UPDATE ON foo FOR EACH ROW FOLLOWS yourTrigger INSERT INTO zzz (a,b) VALUES (old.a, old.b)`: plan.NewCreateTrigger("myTrigger", "before", "update",
+	`CREATE TRIGGER myTrigger BEFORE UPDATE ON foo FOR EACH ROW FOLLOWS yourTrigger INSERT INTO zzz (a,b) VALUES (old.a, old.b)`: plan.NewCreateTrigger("myTrigger", "", "before", "update",
 		&plan.TriggerOrder{PrecedesOrFollows: sqlparser.FollowsStr, OtherTriggerName: "yourTrigger"},
 		plan.NewUnresolvedTable("foo", ""),
 		plan.NewInsertInto(sql.UnresolvedDatabase(""), plan.NewUnresolvedTable("zzz", ""), plan.NewValues([][]sql.Expression{{
@@ -3029,6 +3367,16 @@ CREATE TABLE t2
 		`CREATE TRIGGER myTrigger BEFORE UPDATE ON foo FOR EACH ROW FOLLOWS yourTrigger INSERT INTO zzz (a,b) VALUES (old.a, old.b)`,
 		`INSERT INTO zzz (a,b) VALUES (old.a, old.b)`,
 	),
+	"CREATE DEFINER = `root@localhost` TRIGGER myTrigger BEFORE UPDATE ON foo FOR EACH ROW INSERT INTO zzz (a,b) VALUES (old.a, old.b)": plan.NewCreateTrigger("myTrigger", "root@localhost", "before", "update", nil,
+		plan.NewUnresolvedTable("foo", ""),
+		plan.NewInsertInto(sql.UnresolvedDatabase(""), plan.NewUnresolvedTable("zzz", ""), plan.NewValues([][]sql.Expression{{
+			expression.NewUnresolvedQualifiedColumn("old", "a"),
+			expression.NewUnresolvedQualifiedColumn("old", "b"),
+		}},
+		), false, []string{"a", "b"}, []sql.Expression{}, false),
+		"CREATE DEFINER = `root@localhost` TRIGGER myTrigger BEFORE UPDATE ON foo FOR EACH ROW INSERT INTO zzz (a,b) VALUES (old.a, old.b)",
+		`INSERT INTO zzz (a,b) VALUES (old.a, old.b)`,
+	),
 	`SELECT 2 UNION SELECT 3`: plan.NewDistinct(
 		plan.NewUnion(
 			plan.NewProject(
@@ -3155,6 +3503,9 @@ CREATE TABLE t2
 	`CREATE DATABASE IF NOT EXISTS test`: plan.NewCreateDatabase("test", true),
 	`DROP DATABASE test`:                 plan.NewDropDatabase("test", false),
 	`DROP DATABASE IF EXISTS test`:       plan.NewDropDatabase("test", true),
+	`DROP FUNCTION f`:                    plan.NewDropFunction(sql.UnresolvedDatabase(""), "f", false),
+	"DROP FUNCTION IF EXISTS `F`":        plan.NewDropFunction(sql.UnresolvedDatabase(""), "F", true),
+	"DROP FUNCTION IF EXISTS mydb.`F`":   plan.NewDropFunction(sql.UnresolvedDatabase("mydb"), "F", true),
 }
 
 func TestParse(t *testing.T) {
@@ -3220,23 +3571,49 @@ func assertNodesEqualWithDiff(t *testing.T, expected, actual sql.Node) bool {
 }
 
 var fixturesErrors = map[string]*errors.Kind{
-	`SHOW METHEMONEY`:                                           ErrUnsupportedFeature,
-	`SELECT INTERVAL 1 DAY - '2018-05-01'`:                      ErrUnsupportedSyntax,
-	`SELECT INTERVAL 1 DAY * '2018-05-01'`:                      ErrUnsupportedSyntax,
-	`SELECT '2018-05-01' * INTERVAL 1 DAY`:                      ErrUnsupportedSyntax,
-	`SELECT '2018-05-01' / INTERVAL 1 DAY`:                      ErrUnsupportedSyntax,
-	`SELECT INTERVAL 1 DAY + INTERVAL 1 DAY`:                    ErrUnsupportedSyntax,
-	`SELECT '2018-05-01' + (INTERVAL 1 DAY + INTERVAL 1 DAY)`:   ErrUnsupportedSyntax,
-	"DESCRIBE FORMAT=pretty SELECT * FROM foo":                  errInvalidDescribeFormat,
-	`CREATE TABLE test (pk int, primary key(pk, noexist))`:      ErrUnknownIndexColumn,
-	`CREATE TABLE test (pk int null primary key)`:               ErrPrimaryKeyOnNullField,
-	`CREATE TABLE test (pk int not null null primary key)`:      ErrPrimaryKeyOnNullField,
-	`CREATE TABLE test (pk int null, primary key(pk))`:          ErrPrimaryKeyOnNullField,
-	`CREATE TABLE test (pk int not null null, primary key(pk))`: ErrPrimaryKeyOnNullField,
-	`SELECT a, count(i) over (order by x) FROM foo`:             ErrUnsupportedFeature,
-	`SELECT a, count(i) over (partition by y) FROM foo`:         ErrUnsupportedFeature,
-	`SELECT i, row_number() over (order by a) group by 1`:       ErrUnsupportedFeature,
-	`SELECT i, row_number() over (order by a), max(b)`:          ErrUnsupportedFeature,
+	`SELECT CAST(1 AS FLOAT(54))`:                                  sql.ErrInvalidType,
+	`SHOW METHEMONEY`:                                              ErrUnsupportedFeature,
+	`SHOW GRANTS FOR 'a'@'b'@'c'`:                                  sql.ErrSyntaxError,
+	`RENAME TABLE db1.foo TO db2.foo`:                              ErrUnsupportedFeature,
+	`RENAME TABLE db1.foo TO bar`:                                  ErrUnsupportedFeature,
+	`SELECT INTERVAL 1 DAY - '2018-05-01'`:                         ErrUnsupportedSyntax,
+	`SELECT INTERVAL 1 DAY * '2018-05-01'`:                         ErrUnsupportedSyntax,
+	`SELECT '2018-05-01' * INTERVAL 1 DAY`:                         ErrUnsupportedSyntax,
+	`SELECT '2018-05-01' / INTERVAL 1 DAY`:                         ErrUnsupportedSyntax,
+	`SELECT INTERVAL 1 DAY + INTERVAL 1 DAY`:                       ErrUnsupportedSyntax,
+	`SELECT '2018-05-01' + (INTERVAL 1 DAY + INTERVAL 1 DAY)`:      ErrUnsupportedSyntax,
+	"DESCRIBE FORMAT=pretty SELECT * FROM foo":                     errInvalidDescribeFormat,
+	`CREATE TABLE test (pk int, primary key(pk, noexist))`:         ErrUnknownIndexColumn,
+	`CREATE TABLE test (pk int null primary key)`:                  ErrPrimaryKeyOnNullField,
+	`CREATE TABLE test (pk int not null null primary key)`:         ErrPrimaryKeyOnNullField,
+	`CREATE TABLE test (pk int null, primary key(pk))`:             ErrPrimaryKeyOnNullField,
+	`CREATE TABLE test (pk int not null null, primary key(pk))`:    ErrPrimaryKeyOnNullField,
+	`SELECT a, count(i) over (order by x) FROM foo`:                ErrUnsupportedFeature,
+	`SELECT a, count(i) over (partition by y order by x) FROM foo`: ErrUnsupportedFeature,
+	`SELECT a, count(distinct i) over (order by x) FROM foo`:       sql.ErrWindowDistinctFrame,
+	`SELECT count(distinct i) over (rows 2 preceding) FROM foo`:    sql.ErrWindowDistinctFrame,
+	`SELECT i, row_number() over (order by a) group by 1`:          ErrUnsupportedFeature,
+	`SELECT i, row_number() over (order by a), max(b)`:             ErrUnsupportedFeature,
+	`CREATE TABLE t (a int, FOREIGN KEY (b) REFERENCES p (a))`:     sql.ErrUnknownForeignKeyColumn,
+	`CREATE TABLE t (a int, FOREIGN KEY (a) REFERENCES p (a, b))`:  sql.ErrForeignKeyColumnCountMismatch,
+	`CREATE TABLE t (a int, FOREIGN KEY (a) REFERENCES t (b))`:     sql.ErrUnknownForeignKeyColumn,
+	`CREATE TABLE t(a int, b bit, FOREIGN KEY(a) REFERENCES t(b))`: sql.ErrForeignKeyColumnTypeMismatch,
+	`CREATE TABLE t (a int, KEY k (a), KEY K (a))`:                 sql.ErrDuplicateIndexName,
+	"CREATE TABLE t (a int PRIMARY KEY, KEY `PRIMARY` (a))":        sql.ErrDuplicateIndexName,
+	`CREATE TABLE t (a int, b int, UNIQUE KEY (a), KEY a (b))`:     sql.ErrDuplicateIndexName,
+	`CREATE TABLE t (a int UNIQUE, b int, KEY a (b))`:              sql.ErrDuplicateIndexName,
+	`CREATE TABLE t (a text, b int, KEY (b, a))`:                   ErrIndexPrefixRequired,
+	`CREATE TABLE t (a blob PRIMARY KEY)`:                          ErrIndexPrefixRequired,
+	`CREATE TABLE t (a varchar(10), KEY (a(11)))`:                  ErrInvalidIndexPrefix,
+	`CREATE TABLE t (a int, b text, KEY (b(5), a(2)))`:             ErrInvalidIndexPrefix,
+	`CREATE TABLE t (a varchar(10) PRIMARY KEY AUTO_INCREMENT)`:    ErrInvalidAutoIncCols,
+	`CREATE TABLE t (a decimal PRIMARY KEY AUTO_INCREMENT)`:        ErrInvalidAutoIncCols,
+	`CREATE FUNCTION f(x INT) RETURNS INT BEGIN SET x = 1; END`:    sql.ErrStoredFunctionNoReturn,
+	`CREATE FUNCTION f(x INT) RETURNS INT BEGIN SELECT x; END`:     sql.ErrStoredFunctionResultSet,
+	`CREATE FUNCTION f(x INT) RETURN x`:                            sql.ErrSyntaxError,
+	`CREATE FUNCTION f(INOUT x INT) RETURNS INT RETURN x`:          sql.ErrSyntaxError,
+	`CREATE FUNCTION f(x INT, X INT) RETURNS INT RETURN x`:         sql.ErrStoredFunctionDuplicateParameterName,
+	`CREATE FUNCTION f(x INT) RETURNS INT RETURN x, x`:             sql.ErrSyntaxError,
 }
 
 func TestParseErrors(t *testing.T) {
@@ -3456,3 +3833,225 @@ func TestParseColumnTypeString(t *testing.T) {
 		})
 	}
 }
+
+func TestParseCharsetCollationOptions(t *testing.T) {
+	tests := []struct {
+		options   string
+		collation string
+		err       bool
+	}{
+		{"", "", false},
+		{" ENGINE=InnoDB", "", false},
+		{" DEFAULT CHARACTER SET utf8mb4", sql.CharacterSet_utf8mb4.DefaultCollation().Name, false},
+		{" ENGINE=InnoDB DEFAULT CHARSET=latin1", sql.CharacterSet_latin1.DefaultCollation().Name, false},
+		{" COLLATE utf8mb4_unicode_ci", "utf8mb4_unicode_ci", false},
+		{" CHARACTER SET = utf8mb4 COLLATE = utf8mb4_general_ci", "utf8mb4_general_ci", false},
+		{" CHARSET latin1 COLLATE utf8mb4_bin", "", true},
+		{" COLLATE not_a_collation", "", true},
+		{" comment='the charset is latin1'", "", false},
+		{" (a varchar(10) CHARACTER SET latin1) COLLATE utf8mb4_bin", "utf8mb4_bin", false},
+		{"CREATE DATABASE d3 /* collate utf8mb4_bin */", "", false},
+		{"CREATE DATABASE d2 COMMENT 'charset=latin1'", "", false},
+		{"CREATE DATABASE d1 -- collate utf8mb4_bin\n CHARSET latin1", sql.CharacterSet_latin1.DefaultCollation().Name, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.options, func(t *testing.T) {
+			collation, err := parseCharsetCollationOptions(tt.options)
+			if tt.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.collation, collation.Name)
+		})
+	}
+}
+
+func TestParsePriorityModifiers(t *testing.T) {
+	tests := []struct {
+		query    string
+		priority plan.Priority
+	}{
+		{"INSERT INTO t1 VALUES (1)", plan.PriorityDefault},
+		{"INSERT DELAYED INTO t1 VALUES (1)", plan.PriorityDelayed},
+		{"insert high_priority into t1 values (1)", plan.PriorityHigh},
+		{"INSERT LOW_PRIORITY IGNORE INTO t1 VALUES (1)", plan.PriorityLow},
+		{"REPLACE DELAYED INTO t1 VALUES (1)", plan.PriorityDelayed},
+		{"UPDATE LOW_PRIORITY t1 SET a = 1", plan.PriorityLow},
+		{"DELETE LOW_PRIORITY QUICK FROM t1", plan.PriorityLow},
+		{"DELETE QUICK FROM t1", plan.PriorityDefault},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			node, err := Parse(sql.NewEmptyContext(), tt.query)
+			require.NoError(t, err)
+
+			var priority plan.Priority
+			switch n := node.(type) {
+			case *plan.InsertInto:
+				priority = n.Priority
+			case *plan.Update:
+				priority = n.Priority
+			case *plan.DeleteFrom:
+				priority = n.Priority
+			default:
+				t.Fatalf("unexpected node %T", node)
+			}
+			require.Equal(t, tt.priority, priority)
+		})
+	}
+
+	// The HIGH_PRIORITY modifier of a SELECT is accepted but not recorded
+	node, err := Parse(sql.NewEmptyContext(), "SELECT DISTINCT HIGH_PRIORITY a FROM t1")
+	require.NoError(t, err)
+	require.Equal(t, plan.NewDistinct(plan.NewProject(
+		[]sql.Expression{expression.NewUnresolvedColumn("a")},
+		plan.NewUnresolvedTable("t1", ""),
+	)), node)
+
+	for _, query := range []string{
+		"REPLACE HIGH_PRIORITY INTO t1 VALUES (1)",
+		"INSERT LOW_PRIORITY DELAYED INTO t1 VALUES (1)",
+		"INSERT QUICK INTO t1 VALUES (1)",
+	} {
+		_, err := Parse(sql.NewEmptyContext(), query)
+		require.Error(t, err, query)
+	}
+}
+
+func TestParseLockingClauses(t *testing.T) {
+	tests := []struct {
+		query string
+		lock  sql.RowLock
+	}{
+		{"SELECT a FROM t1 FOR UPDATE", sql.RowLock{Strength: sql.RowLockForUpdate}},
+		{"SELECT a FROM t1 FOR UPDATE SKIP LOCKED", sql.RowLock{Strength: sql.RowLockForUpdate, Wait: sql.RowLockSkipLocked}},
+		{"select a from t1 for update nowait", sql.RowLock{Strength: sql.RowLockForUpdate, Wait: sql.RowLockNoWait}},
+		{"SELECT a FROM t1 FOR UPDATE OF t1, `t2` SKIP LOCKED", sql.RowLock{Strength: sql.RowLockForUpdate, Wait: sql.RowLockSkipLocked}},
+		{"SELECT a FROM t1 FOR SHARE", sql.RowLock{Strength: sql.RowLockForShare}},
+		{"SELECT a FROM t1 FOR SHARE NOWAIT", sql.RowLock{Strength: sql.RowLockForShare, Wait: sql.RowLockNoWait}},
+		{"SELECT a FROM t1 LOCK IN SHARE MODE", sql.RowLock{Strength: sql.RowLockForShare}},
+		{"SELECT a FROM t1 /* it's */ FOR UPDATE SKIP LOCKED", sql.RowLock{Strength: sql.RowLockForUpdate, Wait: sql.RowLockSkipLocked}},
+		{"SELECT a FROM t1 FOR /* it's */ SHARE # it's\n NOWAIT", sql.RowLock{Strength: sql.RowLockForShare, Wait: sql.RowLockNoWait}},
+		{"SELECT a FROM t1 -- for update nowait\n FOR UPDATE", sql.RowLock{Strength: sql.RowLockForUpdate}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			node, err := Parse(sql.NewEmptyContext(), tt.query)
+			require.NoError(t, err)
+			require.Equal(t, plan.NewLockingRead(tt.lock, plan.NewProject(
+				[]sql.Expression{expression.NewUnresolvedColumn("a")},
+				plan.NewUnresolvedTable("t1", ""),
+			)), node)
+		})
+	}
+
+	node, err := Parse(sql.NewEmptyContext(), "SELECT 'for update nowait' AS a FROM t1")
+	require.NoError(t, err)
+	require.Equal(t, plan.NewProject(
+		[]sql.Expression{expression.NewAlias("a", expression.NewLiteral("for update nowait", sql.LongText))},
+		plan.NewUnresolvedTable("t1", ""),
+	), node)
+
+	_, err = Parse(sql.NewEmptyContext(), "SELECT a FROM t1 WHERE a IN (SELECT b FROM t2 FOR UPDATE) FOR UPDATE NOWAIT")
+	require.True(t, ErrUnsupportedFeature.Is(err))
+
+	_, err = Parse(sql.NewEmptyContext(), "SELECT a FROM t1 WHERE a IN (SELECT b FROM t2 FOR SHARE SKIP LOCKED) LOCK IN SHARE MODE")
+	require.True(t, ErrUnsupportedFeature.Is(err))
+}
+
+func TestRewriteComparisonChains(t *testing.T) {
+	tests := []struct {
+		query, expected string
+	}{
+		{"SELECT 1 = 1 = 1", "SELECT (1 = 1) = 1"},
+		{"SELECT a < b < c < d FROM t", "SELECT ((a < b) < c) < d FROM t"},
+		{"SELECT a = 1 = 1 AND b = 2 = 2", "SELECT (a = 1) = 1 AND (b = 2) = 2"},
+		{"SELECT NOT a = b = c", "SELECT NOT (a = b) = c"},
+		{"SELECT a IS NOT NULL = b", "SELECT (a IS NOT NULL) = b"},
+		{"SELECT a = b NOT IN (1, 2)", "SELECT (a = b) NOT IN (1, 2)"},
+		{"SELECT a = b BETWEEN 0 AND 1", "SELECT (a = b) BETWEEN 0 AND 1"},
+		{"SELECT a BETWEEN 1 AND 3 = 1", "SELECT a BETWEEN 1 AND (3 = 1)"},
+		{"SELECT a NOT BETWEEN 1 AND b = c = d, e", "SELECT a NOT BETWEEN 1 AND ((b = c) = d), e"},
+		{"SELECT a BETWEEN 1 AND 3 = 1 IS NULL = b", "SELECT ((a BETWEEN 1 AND (3 = 1)) IS NULL) = b"},
+		{"SELECT f(a = b = c), CASE WHEN x = y = z THEN 1 END = 1 = 1", "SELECT f((a = b) = c), (CASE WHEN (x = y) = z THEN 1 END = 1) = 1"},
+		{"SELECT 'a = b = c' = d, a->>'$.b' = c", "SELECT 'a = b = c' = d, a->>'$.b' = c"},
+		{"UPDATE t SET a = b = c = d, e = f WHERE g = h = i", "UPDATE t SET a = (b = c) = d, e = f WHERE (g = h) = i"},
+		{"SELECT a <=> b != c", "SELECT (a <=> b) != c"},
+		{"SELECT a /* it's */ FROM t WHERE a = 1 = 1", "SELECT a /* it's */ FROM t WHERE (a = 1) = 1"},
+		{"SELECT 1 = 1 = 1 /* it's */ = 1", "SELECT ((1 = 1) = 1) /* it's */ = 1"},
+		{"SELECT 1 = 1 # = 1 = 1\n= 1", "SELECT (1 = 1) # = 1 = 1\n= 1"},
+		{"SELECT @a := 1 = 1 = 1", "SELECT __rewrite_user_var_assignment(@a, (1 = 1) = 1)"},
+		{"UPDATE t SET a := b = c = d", "UPDATE t SET a = (b = c) = d"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			r, err := rewriteQuery(tt.query)
+			require.NoError(t, err)
+			r.rewriteComparisonChains()
+			rewritten, _ := r.apply(true)
+			require.Equal(t, tt.expected, rewritten)
+		})
+	}
+}
+
+func TestParseCharsetIntroducers(t *testing.T) {
+	tests := []struct {
+		query     string
+		value     string
+		collation sql.Collation
+	}{
+		{"SELECT _latin1'abc'", "abc", sql.Collation_latin1_swedish_ci},
+		{"SELECT _LATIN1 'it''s'", "it's", sql.Collation_latin1_swedish_ci},
+		{`SELECT _ascii"abc"`, "abc", sql.Collation_ascii_general_ci},
+		{"SELECT _utf8mb4'abc'", "abc", sql.Collation_utf8mb4_0900_ai_ci},
+		{"SELECT _latin1 X'4142'", "AB", sql.Collation_latin1_swedish_ci},
+		{"SELECT _latin1 0x414", "\x04\x14", sql.Collation_latin1_swedish_ci},
+		{"SELECT _latin1 b'1000001'", "A", sql.Collation_latin1_swedish_ci},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			node, err := Parse(sql.NewEmptyContext(), tt.query)
+			require.NoError(t, err)
+			project, ok := node.(*plan.Project)
+			require.True(t, ok, "unexpected node %T", node)
+			expr := project.Projections[0]
+			if alias, ok := expr.(*expression.Alias); ok {
+				expr = alias.Child
+			}
+			require.Equal(t, expression.NewLiteral(tt.value, sql.CreateLongText(tt.collation)), expr)
+		})
+	}
+
+	node, err := Parse(sql.NewEmptyContext(), "SELECT a, _latin1 'b' FROM t WHERE a = _ascii'x'")
+	require.NoError(t, err)
+	require.Equal(t, plan.NewProject(
+		[]sql.Expression{
+			expression.NewUnresolvedColumn("a"),
+			expression.NewAlias("_latin1 'b'", expression.NewLiteral("b", sql.CreateLongText(sql.Collation_latin1_swedish_ci))),
+		},
+		plan.NewFilter(
+			expression.NewEquals(
+				expression.NewUnresolvedColumn("a"),
+				expression.NewLiteral("x", sql.CreateLongText(sql.Collation_ascii_general_ci)),
+			),
+			plan.NewUnresolvedTable("t", ""),
+		),
+	), node)
+
+	// Separated from a string, an identifier that isn't a character set is a column followed by its alias
+	node, err = Parse(sql.NewEmptyContext(), "SELECT _a 'b' FROM t")
+	require.NoError(t, err)
+	require.Equal(t, plan.NewProject(
+		[]sql.Expression{expression.NewAlias("b", expression.NewUnresolvedColumn("_a"))},
+		plan.NewUnresolvedTable("t", ""),
+	), node)
+
+	_, err = Parse(sql.NewEmptyContext(), "SELECT _foo'abc'")
+	require.True(t, sql.ErrCharacterSetNotSupported.Is(err), "unexpected error %v", err)
+}
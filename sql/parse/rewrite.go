@@ -0,0 +1,1165 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parse
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/dolthub/vitess/go/vt/sqlparser"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+// queryToken is a token of a query, with its value and the positions in the query it starts and ends at.
+type queryToken struct {
+	typ        int
+	val        string
+	start, end int
+}
+
+// assignmentOperator is the token type scanTokens returns for the := operator, which the tokenizer doesn't know.
+const assignmentOperator = -1
+
+// scanTokens returns the tokens of the query given the way the parser reads them, without its comments. Returns false
+// along with the tokens before it if the query has a token the parser doesn't accept.
+func scanTokens(query string) ([]queryToken, bool) {
+	tokenizer := sqlparser.NewStringTokenizer(query)
+	tokenizer.SkipSpecialComments = true
+	var tokens []queryToken
+	end := 0
+	for {
+		typ, val := tokenizer.Scan()
+		switch typ {
+		case 0:
+			return tokens, true
+		case sqlparser.LEX_ERROR:
+			// The tokenizer reads the : of the := operator as the start of a bind variable, and stops at the =
+			if string(val) != ":" || tokenizer.Position > len(query) || query[tokenizer.Position-1] != '=' {
+				return tokens, false
+			}
+			tokenizer.Scan()
+			typ, val = assignmentOperator, []byte(":=")
+		}
+		start := end
+		for start < len(query) && strings.IndexByte(" \t\r\n", query[start]) >= 0 {
+			start++
+		}
+		// The tokenizer has read one character past the token
+		end = tokenizer.Position - 1
+		if typ != sqlparser.COMMENT {
+			tokens = append(tokens, queryToken{typ: typ, val: string(val), start: start, end: end})
+		}
+	}
+}
+
+// tokenizedQuery is a query along with its tokens, as returned by scanTokens.
+type tokenizedQuery struct {
+	query  string
+	tokens []queryToken
+}
+
+// typ returns the type of the token at the index given, or 0 if there is no such token.
+func (q tokenizedQuery) typ(i int) int {
+	if i < 0 || i >= len(q.tokens) {
+		return 0
+	}
+	return q.tokens[i].typ
+}
+
+// text returns the token at the index given as written in the query.
+func (q tokenizedQuery) text(i int) string {
+	return q.query[q.tokens[i].start:q.tokens[i].end]
+}
+
+// isWord returns whether the token at the index given is one of the unquoted keywords or identifiers given.
+func (q tokenizedQuery) isWord(i int, words ...string) bool {
+	if i < 0 || i >= len(q.tokens) {
+		return false
+	}
+	for _, word := range words {
+		if strings.EqualFold(q.text(i), word) {
+			return true
+		}
+	}
+	return false
+}
+
+// isName returns whether the token at the index given can name a table or column: an identifier, quoted or not, or a
+// keyword.
+func (q tokenizedQuery) isName(i int) bool {
+	if i < 0 || i >= len(q.tokens) {
+		return false
+	}
+	c := q.query[q.tokens[i].start]
+	return q.tokens[i].typ == sqlparser.ID || c == '_' || unicode.IsLetter(rune(c))
+}
+
+// isStringLiteral returns whether the token at the index given is a string, hexadecimal or bit literal.
+func (q tokenizedQuery) isStringLiteral(i int) bool {
+	if i < 0 || i >= len(q.tokens) {
+		return false
+	}
+	text := strings.ToLower(q.text(i))
+	switch text[0] {
+	case '\'', '"':
+		return true
+	case 'x', 'b':
+		return len(text) > 1 && text[1] == '\''
+	case '0':
+		return len(text) > 1 && (text[1] == 'x' || text[1] == 'b')
+	default:
+		return false
+	}
+}
+
+// accountEnd returns the index of the token after the account name starting at the index given, as in 'user'@'host',
+// user@host or CURRENT_USER().
+func (q tokenizedQuery) accountEnd(i int) int {
+	_, _, end := q.account(i)
+	return end
+}
+
+// account returns the user and host of the account name starting at the index given, along with the index of the
+// token after it. They're empty for CURRENT_USER, and an account named without a host matches any host.
+func (q tokenizedQuery) account(i int) (user, host string, end int) {
+	if q.isWord(i, "current_user") {
+		if q.typ(i+1) == '(' && q.typ(i+2) == ')' {
+			return "", "", i + 3
+		}
+		return "", "", i + 1
+	}
+	if i >= len(q.tokens) {
+		return "", "", i + 1
+	}
+	// The tokenizer reads an @ as part of an identifier, so an unquoted user and host are a single token, and an @
+	// followed by an unquoted host name, or a lone @, are one too
+	user, host = q.tokens[i].val, "%"
+	if at := strings.IndexByte(user, '@'); at >= 0 && q.tokens[i].typ == sqlparser.ID && q.query[q.tokens[i].start] != '`' {
+		user, host = user[:at], user[at+1:]
+	} else if i+1 < len(q.tokens) && strings.HasPrefix(q.text(i+1), "@") {
+		i++
+		host = q.tokens[i].val[1:]
+	}
+	i++
+	if host == "" && i < len(q.tokens) {
+		host = q.tokens[i].val
+		i++
+	}
+	return user, host, i
+}
+
+// indexTopLevel returns the index of the first token at or after the index given, outside any parentheses, for which
+// the function given returns true. Returns the number of tokens if there is none.
+func (q tokenizedQuery) indexTopLevel(i int, f func(i int) bool) int {
+	depth := 0
+	for ; i < len(q.tokens); i++ {
+		switch q.tokens[i].typ {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		default:
+			if depth == 0 && f(i) {
+				return i
+			}
+		}
+	}
+	return i
+}
+
+// selectInto returns the index of the INTO clause of the SELECT statement starting at the index given, or -1 if it has
+// none. The statement ends at a semicolon outside any parentheses.
+func (q tokenizedQuery) selectInto(i int) int {
+	into := q.indexTopLevel(i, func(j int) bool {
+		return q.tokens[j].typ == ';' || q.tokens[j].typ == sqlparser.INTO
+	})
+	if q.typ(into) != sqlparser.INTO {
+		return -1
+	}
+	return into
+}
+
+// skipParenthesizedTokens returns the index of the token after the parenthesized tokens starting at the index given,
+// or the index given if no parenthesis opens there.
+func skipParenthesizedTokens(tokens []queryToken, i int) int {
+	if i >= len(tokens) || tokens[i].typ != '(' {
+		return i
+	}
+	depth := 0
+	for ; i < len(tokens); i++ {
+		switch tokens[i].typ {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return i + 1
+			}
+		}
+	}
+	return i
+}
+
+// queryRewrite is the rewrite of a query the parser doesn't accept into one it does. Every rewrite reads the tokens of
+// the query, scanned once by scanTokens, so that quoted strings and identifiers, and comments, are left alone the way
+// the parser reads them, and records the parts of the query it replaces, which apply makes all at once. The rewrites
+// also keep what the parser would otherwise lose of the query, for Parse to put back into the node it converts the
+// statement to.
+type queryRewrite struct {
+	tokenizedQuery
+	replacements []replacement
+	// chainReplacements parenthesize the chains of comparisons, which are only rewritten if the parser rejects the
+	// query otherwise
+	chainReplacements []replacement
+
+	priority       plan.Priority
+	viewAttributes sql.ViewAttributes
+	isAlterView    bool
+	checkOption    sql.ViewCheckOption
+	recursiveWiths map[string]bool
+	tupleTargets   map[string]string
+	lockWait       sql.RowLockWait
+	// marker starts the names the rewrites replace parts of the query with, chosen so that the query doesn't contain
+	// it, so users can't type the names. Empty if the query wasn't rewritten.
+	marker string
+}
+
+// replacement is a part of a query, between start and end, replaced with different text. Replacements of an empty
+// part insert text.
+type replacement struct {
+	start, end int
+	text       string
+}
+
+// textEdit is a part of a query replaced with different text: the text between start and end of the rewritten query
+// replaced the text between origStart and origEnd of the original query.
+type textEdit struct {
+	start, end         int
+	origStart, origEnd int
+}
+
+// rewriteQuery returns the rewrite of the query given into one the parser accepts. Queries with a token the parser
+// doesn't accept aren't rewritten, for the parser to report it.
+func rewriteQuery(query string) (*queryRewrite, error) {
+	tokens, ok := scanTokens(query)
+	if !ok {
+		return &queryRewrite{tokenizedQuery: tokenizedQuery{query: query}}, nil
+	}
+
+	r := &queryRewrite{tokenizedQuery: tokenizedQuery{query: query, tokens: tokens}, marker: rewriteMarker(query)}
+	r.rewritePriorityModifiers()
+	if err := r.rewriteViewDefinition(); err != nil {
+		return nil, err
+	}
+	r.rewriteWithRecursive()
+	r.rewriteTupleAssignments()
+	r.rewriteIsUnknown()
+	if err := r.rewriteLockingClauses(); err != nil {
+		return nil, err
+	}
+	r.rewriteUserVarAssignments()
+	r.rewriteSelectInto()
+	r.rewriteCastTypes()
+	r.rewriteEmptySeparators()
+	if err := r.rewriteIntroducers(); err != nil {
+		return nil, err
+	}
+	if err := r.checkDistinctWindowFrames(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// rewriteMarker returns the marker of the names the rewrites of the query given use: the first of __rewrite_,
+// __rewrite1_, __rewrite2_ and so on the query doesn't contain, ignoring case.
+func rewriteMarker(query string) string {
+	lower := strings.ToLower(query)
+	marker := "__rewrite_"
+	for i := 1; strings.Contains(lower, marker); i++ {
+		marker = fmt.Sprintf("__rewrite%d_", i)
+	}
+	return marker
+}
+
+// rewriteMarkerKey is the key of the marker of the rewritten query in the context of its conversion.
+type rewriteMarkerKey struct{}
+
+// withRewriteMarker returns the context given carrying the marker of the rewrite given, so that ExprToExpression tells
+// the names the rewrites made apart from the ones users typed.
+func withRewriteMarker(ctx *sql.Context, r *queryRewrite) *sql.Context {
+	if ctx == nil || r.marker == "" {
+		return ctx
+	}
+	parent := ctx.Context
+	if parent == nil {
+		parent = context.Background()
+	}
+	return ctx.WithContext(context.WithValue(parent, rewriteMarkerKey{}, r.marker))
+}
+
+// rewrittenName returns the name given without the marker of the query converted in the context given, and whether it
+// starts with the marker, so that a rewrite of the query made it.
+func rewrittenName(ctx *sql.Context, name string) (string, bool) {
+	if ctx == nil || ctx.Context == nil {
+		return "", false
+	}
+	marker, ok := ctx.Value(rewriteMarkerKey{}).(string)
+	if !ok || marker == "" || len(name) < len(marker) || !strings.EqualFold(name[:len(marker)], marker) {
+		return "", false
+	}
+	return name[len(marker):], true
+}
+
+// isRewrittenName returns whether the name given is the name given to the rewrites, made by a rewrite of the query
+// converted in the context given.
+func isRewrittenName(ctx *sql.Context, name, rewriteName string) bool {
+	n, ok := rewrittenName(ctx, name)
+	return ok && strings.EqualFold(n, rewriteName)
+}
+
+// replace records the replacement of the text between the positions given with the text given.
+func (r *queryRewrite) replace(start, end int, text string) {
+	r.replacements = append(r.replacements, replacement{start: start, end: end, text: text})
+}
+
+// removeTokens records the removal of the tokens from the index start up to the index end.
+func (r *queryRewrite) removeTokens(start, end int) {
+	r.replace(r.tokens[start].start, r.tokens[end-1].end, "")
+}
+
+// apply returns the rewritten query, with its chains of comparisons parenthesized if withChains is true, along with
+// the edits made to the query.
+func (r *queryRewrite) apply(withChains bool) (string, []textEdit) {
+	replacements := r.replacements
+	if withChains {
+		replacements = append(append([]replacement(nil), r.chainReplacements...), r.replacements...)
+	}
+	if len(replacements) == 0 {
+		return r.query, nil
+	}
+
+	// Text inserted at the start of a replaced part comes before the replacement. Text inserted at the same position
+	// keeps the order it was recorded in, with the parentheses of chains first, as they are the innermost.
+	sort.SliceStable(replacements, func(i, j int) bool {
+		if replacements[i].start != replacements[j].start {
+			return replacements[i].start < replacements[j].start
+		}
+		return replacements[i].start == replacements[i].end && replacements[j].start != replacements[j].end
+	})
+
+	var sb strings.Builder
+	var edits []textEdit
+	last := 0
+	for _, rep := range replacements {
+		// Replacements only overlap in queries the parser rejects anyway, so those overlapping another are dropped
+		if rep.start < last {
+			continue
+		}
+		sb.WriteString(r.query[last:rep.start])
+		edits = append(edits, textEdit{start: sb.Len(), end: sb.Len() + len(rep.text), origStart: rep.start, origEnd: rep.end})
+		sb.WriteString(rep.text)
+		last = rep.end
+	}
+	sb.WriteString(r.query[last:])
+	return sb.String(), edits
+}
+
+// parse parses the rewritten query with the parse function given. Chains of comparisons are only parenthesized if the
+// parser rejects the query otherwise, and it must accept the result, otherwise its original error is returned. The
+// verbatim text of the select expressions and the positions of the sub statements of the statement returned are those
+// of the original query.
+func (r *queryRewrite) parse(parse func(string) (sqlparser.Statement, error)) (sqlparser.Statement, error) {
+	rewritten, edits := r.apply(false)
+	stmt, err := parse(rewritten)
+	if err != nil {
+		r.rewriteComparisonChains()
+		if len(r.chainReplacements) == 0 {
+			return nil, err
+		}
+		var chainErr error
+		rewritten, edits = r.apply(true)
+		stmt, chainErr = parse(rewritten)
+		if chainErr != nil {
+			return nil, err
+		}
+	}
+
+	if len(edits) > 0 {
+		restoreInputExpressions(stmt, r.query, rewritten, edits)
+	}
+	if r.lockWait != sql.RowLockWaitDefault {
+		setLockWait(stmt, r.lockWait)
+	}
+	return stmt, nil
+}
+
+// parseStrictDDL parses the DDL statement given with strict parsing, rewritten the way Parse rewrites queries. Returns
+// the context given carrying the marker of the rewrite as well, to convert the statement in.
+func parseStrictDDL(ctx *sql.Context, query string) (*sql.Context, sqlparser.Statement, error) {
+	r, err := rewriteQuery(query)
+	if err != nil {
+		return nil, nil, err
+	}
+	stmt, err := r.parse(sqlparser.ParseStrictDDL)
+	if err != nil {
+		return nil, nil, err
+	}
+	return withRewriteMarker(ctx, r), stmt, nil
+}
+
+// restoreInputExpressions restores the verbatim text of the select expressions of the statement given, parsed from the
+// rewritten query given, to their text in the original query. The positions of the sub statements of DDL statements
+// are translated to positions in the original query as well.
+func restoreInputExpressions(stmt sqlparser.Statement, original, rewritten string, edits []textEdit) {
+	_ = sqlparser.Walk(func(node sqlparser.SQLNode) (bool, error) {
+		switch n := node.(type) {
+		case *sqlparser.DDL:
+			if n.SubStatementPositionEnd > 0 {
+				n.SubStatementPositionStart = originalPosition(n.SubStatementPositionStart, edits, false)
+				n.SubStatementPositionEnd = originalPosition(n.SubStatementPositionEnd, edits, true)
+			}
+		case *sqlparser.AliasedExpr:
+			if n.InputExpression == "" || n.EndParsePos > len(rewritten) || n.StartParsePos >= n.EndParsePos {
+				return true, nil
+			}
+			start, end := originalPosition(n.StartParsePos, edits, false), originalPosition(n.EndParsePos, edits, true)
+			if end > len(original) || start > end {
+				return true, nil
+			}
+			if text := original[start:end]; text != rewritten[n.StartParsePos:n.EndParsePos] {
+				n.InputExpression = strings.TrimLeft(text, " \n\t")
+			}
+		}
+		return true, nil
+	}, stmt)
+}
+
+// originalPosition returns the position in the original query of the position given of the query rewritten with the
+// edits given. Positions in the middle of an edit are moved to its start. So are positions at the start of an edit
+// that end a part of the query, if isEnd is true, so that the part doesn't take in the text the edit removed.
+func originalPosition(pos int, edits []textEdit, isEnd bool) int {
+	offset := 0
+	for _, e := range edits {
+		if pos < e.end || isEnd && pos == e.start {
+			if pos > e.start {
+				return e.origStart
+			}
+			break
+		}
+		offset = e.origEnd - e.end
+	}
+	return pos + offset
+}
+
+// rewritePriorityModifiers removes the scheduling modifiers the parser doesn't accept, as in INSERT DELAYED or DELETE
+// LOW_PRIORITY QUICK, and keeps the priority they stand for. Modifiers are only removed when valid for the statement,
+// so that others are still reported as syntax errors. The HIGH_PRIORITY modifier of a SELECT is removed too, but not
+// kept, as explained by plan.Priority.
+func (r *queryRewrite) rewritePriorityModifiers() {
+	if r.isWord(0, "select") {
+		i := 1
+		if r.isWord(i, "all", "distinct", "distinctrow") {
+			i++
+		}
+		if r.isWord(i, "high_priority") {
+			r.removeTokens(i, i+1)
+		}
+		return
+	}
+	if !r.isWord(0, "insert", "replace", "update", "delete") {
+		return
+	}
+
+	statement := strings.ToLower(r.text(0))
+	priority := plan.PriorityDefault
+	i := 1
+	for ; r.isWord(i, "low_priority", "high_priority", "delayed", "quick"); i++ {
+		switch modifier := strings.ToLower(r.text(i)); {
+		case modifier == "low_priority" && priority == plan.PriorityDefault:
+			priority = plan.PriorityLow
+		case modifier == "high_priority" && statement == "insert" && priority == plan.PriorityDefault:
+			priority = plan.PriorityHigh
+		case modifier == "delayed" && (statement == "insert" || statement == "replace") && priority == plan.PriorityDefault:
+			priority = plan.PriorityDelayed
+		case modifier == "quick" && statement == "delete":
+		default:
+			return
+		}
+	}
+	if i > 1 {
+		r.removeTokens(1, i)
+		r.priority = priority
+	}
+}
+
+// rewriteViewDefinition rewrites a CREATE VIEW or ALTER VIEW statement into a CREATE VIEW statement the parser
+// accepts, without its ALGORITHM, DEFINER and SQL SECURITY clauses, its column list and its WITH CHECK OPTION clause.
+// Keeps the clauses removed and whether the statement was ALTER VIEW.
+func (r *queryRewrite) rewriteViewDefinition() error {
+	i, isAlter := 0, false
+	switch {
+	case r.isWord(0, "create"):
+		i = 1
+		if r.isWord(1, "or") && r.isWord(2, "replace") {
+			i = 3
+		}
+	case r.isWord(0, "alter"):
+		i, isAlter = 1, true
+	default:
+		return nil
+	}
+
+	clauses := i
+	for !r.isWord(i, "view") {
+		switch {
+		case r.isWord(i, "algorithm") && r.typ(i+1) == '=':
+			i += 3
+		case r.isWord(i, "definer") && r.typ(i+1) == '=':
+			i = r.accountEnd(i + 2)
+		case r.isWord(i, "sql") && r.isWord(i+1, "security"):
+			i += 3
+		default:
+			return nil
+		}
+	}
+	view := i
+
+	// The name of the view may be qualified with the name of its database
+	i += 2
+	if r.typ(i) == '.' {
+		i += 2
+	}
+	columns := i
+	i = skipParenthesizedTokens(r.tokens, i)
+	if !r.isWord(i, "as") {
+		return nil
+	}
+	r.rewriteViewCheckOption()
+	if !isAlter && view == clauses && columns == i {
+		return nil
+	}
+
+	var definition string
+	if view > clauses {
+		definition = r.query[r.tokens[clauses].start:r.tokens[view-1].end] + " "
+	}
+	if i > columns {
+		definition += r.query[r.tokens[columns].start:r.tokens[i-1].end] + " "
+	}
+	attributes, rest := sql.SplitViewAttributes(definition + "as ")
+	if rest != "" {
+		return sql.ErrSyntaxError.New(fmt.Sprintf("invalid view definition near '%s'", strings.TrimSpace(definition)))
+	}
+
+	if isAlter {
+		r.replace(r.tokens[0].start, r.tokens[0].end, "create")
+	}
+	if view > clauses {
+		r.removeTokens(clauses, view)
+	}
+	if i > columns {
+		r.removeTokens(columns, i)
+	}
+	r.viewAttributes, r.isAlterView = attributes, isAlter
+	return nil
+}
+
+// rewriteViewCheckOption removes the WITH CHECK OPTION clause ending a view definition, and keeps the check option the
+// clause declares. A clause without LOCAL or CASCADED is CASCADED.
+func (r *queryRewrite) rewriteViewCheckOption() {
+	last := len(r.tokens) - 1
+	if !r.isWord(last, "option") || !r.isWord(last-1, "check") {
+		return
+	}
+	with, option := last-2, sql.ViewCheckOption_Cascaded
+	if r.isWord(with, "local") {
+		with, option = with-1, sql.ViewCheckOption_Local
+	} else if r.isWord(with, "cascaded") {
+		with--
+	}
+	if !r.isWord(with, "with") || with == 0 {
+		return
+	}
+
+	r.replace(r.tokens[with-1].end, r.tokens[last].end, "")
+	r.checkOption = option
+}
+
+// rewriteWithRecursive removes the RECURSIVE keyword of each WITH RECURSIVE clause, which the parser doesn't accept, and
+// keeps the keys of those clauses, as returned by withKey for the names of their common table expressions.
+func (r *queryRewrite) rewriteWithRecursive() {
+	for i := 0; i+1 < len(r.tokens); i++ {
+		if r.tokens[i].typ != sqlparser.WITH || !r.isWord(i+1, "recursive") {
+			continue
+		}
+		r.removeTokens(i+1, i+2)
+
+		// Each common table expression is a name, optionally followed by a parenthesized column list, then AS and its
+		// parenthesized query
+		var names []string
+		j := i + 2
+		for j < len(r.tokens) {
+			names = append(names, r.tokens[j].val)
+			j = skipParenthesizedTokens(r.tokens, j+1)
+			if r.typ(j) == sqlparser.AS {
+				j = skipParenthesizedTokens(r.tokens, j+1)
+			}
+			if r.typ(j) != ',' {
+				break
+			}
+			j++
+		}
+
+		if r.recursiveWiths == nil {
+			r.recursiveWiths = make(map[string]bool)
+		}
+		r.recursiveWiths[withKey(names)] = true
+	}
+}
+
+// rewriteTupleAssignments replaces the tuples of columns assigned to in the SET clause of an UPDATE statement, as in
+// SET (a, b) = (SELECT x, y FROM s), with placeholder columns the parser accepts, and keeps the text of the columns each
+// placeholder stands for, keyed by its name. A ROW constructor assigned to such a tuple becomes a plain tuple.
+func (r *queryRewrite) rewriteTupleAssignments() {
+	if !r.isWord(0, "update") {
+		return
+	}
+
+	var replacements []replacement
+	targets := make(map[string]string)
+	i := r.indexTopLevel(1, func(i int) bool {
+		return r.isWord(i, "set")
+	}) + 1
+	for i < len(r.tokens) {
+		if r.typ(i) == '(' {
+			end := skipParenthesizedTokens(r.tokens, i)
+			if r.typ(end) != '=' {
+				return
+			}
+
+			name := fmt.Sprintf("<%d>", len(targets))
+			targets[name] = r.query[r.tokens[i].end:r.tokens[end-1].start]
+			replacements = append(replacements, replacement{start: r.tokens[i].start, end: r.tokens[end-1].end, text: "`" + name + "`"})
+
+			i = end + 1
+			if r.isWord(i, "row") && r.typ(i+1) == '(' {
+				replacements = append(replacements, replacement{start: r.tokens[i].start, end: r.tokens[i].end})
+			}
+		}
+
+		i = r.indexTopLevel(i, func(i int) bool {
+			return r.typ(i) == ',' || r.isWord(i, "where", "order", "limit")
+		})
+		if r.typ(i) != ',' {
+			break
+		}
+		i++
+	}
+
+	if len(targets) > 0 {
+		r.replacements = append(r.replacements, replacements...)
+		r.tupleTargets = targets
+	}
+}
+
+// rewriteIsUnknown replaces the IS [NOT] UNKNOWN predicates, which the parser doesn't accept, with the equivalent
+// IS [NOT] NULL.
+func (r *queryRewrite) rewriteIsUnknown() {
+	for i := 0; i < len(r.tokens); i++ {
+		if r.tokens[i].typ != sqlparser.IS {
+			continue
+		}
+		if r.typ(i+1) == sqlparser.NOT {
+			i++
+		}
+		if r.isWord(i+1, "unknown") {
+			r.replace(r.tokens[i+1].start, r.tokens[i+1].end, "NULL")
+		}
+	}
+}
+
+// rewriteLockingClauses rewrites the locking clauses into ones the parser accepts, which only knows FOR UPDATE and
+// LOCK IN SHARE MODE: FOR SHARE is replaced with LOCK IN SHARE MODE, and the OF table list and the NOWAIT and SKIP
+// LOCKED modifiers are removed. Keeps the modifier removed, which applies to every locking clause of the statement, so
+// they must all have the same one.
+func (r *queryRewrite) rewriteLockingClauses() error {
+	wait, waitSet := sql.RowLockWaitDefault, false
+	setWait := func(clauseWait sql.RowLockWait) error {
+		if waitSet && clauseWait != wait {
+			return ErrUnsupportedFeature.New("locking clauses with different NOWAIT or SKIP LOCKED modifiers")
+		}
+		wait, waitSet = clauseWait, true
+		return nil
+	}
+
+	for i := 0; i < len(r.tokens); i++ {
+		if r.isWord(i, "lock") && r.isWord(i+1, "in") && r.isWord(i+2, "share") && r.isWord(i+3, "mode") {
+			// The clause has no modifiers, so it always waits for locks
+			if err := setWait(sql.RowLockWaitDefault); err != nil {
+				return err
+			}
+			i += 3
+			continue
+		}
+		if !r.isWord(i, "for") || !r.isWord(i+1, "update", "share") {
+			continue
+		}
+
+		replacement := "for update"
+		if r.isWord(i+1, "share") {
+			replacement = "lock in share mode"
+		}
+		end := i + 2
+		if r.isWord(end, "of") {
+			end++
+			for r.isName(end) {
+				end++
+				for r.typ(end) == '.' && r.isName(end+1) {
+					end += 2
+				}
+				if r.typ(end) != ',' || !r.isName(end+1) {
+					break
+				}
+				end++
+			}
+		}
+
+		clauseWait := sql.RowLockWaitDefault
+		switch {
+		case r.isWord(end, "nowait"):
+			clauseWait, end = sql.RowLockNoWait, end+1
+		case r.isWord(end, "skip") && r.isWord(end+1, "locked"):
+			clauseWait, end = sql.RowLockSkipLocked, end+2
+		}
+		if err := setWait(clauseWait); err != nil {
+			return err
+		}
+
+		start, clauseEnd := r.tokens[i].start, r.tokens[end-1].end
+		if !strings.EqualFold(r.query[start:clauseEnd], replacement) {
+			r.replace(start, clauseEnd, replacement)
+		}
+		i = end - 1
+	}
+
+	r.lockWait = wait
+	return nil
+}
+
+// userVarAssignmentFunction is the name, after the marker of the query, of the function rewriteUserVarAssignments
+// replaces the := operator with, as in __rewrite_user_var_assignment(@v, @v + 1). ExprToExpression turns calls to it
+// back into the operator.
+const userVarAssignmentFunction = "user_var_assignment"
+
+// userVarAssignmentKeywords are the words that can follow an operand in the value of a := operator without ending it,
+// mapped to whether they are an operand themselves.
+var userVarAssignmentKeywords = map[string]bool{
+	"and": false, "or": false, "xor": false, "not": false, "is": false, "like": false, "between": false, "in": false,
+	"div": false, "mod": false, "regexp": false, "rlike": false, "collate": false, "escape": false, "sounds": false,
+	"interval": false, "binary": false,
+	"microsecond": true, "second": true, "minute": true, "hour": true, "day": true, "week": true, "month": true,
+	"quarter": true, "year": true, "second_microsecond": true, "minute_microsecond": true, "minute_second": true,
+	"hour_microsecond": true, "hour_second": true, "hour_minute": true, "day_microsecond": true, "day_second": true,
+	"day_minute": true, "day_hour": true, "year_month": true,
+}
+
+// rewriteUserVarAssignments replaces the := operators, which the parser doesn't accept, as in @v := @v + 1. The
+// operators assigning the variables of a SET statement are replaced with =, and the others with a call to
+// userVarAssignmentFunction.
+func (r *queryRewrite) rewriteUserVarAssignments() {
+	depth := 0
+	// The depth of the list of assignments of the current SET statement, and whether a variable to assign comes next
+	setDepth, isSetTarget := -1, false
+	for i := 0; i < len(r.tokens); i++ {
+		switch typ := r.tokens[i].typ; {
+		case typ == '(':
+			depth++
+		case typ == ')':
+			depth--
+		case typ == ';':
+			setDepth, isSetTarget = -1, false
+		case typ == ',' && depth == setDepth:
+			isSetTarget = true
+		case typ == '=':
+			isSetTarget = false
+		case typ == sqlparser.SET:
+			setDepth, isSetTarget = depth, true
+		case typ == assignmentOperator && isSetTarget:
+			r.replace(r.tokens[i].start, r.tokens[i].end, "=")
+			isSetTarget = false
+		case typ == assignmentOperator && i > 0:
+			variable := r.text(i - 1)
+			if !strings.HasPrefix(variable, "@") || strings.HasPrefix(variable, "@@") || variable == "@" {
+				continue
+			}
+			valueEnd := r.tokens[r.assignmentValueEnd(i+1)-1].end
+			r.replace(r.tokens[i-1].start, r.tokens[i].end, r.marker+userVarAssignmentFunction+"("+variable+",")
+			r.replace(valueEnd, valueEnd, ")")
+		}
+	}
+}
+
+// assignmentValueEnd returns the index of the token after the value of the := operator beginning at the index given.
+// The operator has the lowest precedence, so the value ends at the end of the select expression, or of the
+// parenthesized expression, it's in. A word following an operand ends it too, as in the alias of @v := 1 AS x.
+func (r *queryRewrite) assignmentValueEnd(i int) int {
+	depth, caseDepth := 0, 0
+	isOperand := false
+	end := i
+	for ; i < len(r.tokens); i++ {
+		text := r.text(i)
+		switch c := text[0]; {
+		case c == '\'' || c == '"' || c == '`':
+			isOperand = true
+		case c == '(':
+			depth++
+			isOperand = false
+		case c == ')':
+			if depth == 0 {
+				return end
+			}
+			depth--
+			isOperand = true
+		case (c == ',' || c == ';') && depth == 0:
+			return end
+		case c == '_' || c == '$' || c == '@' || unicode.IsLetter(rune(c)) || unicode.IsDigit(rune(c)):
+			word := strings.ToLower(text)
+			operand, isKeyword := userVarAssignmentKeywords[word]
+			switch {
+			case word == "case":
+				caseDepth++
+				operand = false
+			case word == "end" && caseDepth > 0:
+				caseDepth--
+				operand = true
+			case caseDepth > 0 && (word == "when" || word == "then" || word == "else"):
+				operand = false
+			case depth == 0 && isOperand && !isKeyword:
+				return end
+			case !isKeyword:
+				operand = true
+			}
+			isOperand = operand
+		default:
+			isOperand = false
+		}
+		end = i + 1
+	}
+	return end
+}
+
+// selectIntoFunction is the name, after the marker of the query, of the function rewriteSelectInto replaces the INTO
+// clause of a SELECT statement with, as in SELECT COUNT(*), __rewrite_into(n) FROM t. convertSelect turns a call to it
+// back into the clause.
+const selectIntoFunction = "into"
+
+// rewriteSelectInto replaces the INTO clauses of the SELECT statements naming variables, as in
+// SELECT COUNT(*) INTO n FROM t, which the parser doesn't accept, with a call to selectIntoFunction of the variables
+// after the select expressions.
+func (r *queryRewrite) rewriteSelectInto() {
+	for i := range r.tokens {
+		if r.tokens[i].typ != sqlparser.SELECT || i > 0 && !isStatementStart(r.tokens[i-1]) {
+			continue
+		}
+		into := r.selectInto(i)
+		if into == -1 || r.isWord(into+1, "outfile", "dumpfile") {
+			continue
+		}
+
+		var vars []string
+		end := into
+		for len(vars) == 0 || r.typ(end) == ',' {
+			if !r.isName(end + 1) {
+				vars = nil
+				break
+			}
+			vars = append(vars, r.text(end+1))
+			end += 2
+		}
+		if vars == nil {
+			continue
+		}
+
+		call := ", " + r.marker + selectIntoFunction + "(" + strings.Join(vars, ", ") + ")"
+		from := r.indexTopLevel(i, func(j int) bool { return r.tokens[j].typ == sqlparser.FROM || j == into })
+		if from < into {
+			r.replace(r.tokens[from].start, r.tokens[from].start, call+" ")
+			r.removeTokens(into, end)
+		} else {
+			r.replace(r.tokens[into].start, r.tokens[end-1].end, call)
+		}
+	}
+}
+
+// castTypes are the types of conversions the parser doesn't accept, which rewriteCastTypes replaces with a conversion
+// to CHAR naming the type after the marker of the query as its character set. ExprToExpression turns such conversions
+// back into conversions to the type.
+var castTypes = map[string]bool{
+	expression.ConvertToDouble: true,
+	expression.ConvertToFloat:  true,
+	expression.ConvertToReal:   true,
+	expression.ConvertToYear:   true,
+}
+
+// rewriteCastTypes replaces the YEAR, DOUBLE [PRECISION], REAL and FLOAT[(p)] types of the CAST(x AS T) and
+// CONVERT(x, T) expressions, which the parser doesn't accept, with a conversion to CHAR naming the type after the
+// marker of the query as its character set, as in CAST(x AS char __rewrite_year). The precision of FLOAT is kept as the
+// length of the CHAR.
+func (r *queryRewrite) rewriteCastTypes() {
+	// Whether each of the parentheses open at the current token is the one of a CAST or CONVERT
+	var casts []bool
+	for i := 0; i < len(r.tokens); i++ {
+		switch r.tokens[i].typ {
+		case '(':
+			casts = append(casts, r.isWord(i-1, "cast", "convert"))
+			continue
+		case ')':
+			if len(casts) > 0 {
+				casts = casts[:len(casts)-1]
+			}
+			continue
+		}
+
+		castTo := strings.ToLower(r.text(i))
+		if len(casts) == 0 || !casts[len(casts)-1] || !castTypes[castTo] || r.typ(i-1) != ',' && !r.isWord(i-1, "as") {
+			continue
+		}
+
+		end, length := i+1, ""
+		switch {
+		case castTo == expression.ConvertToDouble && r.isWord(end, "precision"):
+			end++
+		case castTo == expression.ConvertToFloat && r.typ(end) == '(':
+			lengthEnd := skipParenthesizedTokens(r.tokens, end)
+			if lengthEnd == len(r.tokens) {
+				continue
+			}
+			length = r.query[r.tokens[end].start:r.tokens[lengthEnd-1].end]
+			end = lengthEnd
+		}
+		if r.typ(end) != ')' {
+			continue
+		}
+
+		r.replace(r.tokens[i].start, r.tokens[end-1].end, "char"+length+" "+r.marker+castTo)
+		i = end - 1
+	}
+}
+
+// emptySeparator is the name, after the marker of the query, of the separator rewriteEmptySeparators replaces the
+// empty SEPARATOR of a GROUP_CONCAT with, as the parser doesn't tell an empty separator from a missing one.
+// ExprToExpression turns it back into the empty string.
+const emptySeparator = "empty_separator"
+
+// rewriteEmptySeparators replaces the empty separators of the GROUP_CONCAT calls with emptySeparator.
+func (r *queryRewrite) rewriteEmptySeparators() {
+	for i := 1; i < len(r.tokens); i++ {
+		if r.tokens[i].typ == sqlparser.STRING && r.tokens[i].val == "" && r.tokens[i-1].typ == sqlparser.SEPARATOR {
+			r.replace(r.tokens[i].start, r.tokens[i].end, "'"+r.marker+emptySeparator+"'")
+		}
+	}
+}
+
+// rewriteIntroducers replaces the character set introducers of the string literals, as in _latin1'abc', with a
+// COLLATE clause naming the character set after the marker of the query, as in 'abc' collate __rewrite_latin1, since
+// the parser only accepts the _binary and _utf8mb4 introducers. ExprToExpression turns such clauses back into literals
+// of the introduced character set. Returns an error if an introducer names an unknown character set.
+func (r *queryRewrite) rewriteIntroducers() error {
+	for i := 0; i+1 < len(r.tokens); i++ {
+		introducer := r.text(i)
+		if r.tokens[i].typ != sqlparser.ID || len(introducer) < 2 || introducer[0] != '_' || r.typ(i-1) == '.' || !r.isStringLiteral(i+1) {
+			continue
+		}
+
+		name := strings.ToLower(introducer[1:])
+		if _, err := sql.ParseCharacterSet(name); err != nil {
+			// Separated from the string, this is a column followed by its alias
+			if r.tokens[i+1].start > r.tokens[i].end {
+				continue
+			}
+			return err
+		}
+
+		r.replace(r.tokens[i].start, r.tokens[i+1].end, r.text(i+1)+" collate "+r.marker+name)
+		i++
+	}
+	return nil
+}
+
+// checkDistinctWindowFrames returns sql.ErrWindowDistinctFrame if an aggregate function with DISTINCT is called over a
+// window with a ROWS or RANGE frame, as in COUNT(DISTINCT a) OVER (ORDER BY b ROWS UNBOUNDED PRECEDING). The parser
+// doesn't accept frames, so such calls would otherwise fail with a syntax error instead of the error the same call over
+// an ordered window fails with.
+func (r *queryRewrite) checkDistinctWindowFrames() error {
+	for i := 1; i+1 < len(r.tokens); i++ {
+		if !r.isWord(i, "over") || r.typ(i-1) != ')' || r.typ(i+1) != '(' {
+			continue
+		}
+
+		// The arguments of the function start with DISTINCT
+		open, depth := i-1, 0
+		for ; open >= 0; open-- {
+			if r.typ(open) == ')' {
+				depth++
+			} else if r.typ(open) == '(' {
+				depth--
+				if depth == 0 {
+					break
+				}
+			}
+		}
+		if open < 0 || r.typ(open+1) != sqlparser.DISTINCT {
+			continue
+		}
+
+		end := skipParenthesizedTokens(r.tokens, i+1)
+		frame := r.indexTopLevel(i+2, func(j int) bool { return r.isWord(j, "rows", "range") })
+		if frame < end-1 {
+			return sql.ErrWindowDistinctFrame.New()
+		}
+	}
+	return nil
+}
+
+// comparisonOperators are the tokens of the operators of the precedence level of comparisons, which the parser doesn't
+// accept a chain of, as in 1 = 1 = 1. BETWEEN is lower, but only accepts a comparison to its left inside parentheses
+// too.
+var comparisonOperators = map[int]bool{
+	'=': true, '<': true, '>': true, sqlparser.LE: true, sqlparser.GE: true, sqlparser.NE: true,
+	sqlparser.NULL_SAFE_EQUAL: true, sqlparser.IS: true, sqlparser.LIKE: true, sqlparser.REGEXP: true,
+	sqlparser.IN: true, sqlparser.BETWEEN: true,
+}
+
+// comparisonChainBoundaries are the tokens an operand of a comparison never extends past.
+var comparisonChainBoundaries = map[int]bool{
+	',': true, ';': true, sqlparser.SELECT: true, sqlparser.FROM: true, sqlparser.WHERE: true, sqlparser.HAVING: true,
+	sqlparser.ON: true, sqlparser.AND: true, sqlparser.OR: true, sqlparser.NOT: true, sqlparser.WHEN: true,
+	sqlparser.THEN: true, sqlparser.ELSE: true, sqlparser.SET: true, sqlparser.UPDATE: true, sqlparser.BY: true,
+	sqlparser.LIMIT: true, sqlparser.VALUES: true, sqlparser.INTO: true, sqlparser.AS: true, sqlparser.DISTINCT: true,
+	assignmentOperator: true,
+}
+
+// rewriteComparisonChains parenthesizes the chains of comparison operators, which are left associative, so that
+// a = b = c becomes (a = b) = c, which the parser accepts. The upper bound of BETWEEN takes in the comparisons
+// following it, as in a BETWEEN b AND (c = d). The assignments of SET and ON DUPLICATE KEY UPDATE lists aren't
+// comparisons, and end the operand to their left.
+func (r *queryRewrite) rewriteComparisonChains() {
+	tokens := r.tokens
+	type chain struct {
+		// start is the token the operand to the left of the chain begins at, and ops the number of operators seen since
+		start, ops int
+		// isBetween is whether the next AND is the one of a BETWEEN of the chain, and boundOf the start of the chain of
+		// the BETWEEN if this is the chain of its upper bound, or -1
+		isBetween bool
+		boundOf   int
+	}
+	var opens, closes []int
+	chains := []chain{{boundOf: -1}}
+	cases := 0
+	// The depth of the current list of assignments, and whether the column or variable to assign comes next
+	assignDepth, isAssignTarget := -1, false
+
+	parenthesize := func(start, end int) {
+		opens = append(opens, tokens[start].start)
+		closes = append(closes, tokens[end-1].end)
+	}
+	// endChain ends the current chain before the token i
+	endChain := func(i int) {
+		if c := chains[len(chains)-1]; c.boundOf >= 0 && c.ops > 0 {
+			parenthesize(c.start, i)
+		}
+	}
+	boundary := func(i int) {
+		endChain(i)
+		chains[len(chains)-1] = chain{start: i + 1, boundOf: -1}
+	}
+
+	for i := 0; i < len(tokens); i++ {
+		typ := tokens[i].typ
+		next := r.typ(i + 1)
+		c := &chains[len(chains)-1]
+		switch {
+		case typ == '(':
+			chains = append(chains, chain{start: i + 1, boundOf: -1})
+		case typ == ')':
+			endChain(i)
+			if len(chains) > 1 {
+				chains = chains[:len(chains)-1]
+			}
+			if len(chains) < assignDepth {
+				assignDepth, isAssignTarget = -1, false
+			}
+		case typ == sqlparser.CASE:
+			// A CASE expression is an operand, whose own operands are delimited like parenthesized ones
+			chains = append(chains, chain{start: i + 1, boundOf: -1})
+			cases++
+		case typ == sqlparser.END && cases > 0:
+			endChain(i)
+			if len(chains) > 1 {
+				chains = chains[:len(chains)-1]
+			}
+			cases--
+		case (typ == '=' || typ == assignmentOperator) && isAssignTarget && len(chains) == assignDepth:
+			isAssignTarget = false
+			boundary(i)
+		case typ == sqlparser.AND && c.isBetween:
+			*c = chain{start: i + 1, boundOf: c.start}
+		case comparisonOperators[typ] || typ == sqlparser.NOT && comparisonOperators[next] && next != sqlparser.IS:
+			if typ == sqlparser.IS && c.boundOf >= 0 {
+				// IS is lower than BETWEEN, so it ends its upper bound
+				endChain(i)
+				*c = chain{start: c.boundOf, ops: 1, boundOf: -1}
+			}
+			if c.ops > 0 {
+				parenthesize(c.start, i)
+			}
+			c.ops++
+			if typ == sqlparser.NOT || typ == sqlparser.IS && next == sqlparser.NOT {
+				i++
+			}
+			c.isBetween = tokens[i].typ == sqlparser.BETWEEN
+		case comparisonChainBoundaries[typ]:
+			switch typ {
+			case ';':
+				assignDepth, isAssignTarget = -1, false
+			case ',':
+				if len(chains) == assignDepth {
+					isAssignTarget = true
+				}
+			case sqlparser.SET, sqlparser.UPDATE:
+				assignDepth, isAssignTarget = len(chains), true
+			case sqlparser.AND, sqlparser.OR, sqlparser.NOT, assignmentOperator:
+			default:
+				assignDepth, isAssignTarget = -1, false
+			}
+			boundary(i)
+		}
+	}
+	endChain(len(tokens))
+
+	r.chainReplacements = nil
+	for _, pos := range opens {
+		r.chainReplacements = append(r.chainReplacements, replacement{start: pos, end: pos, text: "("})
+	}
+	for _, pos := range closes {
+		r.chainReplacements = append(r.chainReplacements, replacement{start: pos, end: pos, text: ")"})
+	}
+}
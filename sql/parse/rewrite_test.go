@@ -0,0 +1,120 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parse
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRewriteQuery(t *testing.T) {
+	tests := []struct {
+		query, expected string
+	}{
+		{"SELECT HIGH_PRIORITY a FROM t", "SELECT  a FROM t"},
+		{"INSERT LOW_PRIORITY INTO t VALUES (1)", "INSERT  INTO t VALUES (1)"},
+		{"INSERT QUICK INTO t VALUES (1)", "INSERT QUICK INTO t VALUES (1)"},
+		{
+			"ALTER ALGORITHM = MERGE DEFINER = 'root'@'localhost' VIEW v (a, `b``c`) AS SELECT 1, 2 WITH LOCAL CHECK OPTION",
+			"create  VIEW v  AS SELECT 1, 2",
+		},
+		{"CREATE VIEW v AS SELECT 'WITH CHECK OPTION' -- WITH CHECK OPTION", "CREATE VIEW v AS SELECT 'WITH CHECK OPTION' -- WITH CHECK OPTION"},
+		{"WITH RECURSIVE r AS (SELECT 1) SELECT * FROM r", "WITH  r AS (SELECT 1) SELECT * FROM r"},
+		{"UPDATE t SET (a, b) = ROW(1, 2), c = '(d, e) = (1, 2)'", "UPDATE t SET `<0>` = (1, 2), c = '(d, e) = (1, 2)'"},
+		{"SELECT a IS NOT UNKNOWN, 'is unknown' FROM t", "SELECT a IS NOT NULL, 'is unknown' FROM t"},
+		{"SELECT a FROM t FOR SHARE OF t, s NOWAIT", "SELECT a FROM t lock in share mode"},
+		{"SELECT a FROM t /* FOR SHARE */ FOR UPDATE", "SELECT a FROM t /* FOR SHARE */ FOR UPDATE"},
+		{"SELECT @a := @a + 1 AS x, ':=' FROM t", "SELECT __rewrite_user_var_assignment(@a, @a + 1) AS x, ':=' FROM t"},
+		{"SELECT @a:=(SELECT 1)", "SELECT __rewrite_user_var_assignment(@a,(SELECT 1))"},
+		{"SET @a := 1, @b := ':='", "SET @a = 1, @b = ':='"},
+		{"SELECT COUNT(*) INTO n FROM t", "SELECT COUNT(*) , __rewrite_into(n) FROM t"},
+		{"SELECT a FROM t INTO @a, b", "SELECT a , __rewrite_into(@a, b) FROM t "},
+		{"INSERT INTO t SELECT a FROM s", "INSERT INTO t SELECT a FROM s"},
+		{"SELECT CAST(a AS FLOAT(10)), CONVERT(b, YEAR), 'CAST(a AS YEAR)'", "SELECT CAST(a AS char(10) __rewrite_float), CONVERT(b, char __rewrite_year), 'CAST(a AS YEAR)'"},
+		{"SELECT GROUP_CONCAT(a SEPARATOR '') FROM t", "SELECT GROUP_CONCAT(a SEPARATOR '__rewrite_empty_separator') FROM t"},
+		{"SELECT GROUP_CONCAT(a SEPARATOR ''), '__REWRITE_' FROM t", "SELECT GROUP_CONCAT(a SEPARATOR '__rewrite1_empty_separator'), '__REWRITE_' FROM t"},
+		{"SELECT _latin1'abc' /* _latin1'abc' */", "SELECT 'abc' collate __rewrite_latin1 /* _latin1'abc' */"},
+		{"SELECT 'WITH RECURSIVE', `for share` FROM t # IS UNKNOWN", "SELECT 'WITH RECURSIVE', `for share` FROM t # IS UNKNOWN"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			r, err := rewriteQuery(tt.query)
+			require.NoError(t, err)
+			rewritten, edits := r.apply(false)
+			require.Equal(t, tt.expected, rewritten)
+			requireRoundTrip(t, tt.query, rewritten, edits)
+		})
+	}
+}
+
+func TestRewriteQueryRandom(t *testing.T) {
+	fragments := []string{
+		"SELECT", "HIGH_PRIORITY", "INSERT", "DELAYED", "INTO", "UPDATE", "SET", "DELETE", "QUICK", "CREATE", "ALTER",
+		"ALGORITHM = MERGE", "DEFINER = root@localhost", "VIEW", "v", "(a, b)", "AS", "WITH", "RECURSIVE", "CHECK",
+		"OPTION", "LOCAL", "FROM", "t", "WHERE", "a", "b", "IS", "NOT", "UNKNOWN", "FOR", "SHARE", "OF", "NOWAIT", "SKIP",
+		"LOCKED", "LOCK", "IN", "MODE", "@a", ":=", "=", "<", "BETWEEN", "AND", "CAST(", "CONVERT(", "YEAR", "FLOAT(2)",
+		"DOUBLE PRECISION", ")", "(", ",", "GROUP_CONCAT(", "SEPARATOR", "''", "_latin1", "'x'", "X'41'", "ROW(",
+		"'it''s'", `'a\'b'`, `"q:=d"`, "`q``d`", "/* c */", "/* 'c */", "# c\n", "-- c\n", "/*! STRAIGHT_JOIN */",
+		"CASE", "WHEN", "THEN", "END", "1", "2.5", ";",
+	}
+
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 20000; i++ {
+		words := make([]string, 1+rnd.Intn(16))
+		for j := range words {
+			words[j] = fragments[rnd.Intn(len(fragments))]
+		}
+		query := strings.Join(words, " ")
+
+		r, err := rewriteQuery(query)
+		if err != nil {
+			continue
+		}
+		r.rewriteComparisonChains()
+		rewritten, edits := r.apply(true)
+		requireRoundTrip(t, query, rewritten, edits)
+	}
+}
+
+// requireRoundTrip requires the query given to be rewritten into the one given with the edits given: the text between
+// the edits is unchanged, undoing the edits gives back the original query, and no edit starts or ends inside a token,
+// so that quoted strings and identifiers, and comments, are left alone.
+func requireRoundTrip(t *testing.T, query, rewritten string, edits []textEdit) {
+	tokens, _ := scanTokens(query)
+	boundaries := map[int]bool{}
+	for _, token := range tokens {
+		boundaries[token.start] = true
+		boundaries[token.end] = true
+	}
+
+	var sb strings.Builder
+	last, origLast := 0, 0
+	for _, e := range edits {
+		require.True(t, boundaries[e.origStart] && boundaries[e.origEnd], "edit of %q inside a token of %q", query[e.origStart:e.origEnd], query)
+		require.Equal(t, query[origLast:e.origStart], rewritten[last:e.start], query)
+		sb.WriteString(rewritten[last:e.start])
+		sb.WriteString(query[e.origStart:e.origEnd])
+		last, origLast = e.end, e.origEnd
+	}
+	sb.WriteString(rewritten[last:])
+	require.Equal(t, query, sb.String())
+	if len(edits) == 0 {
+		require.Equal(t, query, rewritten)
+	}
+}
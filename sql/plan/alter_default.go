@@ -102,9 +102,12 @@ func (d *AlterDefaultSet) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, e
 	if col == nil {
 		return nil, sql.ErrTableColumnNotFound.New(d.Child.String(), d.ColumnName)
 	}
-	newCol := &(*col)
+	if col.AutoIncrement {
+		return nil, sql.ErrInvalidAutoIncrementDefault.New(col.Name)
+	}
+	newCol := *col
 	newCol.Default = d.Default
-	return sql.RowsToRowIter(), alterable.ModifyColumn(ctx, d.ColumnName, newCol, nil)
+	return sql.RowsToRowIter(), alterable.ModifyColumn(ctx, d.ColumnName, &newCol, nil)
 }
 
 // WithChildren implements the sql.Node interface.
@@ -170,9 +173,9 @@ func (d *AlterDefaultDrop) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter,
 	if col == nil {
 		return nil, sql.ErrTableColumnNotFound.New(d.Child.String(), d.ColumnName)
 	}
-	newCol := &(*col)
+	newCol := *col
 	newCol.Default = nil
-	return sql.RowsToRowIter(), alterable.ModifyColumn(ctx, d.ColumnName, newCol, nil)
+	return sql.RowsToRowIter(), alterable.ModifyColumn(ctx, d.ColumnName, &newCol, nil)
 }
 
 // WithChildren implements the sql.Node interface.
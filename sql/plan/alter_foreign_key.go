@@ -179,9 +179,28 @@ func executeCreateForeignKey(ctx *sql.Context, fkAlterable sql.ForeignKeyAlterab
 		}
 	}
 
+	// And that every column can reference the column it's paired with
+	for i := range fkDef.Columns {
+		colType := columnTypeInsensitive(fkAlterable.Schema(), fkDef.Columns[i])
+		refColType := columnTypeInsensitive(refTbl.Schema(), fkDef.ReferencedColumns[i])
+		if !sql.ForeignKeyColumnTypesCompatible(colType, refColType) {
+			return sql.ErrForeignKeyColumnTypeMismatch.New(fkDef.Columns[i], fkDef.ReferencedColumns[i], fkDef.Name)
+		}
+	}
+
 	return fkAlterable.CreateForeignKey(ctx, fkDef.Name, fkDef.Columns, fkDef.ReferencedTable, fkDef.ReferencedColumns, fkDef.OnUpdate, fkDef.OnDelete)
 }
 
+// columnTypeInsensitive returns the type of the column of the schema given with the name given, ignoring case.
+func columnTypeInsensitive(sch sql.Schema, name string) sql.Type {
+	for _, col := range sch {
+		if strings.EqualFold(col.Name, name) {
+			return col.Type
+		}
+	}
+	return nil
+}
+
 // WithDatabase implements the sql.Databaser interface.
 func (p *CreateForeignKey) WithDatabase(db sql.Database) (sql.Node, error) {
 	np := *p
@@ -26,6 +26,7 @@ type RenameTable struct {
 	ddlNode
 	oldNames []string
 	newNames []string
+	Catalog  sql.Catalog
 }
 
 var _ sql.Node = (*RenameTable)(nil)
@@ -46,36 +47,155 @@ func (r *RenameTable) WithDatabase(db sql.Database) (sql.Node, error) {
 	return &nr, nil
 }
 
+// NewNames returns the names the tables are renamed to.
+func (r *RenameTable) NewNames() []string {
+	return r.newNames
+}
+
+// WithNewNames returns a copy of this node renaming the tables to the names given.
+func (r *RenameTable) WithNewNames(newNames []string) *RenameTable {
+	nr := *r
+	nr.newNames = newNames
+	return &nr
+}
+
 func (r *RenameTable) String() string {
 	return fmt.Sprintf("Rename table %s to %s", r.oldNames, r.newNames)
 }
 
+// RowIter implements the sql.Node interface. The renames are applied in order, so a later pair can rename a table
+// given its name by an earlier one. Either all the tables are renamed or none are. Foreign keys referencing the renamed
+// tables are updated to reference their new names.
 func (r *RenameTable) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
 	renamer, ok := r.db.(sql.TableRenamer)
 	if !ok {
 		return nil, ErrRenameTableNotSupported.New(r.db.Name())
 	}
 
-	var err error
-	for i, oldName := range r.oldNames {
-		var tbl sql.Table
-		var ok bool
-		tbl, ok, err = r.db.GetTableInsensitive(ctx, oldName)
+	oldNames, err := r.validateRenames(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, oldName := range oldNames {
+		err = renamer.RenameTable(ctx, oldName, r.newNames[i])
 		if err != nil {
+			// Undo the renames already done, so that no table ends up renamed
+			for j := i - 1; j >= 0; j-- {
+				_ = renamer.RenameTable(ctx, r.newNames[j], oldNames[j])
+			}
 			return nil, err
 		}
+	}
 
+	err = r.updateForeignKeyReferences(ctx, oldNames)
+	if err != nil {
+		return nil, err
+	}
+
+	return sql.RowsToRowIter(), nil
+}
+
+// validateRenames checks that every table renamed exists, and that no table is renamed to the name of an existing
+// table, taking into account the renames before it. Returns the names of the tables renamed as they are stored in the
+// database.
+func (r *RenameTable) validateRenames(ctx *sql.Context) ([]string, error) {
+	tableNames, err := r.db.GetTableNames(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	lowerCaseTableNames := sql.GetLowerCaseTableNames(r.Catalog)
+	tables := make(map[string]string, len(tableNames))
+	for _, name := range tableNames {
+		tables[lowerCaseTableNames.Key(name)] = name
+	}
+
+	oldNames := make([]string, len(r.oldNames))
+	for i, oldName := range r.oldNames {
+		oldKey, newKey := lowerCaseTableNames.Key(oldName), lowerCaseTableNames.Key(r.newNames[i])
+
+		var ok bool
+		oldNames[i], ok = tables[oldKey]
 		if !ok {
 			return nil, sql.ErrTableNotFound.New(oldName)
 		}
+		if _, ok := tables[newKey]; ok && newKey != oldKey {
+			return nil, sql.ErrTableAlreadyExists.New(r.newNames[i])
+		}
+
+		delete(tables, oldKey)
+		tables[newKey] = r.newNames[i]
+	}
+
+	return oldNames, nil
+}
+
+// updateForeignKeyReferences updates the foreign keys in the database that reference renamed tables to reference the
+// tables' new names.
+func (r *RenameTable) updateForeignKeyReferences(ctx *sql.Context, oldNames []string) error {
+	// Maps the original name of each renamed table to its final name
+	lowerCaseTableNames := sql.GetLowerCaseTableNames(r.Catalog)
+	renamed := make(map[string]string)
+	for i, oldName := range oldNames {
+		original := lowerCaseTableNames.Key(oldName)
+		for name, newName := range renamed {
+			if lowerCaseTableNames.Equal(oldName, newName) {
+				original = name
+				break
+			}
+		}
+		renamed[original] = r.newNames[i]
+	}
 
-		err = renamer.RenameTable(ctx, tbl.Name(), r.newNames[i])
+	tableNames, err := r.db.GetTableNames(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, tableName := range tableNames {
+		tbl, ok, err := r.db.GetTableInsensitive(ctx, tableName)
 		if err != nil {
-			break
+			return err
+		}
+		if !ok {
+			continue
+		}
+
+		fkTable, ok := tbl.(sql.ForeignKeyTable)
+		if !ok {
+			continue
+		}
+		fkAlterable, ok := tbl.(sql.ForeignKeyAlterableTable)
+		if !ok {
+			continue
+		}
+
+		fks, err := fkTable.GetForeignKeys(ctx)
+		if err != nil {
+			return err
+		}
+
+		// Dropping foreign keys may modify the slice returned by the table
+		fks = append([]sql.ForeignKeyConstraint(nil), fks...)
+		for _, fk := range fks {
+			newName, ok := renamed[lowerCaseTableNames.Key(fk.ReferencedTable)]
+			if !ok || newName == fk.ReferencedTable {
+				continue
+			}
+
+			err = fkAlterable.DropForeignKey(ctx, fk.Name)
+			if err != nil {
+				return err
+			}
+			err = fkAlterable.CreateForeignKey(ctx, fk.Name, fk.Columns, newName, fk.ReferencedColumns, fk.OnUpdate, fk.OnDelete)
+			if err != nil {
+				return err
+			}
 		}
 	}
 
-	return sql.RowsToRowIter(), err
+	return nil
 }
 
 func (r *RenameTable) WithChildren(children ...sql.Node) (sql.Node, error) {
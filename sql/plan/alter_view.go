@@ -0,0 +1,109 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plan
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// AlterView is a node representing the replacement of the definition of an existing view, which is defined by the
+// Child node.
+type AlterView struct {
+	UnaryNode
+	database   sql.Database
+	Name       string
+	Definition *SubqueryAlias
+}
+
+// NewAlterView creates an AlterView node with the specified parameters.
+func NewAlterView(database sql.Database, name string, definition *SubqueryAlias) *AlterView {
+	return &AlterView{
+		UnaryNode:  UnaryNode{Child: definition},
+		database:   database,
+		Name:       name,
+		Definition: definition,
+	}
+}
+
+// View returns the view that will replace the existing one.
+func (av *AlterView) View() *sql.View {
+	return av.Definition.AsView()
+}
+
+// Resolved implements the Node interface. This node is resolved if and only if the database and the Child are both
+// resolved.
+func (av *AlterView) Resolved() bool {
+	_, ok := av.database.(sql.UnresolvedDatabase)
+	return !ok && av.Child.Resolved()
+}
+
+// RowIter implements the Node interface. When executed, this function replaces the definition of the view. It errors
+// if the view doesn't exist. The RowIter returned is always empty.
+func (av *AlterView) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
+	if vdb, ok := av.database.(sql.ViewDatabase); ok {
+		_, exists, err := vdb.GetView(ctx, av.Name)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, sql.ErrViewDoesNotExist.New(av.database.Name(), av.Name)
+		}
+
+		if err := vdb.DropView(ctx, av.Name); err != nil {
+			return nil, err
+		}
+		return sql.RowsToRowIter(), vdb.CreateView(ctx, av.Name, av.Definition.TextDefinition)
+	}
+
+	registry := ctx.GetViewRegistry()
+	if err := registry.Delete(av.database.Name(), av.Name); err != nil {
+		return nil, err
+	}
+	return sql.RowsToRowIter(), registry.Register(av.database.Name(), av.View())
+}
+
+// Schema implements the Node interface. It always returns nil.
+func (av *AlterView) Schema() sql.Schema { return nil }
+
+// String implements the fmt.Stringer interface, using sql.TreePrinter to generate the string.
+func (av *AlterView) String() string {
+	pr := sql.NewTreePrinter()
+	_ = pr.WriteNode("AlterView(%s)", av.Name)
+	_ = pr.WriteChildren(av.Child.String())
+	return pr.String()
+}
+
+// WithChildren implements the Node interface. It only succeeds if the length of the specified children equals 1.
+func (av *AlterView) WithChildren(children ...sql.Node) (sql.Node, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(av, len(children), 1)
+	}
+
+	newAlter := *av
+	newAlter.Child = children[0]
+	return &newAlter, nil
+}
+
+// Database implements the Databaser interface, and it returns the database in which AlterView will replace the view.
+func (av *AlterView) Database() sql.Database {
+	return av.database
+}
+
+// WithDatabase implements the Databaser interface, and it returns a copy of this node with the specified database.
+func (av *AlterView) WithDatabase(database sql.Database) (sql.Node, error) {
+	newAlter := *av
+	newAlter.database = database
+	return &newAlter, nil
+}
@@ -0,0 +1,85 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plan
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/memory"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+func newAlterView(db sql.Database) *AlterView {
+	subqueryAlias := NewSubqueryAlias("myview", "select i + 1",
+		NewProject(
+			[]sql.Expression{
+				expression.NewArithmetic(
+					expression.NewGetFieldWithTable(1, sql.Int32, "mytable", "i", true),
+					expression.NewLiteral(1, sql.Int8),
+					"+",
+				),
+			},
+			NewUnresolvedTable("dual", ""),
+		),
+	)
+	return NewAlterView(db, subqueryAlias.Name(), subqueryAlias)
+}
+
+// Tests that AlterView replaces the definition of a view stored in the database
+func TestAlterViewNative(t *testing.T) {
+	db := memory.NewDatabase("mydb")
+	ctx := sql.NewContext(context.Background())
+	_, err := newCreateView(db, false).RowIter(ctx, nil)
+	require.NoError(t, err)
+
+	alterView := newAlterView(db)
+	_, err = alterView.RowIter(ctx, nil)
+	require.NoError(t, err)
+
+	view, ok, err := db.GetView(ctx, alterView.Name)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, alterView.Definition.TextDefinition, view)
+}
+
+// Tests that AlterView replaces the definition of a view in the registry
+func TestAlterViewWithRegistry(t *testing.T) {
+	db := memory.NewViewlessDatabase("mydb")
+	ctx := sql.NewContext(context.Background())
+	_, err := newCreateView(db, false).RowIter(ctx, nil)
+	require.NoError(t, err)
+
+	alterView := newAlterView(db)
+	_, err = alterView.RowIter(ctx, nil)
+	require.NoError(t, err)
+
+	view, err := ctx.GetViewRegistry().View(db.Name(), alterView.Name)
+	require.NoError(t, err)
+	require.Equal(t, alterView.View(), view)
+}
+
+// Tests that AlterView RowIter returns an error when the view doesn't exist
+func TestAlterMissingView(t *testing.T) {
+	for _, db := range []sql.Database{memory.NewDatabase("mydb"), memory.NewViewlessDatabase("mydb")} {
+		ctx := sql.NewContext(context.Background())
+		_, err := newAlterView(db).RowIter(ctx, nil)
+		require.Error(t, err)
+		require.True(t, sql.ErrViewDoesNotExist.Is(err))
+	}
+}
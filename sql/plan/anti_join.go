@@ -0,0 +1,273 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plan
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+// AntiJoin is an anti-semi-join: it returns the rows of its left child for which no row of its right child satisfies
+// the condition of the join, as a decorrelated NOT EXISTS does. The condition is evaluated on the left row followed by
+// the right row, and a right row only excludes a left row when the condition is true for them, so that a condition
+// that evaluates to NULL, such as a comparison with a NULL column, never excludes a row. This is unlike NOT IN, for
+// which a NULL on the right side makes the result NULL for every row, and which is evaluated as an expression instead.
+//
+// The right child is read once, and its rows are hashed by the RightKeys expressions, which are evaluated on the right
+// row alone. A left row is only checked against the right rows with the same hash of the LeftKeys expressions, which
+// are evaluated on the left row alone. Right rows with a NULL key can't satisfy the condition and are dropped, and a
+// left row with a NULL key is always returned. The keys must be pairs of expressions of the same type whose equality is
+// part of the condition, and whose equal values hash the same, such as integers. The condition is still evaluated for
+// every candidate. Without keys, every left row is checked against every right row.
+type AntiJoin struct {
+	BinaryNode
+	LeftKeys  []sql.Expression
+	RightKeys []sql.Expression
+	Cond      sql.Expression
+}
+
+var _ sql.Node = (*AntiJoin)(nil)
+var _ sql.Expressioner = (*AntiJoin)(nil)
+
+// NewAntiJoin creates a new AntiJoin node. The keys given must be of the same length.
+func NewAntiJoin(left, right sql.Node, leftKeys, rightKeys []sql.Expression, cond sql.Expression) *AntiJoin {
+	return &AntiJoin{
+		BinaryNode: BinaryNode{left: left, right: right},
+		LeftKeys:   leftKeys,
+		RightKeys:  rightKeys,
+		Cond:       cond,
+	}
+}
+
+// Schema implements the Node interface.
+func (j *AntiJoin) Schema() sql.Schema {
+	return j.left.Schema()
+}
+
+// Resolved implements the Resolvable interface.
+func (j *AntiJoin) Resolved() bool {
+	return j.left.Resolved() && j.right.Resolved() && expression.ExpressionsResolved(j.Expressions()...)
+}
+
+// Expressions implements the Expressioner interface.
+func (j *AntiJoin) Expressions() []sql.Expression {
+	exprs := make([]sql.Expression, 0, len(j.LeftKeys)+len(j.RightKeys)+1)
+	exprs = append(exprs, j.LeftKeys...)
+	exprs = append(exprs, j.RightKeys...)
+	return append(exprs, j.Cond)
+}
+
+// WithExpressions implements the Expressioner interface.
+func (j *AntiJoin) WithExpressions(exprs ...sql.Expression) (sql.Node, error) {
+	expected := len(j.LeftKeys) + len(j.RightKeys) + 1
+	if len(exprs) != expected {
+		return nil, sql.ErrInvalidChildrenNumber.New(j, len(exprs), expected)
+	}
+
+	keys := len(j.LeftKeys)
+	return NewAntiJoin(j.left, j.right, exprs[:keys], exprs[keys:2*keys], exprs[2*keys]), nil
+}
+
+// WithChildren implements the Node interface.
+func (j *AntiJoin) WithChildren(children ...sql.Node) (sql.Node, error) {
+	if len(children) != 2 {
+		return nil, sql.ErrInvalidChildrenNumber.New(j, len(children), 2)
+	}
+	return NewAntiJoin(children[0], children[1], j.LeftKeys, j.RightKeys, j.Cond), nil
+}
+
+// RowIter implements the Node interface.
+func (j *AntiJoin) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
+	span, ctx := ctx.Span("plan.AntiJoin")
+
+	l, err := j.left.RowIter(ctx, row)
+	if err != nil {
+		span.Finish()
+		return nil, err
+	}
+
+	return sql.NewSpanIter(span, &antiJoinIter{
+		j:     j,
+		ctx:   ctx,
+		row:   row,
+		left:  l,
+		right: j.right,
+	}), nil
+}
+
+func (j *AntiJoin) String() string {
+	pr := sql.NewTreePrinter()
+	_ = pr.WriteNode("AntiJoin%s", j.describe(func(e interface{}) string { return fmt.Sprint(e) }))
+	_ = pr.WriteChildren(j.left.String(), j.right.String())
+	return pr.String()
+}
+
+func (j *AntiJoin) DebugString() string {
+	pr := sql.NewTreePrinter()
+	_ = pr.WriteNode("AntiJoin%s", j.describe(sql.DebugString))
+	_ = pr.WriteChildren(sql.DebugString(j.left), sql.DebugString(j.right))
+	return pr.String()
+}
+
+// describe returns the condition and keys of the join, formatted with the function given.
+func (j *AntiJoin) describe(format func(interface{}) string) string {
+	if len(j.LeftKeys) == 0 {
+		return fmt.Sprintf("(%s)", format(j.Cond))
+	}
+
+	keys := func(exprs []sql.Expression) string {
+		strs := make([]string, len(exprs))
+		for i, e := range exprs {
+			strs[i] = format(e)
+		}
+		return strings.Join(strs, ", ")
+	}
+	return fmt.Sprintf("(%s, left keys: (%s), right keys: (%s))", format(j.Cond), keys(j.LeftKeys), keys(j.RightKeys))
+}
+
+type antiJoinIter struct {
+	j     *AntiJoin
+	ctx   *sql.Context
+	row   sql.Row
+	left  sql.RowIter
+	right sql.Node
+
+	// rows are the rows of the right child, by the hash of their keys
+	rows    map[uint64][]sql.Row
+	dispose sql.DisposeFunc
+}
+
+func (i *antiJoinIter) Next() (sql.Row, error) {
+	if i.rows == nil {
+		if err := i.loadRight(); err != nil {
+			return nil, err
+		}
+	}
+
+	for {
+		row, err := i.left.Next()
+		if err != nil {
+			return nil, err
+		}
+
+		excluded, err := i.excludes(row)
+		if err != nil {
+			return nil, err
+		}
+		if !excluded {
+			return row, nil
+		}
+	}
+}
+
+// loadRight reads all the rows of the right child and hashes them by their keys.
+func (i *antiJoinIter) loadRight() error {
+	iter, err := i.right.RowIter(i.ctx, i.row)
+	if err != nil {
+		return err
+	}
+
+	cache, dispose := i.ctx.Memory.NewRowsCache()
+	i.dispose = dispose
+	i.rows = make(map[uint64][]sql.Row)
+	for n := 0; ; n++ {
+		if err := sql.CheckCancellation(i.ctx, n); err != nil {
+			iter.Close(i.ctx)
+			return err
+		}
+		row, err := iter.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			iter.Close(i.ctx)
+			return err
+		}
+
+		hash, ok, err := hashKeys(i.ctx, i.j.RightKeys, row)
+		if err != nil {
+			iter.Close(i.ctx)
+			return err
+		}
+		if !ok {
+			continue
+		}
+
+		if err := cache.Add(row); err != nil {
+			iter.Close(i.ctx)
+			return err
+		}
+		i.rows[hash] = append(i.rows[hash], row)
+	}
+
+	return iter.Close(i.ctx)
+}
+
+// excludes returns whether any right row satisfies the condition of the join with the left row given.
+func (i *antiJoinIter) excludes(row sql.Row) (bool, error) {
+	hash, ok, err := hashKeys(i.ctx, i.j.LeftKeys, row)
+	if err != nil || !ok {
+		return false, err
+	}
+
+	for _, right := range i.rows[hash] {
+		res, err := sql.EvaluateCondition(i.ctx, i.j.Cond, append(row.Copy(), right...))
+		if err != nil {
+			return false, err
+		}
+		if sql.IsTrue(res) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// hashKeys returns the hash of the keys given evaluated on the row given, and false if any of them is NULL.
+func hashKeys(ctx *sql.Context, keys []sql.Expression, row sql.Row) (uint64, bool, error) {
+	if len(keys) == 0 {
+		return 0, true, nil
+	}
+
+	vals := make(sql.Row, len(keys))
+	for i, k := range keys {
+		v, err := k.Eval(ctx, row)
+		if err != nil {
+			return 0, false, err
+		}
+		if v == nil {
+			return 0, false, nil
+		}
+		// The keys of both sides have the same type, but their values aren't always of the same Go type
+		if vals[i], err = k.Type().Convert(v); err != nil {
+			return 0, false, err
+		}
+	}
+
+	hash, err := sql.HashOf(vals)
+	return hash, err == nil, err
+}
+
+func (i *antiJoinIter) Close(ctx *sql.Context) error {
+	if i.dispose != nil {
+		i.dispose()
+		i.dispose = nil
+	}
+	i.rows = nil
+	return i.left.Close(ctx)
+}
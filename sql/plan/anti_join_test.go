@@ -0,0 +1,100 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plan
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/memory"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+func TestAntiJoin(t *testing.T) {
+	left := memory.NewTable("left", sql.NewPrimaryKeySchema(sql.Schema{
+		{Name: "a", Type: sql.Int64, Source: "left", Nullable: true},
+		{Name: "b", Type: sql.Int64, Source: "left", Nullable: true},
+	}))
+	right := memory.NewTable("right", sql.NewPrimaryKeySchema(sql.Schema{
+		{Name: "x", Type: sql.Int64, Source: "right", Nullable: true},
+		{Name: "y", Type: sql.Int64, Source: "right", Nullable: true},
+	}))
+
+	ctx := sql.NewEmptyContext()
+	for _, r := range []sql.Row{{int64(1), int64(1)}, {int64(2), int64(2)}, {int64(3), nil}, {nil, int64(4)}} {
+		require.NoError(t, left.Insert(ctx, r))
+	}
+	for _, r := range []sql.Row{{int64(1), int64(10)}, {nil, int64(20)}, {int64(3), nil}} {
+		require.NoError(t, right.Insert(ctx, r))
+	}
+
+	a := expression.NewGetFieldWithTable(0, sql.Int64, "left", "a", true)
+	b := expression.NewGetFieldWithTable(1, sql.Int64, "left", "b", true)
+	x := expression.NewGetFieldWithTable(2, sql.Int64, "right", "x", true)
+	y := expression.NewGetFieldWithTable(3, sql.Int64, "right", "y", true)
+	rightX := expression.NewGetFieldWithTable(0, sql.Int64, "right", "x", true)
+
+	testCases := []struct {
+		name      string
+		leftKeys  []sql.Expression
+		rightKeys []sql.Expression
+		cond      sql.Expression
+		expected  []sql.Row
+	}{
+		{
+			name:      "hashed equality",
+			leftKeys:  []sql.Expression{a},
+			rightKeys: []sql.Expression{rightX},
+			cond:      expression.NewEquals(x, a),
+			expected:  []sql.Row{{int64(2), int64(2)}, {nil, int64(4)}},
+		},
+		{
+			name:     "equality without keys",
+			cond:     expression.NewEquals(x, a),
+			expected: []sql.Row{{int64(2), int64(2)}, {nil, int64(4)}},
+		},
+		{
+			name:      "hashed equality and a condition that is NULL",
+			leftKeys:  []sql.Expression{a},
+			rightKeys: []sql.Expression{rightX},
+			cond:      expression.NewAnd(expression.NewEquals(x, a), expression.NewGreaterThan(y, b)),
+			expected:  []sql.Row{{int64(2), int64(2)}, {int64(3), nil}, {nil, int64(4)}},
+		},
+		{
+			name:     "condition matching NULL columns",
+			cond:     expression.NewOr(expression.NewEquals(y, expression.NewMult(b, expression.NewLiteral(int64(10), sql.Int64))), expression.NewEquals(x, a)),
+			expected: []sql.Row{{nil, int64(4)}},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			j := NewAntiJoin(
+				NewResolvedTable(left, nil, nil),
+				NewResolvedTable(right, nil, nil),
+				tt.leftKeys,
+				tt.rightKeys,
+				tt.cond,
+			)
+			require.Equal(t, left.Schema(), j.Schema())
+
+			rows, err := sql.NodeToRows(ctx, j)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, rows)
+		})
+	}
+}
@@ -23,7 +23,8 @@ import (
 // Block represents a collection of statements that should be executed in sequence.
 type Block struct {
 	statements []sql.Node
-	rowIterSch sql.Schema // This is set during RowIter, as the schema is unknown until iterating over the statements.
+	rowIterSch sql.Schema            // This is set during RowIter, as the schema is unknown until iterating over the statements.
+	txn        *procedureTransaction // This is set by the calling procedure, and is nil outside of procedures.
 }
 
 var _ sql.Node = (*Block)(nil)
@@ -96,6 +97,9 @@ func (b *Block) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
 			defer disposeFunc()
 
 			var isSelect bool
+			if err := b.txn.beginStatement(ctx); err != nil {
+				return err
+			}
 			subIter, err := s.RowIter(ctx, row)
 			if err != nil {
 				return err
@@ -122,6 +126,9 @@ func (b *Block) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
 					if err != nil {
 						return err
 					}
+					if err := b.txn.endStatement(ctx); err != nil {
+						return err
+					}
 					if isSelect || !selectSeen {
 						returnRows = rowCache.Get()
 					}
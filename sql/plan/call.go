@@ -26,10 +26,12 @@ type Call struct {
 	Params []sql.Expression
 	proc   *Procedure
 	pRef   *expression.ProcedureParamReference
+	db     sql.Database
 }
 
 var _ sql.Node = (*Call)(nil)
 var _ sql.Expressioner = (*Call)(nil)
+var _ sql.Databaser = (*Call)(nil)
 
 // NewCall returns a *Call node.
 func NewCall(name string, params []sql.Expression) *Call {
@@ -90,6 +92,11 @@ func (c *Call) WithProcedure(proc *Procedure) *Call {
 	return &nc
 }
 
+// Procedure returns the procedure called, or nil if it hasn't been set.
+func (c *Call) Procedure() *Procedure {
+	return c.proc
+}
+
 // HasProcedure returns whether a *Call has had its procedure set.
 func (c *Call) HasProcedure() bool {
 	return c.proc != nil
@@ -102,6 +109,19 @@ func (c *Call) WithParamReference(pRef *expression.ProcedureParamReference) *Cal
 	return &nc
 }
 
+// Database implements the sql.Databaser interface. The database of a call is the one whose transaction is threaded
+// through the statements of the procedure.
+func (c *Call) Database() sql.Database {
+	return c.db
+}
+
+// WithDatabase implements the sql.Databaser interface.
+func (c *Call) WithDatabase(db sql.Database) (sql.Node, error) {
+	nc := *c
+	nc.db = db
+	return &nc, nil
+}
+
 // String implements the sql.Node interface.
 func (c *Call) String() string {
 	paramStr := ""
@@ -128,8 +148,15 @@ func (c *Call) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
 			return nil, err
 		}
 	}
+
+	txn := newProcedureTransaction(c.db)
+	txn.threadThrough(c.proc)
+
 	innerIter, err := c.proc.RowIter(ctx, row)
 	if err != nil {
+		if rerr := txn.rollbackStatement(ctx); rerr != nil {
+			return nil, rerr
+		}
 		return nil, err
 	}
 	return &callIter{
@@ -16,6 +16,7 @@ package plan
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/dolthub/vitess/go/mysql"
 	"github.com/dolthub/vitess/go/vt/sqlparser"
@@ -28,6 +29,8 @@ type CreateDB struct {
 	Catalog     sql.Catalog
 	dbName      string
 	IfNotExists bool
+	// Collation is the default collation of the new database. The zero value means none was specified.
+	Collation sql.Collation
 }
 
 func (c CreateDB) Resolved() bool {
@@ -39,7 +42,11 @@ func (c CreateDB) String() string {
 	if c.IfNotExists {
 		ifNotExists = " if not exists"
 	}
-	return fmt.Sprintf("%s database%s %v", sqlparser.CreateStr, ifNotExists, c.dbName)
+	collation := ""
+	if c.Collation.Name != "" {
+		collation = fmt.Sprintf(" collate %s", c.Collation.Name)
+	}
+	return fmt.Sprintf("%s database%s %v%s", sqlparser.CreateStr, ifNotExists, c.dbName, collation)
 }
 
 func (c CreateDB) Schema() sql.Schema {
@@ -73,6 +80,19 @@ func (c CreateDB) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
 		return nil, err
 	}
 
+	if c.Collation.Name != "" {
+		db, err := c.Catalog.Database(c.dbName)
+		if err != nil {
+			return nil, err
+		}
+		// Databases that don't track a default collation always use the engine default
+		if collatedDb, ok := db.(sql.CollatedDatabase); ok {
+			if err = collatedDb.SetCollation(ctx, c.Collation); err != nil {
+				return nil, err
+			}
+		}
+	}
+
 	return sql.RowsToRowIter(rows...), nil
 }
 
@@ -87,6 +107,14 @@ func NewCreateDatabase(dbName string, ifNotExists bool) *CreateDB {
 	}
 }
 
+// NewCreateDatabaseWithCollation creates a CreateDB node for a database with the default collation given, which is
+// the engine default if it's the zero collation.
+func NewCreateDatabaseWithCollation(dbName string, ifNotExists bool, collation sql.Collation) *CreateDB {
+	c := NewCreateDatabase(dbName, ifNotExists)
+	c.Collation = collation
+	return c
+}
+
 // DropDB removes a databases from the Catalog and updates the active database if it gets removed itself.
 type DropDB struct {
 	Catalog  sql.Catalog
@@ -132,13 +160,22 @@ func (d DropDB) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
 		}
 	}
 
-	err := d.Catalog.RemoveDatabase(ctx, d.dbName)
+	db, err := d.Catalog.Database(d.dbName)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = dropDatabaseContents(ctx, db); err != nil {
+		return nil, err
+	}
+
+	err = d.Catalog.RemoveDatabase(ctx, d.dbName)
 	if err != nil {
 		return nil, err
 	}
 
 	// Unsets the current database
-	if ctx.GetCurrentDatabase() == d.dbName {
+	if strings.EqualFold(ctx.GetCurrentDatabase(), db.Name()) {
 		ctx.SetCurrentDatabase("")
 	}
 
@@ -157,3 +194,107 @@ func NewDropDatabase(dbName string, ifExists bool) *DropDB {
 		IfExists: ifExists,
 	}
 }
+
+// dropDatabaseContents drops the views and tables of the database given, so that none of them outlive it. Views are
+// dropped first, and the ones of databases that don't store their own views are removed from the session's view
+// registry. Foreign keys only reference tables of their own database, so none of them restrict dropping the tables.
+func dropDatabaseContents(ctx *sql.Context, db sql.Database) error {
+	if viewDb, ok := db.(sql.ViewDatabase); ok {
+		views, err := viewDb.AllViews(ctx)
+		if err != nil {
+			return err
+		}
+		for _, view := range views {
+			if err = viewDb.DropView(ctx, view.Name); err != nil {
+				return err
+			}
+		}
+	}
+
+	registry := ctx.GetViewRegistry()
+	for _, view := range registry.ViewsInDatabase(db.Name()) {
+		if err := registry.Delete(db.Name(), view.Name()); err != nil {
+			return err
+		}
+	}
+
+	dropper, ok := db.(sql.TableDropper)
+	if !ok {
+		return nil
+	}
+
+	names, err := db.GetTableNames(ctx)
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		if err = dropper.DropTable(ctx, name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AlterDB changes the default collation of a database.
+type AlterDB struct {
+	Catalog   sql.Catalog
+	dbName    string
+	Collation sql.Collation
+}
+
+func (a AlterDB) Resolved() bool {
+	return true
+}
+
+func (a AlterDB) String() string {
+	return fmt.Sprintf("%s database %v collate %s", sqlparser.AlterStr, a.dbName, a.Collation.Name)
+}
+
+func (a AlterDB) Schema() sql.Schema {
+	return sql.OkResultSchema
+}
+
+func (a AlterDB) Children() []sql.Node {
+	return nil
+}
+
+func (a AlterDB) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
+	dbName := a.dbName
+	if dbName == "" {
+		dbName = ctx.GetCurrentDatabase()
+		if dbName == "" {
+			return nil, sql.ErrNoDatabaseSelected.New()
+		}
+	}
+
+	db, err := a.Catalog.Database(dbName)
+	if err != nil {
+		return nil, err
+	}
+
+	collatedDb, ok := db.(sql.CollatedDatabase)
+	if !ok {
+		return nil, sql.ErrDatabaseCollationsNotSupported.New(db.Name())
+	}
+
+	if err = collatedDb.SetCollation(ctx, a.Collation); err != nil {
+		return nil, err
+	}
+
+	rows := []sql.Row{{sql.OkResult{RowsAffected: 1}}}
+
+	return sql.RowsToRowIter(rows...), nil
+}
+
+func (a AlterDB) WithChildren(children ...sql.Node) (sql.Node, error) {
+	return NillaryWithChildren(a, children...)
+}
+
+// NewAlterDatabase returns a new AlterDB node. An empty database name refers to the current database.
+func NewAlterDatabase(dbName string, collation sql.Collation) *AlterDB {
+	return &AlterDB{
+		dbName:    dbName,
+		Collation: collation,
+	}
+}
@@ -96,6 +96,9 @@ type TableSpec struct {
 	FkDefs  []*sql.ForeignKeyConstraint
 	ChDefs  []*sql.CheckConstraint
 	IdxDefs []*IndexDefinition
+	// ExplicitCollationCols names the string columns whose collation was given by their definition or the table
+	// options. All other string columns take the default collation of the database.
+	ExplicitCollationCols []string
 }
 
 func (c *TableSpec) WithSchema(schema sql.PrimaryKeySchema) *TableSpec {
@@ -125,15 +128,16 @@ func (c *TableSpec) WithIndices(idxDefs []*IndexDefinition) *TableSpec {
 // CreateTable is a node describing the creation of some table.
 type CreateTable struct {
 	ddlNode
-	name        string
-	schema      sql.PrimaryKeySchema
-	ifNotExists IfNotExistsOption
-	fkDefs      []*sql.ForeignKeyConstraint
-	chDefs      []*sql.CheckConstraint
-	idxDefs     []*IndexDefinition
-	like        sql.Node
-	temporary   TempTableOption
-	selectNode  sql.Node
+	name                  string
+	schema                sql.PrimaryKeySchema
+	ifNotExists           IfNotExistsOption
+	fkDefs                []*sql.ForeignKeyConstraint
+	chDefs                []*sql.CheckConstraint
+	idxDefs               []*IndexDefinition
+	explicitCollationCols []string
+	like                  sql.Node
+	temporary             TempTableOption
+	selectNode            sql.Node
 }
 
 var _ sql.Databaser = (*CreateTable)(nil)
@@ -147,14 +151,15 @@ func NewCreateTable(db sql.Database, name string, ifn IfNotExistsOption, temp Te
 	}
 
 	return &CreateTable{
-		ddlNode:     ddlNode{db},
-		name:        name,
-		schema:      tableSpec.Schema,
-		fkDefs:      tableSpec.FkDefs,
-		chDefs:      tableSpec.ChDefs,
-		idxDefs:     tableSpec.IdxDefs,
-		ifNotExists: ifn,
-		temporary:   temp,
+		ddlNode:               ddlNode{db},
+		name:                  name,
+		schema:                tableSpec.Schema,
+		fkDefs:                tableSpec.FkDefs,
+		chDefs:                tableSpec.ChDefs,
+		idxDefs:               tableSpec.IdxDefs,
+		explicitCollationCols: tableSpec.ExplicitCollationCols,
+		ifNotExists:           ifn,
+		temporary:             temp,
 	}
 }
 
@@ -215,7 +220,11 @@ func (c *CreateTable) Resolved() bool {
 
 // RowIter implements the Node interface.
 func (c *CreateTable) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
-	var err error
+	schema, err := c.schemaWithDatabaseCollation(ctx)
+	if err != nil {
+		return sql.RowsToRowIter(), err
+	}
+
 	if c.temporary == IsTempTable {
 		creatable, ok := c.db.(sql.TemporaryTableCreator)
 		if !ok {
@@ -226,7 +235,7 @@ func (c *CreateTable) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error
 			return sql.RowsToRowIter(), err
 		}
 
-		err = creatable.CreateTemporaryTable(ctx, c.name, c.schema)
+		err = creatable.CreateTemporaryTable(ctx, c.name, schema)
 	} else {
 		creatable, ok := c.db.(sql.TableCreator)
 		if !ok {
@@ -237,7 +246,7 @@ func (c *CreateTable) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error
 			return sql.RowsToRowIter(), err
 		}
 
-		err = creatable.CreateTable(ctx, c.name, c.schema)
+		err = creatable.CreateTable(ctx, c.name, schema)
 	}
 
 	if err != nil && !(sql.ErrTableAlreadyExists.Is(err) && (c.ifNotExists == IfNotExists)) {
@@ -278,6 +287,39 @@ func (c *CreateTable) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error
 	return sql.RowsToRowIter(), nil
 }
 
+// schemaWithDatabaseCollation returns the schema of the table to create, with the string columns whose collation
+// wasn't given explicitly taking the default collation of the database.
+func (c *CreateTable) schemaWithDatabaseCollation(ctx *sql.Context) (sql.PrimaryKeySchema, error) {
+	collation := sql.GetDatabaseCollation(ctx, c.db)
+	if collation.Equals(sql.Collation_Default) {
+		return c.schema, nil
+	}
+
+	explicit := make(map[string]bool, len(c.explicitCollationCols))
+	for _, name := range c.explicitCollationCols {
+		explicit[strings.ToLower(name)] = true
+	}
+
+	schema := make(sql.Schema, len(c.schema.Schema))
+	for i, col := range c.schema.Schema {
+		schema[i] = col
+		st, ok := col.Type.(sql.StringType)
+		if !ok || st.Collation().Equals(sql.Collation_binary) || explicit[strings.ToLower(col.Name)] {
+			continue
+		}
+
+		typ, err := sql.CreateString(st.Type(), st.MaxCharacterLength(), collation)
+		if err != nil {
+			return sql.PrimaryKeySchema{}, err
+		}
+		nc := *col
+		nc.Type = typ
+		schema[i] = &nc
+	}
+
+	return sql.NewPrimaryKeySchema(schema, c.schema.PkOrdinals...), nil
+}
+
 func (c *CreateTable) createIndexes(ctx *sql.Context, tableNode sql.Table) error {
 	idxAlterable, ok := tableNode.(sql.IndexAlterableTable)
 	if !ok {
@@ -503,6 +545,19 @@ func (c *CreateTable) Name() string {
 	return c.name
 }
 
+// WithName returns a copy of this node creating a table with the name given.
+func (c *CreateTable) WithName(name string) *CreateTable {
+	nc := *c
+	nc.name = name
+	nc.schema.Schema = make(sql.Schema, len(c.schema.Schema))
+	for i, col := range c.schema.Schema {
+		newCol := *col
+		newCol.Source = name
+		nc.schema.Schema[i] = &newCol
+	}
+	return &nc
+}
+
 func (c *CreateTable) IfNotExists() IfNotExistsOption {
 	return c.ifNotExists
 }
@@ -554,6 +609,7 @@ type DropTable struct {
 	names        []string
 	ifExists     bool
 	triggerNames []string
+	Catalog      sql.Catalog
 }
 
 var _ sql.Node = (*DropTable)(nil)
@@ -595,6 +651,7 @@ func (d *DropTable) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error)
 	}
 
 	var err error
+	lowerCaseTableNames := sql.GetLowerCaseTableNames(d.Catalog)
 	for _, tableName := range d.names {
 		tbl, ok, err := d.db.GetTableInsensitive(ctx, tableName)
 
@@ -602,6 +659,10 @@ func (d *DropTable) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error)
 			return nil, err
 		}
 
+		if ok && !lowerCaseTableNames.MatchesName(d.db, tableName, tbl.Name()) {
+			ok = false
+		}
+
 		if !ok {
 			if d.ifExists {
 				continue
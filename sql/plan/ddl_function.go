@@ -0,0 +1,139 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plan
+
+import (
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// CreateFunction is the CREATE FUNCTION statement, which stores a stored function in a database. The body of the
+// function isn't analyzed until the function is called.
+type CreateFunction struct {
+	*StoredFunction
+	Db sql.Database
+}
+
+var _ sql.Node = (*CreateFunction)(nil)
+var _ sql.Databaser = (*CreateFunction)(nil)
+
+// NewCreateFunction returns a *CreateFunction node.
+func NewCreateFunction(function *StoredFunction) *CreateFunction {
+	return &CreateFunction{StoredFunction: function}
+}
+
+// Database implements the sql.Databaser interface.
+func (c *CreateFunction) Database() sql.Database {
+	return c.Db
+}
+
+// WithDatabase implements the sql.Databaser interface.
+func (c *CreateFunction) WithDatabase(database sql.Database) (sql.Node, error) {
+	nc := *c
+	nc.Db = database
+	return &nc, nil
+}
+
+// Resolved implements the sql.Node interface.
+func (c *CreateFunction) Resolved() bool {
+	_, ok := c.Db.(sql.UnresolvedDatabase)
+	return c.Db != nil && !ok
+}
+
+// Schema implements the sql.Node interface.
+func (c *CreateFunction) Schema() sql.Schema {
+	return nil
+}
+
+// Children implements the sql.Node interface.
+func (c *CreateFunction) Children() []sql.Node {
+	return nil
+}
+
+// WithChildren implements the sql.Node interface.
+func (c *CreateFunction) WithChildren(children ...sql.Node) (sql.Node, error) {
+	return NillaryWithChildren(c, children...)
+}
+
+// String implements the sql.Node interface.
+func (c *CreateFunction) String() string {
+	definer := ""
+	if c.Definer != "" {
+		definer = fmt.Sprintf(" DEFINER = %s", c.Definer)
+	}
+	comment := ""
+	if c.Comment != "" {
+		comment = fmt.Sprintf(" COMMENT '%s'", c.Comment)
+	}
+	characteristics := ""
+	for _, characteristic := range c.Characteristics {
+		characteristics += fmt.Sprintf(" %s", characteristic.String())
+	}
+	return fmt.Sprintf("CREATE%s FUNCTION %s %s%s%s %s",
+		definer, c.StoredFunction.String(), c.SecurityContext.String(), comment, characteristics, c.BodyString)
+}
+
+// RowIter implements the sql.Node interface.
+func (c *CreateFunction) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
+	return &createFunctionIter{
+		sfd: sql.StoredFunctionDetails{
+			Name:            c.Name,
+			CreateStatement: c.CreateFunctionString,
+			CreatedAt:       c.CreatedAt,
+			ModifiedAt:      c.ModifiedAt,
+		},
+		db:  c.Db,
+		ctx: ctx,
+	}, nil
+}
+
+// createFunctionIter is the row iterator for *CreateFunction.
+type createFunctionIter struct {
+	once sync.Once
+	sfd  sql.StoredFunctionDetails
+	db   sql.Database
+	ctx  *sql.Context
+}
+
+// Next implements the sql.RowIter interface.
+func (c *createFunctionIter) Next() (sql.Row, error) {
+	run := false
+	c.once.Do(func() {
+		run = true
+	})
+	if !run {
+		return nil, io.EOF
+	}
+
+	fdb, ok := c.db.(sql.StoredFunctionDatabase)
+	if !ok {
+		return nil, sql.ErrStoredFunctionsNotSupported.New(c.db.Name())
+	}
+
+	err := fdb.SaveStoredFunction(c.ctx, c.sfd)
+	if err != nil {
+		return nil, err
+	}
+
+	return sql.Row{sql.NewOkResult(0)}, nil
+}
+
+// Close implements the sql.RowIter interface.
+func (c *createFunctionIter) Close(ctx *sql.Context) error {
+	return nil
+}
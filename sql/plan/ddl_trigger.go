@@ -18,8 +18,10 @@ import (
 	"fmt"
 	"io"
 	"sync"
+	"time"
 
 	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression/function"
 )
 
 type TriggerOrder struct {
@@ -29,6 +31,7 @@ type TriggerOrder struct {
 
 type CreateTrigger struct {
 	TriggerName         string
+	Definer             string
 	TriggerTime         string
 	TriggerEvent        string
 	TriggerOrder        *TriggerOrder
@@ -37,11 +40,16 @@ type CreateTrigger struct {
 	CreateTriggerString string
 	BodyString          string
 	CreateDatabase      sql.Database
+	// CreatedAt and SqlMode are set for triggers loaded from their database, from their definition, as is Definer for
+	// triggers created without a DEFINER clause
+	CreatedAt time.Time
+	SqlMode   string
 }
 
-func NewCreateTrigger(triggerName, triggerTime, triggerEvent string, triggerOrder *TriggerOrder, table sql.Node, body sql.Node, createTriggerString, bodyString string) *CreateTrigger {
+func NewCreateTrigger(triggerName, definer, triggerTime, triggerEvent string, triggerOrder *TriggerOrder, table sql.Node, body sql.Node, createTriggerString, bodyString string) *CreateTrigger {
 	return &CreateTrigger{
 		TriggerName:         triggerName,
+		Definer:             definer,
 		TriggerTime:         triggerTime,
 		TriggerEvent:        triggerEvent,
 		TriggerOrder:        triggerOrder,
@@ -136,10 +144,27 @@ func (c *createTriggerIter) Close(*sql.Context) error {
 }
 
 func (c *CreateTrigger) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
+	val, err := ctx.GetSessionVariable(ctx, "sql_mode")
+	if err != nil {
+		return nil, err
+	}
+	sqlMode, _ := val.(string)
+	// Without a DEFINER clause, the trigger is defined by the user creating it
+	definer := c.Definer
+	if definer == "" {
+		val, err := function.NewCurrentUser().Eval(ctx, row)
+		if err != nil {
+			return nil, err
+		}
+		definer = val.(string)
+	}
 	return &createTriggerIter{
 		definition: sql.TriggerDefinition{
 			Name:            c.TriggerName,
 			CreateStatement: c.CreateTriggerString,
+			CreatedAt:       time.Now(),
+			SqlMode:         sqlMode,
+			Definer:         definer,
 		},
 		db:  c.CreateDatabase,
 		ctx: ctx,
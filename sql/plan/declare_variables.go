@@ -0,0 +1,87 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plan
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+// DeclareVariables represents the DECLARE statement for local variables. The variables are kept alongside the
+// parameters of the stored procedure or stored function that declares them, and are NULL until they're set.
+type DeclareVariables struct {
+	Names []string
+	Type  sql.Type
+	pRef  *expression.ProcedureParamReference
+}
+
+var _ sql.Node = (*DeclareVariables)(nil)
+
+// NewDeclareVariables returns a *DeclareVariables node. All names contained within are lowercase.
+func NewDeclareVariables(names []string, typ sql.Type) *DeclareVariables {
+	lowercasedNames := make([]string, len(names))
+	for i, name := range names {
+		lowercasedNames[i] = strings.ToLower(name)
+	}
+	return &DeclareVariables{
+		Names: lowercasedNames,
+		Type:  typ,
+	}
+}
+
+// Resolved implements the sql.Node interface.
+func (d *DeclareVariables) Resolved() bool {
+	return true
+}
+
+// String implements the sql.Node interface.
+func (d *DeclareVariables) String() string {
+	return fmt.Sprintf("DECLARE %s %s", strings.Join(d.Names, ", "), d.Type.String())
+}
+
+// Schema implements the sql.Node interface.
+func (d *DeclareVariables) Schema() sql.Schema {
+	return nil
+}
+
+// Children implements the sql.Node interface.
+func (d *DeclareVariables) Children() []sql.Node {
+	return nil
+}
+
+// WithChildren implements the sql.Node interface.
+func (d *DeclareVariables) WithChildren(children ...sql.Node) (sql.Node, error) {
+	return NillaryWithChildren(d, children...)
+}
+
+// WithParamReference returns a new *DeclareVariables containing the given *expression.ProcedureParamReference.
+func (d *DeclareVariables) WithParamReference(pRef *expression.ProcedureParamReference) *DeclareVariables {
+	nd := *d
+	nd.pRef = pRef
+	return &nd
+}
+
+// RowIter implements the sql.Node interface.
+func (d *DeclareVariables) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
+	for _, name := range d.Names {
+		if err := d.pRef.Initialize(name, d.Type, nil); err != nil {
+			return nil, err
+		}
+	}
+	return sql.RowsToRowIter(), nil
+}
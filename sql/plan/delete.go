@@ -15,6 +15,8 @@
 package plan
 
 import (
+	"strings"
+
 	"gopkg.in/src-d/go-errors.v1"
 
 	"github.com/dolthub/go-mysql-server/sql"
@@ -22,14 +24,23 @@ import (
 
 var ErrDeleteFromNotSupported = errors.NewKind("table doesn't support DELETE FROM")
 
+// ErrUnknownDeleteTarget is returned when a table to delete from in a multiple-table DELETE isn't one of the tables the
+// statement reads from.
+var ErrUnknownDeleteTarget = errors.NewKind("unknown table '%s' in MULTI DELETE")
+
 // DeleteFrom is a node describing a deletion from some table.
 type DeleteFrom struct {
 	UnaryNode
+	// Priority is the LOW_PRIORITY modifier given to the statement, if any.
+	Priority Priority
+	// Targets are the names of the tables to delete from in a multiple-table DELETE, as in DELETE a, b FROM a JOIN b,
+	// which are the names or aliases of tables of the child node. The other tables of the child are only read.
+	Targets []string
 }
 
 // NewDeleteFrom creates a DeleteFrom node.
 func NewDeleteFrom(n sql.Node) *DeleteFrom {
-	return &DeleteFrom{UnaryNode{n}}
+	return &DeleteFrom{UnaryNode: UnaryNode{n}}
 }
 
 func getDeletable(node sql.Node) (sql.DeletableTable, error) {
@@ -101,6 +112,10 @@ func (p *DeleteFrom) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error)
 		return sql.RowsToRowIter(), nil
 	}
 
+	if len(p.Targets) > 0 {
+		return p.multipleTableRowIter(ctx, row)
+	}
+
 	deletable, err := getDeletable(p.Child)
 	if err != nil {
 		return nil, err
@@ -160,24 +175,231 @@ func newDeleteIter(childIter sql.RowIter, deleter sql.RowDeleter, schema sql.Sch
 	})
 }
 
+// multipleTableRowIter returns the iterator of a multiple-table DELETE, which deletes the rows of each of its targets
+// that are part of the rows of the child node.
+func (p *DeleteFrom) multipleTableRowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
+	tables := getTablesByName(p.Child)
+	schemas := recreateTableSchemaFromJoinSchema(p.Child.Schema())
+	deleters := make(map[string]sql.RowDeleter, len(p.Targets))
+	var editors multipleTableEditor
+	for _, target := range p.Targets {
+		name := strings.ToLower(target)
+		if _, ok := deleters[name]; ok {
+			continue
+		}
+
+		table, ok := tables[name]
+		if !ok {
+			return nil, ErrUnknownDeleteTarget.New(target)
+		}
+		deletable, err := getDeletableTable(table.Table)
+		if err != nil {
+			return nil, err
+		}
+		if sql.IsKeyless(deletable.Schema()) {
+			return nil, sql.ErrUnsupportedFeature.New("error: keyless tables unsupported for multiple-table DELETE")
+		}
+
+		deleter := deletable.Deleter(ctx)
+		deleters[name] = deleter
+		editors = append(editors, deleter)
+	}
+
+	iter, err := p.Child.RowIter(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewTableEditorIter(ctx, editors, &deleteJoinIter{
+		ctx:       ctx,
+		childIter: iter,
+		schema:    p.Child.Schema(),
+		schemas:   schemas,
+		deleters:  deleters,
+		caches:    make(map[string]sql.KeyValueCache),
+		disposals: make(map[string]sql.DisposeFunc),
+	}), nil
+}
+
+// getTablesByName returns the tables of the node given by their lower-cased name, or alias if they have one.
+func getTablesByName(node sql.Node) map[string]*ResolvedTable {
+	ret := make(map[string]*ResolvedTable)
+	Inspect(node, func(node sql.Node) bool {
+		switch n := node.(type) {
+		case *ResolvedTable:
+			ret[strings.ToLower(n.Name())] = n
+		case *IndexedTableAccess:
+			ret[strings.ToLower(n.ResolvedTable.Name())] = n.ResolvedTable
+		case *TableAlias:
+			switch child := n.Child.(type) {
+			case *ResolvedTable:
+				ret[strings.ToLower(n.Name())] = child
+			case *IndexedTableAccess:
+				ret[strings.ToLower(n.Name())] = child.ResolvedTable
+			}
+			return false
+		}
+		return true
+	})
+	return ret
+}
+
+// deleteJoinIter deletes the rows of each target of a multiple-table DELETE from the rows of its child, making sure
+// that every table row is deleted once. It returns a row for every table row deleted.
+type deleteJoinIter struct {
+	ctx       *sql.Context
+	childIter sql.RowIter
+	schema    sql.Schema
+	schemas   map[string]sql.Schema
+	deleters  map[string]sql.RowDeleter
+	caches    map[string]sql.KeyValueCache
+	disposals map[string]sql.DisposeFunc
+	// Table rows deleted but not returned yet
+	deleted []sql.Row
+}
+
+func (d *deleteJoinIter) Next() (sql.Row, error) {
+	for len(d.deleted) == 0 {
+		row, err := d.childIter.Next()
+		if err != nil {
+			return nil, err
+		}
+
+		// Reduce the row to the length of the schema, as deleteIter does
+		if len(d.schema) < len(row) {
+			row = row[len(row)-len(d.schema):]
+		}
+
+		tableRows := splitRowIntoTableRowMap(row, d.schema)
+		for name, deleter := range d.deleters {
+			tableRow, ok := tableRows[name]
+			if !ok || isNullRow(tableRow) {
+				// The table has no row in this one, as in the unmatched rows of an outer join
+				continue
+			}
+
+			cache := d.getOrCreateCache(name)
+			hash, err := sql.HashOf(tableRow)
+			if err != nil {
+				return nil, err
+			}
+			if _, err := cache.Get(hash); err == nil {
+				continue
+			} else if !errors.Is(err, sql.ErrKeyNotFound) {
+				return nil, err
+			}
+			if err := cache.Put(hash, struct{}{}); err != nil {
+				return nil, err
+			}
+
+			if err := deleter.Delete(d.ctx, tableRow); err != nil {
+				return nil, err
+			}
+			d.deleted = append(d.deleted, tableRow)
+		}
+	}
+
+	row := d.deleted[0]
+	d.deleted = d.deleted[1:]
+	return row, nil
+}
+
+func (d *deleteJoinIter) getOrCreateCache(name string) sql.KeyValueCache {
+	if cache, ok := d.caches[name]; ok {
+		return cache
+	}
+
+	cache, dispose := d.ctx.Memory.NewHistoryCache()
+	d.caches[name] = cache
+	d.disposals[name] = dispose
+	return cache
+}
+
+func (d *deleteJoinIter) Close(ctx *sql.Context) error {
+	for _, dispose := range d.disposals {
+		dispose()
+	}
+	d.disposals = nil
+
+	for _, deleter := range d.deleters {
+		if err := deleter.Close(ctx); err != nil {
+			_ = d.childIter.Close(ctx)
+			return err
+		}
+	}
+	d.deleters = nil
+
+	return d.childIter.Close(ctx)
+}
+
+// isNullRow returns whether every value of the row given is NULL.
+func isNullRow(row sql.Row) bool {
+	for _, v := range row {
+		if v != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// multipleTableEditor is the sql.TableEditor of several tables edited by the same statement.
+type multipleTableEditor []sql.TableEditor
+
+// StatementBegin implements the sql.TableEditor interface.
+func (m multipleTableEditor) StatementBegin(ctx *sql.Context) {
+	for _, editor := range m {
+		editor.StatementBegin(ctx)
+	}
+}
+
+// DiscardChanges implements the sql.TableEditor interface.
+func (m multipleTableEditor) DiscardChanges(ctx *sql.Context, errorEncountered error) error {
+	for _, editor := range m {
+		if err := editor.DiscardChanges(ctx, errorEncountered); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// StatementComplete implements the sql.TableEditor interface.
+func (m multipleTableEditor) StatementComplete(ctx *sql.Context) error {
+	for _, editor := range m {
+		if err := editor.StatementComplete(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // WithChildren implements the Node interface.
 func (p *DeleteFrom) WithChildren(children ...sql.Node) (sql.Node, error) {
 	if len(children) != 1 {
 		return nil, sql.ErrInvalidChildrenNumber.New(p, len(children), 1)
 	}
-	return NewDeleteFrom(children[0]), nil
+	np := *p
+	np.Child = children[0]
+	return &np, nil
 }
 
 func (p DeleteFrom) String() string {
 	pr := sql.NewTreePrinter()
-	_ = pr.WriteNode("Delete")
+	p.writeNode(pr)
 	_ = pr.WriteChildren(p.Child.String())
 	return pr.String()
 }
 
 func (p DeleteFrom) DebugString() string {
 	pr := sql.NewTreePrinter()
-	_ = pr.WriteNode("Delete")
+	p.writeNode(pr)
 	_ = pr.WriteChildren(sql.DebugString(p.Child))
 	return pr.String()
 }
+
+func (p DeleteFrom) writeNode(pr *sql.TreePrinter) {
+	if len(p.Targets) > 0 {
+		_ = pr.WriteNode("Delete(%s)", strings.Join(p.Targets, ", "))
+	} else {
+		_ = pr.WriteNode("Delete")
+	}
+}
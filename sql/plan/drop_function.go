@@ -0,0 +1,101 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plan
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// DropFunction is the DROP FUNCTION statement, which removes a stored function from a database.
+type DropFunction struct {
+	db           sql.Database
+	IfExists     bool
+	FunctionName string
+}
+
+var _ sql.Databaser = (*DropFunction)(nil)
+var _ sql.Node = (*DropFunction)(nil)
+
+// NewDropFunction creates a new *DropFunction node.
+func NewDropFunction(db sql.Database, functionName string, ifExists bool) *DropFunction {
+	return &DropFunction{
+		db:           db,
+		IfExists:     ifExists,
+		FunctionName: strings.ToLower(functionName),
+	}
+}
+
+// Resolved implements the sql.Node interface.
+func (d *DropFunction) Resolved() bool {
+	_, ok := d.db.(sql.UnresolvedDatabase)
+	return !ok
+}
+
+// String implements the sql.Node interface.
+func (d *DropFunction) String() string {
+	ifExists := ""
+	if d.IfExists {
+		ifExists = "IF EXISTS "
+	}
+	return fmt.Sprintf("DROP FUNCTION %s%s", ifExists, d.FunctionName)
+}
+
+// Schema implements the sql.Node interface.
+func (d *DropFunction) Schema() sql.Schema {
+	return nil
+}
+
+// Children implements the sql.Node interface.
+func (d *DropFunction) Children() []sql.Node {
+	return nil
+}
+
+// RowIter implements the sql.Node interface.
+func (d *DropFunction) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
+	fdb, ok := d.db.(sql.StoredFunctionDatabase)
+	if !ok {
+		if d.IfExists {
+			return sql.RowsToRowIter(), nil
+		}
+		return nil, sql.ErrStoredFunctionsNotSupported.New(d.db.Name())
+	}
+	err := fdb.DropStoredFunction(ctx, d.FunctionName)
+	if d.IfExists && sql.ErrStoredFunctionDoesNotExist.Is(err) {
+		return sql.RowsToRowIter(), nil
+	} else if err != nil {
+		return nil, err
+	}
+	return sql.RowsToRowIter(), nil
+}
+
+// WithChildren implements the sql.Node interface.
+func (d *DropFunction) WithChildren(children ...sql.Node) (sql.Node, error) {
+	return NillaryWithChildren(d, children...)
+}
+
+// Database implements the sql.Databaser interface.
+func (d *DropFunction) Database() sql.Database {
+	return d.db
+}
+
+// WithDatabase implements the sql.Databaser interface.
+func (d *DropFunction) WithDatabase(db sql.Database) (sql.Node, error) {
+	nd := *d
+	nd.db = db
+	return &nd, nil
+}
@@ -15,6 +15,10 @@
 package plan
 
 import (
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/vitess/go/mysql"
 	errors "gopkg.in/src-d/go-errors.v1"
 
 	"github.com/dolthub/go-mysql-server/sql"
@@ -93,6 +97,7 @@ func (dv *SingleDropView) WithDatabase(database sql.Database) (sql.Node, error)
 type DropView struct {
 	children []sql.Node
 	ifExists bool
+	Catalog  sql.Catalog
 }
 
 // NewDropView creates a DropView node with the specified parameters,
@@ -119,33 +124,53 @@ func (dvs *DropView) Resolved() bool {
 }
 
 // RowIter implements the Node interface. When executed, this function drops
-// all the views defined by the node's children. It errors if the flag ifExists
-// is set to false and there is some view that does not exist.
+// all the views defined by the node's children that exist. Views that don't
+// exist are skipped with a warning if the flag ifExists is set, and reported
+// in an error once the other views are dropped otherwise.
 func (dvs *DropView) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
+	var missing []*SingleDropView
 	for _, child := range dvs.children {
 		drop, ok := child.(*SingleDropView)
 		if !ok {
 			return sql.RowsToRowIter(), errDropViewChild.New()
 		}
 
-		if dropper, ok := drop.database.(sql.ViewDatabase); ok {
-			err := dropper.DropView(ctx, drop.viewName)
-			if err != nil {
-				allowedError := dvs.ifExists && sql.ErrViewDoesNotExist.Is(err)
-				if !allowedError {
-					return sql.RowsToRowIter(), err
-				}
+		viewName, err := sql.StoredViewName(ctx, sql.GetLowerCaseTableNames(dvs.Catalog), drop.database, drop.viewName)
+		if err == nil {
+			if dropper, ok := drop.database.(sql.ViewDatabase); ok {
+				err = dropper.DropView(ctx, viewName)
+			} else {
+				err = ctx.GetViewRegistry().Delete(drop.database.Name(), viewName)
 			}
-		} else {
-			err := ctx.GetViewRegistry().Delete(drop.database.Name(), drop.viewName)
-			allowedError := dvs.ifExists && sql.ErrViewDoesNotExist.Is(err)
-			if !allowedError {
-				return sql.RowsToRowIter(), err
+		}
+
+		if sql.ErrViewDoesNotExist.Is(err) {
+			if dvs.ifExists {
+				ctx.Session.Warn(&sql.Warning{
+					Level:   "Note",
+					Code:    mysql.ERBadTable,
+					Message: fmt.Sprintf("Unknown table '%s.%s'", drop.database.Name(), drop.viewName),
+				})
+			} else {
+				missing = append(missing, drop)
 			}
+		} else if err != nil {
+			return sql.RowsToRowIter(), err
 		}
 	}
 
-	return sql.RowsToRowIter(), nil
+	switch len(missing) {
+	case 0:
+		return sql.RowsToRowIter(), nil
+	case 1:
+		return sql.RowsToRowIter(), sql.ErrViewDoesNotExist.New(missing[0].database.Name(), missing[0].viewName)
+	default:
+		names := make([]string, len(missing))
+		for i, drop := range missing {
+			names[i] = fmt.Sprintf("%s.%s", drop.database.Name(), drop.viewName)
+		}
+		return sql.RowsToRowIter(), sql.ErrViewsDoNotExist.New(strings.Join(names, ", "))
+	}
 }
 
 // Schema implements the Node interface. It always returns nil.
@@ -169,7 +194,7 @@ func (dvs *DropView) String() string {
 // WithChildren implements the Node interface. It always suceeds, returning a
 // copy of this node with the new array of nodes as children.
 func (dvs *DropView) WithChildren(children ...sql.Node) (sql.Node, error) {
-	newDrop := dvs
+	newDrop := *dvs
 	newDrop.children = children
-	return newDrop, nil
+	return &newDrop, nil
 }
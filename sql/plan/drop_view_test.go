@@ -147,3 +147,37 @@ func TestDropNonExistingViewNative(t *testing.T) {
 	require.Error(t, err)
 	require.True(t, sql.ErrViewDoesNotExist.Is(err))
 }
+
+// Tests that DropView drops the views that exist in a list along with views
+// that don't, and that the missing views are warned about with ifExists and
+// reported in an error without it
+func TestDropExistingAndMissingViews(t *testing.T) {
+	test := func(ifExists bool) (*sql.Context, error) {
+		db := memory.NewDatabase("mydb")
+		ctx, view := setupView(t, db)
+
+		dropView := NewDropView([]sql.Node{
+			NewSingleDropView(db, "missing1"),
+			NewSingleDropView(db, view.Name()),
+			NewSingleDropView(db, "missing2"),
+		}, ifExists)
+
+		_, dropErr := dropView.RowIter(ctx, nil)
+
+		_, ok, err := db.GetView(ctx, view.Name())
+		require.NoError(t, err)
+		require.False(t, ok)
+
+		return ctx, dropErr
+	}
+
+	ctx, err := test(true)
+	require.NoError(t, err)
+	require.Equal(t, uint16(2), ctx.WarningCount())
+
+	ctx, err = test(false)
+	require.Error(t, err)
+	require.True(t, sql.ErrViewsDoNotExist.Is(err))
+	require.Equal(t, "the views mydb.missing1, mydb.missing2 do not exist", err.Error())
+	require.Equal(t, uint16(0), ctx.WarningCount())
+}
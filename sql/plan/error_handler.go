@@ -0,0 +1,182 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plan
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// ErrorHandlerFunc is called with every error an ErrorHandlerNode drops.
+type ErrorHandlerFunc func(err error)
+
+// FatalErrorFunc tells whether an error returned by the child of an ErrorHandlerNode is fatal. Fatal errors end the
+// iteration with the error, instead of being handed to the handler and dropped.
+type FatalErrorFunc func(err error) bool
+
+// ErrorHandlerNode is a node that skips the rows its child fails to return, instead of failing with them. Every error
+// other than io.EOF is handed to the handler of the node and dropped, and the iteration continues with the next row.
+// Errors aren't dropped once the context is done, nor are context cancellations, so that a cancelled query still
+// ends. Other errors can be made to end the iteration as well with WithFatalErrors.
+type ErrorHandlerNode struct {
+	UnaryNode
+	handler   ErrorHandlerFunc
+	fatal     FatalErrorFunc
+	collected *collectedErrors
+}
+
+// collectedErrors are the errors dropped by a collecting ErrorHandlerNode. They are shared by the copies of the node
+// made by WithChildren, so that they can be read from the node given to the constructor once the query is run.
+type collectedErrors struct {
+	mu      sync.Mutex
+	errs    []error
+	skipped int
+}
+
+// NewErrorHandlerNode creates a new ErrorHandlerNode that drops the errors of its child after calling the handler
+// given with them.
+func NewErrorHandlerNode(child sql.Node, handler ErrorHandlerFunc) *ErrorHandlerNode {
+	return &ErrorHandlerNode{
+		UnaryNode: UnaryNode{Child: child},
+		handler:   handler,
+	}
+}
+
+// NewCollectingErrorHandlerNode creates a new ErrorHandlerNode that collects the errors of its child, along with the
+// number of rows skipped, so that they can be reported with Errors and SkippedRows once the iterator is closed.
+func NewCollectingErrorHandlerNode(child sql.Node) *ErrorHandlerNode {
+	return &ErrorHandlerNode{
+		UnaryNode: UnaryNode{Child: child},
+		collected: &collectedErrors{},
+	}
+}
+
+// WithFatalErrors returns a copy of the node that ends the iteration with the errors of its child for which the
+// function given returns true. Nodes are created without one, and only end with context cancellations.
+func (e *ErrorHandlerNode) WithFatalErrors(fatal FatalErrorFunc) *ErrorHandlerNode {
+	ne := *e
+	ne.fatal = fatal
+	return &ne
+}
+
+// Errors returns the errors collected by the last iteration of a node created with NewCollectingErrorHandlerNode, in
+// the order they were returned by the child. Returns nil for other nodes.
+func (e *ErrorHandlerNode) Errors() []error {
+	if e.collected == nil {
+		return nil
+	}
+	e.collected.mu.Lock()
+	defer e.collected.mu.Unlock()
+	return append([]error(nil), e.collected.errs...)
+}
+
+// SkippedRows returns the number of rows skipped by the last iteration of a node created with
+// NewCollectingErrorHandlerNode. Returns 0 for other nodes.
+func (e *ErrorHandlerNode) SkippedRows() int {
+	if e.collected == nil {
+		return 0
+	}
+	e.collected.mu.Lock()
+	defer e.collected.mu.Unlock()
+	return e.collected.skipped
+}
+
+// RowIter implements the Node interface.
+func (e *ErrorHandlerNode) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
+	span, ctx := ctx.Span("plan.ErrorHandlerNode")
+
+	if e.collected != nil {
+		e.collected.mu.Lock()
+		e.collected.errs, e.collected.skipped = nil, 0
+		e.collected.mu.Unlock()
+	}
+
+	iter, err := e.Child.RowIter(ctx, row)
+	if err != nil {
+		span.Finish()
+		return nil, err
+	}
+	return sql.NewSpanIter(span, &errorHandlerIter{ctx: ctx, node: e, childIter: iter}), nil
+}
+
+// WithChildren implements the Node interface.
+func (e *ErrorHandlerNode) WithChildren(children ...sql.Node) (sql.Node, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(e, len(children), 1)
+	}
+
+	ne := *e
+	ne.Child = children[0]
+	return &ne, nil
+}
+
+func (e *ErrorHandlerNode) String() string {
+	pr := sql.NewTreePrinter()
+	_ = pr.WriteNode("ErrorHandler")
+	_ = pr.WriteChildren(e.Child.String())
+	return pr.String()
+}
+
+func (e *ErrorHandlerNode) DebugString() string {
+	pr := sql.NewTreePrinter()
+	_ = pr.WriteNode("ErrorHandler")
+	_ = pr.WriteChildren(sql.DebugString(e.Child))
+	return pr.String()
+}
+
+type errorHandlerIter struct {
+	ctx       *sql.Context
+	node      *ErrorHandlerNode
+	childIter sql.RowIter
+}
+
+func (i *errorHandlerIter) Next() (sql.Row, error) {
+	for {
+		row, err := i.childIter.Next()
+		if err == nil || err == io.EOF || i.isFatal(err) {
+			return row, err
+		}
+		i.handle(err)
+	}
+}
+
+// isFatal returns whether the error given must end the iteration instead of being dropped.
+func (i *errorHandlerIter) isFatal(err error) bool {
+	if i.ctx.Err() != nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	return i.node.fatal != nil && i.node.fatal(err)
+}
+
+// handle records the error given as a dropped one.
+func (i *errorHandlerIter) handle(err error) {
+	if c := i.node.collected; c != nil {
+		c.mu.Lock()
+		c.errs = append(c.errs, err)
+		c.skipped++
+		c.mu.Unlock()
+	}
+	if i.node.handler != nil {
+		i.node.handler(err)
+	}
+}
+
+func (i *errorHandlerIter) Close(ctx *sql.Context) error {
+	return i.childIter.Close(ctx)
+}
@@ -0,0 +1,131 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plan
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// failingRowsNode is a table node that fails to return the rows with the values given.
+type failingRowsNode struct {
+	*ResolvedTable
+	failing map[interface{}]bool
+}
+
+func (n failingRowsNode) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
+	iter, err := n.ResolvedTable.RowIter(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+	return &failingRowsIter{iter, n.failing}, nil
+}
+
+type failingRowsIter struct {
+	sql.RowIter
+	failing map[interface{}]bool
+}
+
+func (i *failingRowsIter) Next() (sql.Row, error) {
+	row, err := i.RowIter.Next()
+	if err == nil && i.failing[row[0]] {
+		return nil, fmt.Errorf("bad row %v", row[0])
+	}
+	return row, err
+}
+
+func TestErrorHandlerNode(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewEmptyContext()
+
+	table, _ := getTestingTable(t)
+	child := failingRowsNode{NewResolvedTable(table, nil, nil), map[interface{}]bool{"11a": true, "33a": true}}
+
+	var handled []string
+	node := NewErrorHandlerNode(child, func(err error) {
+		handled = append(handled, err.Error())
+	})
+	rows, err := sql.NodeToRows(ctx, node)
+	require.NoError(err)
+	require.Equal([]sql.Row{{"22a"}}, rows)
+	require.Equal([]string{"bad row 11a", "bad row 33a"}, handled)
+	require.Nil(node.Errors())
+	require.Equal(0, node.SkippedRows())
+
+	collecting := NewCollectingErrorHandlerNode(child)
+	transformed, err := collecting.WithChildren(child)
+	require.NoError(err)
+	for i := 0; i < 2; i++ {
+		rows, err = sql.NodeToRows(ctx, transformed)
+		require.NoError(err)
+		require.Equal([]sql.Row{{"22a"}}, rows)
+		require.Equal([]error{fmt.Errorf("bad row 11a"), fmt.Errorf("bad row 33a")}, collecting.Errors())
+		require.Equal(2, collecting.SkippedRows())
+	}
+}
+
+func TestErrorHandlerNodeFatalErrors(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewEmptyContext()
+
+	table, _ := getTestingTable(t)
+	child := failingRowsNode{NewResolvedTable(table, nil, nil), map[interface{}]bool{"11a": true, "33a": true}}
+
+	var handled []string
+	node := NewErrorHandlerNode(child, func(err error) {
+		handled = append(handled, err.Error())
+	}).WithFatalErrors(func(err error) bool {
+		return err.Error() == "bad row 33a"
+	})
+	_, err := sql.NodeToRows(ctx, node)
+	require.EqualError(err, "bad row 33a")
+	require.Equal([]string{"bad row 11a"}, handled)
+
+	cancelled := failingRowsNode{NewResolvedTable(table, nil, nil), nil}
+	cancelling := &cancellingRowsNode{cancelled}
+	_, err = sql.NodeToRows(ctx, NewErrorHandlerNode(cancelling, nil))
+	require.Equal(context.Canceled, err)
+}
+
+// cancellingRowsNode is a table node that fails with a context cancellation once it returns its first row.
+type cancellingRowsNode struct {
+	failingRowsNode
+}
+
+func (n *cancellingRowsNode) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
+	iter, err := n.failingRowsNode.RowIter(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+	return &cancellingRowsIter{RowIter: iter}, nil
+}
+
+type cancellingRowsIter struct {
+	sql.RowIter
+	returned bool
+}
+
+func (i *cancellingRowsIter) Next() (sql.Row, error) {
+	if i.returned {
+		return nil, context.Canceled
+	}
+	i.returned = true
+	return i.RowIter.Next()
+}
@@ -19,7 +19,6 @@ import (
 	"io"
 	"strings"
 
-	"github.com/cespare/xxhash"
 	opentracing "github.com/opentracing/opentracing-go"
 	errors "gopkg.in/src-d/go-errors.v1"
 
@@ -184,6 +183,63 @@ func (g *GroupBy) Expressions() []sql.Expression {
 	return exprs
 }
 
+// OrderedGroupBy is a GroupBy node for a child whose rows are already sorted on the grouping expressions, so that the
+// rows of each group are next to each other. Rather than buffering every group until the child is exhausted, it emits
+// each group as soon as the next one starts, keeping only one group in memory at a time.
+type OrderedGroupBy struct {
+	GroupBy
+}
+
+// NewOrderedGroupBy creates a new OrderedGroupBy node. The child given must return its rows sorted on the grouping
+// expressions.
+func NewOrderedGroupBy(selectedExprs, groupByExprs []sql.Expression, child sql.Node) *OrderedGroupBy {
+	return &OrderedGroupBy{GroupBy: *NewGroupBy(selectedExprs, groupByExprs, child)}
+}
+
+// RowIter implements the Node interface.
+func (g *OrderedGroupBy) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
+	span, ctx := ctx.Span("plan.OrderedGroupBy", opentracing.Tags{
+		"groupings":  len(g.GroupByExprs),
+		"aggregates": len(g.SelectedExprs),
+	})
+
+	i, err := g.Child.RowIter(ctx, row)
+	if err != nil {
+		span.Finish()
+		return nil, err
+	}
+
+	return sql.NewSpanIter(span, newOrderedGroupByIter(ctx, g.SelectedExprs, g.GroupByExprs, i)), nil
+}
+
+// WithChildren implements the Node interface.
+func (g *OrderedGroupBy) WithChildren(children ...sql.Node) (sql.Node, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(g, len(children), 1)
+	}
+
+	return NewOrderedGroupBy(g.SelectedExprs, g.GroupByExprs, children[0]), nil
+}
+
+// WithExpressions implements the Node interface.
+func (g *OrderedGroupBy) WithExpressions(exprs ...sql.Expression) (sql.Node, error) {
+	n, err := g.GroupBy.WithExpressions(exprs...)
+	if err != nil {
+		return nil, err
+	}
+
+	gb := n.(*GroupBy)
+	return NewOrderedGroupBy(gb.SelectedExprs, gb.GroupByExprs, gb.Child), nil
+}
+
+func (g *OrderedGroupBy) String() string {
+	return "Ordered" + g.GroupBy.String()
+}
+
+func (g *OrderedGroupBy) DebugString() string {
+	return "Ordered" + g.GroupBy.DebugString()
+}
+
 type groupByIter struct {
 	selectedExprs []sql.Expression
 	child         sql.RowIter
@@ -216,7 +272,10 @@ func (i *groupByIter) Next() (sql.Row, error) {
 		}
 	}
 
-	for {
+	for n := 0; ; n++ {
+		if err := sql.CheckCancellation(i.ctx, n); err != nil {
+			return nil, err
+		}
 		row, err := i.child.Next()
 		if err != nil {
 			if err == io.EOF {
@@ -290,7 +349,10 @@ func (i *groupByGroupingIter) Next() (sql.Row, error) {
 }
 
 func (i *groupByGroupingIter) compute() error {
-	for {
+	for n := 0; ; n++ {
+		if err := sql.CheckCancellation(i.ctx, n); err != nil {
+			return err
+		}
 		row, err := i.child.Next()
 		if err != nil {
 			if err == io.EOF {
@@ -369,24 +431,120 @@ func (i *groupByGroupingIter) Dispose() {
 	}
 }
 
+// orderedGroupByIter aggregates the rows of its child one group at a time, assuming the rows of each group are next
+// to each other.
+type orderedGroupByIter struct {
+	selectedExprs []sql.Expression
+	groupByExprs  []sql.Expression
+	child         sql.RowIter
+	ctx           *sql.Context
+	buf           []sql.AggregationBuffer
+	key           uint64
+	done          bool
+}
+
+func newOrderedGroupByIter(
+	ctx *sql.Context,
+	selectedExprs, groupByExprs []sql.Expression,
+	child sql.RowIter,
+) *orderedGroupByIter {
+	return &orderedGroupByIter{
+		selectedExprs: selectedExprs,
+		groupByExprs:  groupByExprs,
+		child:         child,
+		ctx:           ctx,
+	}
+}
+
+func (i *orderedGroupByIter) Next() (sql.Row, error) {
+	if i.done {
+		return nil, io.EOF
+	}
+
+	for {
+		row, err := i.child.Next()
+		if err == io.EOF {
+			i.done = true
+			if i.buf == nil {
+				return nil, io.EOF
+			}
+			return i.flush()
+		} else if err != nil {
+			return nil, err
+		}
+
+		key, err := groupingKey(i.ctx, i.groupByExprs, row)
+		if err != nil {
+			return nil, err
+		}
+
+		// The first row of a new group completes the previous one
+		var out sql.Row
+		if i.buf != nil && key != i.key {
+			out, err = i.flush()
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if i.buf == nil {
+			i.buf = make([]sql.AggregationBuffer, len(i.selectedExprs))
+			for j, a := range i.selectedExprs {
+				i.buf[j], err = newAggregationBuffer(a)
+				if err != nil {
+					return nil, err
+				}
+			}
+			i.key = key
+		}
+
+		if err := updateBuffers(i.ctx, i.buf, row); err != nil {
+			return nil, err
+		}
+
+		if out != nil {
+			return out, nil
+		}
+	}
+}
+
+// flush returns the result of the current group and discards its buffers.
+func (i *orderedGroupByIter) flush() (sql.Row, error) {
+	row, err := evalBuffers(i.ctx, i.buf)
+	i.Dispose()
+	i.buf = nil
+	return row, err
+}
+
+func (i *orderedGroupByIter) Close(ctx *sql.Context) error {
+	i.Dispose()
+	i.buf = nil
+	return i.child.Close(ctx)
+}
+
+func (i *orderedGroupByIter) Dispose() {
+	for _, b := range i.buf {
+		b.Dispose()
+	}
+}
+
+// groupingKey returns the key of the group of the row given, which is the hash of the values of the grouping
+// expressions. NULL values are equal for grouping, so all the rows whose grouping values are NULL are in one group.
 func groupingKey(
 	ctx *sql.Context,
 	exprs []sql.Expression,
 	row sql.Row,
 ) (uint64, error) {
-	hash := xxhash.New()
-	for _, expr := range exprs {
+	vals := make(sql.Row, len(exprs))
+	for i, expr := range exprs {
 		v, err := expr.Eval(ctx, row)
 		if err != nil {
 			return 0, err
 		}
-		_, err = hash.Write(([]byte)(fmt.Sprintf("%#v,", v)))
-		if err != nil {
-			return 0, err
-		}
+		vals[i] = v
 	}
 
-	return hash.Sum64(), nil
+	return sql.HashOf(vals)
 }
 
 func newAggregationBuffer(expr sql.Expression) (sql.AggregationBuffer, error) {
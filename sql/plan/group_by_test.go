@@ -168,6 +168,127 @@ func TestGroupByAggregationGrouping(t *testing.T) {
 	require.Equal(expected, rows)
 }
 
+func TestGroupByNullGroup(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewEmptyContext()
+
+	childSchema := sql.Schema{
+		{Name: "col1", Type: sql.LongText, Nullable: true},
+		{Name: "col2", Type: sql.Int64, Nullable: true},
+	}
+	child := memory.NewTable("test", sql.NewPrimaryKeySchema(childSchema))
+
+	rows := []sql.Row{
+		sql.NewRow("col1_1", nil),
+		sql.NewRow(nil, nil),
+		sql.NewRow("col1_1", int64(1111)),
+		sql.NewRow(nil, nil),
+		sql.NewRow("col1_1", nil),
+		sql.NewRow(nil, int64(1111)),
+	}
+
+	for _, r := range rows {
+		require.NoError(child.Insert(sql.NewEmptyContext(), r))
+	}
+
+	p := NewGroupBy(
+		[]sql.Expression{
+			expression.NewGetField(1, sql.Int64, "col2", true),
+			aggregation.NewCount(expression.NewStar()),
+		},
+		[]sql.Expression{
+			expression.NewGetField(1, sql.Int64, "col2", true),
+		},
+		NewResolvedTable(child, nil, nil),
+	)
+
+	rows, err := sql.NodeToRows(ctx, p)
+	require.NoError(err)
+	require.ElementsMatch([]sql.Row{{nil, int64(4)}, {int64(1111), int64(2)}}, rows)
+
+	p = NewGroupBy(
+		[]sql.Expression{
+			expression.NewGetField(0, sql.LongText, "col1", true),
+			expression.NewGetField(1, sql.Int64, "col2", true),
+			aggregation.NewCount(expression.NewStar()),
+		},
+		[]sql.Expression{
+			expression.NewGetField(0, sql.LongText, "col1", true),
+			expression.NewGetField(1, sql.Int64, "col2", true),
+		},
+		NewResolvedTable(child, nil, nil),
+	)
+
+	rows, err = sql.NodeToRows(ctx, p)
+	require.NoError(err)
+	require.ElementsMatch([]sql.Row{
+		{"col1_1", nil, int64(2)},
+		{nil, nil, int64(2)},
+		{"col1_1", int64(1111), int64(1)},
+		{nil, int64(1111), int64(1)},
+	}, rows)
+}
+
+func TestOrderedGroupByRowIter(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewEmptyContext()
+
+	childSchema := sql.Schema{
+		{Name: "col1", Type: sql.LongText},
+		{Name: "col2", Type: sql.Int64},
+	}
+	child := memory.NewTable("test", sql.NewPrimaryKeySchema(childSchema))
+
+	rows := []sql.Row{
+		sql.NewRow("col1_1", int64(1)),
+		sql.NewRow("col1_2", int64(2)),
+		sql.NewRow("col1_1", int64(3)),
+		sql.NewRow("col1_3", int64(4)),
+		sql.NewRow("col1_2", int64(5)),
+	}
+
+	for _, r := range rows {
+		require.NoError(child.Insert(sql.NewEmptyContext(), r))
+	}
+
+	p := NewOrderedGroupBy(
+		[]sql.Expression{
+			expression.NewGetField(0, sql.LongText, "col1", true),
+			aggregation.NewSum(expression.NewGetField(1, sql.Int64, "col2", true)),
+		},
+		[]sql.Expression{
+			expression.NewGetField(0, sql.LongText, "col1", true),
+		},
+		NewSort(
+			[]sql.SortField{
+				{
+					Column: expression.NewGetField(0, sql.LongText, "col1", true),
+					Order:  sql.Ascending,
+				},
+			},
+			NewResolvedTable(child, nil, nil),
+		),
+	)
+
+	rows, err := sql.NodeToRows(ctx, p)
+	require.NoError(err)
+
+	expected := []sql.Row{
+		{"col1_1", float64(4)},
+		{"col1_2", float64(7)},
+		{"col1_3", float64(4)},
+	}
+
+	require.Equal(expected, rows)
+
+	empty := memory.NewTable("empty", sql.NewPrimaryKeySchema(childSchema))
+	n, err := p.WithChildren(NewResolvedTable(empty, nil, nil))
+	require.NoError(err)
+	rows, err = sql.NodeToRows(ctx, n)
+	require.NoError(err)
+	require.Len(rows, 0)
+}
+
 func BenchmarkGroupBy(b *testing.B) {
 	table := benchmarkTable(b)
 
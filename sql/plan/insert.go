@@ -69,6 +69,11 @@ type InsertInto struct {
 	OnDupExprs  []sql.Expression
 	Checks      sql.CheckConstraints
 	Ignore      bool
+	// Priority is the LOW_PRIORITY, HIGH_PRIORITY or DELAYED modifier given to the statement, if any.
+	Priority Priority
+	// NoBatching is set when each row must be inserted before the next one is evaluated, as AFTER INSERT triggers
+	// require, even if the table can insert rows in batches.
+	NoBatching bool
 }
 
 var _ sql.Databaser = (*InsertInto)(nil)
@@ -89,7 +94,9 @@ func NewInsertInto(db sql.Database, dst, src sql.Node, isReplace bool, cols []st
 
 // Schema implements the sql.Node interface.
 // Insert nodes return rows that are inserted. Replaces return a concatenation of the deleted row and the inserted row.
-// If no row was deleted, the value of those columns is nil.
+// If no row was deleted, the value of those columns is nil. When an inserted row replaces several rows, because it
+// conflicts with them on different unique keys, the other rows deleted are returned on their own, without the second
+// half.
 func (ii *InsertInto) Schema() sql.Schema {
 	if ii.IsReplace {
 		return append(ii.Destination.Schema(), ii.Destination.Schema()...)
@@ -111,9 +118,14 @@ func (ii *InsertInto) WithDatabase(database sql.Database) (sql.Node, error) {
 	return &nc, nil
 }
 
+// insertBatchSize is the number of rows handed over at once to tables implementing sql.InsertBatcher.
+const insertBatchSize = 1000
+
 type insertIter struct {
 	schema              sql.Schema
 	inserter            sql.RowInserter
+	batcher             sql.InsertBatcher
+	batchResults        []insertResult
 	replacer            sql.RowReplacer
 	updater             sql.RowUpdater
 	rowSource           sql.RowIter
@@ -125,6 +137,13 @@ type insertIter struct {
 	tableNode           sql.Node
 	closed              bool
 	ignore              bool
+	// Whether values that don't fit their columns are errors, rather than adjusted with a warning
+	strict bool
+	// Number of the row being inserted, counted from 1
+	rowNumber int
+	// Rows deleted by the last REPLACE other than the one returned with the inserted row, which Next returns before
+	// inserting the next row
+	replaced []sql.Row
 }
 
 func GetInsertable(node sql.Node) (sql.InsertableTable, error) {
@@ -164,6 +183,7 @@ func newInsertIter(
 	checks sql.CheckConstraints,
 	row sql.Row,
 	ignore bool,
+	batch bool,
 ) (sql.RowIter, error) {
 	dstSchema := table.Schema()
 
@@ -204,6 +224,10 @@ func newInsertIter(
 		checks:      checks,
 		ctx:         ctx,
 		ignore:      ignore,
+		strict:      !ignore && sql.IsStrictSQLMode(ctx),
+	}
+	if batcher, ok := inserter.(sql.InsertBatcher); ok && batch {
+		insertIter.batcher = batcher
 	}
 
 	if replacer != nil {
@@ -226,7 +250,38 @@ func getInsertExpressions(values sql.Node) []sql.Expression {
 	return exprs
 }
 
+// isBatchable returns whether the rows of the source given can be inserted in batches, which is the case when they
+// come from a list of values that doesn't read any table, and so can't depend on the rows inserted before them.
+func isBatchable(source sql.Node) bool {
+	batchable := true
+	Inspect(source, func(node sql.Node) bool {
+		switch node.(type) {
+		case nil, *Values, *Project:
+		default:
+			batchable = false
+		}
+		return batchable
+	})
+	InspectExpressions(source, func(e sql.Expression) bool {
+		if _, ok := e.(*Subquery); ok {
+			batchable = false
+		}
+		return batchable
+	})
+	return batchable
+}
+
 func (i *insertIter) Next() (returnRow sql.Row, returnErr error) {
+	if i.batcher != nil {
+		return i.nextFromBatch()
+	}
+
+	if len(i.replaced) > 0 {
+		row := i.replaced[0]
+		i.replaced = i.replaced[1:]
+		return row, nil
+	}
+
 	row, err := i.rowSource.Next()
 	if err == io.EOF {
 		return nil, err
@@ -236,15 +291,101 @@ func (i *insertIter) Next() (returnRow sql.Row, returnErr error) {
 		return i.ignoreOrClose(row, err)
 	}
 
+	row, ok, err := i.prepareRow(row)
+	if !ok {
+		return row, err
+	}
+
+	if i.replacer != nil {
+		return i.replaceRow(row)
+	}
+	return i.insertRow(row)
+}
+
+// insertResult is the result of inserting a row in a batch, which Next returns in turn.
+type insertResult struct {
+	row sql.Row
+	err error
+}
+
+// nextFromBatch returns the result of inserting the next row, inserting a new batch of rows if the results of the
+// previous one have all been returned.
+func (i *insertIter) nextFromBatch() (sql.Row, error) {
+	if len(i.batchResults) == 0 {
+		i.insertBatch()
+	}
+
+	result := i.batchResults[0]
+	// The end of the rows is returned for any further call
+	if result.err != io.EOF {
+		i.batchResults = i.batchResults[1:]
+	}
+	return result.row, result.err
+}
+
+// insertBatch evaluates the next rows of the source and inserts them all at once, recording the results for Next. The
+// batch ends early on a row that can't be inserted, whose result is recorded after the ones of the rows before it.
+func (i *insertIter) insertBatch() {
+	var batch []sql.Row
+	var last *insertResult
+	for len(batch) < insertBatchSize && last == nil {
+		row, err := i.rowSource.Next()
+		if err == io.EOF {
+			last = &insertResult{nil, err}
+			break
+		}
+		if err != nil {
+			row, err = i.ignoreOrClose(row, err)
+			last = &insertResult{row, err}
+			break
+		}
+
+		row, ok, err := i.prepareRow(row)
+		if !ok {
+			last = &insertResult{row, err}
+			break
+		}
+		batch = append(batch, row)
+	}
+
+	if len(batch) > 0 {
+		if err := i.batcher.InsertBatch(i.ctx, batch); err == nil {
+			for _, row := range batch {
+				i.updateLastInsertId(i.ctx, row)
+				i.batchResults = append(i.batchResults, insertResult{row, nil})
+			}
+		} else {
+			// Insert the rows one at a time to handle the row that couldn't be inserted
+			for _, row := range batch {
+				row, err := i.insertRow(row)
+				i.batchResults = append(i.batchResults, insertResult{row, err})
+				if _, ok := err.(sql.ErrInsertIgnore); err != nil && !ok {
+					return
+				}
+			}
+		}
+	}
+
+	if last != nil {
+		i.batchResults = append(i.batchResults, *last)
+	}
+}
+
+// prepareRow validates the row given and converts it to the schema of the table. Returns false along with the result
+// Next must return if the row can't be inserted.
+func (i *insertIter) prepareRow(row sql.Row) (sql.Row, bool, error) {
+	i.rowNumber++
+
 	// Prune the row down to the size of the schema. It can be larger in the case of running with an outer scope, in which
 	// case the additional scope variables are prepended to the row.
 	if len(row) > len(i.schema) {
 		row = row[len(row)-len(i.schema):]
 	}
 
-	err = i.validateNullability(i.schema, row)
+	err := i.validateNullability(i.schema, row)
 	if err != nil {
-		return i.ignoreOrClose(row, err)
+		row, err = i.ignoreOrClose(row, err)
+		return row, false, err
 	}
 
 	// apply check constraints
@@ -256,62 +397,76 @@ func (i *insertIter) Next() (returnRow sql.Row, returnErr error) {
 		res, err := sql.EvaluateCondition(i.ctx, check.Expr, row)
 
 		if err != nil {
-			return nil, i.warnOnIgnorableError(row, err)
+			return nil, false, i.warnOnIgnorableError(row, err)
 		}
 
-		if sql.IsFalse(res) {
-			return nil, sql.NewWrappedInsertError(row, sql.ErrCheckConstraintViolated.New(check.Name))
+		if err := check.Violation(res); err != nil {
+			return nil, false, sql.NewWrappedInsertError(row, err)
 		}
 	}
 
 	// Do any necessary type conversions to the target schema
-	for i, col := range i.schema {
-		if row[i] != nil {
-			converted, err := col.Type.Convert(row[i]) // allows for better error handling
+	for idx, col := range i.schema {
+		if row[idx] != nil {
+			converted, err := sql.ConvertColumnValue(i.ctx, col.Name, col.Type, row[idx], i.rowNumber, i.strict)
 			if err != nil {
-				return nil, sql.NewWrappedInsertError(row, err)
+				return nil, false, sql.NewWrappedInsertError(row, err)
 			}
-			row[i] = converted
+			row[idx] = converted
 		}
 	}
 
-	if i.replacer != nil {
-		toReturn := make(sql.Row, len(row)*2)
-		for i := 0; i < len(row); i++ {
-			toReturn[i+len(row)] = row[i]
+	return row, true, nil
+}
+
+// replaceRow inserts the row given, deleting every row it conflicts with on the primary key or on a unique key first.
+// Returns the last row deleted, if any, followed by the row inserted. The other rows deleted are kept for Next.
+func (i *insertIter) replaceRow(row sql.Row) (sql.Row, error) {
+	toReturn := make(sql.Row, len(row)*2)
+	for i := 0; i < len(row); i++ {
+		toReturn[i+len(row)] = row[i]
+	}
+
+	// The row may conflict with a different row on each of the unique indexes of the table, and every one of them is
+	// deleted. The deletes go through the replacer as any other, so that integrators can apply their foreign keys.
+	//TODO: how does this interact with triggers?
+	var deleted sql.Row
+	for {
+		err := i.replacer.Insert(i.ctx, row)
+		if err == nil {
+			break
+		}
+		if !sql.ErrPrimaryKeyViolation.Is(err) && !sql.ErrUniqueKeyViolation.Is(err) {
+			_ = i.rowSource.Close(i.ctx)
+			return nil, sql.NewWrappedInsertError(row, err)
 		}
-		// May have multiple duplicate pk & unique errors due to multiple indexes
-		//TODO: how does this interact with triggers?
-		for {
-			if err := i.replacer.Insert(i.ctx, row); err != nil {
-				if !sql.ErrPrimaryKeyViolation.Is(err) && !sql.ErrUniqueKeyViolation.Is(err) {
-					_ = i.rowSource.Close(i.ctx)
-					return nil, sql.NewWrappedInsertError(row, err)
-				}
 
-				ue := err.(*errors.Error).Cause().(sql.UniqueKeyError)
-				if err = i.replacer.Delete(i.ctx, ue.Existing); err != nil {
-					_ = i.rowSource.Close(i.ctx)
-					return nil, sql.NewWrappedInsertError(row, err)
-				}
-				// the row had to be deleted, write the values into the toReturn row
-				for i := 0; i < len(ue.Existing); i++ {
-					toReturn[i] = ue.Existing[i]
-				}
-			} else {
-				break
-			}
+		ue := err.(*errors.Error).Cause().(sql.UniqueKeyError)
+		if err = i.replacer.Delete(i.ctx, ue.Existing); err != nil {
+			_ = i.rowSource.Close(i.ctx)
+			return nil, sql.NewWrappedInsertError(row, err)
 		}
-		return toReturn, nil
-	} else {
-		if err := i.inserter.Insert(i.ctx, row); err != nil {
-			if (!sql.ErrPrimaryKeyViolation.Is(err) && !sql.ErrUniqueKeyViolation.Is(err) && !sql.ErrDuplicateEntry.Is(err)) || len(i.updateExprs) == 0 {
-				return i.ignoreOrClose(row, err)
-			}
+		if deleted != nil {
+			i.replaced = append(i.replaced, deleted)
+		}
+		deleted = ue.Existing
+	}
+
+	// the row had to be deleted, write the values into the toReturn row
+	copy(toReturn, deleted)
+	return toReturn, nil
+}
 
-			ue := err.(*errors.Error).Cause().(sql.UniqueKeyError)
-			return i.handleOnDuplicateKeyUpdate(row, ue.Existing)
+// insertRow inserts the row given, updating the existing row instead for ON DUPLICATE KEY UPDATE if the row has a
+// duplicate key.
+func (i *insertIter) insertRow(row sql.Row) (sql.Row, error) {
+	if err := i.inserter.Insert(i.ctx, row); err != nil {
+		if (!sql.ErrPrimaryKeyViolation.Is(err) && !sql.ErrUniqueKeyViolation.Is(err) && !sql.ErrDuplicateEntry.Is(err)) || len(i.updateExprs) == 0 {
+			return i.ignoreOrClose(row, err)
 		}
+
+		ue := err.(*errors.Error).Cause().(sql.UniqueKeyError)
+		return i.handleOnDuplicateKeyUpdate(row, ue.Existing)
 	}
 
 	i.updateLastInsertId(i.ctx, row)
@@ -488,7 +643,15 @@ func toInt64(x interface{}) int64 {
 
 // RowIter implements the Node interface.
 func (ii *InsertInto) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
-	return newInsertIter(ctx, ii.Destination, ii.Source, ii.IsReplace, ii.OnDupExprs, ii.Checks, row, ii.Ignore)
+	if ii.Priority == PriorityDelayed {
+		statement := "INSERT"
+		if ii.IsReplace {
+			statement = "REPLACE"
+		}
+		ctx.Warn(legacySyntaxConvertedCode, "%s DELAYED is no longer supported. The statement was converted to %s.", statement, statement)
+	}
+	batch := !ii.NoBatching && isBatchable(ii.Source)
+	return newInsertIter(ctx, ii.Destination, ii.Source, ii.IsReplace, ii.OnDupExprs, ii.Checks, row, ii.Ignore, batch)
 }
 
 // WithChildren implements the Node interface.
@@ -516,19 +679,34 @@ func (ii InsertInto) String() string {
 	} else {
 		_ = pr.WriteNode("Insert(%s)", strings.Join(ii.ColumnNames, ", "))
 	}
-	_ = pr.WriteChildren(ii.Destination.String(), ii.Source.String())
+	children := []string{ii.Destination.String(), ii.Source.String()}
+	if len(ii.OnDupExprs) > 0 {
+		onDupExprs := make([]string, len(ii.OnDupExprs))
+		for i, e := range ii.OnDupExprs {
+			onDupExprs[i] = e.String()
+		}
+		children = append(children, fmt.Sprintf("OnDuplicateKeyUpdate(%s)", strings.Join(onDupExprs, ", ")))
+	}
+	_ = pr.WriteChildren(children...)
 	return pr.String()
 }
 
 func (ii InsertInto) DebugString() string {
 	pr := sql.NewTreePrinter()
-	var columnNames []string
 	if ii.IsReplace {
-		_ = pr.WriteNode("Replace(%s)", strings.Join(columnNames, ", "))
+		_ = pr.WriteNode("Replace(%s)", strings.Join(ii.ColumnNames, ", "))
 	} else {
-		_ = pr.WriteNode("Insert(%s)", strings.Join(columnNames, ", "))
+		_ = pr.WriteNode("Insert(%s)", strings.Join(ii.ColumnNames, ", "))
+	}
+	children := []string{sql.DebugString(ii.Destination), sql.DebugString(ii.Source)}
+	if len(ii.OnDupExprs) > 0 {
+		onDupExprs := make([]string, len(ii.OnDupExprs))
+		for i, e := range ii.OnDupExprs {
+			onDupExprs[i] = sql.DebugString(e)
+		}
+		children = append(children, fmt.Sprintf("OnDuplicateKeyUpdate(%s)", strings.Join(onDupExprs, ", ")))
 	}
-	_ = pr.WriteChildren(sql.DebugString(ii.Destination), sql.DebugString(ii.Source))
+	_ = pr.WriteChildren(children...)
 	return pr.String()
 }
 
@@ -0,0 +1,105 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plan
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/memory"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+// batchCountingTable is a table counting the batches and the single rows its inserter is given.
+type batchCountingTable struct {
+	*memory.Table
+	batches, rows int
+}
+
+func (t *batchCountingTable) Inserter(ctx *sql.Context) sql.RowInserter {
+	return &batchCountingInserter{t.Table.Inserter(ctx).(sql.InsertBatcher), t}
+}
+
+type batchCountingInserter struct {
+	sql.InsertBatcher
+	table *batchCountingTable
+}
+
+func (i *batchCountingInserter) Insert(ctx *sql.Context, row sql.Row) error {
+	i.table.rows++
+	return i.InsertBatcher.Insert(ctx, row)
+}
+
+func (i *batchCountingInserter) InsertBatch(ctx *sql.Context, rows []sql.Row) error {
+	i.table.batches++
+	return i.InsertBatcher.InsertBatch(ctx, rows)
+}
+
+func TestInsertBatches(t *testing.T) {
+	newInsert := func(table sql.Table, pks ...int) *InsertInto {
+		tuples := make([][]sql.Expression, len(pks))
+		for i, pk := range pks {
+			tuples[i] = []sql.Expression{expression.NewLiteral(int64(pk), sql.Int64)}
+		}
+		return NewInsertInto(nil, NewResolvedTable(table, nil, nil), NewValues(tuples), false, []string{"pk"}, nil, false)
+	}
+	schema := sql.NewPrimaryKeySchema(sql.Schema{{Name: "pk", Type: sql.Int64, PrimaryKey: true, Source: "t"}})
+
+	t.Run("many rows", func(t *testing.T) {
+		require := require.New(t)
+		table := &batchCountingTable{Table: memory.NewTable("t", schema)}
+		pks := make([]int, 2*insertBatchSize+1)
+		for i := range pks {
+			pks[i] = i
+		}
+
+		ctx := sql.NewEmptyContext()
+		rows, err := sql.NodeToRows(ctx, newInsert(table, pks...))
+		require.NoError(err)
+		require.Len(rows, len(pks))
+		require.Equal(3, table.batches)
+		require.Equal(0, table.rows)
+
+		rows, err = sql.NodeToRows(ctx, NewResolvedTable(table, nil, nil))
+		require.NoError(err)
+		require.Len(rows, len(pks))
+	})
+
+	t.Run("duplicate key", func(t *testing.T) {
+		require := require.New(t)
+		table := &batchCountingTable{Table: memory.NewTable("t", schema)}
+
+		ctx := sql.NewEmptyContext()
+		_, err := sql.NodeToRows(ctx, newInsert(table, 1, 2, 1))
+		require.Error(err)
+		require.True(sql.ErrPrimaryKeyViolation.Is(err.(sql.WrappedInsertError).Cause))
+		require.Equal(1, table.batches)
+		require.Equal(3, table.rows)
+	})
+
+	t.Run("no batching", func(t *testing.T) {
+		require := require.New(t)
+		table := &batchCountingTable{Table: memory.NewTable("t", schema)}
+		insert := newInsert(table, 1, 2, 3)
+		insert.NoBatching = true
+
+		_, err := sql.NodeToRows(sql.NewEmptyContext(), insert)
+		require.NoError(err)
+		require.Equal(0, table.batches)
+		require.Equal(3, table.rows)
+	})
+}
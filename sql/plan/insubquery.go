@@ -40,73 +40,30 @@ func NewInSubquery(left sql.Expression, right sql.Expression) *InSubquery {
 	return &InSubquery{expression.BinaryExpression{Left: left, Right: right}}
 }
 
-var nilKey, _ = sql.HashOf(sql.NewRow(nil))
-
 // Eval implements the Expression interface.
 func (in *InSubquery) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
-	typ := in.Left.Type().Promote()
 	left, err := in.Left.Eval(ctx, row)
 	if err != nil {
 		return nil, err
 	}
 
-	// The NULL handling for IN expressions is tricky. According to
-	// https://dev.mysql.com/doc/refman/8.0/en/comparison-operators.html#operator_in:
-	// To comply with the SQL standard, IN() returns NULL not only if the expression on the left hand side is NULL, but
-	// also if no match is found in the list and one of the expressions in the list is NULL.
-	// However, there's a strange edge case. NULL IN (empty list) return 0, not NULL.
-	leftNull := left == nil
-
-	left, err = typ.Convert(left)
-	if err != nil {
-		return nil, err
-	}
-
 	switch right := in.Right.(type) {
 	case *Subquery:
-		if sql.NumColumns(typ) != sql.NumColumns(right.Type()) {
-			return nil, sql.ErrInvalidOperandColumns.New(sql.NumColumns(typ), sql.NumColumns(right.Type()))
-		}
-
-		typ := right.Type()
-
-		values, err := right.HashMultiple(ctx, row)
-		if err != nil {
-			return nil, err
-		}
-
-		// NULL IN (list) returns NULL. NULL IN (empty list) returns 0
-		if leftNull {
-			if values.Size() == 0 {
-				return false, nil
-			}
-			return nil, nil
-		}
-
-		key, err := sql.HashOf(sql.NewRow(left))
-		if err != nil {
-			return nil, err
-		}
-
-		val, notFoundErr := values.Get(key)
-		if notFoundErr != nil {
-			if _, nilValNotFoundErr := values.Get(nilKey); nilValNotFoundErr == nil {
-				return nil, nil
-			}
-			return false, nil
-		}
-
-		val, err = typ.Convert(val)
-		if err != nil {
-			return nil, err
+		leftCols, rightCols := sql.NumColumns(in.Left.Type()), sql.NumColumns(right.Type())
+		if leftCols != rightCols {
+			return nil, sql.ErrInvalidOperandColumns.New(leftCols, rightCols)
 		}
 
-		cmp, err := typ.Compare(left, val)
+		// The NULL handling for IN expressions is tricky. According to
+		// https://dev.mysql.com/doc/refman/8.0/en/comparison-operators.html#operator_in:
+		// To comply with the SQL standard, IN() returns NULL not only if the expression on the left hand side is NULL,
+		// but also if no match is found in the list and one of the expressions in the list is NULL.
+		// However, there's a strange edge case. NULL IN (empty list) return 0, not NULL.
+		values, err := right.HashedResults(ctx, row, in.Left.Type())
 		if err != nil {
 			return nil, err
 		}
-
-		return cmp == 0, nil
+		return values.Contains(ctx, left)
 
 	default:
 		return nil, expression.ErrUnsupportedInOperand.New(right)
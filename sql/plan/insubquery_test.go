@@ -93,6 +93,62 @@ func TestInSubquery(t *testing.T) {
 			false,
 			nil,
 		},
+		{
+			"left is not in right with NULL",
+			expression.NewGetField(0, sql.Text, "foo", false),
+			project(
+				expression.NewCase(
+					expression.NewGetField(1, sql.Text, "foo", false),
+					[]expression.CaseBranch{{Cond: expression.NewLiteral("two", sql.Text), Value: expression.NewLiteral(nil, sql.Null)}},
+					expression.NewGetField(1, sql.Text, "foo", false),
+				),
+			),
+			sql.NewRow("four"),
+			nil,
+			nil,
+		},
+		{
+			"left tuple is in right",
+			expression.NewTuple(
+				expression.NewGetField(0, sql.Text, "foo", false),
+				expression.NewLiteral(int64(1), sql.Int64),
+			),
+			plan.NewProject([]sql.Expression{
+				expression.NewGetField(1, sql.Text, "foo", false),
+				expression.NewLiteral(int8(1), sql.Int8),
+			}, plan.NewResolvedTable(table, nil, nil)),
+			sql.NewRow("two"),
+			true,
+			nil,
+		},
+		{
+			"left tuple with NULL is not in right",
+			expression.NewTuple(
+				expression.NewGetField(0, sql.Text, "foo", false),
+				expression.NewLiteral(nil, sql.Null),
+			),
+			plan.NewProject([]sql.Expression{
+				expression.NewGetField(1, sql.Text, "foo", false),
+				expression.NewLiteral(int8(1), sql.Int8),
+			}, plan.NewResolvedTable(table, nil, nil)),
+			sql.NewRow("four"),
+			false,
+			nil,
+		},
+		{
+			"left tuple with NULL might be in right",
+			expression.NewTuple(
+				expression.NewGetField(0, sql.Text, "foo", false),
+				expression.NewLiteral(nil, sql.Null),
+			),
+			plan.NewProject([]sql.Expression{
+				expression.NewGetField(1, sql.Text, "foo", false),
+				expression.NewLiteral(int8(1), sql.Int8),
+			}, plan.NewResolvedTable(table, nil, nil)),
+			sql.NewRow("two"),
+			nil,
+			nil,
+		},
 	}
 
 	for _, tt := range testCases {
@@ -0,0 +1,131 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plan
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+// Into represents the INTO clause of a SELECT ... INTO statement. Its child is the SELECT, and running it assigns the
+// columns of the row selected to its variables, which are user variables or the parameters and local variables of a
+// stored procedure or function, and returns no rows.
+type Into struct {
+	UnaryNode
+	IntoVars []sql.Expression
+}
+
+var _ sql.Node = (*Into)(nil)
+var _ sql.Expressioner = (*Into)(nil)
+
+// NewInto returns an *Into node assigning the row selected by the child given to the variables given.
+func NewInto(child sql.Node, vars []sql.Expression) *Into {
+	return &Into{UnaryNode: UnaryNode{Child: child}, IntoVars: vars}
+}
+
+// Resolved implements the sql.Node interface.
+func (i *Into) Resolved() bool {
+	return i.Child.Resolved() && expression.ExpressionsResolved(i.IntoVars...)
+}
+
+// String implements the sql.Node interface.
+func (i *Into) String() string {
+	vars := make([]string, len(i.IntoVars))
+	for j, v := range i.IntoVars {
+		vars[j] = v.String()
+	}
+	p := sql.NewTreePrinter()
+	_ = p.WriteNode("Into(%s)", strings.Join(vars, ", "))
+	_ = p.WriteChildren(i.Child.String())
+	return p.String()
+}
+
+// Schema implements the sql.Node interface.
+func (i *Into) Schema() sql.Schema {
+	return nil
+}
+
+// Expressions implements the sql.Expressioner interface.
+func (i *Into) Expressions() []sql.Expression {
+	return i.IntoVars
+}
+
+// WithExpressions implements the sql.Expressioner interface.
+func (i *Into) WithExpressions(exprs ...sql.Expression) (sql.Node, error) {
+	if len(exprs) != len(i.IntoVars) {
+		return nil, sql.ErrInvalidChildrenNumber.New(i, len(exprs), len(i.IntoVars))
+	}
+	return NewInto(i.Child, exprs), nil
+}
+
+// WithChildren implements the sql.Node interface.
+func (i *Into) WithChildren(children ...sql.Node) (sql.Node, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(i, len(children), 1)
+	}
+	return NewInto(children[0], i.IntoVars), nil
+}
+
+// RowIter implements the sql.Node interface. The variables are left unchanged, with a warning, if no row is selected,
+// and selecting more than one row is an error.
+func (i *Into) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
+	schema := i.Child.Schema()
+	if len(schema) != len(i.IntoVars) {
+		return nil, sql.ErrSelectIntoColumnCount.New()
+	}
+
+	iter, err := i.Child.RowIter(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+	selected, err := iter.Next()
+	if err == io.EOF {
+		ctx.Warn(1329, "No data - zero rows fetched, selected, or processed")
+		return sql.RowsToRowIter(), iter.Close(ctx)
+	}
+	if err == nil {
+		if _, err = iter.Next(); err == nil {
+			err = sql.ErrSelectIntoMultipleRows.New()
+		} else if err == io.EOF {
+			err = nil
+		}
+	}
+	if err != nil {
+		_ = iter.Close(ctx)
+		return nil, err
+	}
+	if err := iter.Close(ctx); err != nil {
+		return nil, err
+	}
+
+	for j, v := range i.IntoVars {
+		switch v := v.(type) {
+		case *expression.ProcedureParam:
+			err = v.Set(selected[j], schema[j].Type)
+		case *expression.UserVar:
+			err = ctx.SetUserVariable(ctx, v.Name, selected[j])
+		default:
+			err = fmt.Errorf("unsupported type for SELECT ... INTO: %T", v)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return sql.RowsToRowIter(), nil
+}
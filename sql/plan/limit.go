@@ -15,7 +15,6 @@
 package plan
 
 import (
-	"fmt"
 	"io"
 
 	opentracing "github.com/opentracing/opentracing-go"
@@ -113,9 +112,15 @@ func getInt64Value(ctx *sql.Context, expr sql.Expression) (int64, error) {
 		return int64(i), nil
 	case uint64:
 		return int64(i), nil
+	case nil:
+		return 0, sql.ErrInvalidType.New("NULL")
 	default:
-		// analyzer should catch this already
-		panic(fmt.Sprintf("Unsupported type for limit %T", i))
+		// Computed limits, such as a limit plus an offset, may evaluate to a non-integer type
+		v, err := sql.Int64.Convert(i)
+		if err != nil {
+			return 0, err
+		}
+		return v.(int64), nil
 	}
 }
 
@@ -0,0 +1,100 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plan
+
+import (
+	opentracing "github.com/opentracing/opentracing-go"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// LockingRead is a node for the locking clause of a SELECT, as in SELECT ... FOR UPDATE SKIP LOCKED. When it's executed,
+// the tables of its child that implement sql.LockableTable are read with the lock; other tables are read as usual.
+// Tables in subquery expressions aren't locked, as they have locking clauses of their own.
+type LockingRead struct {
+	UnaryNode
+	Lock sql.RowLock
+}
+
+// NewLockingRead creates a new LockingRead node.
+func NewLockingRead(lock sql.RowLock, child sql.Node) *LockingRead {
+	return &LockingRead{
+		UnaryNode: UnaryNode{Child: child},
+		Lock:      lock,
+	}
+}
+
+// RowIter implements the Node interface.
+func (l *LockingRead) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
+	span, ctx := ctx.Span("plan.LockingRead", opentracing.Tag{Key: "lock", Value: l.Lock.String()})
+
+	child, err := lockTables(l.Child, l.Lock)
+	if err != nil {
+		span.Finish()
+		return nil, err
+	}
+
+	iter, err := child.RowIter(ctx, row)
+	if err != nil {
+		span.Finish()
+		return nil, err
+	}
+	return sql.NewSpanIter(span, iter), nil
+}
+
+// WithChildren implements the Node interface.
+func (l *LockingRead) WithChildren(children ...sql.Node) (sql.Node, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(l, len(children), 1)
+	}
+	return NewLockingRead(l.Lock, children[0]), nil
+}
+
+func (l *LockingRead) String() string {
+	pr := sql.NewTreePrinter()
+	_ = pr.WriteNode("LockingRead(%s)", l.Lock)
+	_ = pr.WriteChildren(l.Child.String())
+	return pr.String()
+}
+
+func (l *LockingRead) DebugString() string {
+	pr := sql.NewTreePrinter()
+	_ = pr.WriteNode("LockingRead(%s)", l.Lock)
+	_ = pr.WriteChildren(sql.DebugString(l.Child))
+	return pr.String()
+}
+
+// lockTables returns the node given with the lock applied to all the tables in it that implement sql.LockableTable.
+func lockTables(node sql.Node, lock sql.RowLock) (sql.Node, error) {
+	return TransformUp(node, func(n sql.Node) (sql.Node, error) {
+		switch n := n.(type) {
+		case *ResolvedTable:
+			if t, ok := n.Table.(sql.LockableTable); ok {
+				return n.WithTable(t.WithRowLock(lock))
+			}
+		case *IndexedTableAccess:
+			if t, ok := n.ResolvedTable.Table.(sql.LockableTable); ok {
+				rt, err := n.ResolvedTable.WithTable(t.WithRowLock(lock))
+				if err != nil {
+					return nil, err
+				}
+				nn := *n
+				nn.ResolvedTable = rt
+				return &nn, nil
+			}
+		}
+		return n, nil
+	})
+}
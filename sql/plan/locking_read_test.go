@@ -0,0 +1,62 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plan
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/memory"
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+type lockableTable struct {
+	*memory.Table
+	locks *[]sql.RowLock
+}
+
+var _ sql.LockableTable = lockableTable{}
+
+func (t lockableTable) WithRowLock(lock sql.RowLock) sql.Table {
+	*t.locks = append(*t.locks, lock)
+	return t
+}
+
+func TestLockingRead(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewEmptyContext()
+
+	table, size := getTestingTable(t)
+	var locks []sql.RowLock
+	lockable := lockableTable{table, &locks}
+
+	lock := sql.RowLock{Strength: sql.RowLockForUpdate, Wait: sql.RowLockSkipLocked}
+	node := NewLockingRead(lock, NewCrossJoin(
+		NewResolvedTable(lockable, nil, nil),
+		NewResolvedTable(table, nil, nil),
+	))
+	require.Equal("FOR UPDATE SKIP LOCKED", lock.String())
+
+	iter, err := node.RowIter(ctx, nil)
+	require.NoError(err)
+	rows, err := sql.RowIterToRows(ctx, iter)
+	require.NoError(err)
+	require.Len(rows, size*size)
+	require.Equal([]sql.RowLock{lock}, locks)
+
+	lock = sql.RowLock{Strength: sql.RowLockForShare, Wait: sql.RowLockNoWait}
+	require.Equal("FOR SHARE NOWAIT", lock.String())
+}
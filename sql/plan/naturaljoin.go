@@ -14,19 +14,34 @@
 
 package plan
 
-import "github.com/dolthub/go-mysql-server/sql"
+import (
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
 
 // NaturalJoin is a join that automatically joins by all the columns with the
-// same name.
-// NaturalJoin is a placeholder node, it should be transformed into an INNER
-// JOIN during analysis.
+// same name, or by the columns with the same name listed in a USING clause.
+// NaturalJoin is a placeholder node, it should be transformed into an INNER,
+// LEFT or RIGHT JOIN during analysis.
 type NaturalJoin struct {
 	BinaryNode
+	// Type is the type of the join the node is transformed into.
+	Type JoinType
+	// Using are the names of the columns to join by, or nil to join by all the
+	// columns with the same name.
+	Using []string
 }
 
 // NewNaturalJoin returns a new NaturalJoin node.
 func NewNaturalJoin(left, right sql.Node) *NaturalJoin {
-	return &NaturalJoin{BinaryNode{left, right}}
+	return NewUsingJoin(left, right, JoinTypeInner, nil)
+}
+
+// NewUsingJoin returns a new NaturalJoin node of the type given, joining by
+// the columns named, or by all the columns with the same name if using is nil.
+func NewUsingJoin(left, right sql.Node, typ JoinType, using []string) *NaturalJoin {
+	return &NaturalJoin{BinaryNode{left, right}, typ, using}
 }
 
 // RowIter implements the Node interface.
@@ -44,7 +59,14 @@ func (NaturalJoin) Resolved() bool { return false }
 
 func (j NaturalJoin) String() string {
 	pr := sql.NewTreePrinter()
-	_ = pr.WriteNode("NaturalJoin")
+	switch {
+	case j.Using != nil:
+		_ = pr.WriteNode("%s(using: %s)", j.Type, strings.Join(j.Using, ", "))
+	case j.Type != JoinTypeInner:
+		_ = pr.WriteNode("Natural%s", j.Type)
+	default:
+		_ = pr.WriteNode("NaturalJoin")
+	}
 	_ = pr.WriteChildren(j.left.String(), j.right.String())
 	return pr.String()
 }
@@ -55,5 +77,5 @@ func (j *NaturalJoin) WithChildren(children ...sql.Node) (sql.Node, error) {
 		return nil, sql.ErrInvalidChildrenNumber.New(j, len(children), 2)
 	}
 
-	return NewNaturalJoin(children[0], children[1]), nil
+	return NewUsingJoin(children[0], children[1], j.Type, j.Using), nil
 }
@@ -0,0 +1,52 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plan
+
+// legacySyntaxConvertedCode is the code of the warning given when a statement uses a modifier that is no longer
+// supported and runs as if it hadn't been given.
+const legacySyntaxConvertedCode = 3005
+
+// Priority is the scheduling modifier given to a data modification statement, such as the LOW_PRIORITY of
+// UPDATE LOW_PRIORITY. These modifiers only matter to storage engines with table-level locking, so they are accepted
+// and recorded for integrators that want to honor them, but don't change how statements are executed. The
+// HIGH_PRIORITY modifier of SELECT is accepted but not recorded, as a SELECT has no node standing for the whole
+// statement to carry it.
+type Priority byte
+
+const (
+	// PriorityDefault is the priority of a statement given without a modifier.
+	PriorityDefault Priority = iota
+	// PriorityLow is given by the LOW_PRIORITY modifier.
+	PriorityLow
+	// PriorityHigh is given by the HIGH_PRIORITY modifier.
+	PriorityHigh
+	// PriorityDelayed is given by the DELAYED modifier of INSERT and REPLACE. MySQL no longer supports delayed inserts
+	// and runs them as normal inserts.
+	PriorityDelayed
+)
+
+// String returns the modifier keyword of the priority, or the empty string for the default priority.
+func (p Priority) String() string {
+	switch p {
+	case PriorityLow:
+		return "LOW_PRIORITY"
+	case PriorityHigh:
+		return "HIGH_PRIORITY"
+	case PriorityDelayed:
+		return "DELAYED"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plan
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// procedureTransaction threads the transaction of the session through the statements of a called procedure, so that
+// each of them begins and commits its transaction the same way it would if it was run on its own: a statement begins
+// a new transaction when the session has none, such as after a COMMIT or ROLLBACK in the body, and is committed on its
+// own when @@autocommit is on and no transaction was explicitly started. A nil *procedureTransaction, used for
+// databases that don't implement sql.TransactionDatabase, does nothing.
+type procedureTransaction struct {
+	db sql.TransactionDatabase
+}
+
+// newProcedureTransaction returns the procedureTransaction for a procedure called on the database given, which is nil
+// if the database doesn't support transactions.
+func newProcedureTransaction(db sql.Database) *procedureTransaction {
+	tdb, ok := db.(sql.TransactionDatabase)
+	if !ok {
+		return nil
+	}
+	return &procedureTransaction{db: tdb}
+}
+
+// threadThrough sets the transaction on all the blocks of the procedure given. Statements that aren't blocks aren't
+// descended into, as the blocks in them, such as the bodies of triggers, run as part of a single statement.
+func (t *procedureTransaction) threadThrough(proc *Procedure) {
+	Inspect(proc, func(n sql.Node) bool {
+		switch n := n.(type) {
+		case *Block:
+			n.txn = t
+		case *BeginEndBlock:
+			n.txn = t
+		case *Procedure, *IfElseBlock, *IfConditional:
+		default:
+			return false
+		}
+		return true
+	})
+}
+
+// beginStatement begins a new transaction for the statement about to run if the session has none.
+func (t *procedureTransaction) beginStatement(ctx *sql.Context) error {
+	if t == nil || ctx.GetTransaction() != nil {
+		return nil
+	}
+
+	tx, err := sql.StartTransaction(ctx, t.db, sql.ReadWrite)
+	if err != nil {
+		return err
+	}
+	ctx.SetTransaction(tx)
+	return nil
+}
+
+// endStatement commits the statement that was just run if it should be committed on its own.
+func (t *procedureTransaction) endStatement(ctx *sql.Context) error {
+	tx, err := t.autocommitted(ctx)
+	if err != nil || tx == nil {
+		return err
+	}
+
+	ctx.GetLogger().Tracef("committing transaction %s", tx)
+	if err := ctx.Session.CommitTransaction(ctx, t.db.Name(), tx); err != nil {
+		return err
+	}
+	ctx.SetTransaction(nil)
+	return nil
+}
+
+// rollbackStatement rolls back the statement whose error propagated out of the procedure, if it would have been
+// committed on its own. Otherwise, the transaction is left as it is, so that its fate is decided by the explicit
+// COMMIT or ROLLBACK of the session, the same as for any other statement that fails.
+func (t *procedureTransaction) rollbackStatement(ctx *sql.Context) error {
+	tx, err := t.autocommitted(ctx)
+	if err != nil || tx == nil {
+		return err
+	}
+
+	ctx.GetLogger().Tracef("rolling back transaction %s", tx)
+	if err := t.db.Rollback(ctx, tx); err != nil {
+		return err
+	}
+	ctx.SetTransaction(nil)
+	return nil
+}
+
+// autocommitted returns the current transaction if it's committed with each statement, which is the case when
+// @@autocommit is on and no transaction was explicitly started. Returns nil otherwise.
+func (t *procedureTransaction) autocommitted(ctx *sql.Context) (sql.Transaction, error) {
+	if t == nil || ctx.GetIgnoreAutoCommit() {
+		return nil, nil
+	}
+
+	tx := ctx.GetTransaction()
+	if tx == nil {
+		return nil, nil
+	}
+
+	autocommit, err := ctx.GetSessionVariable(ctx, sql.AutoCommitSessionVar)
+	if err != nil {
+		return nil, err
+	}
+	on, err := sql.ConvertToBool(autocommit)
+	if err != nil || !on {
+		return nil, err
+	}
+	return tx, nil
+}
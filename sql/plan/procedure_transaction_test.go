@@ -0,0 +1,239 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plan
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/memory"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+var errWriteFailed = errors.New("write failed")
+
+// transactionLog records the transactions started, written to, committed and rolled back during a test.
+type transactionLog struct {
+	events []string
+	txs    int
+}
+
+func (l *transactionLog) add(event string, tx sql.Transaction) {
+	l.events = append(l.events, fmt.Sprintf("%s %s", event, tx))
+}
+
+type loggingTransaction int
+
+func (t loggingTransaction) String() string {
+	return fmt.Sprint(int(t))
+}
+
+func (t loggingTransaction) IsReadOnly() bool {
+	return false
+}
+
+type loggingTransactionDatabase struct {
+	*memory.Database
+	log *transactionLog
+}
+
+var _ sql.TransactionDatabase = loggingTransactionDatabase{}
+
+func (d loggingTransactionDatabase) StartTransaction(*sql.Context, sql.TransactionCharacteristic) (sql.Transaction, error) {
+	d.log.txs++
+	tx := loggingTransaction(d.log.txs)
+	d.log.add("start", tx)
+	return tx, nil
+}
+
+func (d loggingTransactionDatabase) CommitTransaction(_ *sql.Context, tx sql.Transaction) error {
+	d.log.add("commit", tx)
+	return nil
+}
+
+func (d loggingTransactionDatabase) Rollback(_ *sql.Context, tx sql.Transaction) error {
+	d.log.add("rollback", tx)
+	return nil
+}
+
+func (d loggingTransactionDatabase) CreateSavepoint(*sql.Context, sql.Transaction, string) error {
+	return nil
+}
+
+func (d loggingTransactionDatabase) RollbackToSavepoint(*sql.Context, sql.Transaction, string) error {
+	return nil
+}
+
+func (d loggingTransactionDatabase) ReleaseSavepoint(*sql.Context, sql.Transaction, string) error {
+	return nil
+}
+
+// loggingSession is a session that logs the transactions committed with @@autocommit.
+type loggingSession struct {
+	*sql.BaseSession
+	log *transactionLog
+}
+
+func (s loggingSession) CommitTransaction(_ *sql.Context, _ string, tx sql.Transaction) error {
+	s.log.add("commit", tx)
+	return nil
+}
+
+// writeNode is a statement that writes in the current transaction, or fails to.
+type writeNode struct {
+	log  *transactionLog
+	fail bool
+}
+
+func (w writeNode) Resolved() bool       { return true }
+func (w writeNode) String() string       { return "write" }
+func (w writeNode) Schema() sql.Schema   { return nil }
+func (w writeNode) Children() []sql.Node { return nil }
+
+func (w writeNode) WithChildren(children ...sql.Node) (sql.Node, error) {
+	return NillaryWithChildren(w, children...)
+}
+
+func (w writeNode) RowIter(ctx *sql.Context, _ sql.Row) (sql.RowIter, error) {
+	w.log.add("write", ctx.GetTransaction())
+	if w.fail {
+		return nil, errWriteFailed
+	}
+	return sql.RowsToRowIter(), nil
+}
+
+func TestCallTransactions(t *testing.T) {
+	testCases := []struct {
+		name       string
+		autocommit bool
+		body       func(db sql.Database, log *transactionLog) []sql.Node
+		err        bool
+		events     []string
+		openTx     string
+	}{
+		{
+			name:       "commit mid-body with autocommit on",
+			autocommit: true,
+			body: func(db sql.Database, log *transactionLog) []sql.Node {
+				commit, _ := NewCommit("").WithDatabase(db)
+				return []sql.Node{writeNode{log: log}, commit, writeNode{log: log}}
+			},
+			events: []string{
+				"start 1", "write 1", "commit 1",
+				"start 2", "commit 2",
+				"start 3", "write 3", "commit 3",
+			},
+		},
+		{
+			name:       "commit mid-body with autocommit off",
+			autocommit: false,
+			body: func(db sql.Database, log *transactionLog) []sql.Node {
+				commit, _ := NewCommit("").WithDatabase(db)
+				return []sql.Node{writeNode{log: log}, commit, writeNode{log: log}}
+			},
+			events: []string{
+				"start 1", "write 1", "commit 1",
+				"start 2", "write 2",
+			},
+			openTx: "2",
+		},
+		{
+			name:       "error after a partial change with autocommit on",
+			autocommit: true,
+			body: func(db sql.Database, log *transactionLog) []sql.Node {
+				return []sql.Node{writeNode{log: log}, writeNode{log: log, fail: true}}
+			},
+			err: true,
+			events: []string{
+				"start 1", "write 1", "commit 1",
+				"start 2", "write 2", "rollback 2",
+			},
+		},
+		{
+			name:       "error after a partial change with autocommit off",
+			autocommit: false,
+			body: func(db sql.Database, log *transactionLog) []sql.Node {
+				return []sql.Node{writeNode{log: log}, writeNode{log: log, fail: true}}
+			},
+			err: true,
+			events: []string{
+				"start 1", "write 1", "write 1",
+			},
+			openTx: "1",
+		},
+		{
+			name:       "error in an explicit transaction with autocommit on",
+			autocommit: true,
+			body: func(db sql.Database, log *transactionLog) []sql.Node {
+				start, _ := NewStartTransaction("", sql.ReadWrite).WithDatabase(db)
+				return []sql.Node{start, writeNode{log: log}, writeNode{log: log, fail: true}}
+			},
+			err: true,
+			events: []string{
+				"start 1", "commit 1", "start 2",
+				"write 2", "write 2",
+			},
+			openTx: "2",
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			log := &transactionLog{}
+			db := loggingTransactionDatabase{memory.NewDatabase("mydb"), log}
+			ctx := sql.NewContext(context.Background(), sql.WithSession(loggingSession{sql.NewBaseSession(), log}))
+			autocommit := int8(0)
+			if tt.autocommit {
+				autocommit = 1
+			}
+			require.NoError(ctx.SetSessionVariable(ctx, sql.AutoCommitSessionVar, autocommit))
+
+			// The engine begins a transaction before the CALL statement
+			tx, err := db.StartTransaction(ctx, sql.ReadWrite)
+			require.NoError(err)
+			ctx.SetTransaction(tx)
+
+			body := NewBeginEndBlock(NewBlock(tt.body(db, log)))
+			proc := NewProcedure("p", "", nil, ProcedureSecurityContext_Definer, "", nil, "", body, time.Now(), time.Now())
+			call, err := NewCall("p", nil).
+				WithProcedure(proc).
+				WithParamReference(expression.NewProcedureParamReference()).
+				WithDatabase(db)
+			require.NoError(err)
+
+			_, err = sql.NodeToRows(ctx, call)
+			if tt.err {
+				require.Equal(errWriteFailed, err)
+			} else {
+				require.NoError(err)
+			}
+
+			require.Equal(tt.events, log.events)
+			if tt.openTx == "" {
+				require.Nil(ctx.GetTransaction())
+			} else {
+				require.Equal(tt.openTx, ctx.GetTransaction().String())
+			}
+		})
+	}
+}
@@ -427,7 +427,7 @@ func IsDDLNode(node sql.Node) bool {
 		*AddColumn, *ModifyColumn, *DropColumn,
 		*CreateDB, *DropDB,
 		*RenameTable, *RenameColumn,
-		*CreateView, *DropView,
+		*CreateView, *AlterView, *DropView,
 		*CreateIndex, *AlterIndex, *DropIndex,
 		*CreateProcedure, *DropProcedure,
 		*CreateForeignKey, *DropForeignKey,
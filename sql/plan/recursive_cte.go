@@ -0,0 +1,281 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plan
+
+import (
+	"io"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// RecursiveCte is the definition of a recursive common table expression. It returns the rows of its anchor part (the
+// left child), followed by the rows its recursive part (the right child) produces from the rows produced last, until
+// the recursive part produces no rows. The recursive part reads the rows produced last from a RecursiveTable. When the
+// parts are combined with UNION DISTINCT, rows that were already produced are discarded, which makes recursion over
+// cyclic data terminate; with UNION ALL, recursion is only bounded by @@cte_max_recursion_depth.
+type RecursiveCte struct {
+	BinaryNode
+	name     string
+	columns  []string
+	distinct bool
+	table    *RecursiveTable
+}
+
+var _ sql.OpaqueNode = (*RecursiveCte)(nil)
+
+// NewRecursiveCte creates a new RecursiveCte node named after the common table expression, with the columns it
+// declares, if any. The table read by the recursive part is set with WithTable once the anchor part is resolved.
+func NewRecursiveCte(anchor, recursive sql.Node, name string, columns []string, distinct bool) *RecursiveCte {
+	return &RecursiveCte{
+		BinaryNode: BinaryNode{left: anchor, right: recursive},
+		name:       name,
+		columns:    columns,
+		distinct:   distinct,
+	}
+}
+
+// Name returns the name of the common table expression.
+func (r *RecursiveCte) Name() string {
+	return r.name
+}
+
+// Columns returns the names of the columns declared by the common table expression, if any.
+func (r *RecursiveCte) Columns() []string {
+	return r.columns
+}
+
+// Distinct returns whether the parts are combined with UNION DISTINCT.
+func (r *RecursiveCte) Distinct() bool {
+	return r.distinct
+}
+
+// Table returns the table the recursive part reads the rows produced last from, or nil if it isn't set yet.
+func (r *RecursiveCte) Table() *RecursiveTable {
+	return r.table
+}
+
+// WithTable returns a copy of this node reading the rows produced last from the table given.
+func (r *RecursiveCte) WithTable(table *RecursiveTable) *RecursiveCte {
+	nr := *r
+	nr.table = table
+	return &nr
+}
+
+// Opaque implements the sql.OpaqueNode interface. Like those of a Union, the parts of a recursive common table
+// expression must be analyzed in isolation.
+func (r *RecursiveCte) Opaque() bool {
+	return true
+}
+
+// Resolved implements the sql.Node interface.
+func (r *RecursiveCte) Resolved() bool {
+	return r.table != nil && r.BinaryNode.Resolved()
+}
+
+// Schema implements the sql.Node interface.
+func (r *RecursiveCte) Schema() sql.Schema {
+	if r.table != nil {
+		return r.table.Schema()
+	}
+	return r.left.Schema()
+}
+
+// RowIter implements the sql.Node interface.
+func (r *RecursiveCte) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
+	maxDepth, err := ctx.GetSessionVariable(ctx, "cte_max_recursion_depth")
+	if err != nil {
+		return nil, err
+	}
+	maxDepth, err = sql.Int64.Convert(maxDepth)
+	if err != nil {
+		return nil, err
+	}
+
+	var seen map[uint64]struct{}
+	if r.distinct {
+		seen = make(map[uint64]struct{})
+	}
+
+	iter, err := r.left.RowIter(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := r.newRows(ctx, iter, seen)
+	if err != nil {
+		return nil, err
+	}
+
+	produced := rows
+	for iterations := int64(1); len(produced) > 0; iterations++ {
+		r.table.rows = produced
+		iter, err = r.right.RowIter(ctx, row)
+		if err != nil {
+			return nil, err
+		}
+		produced, err = r.newRows(ctx, iter, seen)
+		if err != nil {
+			return nil, err
+		}
+		if len(produced) > 0 && iterations > maxDepth.(int64) {
+			return nil, sql.ErrCteRecursionLimit.New(r.name, iterations)
+		}
+		rows = append(rows, produced...)
+	}
+	r.table.rows = nil
+
+	return sql.RowsToRowIter(rows...), nil
+}
+
+// newRows returns the rows of the iterator given, converted to the types of the schema of this node, closing the
+// iterator. If seen is not nil, rows in it are discarded, and the rows returned are added to it.
+func (r *RecursiveCte) newRows(ctx *sql.Context, iter sql.RowIter, seen map[uint64]struct{}) ([]sql.Row, error) {
+	schema := r.Schema()
+
+	var rows []sql.Row
+	for {
+		row, err := iter.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			iter.Close(ctx)
+			return nil, err
+		}
+
+		converted := make(sql.Row, len(row))
+		for i, v := range row {
+			if converted[i], err = schema[i].Type.Convert(v); err != nil {
+				iter.Close(ctx)
+				return nil, err
+			}
+		}
+
+		if seen != nil {
+			hash, err := sql.HashOf(converted)
+			if err != nil {
+				iter.Close(ctx)
+				return nil, err
+			}
+			if _, ok := seen[hash]; ok {
+				continue
+			}
+			seen[hash] = struct{}{}
+		}
+		rows = append(rows, converted)
+	}
+
+	return rows, iter.Close(ctx)
+}
+
+// WithChildren implements the sql.Node interface.
+func (r *RecursiveCte) WithChildren(children ...sql.Node) (sql.Node, error) {
+	if len(children) != 2 {
+		return nil, sql.ErrInvalidChildrenNumber.New(r, len(children), 2)
+	}
+
+	nr := *r
+	nr.left = children[0]
+	nr.right = children[1]
+	return &nr, nil
+}
+
+func (r *RecursiveCte) String() string {
+	pr := sql.NewTreePrinter()
+	if r.distinct {
+		_ = pr.WriteNode("RecursiveCte(%s, distinct)", r.name)
+	} else {
+		_ = pr.WriteNode("RecursiveCte(%s)", r.name)
+	}
+	_ = pr.WriteChildren(r.left.String(), r.right.String())
+	return pr.String()
+}
+
+func (r *RecursiveCte) DebugString() string {
+	pr := sql.NewTreePrinter()
+	if r.distinct {
+		_ = pr.WriteNode("RecursiveCte(%s, distinct)", r.name)
+	} else {
+		_ = pr.WriteNode("RecursiveCte(%s)", r.name)
+	}
+	_ = pr.WriteChildren(sql.DebugString(r.left), sql.DebugString(r.right))
+	return pr.String()
+}
+
+// RecursiveTable is the table the recursive part of a recursive common table expression reads the rows produced last
+// from. Until its schema is known, it stands for the references to the common table expression in its recursive part.
+type RecursiveTable struct {
+	name   string
+	schema sql.Schema
+	rows   []sql.Row
+}
+
+var _ sql.Table = (*RecursiveTable)(nil)
+
+// NewRecursiveTable creates a new RecursiveTable with the name of the common table expression and the schema given,
+// which is nil until the schema of the anchor part is known.
+func NewRecursiveTable(name string, schema sql.Schema) *RecursiveTable {
+	return &RecursiveTable{name: name, schema: schema}
+}
+
+// Name implements the sql.Table interface.
+func (t *RecursiveTable) Name() string {
+	return t.name
+}
+
+// String implements the sql.Table interface.
+func (t *RecursiveTable) String() string {
+	return t.name
+}
+
+// Schema implements the sql.Table interface.
+func (t *RecursiveTable) Schema() sql.Schema {
+	return t.schema
+}
+
+// Partitions implements the sql.Table interface.
+func (t *RecursiveTable) Partitions(*sql.Context) (sql.PartitionIter, error) {
+	return &recursiveTablePartitionIter{}, nil
+}
+
+// PartitionRows implements the sql.Table interface.
+func (t *RecursiveTable) PartitionRows(*sql.Context, sql.Partition) (sql.RowIter, error) {
+	return sql.RowsToRowIter(t.rows...), nil
+}
+
+// recursiveTablePartitionIter returns the single partition of a RecursiveTable.
+type recursiveTablePartitionIter struct {
+	done bool
+}
+
+var _ sql.Partition = (*recursiveTablePartitionIter)(nil)
+
+// Key implements the sql.Partition interface.
+func (p *recursiveTablePartitionIter) Key() []byte {
+	return nil
+}
+
+// Next implements the sql.PartitionIter interface.
+func (p *recursiveTablePartitionIter) Next() (sql.Partition, error) {
+	if p.done {
+		return nil, io.EOF
+	}
+	p.done = true
+	return p, nil
+}
+
+// Close implements the sql.PartitionIter interface.
+func (p *recursiveTablePartitionIter) Close(*sql.Context) error {
+	return nil
+}
@@ -0,0 +1,86 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plan
+
+import (
+	"io"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// Return represents the RETURN statement of a stored function with a compound statement body. Its child is a SELECT
+// of the expression returned, and running it ends the execution of the body with the value of that expression.
+type Return struct {
+	UnaryNode
+}
+
+var _ sql.Node = (*Return)(nil)
+
+// NewReturn returns a *Return node of the SELECT given.
+func NewReturn(child sql.Node) *Return {
+	return &Return{UnaryNode{Child: child}}
+}
+
+// String implements the sql.Node interface.
+func (r *Return) String() string {
+	p := sql.NewTreePrinter()
+	_ = p.WriteNode("RETURN")
+	_ = p.WriteChildren(r.Child.String())
+	return p.String()
+}
+
+// Schema implements the sql.Node interface.
+func (r *Return) Schema() sql.Schema {
+	return nil
+}
+
+// WithChildren implements the sql.Node interface.
+func (r *Return) WithChildren(children ...sql.Node) (sql.Node, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(r, len(children), 1)
+	}
+	return NewReturn(children[0]), nil
+}
+
+// RowIter implements the sql.Node interface. The value returned is carried out of the statements that contain the
+// RETURN as a *returnedValue error, which the call of the stored function unwraps.
+func (r *Return) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
+	iter, err := r.Child.RowIter(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+	returned, err := iter.Next()
+	if err == io.EOF {
+		err = sql.ErrExpectedSingleRow.New()
+	}
+	if err != nil {
+		_ = iter.Close(ctx)
+		return nil, err
+	}
+	if err := iter.Close(ctx); err != nil {
+		return nil, err
+	}
+	return nil, &returnedValue{value: returned[0]}
+}
+
+// returnedValue is the value of a RETURN statement on its way out of the body of a stored function.
+type returnedValue struct {
+	value interface{}
+}
+
+// Error implements the error interface.
+func (r *returnedValue) Error() string {
+	return "RETURN is only allowed in a FUNCTION"
+}
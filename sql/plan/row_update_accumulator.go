@@ -96,11 +96,18 @@ func (i *insertRowHandler) okResult() sql.OkResult {
 
 type replaceRowHandler struct {
 	rowsAffected int
+	schema       sql.Schema
 }
 
 func (r *replaceRowHandler) handleRowUpdate(row sql.Row) error {
 	r.rowsAffected++
 
+	// A row without the second half is one of several rows deleted for a single inserted row, which is counted with
+	// the row returned with the inserted one
+	if len(row) < len(r.schema) {
+		return nil
+	}
+
 	// If a row was deleted as well as inserted, increment the counter again. A row was deleted if at least one column in
 	// the first half of the row is non-null.
 	for i := 0; i < len(row)/2; i++ {
@@ -201,8 +208,9 @@ func (u *updateJoinRowHandler) handleRowUpdate(row sql.Row) error {
 	tableToOldRow := splitRowIntoTableRowMap(oldJoinRow, u.joinSchema)
 	tableToNewRow := splitRowIntoTableRowMap(newJoinRow, u.joinSchema)
 
+	// The updateJoinIter rewrites the new row of a table row it has already updated back to its old row, so a table row
+	// is only affected the first time it shows up in the join. It counts the rows matched itself.
 	for tableName, _ := range u.updaterMap {
-		u.rowsMatched++ // TODO: This currently returns the incorrect answer
 		tableOldRow := tableToOldRow[tableName]
 		tableNewRow := tableToNewRow[tableName]
 		if equals, err := tableOldRow.Equals(tableNewRow, u.tableMap[tableName]); err == nil {
@@ -216,6 +224,11 @@ func (u *updateJoinRowHandler) handleRowUpdate(row sql.Row) error {
 	return nil
 }
 
+// handleRowMatched counts a table row matched by the join of the update.
+func (u *updateJoinRowHandler) handleRowMatched() {
+	u.rowsMatched++
+}
+
 func (u *updateJoinRowHandler) okResult() sql.OkResult {
 	return sql.OkResult{
 		RowsAffected: uint64(u.rowsAffected),
@@ -257,9 +270,12 @@ func (u *deleteRowHandler) okResult() sql.OkResult {
 }
 
 type accumulatorIter struct {
+	ctx              *sql.Context
 	iter             sql.RowIter
 	once             sync.Once
 	updateRowHandler accumulatorRowHandler
+	// warningCount is the number of warnings of the session before the statement ran
+	warningCount uint16
 }
 
 func (a *accumulatorIter) Next() (sql.Row, error) {
@@ -277,7 +293,7 @@ func (a *accumulatorIter) Next() (sql.Row, error) {
 		_, isIg := err.(sql.ErrInsertIgnore)
 
 		if err == io.EOF {
-			return sql.NewRow(a.updateRowHandler.okResult()), nil
+			return sql.NewRow(a.okResult()), nil
 		} else if isIg {
 			continue
 		} else if err != nil {
@@ -291,6 +307,21 @@ func (a *accumulatorIter) Next() (sql.Row, error) {
 	}
 }
 
+// okResult returns the result of the handler, with the warnings added to the session while the statement ran counted
+// in its update info.
+func (a *accumulatorIter) okResult() sql.OkResult {
+	result := a.updateRowHandler.okResult()
+	if info, ok := result.Info.(UpdateInfo); ok {
+		warnings := a.ctx.WarningCount()
+		if warnings >= a.warningCount {
+			warnings -= a.warningCount
+		}
+		info.Warnings = int(warnings)
+		result.Info = info
+	}
+	return result
+}
+
 func (a *accumulatorIter) Close(ctx *sql.Context) error {
 	err := a.iter.Close(ctx)
 	if err != nil {
@@ -310,17 +341,13 @@ func (a *accumulatorIter) Close(ctx *sql.Context) error {
 }
 
 func (r RowUpdateAccumulator) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
-	rowIter, err := r.Child.RowIter(ctx, row)
-	if err != nil {
-		return nil, err
-	}
-
+	child := r.Child
 	var rowHandler accumulatorRowHandler
 	switch r.RowUpdateType {
 	case UpdateTypeInsert:
 		rowHandler = &insertRowHandler{}
 	case UpdateTypeReplace:
-		rowHandler = &replaceRowHandler{}
+		rowHandler = &replaceRowHandler{schema: r.Child.Schema()}
 	case UpdateTypeDuplicateKeyUpdate:
 		clientFoundRowsToggled := (ctx.Client().Capabilities & mysql.CapabilityClientFoundRows) == mysql.CapabilityClientFoundRows
 		rowHandler = &onDuplicateUpdateHandler{schema: r.Child.Schema(), clientFoundRowsCapability: clientFoundRowsToggled}
@@ -351,13 +378,34 @@ func (r RowUpdateAccumulator) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIte
 			return nil, fmt.Errorf("error: No JoinNode found in query plan to go along with an UpdateTypeJoinUpdate")
 		}
 
-		rowHandler = &updateJoinRowHandler{joinSchema: schema, tableMap: recreateTableSchemaFromJoinSchema(schema), updaterMap: updaterMap}
+		joinRowHandler := &updateJoinRowHandler{joinSchema: schema, tableMap: recreateTableSchemaFromJoinSchema(schema), updaterMap: updaterMap}
+		rowHandler = joinRowHandler
+
+		// Only the UpdateJoin knows which table rows it has seen before, so it counts the rows matched for the handler
+		var err error
+		child, err = TransformUp(child, func(node sql.Node) (sql.Node, error) {
+			if uj, ok := node.(*UpdateJoin); ok {
+				return uj.withAccumulator(joinRowHandler), nil
+			}
+			return node, nil
+		})
+		if err != nil {
+			return nil, err
+		}
 	default:
 		panic(fmt.Sprintf("Unrecognized RowUpdateType %d", r.RowUpdateType))
 	}
 
+	warningCount := ctx.WarningCount()
+	rowIter, err := child.RowIter(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+
 	return &accumulatorIter{
+		ctx:              ctx,
 		iter:             rowIter,
 		updateRowHandler: rowHandler,
+		warningCount:     warningCount,
 	}, nil
 }
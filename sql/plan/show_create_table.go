@@ -24,7 +24,7 @@ import (
 	"github.com/dolthub/go-mysql-server/sql"
 )
 
-var ErrNotView = errors.NewKind("'%' is not VIEW")
+var ErrNotView = errors.NewKind("'%s' is not VIEW")
 
 // ShowCreateTable is a node that shows the CREATE TABLE statement for a table.
 type ShowCreateTable struct {
@@ -71,6 +71,8 @@ func (n *ShowCreateTable) Schema() sql.Schema {
 		return sql.Schema{
 			&sql.Column{Name: "View", Type: sql.LongText, Nullable: false},
 			&sql.Column{Name: "Create View", Type: sql.LongText, Nullable: false},
+			&sql.Column{Name: "character_set_client", Type: sql.LongText, Nullable: false},
+			&sql.Column{Name: "collation_connection", Type: sql.LongText, Nullable: false},
 		}
 	case *ResolvedTable, *UnresolvedTable:
 		return sql.Schema{
@@ -141,8 +143,12 @@ func (i *showCreateTablesIter) Next() (sql.Row, error) {
 			return nil, err
 		}
 	case *SubqueryAlias:
-		tableName = table.Name()
-		composedCreateTableStatement = produceCreateViewStatement(table)
+		return sql.NewRow(
+			table.Name(),                      // "View" string
+			produceCreateViewStatement(table), // "Create View" string
+			sql.Collation_Default.CharacterSet().String(),
+			sql.Collation_Default.String(),
+		), nil
 	default:
 		panic(fmt.Sprintf("unexpected type %T", i.table))
 	}
@@ -181,6 +187,10 @@ func (i *showCreateTablesIter) produceCreateTableStatement(table sql.Table) (str
 			stmt = fmt.Sprintf("%s DEFAULT %s", stmt, col.Default.String())
 		}
 
+		if col.OnUpdate != nil {
+			stmt = fmt.Sprintf("%s ON UPDATE CURRENT_TIMESTAMP", stmt)
+		}
+
 		if col.Comment != "" {
 			stmt = fmt.Sprintf("%s COMMENT '%s'", stmt, col.Comment)
 		}
@@ -220,7 +230,7 @@ func (i *showCreateTablesIter) produceCreateTableStatement(table sql.Table) (str
 
 		key := fmt.Sprintf("  %sKEY `%s` (%s)", unique, index.ID(), strings.Join(indexCols, ","))
 		if index.Comment() != "" {
-			key = fmt.Sprintf("%s COMMENT '%s'", key, index.Comment())
+			key = fmt.Sprintf("%s COMMENT '%s'", key, strings.Replace(index.Comment(), "'", "''", -1))
 		}
 
 		colStmts = append(colStmts, key)
@@ -321,11 +331,7 @@ func isPrimaryKeyIndex(index sql.Index, table sql.Table) bool {
 }
 
 func produceCreateViewStatement(view *SubqueryAlias) string {
-	return fmt.Sprintf(
-		"CREATE VIEW `%s` AS %s",
-		view.Name(),
-		view.TextDefinition,
-	)
+	return sql.CreateViewStatement(view.Name(), view.TextDefinition)
 }
 
 func (i *showCreateTablesIter) Close(*sql.Context) error {
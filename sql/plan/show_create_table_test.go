@@ -175,8 +175,23 @@ func TestShowCreateView(t *testing.T) {
 
 	expected := sql.NewRow(
 		"myView",
-		"CREATE VIEW `myView` AS select * from `test-table`",
+		"CREATE ALGORITHM=UNDEFINED DEFINER=`root`@`localhost` SQL SECURITY DEFINER VIEW `myView` AS select * from `test-table`",
+		"utf8mb4",
+		"utf8mb4_0900_bin",
 	)
 
 	require.Equal(expected, row)
+
+	showCreateTable = NewShowCreateTable(
+		NewSubqueryAlias("myView", "SQL SECURITY INVOKER (`a`,`b`) AS select * from `test-table`", NewResolvedTable(table, nil, nil)),
+		true,
+	)
+
+	rowIter, _ = showCreateTable.RowIter(ctx, nil)
+	row, err = rowIter.Next()
+	require.Nil(err)
+	require.Equal(
+		"CREATE ALGORITHM=UNDEFINED DEFINER=`root`@`localhost` SQL SECURITY INVOKER VIEW `myView` (`a`,`b`) AS select * from `test-table`",
+		row[1],
+	)
 }
@@ -17,7 +17,6 @@ package plan
 import (
 	"fmt"
 	"strings"
-	"time"
 
 	"github.com/dolthub/go-mysql-server/sql"
 )
@@ -94,13 +93,13 @@ func (s *ShowCreateTrigger) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter,
 				return nil, err
 			}
 			return sql.RowsToRowIter(sql.Row{
-				trigger.Name,            // Trigger
-				"",                      // sql_mode
-				trigger.CreateStatement, // SQL Original Statement
-				characterSetClient,      // character_set_client
-				collationConnection,     // collation_connection
-				collationServer,         // Database Collation
-				time.Unix(0, 0).UTC(),   // Created
+				trigger.Name,                      // Trigger
+				trigger.SqlMode,                   // sql_mode
+				trigger.CreateStatement,           // SQL Original Statement
+				characterSetClient,                // character_set_client
+				collationConnection,               // collation_connection
+				collationServer,                   // Database Collation
+				triggerCreated(trigger.CreatedAt), // Created
 			}), nil
 		}
 	}
@@ -146,8 +146,8 @@ func (i *showIndexesIter) Next() (sql.Row, error) {
 		nil,                    // "Packed" string
 		nullable,               // "Null" string, Values [YES, '']
 		show.index.IndexType(), // "Index_type" string
-		show.index.Comment(),   // "Comment" string
-		"",                     // "Index_comment" string
+		"",                     // "Comment" string
+		show.index.Comment(),   // "Index_comment" string
 		visible,                // "Visible" string, Values [YES, NO]
 		expression,             // "Expression" string
 	), nil
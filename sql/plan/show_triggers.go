@@ -91,22 +91,31 @@ func (s *ShowTriggers) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, erro
 			return nil, err
 		}
 		rows = append(rows, sql.Row{
-			trigger.TriggerName,   // Trigger
-			triggerEvent,          // Event
-			tableName,             // Table
-			trigger.BodyString,    // Statement
-			triggerTime,           // Timing
-			time.Unix(0, 0).UTC(), // Created
-			"",                    // sql_mode
-			"",                    // Definer
-			characterSetClient,    // character_set_client
-			collationConnection,   // collation_connection
-			collationServer,       // Database Collation
+			trigger.TriggerName,               // Trigger
+			triggerEvent,                      // Event
+			tableName,                         // Table
+			trigger.BodyString,                // Statement
+			triggerTime,                       // Timing
+			triggerCreated(trigger.CreatedAt), // Created
+			trigger.SqlMode,                   // sql_mode
+			trigger.Definer,                   // Definer
+			characterSetClient,                // character_set_client
+			collationConnection,               // collation_connection
+			collationServer,                   // Database Collation
 		})
 	}
 	return sql.RowsToRowIter(rows...), nil
 }
 
+// triggerCreated returns the creation time to show for a trigger created at the time given. Triggers that were stored
+// without their creation time are shown as created at the epoch.
+func triggerCreated(createdAt time.Time) time.Time {
+	if createdAt.IsZero() {
+		return time.Unix(0, 0).UTC()
+	}
+	return createdAt.UTC()
+}
+
 // WithChildren implements the sql.Node interface.
 func (s *ShowTriggers) WithChildren(children ...sql.Node) (sql.Node, error) {
 	return NillaryWithChildren(s, children...)
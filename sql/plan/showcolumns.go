@@ -77,6 +77,9 @@ func (s *ShowColumns) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error
 		var collation interface{}
 		if sql.IsTextOnly(col.Type) {
 			collation = sql.Collation_Default.String()
+			if st, ok := col.Type.(sql.StringType); ok {
+				collation = st.Collation().Name
+			}
 		}
 
 		var null = "NO"
@@ -113,23 +116,23 @@ func (s *ShowColumns) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error
 		if s.Full {
 			row = sql.Row{
 				col.Name,
-				strings.ToLower(col.Type.String()),
+				strings.ToLower(columnTypeName(col.Type)),
 				collation,
 				null,
 				key,
 				defaultVal,
-				col.Extra,
+				col.ExtraInfo(),
 				"", // Privileges
 				col.Comment,
 			}
 		} else {
 			row = sql.Row{
 				col.Name,
-				strings.ToLower(col.Type.String()),
+				strings.ToLower(columnTypeName(col.Type)),
 				null,
 				key,
 				defaultVal,
-				col.Extra,
+				col.ExtraInfo(),
 			}
 		}
 
@@ -190,3 +193,17 @@ func (s *ShowColumns) isFirstColInNonUniqueKey(col *sql.Column, table sql.Table)
 
 	return false
 }
+
+// columnTypeName returns the name of the type given without any character set or collation, which are shown separately.
+func columnTypeName(typ sql.Type) string {
+	name := typ.String()
+	if _, ok := typ.(sql.StringType); !ok {
+		return name
+	}
+	for _, clause := range []string{" CHARACTER SET ", " COLLATE "} {
+		if idx := strings.Index(name, clause); idx >= 0 {
+			name = name[:idx]
+		}
+	}
+	return name
+}
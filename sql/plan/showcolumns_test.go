@@ -17,6 +17,7 @@ package plan_test
 import (
 	"testing"
 
+	"github.com/dolthub/vitess/go/sqltypes"
 	"github.com/stretchr/testify/require"
 
 	"github.com/dolthub/go-mysql-server/memory"
@@ -162,3 +163,59 @@ func TestShowColumnsFull(t *testing.T) {
 
 	require.Equal(expected, rows)
 }
+
+func TestShowColumnsExtra(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewEmptyContext()
+
+	table := NewResolvedTable(memory.NewTable("foo", sql.NewPrimaryKeySchema(sql.Schema{
+		{Name: "a", Type: sql.Int64, PrimaryKey: true, AutoIncrement: true},
+		{Name: "b", Type: sql.Int64, Nullable: true, Default: parse.MustStringToColumnDefaultValue(ctx, "(a + 1)", sql.Int64, true)},
+		{Name: "c", Type: sql.Datetime, Nullable: true, OnUpdate: parse.MustStringToColumnDefaultValue(ctx, "NOW()", sql.Datetime, true)},
+		{Name: "d", Type: sql.Datetime, Nullable: true, Default: parse.MustStringToColumnDefaultValue(ctx, "(NOW())", sql.Datetime, true), OnUpdate: parse.MustStringToColumnDefaultValue(ctx, "NOW()", sql.Datetime, true)},
+		{Name: "e", Type: sql.Int64, Nullable: true, Generated: parse.MustStringToColumnDefaultValue(ctx, "(a * 2)", sql.Int64, true), Virtual: true},
+		{Name: "f", Type: sql.Int64, Nullable: true, Generated: parse.MustStringToColumnDefaultValue(ctx, "(a * 3)", sql.Int64, true)},
+		{Name: "g", Type: sql.Int64, Nullable: true, Default: parse.MustStringToColumnDefaultValue(ctx, "(a + 1)", sql.Int64, true), Extra: "custom"},
+	})), nil, nil)
+
+	iter, err := NewShowColumns(false, table).RowIter(ctx, nil)
+	require.NoError(err)
+
+	rows, err := sql.RowIterToRows(ctx, iter)
+	require.NoError(err)
+
+	expected := []sql.Row{
+		{"a", "bigint", "NO", "PRI", "", "auto_increment"},
+		{"b", "bigint", "YES", "", "((a + 1))", "DEFAULT_GENERATED"},
+		{"c", "datetime", "YES", "", "", "on update CURRENT_TIMESTAMP"},
+		{"d", "datetime", "YES", "", "(now())", "DEFAULT_GENERATED on update CURRENT_TIMESTAMP"},
+		{"e", "bigint", "YES", "", "", "VIRTUAL GENERATED"},
+		{"f", "bigint", "YES", "", "", "STORED GENERATED"},
+		{"g", "bigint", "YES", "", "((a + 1))", "custom"},
+	}
+
+	require.Equal(expected, rows)
+}
+
+func TestShowColumnsCollation(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewEmptyContext()
+
+	table := NewResolvedTable(memory.NewTable("foo", sql.NewPrimaryKeySchema(sql.Schema{
+		{Name: "a", Type: sql.MustCreateString(sqltypes.VarChar, 10, sql.Collation_utf8mb4_general_ci), PrimaryKey: true},
+		{Name: "b", Type: sql.MustCreateString(sqltypes.Text, 65535, sql.Collation_latin1_swedish_ci), Nullable: true},
+	})), nil, nil)
+
+	iter, err := NewShowColumns(true, table).RowIter(ctx, nil)
+	require.NoError(err)
+
+	rows, err := sql.RowIterToRows(ctx, iter)
+	require.NoError(err)
+
+	expected := []sql.Row{
+		{"a", "varchar(10)", "utf8mb4_general_ci", "NO", "PRI", "", "", "", ""},
+		{"b", "text", "latin1_swedish_ci", "YES", "", "", "", "", ""},
+	}
+
+	require.Equal(expected, rows)
+}
@@ -15,27 +15,40 @@
 package plan
 
 import (
+	"fmt"
+
 	"github.com/dolthub/go-mysql-server/sql"
 )
 
-// ShowGrants shows the columns details of a table.
-type ShowGrants struct{}
+// ShowGrants shows the privileges granted to a user account, as the GRANT statement that would grant them.
+type ShowGrants struct {
+	// User and Host name the account, and are empty for the account of the current session.
+	User string
+	Host string
+	// Privileges lists the privileges granted to the account as in a GRANT statement, and GrantOption is whether the
+	// account may grant them to others. The engine sets them, and the account, from its users.
+	Privileges  string
+	GrantOption bool
+}
 
-var (
-	showGrantsSchema = sql.Schema{
-		{Name: "Grants for root@", Type: sql.LongText},
-	}
-)
+// NewShowGrants creates a new ShowGrants node for the account given, or for the account of the current session if
+// the user is empty.
+func NewShowGrants(user, host string) *ShowGrants {
+	return &ShowGrants{User: user, Host: host}
+}
 
-// NewShowGrants creates a new ShowGrants node.
-func NewShowGrants() *ShowGrants {
-	return &ShowGrants{}
+// WithPrivileges returns a copy of this node that grants the given privileges to its account.
+func (s *ShowGrants) WithPrivileges(privileges string, grantOption bool) *ShowGrants {
+	ns := *s
+	ns.Privileges = privileges
+	ns.GrantOption = grantOption
+	return &ns
 }
 
 // Schema implements the sql.Node interface.
 func (s *ShowGrants) Schema() sql.Schema {
 	return sql.Schema{{
-		Name: "Grants for root@%",
+		Name: fmt.Sprintf("Grants for %s@%s", s.User, s.Host),
 		Type: sql.LongText,
 	}}
 }
@@ -44,8 +57,14 @@ func (s *ShowGrants) Schema() sql.Schema {
 func (s *ShowGrants) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
 	span, _ := ctx.Span("plan.ShowGrants")
 
-	rows := []sql.Row{
-		sql.Row{"GRANT ALL PRIVILEGES ON *.* TO 'root'@'%' WITH GRANT OPTION"},
+	grant := fmt.Sprintf("GRANT %s ON *.* TO '%s'@'%s'", s.Privileges, s.User, s.Host)
+	rows := []sql.Row{{grant}}
+	if s.GrantOption {
+		// An account that may grant its privileges may also grant proxying to any user, like the root account of MySQL
+		rows = []sql.Row{
+			{grant + " WITH GRANT OPTION"},
+			{fmt.Sprintf("GRANT PROXY ON ''@'' TO '%s'@'%s' WITH GRANT OPTION", s.User, s.Host)},
+		}
 	}
 
 	return sql.NewSpanIter(span, sql.RowsToRowIter(rows...)), nil
@@ -53,12 +72,15 @@ func (s *ShowGrants) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error)
 
 // WithChildren implements the Node interface.
 func (s *ShowGrants) WithChildren(children ...sql.Node) (sql.Node, error) {
-	return NewShowGrants(), nil
+	if len(children) != 0 {
+		return nil, sql.ErrInvalidChildrenNumber.New(s, len(children), 0)
+	}
+	return s, nil
 }
 
 func (s *ShowGrants) String() string {
 	p := sql.NewTreePrinter()
-	_ = p.WriteNode("ShowGrants")
+	_ = p.WriteNode("ShowGrants(%s@%s)", s.User, s.Host)
 	return p.String()
 }
 
@@ -67,5 +89,5 @@ func (s *ShowGrants) Children() []sql.Node {
 }
 
 func (s *ShowGrants) Resolved() bool {
-	return true
+	return s.Privileges != ""
 }
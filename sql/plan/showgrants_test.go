@@ -27,15 +27,30 @@ func TestShowGrants(t *testing.T) {
 	require := require.New(t)
 	ctx := sql.NewEmptyContext()
 
-	iter, err := NewShowGrants().RowIter(ctx, nil)
+	node := NewShowGrants("bob", "localhost")
+	require.False(node.Resolved())
+
+	node = node.WithPrivileges("ALL PRIVILEGES", true)
+	require.True(node.Resolved())
+	require.Equal(sql.Schema{{Name: "Grants for bob@localhost", Type: sql.LongText}}, node.Schema())
+
+	iter, err := node.RowIter(ctx, nil)
 	require.NoError(err)
 
 	rows, err := sql.RowIterToRows(ctx, iter)
 	require.NoError(err)
 
 	expected := []sql.Row{
-		{"GRANT ALL PRIVILEGES ON *.* TO 'root'@'%' WITH GRANT OPTION"},
+		{"GRANT ALL PRIVILEGES ON *.* TO 'bob'@'localhost' WITH GRANT OPTION"},
+		{"GRANT PROXY ON ''@'' TO 'bob'@'localhost' WITH GRANT OPTION"},
 	}
 
 	require.Equal(expected, rows)
+
+	iter, err = NewShowGrants("alice", "%").WithPrivileges("SELECT, EXECUTE", false).RowIter(ctx, nil)
+	require.NoError(err)
+
+	rows, err = sql.RowIterToRows(ctx, iter)
+	require.NoError(err)
+	require.Equal([]sql.Row{{"GRANT SELECT, EXECUTE ON *.* TO 'alice'@'%'"}}, rows)
 }
@@ -164,9 +164,11 @@ func (i *sortIter) computeSortedRows() error {
 	cache, dispose := i.ctx.Memory.NewRowsCache()
 	defer dispose()
 
-	for {
+	for n := 0; ; n++ {
+		if err := sql.CheckCancellation(i.ctx, n); err != nil {
+			return err
+		}
 		row, err := i.childIter.Next()
-
 		if err == io.EOF {
 			break
 		}
@@ -345,14 +347,17 @@ func (i *topRowsIter) Close(ctx *sql.Context) error {
 
 func (i *topRowsIter) computeTopRows() error {
 	topRowsHeap := &expression.TopRowsHeap{
-		expression.Sorter{
+		Sorter: expression.Sorter{
 			SortFields: i.n.Fields,
 			Rows:       []sql.Row{},
 			LastError:  nil,
 			Ctx:        i.ctx,
 		},
 	}
-	for {
+	for n := 0; ; n++ {
+		if err := sql.CheckCancellation(i.ctx, n); err != nil {
+			return err
+		}
 		row, err := i.childIter.Next()
 		if err == io.EOF {
 			break
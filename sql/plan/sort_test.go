@@ -15,6 +15,8 @@
 package plan
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"testing"
 
@@ -294,3 +296,106 @@ func TestSortDescending(t *testing.T) {
 	require.NoError(err)
 	require.Equal(expected, actual)
 }
+
+func TestTopNTies(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewEmptyContext()
+
+	schema := sql.NewPrimaryKeySchema(sql.Schema{
+		{Name: "col1", Type: sql.Int64, Nullable: true},
+		{Name: "col2", Type: sql.Text, Nullable: true},
+	})
+
+	child := memory.NewTable("test", schema)
+	for i := 0; i < 100; i++ {
+		require.NoError(child.Insert(sql.NewEmptyContext(), sql.NewRow(int64(i%5), fmt.Sprintf("row%d", i))))
+	}
+
+	sf := []sql.SortField{
+		{Column: expression.NewGetField(0, sql.Int64, "col1", true), Order: sql.Descending, NullOrdering: sql.NullsFirst},
+	}
+
+	for _, limit := range []int64{0, 1, 7, 20, 21, 100, 200} {
+		t.Run(fmt.Sprintf("limit %d", limit), func(t *testing.T) {
+			limitExpr := expression.NewLiteral(limit, sql.Int64)
+			sorted, err := sql.NodeToRows(ctx, NewLimit(limitExpr, NewSort(sf, NewResolvedTable(child, nil, nil))))
+			require.NoError(err)
+
+			topN, err := sql.NodeToRows(ctx, NewTopN(sf, limitExpr, NewResolvedTable(child, nil, nil)))
+			require.NoError(err)
+			require.Equal(sorted, topN)
+		})
+	}
+}
+
+func TestSortCancellation(t *testing.T) {
+	schema := sql.NewPrimaryKeySchema(sql.Schema{
+		{Name: "col1", Type: sql.Int64, Nullable: false},
+	})
+
+	child := memory.NewTable("test", schema)
+	for i := 0; i < 20000; i++ {
+		require.NoError(t, child.Insert(sql.NewEmptyContext(), sql.NewRow(int64((i*7919)%20000))))
+	}
+
+	col1 := expression.NewGetField(0, sql.Int64, "col1", false)
+
+	testCases := []struct {
+		name string
+		node func(e sql.Expression) sql.Node
+	}{
+		{
+			name: "while reading the input",
+			node: func(e sql.Expression) sql.Node {
+				return NewSort(
+					[]sql.SortField{{Column: col1, Order: sql.Ascending}},
+					NewProject([]sql.Expression{e}, NewResolvedTable(child, nil, nil)),
+				)
+			},
+		},
+		{
+			name: "while sorting",
+			node: func(e sql.Expression) sql.Node {
+				return NewSort(
+					[]sql.SortField{{Column: e, Order: sql.Ascending}},
+					NewResolvedTable(child, nil, nil),
+				)
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			ctx, cancel := sql.NewEmptyContext().NewSubContext()
+			defer cancel()
+
+			e := &cancellingExpression{Expression: col1, after: 500, cancel: cancel}
+			iter, err := tt.node(e).RowIter(ctx, nil)
+			require.NoError(err)
+
+			_, err = iter.Next()
+			require.True(errors.Is(err, context.Canceled), "unexpected error %v", err)
+			require.NoError(iter.Close(ctx))
+
+			// Sorting evaluates both rows of a comparison
+			require.LessOrEqual(e.evals, e.after+2*sql.CancellationCheckInterval)
+		})
+	}
+}
+
+// cancellingExpression is an expression that cancels its context after it's evaluated a number of times.
+type cancellingExpression struct {
+	sql.Expression
+	after  int
+	evals  int
+	cancel context.CancelFunc
+}
+
+func (e *cancellingExpression) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	e.evals++
+	if e.evals == e.after {
+		e.cancel()
+	}
+	return e.Expression.Eval(ctx, row)
+}
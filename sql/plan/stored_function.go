@@ -0,0 +1,276 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plan
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+// StoredFunction is a stored function, which is called in expressions like a built-in function. A body that is a
+// single RETURN statement is kept as a SELECT of its expression, which is evaluated with the arguments of a call as the
+// outer scope, in which the parameters are columns. A BEGIN ... END body is kept as a *Procedure with the parameters of
+// the function, which is run with the arguments of a call as the values of its parameters until a *Return ends it.
+type StoredFunction struct {
+	Name                 string
+	Definer              string
+	Params               []ProcedureParam
+	ReturnType           sql.Type
+	SecurityContext      ProcedureSecurityContext
+	Comment              string
+	Characteristics      []Characteristic
+	CreateFunctionString string
+	Body                 sql.Node
+	BodyString           string
+	CreatedAt            time.Time
+	ModifiedAt           time.Time
+}
+
+// NewStoredFunction returns a *StoredFunction. All names contained within are lowercase.
+func NewStoredFunction(
+	name string,
+	definer string,
+	params []ProcedureParam,
+	returnType sql.Type,
+	securityContext ProcedureSecurityContext,
+	comment string,
+	characteristics []Characteristic,
+	createFunctionString string,
+	body sql.Node,
+	bodyString string,
+	createdAt time.Time,
+	modifiedAt time.Time,
+) *StoredFunction {
+	lowercasedParams := make([]ProcedureParam, len(params))
+	for i, param := range params {
+		lowercasedParams[i] = ProcedureParam{
+			Direction: param.Direction,
+			Name:      strings.ToLower(param.Name),
+			Type:      param.Type,
+		}
+	}
+	return &StoredFunction{
+		Name:                 strings.ToLower(name),
+		Definer:              definer,
+		Params:               lowercasedParams,
+		ReturnType:           returnType,
+		SecurityContext:      securityContext,
+		Comment:              comment,
+		Characteristics:      characteristics,
+		CreateFunctionString: createFunctionString,
+		Body:                 body,
+		BodyString:           bodyString,
+		CreatedAt:            createdAt,
+		ModifiedAt:           modifiedAt,
+	}
+}
+
+// IsDeterministic returns whether the stored function was declared DETERMINISTIC, in which case it always returns the
+// same result for the same arguments. Stored functions are NOT DETERMINISTIC by default.
+func (f *StoredFunction) IsDeterministic() bool {
+	deterministic := false
+	for _, characteristic := range f.Characteristics {
+		switch characteristic {
+		case Characteristic_Deterministic:
+			deterministic = true
+		case Characteristic_NotDeterministic:
+			deterministic = false
+		}
+	}
+	return deterministic
+}
+
+// ParamsSchema returns the schema of the parameters of the stored function, as the columns of a table named after the
+// function, which is the schema of the scope that its body is evaluated in.
+func (f *StoredFunction) ParamsSchema() sql.Schema {
+	schema := make(sql.Schema, len(f.Params))
+	for i, param := range f.Params {
+		schema[i] = &sql.Column{
+			Name:     param.Name,
+			Source:   f.Name,
+			Type:     param.Type,
+			Nullable: true,
+		}
+	}
+	return schema
+}
+
+// String returns the signature of the stored function.
+func (f *StoredFunction) String() string {
+	params := make([]string, len(f.Params))
+	for i, param := range f.Params {
+		params[i] = fmt.Sprintf("%s %s", param.Name, param.Type.String())
+	}
+	return fmt.Sprintf("%s(%s) RETURNS %s", f.Name, strings.Join(params, ", "), f.ReturnType.String())
+}
+
+// StoredFunctionCall is a call of a stored function in an expression. The body of the function must have been
+// analyzed with the parameters of the function as its scope before it's evaluated.
+type StoredFunctionCall struct {
+	Function *StoredFunction
+	args     []sql.Expression
+	pRef     *expression.ProcedureParamReference
+}
+
+var _ sql.FunctionExpression = (*StoredFunctionCall)(nil)
+var _ sql.NonDeterministicExpression = (*StoredFunctionCall)(nil)
+
+// NewStoredFunctionCall returns a call of the stored function given with the arguments given. Returns an error if the
+// number of arguments doesn't match the parameters of the function.
+func NewStoredFunctionCall(function *StoredFunction, args ...sql.Expression) (*StoredFunctionCall, error) {
+	if len(args) != len(function.Params) {
+		return nil, sql.ErrStoredFunctionIncorrectArgumentCount.New(function.Name, len(function.Params), len(args))
+	}
+	return &StoredFunctionCall{Function: function, args: args}, nil
+}
+
+// FunctionName implements sql.FunctionExpression
+func (c *StoredFunctionCall) FunctionName() string {
+	return c.Function.Name
+}
+
+// Type implements the sql.Expression interface.
+func (c *StoredFunctionCall) Type() sql.Type {
+	return c.Function.ReturnType
+}
+
+// IsNullable implements the sql.Expression interface.
+func (c *StoredFunctionCall) IsNullable() bool {
+	return true
+}
+
+// IsNonDeterministic implements the sql.NonDeterministicExpression interface. Calls of stored functions that weren't
+// declared DETERMINISTIC are evaluated again for every row, even if their arguments don't change.
+func (c *StoredFunctionCall) IsNonDeterministic() bool {
+	return !c.Function.IsDeterministic()
+}
+
+// Resolved implements the sql.Expression interface.
+func (c *StoredFunctionCall) Resolved() bool {
+	for _, arg := range c.args {
+		if !arg.Resolved() {
+			return false
+		}
+	}
+	return c.Function.Body.Resolved()
+}
+
+// Children implements the sql.Expression interface.
+func (c *StoredFunctionCall) Children() []sql.Expression {
+	return c.args
+}
+
+// WithChildren implements the sql.Expression interface.
+func (c *StoredFunctionCall) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != len(c.args) {
+		return nil, sql.ErrInvalidChildrenNumber.New(c, len(children), len(c.args))
+	}
+	nc, err := NewStoredFunctionCall(c.Function, children...)
+	if err != nil {
+		return nil, err
+	}
+	nc.pRef = c.pRef
+	return nc, nil
+}
+
+// WithParamReference returns a new *StoredFunctionCall containing the given *expression.ProcedureParamReference,
+// which holds the parameters and local variables of a BEGIN ... END body.
+func (c *StoredFunctionCall) WithParamReference(pRef *expression.ProcedureParamReference) *StoredFunctionCall {
+	nc := *c
+	nc.pRef = pRef
+	return &nc
+}
+
+func (c *StoredFunctionCall) String() string {
+	args := make([]string, len(c.args))
+	for i, arg := range c.args {
+		args[i] = arg.String()
+	}
+	return fmt.Sprintf("%s(%s)", c.Function.Name, strings.Join(args, ", "))
+}
+
+// Eval implements the sql.Expression interface. The arguments are evaluated in the scope of the caller and converted
+// to the types of the parameters as values stored in columns are, and the body of the function is evaluated with them
+// as its scope.
+func (c *StoredFunctionCall) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	strict := sql.IsStrictSQLMode(ctx)
+	scope := make(sql.Row, len(c.args))
+	for i, arg := range c.args {
+		val, err := arg.Eval(ctx, row)
+		if err != nil {
+			return nil, err
+		}
+		param := c.Function.Params[i]
+		scope[i], err = sql.ConvertColumnValue(ctx, param.Name, param.Type, val, 1, strict)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if proc, ok := c.Function.Body.(*Procedure); ok {
+		val, err := c.evalProcedure(ctx, proc, scope)
+		if err != nil {
+			return nil, err
+		}
+		return c.Function.ReturnType.Convert(val)
+	}
+
+	// The body is a SELECT of a single expression without a FROM, so its expression is evaluated directly, with the
+	// scope row prepended to the row of the dual table as the rows of a subquery are
+	body, ok := c.Function.Body.(*Project)
+	if !ok || len(body.Projections) != 1 {
+		return nil, sql.ErrExpectedSingleRow.New()
+	}
+	val, err := body.Projections[0].Eval(ctx, append(scope, make(sql.Row, len(body.Child.Schema()))...))
+	if err != nil {
+		return nil, err
+	}
+
+	return c.Function.ReturnType.Convert(val)
+}
+
+// evalProcedure runs the BEGIN ... END body given with the arguments given as the values of its parameters, and
+// returns the value of the RETURN statement that ends it.
+func (c *StoredFunctionCall) evalProcedure(ctx *sql.Context, proc *Procedure, args sql.Row) (interface{}, error) {
+	for i, param := range proc.Params {
+		if err := c.pRef.Initialize(param.Name, param.Type, args[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	iter, err := proc.RowIter(ctx, nil)
+	if err == nil {
+		for err == nil {
+			_, err = iter.Next()
+		}
+		if err == io.EOF {
+			err = iter.Close(ctx)
+		} else {
+			_ = iter.Close(ctx)
+		}
+		if err == nil {
+			return nil, sql.ErrStoredFunctionEndedWithoutReturn.New(c.Function.Name)
+		}
+	}
+	if returned, ok := err.(*returnedValue); ok {
+		return returned.value, nil
+	}
+	return nil, err
+}
@@ -20,6 +20,7 @@ import (
 	"sync"
 
 	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
 )
 
 // Subquery is as an expression whose value is derived by executing a subquery. It must be executed for every row in
@@ -38,6 +39,8 @@ type Subquery struct {
 	cache []interface{}
 	// Cached hash results, if any
 	hashCache sql.KeyValueCache
+	// Cached results hashed for comparisons by IN, if any
+	inSet *expression.InSet
 	// Dispose function for the cache, if any. This would appear to violate the rule that nodes must be comparable by
 	// reflect.DeepEquals, but it's safe in practice because the function is always nil until execution.
 	disposeFunc sql.DisposeFunc
@@ -165,18 +168,7 @@ func (p *prependNode) WithChildren(children ...sql.Node) (sql.Node, error) {
 
 // Eval implements the Expression interface.
 func (s *Subquery) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
-	s.cacheMu.Lock()
-	cached := s.resultsCached
-	s.cacheMu.Unlock()
-
-	if cached {
-		if len(s.cache) == 0 {
-			return nil, nil
-		}
-		return s.cache[0], nil
-	}
-
-	rows, err := s.evalMultiple(ctx, row)
+	rows, err := s.EvalMultiple(ctx, row)
 	if err != nil {
 		return nil, err
 	}
@@ -185,14 +177,6 @@ func (s *Subquery) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 		return nil, sql.ErrExpectedSingleRow.New()
 	}
 
-	if s.canCacheResults {
-		s.cacheMu.Lock()
-		if !s.resultsCached {
-			s.cache, s.resultsCached = rows, true
-		}
-		s.cacheMu.Unlock()
-	}
-
 	if len(rows) == 0 {
 		return nil, nil
 	}
@@ -218,27 +202,27 @@ func prependRowInPlan(row sql.Row) func(n sql.Node) (sql.Node, error) {
 
 // EvalMultiple returns all rows returned by a subquery.
 func (s *Subquery) EvalMultiple(ctx *sql.Context, row sql.Row) ([]interface{}, error) {
-	s.cacheMu.Lock()
-	cached := s.resultsCached
-	s.cacheMu.Unlock()
-	if cached {
-		return s.cache, nil
+	if !s.canCacheResults {
+		return s.evalMultiple(ctx, row)
 	}
 
-	result, err := s.evalMultiple(ctx, row)
-	if err != nil {
-		return nil, err
-	}
+	s.cacheMu.Lock()
+	defer s.cacheMu.Unlock()
+	return s.cachedResults(ctx, row)
+}
 
-	if s.canCacheResults {
-		s.cacheMu.Lock()
-		if s.resultsCached == false {
-			s.cache, s.resultsCached = result, true
+// cachedResults returns the results of a cacheable subquery, evaluating it only if they haven't been cached yet. The
+// cache mutex must be held by the caller, so that concurrent evaluations wait for the results to be computed once
+// instead of computing them again.
+func (s *Subquery) cachedResults(ctx *sql.Context, row sql.Row) ([]interface{}, error) {
+	if !s.resultsCached {
+		result, err := s.evalMultiple(ctx, row)
+		if err != nil {
+			return nil, err
 		}
-		s.cacheMu.Unlock()
+		s.cache, s.resultsCached = result, true
 	}
-
-	return result, nil
+	return s.cache, nil
 }
 
 func (s *Subquery) evalMultiple(ctx *sql.Context, row sql.Row) ([]interface{}, error) {
@@ -286,34 +270,58 @@ func (s *Subquery) evalMultiple(ctx *sql.Context, row sql.Row) ([]interface{}, e
 // HashMultiple returns all rows returned by a subquery, backed by a sql.KeyValueCache. Keys are constructed using the
 // 64-bit hash of the values stored.
 func (s *Subquery) HashMultiple(ctx *sql.Context, row sql.Row) (sql.KeyValueCache, error) {
-	s.cacheMu.Lock()
-	cached := s.resultsCached && s.hashCache != nil
-	s.cacheMu.Unlock()
-	if cached {
-		return s.hashCache, nil
+	if !s.canCacheResults {
+		result, err := s.evalMultiple(ctx, row)
+		if err != nil {
+			return nil, err
+		}
+		cache := sql.NewMapCache()
+		return cache, putAllRows(cache, result)
 	}
 
-	result, err := s.evalMultiple(ctx, row)
-	if err != nil {
-		return nil, err
+	s.cacheMu.Lock()
+	defer s.cacheMu.Unlock()
+	if s.hashCache == nil {
+		result, err := s.cachedResults(ctx, row)
+		if err != nil {
+			return nil, err
+		}
+		hashCache, disposeFn := ctx.Memory.NewHistoryCache()
+		err = putAllRows(hashCache, result)
+		if err != nil {
+			return nil, err
+		}
+		s.hashCache, s.disposeFunc = hashCache, disposeFn
 	}
+	return s.hashCache, nil
+}
 
-	if s.canCacheResults {
-		s.cacheMu.Lock()
-		defer s.cacheMu.Unlock()
-		if !s.resultsCached || s.hashCache == nil {
-			hashCache, disposeFn := ctx.Memory.NewHistoryCache()
-			err = putAllRows(hashCache, result)
-			if err != nil {
-				return nil, err
-			}
-			s.cache, s.hashCache, s.disposeFunc, s.resultsCached = result, hashCache, disposeFn, true
+// HashedResults returns the rows returned by a subquery as an expression.InSet, for comparisons to values of the type
+// given by an IN expression. The set of a subquery whose results can be cached, which the analyzer only allows when the
+// subquery doesn't depend on the outer row, is built once and reused for every row.
+func (s *Subquery) HashedResults(ctx *sql.Context, row sql.Row, left sql.Type) (*expression.InSet, error) {
+	if !s.canCacheResults {
+		result, err := s.evalMultiple(ctx, row)
+		if err != nil {
+			return nil, err
 		}
-		return s.hashCache, nil
+		return expression.NewInSet(ctx, left, s.Type(), result)
 	}
 
-	cache := sql.NewMapCache()
-	return cache, putAllRows(cache, result)
+	s.cacheMu.Lock()
+	defer s.cacheMu.Unlock()
+	if s.inSet == nil {
+		result, err := s.cachedResults(ctx, row)
+		if err != nil {
+			return nil, err
+		}
+		inSet, err := expression.NewInSet(ctx, left, s.Type(), result)
+		if err != nil {
+			return nil, err
+		}
+		s.inSet = inSet
+	}
+	return s.inSet, nil
 }
 
 // HasResultRow returns whether the subquery has a result set > 0.
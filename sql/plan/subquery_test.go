@@ -15,6 +15,8 @@
 package plan_test
 
 import (
+	"sync"
+	"sync/atomic"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -82,3 +84,72 @@ func TestSubqueryMultipleRows(t *testing.T) {
 	require.NoError(err)
 	require.Equal(values, []interface{}{"one", "two", "three"})
 }
+
+func TestSubqueryCachedResultsEvaluatedOnce(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewEmptyContext()
+
+	table := memory.NewTable("", sql.PrimaryKeySchema{})
+	require.NoError(table.Insert(ctx, nil))
+
+	var evaluations int32
+	subquery := plan.NewSubquery(plan.NewProject(
+		[]sql.Expression{
+			&countingExpression{
+				UnaryExpression: expression.UnaryExpression{Child: expression.NewLiteral("one", sql.LongText)},
+				count:           &evaluations,
+			},
+		},
+		plan.NewResolvedTable(table, nil, nil),
+	), "select 'one'").WithCachedResults()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			value, err := subquery.Eval(ctx, nil)
+			require.NoError(err)
+			require.Equal("one", value)
+		}()
+	}
+	wg.Wait()
+
+	values, err := subquery.EvalMultiple(ctx, nil)
+	require.NoError(err)
+	require.Equal([]interface{}{"one"}, values)
+
+	_, err = subquery.HashMultiple(ctx, nil)
+	require.NoError(err)
+
+	_, err = subquery.HashedResults(ctx, nil, sql.LongText)
+	require.NoError(err)
+
+	require.Equal(int32(1), atomic.LoadInt32(&evaluations))
+}
+
+// countingExpression evaluates its child, counting how many times it's evaluated.
+type countingExpression struct {
+	expression.UnaryExpression
+	count *int32
+}
+
+func (c *countingExpression) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	atomic.AddInt32(c.count, 1)
+	return c.Child.Eval(ctx, row)
+}
+
+func (c *countingExpression) Type() sql.Type {
+	return c.Child.Type()
+}
+
+func (c *countingExpression) String() string {
+	return c.Child.String()
+}
+
+func (c *countingExpression) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(c, len(children), 1)
+	}
+	return &countingExpression{UnaryExpression: expression.UnaryExpression{Child: children[0]}, count: c.count}, nil
+}
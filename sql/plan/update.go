@@ -30,6 +30,8 @@ var ErrUpdateUnexpectedSetResult = errors.NewKind("attempted to set field but ex
 type Update struct {
 	UnaryNode
 	Checks sql.CheckConstraints
+	// Priority is the LOW_PRIORITY modifier given to the statement, if any.
+	Priority Priority
 }
 
 // NewUpdate creates an Update node.
@@ -166,8 +168,8 @@ func (u *updateIter) Next() (sql.Row, error) {
 					return nil, err
 				}
 
-				if sql.IsFalse(res) {
-					return nil, sql.ErrCheckConstraintViolated.New(check.Name)
+				if err := check.Violation(res); err != nil {
+					return nil, err
 				}
 			}
 
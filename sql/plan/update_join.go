@@ -24,6 +24,9 @@ import (
 
 type UpdateJoin struct {
 	updaters map[string]sql.RowUpdater
+	// accumulator counts the table rows matched by the join, and is set on the copy of the node executed by a
+	// RowUpdateAccumulator
+	accumulator *updateJoinRowHandler
 	UnaryNode
 }
 
@@ -60,9 +63,17 @@ func (u *UpdateJoin) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error)
 		caches:           make(map[string]sql.KeyValueCache),
 		disposals:        make(map[string]sql.DisposeFunc),
 		joinNode:         u.Child.(*UpdateSource).Child,
+		accumulator:      u.accumulator,
 	}, nil
 }
 
+// withAccumulator returns a copy of the node that counts the table rows matched by the join with the row handler given.
+func (u *UpdateJoin) withAccumulator(accumulator *updateJoinRowHandler) *UpdateJoin {
+	nu := *u
+	nu.accumulator = accumulator
+	return &nu
+}
+
 // GetUpdatable returns an updateJoinTable which implements sql.UpdatableTable.
 func (u *UpdateJoin) GetUpdatable() sql.UpdatableTable {
 	return &updatableJoinTable{
@@ -77,7 +88,9 @@ func (u *UpdateJoin) WithChildren(children ...sql.Node) (sql.Node, error) {
 		return nil, sql.ErrInvalidChildrenNumber.New(u, len(children), 1)
 	}
 
-	return NewUpdateJoin(u.updaters, children[0]), nil
+	nu := *u
+	nu.UnaryNode = UnaryNode{Child: children[0]}
+	return &nu, nil
 }
 
 // updateJoinIter wraps the child UpdateSource iter and returns join row in such a way that updates per table row are
@@ -90,6 +103,7 @@ type updateJoinIter struct {
 	caches           map[string]sql.KeyValueCache
 	disposals        map[string]sql.DisposeFunc
 	joinNode         sql.Node
+	accumulator      *updateJoinRowHandler
 }
 
 var _ sql.RowIter = (*updateJoinIter)(nil)
@@ -130,9 +144,13 @@ func (u *updateJoinIter) Next() (sql.Row, error) {
 				return nil, err
 			}
 
+			// A table row is matched the first time it shows up in the join, whether its values change or not
 			_, err = cache.Get(hash)
 			if errors.Is(err, sql.ErrKeyNotFound) {
 				cache.Put(hash, struct{}{})
+				if u.accumulator != nil {
+					u.accumulator.handleRowMatched()
+				}
 				continue
 			} else if err != nil {
 				return nil, err
@@ -76,16 +76,12 @@ func (p *Values) Resolved() bool {
 func (p *Values) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
 	rows := make([]sql.Row, len(p.ExpressionTuples))
 	for i, et := range p.ExpressionTuples {
-		vals := make([]interface{}, len(et))
-		for j, e := range et {
-			var err error
-			vals[j], err = e.Eval(ctx, row)
-			if err != nil {
-				return nil, err
-			}
+		// Tuples may have column defaults that reference the other values of the tuple, which ProjectRow handles
+		var err error
+		rows[i], err = ProjectRow(ctx, et, row)
+		if err != nil {
+			return nil, err
 		}
-
-		rows[i] = sql.NewRow(vals...)
 	}
 
 	return sql.RowsToRowIter(rows...), nil
@@ -171,7 +171,10 @@ func (i *windowIter) compute() error {
 		}
 	}
 
-	for {
+	for n := 0; ; n++ {
+		if err := sql.CheckCancellation(i.ctx, n); err != nil {
+			return err
+		}
 		row, err := i.childIter.Next()
 		if err == io.EOF {
 			break
@@ -22,10 +22,12 @@ import (
 )
 
 // With is a node to wrap the top-level node in a query plan so that any common table expressions can be applied in
-// analysis. It is removed during analysis.
+// analysis. It is removed during analysis. In a WITH RECURSIVE clause, common table expressions may refer to
+// themselves.
 type With struct {
 	UnaryNode
-	CTEs []*CommonTableExpression
+	CTEs      []*CommonTableExpression
+	Recursive bool
 }
 
 func NewWith(child sql.Node, ctes []*CommonTableExpression) *With {
@@ -35,6 +37,15 @@ func NewWith(child sql.Node, ctes []*CommonTableExpression) *With {
 	}
 }
 
+// NewRecursiveWith returns a With node for a WITH RECURSIVE clause.
+func NewRecursiveWith(child sql.Node, ctes []*CommonTableExpression) *With {
+	return &With{
+		UnaryNode: UnaryNode{child},
+		CTEs:      ctes,
+		Recursive: true,
+	}
+}
+
 func (w *With) String() string {
 	cteStrings := make([]string, len(w.CTEs))
 	for i, e := range w.CTEs {
@@ -42,7 +53,11 @@ func (w *With) String() string {
 	}
 
 	pr := sql.NewTreePrinter()
-	_ = pr.WriteNode("With(%s)", strings.Join(cteStrings, ", "))
+	if w.Recursive {
+		_ = pr.WriteNode("With recursive(%s)", strings.Join(cteStrings, ", "))
+	} else {
+		_ = pr.WriteNode("With(%s)", strings.Join(cteStrings, ", "))
+	}
 	_ = pr.WriteChildren(w.Child.String())
 	return pr.String()
 }
@@ -54,7 +69,11 @@ func (w *With) DebugString() string {
 	}
 
 	pr := sql.NewTreePrinter()
-	_ = pr.WriteNode("With(%s)", strings.Join(cteStrings, ", "))
+	if w.Recursive {
+		_ = pr.WriteNode("With recursive(%s)", strings.Join(cteStrings, ", "))
+	} else {
+		_ = pr.WriteNode("With(%s)", strings.Join(cteStrings, ", "))
+	}
 	_ = pr.WriteChildren(sql.DebugString(w.Child))
 	return pr.String()
 }
@@ -68,7 +87,9 @@ func (w *With) WithChildren(children ...sql.Node) (sql.Node, error) {
 		return nil, sql.ErrInvalidChildrenNumber.New(w, len(children), 1)
 	}
 
-	return NewWith(children[0], w.CTEs), nil
+	nw := *w
+	nw.Child = children[0]
+	return &nw, nil
 }
 
 type CommonTableExpression struct {
@@ -85,10 +85,31 @@ func FormatRow(row Row) string {
 type RowIter interface {
 	// Next retrieves the next row. It will return io.EOF if it's the last row.
 	// After retrieving the last row, Close will be automatically closed.
+	// Once the context of the iterator is cancelled or its deadline is
+	// exceeded, Next returns the error of the context promptly, including
+	// when it's called on an iterator that buffers its input before returning
+	// its first row, such as a sort or a hash build, which checks its context
+	// with CheckCancellation while buffering instead of reading all its input.
 	Next() (Row, error)
 	Closer
 }
 
+// CancellationCheckInterval is the number of rows read, or comparisons made,
+// between the checks of the context made by CheckCancellation.
+const CancellationCheckInterval = 1024
+
+// CheckCancellation returns the error of the context given if it's done,
+// checking it once every CancellationCheckInterval calls, where n is the
+// number of calls so far. It's meant for the loops of iterators that buffer
+// rows or sort them, which would otherwise not return until their whole input
+// is processed.
+func CheckCancellation(ctx *Context, n int) error {
+	if ctx == nil || n%CancellationCheckInterval != 0 {
+		return nil
+	}
+	return ctx.Err()
+}
+
 // RowIterToRows converts a row iterator to a slice of rows.
 func RowIterToRows(ctx *Context, i RowIter) ([]Row, error) {
 	var rows []Row
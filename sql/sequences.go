@@ -0,0 +1,119 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+import (
+	"math"
+	"strings"
+	"sync"
+
+	"gopkg.in/src-d/go-errors.v1"
+)
+
+// ErrSequenceNotFound is the kind of error returned when a sequence with the name given doesn't exist
+var ErrSequenceNotFound = errors.NewKind("Unknown sequence '%s'")
+
+// ErrSequenceAlreadyExists is the kind of error returned when creating a sequence with the name of an existing one
+var ErrSequenceAlreadyExists = errors.NewKind("Sequence '%s' already exists")
+
+// ErrInvalidSequenceIncrement is the kind of error returned when creating a sequence that doesn't advance
+var ErrInvalidSequenceIncrement = errors.NewKind("Sequence '%s' has an increment of 0")
+
+// ErrSequenceExhausted is the kind of error returned when the next value of a sequence doesn't fit in a BIGINT
+var ErrSequenceExhausted = errors.NewKind("Sequence '%s' has run out of values")
+
+type sequence struct {
+	next      int64
+	increment int64
+	exhausted bool
+}
+
+// Sequences manages named sequences, which hand out a monotonic series of values with NEXTVAL(). They emulate the
+// sequences of other databases, so that a column can draw its values from a sequence with DEFAULT (NEXTVAL('seq'))
+// instead of being an AUTO_INCREMENT column. Sequences are shared by all the sessions of an engine, and aren't
+// persisted. The names of sequences are case-insensitive.
+type Sequences struct {
+	mu        sync.Mutex
+	sequences map[string]*sequence
+}
+
+// NewSequences creates a Sequences object without any sequences
+func NewSequences() *Sequences {
+	return &Sequences{sequences: make(map[string]*sequence)}
+}
+
+// Create creates a sequence with the name given, which starts at the value given and then advances by the increment
+// given, which may be negative.
+func (s *Sequences) Create(name string, start, increment int64) error {
+	if increment == 0 {
+		return ErrInvalidSequenceIncrement.New(name)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := strings.ToLower(name)
+	if _, ok := s.sequences[key]; ok {
+		return ErrSequenceAlreadyExists.New(name)
+	}
+	s.sequences[key] = &sequence{next: start, increment: increment}
+	return nil
+}
+
+// Drop removes the sequence with the name given.
+func (s *Sequences) Drop(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := strings.ToLower(name)
+	if _, ok := s.sequences[key]; !ok {
+		return ErrSequenceNotFound.New(name)
+	}
+	delete(s.sequences, key)
+	return nil
+}
+
+// Exists returns whether a sequence with the name given exists.
+func (s *Sequences) Exists(name string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, ok := s.sequences[strings.ToLower(name)]
+	return ok
+}
+
+// NextVal returns the next value of the sequence with the name given and advances it. Concurrent callers always get
+// distinct values.
+func (s *Sequences) NextVal(name string) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	seq, ok := s.sequences[strings.ToLower(name)]
+	if !ok {
+		return 0, ErrSequenceNotFound.New(name)
+	}
+	if seq.exhausted {
+		return 0, ErrSequenceExhausted.New(name)
+	}
+
+	val := seq.next
+	if (seq.increment > 0 && val > math.MaxInt64-seq.increment) ||
+		(seq.increment < 0 && val < math.MinInt64-seq.increment) {
+		seq.exhausted = true
+	} else {
+		seq.next += seq.increment
+	}
+	return val, nil
+}
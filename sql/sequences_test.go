@@ -0,0 +1,101 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSequences(t *testing.T) {
+	s := NewSequences()
+	require.NoError(t, s.Create("up", 1, 2))
+	require.NoError(t, s.Create("down", 0, -5))
+	assert.True(t, ErrSequenceAlreadyExists.Is(s.Create("UP", 1, 1)))
+	assert.True(t, ErrInvalidSequenceIncrement.Is(s.Create("zero", 1, 0)))
+	assert.True(t, s.Exists("Up"))
+	assert.False(t, s.Exists("zero"))
+
+	for _, expected := range []int64{1, 3, 5} {
+		val, err := s.NextVal("up")
+		require.NoError(t, err)
+		assert.Equal(t, expected, val)
+	}
+	for _, expected := range []int64{0, -5} {
+		val, err := s.NextVal("DOWN")
+		require.NoError(t, err)
+		assert.Equal(t, expected, val)
+	}
+
+	_, err := s.NextVal("unknown")
+	assert.True(t, ErrSequenceNotFound.Is(err))
+
+	require.NoError(t, s.Drop("up"))
+	_, err = s.NextVal("up")
+	assert.True(t, ErrSequenceNotFound.Is(err))
+	assert.True(t, ErrSequenceNotFound.Is(s.Drop("up")))
+}
+
+func TestSequenceExhausted(t *testing.T) {
+	s := NewSequences()
+	require.NoError(t, s.Create("s", math.MaxInt64-1, 1))
+
+	for _, expected := range []int64{math.MaxInt64 - 1, math.MaxInt64} {
+		val, err := s.NextVal("s")
+		require.NoError(t, err)
+		assert.Equal(t, expected, val)
+	}
+	_, err := s.NextVal("s")
+	assert.True(t, ErrSequenceExhausted.Is(err))
+}
+
+func TestSequenceConcurrentNextVal(t *testing.T) {
+	const workers, callsPerWorker = 8, 1000
+
+	s := NewSequences()
+	require.NoError(t, s.Create("s", 1, 1))
+
+	vals := make([][]int64, workers)
+	var wg sync.WaitGroup
+	for i := range vals {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < callsPerWorker; j++ {
+				val, err := s.NextVal("s")
+				if err != nil {
+					panic(err)
+				}
+				vals[i] = append(vals[i], val)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	var all []int64
+	for _, workerVals := range vals {
+		assert.True(t, sort.SliceIsSorted(workerVals, func(i, j int) bool { return workerVals[i] < workerVals[j] }))
+		all = append(all, workerVals...)
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i] < all[j] })
+	for i, val := range all {
+		require.Equal(t, int64(i+1), val)
+	}
+}
@@ -38,8 +38,9 @@ const (
 )
 
 const (
-	CurrentDBSessionVar  = "current_database"
-	AutoCommitSessionVar = "autocommit"
+	CurrentDBSessionVar            = "current_database"
+	AutoCommitSessionVar           = "autocommit"
+	TransactionIsolationSessionVar = "transaction_isolation"
 )
 
 // Client holds session user information.
@@ -236,6 +237,9 @@ func (s *BaseSession) SetSessionVariable(ctx *Context, sysVarName string, value
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	s.systemVars[sysVar.Name] = convertedVal
+	if alias, ok := systemVariableAliases[sysVar.Name]; ok {
+		s.systemVars[alias] = convertedVal
+	}
 	return nil
 }
 
@@ -659,6 +663,20 @@ func (c *Context) QueryTime() time.Time {
 	return c.queryTime
 }
 
+// GetTransactionIsolationLevel returns the isolation level of the transactions begun by this session, which is the
+// value of @@transaction_isolation.
+func (c *Context) GetTransactionIsolationLevel() (IsolationLevel, error) {
+	val, err := c.GetSessionVariable(c, TransactionIsolationSessionVar)
+	if err != nil {
+		return "", err
+	}
+	level, ok := val.(string)
+	if !ok {
+		return "", ErrInvalidSystemVariableValue.New(TransactionIsolationSessionVar, val)
+	}
+	return IsolationLevel(level), nil
+}
+
 // Span creates a new tracing span with the given context.
 // It will return the span and a new context that should be passed to all
 // children of this span.
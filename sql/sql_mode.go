@@ -0,0 +1,208 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+import (
+	"math"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/dolthub/vitess/go/sqltypes"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	// dataTruncatedCode is the MySQL warning code for a value that is truncated or replaced when it's stored.
+	dataTruncatedCode = 1265
+	// dataOutOfRangeCode is the MySQL warning code for a number that is clamped to the range of its column.
+	dataOutOfRangeCode = 1264
+)
+
+// IsStrictSQLMode returns whether the sql_mode of the session of the context given has STRICT_TRANS_TABLES or
+// STRICT_ALL_TABLES, in which case values that don't fit the columns they're stored in are errors instead of being
+// adjusted with a warning.
+func IsStrictSQLMode(ctx *Context) bool {
+	val, err := ctx.GetSessionVariable(ctx, "sql_mode")
+	if err != nil {
+		return true
+	}
+	mode, ok := val.(string)
+	if !ok {
+		return true
+	}
+
+	for _, m := range strings.Split(mode, ",") {
+		m = strings.ToUpper(strings.TrimSpace(m))
+		if m == "STRICT_TRANS_TABLES" || m == "STRICT_ALL_TABLES" {
+			return true
+		}
+	}
+	return false
+}
+
+// ConvertColumnValue converts the value given to the type of the column given, as INSERT and UPDATE do for the values
+// they store. Strings that are too long, numbers out of range, strings stored as numbers that aren't numbers and
+// invalid dates are errors when strict is true, as in strict SQL mode. Otherwise, strings are truncated, numbers are
+// clamped to the range of the type, strings that aren't numbers are stored as the number they start with and invalid
+// dates and years are replaced by the zero value, with a warning. The row given is the number of the row the value is stored in,
+// counted from 1, for the messages.
+func ConvertColumnValue(ctx *Context, column string, typ Type, val interface{}, row int, strict bool) (interface{}, error) {
+	converted, err := typ.Convert(val)
+	if err == nil {
+		return converted, nil
+	}
+
+	switch {
+	case ErrLengthBeyondLimit.Is(err):
+		if strict {
+			return nil, ErrDataTooLong.New(column, row)
+		}
+		st, ok := typ.(StringType)
+		if !ok {
+			return nil, err
+		}
+		s, err := LongText.Convert(val)
+		if err != nil {
+			return nil, err
+		}
+		// Lengths are checked in bytes, see stringType.Convert
+		limit := st.MaxCharacterLength()
+		if typ.Type() == sqltypes.Text || typ.Type() == sqltypes.Blob {
+			limit = st.MaxByteLength()
+		}
+		ctx.Warn(dataTruncatedCode, "Data truncated for column '%s' at row %d", column, row)
+		return typ.Convert(truncateString(s.(string), limit))
+	case ErrOutOfRange.Is(err), ErrConvertToDecimalLimit.Is(err):
+		if strict {
+			return nil, ErrDataOutOfRange.New(column, row)
+		}
+		bound, ok := rangeBound(typ, val)
+		if !ok {
+			return nil, err
+		}
+		ctx.Warn(dataOutOfRangeCode, "Out of range value for column '%s' at row %d", column, row)
+		return typ.Convert(bound)
+	case ErrConvertingToYear.Is(err):
+		// Years out of range are replaced by the zero year
+		if strict {
+			return nil, ErrDataOutOfRange.New(column, row)
+		}
+		ctx.Warn(dataOutOfRangeCode, "Out of range value for column '%s' at row %d", column, row)
+		return typ.Zero(), nil
+	case ErrInvalidValue.Is(err) && IsNumber(typ):
+		s, ok := val.(string)
+		if !ok {
+			return nil, err
+		}
+		prefix, whole := NumericPrefix(s)
+		if !whole {
+			if strict {
+				return nil, ErrIncorrectValue.New(numberTypeName(typ), val, column, row)
+			}
+			ctx.Warn(dataTruncatedCode, "Data truncated for column '%s' at row %d", column, row)
+		}
+		// Numbers the type doesn't parse from strings, such as negative or fractional ones for unsigned types, are
+		// converted from their value, which may still be out of range
+		d, err := decimal.NewFromString(prefix)
+		if err != nil {
+			return nil, err
+		}
+		return ConvertColumnValue(ctx, column, typ, d, row, strict)
+	case ErrConvertingToTime.Is(err), ErrConvertingToTimeOutOfRange.Is(err), ErrConvertingToTimeType.Is(err):
+		if strict {
+			return nil, ErrInvalidDate.New(strings.ToLower(typ.String()), val, column, row)
+		}
+		ctx.Warn(dataTruncatedCode, "Data truncated for column '%s' at row %d", column, row)
+		return typ.Zero(), nil
+	default:
+		return nil, err
+	}
+}
+
+// numberTypeName returns the name of the number type given in the messages of values that aren't numbers.
+func numberTypeName(typ Type) string {
+	switch {
+	case IsInteger(typ):
+		return "integer"
+	case IsDecimal(typ):
+		return "decimal"
+	default:
+		return "double"
+	}
+}
+
+// truncateString returns the longest prefix of the string given that has at most the number of bytes given, without
+// splitting a character.
+func truncateString(s string, limit int64) string {
+	if int64(len(s)) <= limit {
+		return s
+	}
+	end := int(limit)
+	for end > 0 && !utf8.RuneStart(s[end]) {
+		end--
+	}
+	return s[:end]
+}
+
+// rangeBound returns the bound of the range of the number type given that is the closest to the value given, which is
+// out of that range. Returns false if the type has no such bound.
+func rangeBound(typ Type, val interface{}) (interface{}, bool) {
+	d, err := InternalDecimalType.ConvertToDecimal(val)
+	if err != nil || !d.Valid {
+		return nil, false
+	}
+	negative := d.Decimal.Sign() < 0
+
+	if dt, ok := typ.(DecimalType); ok {
+		max := dt.ExclusiveUpperBound().Sub(decimal.New(1, -int32(dt.Scale())))
+		if negative {
+			return max.Neg(), true
+		}
+		return max, true
+	}
+
+	var min, max interface{}
+	switch typ.Type() {
+	case sqltypes.Int8:
+		min, max = int64(math.MinInt8), int64(math.MaxInt8)
+	case sqltypes.Uint8:
+		min, max = uint64(0), uint64(math.MaxUint8)
+	case sqltypes.Int16:
+		min, max = int64(math.MinInt16), int64(math.MaxInt16)
+	case sqltypes.Uint16:
+		min, max = uint64(0), uint64(math.MaxUint16)
+	case sqltypes.Int24:
+		min, max = int64(-1<<23), int64(1<<23-1)
+	case sqltypes.Uint24:
+		min, max = uint64(0), uint64(1<<24-1)
+	case sqltypes.Int32:
+		min, max = int64(math.MinInt32), int64(math.MaxInt32)
+	case sqltypes.Uint32:
+		min, max = uint64(0), uint64(math.MaxUint32)
+	case sqltypes.Int64:
+		min, max = int64(math.MinInt64), int64(math.MaxInt64)
+	case sqltypes.Uint64:
+		min, max = uint64(0), uint64(math.MaxUint64)
+	case sqltypes.Float32:
+		min, max = -math.MaxFloat32, math.MaxFloat32
+	default:
+		return nil, false
+	}
+
+	if negative {
+		return min, true
+	}
+	return max, true
+}
@@ -0,0 +1,83 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+import (
+	"testing"
+
+	"github.com/dolthub/vitess/go/sqltypes"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/src-d/go-errors.v1"
+)
+
+func TestIsStrictSQLMode(t *testing.T) {
+	ctx := NewEmptyContext()
+	require.True(t, IsStrictSQLMode(ctx))
+
+	for mode, expected := range map[string]bool{
+		"":                                     false,
+		"NO_ENGINE_SUBSTITUTION":               false,
+		"STRICT_TRANS_TABLES":                  true,
+		"ONLY_FULL_GROUP_BY,STRICT_ALL_TABLES": true,
+		"no_engine_substitution,strict_all_tables": true,
+	} {
+		require.NoError(t, ctx.SetSessionVariable(ctx, "sql_mode", mode))
+		require.Equal(t, expected, IsStrictSQLMode(ctx), mode)
+	}
+}
+
+func TestConvertColumnValue(t *testing.T) {
+	testCases := []struct {
+		name     string
+		typ      Type
+		val      interface{}
+		expected interface{}
+		err      *errors.Kind
+	}{
+		{"fits", Int8, 100, int8(100), nil},
+		{"string too long", MustCreateStringWithDefaults(sqltypes.VarChar, 3), "abcdef", "abc", ErrDataTooLong},
+		{"multibyte string too long", MustCreateStringWithDefaults(sqltypes.VarChar, 4), "añbc", "añb", ErrDataTooLong},
+		{"number above range", Int8, 300, int8(127), ErrDataOutOfRange},
+		{"number below range", Uint8, -1, uint8(0), ErrDataOutOfRange},
+		{"year out of range", Year, 1900, int16(0), ErrDataOutOfRange},
+		{"invalid date", Date, "not a date", zeroTime, ErrInvalidDate},
+		{"string with a number", Int32, " 12 ", int32(12), nil},
+		{"string starting with a number", Int32, "12abc", int32(12), ErrIncorrectValue},
+		{"string not a number", MustCreateDecimalType(4, 1), "abc", "0.0", ErrIncorrectValue},
+		{"negative string for unsigned", Uint8, "-5", uint8(0), ErrDataOutOfRange},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := NewEmptyContext()
+			_, err := ConvertColumnValue(ctx, "c", tt.typ, tt.val, 2, true)
+			if tt.err == nil {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				require.True(t, tt.err.Is(err), err.Error())
+			}
+
+			val, err := ConvertColumnValue(ctx, "c", tt.typ, tt.val, 2, false)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, val)
+			if tt.err == nil {
+				require.Empty(t, ctx.Warnings())
+			} else {
+				require.Len(t, ctx.Warnings(), 1)
+			}
+		})
+	}
+}
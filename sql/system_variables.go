@@ -168,9 +168,21 @@ func (sv *globalSystemVariables) SetGlobal(name string, val interface{}) error {
 		return err
 	}
 	sv.sysVarVals[name] = convertedVal
+	if alias, ok := systemVariableAliases[name]; ok {
+		sv.sysVarVals[alias] = convertedVal
+	}
 	return nil
 }
 
+// systemVariableAliases are the pairs of system variables that are different names for the same value, such as the
+// deprecated tx_isolation for transaction_isolation. Setting either of them sets the other one.
+var systemVariableAliases = map[string]string{
+	"transaction_isolation": "tx_isolation",
+	"tx_isolation":          "transaction_isolation",
+	"transaction_read_only": "tx_read_only",
+	"tx_read_only":          "transaction_read_only",
+}
+
 // InitSystemVariables resets the systemVars singleton
 func InitSystemVariables() {
 	for _, sysVar := range systemVars {
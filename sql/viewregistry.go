@@ -15,6 +15,8 @@
 package sql
 
 import (
+	"fmt"
+	"regexp"
 	"strings"
 	"sync"
 )
@@ -47,6 +49,240 @@ func (v *View) TextDefinition() string {
 	return v.textDefinition
 }
 
+// CheckOption returns the WITH CHECK OPTION clause the view was defined with.
+func (v *View) CheckOption() ViewCheckOption {
+	_, option := SplitViewCheckOption(v.textDefinition)
+	return option
+}
+
+// ViewCheckOption is the WITH CHECK OPTION clause of a view definition. Views defined with a check option reject
+// inserts and updates through them that produce rows the view cannot see.
+type ViewCheckOption byte
+
+const (
+	ViewCheckOption_None     ViewCheckOption = iota // No check option was given
+	ViewCheckOption_Local                           // Only the view's own WHERE clause is checked
+	ViewCheckOption_Cascaded                        // The WHERE clauses of the view and its underlying views are checked
+)
+
+// String returns the check option as shown in the CHECK_OPTION column of information_schema.VIEWS.
+func (o ViewCheckOption) String() string {
+	switch o {
+	case ViewCheckOption_Local:
+		return "LOCAL"
+	case ViewCheckOption_Cascaded:
+		return "CASCADED"
+	default:
+		return "NONE"
+	}
+}
+
+var viewCheckOptionRegex = regexp.MustCompile(`(?is)\s+with\s+(?:(cascaded|local)\s+)?check\s+option\s*$`)
+
+// SplitViewCheckOption separates a trailing WITH CHECK OPTION clause from the text definition of a view, returning the
+// select statement and the check option the clause declares. A clause without LOCAL or CASCADED is CASCADED.
+func SplitViewCheckOption(textDefinition string) (string, ViewCheckOption) {
+	match := viewCheckOptionRegex.FindStringSubmatchIndex(textDefinition)
+	if match == nil {
+		return textDefinition, ViewCheckOption_None
+	}
+
+	option := ViewCheckOption_Cascaded
+	if match[2] >= 0 && strings.EqualFold(textDefinition[match[2]:match[3]], "local") {
+		option = ViewCheckOption_Local
+	}
+	return textDefinition[:match[0]], option
+}
+
+// DefaultViewDefiner is the definer shown for views whose definition doesn't declare one. Views don't record the user
+// that created them otherwise.
+const DefaultViewDefiner = "`root`@`localhost`"
+
+// ViewAttributes are the clauses of a CREATE VIEW statement besides its select statement and check option: the
+// ALGORITHM, DEFINER and SQL SECURITY clauses, and the list of the view's column names. Clauses the view wasn't
+// defined with are empty.
+type ViewAttributes struct {
+	// Algorithm is UNDEFINED, MERGE or TEMPTABLE
+	Algorithm   string
+	DefinerUser string
+	DefinerHost string
+	// Security is DEFINER or INVOKER
+	Security string
+	Columns  []string
+}
+
+// IsEmpty returns whether the view was defined without any of the clauses.
+func (a ViewAttributes) IsEmpty() bool {
+	return a.Algorithm == "" && a.DefinerUser == "" && a.Security == "" && len(a.Columns) == 0
+}
+
+// Definer returns the DEFINER clause's account, as in `user`@`host`, or an empty string if the view was defined
+// without one.
+func (a ViewAttributes) Definer() string {
+	if a.DefinerUser == "" {
+		return ""
+	}
+	return quoteViewIdentifier(a.DefinerUser) + "@" + quoteViewIdentifier(a.DefinerHost)
+}
+
+// ColumnList returns the list of the view's column names, as in (`a`,`b`), or an empty string if the view was
+// defined without one.
+func (a ViewAttributes) ColumnList() string {
+	if len(a.Columns) == 0 {
+		return ""
+	}
+	quoted := make([]string, len(a.Columns))
+	for i, column := range a.Columns {
+		quoted[i] = quoteViewIdentifier(column)
+	}
+	return "(" + strings.Join(quoted, ",") + ")"
+}
+
+// String returns the clauses as the text definition of a view begins with them, ending in the AS keyword that
+// introduces the select statement, or an empty string if the view was defined without any of them.
+func (a ViewAttributes) String() string {
+	if a.IsEmpty() {
+		return ""
+	}
+
+	var sb strings.Builder
+	if a.Algorithm != "" {
+		sb.WriteString("ALGORITHM=" + a.Algorithm + " ")
+	}
+	if a.DefinerUser != "" {
+		sb.WriteString("DEFINER=" + a.Definer() + " ")
+	}
+	if a.Security != "" {
+		sb.WriteString("SQL SECURITY " + a.Security + " ")
+	}
+	if len(a.Columns) > 0 {
+		sb.WriteString(a.ColumnList() + " ")
+	}
+	sb.WriteString("AS ")
+	return sb.String()
+}
+
+func quoteViewIdentifier(name string) string {
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
+
+const viewAccountPartPattern = "'(?:[^']|'')*'|\"(?:[^\"]|\"\")*\"|`(?:[^`]|``)*`|[\\w.%$]+"
+
+var viewAttributesRegex = regexp.MustCompile("(?is)^\\s*" +
+	"(?:algorithm\\s*=\\s*(undefined|merge|temptable)\\s+)?" +
+	"(?:definer\\s*=\\s*(?:current_user(?:\\s*\\(\\s*\\))?|(" + viewAccountPartPattern + ")(?:\\s*@\\s*(" + viewAccountPartPattern + "))?)\\s+)?" +
+	"(?:sql\\s+security\\s+(definer|invoker)\\s+)?" +
+	"(?:\\(((?:`(?:[^`]|``)*`|[^()`])*)\\)\\s*)?" +
+	"as\\s+")
+
+// SplitViewAttributes separates the clauses a text definition of a view may begin with, as ViewAttributes.String
+// returns them, from the rest of the definition. A definition without them is returned as is. DEFINER = CURRENT_USER
+// is the same as no DEFINER clause.
+func SplitViewAttributes(textDefinition string) (ViewAttributes, string) {
+	match := viewAttributesRegex.FindStringSubmatchIndex(textDefinition)
+	if match == nil {
+		return ViewAttributes{}, textDefinition
+	}
+
+	group := func(i int) string {
+		if match[2*i] < 0 {
+			return ""
+		}
+		return textDefinition[match[2*i]:match[2*i+1]]
+	}
+
+	var attributes ViewAttributes
+	attributes.Algorithm = strings.ToUpper(group(1))
+	if user := group(2); user != "" {
+		attributes.DefinerUser = unquoteViewAccountPart(user)
+		attributes.DefinerHost = "%"
+		if host := group(3); host != "" {
+			attributes.DefinerHost = unquoteViewAccountPart(host)
+		}
+	}
+	attributes.Security = strings.ToUpper(group(4))
+	if match[10] >= 0 {
+		columns, ok := splitViewColumns(group(5))
+		if !ok {
+			return ViewAttributes{}, textDefinition
+		}
+		attributes.Columns = columns
+	}
+
+	return attributes, textDefinition[match[1]:]
+}
+
+func unquoteViewAccountPart(part string) string {
+	switch quote := part[0]; quote {
+	case '\'', '"', '`':
+		return strings.ReplaceAll(part[1:len(part)-1], string([]byte{quote, quote}), string(quote))
+	default:
+		return part
+	}
+}
+
+// splitViewColumns returns the names in a comma-separated list of column names, which may be quoted with backticks.
+// Returns false if any of the names is empty.
+func splitViewColumns(list string) ([]string, bool) {
+	var columns []string
+	var name strings.Builder
+	isQuoted, isEmpty := false, true
+	for i := 0; i < len(list); i++ {
+		c := list[i]
+		switch {
+		case c == '`' && isQuoted && i+1 < len(list) && list[i+1] == '`':
+			name.WriteByte(c)
+			i++
+		case c == '`':
+			isQuoted = !isQuoted
+			isEmpty = false
+		case isQuoted:
+			name.WriteByte(c)
+		case c == ',':
+			if isEmpty {
+				return nil, false
+			}
+			columns = append(columns, name.String())
+			name.Reset()
+			isEmpty = true
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+		default:
+			name.WriteByte(c)
+			isEmpty = false
+		}
+	}
+	if isEmpty || name.Len() == 0 {
+		return nil, false
+	}
+	return append(columns, name.String()), true
+}
+
+// CreateViewStatement returns the CREATE VIEW statement for the view with the name and text definition given, as SHOW
+// CREATE VIEW shows it. The clauses the view wasn't defined with are shown with their default values.
+func CreateViewStatement(name, textDefinition string) string {
+	attributes, definition := SplitViewAttributes(textDefinition)
+
+	algorithm := attributes.Algorithm
+	if algorithm == "" {
+		algorithm = "UNDEFINED"
+	}
+	definer := attributes.Definer()
+	if definer == "" {
+		definer = DefaultViewDefiner
+	}
+	security := attributes.Security
+	if security == "" {
+		security = "DEFINER"
+	}
+	columns := attributes.ColumnList()
+	if columns != "" {
+		columns += " "
+	}
+
+	return fmt.Sprintf("CREATE ALGORITHM=%s DEFINER=%s SQL SECURITY %s VIEW %s %sAS %s",
+		algorithm, definer, security, quoteViewIdentifier(name), columns, definition)
+}
+
 // ViewKey is the key used to store view definitions
 type ViewKey struct {
 	dbName, viewName string
@@ -148,3 +384,30 @@ func (r *ViewRegistry) Exists(databaseName, viewName string) bool {
 
 	return r.exists(databaseName, viewName)
 }
+
+// StoredViewName returns the name of the view with the name given in the database given as it's stored, either by the
+// database or in the session's view registry, matching the name as lowerCaseTableNames says to. Returns
+// ErrViewDoesNotExist if no view matches it.
+func StoredViewName(ctx *Context, lowerCaseTableNames LowerCaseTableNames, db Database, name string) (string, error) {
+	if vdb, ok := db.(ViewDatabase); ok {
+		views, err := vdb.AllViews(ctx)
+		if err != nil {
+			return "", err
+		}
+		for _, view := range views {
+			if lowerCaseTableNames.MatchesName(db, name, view.Name) {
+				return view.Name, nil
+			}
+		}
+		return "", ErrViewDoesNotExist.New(db.Name(), name)
+	}
+
+	view, err := ctx.GetViewRegistry().View(db.Name(), name)
+	if err != nil {
+		return "", err
+	}
+	if !lowerCaseTableNames.MatchesName(db, name, view.Name()) {
+		return "", ErrViewDoesNotExist.New(db.Name(), name)
+	}
+	return view.Name(), nil
+}
@@ -179,3 +179,79 @@ func TestExistsOnNonExistingView(t *testing.T) {
 
 	require.False(registry.Exists("non", "existing"))
 }
+
+func TestSplitViewCheckOption(t *testing.T) {
+	tests := []struct {
+		definition string
+		selectStmt string
+		option     ViewCheckOption
+	}{
+		{"SELECT * FROM t", "SELECT * FROM t", ViewCheckOption_None},
+		{"SELECT * FROM t WITH CHECK OPTION", "SELECT * FROM t", ViewCheckOption_Cascaded},
+		{"SELECT * FROM t with cascaded check option", "SELECT * FROM t", ViewCheckOption_Cascaded},
+		{"SELECT * FROM t WHERE a > 1\nWITH  LOCAL CHECK\tOPTION ", "SELECT * FROM t WHERE a > 1", ViewCheckOption_Local},
+		{"SELECT 'WITH CHECK OPTION' FROM t", "SELECT 'WITH CHECK OPTION' FROM t", ViewCheckOption_None},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.definition, func(t *testing.T) {
+			selectStmt, option := SplitViewCheckOption(tt.definition)
+			require.Equal(t, tt.selectStmt, selectStmt)
+			require.Equal(t, tt.option, option)
+			require.Equal(t, tt.option, NewView("v", nil, tt.definition).CheckOption())
+		})
+	}
+}
+
+func TestSplitViewAttributes(t *testing.T) {
+	tests := []struct {
+		definition string
+		attributes ViewAttributes
+		selectStmt string
+		create     string
+	}{
+		{
+			"SELECT * FROM t",
+			ViewAttributes{},
+			"SELECT * FROM t",
+			"CREATE ALGORITHM=UNDEFINED DEFINER=`root`@`localhost` SQL SECURITY DEFINER VIEW `v` AS SELECT * FROM t",
+		},
+		{
+			"ALGORITHM=MERGE DEFINER=`bob`@`%` SQL SECURITY INVOKER (`a`,`b c`) AS SELECT * FROM t",
+			ViewAttributes{Algorithm: "MERGE", DefinerUser: "bob", DefinerHost: "%", Security: "INVOKER", Columns: []string{"a", "b c"}},
+			"SELECT * FROM t",
+			"CREATE ALGORITHM=MERGE DEFINER=`bob`@`%` SQL SECURITY INVOKER VIEW `v` (`a`,`b c`) AS SELECT * FROM t",
+		},
+		{
+			"definer = 'b''ob' sql security definer as SELECT * FROM t",
+			ViewAttributes{DefinerUser: "b'ob", DefinerHost: "%", Security: "DEFINER"},
+			"SELECT * FROM t",
+			"CREATE ALGORITHM=UNDEFINED DEFINER=`b'ob`@`%` SQL SECURITY DEFINER VIEW `v` AS SELECT * FROM t",
+		},
+		{
+			"DEFINER=CURRENT_USER() ( x , `y``z` ) AS SELECT 1, 2 WITH CHECK OPTION",
+			ViewAttributes{Columns: []string{"x", "y`z"}},
+			"SELECT 1, 2 WITH CHECK OPTION",
+			"CREATE ALGORITHM=UNDEFINED DEFINER=`root`@`localhost` SQL SECURITY DEFINER VIEW `v` (`x`,`y``z`) AS SELECT 1, 2 WITH CHECK OPTION",
+		},
+		{
+			"(a,,b) AS SELECT 1, 2",
+			ViewAttributes{},
+			"(a,,b) AS SELECT 1, 2",
+			"CREATE ALGORITHM=UNDEFINED DEFINER=`root`@`localhost` SQL SECURITY DEFINER VIEW `v` AS (a,,b) AS SELECT 1, 2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.definition, func(t *testing.T) {
+			attributes, selectStmt := SplitViewAttributes(tt.definition)
+			require.Equal(t, tt.attributes, attributes)
+			require.Equal(t, tt.selectStmt, selectStmt)
+			require.Equal(t, tt.create, CreateViewStatement("v", tt.definition))
+
+			reparsed, selectStmt := SplitViewAttributes(attributes.String() + selectStmt)
+			require.Equal(t, tt.attributes, reparsed)
+			require.Equal(t, tt.selectStmt, selectStmt)
+		})
+	}
+}
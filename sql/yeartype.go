@@ -15,11 +15,14 @@
 package sql
 
 import (
+	"math"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/dolthub/vitess/go/sqltypes"
 	"github.com/dolthub/vitess/go/vt/proto/query"
+	"github.com/shopspring/decimal"
 	"gopkg.in/src-d/go-errors.v1"
 )
 
@@ -63,7 +66,9 @@ func (t yearType) Compare(a interface{}, b interface{}) (int, error) {
 	return 1, nil
 }
 
-// Convert implements Type interface.
+// Convert implements Type interface. One and two digit years are mapped to the years 1970 through 2069: 1 through 69
+// are years 2001 through 2069, and 70 through 99 are years 1970 through 1999. The number 0 is the zero year, while the
+// strings '0' and '00' are the year 2000. Fractional numbers are rounded to the nearest year.
 func (t yearType) Convert(v interface{}) (interface{}, error) {
 	if v == nil {
 		return nil, nil
@@ -102,17 +107,20 @@ func (t yearType) Convert(v interface{}) (interface{}, error) {
 	case uint64:
 		return t.Convert(int64(value))
 	case float32:
-		return t.Convert(int64(value))
+		return t.Convert(float64(value))
 	case float64:
-		return t.Convert(int64(value))
+		return t.Convert(int64(math.Round(value)))
+	case decimal.Decimal:
+		return t.Convert(value.Round(0).IntPart())
 	case string:
+		value = strings.TrimSpace(value)
 		valueLength := len(value)
 		if valueLength == 1 || valueLength == 2 || valueLength == 4 {
 			i, err := strconv.ParseInt(value, 10, 64)
 			if err != nil {
-				return nil, err
+				return nil, ErrConvertingToYear.New(v)
 			}
-			if i == 0 {
+			if i == 0 && valueLength < 4 {
 				return int16(2000), nil
 			}
 			return t.Convert(i)
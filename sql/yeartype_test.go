@@ -19,6 +19,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -77,12 +78,21 @@ func TestYearConvert(t *testing.T) {
 		{"2000", int16(2000), false},
 		{"2100", int16(2100), false},
 		{"2155", int16(2155), false},
+		{"00", int16(2000), false},
+		{"0000", int16(0), false},
+		{" 70 ", int16(1970), false},
+		{float32(1.4), int16(2001), false},
+		{float64(69.5), int16(1970), false},
+		{float64(2154.5), int16(2155), false},
+		{decimal.NewFromFloat(99.2), int16(1999), false},
 		{time.Date(2010, 1, 2, 3, 4, 5, 0, time.UTC), int16(2010), false},
 
 		{100, nil, true},
 		{"100", nil, true},
 		{1850, nil, true},
 		{"1850", nil, true},
+		{"abc", nil, true},
+		{2155.5, nil, true},
 		{[]byte{0}, nil, true},
 		{false, nil, true},
 	}